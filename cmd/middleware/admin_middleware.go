@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	userService "github.com/skamranahmed/go-bank/internal/user/service"
+	userTypes "github.com/skamranahmed/go-bank/internal/user/types"
+)
+
+// AdminMiddleware returns a Gin middleware that only lets users with the ADMIN role through.
+// It must be registered after the AuthMiddleware because it relies on the user ID attached to the request context
+func AdminMiddleware(userService userService.UserService) gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		requestCtx := ginCtx.Request.Context()
+
+		userID, ok := requestCtx.Value(ContextUserIDKey).(string)
+		if !ok || userID == "" {
+			server.SendErrorResponse(ginCtx, &server.ApiError{
+				HttpStatusCode: http.StatusUnauthorized,
+				Message:        "User not authenticated",
+			})
+			ginCtx.Abort()
+			return
+		}
+
+		/*
+			The role is looked up from the database on every admin request instead of being embedded in the access token
+			This way, revoking the admin role takes effect immediately rather than when the token expires
+		*/
+		user, err := userService.GetUser(requestCtx, nil, userTypes.UserQueryOptions{
+			ID:      &userID,
+			Columns: []string{"id", "role"},
+		})
+		if err != nil {
+			server.SendErrorResponse(ginCtx, err)
+			ginCtx.Abort()
+			return
+		}
+
+		if user.Role != userModel.Admin {
+			server.SendErrorResponse(ginCtx, &server.ApiError{
+				HttpStatusCode: http.StatusForbidden,
+				Message:        "You do not have permission to perform this action",
+			})
+			ginCtx.Abort()
+			return
+		}
+
+		ginCtx.Next()
+	}
+}
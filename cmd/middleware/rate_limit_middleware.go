@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/pkg/cache"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+)
+
+// RateLimitMiddleware returns a Gin middleware that lets each user make at most limit requests to the named action per window.
+// It must be registered after the AuthMiddleware because the requests are counted per user ID attached to the request context
+func RateLimitMiddleware(cacheClient cache.CacheClient, action string, limit int, window time.Duration) gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		requestCtx := ginCtx.Request.Context()
+
+		userID, ok := requestCtx.Value(ContextUserIDKey).(string)
+		if !ok || userID == "" {
+			server.SendErrorResponse(ginCtx, &server.ApiError{
+				HttpStatusCode: http.StatusUnauthorized,
+				Message:        "User not authenticated",
+			})
+			ginCtx.Abort()
+			return
+		}
+
+		/*
+			The requests are counted in fixed windows that start with the first request of the user
+
+			If the cache is unavailable, the request is let through rather than failing every request of the action,
+			the limit is a safeguard against abuse and not a correctness requirement
+		*/
+		rateLimitCacheKey := fmt.Sprintf("rate_limit:%s:user_id:%s", action, userID)
+		requestCount, err := cacheClient.Increment(requestCtx, rateLimitCacheKey, window)
+		if err != nil {
+			logger.Error(requestCtx, "Unable to count %s requests for userID: %s, error: %+v", action, userID, err)
+			ginCtx.Next()
+			return
+		}
+
+		if requestCount > int64(limit) {
+			server.SendErrorResponse(ginCtx, &server.ApiError{
+				HttpStatusCode: http.StatusTooManyRequests,
+				Message:        "Too many requests. Please try again later.",
+			})
+			ginCtx.Abort()
+			return
+		}
+
+		ginCtx.Next()
+	}
+}
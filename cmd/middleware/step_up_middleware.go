@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	authenticationService "github.com/skamranahmed/go-bank/internal/authentication/service"
+)
+
+const StepUpTokenHeader = "X-Step-Up-Token"
+
+// StepUpMiddleware returns a Gin middleware that only lets requests confirmed with a step-up token of the authenticated user through.
+// It must be registered after the AuthMiddleware because it relies on the user ID attached to the request context.
+// The token is spent by the request whatever its outcome, a failed request has to be confirmed again
+func StepUpMiddleware(authService authenticationService.AuthenticationService) gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		requestCtx := ginCtx.Request.Context()
+
+		userID, ok := requestCtx.Value(ContextUserIDKey).(string)
+		if !ok || userID == "" {
+			server.SendErrorResponse(ginCtx, &server.ApiError{
+				HttpStatusCode: http.StatusUnauthorized,
+				Message:        "User not authenticated",
+			})
+			ginCtx.Abort()
+			return
+		}
+
+		stepUpToken := ginCtx.GetHeader(StepUpTokenHeader)
+		if stepUpToken == "" {
+			server.SendErrorResponse(ginCtx, &server.ApiError{
+				HttpStatusCode: http.StatusForbidden,
+				Message:        "This action needs a valid step-up token, confirm your password to get one",
+			})
+			ginCtx.Abort()
+			return
+		}
+
+		err := authService.ConsumeStepUpToken(requestCtx, userID, stepUpToken)
+		if err != nil {
+			server.SendErrorResponse(ginCtx, err)
+			ginCtx.Abort()
+			return
+		}
+
+		ginCtx.Next()
+	}
+}
@@ -4,13 +4,22 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/skamranahmed/go-bank/cmd/middleware"
+	"github.com/skamranahmed/go-bank/cmd/server"
 	"github.com/skamranahmed/go-bank/config"
 	"github.com/skamranahmed/go-bank/internal"
 	accountController "github.com/skamranahmed/go-bank/internal/account/controller"
 	authenticationController "github.com/skamranahmed/go-bank/internal/authentication/controller"
+	balanceController "github.com/skamranahmed/go-bank/internal/balance/controller"
+	beneficiaryController "github.com/skamranahmed/go-bank/internal/beneficiary/controller"
+	depositController "github.com/skamranahmed/go-bank/internal/deposit/controller"
+	feeController "github.com/skamranahmed/go-bank/internal/fee/controller"
+	fxController "github.com/skamranahmed/go-bank/internal/fx/controller"
 	healthzController "github.com/skamranahmed/go-bank/internal/healthz/controller"
+	loanController "github.com/skamranahmed/go-bank/internal/loan/controller"
+	paymentRequestController "github.com/skamranahmed/go-bank/internal/paymentrequest/controller"
 	transferController "github.com/skamranahmed/go-bank/internal/transfer/controller"
 	userController "github.com/skamranahmed/go-bank/internal/user/controller"
+	vpaController "github.com/skamranahmed/go-bank/internal/vpa/controller"
 	"github.com/skamranahmed/go-bank/pkg/metrics"
 	"github.com/uptrace/bun"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
@@ -28,6 +37,9 @@ func Init(db *bun.DB, services *internal.Services) *gin.Engine {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	// register the custom validation tags used by the request bindings
+	server.RegisterCustomValidations()
+
 	router := gin.New()
 	router.Use(gin.Recovery())
 
@@ -61,6 +73,7 @@ func Init(db *bun.DB, services *internal.Services) *gin.Engine {
 		UserService:           services.UserService,
 		AccountService:        services.AccountService,
 		TaskEnqueuer:          services.TaskEnqueuer,
+		CacheClient:           services.Cache,
 	})
 
 	userController.Register(router, userController.Dependency{
@@ -69,8 +82,14 @@ func Init(db *bun.DB, services *internal.Services) *gin.Engine {
 	})
 
 	accountController.Register(router, accountController.Dependency{
+		Db:                    db,
 		AuthenticationService: services.AuthenticationService,
 		AccountService:        services.AccountService,
+		FeeService:            services.FeeService,
+		UserService:           services.UserService,
+		TransferService:       services.TransferService,
+		TaskEnqueuer:          services.TaskEnqueuer,
+		CacheClient:           services.Cache,
 	})
 
 	transferController.Register(router, transferController.Dependency{
@@ -78,6 +97,68 @@ func Init(db *bun.DB, services *internal.Services) *gin.Engine {
 		AuthenticationService: services.AuthenticationService,
 		AccountService:        services.AccountService,
 		TransferService:       services.TransferService,
+		BeneficiaryService:    services.BeneficiaryService,
+		UserService:           services.UserService,
+		VPAService:            services.VPAService,
+		TaskEnqueuer:          services.TaskEnqueuer,
+	})
+
+	balanceController.Register(router, balanceController.Dependency{
+		AuthenticationService: services.AuthenticationService,
+		AccountService:        services.AccountService,
+		BalanceService:        services.BalanceService,
+	})
+
+	depositController.Register(router, depositController.Dependency{
+		Db:                    db,
+		AuthenticationService: services.AuthenticationService,
+		AccountService:        services.AccountService,
+		DepositService:        services.DepositService,
+	})
+
+	feeController.Register(router, feeController.Dependency{
+		Db:                    db,
+		AuthenticationService: services.AuthenticationService,
+		FeeService:            services.FeeService,
+		UserService:           services.UserService,
+	})
+
+	loanController.Register(router, loanController.Dependency{
+		Db:                    db,
+		AuthenticationService: services.AuthenticationService,
+		AccountService:        services.AccountService,
+		LoanService:           services.LoanService,
+		UserService:           services.UserService,
+	})
+
+	beneficiaryController.Register(router, beneficiaryController.Dependency{
+		AuthenticationService: services.AuthenticationService,
+		AccountService:        services.AccountService,
+		BeneficiaryService:    services.BeneficiaryService,
+		TaskEnqueuer:          services.TaskEnqueuer,
+		CacheClient:           services.Cache,
+	})
+
+	vpaController.Register(router, vpaController.Dependency{
+		Db:                    db,
+		AuthenticationService: services.AuthenticationService,
+		VPAService:            services.VPAService,
+		TaskEnqueuer:          services.TaskEnqueuer,
+	})
+
+	paymentRequestController.Register(router, paymentRequestController.Dependency{
+		Db:                    db,
+		AuthenticationService: services.AuthenticationService,
+		PaymentRequestService: services.PaymentRequestService,
+		UserService:           services.UserService,
+		TaskEnqueuer:          services.TaskEnqueuer,
+	})
+
+	fxController.Register(router, fxController.Dependency{
+		Db:                    db,
+		AuthenticationService: services.AuthenticationService,
+		FXService:             services.FXService,
+		UserService:           services.UserService,
 	})
 
 	return router
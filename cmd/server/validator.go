@@ -4,38 +4,94 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"unicode"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"github.com/skamranahmed/go-bank/pkg/accountnumber"
+	"github.com/skamranahmed/go-bank/pkg/money"
 )
 
+// RegisterCustomValidations registers the validation tags that are not built into the validator with gin's binding engine
+func RegisterCustomValidations() {
+	validatorEngine, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	// account_number rejects mistyped account numbers by their check digit, before they are looked up in the database
+	validatorEngine.RegisterValidation("account_number", func(fieldLevel validator.FieldLevel) bool {
+		return accountnumber.IsValid(fieldLevel.Field().Int())
+	})
+
+	// reference only allows the characters that survive being passed on to other systems as an identifier
+	validatorEngine.RegisterValidation("reference", func(fieldLevel validator.FieldLevel) bool {
+		for _, r := range fieldLevel.Field().String() {
+			isAllowed := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || strings.ContainsRune("-_/.", r)
+			if !isAllowed {
+				return false
+			}
+		}
+		return true
+	})
+
+	// ifsc rejects codes that do not have the layout of an IFSC, before any transfer is sent to them
+	validatorEngine.RegisterValidation("ifsc", func(fieldLevel validator.FieldLevel) bool {
+		return accountnumber.IsValidIFSC(fieldLevel.Field().String())
+	})
+
+	// vpa rejects virtual payment addresses that do not have the layout of a handle@provider address, before they are looked up in the database
+	validatorEngine.RegisterValidation("vpa", func(fieldLevel validator.FieldLevel) bool {
+		return accountnumber.IsValidVPA(fieldLevel.Field().String())
+	})
+
+	// currency only allows the ISO 4217 codes of the currencies the bank holds accounts in
+	validatorEngine.RegisterValidation("currency", func(fieldLevel validator.FieldLevel) bool {
+		return money.Currency(fieldLevel.Field().String()).IsSupported()
+	})
+}
+
 func BindAndValidateIncomingRequestBody(ginCtx *gin.Context, requestBody any) bool {
 	err := ginCtx.ShouldBindJSON(requestBody)
 	if err != nil {
-		// handle the errors captured by go validator
-		var validationErrors validator.ValidationErrors
+		sendBindingErrorResponse(ginCtx, err)
+		return false
+	}
+	return true
+}
+
+func BindAndValidateIncomingRequestQuery(ginCtx *gin.Context, requestQuery any) bool {
+	err := ginCtx.ShouldBindQuery(requestQuery)
+	if err != nil {
+		sendBindingErrorResponse(ginCtx, err)
+		return false
+	}
+	return true
+}
 
-		if errors.As(err, &validationErrors) {
-			errorMap := make(map[string]string)
+func sendBindingErrorResponse(ginCtx *gin.Context, err error) {
+	// handle the errors captured by go validator
+	var validationErrors validator.ValidationErrors
 
-			for _, fieldError := range validationErrors {
-				// convert struct field name to snake_case to match the JSON field name
-				jsonFieldName := toSnakeCase(fieldError.StructField())
-				// generate a user-friendly error message for the JSON field name
-				errorMap[jsonFieldName] = messageForTag(jsonFieldName, fieldError)
-			}
+	if errors.As(err, &validationErrors) {
+		errorMap := make(map[string]string)
 
-			sendErrorResponse(ginCtx, http.StatusBadRequest, errorMap)
-			return false
+		for _, fieldError := range validationErrors {
+			// convert struct field name to snake_case to match the JSON field name
+			jsonFieldName := toSnakeCase(fieldError.StructField())
+			// generate a user-friendly error message for the JSON field name
+			errorMap[jsonFieldName] = messageForTag(jsonFieldName, fieldError)
 		}
 
-		// any other errors that are not captured by go validator
-		// eg: malformed JSON body
-		sendErrorResponse(ginCtx, http.StatusBadRequest, err.Error())
-		return false
+		sendErrorResponse(ginCtx, http.StatusBadRequest, errorMap)
+		return
 	}
-	return true
+
+	// any other errors that are not captured by go validator
+	// eg: malformed JSON body
+	sendErrorResponse(ginCtx, http.StatusBadRequest, err.Error())
 }
 
 func messageForTag(jsonFieldName string, fieldError validator.FieldError) string {
@@ -46,14 +102,60 @@ func messageForTag(jsonFieldName string, fieldError validator.FieldError) string
 	case "required":
 		return fmt.Sprintf("%s is a required field", jsonFieldName)
 
+	case "required_without":
+		return fmt.Sprintf("%s is required when %s is not provided", jsonFieldName, toSnakeCase(fieldParam))
+
+	case "required_without_all":
+		otherFieldNames := strings.Fields(fieldParam)
+		for i, otherFieldName := range otherFieldNames {
+			otherFieldNames[i] = toSnakeCase(otherFieldName)
+		}
+		return fmt.Sprintf("%s is required when none of %s is provided", jsonFieldName, strings.Join(otherFieldNames, ", "))
+
 	case "min":
 		return fmt.Sprintf("%s must be at least %s characters", jsonFieldName, fieldParam)
 
+	case "max":
+		return fmt.Sprintf("%s must be at most %s characters", jsonFieldName, fieldParam)
+
 	case "gt":
 		return fmt.Sprintf("%s must be greater than %s", jsonFieldName, fieldParam)
 
+	case "gte":
+		return fmt.Sprintf("%s must be greater than or equal to %s", jsonFieldName, fieldParam)
+
+	case "lte":
+		return fmt.Sprintf("%s must be less than or equal to %s", jsonFieldName, fieldParam)
+
+	case "datetime":
+		return fmt.Sprintf("%s is not a valid date", jsonFieldName)
+
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", jsonFieldName, strings.ReplaceAll(fieldParam, " ", ", "))
+
 	case "email":
 		return fmt.Sprintf("%v is not a valid email", fieldValue)
+
+	case "account_number":
+		return fmt.Sprintf("%s is not a valid account number", jsonFieldName)
+
+	case "numeric":
+		return fmt.Sprintf("%s can only contain digits", jsonFieldName)
+
+	case "ifsc":
+		return fmt.Sprintf("%s is not a valid IFSC", jsonFieldName)
+
+	case "vpa":
+		return fmt.Sprintf("%s is not a valid VPA", jsonFieldName)
+
+	case "currency":
+		return fmt.Sprintf("%s is not a supported currency", jsonFieldName)
+
+	case "uuid":
+		return fmt.Sprintf("%s is not a valid ID", jsonFieldName)
+
+	case "reference":
+		return fmt.Sprintf("%s can only contain letters, digits and the characters - _ / .", jsonFieldName)
 	}
 
 	// fallback to default
@@ -5,7 +5,15 @@ import (
 
 	"github.com/skamranahmed/go-bank/config"
 	"github.com/skamranahmed/go-bank/internal"
+	accountTasks "github.com/skamranahmed/go-bank/internal/account/tasks"
+	balanceTasks "github.com/skamranahmed/go-bank/internal/balance/tasks"
+	beneficiaryTasks "github.com/skamranahmed/go-bank/internal/beneficiary/tasks"
+	depositTasks "github.com/skamranahmed/go-bank/internal/deposit/tasks"
+	loanTasks "github.com/skamranahmed/go-bank/internal/loan/tasks"
+	paymentRequestTasks "github.com/skamranahmed/go-bank/internal/paymentrequest/tasks"
+	transferTasks "github.com/skamranahmed/go-bank/internal/transfer/tasks"
 	userTasks "github.com/skamranahmed/go-bank/internal/user/tasks"
+	vpaTasks "github.com/skamranahmed/go-bank/internal/vpa/tasks"
 	"github.com/skamranahmed/go-bank/pkg/logger"
 	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
 )
@@ -40,9 +48,54 @@ func Start(queueName string, services *internal.Services) {
 func RegisterSchedulableTasks(taskScheduler tasksHelper.TaskScheduler) {
 	// user tasks
 	userTasks.RegisterSchedulableTasks(taskScheduler)
+
+	// account tasks
+	accountTasks.RegisterSchedulableTasks(taskScheduler)
+
+	// balance tasks
+	balanceTasks.RegisterSchedulableTasks(taskScheduler)
+
+	// deposit tasks
+	depositTasks.RegisterSchedulableTasks(taskScheduler)
+
+	// loan tasks
+	loanTasks.RegisterSchedulableTasks(taskScheduler)
+
+	// transfer tasks
+	transferTasks.RegisterSchedulableTasks(taskScheduler)
+
+	// vpa tasks
+	vpaTasks.RegisterSchedulableTasks(taskScheduler)
+
+	// payment request tasks
+	paymentRequestTasks.RegisterSchedulableTasks(taskScheduler)
 }
 
 func RegisterTaskProcessors(taskWorker tasksHelper.TaskWorker, services *internal.Services) {
 	// user tasks
 	userTasks.RegisterTaskProcessors(taskWorker.Router(), services)
+
+	// account tasks
+	accountTasks.RegisterTaskProcessors(taskWorker.Router(), services)
+
+	// balance tasks
+	balanceTasks.RegisterTaskProcessors(taskWorker.Router(), services)
+
+	// deposit tasks
+	depositTasks.RegisterTaskProcessors(taskWorker.Router(), services)
+
+	// loan tasks
+	loanTasks.RegisterTaskProcessors(taskWorker.Router(), services)
+
+	// beneficiary tasks
+	beneficiaryTasks.RegisterTaskProcessors(taskWorker.Router(), services)
+
+	// transfer tasks
+	transferTasks.RegisterTaskProcessors(taskWorker.Router(), services)
+
+	// vpa tasks
+	vpaTasks.RegisterTaskProcessors(taskWorker.Router(), services)
+
+	// payment request tasks
+	paymentRequestTasks.RegisterTaskProcessors(taskWorker.Router(), services)
 }
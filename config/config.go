@@ -235,5 +235,377 @@ func GetAuthConfig() AuthConfig {
 		authConfig.AccessTokenSecretSigningKey = accessTokenSecretSigningKey
 	}
 
+	stepUpTokenExpiryDurationInSeconds := getStepUpTokenExpiryDurationInSeconds()
+	if stepUpTokenExpiryDurationInSeconds != -1 {
+		authConfig.StepUpTokenExpiryDurationInSeconds = stepUpTokenExpiryDurationInSeconds
+	}
+
+	stepUpMaxRequestsPerWindow := getStepUpMaxRequestsPerWindow()
+	if stepUpMaxRequestsPerWindow != -1 {
+		authConfig.StepUpMaxRequestsPerWindow = stepUpMaxRequestsPerWindow
+	}
+
+	stepUpWindowInSeconds := getStepUpWindowInSeconds()
+	if stepUpWindowInSeconds != -1 {
+		authConfig.StepUpWindowInSeconds = stepUpWindowInSeconds
+	}
+
 	return authConfig
 }
+
+func GetOverdraftConfig() OverdraftConfig {
+	overdraftConfig := loadConfig().Overdraft
+
+	annualInterestRateInBasisPoints := getOverdraftAnnualInterestRateInBasisPoints()
+	if annualInterestRateInBasisPoints != -1 {
+		overdraftConfig.AnnualInterestRateInBasisPoints = annualInterestRateInBasisPoints
+	}
+
+	return overdraftConfig
+}
+
+func GetMinimumAverageBalanceConfig() MinimumAverageBalanceConfig {
+	minimumAverageBalanceConfig := loadConfig().MinimumAverageBalance
+
+	requiredAverageBalance := getMinimumAverageBalanceSavingsAccountRequiredAverageBalance()
+	if requiredAverageBalance != -1 {
+		minimumAverageBalanceConfig.SavingsAccountRequiredAverageBalance = requiredAverageBalance
+	}
+
+	chargePenalty, ok := getMinimumAverageBalanceChargePenalty()
+	if ok {
+		minimumAverageBalanceConfig.ChargePenalty = chargePenalty
+	}
+
+	return minimumAverageBalanceConfig
+}
+
+func GetFixedDepositConfig() FixedDepositConfig {
+	fixedDepositConfig := loadConfig().FixedDeposit
+
+	annualInterestRateInBasisPoints := getFixedDepositAnnualInterestRateInBasisPoints()
+	if annualInterestRateInBasisPoints != -1 {
+		fixedDepositConfig.AnnualInterestRateInBasisPoints = annualInterestRateInBasisPoints
+	}
+
+	prematureClosurePenaltyInBasisPoints := getFixedDepositPrematureClosurePenaltyInBasisPoints()
+	if prematureClosurePenaltyInBasisPoints != -1 {
+		fixedDepositConfig.PrematureClosurePenaltyInBasisPoints = prematureClosurePenaltyInBasisPoints
+	}
+
+	minimumAmount := getFixedDepositMinimumAmount()
+	if minimumAmount != -1 {
+		fixedDepositConfig.MinimumAmount = minimumAmount
+	}
+
+	return fixedDepositConfig
+}
+
+func GetRecurringDepositConfig() RecurringDepositConfig {
+	recurringDepositConfig := loadConfig().RecurringDeposit
+
+	annualInterestRateInBasisPoints := getRecurringDepositAnnualInterestRateInBasisPoints()
+	if annualInterestRateInBasisPoints != -1 {
+		recurringDepositConfig.AnnualInterestRateInBasisPoints = annualInterestRateInBasisPoints
+	}
+
+	minimumInstallmentAmount := getRecurringDepositMinimumInstallmentAmount()
+	if minimumInstallmentAmount != -1 {
+		recurringDepositConfig.MinimumInstallmentAmount = minimumInstallmentAmount
+	}
+
+	gracePeriodInDays := getRecurringDepositGracePeriodInDays()
+	if gracePeriodInDays != -1 {
+		recurringDepositConfig.GracePeriodInDays = gracePeriodInDays
+	}
+
+	return recurringDepositConfig
+}
+
+func GetLoanConfig() LoanConfig {
+	loanConfig := loadConfig().Loan
+
+	annualInterestRateInBasisPoints := getLoanAnnualInterestRateInBasisPoints()
+	if annualInterestRateInBasisPoints != -1 {
+		loanConfig.AnnualInterestRateInBasisPoints = annualInterestRateInBasisPoints
+	}
+
+	minimumAmount := getLoanMinimumAmount()
+	if minimumAmount != -1 {
+		loanConfig.MinimumAmount = minimumAmount
+	}
+
+	maximumAmount := getLoanMaximumAmount()
+	if maximumAmount != -1 {
+		loanConfig.MaximumAmount = maximumAmount
+	}
+
+	gracePeriodInDays := getLoanGracePeriodInDays()
+	if gracePeriodInDays != -1 {
+		loanConfig.GracePeriodInDays = gracePeriodInDays
+	}
+
+	return loanConfig
+}
+
+func GetBankConfig() BankConfig {
+	bankConfig := loadConfig().Bank
+
+	countryCode := getBankCountryCode()
+	if countryCode != "" {
+		bankConfig.CountryCode = countryCode
+	}
+
+	code := getBankCode()
+	if code != "" {
+		bankConfig.Code = code
+	}
+
+	branchCode := getBankBranchCode()
+	if branchCode != "" {
+		bankConfig.BranchCode = branchCode
+	}
+
+	currency := getBankCurrency()
+	if currency != "" {
+		bankConfig.Currency = currency
+	}
+
+	return bankConfig
+}
+
+func GetBeneficiaryConfig() BeneficiaryConfig {
+	beneficiaryConfig := loadConfig().Beneficiary
+
+	coolingPeriodInHours := getBeneficiaryCoolingPeriodInHours()
+	if coolingPeriodInHours != -1 {
+		beneficiaryConfig.CoolingPeriodInHours = coolingPeriodInHours
+	}
+
+	coolingPeriodTransferLimit := getBeneficiaryCoolingPeriodTransferLimit()
+	if coolingPeriodTransferLimit != -1 {
+		beneficiaryConfig.CoolingPeriodTransferLimit = coolingPeriodTransferLimit
+	}
+
+	return beneficiaryConfig
+}
+
+func GetNameEnquiryConfig() NameEnquiryConfig {
+	nameEnquiryConfig := loadConfig().NameEnquiry
+
+	maxRequestsPerWindow := getNameEnquiryMaxRequestsPerWindow()
+	if maxRequestsPerWindow != -1 {
+		nameEnquiryConfig.MaxRequestsPerWindow = maxRequestsPerWindow
+	}
+
+	windowInSeconds := getNameEnquiryWindowInSeconds()
+	if windowInSeconds != -1 {
+		nameEnquiryConfig.WindowInSeconds = windowInSeconds
+	}
+
+	return nameEnquiryConfig
+}
+
+func GetTransferLimitConfig() TransferLimitConfig {
+	transferLimitConfig := loadConfig().TransferLimit
+
+	savingsAccountPerTransactionAmount := getTransferLimitSavingsAccountPerTransactionAmount()
+	if savingsAccountPerTransactionAmount != -1 {
+		transferLimitConfig.SavingsAccount.PerTransactionAmount = savingsAccountPerTransactionAmount
+	}
+
+	savingsAccountDailyCount := getTransferLimitSavingsAccountDailyCount()
+	if savingsAccountDailyCount != -1 {
+		transferLimitConfig.SavingsAccount.DailyCount = savingsAccountDailyCount
+	}
+
+	savingsAccountDailyAmount := getTransferLimitSavingsAccountDailyAmount()
+	if savingsAccountDailyAmount != -1 {
+		transferLimitConfig.SavingsAccount.DailyAmount = savingsAccountDailyAmount
+	}
+
+	savingsAccountMonthlyAmount := getTransferLimitSavingsAccountMonthlyAmount()
+	if savingsAccountMonthlyAmount != -1 {
+		transferLimitConfig.SavingsAccount.MonthlyAmount = savingsAccountMonthlyAmount
+	}
+
+	currentAccountPerTransactionAmount := getTransferLimitCurrentAccountPerTransactionAmount()
+	if currentAccountPerTransactionAmount != -1 {
+		transferLimitConfig.CurrentAccount.PerTransactionAmount = currentAccountPerTransactionAmount
+	}
+
+	currentAccountDailyCount := getTransferLimitCurrentAccountDailyCount()
+	if currentAccountDailyCount != -1 {
+		transferLimitConfig.CurrentAccount.DailyCount = currentAccountDailyCount
+	}
+
+	currentAccountDailyAmount := getTransferLimitCurrentAccountDailyAmount()
+	if currentAccountDailyAmount != -1 {
+		transferLimitConfig.CurrentAccount.DailyAmount = currentAccountDailyAmount
+	}
+
+	currentAccountMonthlyAmount := getTransferLimitCurrentAccountMonthlyAmount()
+	if currentAccountMonthlyAmount != -1 {
+		transferLimitConfig.CurrentAccount.MonthlyAmount = currentAccountMonthlyAmount
+	}
+
+	return transferLimitConfig
+}
+
+func GetStandingInstructionConfig() StandingInstructionConfig {
+	standingInstructionConfig := loadConfig().StandingInstruction
+
+	failurePolicy := getStandingInstructionFailurePolicy()
+	if failurePolicy != "" {
+		standingInstructionConfig.FailurePolicy = StandingInstructionFailurePolicy(failurePolicy)
+	}
+
+	retryPeriodInDays := getStandingInstructionRetryPeriodInDays()
+	if retryPeriodInDays != -1 {
+		standingInstructionConfig.RetryPeriodInDays = retryPeriodInDays
+	}
+
+	maxConsecutiveFailures := getStandingInstructionMaxConsecutiveFailures()
+	if maxConsecutiveFailures != -1 {
+		standingInstructionConfig.MaxConsecutiveFailures = maxConsecutiveFailures
+	}
+
+	return standingInstructionConfig
+}
+
+func GetPaymentRailConfig() PaymentRailConfig {
+	paymentRailConfig := loadConfig().PaymentRail
+
+	latencyInSeconds := getPaymentRailLatencyInSeconds()
+	if latencyInSeconds != -1 {
+		paymentRailConfig.LatencyInSeconds = latencyInSeconds
+	}
+
+	returnProbabilityInBasisPoints := getPaymentRailReturnProbabilityInBasisPoints()
+	if returnProbabilityInBasisPoints != -1 {
+		paymentRailConfig.ReturnProbabilityInBasisPoints = returnProbabilityInBasisPoints
+	}
+
+	neftBatchIntervalInMinutes := getPaymentRailNeftBatchIntervalInMinutes()
+	if neftBatchIntervalInMinutes != -1 {
+		paymentRailConfig.NeftBatchIntervalInMinutes = neftBatchIntervalInMinutes
+	}
+
+	neftFirstBatchHour := getPaymentRailNeftFirstBatchHour()
+	if neftFirstBatchHour != -1 {
+		paymentRailConfig.NeftFirstBatchHour = neftFirstBatchHour
+	}
+
+	neftCutOffHour := getPaymentRailNeftCutOffHour()
+	if neftCutOffHour != -1 {
+		paymentRailConfig.NeftCutOffHour = neftCutOffHour
+	}
+
+	rtgsMinimumAmount := getPaymentRailRtgsMinimumAmount()
+	if rtgsMinimumAmount != -1 {
+		paymentRailConfig.RtgsMinimumAmount = rtgsMinimumAmount
+	}
+
+	impsMaximumAmount := getPaymentRailImpsMaximumAmount()
+	if impsMaximumAmount != -1 {
+		paymentRailConfig.ImpsMaximumAmount = impsMaximumAmount
+	}
+
+	return paymentRailConfig
+}
+
+func GetInboundPaymentConfig() InboundPaymentConfig {
+	inboundPaymentConfig := loadConfig().InboundPayment
+
+	directory := getInboundPaymentDirectory()
+	if directory != "" {
+		inboundPaymentConfig.Directory = directory
+	}
+
+	return inboundPaymentConfig
+}
+
+func GetVPAConfig() VPAConfig {
+	vpaConfig := loadConfig().VPA
+
+	handle := getVPAHandle()
+	if handle != "" {
+		vpaConfig.Handle = handle
+	}
+
+	maxPerUser := getVPAMaxPerUser()
+	if maxPerUser != -1 {
+		vpaConfig.MaxPerUser = maxPerUser
+	}
+
+	collectRequestExpiryInMinutes := getVPACollectRequestExpiryInMinutes()
+	if collectRequestExpiryInMinutes != -1 {
+		vpaConfig.CollectRequestExpiryInMinutes = collectRequestExpiryInMinutes
+	}
+
+	return vpaConfig
+}
+
+func GetPaymentRequestConfig() PaymentRequestConfig {
+	paymentRequestConfig := loadConfig().PaymentRequest
+
+	linkBaseURL := getPaymentRequestLinkBaseURL()
+	if linkBaseURL != "" {
+		paymentRequestConfig.LinkBaseURL = linkBaseURL
+	}
+
+	defaultExpiryInHours := getPaymentRequestDefaultExpiryInHours()
+	if defaultExpiryInHours != -1 {
+		paymentRequestConfig.DefaultExpiryInHours = defaultExpiryInHours
+	}
+
+	maxExpiryInHours := getPaymentRequestMaxExpiryInHours()
+	if maxExpiryInHours != -1 {
+		paymentRequestConfig.MaxExpiryInHours = maxExpiryInHours
+	}
+
+	return paymentRequestConfig
+}
+
+func GetBulkTransferConfig() BulkTransferConfig {
+	bulkTransferConfig := loadConfig().BulkTransfer
+
+	maxRows := getBulkTransferMaxRows()
+	if maxRows != -1 {
+		bulkTransferConfig.MaxRows = maxRows
+	}
+
+	return bulkTransferConfig
+}
+
+func GetFXConfig() FXConfig {
+	fxConfig := loadConfig().FX
+
+	quoteExpiryInSeconds := getFXQuoteExpiryInSeconds()
+	if quoteExpiryInSeconds != -1 {
+		fxConfig.QuoteExpiryInSeconds = quoteExpiryInSeconds
+	}
+
+	marginInBasisPoints := getFXMarginInBasisPoints()
+	if marginInBasisPoints != -1 {
+		fxConfig.MarginInBasisPoints = marginInBasisPoints
+	}
+
+	rateMaxAgeInHours := getFXRateMaxAgeInHours()
+	if rateMaxAgeInHours != -1 {
+		fxConfig.RateMaxAgeInHours = rateMaxAgeInHours
+	}
+
+	return fxConfig
+}
+
+func GetPocketConfig() PocketConfig {
+	pocketConfig := loadConfig().Pocket
+
+	maxPerAccount := getPocketMaxPerAccount()
+	if maxPerAccount != -1 {
+		pocketConfig.MaxPerAccount = maxPerAccount
+	}
+
+	return pocketConfig
+}
@@ -42,6 +42,93 @@ const (
 	// auth
 	authAccessTokenExpiryDurationInSeconds = "AUTH_ACCESS_TOKEN_EXPIRY_DURATION_IN_SECONDS"
 	authAccessTokenSecretSigningKey        = "AUTH_ACCESS_TOKEN_SECRET_SIGNING_KEY"
+	authStepUpTokenExpiryDurationInSeconds = "AUTH_STEP_UP_TOKEN_EXPIRY_DURATION_IN_SECONDS"
+	authStepUpMaxRequestsPerWindow         = "AUTH_STEP_UP_MAX_REQUESTS_PER_WINDOW"
+	authStepUpWindowInSeconds              = "AUTH_STEP_UP_WINDOW_IN_SECONDS"
+
+	// overdraft
+	overdraftAnnualInterestRateInBasisPoints = "OVERDRAFT_ANNUAL_INTEREST_RATE_IN_BASIS_POINTS"
+
+	// minimum average balance
+	minimumAverageBalanceSavingsAccountRequiredAverageBalance = "MINIMUM_AVERAGE_BALANCE_SAVINGS_ACCOUNT_REQUIRED_AVERAGE_BALANCE"
+	minimumAverageBalanceChargePenalty                        = "MINIMUM_AVERAGE_BALANCE_CHARGE_PENALTY"
+
+	// fixed deposit
+	fixedDepositAnnualInterestRateInBasisPoints      = "FIXED_DEPOSIT_ANNUAL_INTEREST_RATE_IN_BASIS_POINTS"
+	fixedDepositPrematureClosurePenaltyInBasisPoints = "FIXED_DEPOSIT_PREMATURE_CLOSURE_PENALTY_IN_BASIS_POINTS"
+	fixedDepositMinimumAmount                        = "FIXED_DEPOSIT_MINIMUM_AMOUNT"
+
+	// recurring deposit
+	recurringDepositAnnualInterestRateInBasisPoints = "RECURRING_DEPOSIT_ANNUAL_INTEREST_RATE_IN_BASIS_POINTS"
+	recurringDepositMinimumInstallmentAmount        = "RECURRING_DEPOSIT_MINIMUM_INSTALLMENT_AMOUNT"
+	recurringDepositGracePeriodInDays               = "RECURRING_DEPOSIT_GRACE_PERIOD_IN_DAYS"
+
+	// loan
+	loanAnnualInterestRateInBasisPoints = "LOAN_ANNUAL_INTEREST_RATE_IN_BASIS_POINTS"
+	loanMinimumAmount                   = "LOAN_MINIMUM_AMOUNT"
+	loanMaximumAmount                   = "LOAN_MAXIMUM_AMOUNT"
+	loanGracePeriodInDays               = "LOAN_GRACE_PERIOD_IN_DAYS"
+
+	// bank
+	bankCountryCode = "BANK_COUNTRY_CODE"
+	bankCode        = "BANK_CODE"
+	bankBranchCode  = "BANK_BRANCH_CODE"
+	bankCurrency    = "BANK_CURRENCY"
+
+	// beneficiary
+	beneficiaryCoolingPeriodInHours       = "BENEFICIARY_COOLING_PERIOD_IN_HOURS"
+	beneficiaryCoolingPeriodTransferLimit = "BENEFICIARY_COOLING_PERIOD_TRANSFER_LIMIT"
+
+	// name enquiry
+	nameEnquiryMaxRequestsPerWindow = "NAME_ENQUIRY_MAX_REQUESTS_PER_WINDOW"
+	nameEnquiryWindowInSeconds      = "NAME_ENQUIRY_WINDOW_IN_SECONDS"
+
+	// transfer limit
+	transferLimitSavingsAccountPerTransactionAmount = "TRANSFER_LIMIT_SAVINGS_ACCOUNT_PER_TRANSACTION_AMOUNT"
+	transferLimitSavingsAccountDailyCount           = "TRANSFER_LIMIT_SAVINGS_ACCOUNT_DAILY_COUNT"
+	transferLimitSavingsAccountDailyAmount          = "TRANSFER_LIMIT_SAVINGS_ACCOUNT_DAILY_AMOUNT"
+	transferLimitSavingsAccountMonthlyAmount        = "TRANSFER_LIMIT_SAVINGS_ACCOUNT_MONTHLY_AMOUNT"
+	transferLimitCurrentAccountPerTransactionAmount = "TRANSFER_LIMIT_CURRENT_ACCOUNT_PER_TRANSACTION_AMOUNT"
+	transferLimitCurrentAccountDailyCount           = "TRANSFER_LIMIT_CURRENT_ACCOUNT_DAILY_COUNT"
+	transferLimitCurrentAccountDailyAmount          = "TRANSFER_LIMIT_CURRENT_ACCOUNT_DAILY_AMOUNT"
+	transferLimitCurrentAccountMonthlyAmount        = "TRANSFER_LIMIT_CURRENT_ACCOUNT_MONTHLY_AMOUNT"
+
+	// standing instruction
+	standingInstructionFailurePolicy          = "STANDING_INSTRUCTION_FAILURE_POLICY"
+	standingInstructionRetryPeriodInDays      = "STANDING_INSTRUCTION_RETRY_PERIOD_IN_DAYS"
+	standingInstructionMaxConsecutiveFailures = "STANDING_INSTRUCTION_MAX_CONSECUTIVE_FAILURES"
+
+	// payment rail
+	paymentRailLatencyInSeconds               = "PAYMENT_RAIL_LATENCY_IN_SECONDS"
+	paymentRailReturnProbabilityInBasisPoints = "PAYMENT_RAIL_RETURN_PROBABILITY_IN_BASIS_POINTS"
+	paymentRailNeftBatchIntervalInMinutes     = "PAYMENT_RAIL_NEFT_BATCH_INTERVAL_IN_MINUTES"
+	paymentRailNeftFirstBatchHour             = "PAYMENT_RAIL_NEFT_FIRST_BATCH_HOUR"
+	paymentRailNeftCutOffHour                 = "PAYMENT_RAIL_NEFT_CUT_OFF_HOUR"
+	paymentRailRtgsMinimumAmount              = "PAYMENT_RAIL_RTGS_MINIMUM_AMOUNT"
+	paymentRailImpsMaximumAmount              = "PAYMENT_RAIL_IMPS_MAXIMUM_AMOUNT"
+
+	// inbound payment
+	inboundPaymentDirectory = "INBOUND_PAYMENT_DIRECTORY"
+
+	// vpa
+	vpaHandle                        = "VPA_HANDLE"
+	vpaMaxPerUser                    = "VPA_MAX_PER_USER"
+	vpaCollectRequestExpiryInMinutes = "VPA_COLLECT_REQUEST_EXPIRY_IN_MINUTES"
+
+	paymentRequestLinkBaseURL          = "PAYMENT_REQUEST_LINK_BASE_URL"
+	paymentRequestDefaultExpiryInHours = "PAYMENT_REQUEST_DEFAULT_EXPIRY_IN_HOURS"
+	paymentRequestMaxExpiryInHours     = "PAYMENT_REQUEST_MAX_EXPIRY_IN_HOURS"
+
+	// bulk transfer
+	bulkTransferMaxRows = "BULK_TRANSFER_MAX_ROWS"
+
+	// fx
+	fxQuoteExpiryInSeconds = "FX_QUOTE_EXPIRY_IN_SECONDS"
+	fxMarginInBasisPoints  = "FX_MARGIN_IN_BASIS_POINTS"
+	fxRateMaxAgeInHours    = "FX_RATE_MAX_AGE_IN_HOURS"
+
+	// pocket
+	pocketMaxPerAccount = "POCKET_MAX_PER_ACCOUNT"
 )
 
 func getLoggerLevel() string {
@@ -201,3 +288,449 @@ func getAccessTokenExpiryDurationInSeconds() int {
 func getAccessTokenSecretSigningKey() string {
 	return os.Getenv(authAccessTokenSecretSigningKey)
 }
+
+func getStepUpTokenExpiryDurationInSeconds() int {
+	duration, err := strconv.Atoi(os.Getenv(authStepUpTokenExpiryDurationInSeconds))
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return duration
+}
+
+func getStepUpMaxRequestsPerWindow() int {
+	maxRequestsPerWindow, err := strconv.Atoi(os.Getenv(authStepUpMaxRequestsPerWindow))
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return maxRequestsPerWindow
+}
+
+func getStepUpWindowInSeconds() int {
+	windowInSeconds, err := strconv.Atoi(os.Getenv(authStepUpWindowInSeconds))
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return windowInSeconds
+}
+
+func getOverdraftAnnualInterestRateInBasisPoints() int64 {
+	rate, err := strconv.ParseInt(os.Getenv(overdraftAnnualInterestRateInBasisPoints), 10, 64)
+	if err != nil {
+		// since 0 is a valid interest rate, to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return rate
+}
+
+func getMinimumAverageBalanceSavingsAccountRequiredAverageBalance() int64 {
+	requiredAverageBalance, err := strconv.ParseInt(os.Getenv(minimumAverageBalanceSavingsAccountRequiredAverageBalance), 10, 64)
+	if err != nil {
+		// since 0 is a valid required average balance, to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return requiredAverageBalance
+}
+
+// getMinimumAverageBalanceChargePenalty returns false as the second value when the env variable is not set or is invalid
+func getMinimumAverageBalanceChargePenalty() (bool, bool) {
+	chargePenalty, err := strconv.ParseBool(os.Getenv(minimumAverageBalanceChargePenalty))
+	if err != nil {
+		return false, false
+	}
+	return chargePenalty, true
+}
+
+func getFixedDepositAnnualInterestRateInBasisPoints() int64 {
+	rate, err := strconv.ParseInt(os.Getenv(fixedDepositAnnualInterestRateInBasisPoints), 10, 64)
+	if err != nil {
+		// since 0 is a valid interest rate, to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return rate
+}
+
+func getFixedDepositPrematureClosurePenaltyInBasisPoints() int64 {
+	penalty, err := strconv.ParseInt(os.Getenv(fixedDepositPrematureClosurePenaltyInBasisPoints), 10, 64)
+	if err != nil {
+		// since 0 is a valid penalty, to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return penalty
+}
+
+func getFixedDepositMinimumAmount() int64 {
+	minimumAmount, err := strconv.ParseInt(os.Getenv(fixedDepositMinimumAmount), 10, 64)
+	if err != nil {
+		// since 0 is a valid minimum amount, to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return minimumAmount
+}
+
+func getRecurringDepositAnnualInterestRateInBasisPoints() int64 {
+	rate, err := strconv.ParseInt(os.Getenv(recurringDepositAnnualInterestRateInBasisPoints), 10, 64)
+	if err != nil {
+		// since 0 is a valid interest rate, to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return rate
+}
+
+func getRecurringDepositMinimumInstallmentAmount() int64 {
+	minimumInstallmentAmount, err := strconv.ParseInt(os.Getenv(recurringDepositMinimumInstallmentAmount), 10, 64)
+	if err != nil {
+		// since 0 is a valid minimum amount, to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return minimumInstallmentAmount
+}
+
+func getRecurringDepositGracePeriodInDays() int {
+	gracePeriodInDays, err := strconv.Atoi(os.Getenv(recurringDepositGracePeriodInDays))
+	if err != nil {
+		// since 0 is a valid grace period, to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return gracePeriodInDays
+}
+
+func getLoanAnnualInterestRateInBasisPoints() int64 {
+	rate, err := strconv.ParseInt(os.Getenv(loanAnnualInterestRateInBasisPoints), 10, 64)
+	if err != nil {
+		// since 0 is a valid interest rate, to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return rate
+}
+
+func getLoanMinimumAmount() int64 {
+	minimumAmount, err := strconv.ParseInt(os.Getenv(loanMinimumAmount), 10, 64)
+	if err != nil {
+		// since 0 is a valid minimum amount, to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return minimumAmount
+}
+
+func getLoanMaximumAmount() int64 {
+	maximumAmount, err := strconv.ParseInt(os.Getenv(loanMaximumAmount), 10, 64)
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return maximumAmount
+}
+
+func getLoanGracePeriodInDays() int {
+	gracePeriodInDays, err := strconv.Atoi(os.Getenv(loanGracePeriodInDays))
+	if err != nil {
+		// since 0 is a valid grace period, to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return gracePeriodInDays
+}
+
+func getBankCountryCode() string {
+	return os.Getenv(bankCountryCode)
+}
+
+func getBankCode() string {
+	return os.Getenv(bankCode)
+}
+
+func getBankBranchCode() string {
+	return os.Getenv(bankBranchCode)
+}
+
+func getBankCurrency() string {
+	return os.Getenv(bankCurrency)
+}
+
+func getBeneficiaryCoolingPeriodInHours() int {
+	coolingPeriodInHours, err := strconv.Atoi(os.Getenv(beneficiaryCoolingPeriodInHours))
+	if err != nil {
+		// since 0 is a valid cooling period, to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return coolingPeriodInHours
+}
+
+func getBeneficiaryCoolingPeriodTransferLimit() int64 {
+	transferLimit, err := strconv.ParseInt(os.Getenv(beneficiaryCoolingPeriodTransferLimit), 10, 64)
+	if err != nil {
+		// since 0 is a valid transfer limit, to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return transferLimit
+}
+
+func getNameEnquiryMaxRequestsPerWindow() int {
+	maxRequestsPerWindow, err := strconv.Atoi(os.Getenv(nameEnquiryMaxRequestsPerWindow))
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return maxRequestsPerWindow
+}
+
+func getNameEnquiryWindowInSeconds() int {
+	windowInSeconds, err := strconv.Atoi(os.Getenv(nameEnquiryWindowInSeconds))
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return windowInSeconds
+}
+
+func getTransferLimitSavingsAccountPerTransactionAmount() int64 {
+	perTransactionAmount, err := strconv.ParseInt(os.Getenv(transferLimitSavingsAccountPerTransactionAmount), 10, 64)
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return perTransactionAmount
+}
+
+func getTransferLimitSavingsAccountDailyCount() int {
+	dailyCount, err := strconv.Atoi(os.Getenv(transferLimitSavingsAccountDailyCount))
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return dailyCount
+}
+
+func getTransferLimitSavingsAccountDailyAmount() int64 {
+	dailyAmount, err := strconv.ParseInt(os.Getenv(transferLimitSavingsAccountDailyAmount), 10, 64)
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return dailyAmount
+}
+
+func getTransferLimitSavingsAccountMonthlyAmount() int64 {
+	monthlyAmount, err := strconv.ParseInt(os.Getenv(transferLimitSavingsAccountMonthlyAmount), 10, 64)
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return monthlyAmount
+}
+
+func getTransferLimitCurrentAccountPerTransactionAmount() int64 {
+	perTransactionAmount, err := strconv.ParseInt(os.Getenv(transferLimitCurrentAccountPerTransactionAmount), 10, 64)
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return perTransactionAmount
+}
+
+func getTransferLimitCurrentAccountDailyCount() int {
+	dailyCount, err := strconv.Atoi(os.Getenv(transferLimitCurrentAccountDailyCount))
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return dailyCount
+}
+
+func getTransferLimitCurrentAccountDailyAmount() int64 {
+	dailyAmount, err := strconv.ParseInt(os.Getenv(transferLimitCurrentAccountDailyAmount), 10, 64)
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return dailyAmount
+}
+
+func getTransferLimitCurrentAccountMonthlyAmount() int64 {
+	monthlyAmount, err := strconv.ParseInt(os.Getenv(transferLimitCurrentAccountMonthlyAmount), 10, 64)
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return monthlyAmount
+}
+
+func getStandingInstructionFailurePolicy() string {
+	return os.Getenv(standingInstructionFailurePolicy)
+}
+
+func getStandingInstructionRetryPeriodInDays() int {
+	retryPeriodInDays, err := strconv.Atoi(os.Getenv(standingInstructionRetryPeriodInDays))
+	if err != nil {
+		// since 0 is a valid retry period, to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return retryPeriodInDays
+}
+
+func getStandingInstructionMaxConsecutiveFailures() int {
+	maxConsecutiveFailures, err := strconv.Atoi(os.Getenv(standingInstructionMaxConsecutiveFailures))
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return maxConsecutiveFailures
+}
+
+func getPaymentRailLatencyInSeconds() int {
+	latencyInSeconds, err := strconv.Atoi(os.Getenv(paymentRailLatencyInSeconds))
+	if err != nil {
+		// since 0 is a valid latency, to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return latencyInSeconds
+}
+
+func getPaymentRailReturnProbabilityInBasisPoints() int64 {
+	returnProbability, err := strconv.ParseInt(os.Getenv(paymentRailReturnProbabilityInBasisPoints), 10, 64)
+	if err != nil {
+		// since 0 is a valid probability, to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return returnProbability
+}
+
+func getPaymentRailNeftBatchIntervalInMinutes() int {
+	batchIntervalInMinutes, err := strconv.Atoi(os.Getenv(paymentRailNeftBatchIntervalInMinutes))
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return batchIntervalInMinutes
+}
+
+func getPaymentRailNeftFirstBatchHour() int {
+	firstBatchHour, err := strconv.Atoi(os.Getenv(paymentRailNeftFirstBatchHour))
+	if err != nil {
+		// since 0 is a valid hour, to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return firstBatchHour
+}
+
+func getPaymentRailNeftCutOffHour() int {
+	cutOffHour, err := strconv.Atoi(os.Getenv(paymentRailNeftCutOffHour))
+	if err != nil {
+		// since 0 is a valid hour, to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return cutOffHour
+}
+
+func getPaymentRailRtgsMinimumAmount() int64 {
+	minimumAmount, err := strconv.ParseInt(os.Getenv(paymentRailRtgsMinimumAmount), 10, 64)
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return minimumAmount
+}
+
+func getPaymentRailImpsMaximumAmount() int64 {
+	maximumAmount, err := strconv.ParseInt(os.Getenv(paymentRailImpsMaximumAmount), 10, 64)
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return maximumAmount
+}
+
+func getInboundPaymentDirectory() string {
+	return os.Getenv(inboundPaymentDirectory)
+}
+
+func getVPAHandle() string {
+	return os.Getenv(vpaHandle)
+}
+
+func getVPAMaxPerUser() int {
+	maxPerUser, err := strconv.Atoi(os.Getenv(vpaMaxPerUser))
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return maxPerUser
+}
+
+func getVPACollectRequestExpiryInMinutes() int {
+	expiryInMinutes, err := strconv.Atoi(os.Getenv(vpaCollectRequestExpiryInMinutes))
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return expiryInMinutes
+}
+
+func getPaymentRequestLinkBaseURL() string {
+	return os.Getenv(paymentRequestLinkBaseURL)
+}
+
+func getPaymentRequestDefaultExpiryInHours() int {
+	expiryInHours, err := strconv.Atoi(os.Getenv(paymentRequestDefaultExpiryInHours))
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return expiryInHours
+}
+
+func getPaymentRequestMaxExpiryInHours() int {
+	expiryInHours, err := strconv.Atoi(os.Getenv(paymentRequestMaxExpiryInHours))
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return expiryInHours
+}
+
+func getBulkTransferMaxRows() int {
+	maxRows, err := strconv.Atoi(os.Getenv(bulkTransferMaxRows))
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return maxRows
+}
+
+func getFXQuoteExpiryInSeconds() int {
+	expiryInSeconds, err := strconv.Atoi(os.Getenv(fxQuoteExpiryInSeconds))
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return expiryInSeconds
+}
+
+func getFXMarginInBasisPoints() int64 {
+	marginInBasisPoints, err := strconv.ParseInt(os.Getenv(fxMarginInBasisPoints), 10, 64)
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return marginInBasisPoints
+}
+
+func getFXRateMaxAgeInHours() int {
+	maxAgeInHours, err := strconv.Atoi(os.Getenv(fxRateMaxAgeInHours))
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return maxAgeInHours
+}
+
+func getPocketMaxPerAccount() int {
+	maxPerAccount, err := strconv.Atoi(os.Getenv(pocketMaxPerAccount))
+	if err != nil {
+		// to indicate that an error has occured, we are returning -1
+		return -1
+	}
+	return maxPerAccount
+}
@@ -15,6 +15,24 @@ type Config struct {
 	Database    DatabaseConfig  `koanf:"database"`
 	Cache       CacheConfig     `koanf:"cache"`
 	Auth        AuthConfig      `koanf:"auth"`
+	Overdraft   OverdraftConfig `koanf:"overdraft"`
+	Bank        BankConfig      `koanf:"bank"`
+
+	MinimumAverageBalance MinimumAverageBalanceConfig `koanf:"minimumAverageBalance"`
+	FixedDeposit          FixedDepositConfig          `koanf:"fixedDeposit"`
+	RecurringDeposit      RecurringDepositConfig      `koanf:"recurringDeposit"`
+	Loan                  LoanConfig                  `koanf:"loan"`
+	Beneficiary           BeneficiaryConfig           `koanf:"beneficiary"`
+	NameEnquiry           NameEnquiryConfig           `koanf:"nameEnquiry"`
+	TransferLimit         TransferLimitConfig         `koanf:"transferLimit"`
+	StandingInstruction   StandingInstructionConfig   `koanf:"standingInstruction"`
+	PaymentRail           PaymentRailConfig           `koanf:"paymentRail"`
+	InboundPayment        InboundPaymentConfig        `koanf:"inboundPayment"`
+	VPA                   VPAConfig                   `koanf:"vpa"`
+	PaymentRequest        PaymentRequestConfig        `koanf:"paymentRequest"`
+	BulkTransfer          BulkTransferConfig          `koanf:"bulkTransfer"`
+	FX                    FXConfig                    `koanf:"fx"`
+	Pocket                PocketConfig                `koanf:"pocket"`
 }
 
 type LoggerConfig struct {
@@ -66,4 +84,134 @@ type RedisConfig struct {
 type AuthConfig struct {
 	AccessTokenExpiryDurationInSeconds int    `koanf:"accessTokenExpiryDurationInSeconds"`
 	AccessTokenSecretSigningKey        string `koanf:"accessTokenSecretSigningKey"`
+
+	// a step-up token confirms a sensitive action by re-entering the password, it can be used once within its expiry
+	StepUpTokenExpiryDurationInSeconds int `koanf:"stepUpTokenExpiryDurationInSeconds"`
+
+	// the number of step-ups a user can make per window
+	StepUpMaxRequestsPerWindow int `koanf:"stepUpMaxRequestsPerWindow"`
+	StepUpWindowInSeconds      int `koanf:"stepUpWindowInSeconds"`
+}
+
+type OverdraftConfig struct {
+	AnnualInterestRateInBasisPoints int64 `koanf:"annualInterestRateInBasisPoints"`
+}
+
+type MinimumAverageBalanceConfig struct {
+	SavingsAccountRequiredAverageBalance int64 `koanf:"savingsAccountRequiredAverageBalance"`
+	ChargePenalty                        bool  `koanf:"chargePenalty"`
+}
+
+type FixedDepositConfig struct {
+	AnnualInterestRateInBasisPoints      int64 `koanf:"annualInterestRateInBasisPoints"`
+	PrematureClosurePenaltyInBasisPoints int64 `koanf:"prematureClosurePenaltyInBasisPoints"`
+	MinimumAmount                        int64 `koanf:"minimumAmount"`
+}
+
+type RecurringDepositConfig struct {
+	AnnualInterestRateInBasisPoints int64 `koanf:"annualInterestRateInBasisPoints"`
+	MinimumInstallmentAmount        int64 `koanf:"minimumInstallmentAmount"`
+	GracePeriodInDays               int   `koanf:"gracePeriodInDays"`
+}
+
+type LoanConfig struct {
+	AnnualInterestRateInBasisPoints int64 `koanf:"annualInterestRateInBasisPoints"`
+	MinimumAmount                   int64 `koanf:"minimumAmount"`
+	MaximumAmount                   int64 `koanf:"maximumAmount"`
+	GracePeriodInDays               int   `koanf:"gracePeriodInDays"`
+}
+
+type BankConfig struct {
+	CountryCode string `koanf:"countryCode"`
+	Code        string `koanf:"code"`
+	BranchCode  string `koanf:"branchCode"`
+	Currency    string `koanf:"currency"`
+}
+
+type BeneficiaryConfig struct {
+	CoolingPeriodInHours       int   `koanf:"coolingPeriodInHours"`
+	CoolingPeriodTransferLimit int64 `koanf:"coolingPeriodTransferLimit"`
+}
+
+type NameEnquiryConfig struct {
+	MaxRequestsPerWindow int `koanf:"maxRequestsPerWindow"`
+	WindowInSeconds      int `koanf:"windowInSeconds"`
+}
+
+type TransferLimitConfig struct {
+	SavingsAccount TransferLimits `koanf:"savingsAccount"`
+	CurrentAccount TransferLimits `koanf:"currentAccount"`
+}
+
+type TransferLimits struct {
+	PerTransactionAmount int64 `koanf:"perTransactionAmount"`
+	DailyCount           int   `koanf:"dailyCount"`
+	DailyAmount          int64 `koanf:"dailyAmount"`
+	MonthlyAmount        int64 `koanf:"monthlyAmount"`
+}
+
+type StandingInstructionConfig struct {
+	FailurePolicy          StandingInstructionFailurePolicy `koanf:"failurePolicy"`
+	RetryPeriodInDays      int                              `koanf:"retryPeriodInDays"`
+	MaxConsecutiveFailures int                              `koanf:"maxConsecutiveFailures"`
+}
+
+// StandingInstructionFailurePolicy decides what happens to an occurrence of a standing instruction that could not be paid, e.g. for insufficient funds
+type StandingInstructionFailurePolicy string
+
+const (
+	StandingInstructionRetry StandingInstructionFailurePolicy = "RETRY" // the occurrence is attempted again every day until the end of the retry period
+	StandingInstructionSkip  StandingInstructionFailurePolicy = "SKIP"  // the occurrence is skipped and the next one is waited for
+)
+
+// PaymentRailConfig configures the local simulator of the NEFT, IMPS and RTGS rails external transfers are sent over
+type PaymentRailConfig struct {
+	LatencyInSeconds               int   `koanf:"latencyInSeconds"`
+	ReturnProbabilityInBasisPoints int64 `koanf:"returnProbabilityInBasisPoints"`
+	NeftBatchIntervalInMinutes     int   `koanf:"neftBatchIntervalInMinutes"`
+	NeftFirstBatchHour             int   `koanf:"neftFirstBatchHour"`
+	NeftCutOffHour                 int   `koanf:"neftCutOffHour"`
+	RtgsMinimumAmount              int64 `koanf:"rtgsMinimumAmount"`
+	ImpsMaximumAmount              int64 `koanf:"impsMaximumAmount"`
+}
+
+// InboundPaymentConfig configures the ingestion of the clearing files listing the payments received from other banks
+type InboundPaymentConfig struct {
+	// Directory is polled by the worker for clearing files, .csv files are read as CSV and .txt files as FIXED_WIDTH
+	Directory string `koanf:"directory"`
+}
+
+// VPAConfig configures the virtual payment addresses, the handles like alice@gobank customers are paid by
+type VPAConfig struct {
+	// Handle is the part of every VPA of this bank after the "@"
+	Handle                        string `koanf:"handle"`
+	MaxPerUser                    int    `koanf:"maxPerUser"`
+	CollectRequestExpiryInMinutes int    `koanf:"collectRequestExpiryInMinutes"`
+}
+
+// PaymentRequestConfig configures the payment requests, the shareable links a user asks another user to pay through
+type PaymentRequestConfig struct {
+	// LinkBaseURL is followed by the token of a payment request in the link shared with the payer
+	LinkBaseURL          string `koanf:"linkBaseURL"`
+	DefaultExpiryInHours int    `koanf:"defaultExpiryInHours"`
+	MaxExpiryInHours     int    `koanf:"maxExpiryInHours"`
+}
+
+// BulkTransferConfig configures the batches of internal transfers business customers upload as CSV files
+type BulkTransferConfig struct {
+	MaxRows int `koanf:"maxRows"`
+}
+
+// FXConfig configures the FX quotes given for transfers between accounts held in different currencies
+type FXConfig struct {
+	QuoteExpiryInSeconds int   `koanf:"quoteExpiryInSeconds"`
+	MarginInBasisPoints  int64 `koanf:"marginInBasisPoints"`
+
+	// RateMaxAgeInHours is how long a published rate is quoted for, no quote is given for a pair without a newer rate
+	RateMaxAgeInHours int `koanf:"rateMaxAgeInHours"`
+}
+
+// PocketConfig configures the pockets savings accounts earmark part of their balance in
+type PocketConfig struct {
+	MaxPerAccount int `koanf:"maxPerAccount"`
 }
@@ -1,28 +1,55 @@
 package controller
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/skamranahmed/go-bank/cmd/middleware"
 	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/account/model"
 	accountService "github.com/skamranahmed/go-bank/internal/account/service"
+	accountTasks "github.com/skamranahmed/go-bank/internal/account/tasks"
 	"github.com/skamranahmed/go-bank/internal/account/types"
+	feeModel "github.com/skamranahmed/go-bank/internal/fee/model"
+	feeService "github.com/skamranahmed/go-bank/internal/fee/service"
+	feeTypes "github.com/skamranahmed/go-bank/internal/fee/types"
+	transferService "github.com/skamranahmed/go-bank/internal/transfer/service"
+	userService "github.com/skamranahmed/go-bank/internal/user/service"
+	userTypes "github.com/skamranahmed/go-bank/internal/user/types"
+	"github.com/skamranahmed/go-bank/pkg/accountnumber"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/skamranahmed/go-bank/pkg/money"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/uptrace/bun"
 )
 
 type accountController struct {
-	accountService accountService.AccountService
+	db              *bun.DB
+	accountService  accountService.AccountService
+	feeService      feeService.FeeService
+	userService     userService.UserService
+	transferService transferService.TransferService
+	taskEnqueuer    tasksHelper.TaskEnqueuer
 }
 
 func newAccountController(dependency Dependency) AccountController {
 	return &accountController{
-		accountService: dependency.AccountService,
+		db:              dependency.Db,
+		accountService:  dependency.AccountService,
+		feeService:      dependency.FeeService,
+		userService:     dependency.UserService,
+		transferService: dependency.TransferService,
+		taskEnqueuer:    dependency.TaskEnqueuer,
 	}
 }
 
-func (c *accountController) GetAccounts(ginCtx *gin.Context) {
+func (c *accountController) OpenAccount(ginCtx *gin.Context) {
 	requestCtx := ginCtx.Request.Context()
 
 	// extract user ID from the request context
@@ -44,20 +71,30 @@ func (c *accountController) GetAccounts(ginCtx *gin.Context) {
 		return
 	}
 
-	accounts, err := c.accountService.GetAccountsByUserID(requestCtx, nil, userUUID)
+	var payload types.OpenAccountRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	var account *model.Account
+	err = database.RunInTransaction(requestCtx, "openAccount", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		account, err = c.accountService.OpenAccount(txCtx, tx, userUUID, model.AccountType(payload.Data.Type), money.Currency(payload.Data.Currency))
+		return err
+	})
 	if err != nil {
 		server.SendErrorResponse(ginCtx, err)
 		return
 	}
 
 	// transform to DTO and return response
-	accountDtos := types.TransformToAccountDtoList(accounts)
-	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetAccountsResponse{
-		Data: accountDtos,
+	accountDto := types.TransformToAccountDto(account)
+	server.SendSuccessResponse(ginCtx, http.StatusCreated, types.OpenAccountResponse{
+		Data: *accountDto,
 	})
 }
 
-func (c *accountController) GetAccountByID(ginCtx *gin.Context) {
+func (c *accountController) GetAccounts(ginCtx *gin.Context) {
 	requestCtx := ginCtx.Request.Context()
 
 	// extract user ID from the request context
@@ -79,10 +116,75 @@ func (c *accountController) GetAccountByID(ginCtx *gin.Context) {
 		return
 	}
 
+	accounts, err := c.accountService.GetAccountsOfHolder(requestCtx, nil, userUUID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	accountDtos := types.TransformToAccountDtoList(accounts)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetAccountsResponse{
+		Data: accountDtos,
+	})
+}
+
+func (c *accountController) GetAccountByID(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	account, ok := c.getAccountOfAuthenticatedUser(ginCtx)
+	if !ok {
+		return
+	}
+
+	accountHolders, err := c.accountService.ListAccountHolders(requestCtx, nil, types.AccountHolderListOptions{
+		AccountID: &account.ID,
+		Statuses:  []model.AccountHolderStatus{model.AccountHolderInvited, model.AccountHolderActive},
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	activeStatus := model.PocketActive
+	pockets, err := c.accountService.ListPockets(requestCtx, nil, types.PocketListOptions{
+		AccountID: &account.ID,
+		Status:    &activeStatus,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	activeNomineeStatus := model.NomineeActive
+	nominees, err := c.accountService.ListNominees(requestCtx, nil, types.NomineeListOptions{
+		AccountID: &account.ID,
+		Status:    &activeNomineeStatus,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	accountDto := types.TransformToAccountDto(account)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetAccountByIDResponse{
+		Data: types.AccountDetailsDto{
+			AccountDto: *accountDto,
+			Holders:    types.TransformToAccountHolderDtoList(accountHolders),
+			Pockets:    types.TransformToPocketDtoList(pockets, account.Currency),
+			Nominees:   types.TransformToNomineeDtoList(nominees),
+		},
+	})
+}
+
+func (c *accountController) UpdateOverdraftLimit(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
 	// extract account ID from URL parameter
 	accountIDParam := ginCtx.Param("account_id")
 	accountID, err := strconv.ParseInt(accountIDParam, 10, 64)
-	if err != nil {
+	if err != nil || !accountnumber.IsValid(accountID) {
 		server.SendErrorResponse(ginCtx, &server.ApiError{
 			HttpStatusCode: http.StatusBadRequest,
 			Message:        "Invalid account ID",
@@ -90,26 +192,290 @@ func (c *accountController) GetAccountByID(ginCtx *gin.Context) {
 		return
 	}
 
+	var payload types.UpdateOverdraftLimitRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	var account *model.Account
+	err = database.RunInTransaction(requestCtx, "updateOverdraftLimit", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		account, err = c.accountService.SetOverdraftLimit(txCtx, tx, accountID, *payload.Data.OverdraftLimit)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	accountDto := types.TransformToAccountDto(account)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.UpdateOverdraftLimitResponse{
+		Data: *accountDto,
+	})
+}
+
+func (c *accountController) GetTransactions(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	account, ok := c.getAccountOfAuthenticatedUser(ginCtx)
+	if !ok {
+		return
+	}
+
+	var query types.GetTransactionsRequestQuery
+	isSuccess := server.BindAndValidateIncomingRequestQuery(ginCtx, &query)
+	if !isSuccess {
+		return
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = 20
+	}
+
+	transactions, err := c.accountService.ListTransactions(requestCtx, nil, types.TransactionQueryOptions{
+		AccountID: &account.ID,
+		Limit:     limit,
+		Offset:    query.Offset,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	transactionDtos := types.TransformToTransactionDtoList(transactions)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetTransactionsResponse{
+		Data: transactionDtos,
+	})
+}
+
+func (c *accountController) RequestStatement(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	account, ok := c.getAccountOfAuthenticatedUser(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.RequestStatementRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	// the dates are already validated to be in the YYYY-MM-DD format, so the string comparison matches the date comparison
+	if payload.Data.FromDate > payload.Data.ToDate {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "from_date cannot be after to_date",
+		})
+		return
+	}
+
+	statementRequestID := uuid.New().String()
+
+	var feeCharge *feeModel.FeeCharge
+	err := database.RunInTransaction(requestCtx, "requestStatement", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		feeCharge, err = c.feeService.ChargeFee(txCtx, tx, feeTypes.ChargeFeeParams{
+			AccountID: account.ID,
+			Event:     feeModel.StatementRequest,
+			Reference: statementRequestID,
+		})
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	/*
+		The statement is generated in the background after the fee is committed
+		If the task cannot be enqueued, the error is logged so that the statement can be sent manually
+		against the statement request ID, which is also the reference of the fee charge
+	*/
+	err = c.taskEnqueuer.Enqueue(requestCtx, accountTasks.NewGenerateAccountStatementTask(statementRequestID, account.ID, payload.Data.FromDate, payload.Data.ToDate), nil, nil)
+	if err != nil {
+		logger.Error(requestCtx, "Unable to enqueue GenerateAccountStatementTask for statementRequestID: %s, error: %+v", statementRequestID, err)
+	}
+
+	var feeCharged int64
+	if feeCharge != nil {
+		feeCharged = feeCharge.TotalAmount()
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusAccepted, types.RequestStatementResponse{
+		Data: types.StatementRequestDto{
+			ID:         statementRequestID,
+			AccountID:  account.ID,
+			FromDate:   payload.Data.FromDate,
+			ToDate:     payload.Data.ToDate,
+			FeeCharged: feeCharged,
+		},
+	})
+}
+
+func (c *accountController) GetEndOfDayStatement(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	account, ok := c.getAccountOfAuthenticatedUser(ginCtx)
+	if !ok {
+		return
+	}
+
+	var query types.GetEndOfDayStatementRequestQuery
+	isSuccess := server.BindAndValidateIncomingRequestQuery(ginCtx, &query)
+	if !isSuccess {
+		return
+	}
+
+	// the format has already been validated by the binding
+	statementDate, _ := time.Parse(time.DateOnly, query.Date)
+
+	var content []byte
+	err := database.RunInTransaction(requestCtx, "buildEndOfDayStatement", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		content, err = c.accountService.BuildEndOfDayStatement(txCtx, tx, account.ID, statementDate)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetEndOfDayStatementResponse{
+		Data: types.EndOfDayStatementDto{
+			FileName: fmt.Sprintf("%d_%s.camt053.xml", account.ID, statementDate.Format("20060102")),
+			Content:  string(content),
+		},
+	})
+}
+
+// getAccountOfAuthenticatedUser fetches the account in the URL and verifies that the authenticated user is one of its holders
+// On failure, the error response is already sent and false is returned
+func (c *accountController) getAccountOfAuthenticatedUser(ginCtx *gin.Context) (*model.Account, bool) {
+	requestCtx := ginCtx.Request.Context()
+
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return nil, false
+	}
+
+	// extract account ID from URL parameter
+	accountID, err := strconv.ParseInt(ginCtx.Param("account_id"), 10, 64)
+	if err != nil || !accountnumber.IsValid(accountID) {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid account ID",
+		})
+		return nil, false
+	}
+
 	account, err := c.accountService.GetAccount(requestCtx, nil, types.AccountQueryOptions{
 		AccountID: &accountID,
 	})
 	if err != nil {
 		server.SendErrorResponse(ginCtx, err)
-		return
+		return nil, false
 	}
 
-	// authorization check: verify account belongs to authenticated user
-	if account.UserID != userUUID {
+	// authorization check: verify the authenticated user holds the account, alone or jointly
+	isAccountHolder, err := c.accountService.IsAccountHolder(requestCtx, nil, account, userID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return nil, false
+	}
+	if !isAccountHolder {
 		server.SendErrorResponse(ginCtx, &server.ApiError{
 			HttpStatusCode: http.StatusForbidden,
 			Message:        "You do not have permission to access this account",
 		})
+		return nil, false
+	}
+
+	return account, true
+}
+
+func getAuthenticatedUserID(ginCtx *gin.Context) (uuid.UUID, bool) {
+	userID, ok := ginCtx.Request.Context().Value(middleware.ContextUserIDKey).(string)
+	if !ok || userID == "" {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusUnauthorized,
+			Message:        "User not authenticated",
+		})
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid user ID",
+		})
+		return uuid.Nil, false
+	}
+
+	return userUUID, true
+}
+
+/*
+NameEnquiry returns the masked name of the holder of an account, so that a customer can confirm whom they are about to pay
+
+Nothing else about the account is returned, and the enquiries are rate limited per user to prevent enumerating the account holders
+*/
+func (c *accountController) NameEnquiry(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	var query types.NameEnquiryRequestQuery
+	isSuccess := server.BindAndValidateIncomingRequestQuery(ginCtx, &query)
+	if !isSuccess {
 		return
 	}
 
-	// transform to DTO and return response
-	accountDto := types.TransformToAccountDto(account)
-	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetAccountByIDResponse{
-		Data: *accountDto,
+	// resolve the account referenced by its IBAN to its account number
+	accountID := query.AccountID
+	if query.IBAN != "" {
+		ibanAccountID, err := c.accountService.ResolveIBAN(requestCtx, nil, query.IBAN)
+		if err != nil {
+			server.SendErrorResponse(ginCtx, err)
+			return
+		}
+
+		if accountID != 0 && accountID != ibanAccountID {
+			server.SendErrorResponse(ginCtx, &server.ApiError{
+				HttpStatusCode: http.StatusBadRequest,
+				Message:        "account_id and iban refer to different accounts",
+			})
+			return
+		}
+		accountID = ibanAccountID
+	}
+
+	account, err := c.accountService.GetAccount(requestCtx, nil, types.AccountQueryOptions{
+		AccountID: &accountID,
+		Columns:   []string{"id", "user_id"},
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	holderID := account.UserID.String()
+	holder, err := c.userService.GetUser(requestCtx, nil, userTypes.UserQueryOptions{
+		ID:      &holderID,
+		Columns: []string{"username", "first_name", "last_name"},
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.NameEnquiryResponse{
+		Data: types.NameEnquiryDto{
+			AccountID:  account.ID,
+			HolderName: holder.MaskedName(),
+		},
 	})
 }
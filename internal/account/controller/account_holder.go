@@ -0,0 +1,271 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	userTypes "github.com/skamranahmed/go-bank/internal/user/types"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/uptrace/bun"
+)
+
+// InviteAccountHolder invites a user, referenced by their username, to become a joint holder of the account
+func (c *accountController) InviteAccountHolder(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	account, ok := c.getAccountOfPrimaryHolder(ginCtx, "Only the primary holder can invite holders to this account")
+	if !ok {
+		return
+	}
+
+	var payload types.InviteAccountHolderRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	invitee, err := c.userService.GetUser(requestCtx, nil, userTypes.UserQueryOptions{
+		Username: &payload.Data.Username,
+		Columns:  []string{"id"},
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	var accountHolder *model.AccountHolder
+	err = database.RunInTransaction(requestCtx, "inviteAccountHolder", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		accountHolder, err = c.accountService.InviteAccountHolder(txCtx, tx, account.ID, account.UserID, invitee.ID)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	accountHolderDto := types.TransformToAccountHolderDto(accountHolder)
+	server.SendSuccessResponse(ginCtx, http.StatusCreated, types.InviteAccountHolderResponse{
+		Data: *accountHolderDto,
+	})
+}
+
+// GetAccountHolders lists the joint holders of the account along with the pending invitations, the primary holder is the user of the account
+func (c *accountController) GetAccountHolders(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	account, ok := c.getAccountOfAuthenticatedUser(ginCtx)
+	if !ok {
+		return
+	}
+
+	accountHolders, err := c.accountService.ListAccountHolders(requestCtx, nil, types.AccountHolderListOptions{
+		AccountID: &account.ID,
+		Statuses:  []model.AccountHolderStatus{model.AccountHolderInvited, model.AccountHolderActive},
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	accountHolderDtos := types.TransformToAccountHolderDtoList(accountHolders)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetAccountHoldersResponse{
+		Data: accountHolderDtos,
+	})
+}
+
+// RemoveAccountHolder removes a joint holder or withdraws their invitation, a joint holder can also remove themselves to leave the account
+func (c *accountController) RemoveAccountHolder(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	account, ok := c.getAccountOfAuthenticatedUser(ginCtx)
+	if !ok {
+		return
+	}
+
+	accountHolderID, ok := getAccountHolderID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var accountHolder *model.AccountHolder
+	err := database.RunInTransaction(requestCtx, "removeAccountHolder", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		accountHolder, err = c.accountService.RemoveAccountHolder(txCtx, tx, account, accountHolderID, userID)
+		if err != nil {
+			return err
+		}
+
+		// the scheduled transfers, standing instructions, bulk transfers and joint transfers of the holder are not executed once they no longer hold the account
+		return c.transferService.CancelTransfersOfRemovedHolder(txCtx, tx, account.ID, accountHolder.UserID)
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	accountHolderDto := types.TransformToAccountHolderDto(accountHolder)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.RemoveAccountHolderResponse{
+		Data: *accountHolderDto,
+	})
+}
+
+// UpdateMandate changes how the holders of the account operate it, only its primary holder can change it
+func (c *accountController) UpdateMandate(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	account, ok := c.getAccountOfPrimaryHolder(ginCtx, "Only the primary holder can change the mandate of this account")
+	if !ok {
+		return
+	}
+
+	var payload types.UpdateMandateRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	mandate := model.AccountMandate(payload.Data.Mandate)
+	var jointApprovalThreshold int64
+	if mandate == model.MandateJointly {
+		if payload.Data.JointApprovalThreshold == nil {
+			server.SendErrorResponse(ginCtx, &server.ApiError{
+				HttpStatusCode: http.StatusBadRequest,
+				Message:        "joint_approval_threshold is required for the JOINTLY mandate",
+			})
+			return
+		}
+		jointApprovalThreshold = *payload.Data.JointApprovalThreshold
+	}
+
+	err := database.RunInTransaction(requestCtx, "updateMandate", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		account, err = c.accountService.SetMandate(txCtx, tx, account.ID, mandate, jointApprovalThreshold)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	accountDto := types.TransformToAccountDto(account)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.UpdateMandateResponse{
+		Data: *accountDto,
+	})
+}
+
+// GetAccountHolderInvitations lists the pending invitations of the authenticated user to hold the accounts of other users
+func (c *accountController) GetAccountHolderInvitations(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	accountHolders, err := c.accountService.ListAccountHolders(requestCtx, nil, types.AccountHolderListOptions{
+		UserID:   &userID,
+		Statuses: []model.AccountHolderStatus{model.AccountHolderInvited},
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	accountHolderDtos := types.TransformToAccountHolderDtoList(accountHolders)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetAccountHolderInvitationsResponse{
+		Data: accountHolderDtos,
+	})
+}
+
+func (c *accountController) AcceptAccountHolderInvitation(ginCtx *gin.Context) {
+	c.respondToAccountHolderInvitation(ginCtx, "acceptAccountHolderInvitation", c.accountService.AcceptAccountHolderInvitation)
+}
+
+func (c *accountController) DeclineAccountHolderInvitation(ginCtx *gin.Context) {
+	c.respondToAccountHolderInvitation(ginCtx, "declineAccountHolderInvitation", c.accountService.DeclineAccountHolderInvitation)
+}
+
+// respondToAccountHolderInvitation handles both the acceptance and the decline of an invitation by the invited user
+func (c *accountController) respondToAccountHolderInvitation(ginCtx *gin.Context, transactionName string, respond func(requestCtx context.Context, dbExecutor bun.IDB, accountHolderID uuid.UUID, userID uuid.UUID) (*model.AccountHolder, error)) {
+	requestCtx := ginCtx.Request.Context()
+
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	accountHolderID, ok := getAccountHolderID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var accountHolder *model.AccountHolder
+	err := database.RunInTransaction(requestCtx, transactionName, c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		accountHolder, err = respond(txCtx, tx, accountHolderID, userID)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	accountHolderDto := types.TransformToAccountHolderDto(accountHolder)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.RespondToAccountHolderInvitationResponse{
+		Data: *accountHolderDto,
+	})
+}
+
+// getAccountOfPrimaryHolder fetches the account in the URL and verifies that the authenticated user is its primary holder
+// On failure, the error response is already sent and false is returned
+func (c *accountController) getAccountOfPrimaryHolder(ginCtx *gin.Context, forbiddenMessage string) (*model.Account, bool) {
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return nil, false
+	}
+
+	account, ok := c.getAccountOfAuthenticatedUser(ginCtx)
+	if !ok {
+		return nil, false
+	}
+
+	if account.UserID != userID {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        forbiddenMessage,
+		})
+		return nil, false
+	}
+
+	return account, true
+}
+
+// getAccountHolderID extracts the account holder ID from the URL parameter, on failure the error response is already sent and false is returned
+func getAccountHolderID(ginCtx *gin.Context) (uuid.UUID, bool) {
+	accountHolderID, err := uuid.Parse(ginCtx.Param("account_holder_id"))
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid account holder ID",
+		})
+		return uuid.Nil, false
+	}
+	return accountHolderID, true
+}
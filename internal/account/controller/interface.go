@@ -3,6 +3,30 @@ package controller
 import "github.com/gin-gonic/gin"
 
 type AccountController interface {
+	OpenAccount(ginCtx *gin.Context)
 	GetAccounts(ginCtx *gin.Context)
 	GetAccountByID(ginCtx *gin.Context)
+	GetTransactions(ginCtx *gin.Context)
+	RequestStatement(ginCtx *gin.Context)
+	GetEndOfDayStatement(ginCtx *gin.Context)
+	UpdateOverdraftLimit(ginCtx *gin.Context)
+	NameEnquiry(ginCtx *gin.Context)
+	CreatePocket(ginCtx *gin.Context)
+	GetPockets(ginCtx *gin.Context)
+	UpdatePocket(ginCtx *gin.Context)
+	DepositToPocket(ginCtx *gin.Context)
+	WithdrawFromPocket(ginCtx *gin.Context)
+	ClosePocket(ginCtx *gin.Context)
+	GetPocketMovements(ginCtx *gin.Context)
+	InviteAccountHolder(ginCtx *gin.Context)
+	GetAccountHolders(ginCtx *gin.Context)
+	RemoveAccountHolder(ginCtx *gin.Context)
+	UpdateMandate(ginCtx *gin.Context)
+	GetAccountHolderInvitations(ginCtx *gin.Context)
+	AcceptAccountHolderInvitation(ginCtx *gin.Context)
+	DeclineAccountHolderInvitation(ginCtx *gin.Context)
+	GetNominees(ginCtx *gin.Context)
+	AddNominee(ginCtx *gin.Context)
+	UpdateNominee(ginCtx *gin.Context)
+	RemoveNominee(ginCtx *gin.Context)
 }
@@ -0,0 +1,197 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/uptrace/bun"
+)
+
+// GetNominees lists the active nominees of the account, any of its holders can see them
+func (c *accountController) GetNominees(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	account, ok := c.getAccountOfAuthenticatedUser(ginCtx)
+	if !ok {
+		return
+	}
+
+	activeStatus := model.NomineeActive
+	nominees, err := c.accountService.ListNominees(requestCtx, nil, types.NomineeListOptions{
+		AccountID: &account.ID,
+		Status:    &activeStatus,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	nomineeDtos := types.TransformToNomineeDtoList(nominees)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetNomineesResponse{
+		Data: nomineeDtos,
+	})
+}
+
+// AddNominee registers a nominee of the account, only its primary holder can add one and the request must carry a step-up token
+func (c *accountController) AddNominee(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	account, ok := c.getAccountOfPrimaryHolder(ginCtx, "Only the primary holder can change the nominees of this account")
+	if !ok {
+		return
+	}
+
+	var payload types.AddNomineeRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	params, otherShares := toNomineeParams(payload.Data)
+
+	var nominee *model.Nominee
+	err := database.RunInTransaction(requestCtx, "addNominee", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		nominee, err = c.accountService.AddNominee(txCtx, tx, account.ID, params, otherShares)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	nomineeDto := types.TransformToNomineeDto(nominee)
+	server.SendSuccessResponse(ginCtx, http.StatusCreated, types.AddNomineeResponse{
+		Data: *nomineeDto,
+	})
+}
+
+// UpdateNominee replaces the details of a nominee of the account, only its primary holder can update one and the request must carry a step-up token
+func (c *accountController) UpdateNominee(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	account, ok := c.getAccountOfPrimaryHolder(ginCtx, "Only the primary holder can change the nominees of this account")
+	if !ok {
+		return
+	}
+
+	nomineeID, ok := getNomineeID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.UpdateNomineeRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	params, otherShares := toNomineeParams(payload.Data)
+
+	var nominee *model.Nominee
+	err := database.RunInTransaction(requestCtx, "updateNominee", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		nominee, err = c.accountService.UpdateNominee(txCtx, tx, account.ID, nomineeID, params, otherShares)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	nomineeDto := types.TransformToNomineeDto(nominee)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.UpdateNomineeResponse{
+		Data: *nomineeDto,
+	})
+}
+
+// RemoveNominee removes a nominee of the account, only its primary holder can remove one and the request must carry a step-up token
+func (c *accountController) RemoveNominee(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	account, ok := c.getAccountOfPrimaryHolder(ginCtx, "Only the primary holder can change the nominees of this account")
+	if !ok {
+		return
+	}
+
+	nomineeID, ok := getNomineeID(ginCtx)
+	if !ok {
+		return
+	}
+
+	// the body is optional, the last nominee of an account is removed without handing its share over
+	var payload types.RemoveNomineeRequest
+	if ginCtx.Request.ContentLength != 0 {
+		isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+		if !isSuccess {
+			return
+		}
+	}
+
+	otherShares := toNomineeShares(payload.Data.OtherShares)
+
+	var nominee *model.Nominee
+	err := database.RunInTransaction(requestCtx, "removeNominee", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		nominee, err = c.accountService.RemoveNominee(txCtx, tx, account.ID, nomineeID, otherShares)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	nomineeDto := types.TransformToNomineeDto(nominee)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.RemoveNomineeResponse{
+		Data: *nomineeDto,
+	})
+}
+
+// toNomineeParams converts the validated request data, its date of birth and nominee IDs are known to parse
+func toNomineeParams(data types.NomineeRequestData) (types.NomineeParams, []types.NomineeShare) {
+	dateOfBirth, _ := time.Parse(time.DateOnly, data.DateOfBirth)
+	params := types.NomineeParams{
+		Name:                 data.Name,
+		Relationship:         model.NomineeRelationship(data.Relationship),
+		DateOfBirth:          dateOfBirth,
+		SharePercentage:      data.SharePercentage,
+		GuardianName:         data.GuardianName,
+		GuardianRelationship: data.GuardianRelationship,
+	}
+	return params, toNomineeShares(data.OtherShares)
+}
+
+func toNomineeShares(data []types.NomineeShareRequestData) []types.NomineeShare {
+	shares := make([]types.NomineeShare, 0, len(data))
+	for _, share := range data {
+		shares = append(shares, types.NomineeShare{
+			NomineeID:       uuid.MustParse(share.NomineeID),
+			SharePercentage: share.SharePercentage,
+		})
+	}
+	return shares
+}
+
+// getNomineeID extracts the nominee ID from the URL parameter, on failure the error response is already sent and false is returned
+func getNomineeID(ginCtx *gin.Context) (uuid.UUID, bool) {
+	nomineeID, err := uuid.Parse(ginCtx.Param("nominee_id"))
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid nominee ID",
+		})
+		return uuid.Nil, false
+	}
+	return nomineeID, true
+}
@@ -0,0 +1,279 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/uptrace/bun"
+)
+
+func (c *accountController) CreatePocket(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	account, ok := c.getAccountOfAuthenticatedUser(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.CreatePocketRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	params := types.CreatePocketParams{
+		Name:         payload.Data.Name,
+		TargetAmount: payload.Data.TargetAmount,
+		RoundUpTo:    payload.Data.RoundUpTo,
+	}
+	if payload.Data.TargetDate != "" {
+		targetDate, _ := time.Parse(time.DateOnly, payload.Data.TargetDate)
+		params.TargetDate = &targetDate
+	}
+
+	var pocket *model.Pocket
+	err := database.RunInTransaction(requestCtx, "createPocket", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		pocket, err = c.accountService.CreatePocket(txCtx, tx, account.ID, params)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	pocketDto := types.TransformToPocketDto(pocket, account.Currency)
+	server.SendSuccessResponse(ginCtx, http.StatusCreated, types.CreatePocketResponse{
+		Data: *pocketDto,
+	})
+}
+
+// GetPockets lists every pocket of the account, including the closed ones
+func (c *accountController) GetPockets(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	account, ok := c.getAccountOfAuthenticatedUser(ginCtx)
+	if !ok {
+		return
+	}
+
+	pockets, err := c.accountService.ListPockets(requestCtx, nil, types.PocketListOptions{
+		AccountID: &account.ID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	pocketDtos := types.TransformToPocketDtoList(pockets, account.Currency)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetPocketsResponse{
+		Data: pocketDtos,
+	})
+}
+
+func (c *accountController) UpdatePocket(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	account, ok := c.getAccountOfAuthenticatedUser(ginCtx)
+	if !ok {
+		return
+	}
+
+	pocketID, ok := getPocketID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.UpdatePocketRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	params := types.UpdatePocketParams{
+		Name:         payload.Data.Name,
+		TargetAmount: payload.Data.TargetAmount,
+		RoundUpTo:    payload.Data.RoundUpTo,
+	}
+	if payload.Data.TargetDate != "" {
+		targetDate, _ := time.Parse(time.DateOnly, payload.Data.TargetDate)
+		params.TargetDate = &targetDate
+	}
+
+	var pocket *model.Pocket
+	err := database.RunInTransaction(requestCtx, "updatePocket", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		pocket, err = c.accountService.UpdatePocket(txCtx, tx, account.ID, pocketID, params)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	pocketDto := types.TransformToPocketDto(pocket, account.Currency)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.UpdatePocketResponse{
+		Data: *pocketDto,
+	})
+}
+
+// DepositToPocket moves money from the main balance of the account into the pocket
+func (c *accountController) DepositToPocket(ginCtx *gin.Context) {
+	c.movePocketFunds(ginCtx, "depositToPocket", c.accountService.DepositToPocket)
+}
+
+// WithdrawFromPocket moves money from the pocket back to the main balance of the account
+func (c *accountController) WithdrawFromPocket(ginCtx *gin.Context) {
+	c.movePocketFunds(ginCtx, "withdrawFromPocket", c.accountService.WithdrawFromPocket)
+}
+
+// ClosePocket moves the whole balance of the pocket back to the main balance of the account and closes the pocket
+func (c *accountController) ClosePocket(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	account, ok := c.getAccountOfAuthenticatedUser(ginCtx)
+	if !ok {
+		return
+	}
+
+	pocketID, ok := getPocketID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var pocket *model.Pocket
+	err := database.RunInTransaction(requestCtx, "closePocket", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		pocket, err = c.accountService.ClosePocket(txCtx, tx, account.ID, pocketID)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	pocketDto := types.TransformToPocketDto(pocket, account.Currency)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.ClosePocketResponse{
+		Data: *pocketDto,
+	})
+}
+
+func (c *accountController) GetPocketMovements(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	account, ok := c.getAccountOfAuthenticatedUser(ginCtx)
+	if !ok {
+		return
+	}
+
+	pocketID, ok := getPocketID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var query types.GetPocketMovementsRequestQuery
+	isSuccess := server.BindAndValidateIncomingRequestQuery(ginCtx, &query)
+	if !isSuccess {
+		return
+	}
+
+	pocket, err := c.accountService.GetPocket(requestCtx, nil, types.PocketQueryOptions{
+		ID: &pocketID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// a pocket of another account is reported as not found, so that its existence is not revealed
+	if pocket.AccountID != account.ID {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusNotFound,
+			Message:        "Pocket not found",
+		})
+		return
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = 20
+	}
+
+	pocketMovements, err := c.accountService.ListPocketMovements(requestCtx, nil, types.PocketMovementListOptions{
+		PocketID: &pocket.ID,
+		Limit:    limit,
+		Offset:   query.Offset,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	pocketMovementDtos := types.TransformToPocketMovementDtoList(pocketMovements)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetPocketMovementsResponse{
+		Data: pocketMovementDtos,
+	})
+}
+
+// movePocketFunds handles both directions of moving money between the main balance of the account and one of its pockets
+func (c *accountController) movePocketFunds(ginCtx *gin.Context, transactionName string, move func(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, pocketID uuid.UUID, amount int64) (*model.Pocket, error)) {
+	requestCtx := ginCtx.Request.Context()
+
+	account, ok := c.getAccountOfAuthenticatedUser(ginCtx)
+	if !ok {
+		return
+	}
+
+	pocketID, ok := getPocketID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.MovePocketFundsRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	var pocket *model.Pocket
+	err := database.RunInTransaction(requestCtx, transactionName, c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		pocket, err = move(txCtx, tx, account.ID, pocketID, payload.Data.Amount)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	pocketDto := types.TransformToPocketDto(pocket, account.Currency)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.MovePocketFundsResponse{
+		Data: *pocketDto,
+	})
+}
+
+// getPocketID extracts the pocket ID from the URL parameter, on failure the error response is already sent and false is returned
+func getPocketID(ginCtx *gin.Context) (uuid.UUID, bool) {
+	pocketID, err := uuid.Parse(ginCtx.Param("pocket_id"))
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid pocket ID",
+		})
+		return uuid.Nil, false
+	}
+	return pocketID, true
+}
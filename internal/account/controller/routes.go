@@ -1,19 +1,69 @@
 package controller
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/skamranahmed/go-bank/cmd/middleware"
+	"github.com/skamranahmed/go-bank/config"
 	accountService "github.com/skamranahmed/go-bank/internal/account/service"
 	authenticationService "github.com/skamranahmed/go-bank/internal/authentication/service"
+	feeService "github.com/skamranahmed/go-bank/internal/fee/service"
+	transferService "github.com/skamranahmed/go-bank/internal/transfer/service"
+	userService "github.com/skamranahmed/go-bank/internal/user/service"
+	"github.com/skamranahmed/go-bank/pkg/cache"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/uptrace/bun"
 )
 
 type Dependency struct {
+	Db                    *bun.DB
 	AuthenticationService authenticationService.AuthenticationService
 	AccountService        accountService.AccountService
+	FeeService            feeService.FeeService
+	UserService           userService.UserService
+	TransferService       transferService.TransferService
+	TaskEnqueuer          tasksHelper.TaskEnqueuer
+	CacheClient           cache.CacheClient
 }
 
 func Register(router *gin.Engine, dependency Dependency) {
 	accountController := newAccountController(dependency)
+	router.POST("/v1/accounts", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), accountController.OpenAccount)
 	router.GET("/v1/accounts", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), accountController.GetAccounts)
 	router.GET("/v1/accounts/:account_id", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), accountController.GetAccountByID)
+	router.GET("/v1/accounts/:account_id/transactions", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), accountController.GetTransactions)
+	router.POST("/v1/accounts/:account_id/statements", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), accountController.RequestStatement)
+	router.GET("/v1/accounts/:account_id/statements/camt053", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), accountController.GetEndOfDayStatement)
+
+	// joint accounts are held by their primary holder along with the users who accepted their invitation
+	router.POST("/v1/accounts/:account_id/holders", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), accountController.InviteAccountHolder)
+	router.GET("/v1/accounts/:account_id/holders", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), accountController.GetAccountHolders)
+	router.DELETE("/v1/accounts/:account_id/holders/:account_holder_id", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), accountController.RemoveAccountHolder)
+	router.PUT("/v1/accounts/:account_id/mandate", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), accountController.UpdateMandate)
+	router.GET("/v1/account-holder-invitations", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), accountController.GetAccountHolderInvitations)
+	router.POST("/v1/account-holder-invitations/:account_holder_id/accept", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), accountController.AcceptAccountHolderInvitation)
+	router.POST("/v1/account-holder-invitations/:account_holder_id/decline", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), accountController.DeclineAccountHolderInvitation)
+
+	// pockets earmark part of the balance of a savings account for a goal
+	router.POST("/v1/accounts/:account_id/pockets", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), accountController.CreatePocket)
+	router.GET("/v1/accounts/:account_id/pockets", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), accountController.GetPockets)
+	router.PATCH("/v1/accounts/:account_id/pockets/:pocket_id", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), accountController.UpdatePocket)
+	router.DELETE("/v1/accounts/:account_id/pockets/:pocket_id", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), accountController.ClosePocket)
+	router.POST("/v1/accounts/:account_id/pockets/:pocket_id/deposit", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), accountController.DepositToPocket)
+	router.POST("/v1/accounts/:account_id/pockets/:pocket_id/withdraw", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), accountController.WithdrawFromPocket)
+	router.GET("/v1/accounts/:account_id/pockets/:pocket_id/movements", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), accountController.GetPocketMovements)
+
+	// nominees are paid the balance of the account on the death of its holders, changing them needs a step-up token
+	router.GET("/v1/accounts/:account_id/nominees", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), accountController.GetNominees)
+	router.POST("/v1/accounts/:account_id/nominees", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.StepUpMiddleware(dependency.AuthenticationService), accountController.AddNominee)
+	router.PUT("/v1/accounts/:account_id/nominees/:nominee_id", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.StepUpMiddleware(dependency.AuthenticationService), accountController.UpdateNominee)
+	router.DELETE("/v1/accounts/:account_id/nominees/:nominee_id", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.StepUpMiddleware(dependency.AuthenticationService), accountController.RemoveNominee)
+
+	// the holder names of accounts can be enumerated through name enquiries, so they are rate limited per user
+	nameEnquiryConfig := config.GetNameEnquiryConfig()
+	router.GET("/v1/name-enquiry", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.RateLimitMiddleware(dependency.CacheClient, "name_enquiry", nameEnquiryConfig.MaxRequestsPerWindow, time.Duration(nameEnquiryConfig.WindowInSeconds)*time.Second), accountController.NameEnquiry)
+
+	// admin routes
+	router.PUT("/v1/admin/accounts/:account_id/overdraft-limit", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.AdminMiddleware(dependency.UserService), accountController.UpdateOverdraftLimit)
 }
@@ -5,32 +5,112 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/skamranahmed/go-bank/pkg/money"
 	"github.com/uptrace/bun"
 )
 
 type Account struct {
 	bun.BaseModel `bun:"table:accounts"`
 
-	// ID is used as customer-facing account identifier, can also be called account number. It must be 10-15 digits.
+	// ID is used as customer-facing account identifier, can also be called account number
+	// It is 16 digits long: a product code, a random body and a Luhn check digit, see the "accountnumber" package
+	// Accounts opened before check digits were introduced keep their legacy number of 10-15 digits
 	ID int64 `bun:"id,pk,notnull"`
 
 	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
 	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
 
-	// foreign key to "users" table
-	UserID uuid.UUID   `bun:"user_id,notnull,type:uuid,unique:accounts_user_id_type_unique"`
+	// foreign key to "users" table, the primary holder of the account
+	// A joint account has its other holders in the "account_holders" table, see IsAccountHolder of the account service
+	UserID uuid.UUID   `bun:"user_id,notnull,type:uuid"`
 	User   *model.User `bun:"rel:belongs-to,join:user_id=id"`
 
+	// Currency is the ISO 4217 code of the currency the account is held in, it never changes once the account is opened
+	// Every amount of the account and of its transactions is stored in the smallest unit of this currency
+	Currency money.Currency `bun:"currency,notnull,type:varchar(3),default:'INR'"`
+
+	// BranchCode is the 6 character code of the branch the account is held at, it is part of the IFSC and the IBAN of the account
+	BranchCode string `bun:"branch_code,notnull,type:varchar(6),default:'000001'"`
+
 	// Balance is stored in the smallest currency unit (paise for INR)
+	// It can go below zero for accounts with a sanctioned overdraft limit, but never below -OverdraftLimit
 	Balance int64 `bun:"balance,notnull,default:0"`
 
-	// Type of bank account: SAVINGS_ACCOUNT, CURRENT_ACCOUNT
-	Type AccountType `bun:"type,notnull,unique:accounts_user_id_type_unique,default:'SAVINGS_ACCOUNT'"`
+	// OverdraftLimit is the sanctioned overdraft limit, stored in the smallest currency unit (paise for INR)
+	// Only CURRENT_ACCOUNT can have a non-zero overdraft limit
+	OverdraftLimit int64 `bun:"overdraft_limit,notnull,default:0"`
+
+	// HeldAmount is blocked from being debited, it is owed back for a transfer reversal the balance could not cover, see the "transfer_reversals" table
+	// Stored in the smallest currency unit (paise for INR)
+	HeldAmount int64 `bun:"held_amount,notnull,default:0"`
+
+	// PocketedAmount is the total balance of the pockets of the account, see the "pockets" table
+	// It is part of the balance but blocked from being debited until it is moved back to the main balance
+	// Stored in the smallest currency unit (paise for INR)
+	PocketedAmount int64 `bun:"pocketed_amount,notnull,default:0"`
+
+	// Mandate is how the holders of a joint account operate it: EITHER_OR_SURVIVOR, JOINTLY
+	// Under JOINTLY, a debit above the JointApprovalThreshold needs the approval of every holder, stored in the smallest currency unit
+	Mandate                AccountMandate `bun:"mandate,notnull,default:'EITHER_OR_SURVIVOR'"`
+	JointApprovalThreshold int64          `bun:"joint_approval_threshold,notnull,default:0"`
+
+	// Type of bank account: SAVINGS_ACCOUNT, CURRENT_ACCOUNT, FIXED_DEPOSIT, RECURRING_DEPOSIT
+	// A user can have at most one SAVINGS_ACCOUNT and one CURRENT_ACCOUNT, but any number of deposit accounts
+	Type AccountType `bun:"type,notnull,default:'SAVINGS_ACCOUNT'"`
 }
 
+type AccountMandate string
+
+const (
+	MandateEitherOrSurvivor AccountMandate = "EITHER_OR_SURVIVOR" // any holder can operate the account alone
+	MandateJointly          AccountMandate = "JOINTLY"            // debits above the joint approval threshold need the approval of every holder
+)
+
 type AccountType string
 
 const (
 	SavingsAccount AccountType = "SAVINGS_ACCOUNT"
 	CurrentAccount AccountType = "CURRENT_ACCOUNT"
+	FixedDeposit   AccountType = "FIXED_DEPOSIT" // holds the funds of a single fixed deposit, see the "fixed_deposits" table
+
+	RecurringDeposit AccountType = "RECURRING_DEPOSIT" // holds the installments of a single recurring deposit, see the "recurring_deposits" table
 )
+
+// productCodes maps every account type to the 2 digit product code its account numbers start with
+var productCodes = map[AccountType]int{
+	SavingsAccount:   10,
+	CurrentAccount:   11,
+	FixedDeposit:     20,
+	RecurringDeposit: 21,
+}
+
+// ProductCode returns the 2 digit product code account numbers of this type start with
+func (t AccountType) ProductCode() int {
+	return productCodes[t]
+}
+
+// AllowsTransfers reports whether customers can transfer money to or from accounts of this type directly
+// Deposit accounts are funded and paid out only by the deposit product itself
+func (t AccountType) AllowsTransfers() bool {
+	return t == SavingsAccount || t == CurrentAccount
+}
+
+// Money returns the amount in the currency of the account
+func (a *Account) Money(amount int64) money.Money {
+	return money.New(amount, a.Currency)
+}
+
+// AvailableBalance returns the amount that can be debited from the account, including the sanctioned overdraft limit and excluding the held and pocketed amounts
+func (a *Account) AvailableBalance() int64 {
+	return a.Balance + a.OverdraftLimit - a.HeldAmount - a.PocketedAmount
+}
+
+// OwnFunds returns the part of the main balance that is the account holder's own money, the available balance without the overdraft limit
+func (a *Account) OwnFunds() int64 {
+	return a.Balance - a.HeldAmount - a.PocketedAmount
+}
+
+// RequiresJointApproval reports whether a debit of the amount needs the approval of every holder of the account, once it has more than one
+func (a *Account) RequiresJointApproval(amount int64) bool {
+	return a.Mandate == MandateJointly && amount > a.JointApprovalThreshold
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/uptrace/bun"
+)
+
+/*
+AccountHolder is a holder of a joint account other than its primary holder, the user the account was opened by
+
+A user becomes a holder by accepting the invitation of the primary holder, from then on
+they can operate the account as its mandate allows until they leave or are removed.
+*/
+type AccountHolder struct {
+	bun.BaseModel `bun:"table:account_holders"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "accounts" table
+	AccountID int64    `bun:"account_id,notnull"`
+	Account   *Account `bun:"rel:belongs-to,join:account_id=id"`
+
+	// foreign keys to "users" table, the invited holder and the primary holder who invited them
+	UserID          uuid.UUID   `bun:"user_id,notnull,type:uuid"`
+	User            *model.User `bun:"rel:belongs-to,join:user_id=id"`
+	InvitedByUserID uuid.UUID   `bun:"invited_by_user_id,notnull,type:uuid"`
+
+	Status      AccountHolderStatus `bun:"status,notnull,default:'INVITED'"`
+	RespondedAt *time.Time          `bun:"responded_at"`
+	RemovedAt   *time.Time          `bun:"removed_at"`
+}
+
+type AccountHolderStatus string
+
+const (
+	AccountHolderInvited  AccountHolderStatus = "INVITED"
+	AccountHolderActive   AccountHolderStatus = "ACTIVE"   // accepted the invitation
+	AccountHolderDeclined AccountHolderStatus = "DECLINED" // declined the invitation, they can be invited again
+	AccountHolderRemoved  AccountHolderStatus = "REMOVED"  // left the account or was removed by the primary holder, or the invitation was withdrawn
+)
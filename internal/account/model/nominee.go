@@ -0,0 +1,64 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// age below which a nominee is a minor, the share of a minor is received on their behalf by their guardian
+const nomineeAgeOfMajority int = 18
+
+/*
+Nominee is a person the balance of an account is paid out to on the death of its holders
+
+An account can have several nominees, the balance is split between them by their share percentages,
+which always add up to 100 across the active nominees of the account.
+*/
+type Nominee struct {
+	bun.BaseModel `bun:"table:nominees"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "accounts" table
+	AccountID int64    `bun:"account_id,notnull"`
+	Account   *Account `bun:"rel:belongs-to,join:account_id=id"`
+
+	Name            string              `bun:"name,notnull"`
+	Relationship    NomineeRelationship `bun:"relationship,notnull"`
+	DateOfBirth     time.Time           `bun:"date_of_birth,notnull,type:date"`
+	SharePercentage int                 `bun:"share_percentage,notnull"`
+
+	// the guardian is only set for a minor nominee, GuardianRelationship is their relationship to the nominee
+	GuardianName         *string `bun:"guardian_name"`
+	GuardianRelationship *string `bun:"guardian_relationship"`
+
+	Status    NomineeStatus `bun:"status,notnull,default:'ACTIVE'"`
+	RemovedAt *time.Time    `bun:"removed_at"`
+}
+
+// IsMinorOn reports whether the nominee is below the age of majority on the date
+func (n *Nominee) IsMinorOn(date time.Time) bool {
+	return date.Before(n.DateOfBirth.AddDate(nomineeAgeOfMajority, 0, 0))
+}
+
+// NomineeRelationship is the relationship of the nominee to the primary holder of the account
+type NomineeRelationship string
+
+const (
+	NomineeSpouse  NomineeRelationship = "SPOUSE"
+	NomineeChild   NomineeRelationship = "CHILD"
+	NomineeParent  NomineeRelationship = "PARENT"
+	NomineeSibling NomineeRelationship = "SIBLING"
+	NomineeOther   NomineeRelationship = "OTHER"
+)
+
+type NomineeStatus string
+
+const (
+	NomineeActive  NomineeStatus = "ACTIVE"
+	NomineeRemoved NomineeStatus = "REMOVED"
+)
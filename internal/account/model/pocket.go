@@ -0,0 +1,82 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+/*
+Pocket earmarks part of the balance of a savings account for a goal, eg: "Vacation" or "Emergency"
+
+The money in a pocket never leaves the account, it is still part of the account balance,
+but it is excluded from the available balance until it is moved back to the main balance.
+Moving money between the main balance and a pocket therefore does not create a transaction nor touch the ledger.
+*/
+type Pocket struct {
+	bun.BaseModel `bun:"table:pockets"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "accounts" table, the savings account the pocket earmarks money of
+	AccountID int64    `bun:"account_id,notnull"`
+	Account   *Account `bun:"rel:belongs-to,join:account_id=id"`
+
+	Name string `bun:"name,notnull"`
+
+	// Balance and TargetAmount are stored in the smallest unit of the currency of the account (paise for INR)
+	Balance      int64      `bun:"balance,notnull,default:0"`
+	TargetAmount int64      `bun:"target_amount,notnull"`
+	TargetDate   *time.Time `bun:"target_date,type:date"`
+
+	// RoundUpTo is set when the spare change of the payments made from the account is swept into the pocket,
+	// every payment is rounded up to a multiple of it and the difference moved to the pocket
+	// Stored in the smallest unit of the currency of the account, only one pocket of an account can have it set
+	RoundUpTo *int64 `bun:"round_up_to"`
+
+	Status   PocketStatus `bun:"status,notnull,default:'ACTIVE'"`
+	ClosedAt *time.Time   `bun:"closed_at"`
+}
+
+type PocketStatus string
+
+const (
+	PocketActive PocketStatus = "ACTIVE"
+	PocketClosed PocketStatus = "CLOSED" // its balance was moved back to the main balance
+)
+
+// PocketMovement records money moved between the main balance of an account and one of its pockets
+type PocketMovement struct {
+	bun.BaseModel `bun:"table:pocket_movements"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+
+	// foreign key to "pockets" table
+	PocketID uuid.UUID `bun:"pocket_id,notnull,type:uuid"`
+	Pocket   *Pocket   `bun:"rel:belongs-to,join:pocket_id=id"`
+
+	// Type of movement: DEPOSIT, WITHDRAWAL, ROUND_UP
+	Type PocketMovementType `bun:"type,notnull"`
+
+	// Amount and BalanceAfter are stored in the smallest unit of the currency of the account (paise for INR)
+	// BalanceAfter is the balance of the pocket after the movement
+	Amount       int64 `bun:"amount,notnull"`
+	BalanceAfter int64 `bun:"balance_after,notnull"`
+
+	// foreign key to "transactions" table, the payment whose spare change was swept
+	// Set on a ROUND_UP and on the WITHDRAWAL moving that spare change back when the payment is returned
+	TransactionID *uuid.UUID   `bun:"transaction_id,type:uuid"`
+	Transaction   *Transaction `bun:"rel:belongs-to,join:transaction_id=id"`
+}
+
+type PocketMovementType string
+
+const (
+	PocketDeposit    PocketMovementType = "DEPOSIT"    // moved from the main balance into the pocket by the account holder
+	PocketWithdrawal PocketMovementType = "WITHDRAWAL" // moved from the pocket back to the main balance, including when the pocket is closed or a rounded up payment is returned
+	PocketRoundUp    PocketMovementType = "ROUND_UP"   // spare change of a payment swept into the pocket
+)
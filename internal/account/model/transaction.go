@@ -23,13 +23,95 @@ type Transaction struct {
 	// BalanceAfter is the account balance after this transaction, stored in the smallest currency unit (paise for INR)
 	BalanceAfter int64 `bun:"balance_after,notnull"`
 
-	// Type of transaction: DEBIT, CREDIT
+	// OverdraftLimit is the overdraft limit of the account when the transaction was booked, BalanceAfter never goes below its negative
+	// It is read from the account row when the transaction is inserted, so it does not need to be set
+	OverdraftLimit int64 `bun:"overdraft_limit,notnull,default:0"`
+
+	// ChargeDate is set on an OVERDRAFT_INTEREST, it is the day (UTC) the interest is charged for
+	ChargeDate *time.Time `bun:"charge_date,type:date"`
+
+	// Type of transaction: DEBIT, CREDIT, OVERDRAFT_INTEREST, FEE, FEE_WAIVER, INTEREST_CREDIT, LOAN_DISBURSEMENT, LOAN_REPAYMENT, REVERSAL_DEBIT, REVERSAL_CREDIT, EXTERNAL_TRANSFER, EXTERNAL_TRANSFER_RETURN, INBOUND_TRANSFER
 	Type TransactionType `bun:"type,notnull"`
+
+	// Status of the transaction: COMPLETED, PARTIALLY_REVERSED, REVERSED
+	Status TransactionStatus `bun:"status,notnull,default:'COMPLETED'"`
+
+	// CounterpartTransactionID is set on the CREDIT of a transfer, it is the DEBIT of the sender account the credit was paid by
+	CounterpartTransactionID *uuid.UUID `bun:"counterpart_transaction_id,type:uuid"`
+
+	// ReversedTransactionID is set on a REVERSAL_DEBIT or REVERSAL_CREDIT, it is the transaction of the same account it compensates
+	ReversedTransactionID *uuid.UUID `bun:"reversed_transaction_id,type:uuid"`
+
+	// Description is generated by the bank for the account holder, e.g. "Transfer to ****1234"
+	Description *string `bun:"description"`
+
+	// Narration, ClientReference and Category are attached to a transfer by its sender and stored on both of its transactions
+	Narration       *string              `bun:"narration"`
+	ClientReference *string              `bun:"client_reference"`
+	Category        *TransactionCategory `bun:"category"`
 }
 
 type TransactionType string
 
 const (
-	Debit  TransactionType = "DEBIT"
-	Credit TransactionType = "CREDIT"
+	Debit             TransactionType = "DEBIT"
+	Credit            TransactionType = "CREDIT"
+	OverdraftInterest TransactionType = "OVERDRAFT_INTEREST" // interest charged on the overdrawn balance, debited from the account
+	Fee               TransactionType = "FEE"                // fee (including tax) charged to the account, debited from the account
+	FeeWaiver         TransactionType = "FEE_WAIVER"         // refund of a fee waived by an admin, credited to the account
+	InterestCredit    TransactionType = "INTEREST_CREDIT"    // interest paid by the bank on a deposit, credited to the account
+	LoanDisbursement  TransactionType = "LOAN_DISBURSEMENT"  // amount of an approved loan, credited to the account
+	LoanRepayment     TransactionType = "LOAN_REPAYMENT"     // EMI, prepayment or foreclosure of a loan, debited from the account
+	ReversalDebit     TransactionType = "REVERSAL_DEBIT"     // amount of a reversed transfer taken back from the receiver account
+	ReversalCredit    TransactionType = "REVERSAL_CREDIT"    // amount of a reversed transfer paid back to the sender account
+
+	ExternalTransfer       TransactionType = "EXTERNAL_TRANSFER"        // amount of a transfer to an account at another bank, debited from the account
+	ExternalTransferReturn TransactionType = "EXTERNAL_TRANSFER_RETURN" // amount of an external transfer returned by the beneficiary bank, credited back to the account
+	InboundTransfer        TransactionType = "INBOUND_TRANSFER"         // amount of a payment received from an account at another bank, credited to the account
+)
+
+// debitTransactionTypes holds every transaction type that reduces the balance of the account
+var debitTransactionTypes = map[TransactionType]bool{
+	Debit:             true,
+	OverdraftInterest: true,
+	Fee:               true,
+	LoanRepayment:     true,
+	ReversalDebit:     true,
+	ExternalTransfer:  true,
+}
+
+// DebitTransactionTypes returns every transaction type that reduces the balance of the account
+func DebitTransactionTypes() []TransactionType {
+	transactionTypes := make([]TransactionType, 0, len(debitTransactionTypes))
+	for transactionType := range debitTransactionTypes {
+		transactionTypes = append(transactionTypes, transactionType)
+	}
+	return transactionTypes
+}
+
+// IsDebit reports whether the transaction type reduces the balance of the account
+func (t TransactionType) IsDebit() bool {
+	return debitTransactionTypes[t]
+}
+
+type TransactionCategory string
+
+const (
+	BillPayment TransactionCategory = "BILL_PAYMENT"
+	Education   TransactionCategory = "EDUCATION"
+	Family      TransactionCategory = "FAMILY"
+	Medical     TransactionCategory = "MEDICAL"
+	Rent        TransactionCategory = "RENT"
+	Salary      TransactionCategory = "SALARY"
+	Shopping    TransactionCategory = "SHOPPING"
+	Travel      TransactionCategory = "TRAVEL"
+	Other       TransactionCategory = "OTHER"
+)
+
+type TransactionStatus string
+
+const (
+	TransactionCompleted         TransactionStatus = "COMPLETED"
+	TransactionPartiallyReversed TransactionStatus = "PARTIALLY_REVERSED" // part of the amount is still held on the receiver account, see the "transfer_reversals" table
+	TransactionReversed          TransactionStatus = "REVERSED"
 )
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/skamranahmed/go-bank/cmd/server"
@@ -15,6 +16,9 @@ import (
 	"github.com/uptrace/bun"
 )
 
+// ErrAccountIDAlreadyExists is returned by CreateAccount when the account number is already taken by another account
+var ErrAccountIDAlreadyExists = errors.New("account ID already exists")
+
 type accountRepository struct {
 	db *bun.DB
 }
@@ -25,23 +29,33 @@ func NewAccountRepository(db *bun.DB) AccountRepository {
 	}
 }
 
-func (r *accountRepository) CreateAccount(requestCtx context.Context, dbExecutor bun.IDB, account *model.Account) error {
+func (r *accountRepository) CreateAccount(requestCtx context.Context, dbExecutor bun.IDB, account *model.Account) (*model.Account, error) {
 	if dbExecutor == nil {
 		dbExecutor = r.db
 	}
 
+	/*
+		A conflicting account number is skipped instead of failing the insert, so that the caller can retry with
+		another number without the unique violation aborting the database transaction it is running in
+	*/
 	err := dbExecutor.NewInsert().
 		Model(account).
+		On("CONFLICT (id) DO NOTHING").
+		Returning("*").
 		Scan(requestCtx)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAccountIDAlreadyExists
+		}
+
 		logger.Error(requestCtx, "Error while creating new account for userID: %+v, error: %+v", account.UserID, err)
-		return &server.ApiError{
+		return nil, &server.ApiError{
 			HttpStatusCode: http.StatusInternalServerError,
 			Message:        "We couldn't create your account at the moment. Please try again later.",
 		}
 	}
 
-	return nil
+	return account, nil
 }
 
 func (r *accountRepository) GetAccountsByUserID(requestCtx context.Context, dbExecutor bun.IDB, userID uuid.UUID) ([]model.Account, error) {
@@ -115,6 +129,40 @@ func (r *accountRepository) GetAccount(requestCtx context.Context, dbExecutor bu
 	return &account, nil
 }
 
+func (r *accountRepository) ListAccounts(requestCtx context.Context, dbExecutor bun.IDB, options types.AccountListOptions) ([]model.Account, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var accounts []model.Account
+	query := dbExecutor.NewSelect().Model(&accounts)
+
+	// dynamically construct the query based on which fields are set
+	if options.Type != nil {
+		query = query.Where("type = ?", *options.Type)
+	}
+	if options.BalanceBelow != nil {
+		query = query.Where("balance < ?", *options.BalanceBelow)
+	}
+	if options.AfterID != nil {
+		query = query.Where("id > ?", *options.AfterID)
+	}
+	if options.Limit > 0 {
+		query = query.Limit(options.Limit)
+	}
+
+	err := query.Order("id ASC").Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing accounts with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the accounts at the moment. Please try again later.",
+		}
+	}
+
+	return accounts, nil
+}
+
 func (r *accountRepository) UpdateAccount(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, options types.AccountUpdateOptions) (*model.Account, error) {
 	if dbExecutor == nil {
 		dbExecutor = r.db
@@ -128,6 +176,24 @@ func (r *accountRepository) UpdateAccount(requestCtx context.Context, dbExecutor
 		query = query.Set("balance = ?", *options.NewBalance)
 	}
 
+	if options.NewOverdraftLimit != nil {
+		query = query.Set("overdraft_limit = ?", *options.NewOverdraftLimit)
+	}
+
+	if options.NewHeldAmount != nil {
+		query = query.Set("held_amount = ?", *options.NewHeldAmount)
+	}
+
+	if options.NewPocketedAmount != nil {
+		query = query.Set("pocketed_amount = ?", *options.NewPocketedAmount)
+	}
+	if options.NewMandate != nil {
+		query = query.Set("mandate = ?", *options.NewMandate)
+	}
+	if options.NewJointApprovalThreshold != nil {
+		query = query.Set("joint_approval_threshold = ?", *options.NewJointApprovalThreshold)
+	}
+
 	// always update the updated_at timestamp
 	query = query.Set("updated_at = NOW()").
 		Where("id = ?", accountID).
@@ -150,8 +216,10 @@ func (r *accountRepository) CreateTransactionRecord(requestCtx context.Context,
 		dbExecutor = r.db
 	}
 
+	// the overdraft limit is taken from the account row, which is updated along with its balance in the same database transaction
 	err := dbExecutor.NewInsert().
 		Model(transaction).
+		Value("overdraft_limit", "(SELECT overdraft_limit FROM accounts WHERE id = ?)", transaction.AccountID).
 		Returning("*").
 		Scan(requestCtx)
 	if err != nil {
@@ -164,3 +232,208 @@ func (r *accountRepository) CreateTransactionRecord(requestCtx context.Context,
 
 	return transaction, nil
 }
+
+func (r *accountRepository) GetTransaction(requestCtx context.Context, dbExecutor bun.IDB, options types.TransactionGetOptions) (*model.Transaction, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var transaction model.Transaction
+	query := dbExecutor.NewSelect().Model(&transaction)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+	if options.CounterpartTransactionID != nil {
+		query = query.Where("counterpart_transaction_id = ?", *options.CounterpartTransactionID)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Transaction not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding transaction with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the transaction at the moment. Please try again later.",
+		}
+	}
+
+	return &transaction, nil
+}
+
+func (r *accountRepository) UpdateTransaction(requestCtx context.Context, dbExecutor bun.IDB, transactionID uuid.UUID, options types.TransactionUpdateOptions) (*model.Transaction, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var transaction model.Transaction
+	query := dbExecutor.NewUpdate().Model(&transaction)
+
+	// dynamically construct the update query based on which fields are set
+	if options.NewStatus != nil {
+		query = query.Set("status = ?", *options.NewStatus)
+	}
+
+	query = query.Where("id = ?", transactionID).
+		Returning("*")
+
+	_, err := query.Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating transaction with ID: %s, options: %+v, error: %+v", transactionID, options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the transaction at the moment. Please try again later.",
+		}
+	}
+
+	return &transaction, nil
+}
+
+func (r *accountRepository) ListTransactions(requestCtx context.Context, dbExecutor bun.IDB, options types.TransactionQueryOptions) ([]model.Transaction, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var transactions []model.Transaction
+	query := dbExecutor.NewSelect().Model(&transactions)
+
+	// dynamically construct the query based on which fields are set
+	if options.AccountID != nil {
+		query = query.Where("account_id = ?", *options.AccountID)
+	}
+	if len(options.Types) > 0 {
+		query = query.Where("type IN (?)", bun.In(options.Types))
+	}
+	if options.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *options.CreatedAfter)
+	}
+	if options.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *options.CreatedBefore)
+	}
+	if options.ChargeDate != nil {
+		query = query.Where("charge_date = ?", options.ChargeDate.Format(time.DateOnly))
+	}
+	if len(options.ExcludeCounterpartAccountTypes) > 0 {
+		query = query.Where(
+			"NOT EXISTS (SELECT 1 FROM transactions AS counterpart JOIN accounts ON accounts.id = counterpart.account_id WHERE counterpart.counterpart_transaction_id = transaction.id AND accounts.type IN (?))",
+			bun.In(options.ExcludeCounterpartAccountTypes),
+		)
+	}
+	if options.Limit > 0 {
+		query = query.Limit(options.Limit)
+	}
+	if options.Offset > 0 {
+		query = query.Offset(options.Offset)
+	}
+
+	// newest first, transactions created in the same database transaction share created_at so the ID keeps their order stable across pages
+	err := query.Order("created_at DESC", "id DESC").Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing transactions with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch your transactions at the moment. Please try again later.",
+		}
+	}
+
+	return transactions, nil
+}
+
+func (r *accountRepository) CountTransactions(requestCtx context.Context, dbExecutor bun.IDB, options types.TransactionQueryOptions) (int, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	query := dbExecutor.NewSelect().Model((*model.Transaction)(nil))
+
+	// dynamically construct the query based on which fields are set
+	if options.AccountID != nil {
+		query = query.Where("account_id = ?", *options.AccountID)
+	}
+	if len(options.Types) > 0 {
+		query = query.Where("type IN (?)", bun.In(options.Types))
+	}
+	if options.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *options.CreatedAfter)
+	}
+	if options.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *options.CreatedBefore)
+	}
+	if options.ChargeDate != nil {
+		query = query.Where("charge_date = ?", options.ChargeDate.Format(time.DateOnly))
+	}
+	if len(options.ExcludeCounterpartAccountTypes) > 0 {
+		query = query.Where(
+			"NOT EXISTS (SELECT 1 FROM transactions AS counterpart JOIN accounts ON accounts.id = counterpart.account_id WHERE counterpart.counterpart_transaction_id = transaction.id AND accounts.type IN (?))",
+			bun.In(options.ExcludeCounterpartAccountTypes),
+		)
+	}
+
+	count, err := query.Count(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while counting transactions with options: %+v, error: %+v", options, err)
+		return 0, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch your transactions at the moment. Please try again later.",
+		}
+	}
+
+	return count, nil
+}
+
+func (r *accountRepository) SumTransactions(requestCtx context.Context, dbExecutor bun.IDB, options types.TransactionQueryOptions) (int64, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	query := dbExecutor.NewSelect().
+		Model((*model.Transaction)(nil)).
+		ColumnExpr("COALESCE(SUM(amount), 0)")
+
+	// dynamically construct the query based on which fields are set
+	if options.AccountID != nil {
+		query = query.Where("account_id = ?", *options.AccountID)
+	}
+	if len(options.Types) > 0 {
+		query = query.Where("type IN (?)", bun.In(options.Types))
+	}
+	if options.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *options.CreatedAfter)
+	}
+	if options.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *options.CreatedBefore)
+	}
+	if options.ChargeDate != nil {
+		query = query.Where("charge_date = ?", options.ChargeDate.Format(time.DateOnly))
+	}
+	if len(options.ExcludeCounterpartAccountTypes) > 0 {
+		query = query.Where(
+			"NOT EXISTS (SELECT 1 FROM transactions AS counterpart JOIN accounts ON accounts.id = counterpart.account_id WHERE counterpart.counterpart_transaction_id = transaction.id AND accounts.type IN (?))",
+			bun.In(options.ExcludeCounterpartAccountTypes),
+		)
+	}
+
+	var total int64
+	err := query.Scan(requestCtx, &total)
+	if err != nil {
+		logger.Error(requestCtx, "Error while summing transactions with options: %+v, error: %+v", options, err)
+		return 0, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch your transactions at the moment. Please try again later.",
+		}
+	}
+
+	return total, nil
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+// GetAccountsOfHolder returns the accounts the user is the primary holder of, followed by the joint accounts they are an active holder of
+func (r *accountRepository) GetAccountsOfHolder(requestCtx context.Context, dbExecutor bun.IDB, userID uuid.UUID) ([]model.Account, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	activeHolderAccountIDs := dbExecutor.NewSelect().
+		Model((*model.AccountHolder)(nil)).
+		Column("account_id").
+		Where("user_id = ?", userID).
+		Where("status = ?", model.AccountHolderActive)
+
+	var accounts []model.Account
+	err := dbExecutor.NewSelect().
+		Model(&accounts).
+		WhereGroup(" AND ", func(query *bun.SelectQuery) *bun.SelectQuery {
+			return query.
+				Where("user_id = ?", userID).
+				WhereOr("id IN (?)", activeHolderAccountIDs)
+		}).
+		OrderExpr("user_id = ? DESC", userID).
+		Order("created_at ASC").
+		Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while fetching accounts of holder with userID: %+v, error: %+v", userID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch your accounts at the moment. Please try again later.",
+		}
+	}
+
+	return accounts, nil
+}
+
+func (r *accountRepository) CreateAccountHolder(requestCtx context.Context, dbExecutor bun.IDB, accountHolder *model.AccountHolder) (*model.AccountHolder, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewInsert().
+		Model(accountHolder).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating account holder for accountID: %d, error: %+v", accountHolder.AccountID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't invite the holder at the moment. Please try again later.",
+		}
+	}
+
+	return accountHolder, nil
+}
+
+func (r *accountRepository) GetAccountHolder(requestCtx context.Context, dbExecutor bun.IDB, options types.AccountHolderQueryOptions) (*model.AccountHolder, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var accountHolder model.AccountHolder
+	query := dbExecutor.NewSelect().Model(&accountHolder)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+	if options.AccountID != nil {
+		query = query.Where("account_id = ?", *options.AccountID)
+	}
+	if options.UserID != nil {
+		query = query.Where("user_id = ?", *options.UserID)
+	}
+	if len(options.Statuses) > 0 {
+		query = query.Where("status IN (?)", bun.In(options.Statuses))
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Account holder not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding account holder with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the account holder at the moment. Please try again later.",
+		}
+	}
+
+	return &accountHolder, nil
+}
+
+func (r *accountRepository) ListAccountHolders(requestCtx context.Context, dbExecutor bun.IDB, options types.AccountHolderListOptions) ([]model.AccountHolder, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var accountHolders []model.AccountHolder
+	query := dbExecutor.NewSelect().Model(&accountHolders)
+
+	// dynamically construct the query based on which fields are set
+	if options.AccountID != nil {
+		query = query.Where("account_id = ?", *options.AccountID)
+	}
+	if options.UserID != nil {
+		query = query.Where("user_id = ?", *options.UserID)
+	}
+	if len(options.Statuses) > 0 {
+		query = query.Where("status IN (?)", bun.In(options.Statuses))
+	}
+
+	err := query.Order("created_at ASC").Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing account holders with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the account holders at the moment. Please try again later.",
+		}
+	}
+
+	return accountHolders, nil
+}
+
+func (r *accountRepository) UpdateAccountHolder(requestCtx context.Context, dbExecutor bun.IDB, accountHolderID uuid.UUID, options types.AccountHolderUpdateOptions) (*model.AccountHolder, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var accountHolder model.AccountHolder
+	query := dbExecutor.NewUpdate().Model(&accountHolder)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewStatus != nil {
+		query = query.Set("status = ?", *options.NewStatus)
+	}
+	if options.NewRespondedAt != nil {
+		query = query.Set("responded_at = ?", *options.NewRespondedAt)
+	}
+	if options.NewRemovedAt != nil {
+		query = query.Set("removed_at = ?", *options.NewRemovedAt)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", accountHolderID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating account holder with ID: %s, error: %+v", accountHolderID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the account holder at the moment. Please try again later.",
+		}
+	}
+
+	return &accountHolder, nil
+}
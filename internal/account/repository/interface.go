@@ -10,9 +10,32 @@ import (
 )
 
 type AccountRepository interface {
-	CreateAccount(requestCtx context.Context, dbExecutor bun.IDB, account *model.Account) error
+	CreateAccount(requestCtx context.Context, dbExecutor bun.IDB, account *model.Account) (*model.Account, error)
 	GetAccountsByUserID(requestCtx context.Context, dbExecutor bun.IDB, userID uuid.UUID) ([]model.Account, error)
 	GetAccount(requestCtx context.Context, dbExecutor bun.IDB, options types.AccountQueryOptions) (*model.Account, error)
+	ListAccounts(requestCtx context.Context, dbExecutor bun.IDB, options types.AccountListOptions) ([]model.Account, error)
 	UpdateAccount(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, options types.AccountUpdateOptions) (*model.Account, error)
 	CreateTransactionRecord(requestCtx context.Context, dbExecutor bun.IDB, transaction *model.Transaction) (*model.Transaction, error)
+	GetTransaction(requestCtx context.Context, dbExecutor bun.IDB, options types.TransactionGetOptions) (*model.Transaction, error)
+	UpdateTransaction(requestCtx context.Context, dbExecutor bun.IDB, transactionID uuid.UUID, options types.TransactionUpdateOptions) (*model.Transaction, error)
+	ListTransactions(requestCtx context.Context, dbExecutor bun.IDB, options types.TransactionQueryOptions) ([]model.Transaction, error)
+	CountTransactions(requestCtx context.Context, dbExecutor bun.IDB, options types.TransactionQueryOptions) (int, error)
+	SumTransactions(requestCtx context.Context, dbExecutor bun.IDB, options types.TransactionQueryOptions) (int64, error)
+
+	CreatePocket(requestCtx context.Context, dbExecutor bun.IDB, pocket *model.Pocket) (*model.Pocket, error)
+	GetPocket(requestCtx context.Context, dbExecutor bun.IDB, options types.PocketQueryOptions) (*model.Pocket, error)
+	ListPockets(requestCtx context.Context, dbExecutor bun.IDB, options types.PocketListOptions) ([]model.Pocket, error)
+	UpdatePocket(requestCtx context.Context, dbExecutor bun.IDB, pocketID uuid.UUID, options types.PocketUpdateOptions) (*model.Pocket, error)
+	CreatePocketMovement(requestCtx context.Context, dbExecutor bun.IDB, pocketMovement *model.PocketMovement) (*model.PocketMovement, error)
+	ListPocketMovements(requestCtx context.Context, dbExecutor bun.IDB, options types.PocketMovementListOptions) ([]model.PocketMovement, error)
+
+	GetAccountsOfHolder(requestCtx context.Context, dbExecutor bun.IDB, userID uuid.UUID) ([]model.Account, error)
+	CreateAccountHolder(requestCtx context.Context, dbExecutor bun.IDB, accountHolder *model.AccountHolder) (*model.AccountHolder, error)
+	GetAccountHolder(requestCtx context.Context, dbExecutor bun.IDB, options types.AccountHolderQueryOptions) (*model.AccountHolder, error)
+	ListAccountHolders(requestCtx context.Context, dbExecutor bun.IDB, options types.AccountHolderListOptions) ([]model.AccountHolder, error)
+	UpdateAccountHolder(requestCtx context.Context, dbExecutor bun.IDB, accountHolderID uuid.UUID, options types.AccountHolderUpdateOptions) (*model.AccountHolder, error)
+	CreateNominee(requestCtx context.Context, dbExecutor bun.IDB, nominee *model.Nominee) (*model.Nominee, error)
+	GetNominee(requestCtx context.Context, dbExecutor bun.IDB, options types.NomineeQueryOptions) (*model.Nominee, error)
+	ListNominees(requestCtx context.Context, dbExecutor bun.IDB, options types.NomineeListOptions) ([]model.Nominee, error)
+	UpdateNominee(requestCtx context.Context, dbExecutor bun.IDB, nomineeID uuid.UUID, options types.NomineeUpdateOptions) (*model.Nominee, error)
 }
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+func (r *accountRepository) CreateNominee(requestCtx context.Context, dbExecutor bun.IDB, nominee *model.Nominee) (*model.Nominee, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewInsert().
+		Model(nominee).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating nominee for accountID: %d, error: %+v", nominee.AccountID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't add the nominee at the moment. Please try again later.",
+		}
+	}
+
+	return nominee, nil
+}
+
+func (r *accountRepository) GetNominee(requestCtx context.Context, dbExecutor bun.IDB, options types.NomineeQueryOptions) (*model.Nominee, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var nominee model.Nominee
+	query := dbExecutor.NewSelect().Model(&nominee)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+	if options.AccountID != nil {
+		query = query.Where("account_id = ?", *options.AccountID)
+	}
+	if options.Status != nil {
+		query = query.Where("status = ?", *options.Status)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Nominee not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding nominee with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the nominee at the moment. Please try again later.",
+		}
+	}
+
+	return &nominee, nil
+}
+
+func (r *accountRepository) ListNominees(requestCtx context.Context, dbExecutor bun.IDB, options types.NomineeListOptions) ([]model.Nominee, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var nominees []model.Nominee
+	query := dbExecutor.NewSelect().Model(&nominees)
+
+	// dynamically construct the query based on which fields are set
+	if options.AccountID != nil {
+		query = query.Where("account_id = ?", *options.AccountID)
+	}
+	if options.Status != nil {
+		query = query.Where("status = ?", *options.Status)
+	}
+
+	err := query.Order("created_at ASC").Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing nominees with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the nominees at the moment. Please try again later.",
+		}
+	}
+
+	return nominees, nil
+}
+
+func (r *accountRepository) UpdateNominee(requestCtx context.Context, dbExecutor bun.IDB, nomineeID uuid.UUID, options types.NomineeUpdateOptions) (*model.Nominee, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var nominee model.Nominee
+	query := dbExecutor.NewUpdate().Model(&nominee)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewName != nil {
+		query = query.Set("name = ?", *options.NewName)
+	}
+	if options.NewRelationship != nil {
+		query = query.Set("relationship = ?", *options.NewRelationship)
+	}
+	if options.NewDateOfBirth != nil {
+		query = query.Set("date_of_birth = ?", *options.NewDateOfBirth)
+	}
+	if options.NewSharePercentage != nil {
+		query = query.Set("share_percentage = ?", *options.NewSharePercentage)
+	}
+	if options.NewGuardianName != nil {
+		query = query.Set("guardian_name = NULLIF(?, '')", *options.NewGuardianName)
+	}
+	if options.NewGuardianRelationship != nil {
+		query = query.Set("guardian_relationship = NULLIF(?, '')", *options.NewGuardianRelationship)
+	}
+	if options.NewStatus != nil {
+		query = query.Set("status = ?", *options.NewStatus)
+	}
+	if options.NewRemovedAt != nil {
+		query = query.Set("removed_at = ?", *options.NewRemovedAt)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", nomineeID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating nominee with ID: %s, error: %+v", nomineeID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the nominee at the moment. Please try again later.",
+		}
+	}
+
+	return &nominee, nil
+}
@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+func (r *accountRepository) CreatePocket(requestCtx context.Context, dbExecutor bun.IDB, pocket *model.Pocket) (*model.Pocket, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewInsert().
+		Model(pocket).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating pocket for accountID: %d, error: %+v", pocket.AccountID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't create the pocket at the moment. Please try again later.",
+		}
+	}
+
+	return pocket, nil
+}
+
+func (r *accountRepository) GetPocket(requestCtx context.Context, dbExecutor bun.IDB, options types.PocketQueryOptions) (*model.Pocket, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var pocket model.Pocket
+	query := dbExecutor.NewSelect().Model(&pocket)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Pocket not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding pocket with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the pocket at the moment. Please try again later.",
+		}
+	}
+
+	return &pocket, nil
+}
+
+func (r *accountRepository) ListPockets(requestCtx context.Context, dbExecutor bun.IDB, options types.PocketListOptions) ([]model.Pocket, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var pockets []model.Pocket
+	query := dbExecutor.NewSelect().Model(&pockets)
+
+	// dynamically construct the query based on which fields are set
+	if options.AccountID != nil {
+		query = query.Where("account_id = ?", *options.AccountID)
+	}
+	if options.Status != nil {
+		query = query.Where("status = ?", *options.Status)
+	}
+	if options.RoundUpEnabled {
+		query = query.Where("round_up_to IS NOT NULL")
+	}
+
+	err := query.Order("created_at ASC", "id ASC").Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing pockets with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the pockets at the moment. Please try again later.",
+		}
+	}
+
+	return pockets, nil
+}
+
+func (r *accountRepository) UpdatePocket(requestCtx context.Context, dbExecutor bun.IDB, pocketID uuid.UUID, options types.PocketUpdateOptions) (*model.Pocket, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var pocket model.Pocket
+	query := dbExecutor.NewUpdate().Model(&pocket)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewName != nil {
+		query = query.Set("name = ?", *options.NewName)
+	}
+	if options.NewTargetAmount != nil {
+		query = query.Set("target_amount = ?", *options.NewTargetAmount)
+	}
+	if options.NewTargetDate != nil {
+		query = query.Set("target_date = ?", *options.NewTargetDate)
+	}
+	if options.NewBalance != nil {
+		query = query.Set("balance = ?", *options.NewBalance)
+	}
+	if options.NewStatus != nil {
+		query = query.Set("status = ?", *options.NewStatus)
+	}
+	if options.NewClosedAt != nil {
+		query = query.Set("closed_at = ?", *options.NewClosedAt)
+	}
+	if options.NewRoundUpTo != nil {
+		if *options.NewRoundUpTo == 0 {
+			query = query.Set("round_up_to = NULL")
+		} else {
+			query = query.Set("round_up_to = ?", *options.NewRoundUpTo)
+		}
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", pocketID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating pocket with ID: %s, error: %+v", pocketID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the pocket at the moment. Please try again later.",
+		}
+	}
+
+	return &pocket, nil
+}
+
+func (r *accountRepository) CreatePocketMovement(requestCtx context.Context, dbExecutor bun.IDB, pocketMovement *model.PocketMovement) (*model.PocketMovement, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewInsert().
+		Model(pocketMovement).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating pocket movement for pocketID: %s, error: %+v", pocketMovement.PocketID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't move the money at the moment. Please try again later.",
+		}
+	}
+
+	return pocketMovement, nil
+}
+
+func (r *accountRepository) ListPocketMovements(requestCtx context.Context, dbExecutor bun.IDB, options types.PocketMovementListOptions) ([]model.PocketMovement, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var pocketMovements []model.PocketMovement
+	query := dbExecutor.NewSelect().Model(&pocketMovements)
+
+	// dynamically construct the query based on which fields are set
+	if options.PocketID != nil {
+		query = query.Where("pocket_id = ?", *options.PocketID)
+	}
+	if options.Type != nil {
+		query = query.Where("type = ?", *options.Type)
+	}
+	if options.TransactionID != nil {
+		query = query.Where("transaction_id = ?", *options.TransactionID)
+	}
+	if options.Limit > 0 {
+		query = query.Limit(options.Limit)
+	}
+	if options.Offset > 0 {
+		query = query.Offset(options.Offset)
+	}
+
+	err := query.Order("created_at DESC", "id DESC").Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing pocket movements with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the pocket movements at the moment. Please try again later.",
+		}
+	}
+
+	return pocketMovements, nil
+}
@@ -2,40 +2,108 @@ package service
 
 import (
 	"context"
-	"crypto/rand"
-	"math/big"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/config"
 	"github.com/skamranahmed/go-bank/internal/account/model"
 	"github.com/skamranahmed/go-bank/internal/account/repository"
 	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/accountnumber"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/skamranahmed/go-bank/pkg/money"
 	"github.com/uptrace/bun"
 )
 
+// number of account numbers tried before giving up on creating an account
+const maxAccountIDGenerationAttempts int = 5
+
 type accountService struct {
 	db                *bun.DB
 	accountRepository repository.AccountRepository
+	pocketConfig      config.PocketConfig
 }
 
-func NewAccountService(db *bun.DB, accountRepository repository.AccountRepository) AccountService {
+func NewAccountService(db *bun.DB, accountRepository repository.AccountRepository, pocketConfig config.PocketConfig) AccountService {
 	return &accountService{
 		db:                db,
 		accountRepository: accountRepository,
+		pocketConfig:      pocketConfig,
+	}
+}
+
+func (s *accountService) CreateAccount(requestCtx context.Context, dbExecutor bun.IDB, userID uuid.UUID, accountType model.AccountType, currency money.Currency) (*model.Account, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	// account numbers are random, so a new one is generated for as long as the generated number is already taken
+	for attempt := 1; attempt <= maxAccountIDGenerationAttempts; attempt++ {
+		accountID, err := accountnumber.Generate(accountType.ProductCode())
+		if err != nil {
+			logger.Error(requestCtx, "Error while generating account number for userID: %+v, error: %+v", userID, err)
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusInternalServerError,
+				Message:        "We couldn't create your account at the moment. Please try again later.",
+			}
+		}
+
+		account, err := s.accountRepository.CreateAccount(requestCtx, dbExecutor, &model.Account{
+			ID:         accountID,
+			UserID:     userID,
+			Currency:   currency,
+			BranchCode: config.GetBankConfig().BranchCode,
+			Type:       accountType,
+		})
+		if errors.Is(err, repository.ErrAccountIDAlreadyExists) {
+			logger.Warn(requestCtx, "Generated account number: %d is already taken, attempt: %d", accountID, attempt)
+			continue
+		}
+		return account, err
+	}
+
+	logger.Error(requestCtx, "Unable to generate an unused account number for userID: %+v after %d attempts", userID, maxAccountIDGenerationAttempts)
+	return nil, &server.ApiError{
+		HttpStatusCode: http.StatusInternalServerError,
+		Message:        "We couldn't create your account at the moment. Please try again later.",
 	}
 }
 
-func (s *accountService) CreateAccount(requestCtx context.Context, dbExecutor bun.IDB, userID uuid.UUID, accountType model.AccountType) error {
+/*
+OpenAccount opens another savings or current account for the user, held in the given currency
+
+A user can hold at most one account of each type in each currency, eg: a savings account in INR and another in USD.
+*/
+func (s *accountService) OpenAccount(requestCtx context.Context, dbExecutor bun.IDB, userID uuid.UUID, accountType model.AccountType, currency money.Currency) (*model.Account, error) {
 	if dbExecutor == nil {
 		dbExecutor = s.db
 	}
 
-	account := &model.Account{
-		ID:     s.generateAccountID(),
-		UserID: userID,
-		Type:   accountType,
+	if !accountType.AllowsTransfers() {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Only savings and current accounts can be opened, deposits are booked on their own",
+		}
 	}
 
-	return s.accountRepository.CreateAccount(requestCtx, dbExecutor, account)
+	existingAccounts, err := s.accountRepository.GetAccountsByUserID(requestCtx, dbExecutor, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, existingAccount := range existingAccounts {
+		if existingAccount.Type == accountType && existingAccount.Currency == currency {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusConflict,
+				Message:        fmt.Sprintf("You already have a %s in %s", accountType, currency),
+			}
+		}
+	}
+
+	return s.CreateAccount(requestCtx, dbExecutor, userID, accountType, currency)
 }
 
 func (s *accountService) GetAccountsByUserID(requestCtx context.Context, dbExecutor bun.IDB, userID uuid.UUID) ([]model.Account, error) {
@@ -54,6 +122,14 @@ func (s *accountService) GetAccount(requestCtx context.Context, dbExecutor bun.I
 	return s.accountRepository.GetAccount(requestCtx, dbExecutor, options)
 }
 
+func (s *accountService) ListAccounts(requestCtx context.Context, dbExecutor bun.IDB, options types.AccountListOptions) ([]model.Account, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.accountRepository.ListAccounts(requestCtx, dbExecutor, options)
+}
+
 func (s *accountService) UpdateAccount(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, options types.AccountUpdateOptions) (*model.Account, error) {
 	if dbExecutor == nil {
 		dbExecutor = s.db
@@ -70,17 +146,198 @@ func (s *accountService) CreateTransactionRecord(requestCtx context.Context, dbE
 	return s.accountRepository.CreateTransactionRecord(requestCtx, dbExecutor, transaction)
 }
 
-func (s *accountService) generateAccountID() int64 {
-	min := int64(1000000000)      // 10 digits
-	max := int64(999999999999999) // 15 digits
+func (s *accountService) GetTransaction(requestCtx context.Context, dbExecutor bun.IDB, options types.TransactionGetOptions) (*model.Transaction, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.accountRepository.GetTransaction(requestCtx, dbExecutor, options)
+}
+
+func (s *accountService) UpdateTransaction(requestCtx context.Context, dbExecutor bun.IDB, transactionID uuid.UUID, options types.TransactionUpdateOptions) (*model.Transaction, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.accountRepository.UpdateTransaction(requestCtx, dbExecutor, transactionID, options)
+}
+
+func (s *accountService) ListTransactions(requestCtx context.Context, dbExecutor bun.IDB, options types.TransactionQueryOptions) ([]model.Transaction, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.accountRepository.ListTransactions(requestCtx, dbExecutor, options)
+}
+
+func (s *accountService) CountTransactions(requestCtx context.Context, dbExecutor bun.IDB, options types.TransactionQueryOptions) (int, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.accountRepository.CountTransactions(requestCtx, dbExecutor, options)
+}
+
+// SumTransactions returns the total amount of the transactions matching the options, 0 when there are none
+func (s *accountService) SumTransactions(requestCtx context.Context, dbExecutor bun.IDB, options types.TransactionQueryOptions) (int64, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.accountRepository.SumTransactions(requestCtx, dbExecutor, options)
+}
+
+// SetOverdraftLimit must be called within a database transaction because it locks the account row for update
+func (s *accountService) SetOverdraftLimit(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, overdraftLimit int64) (*model.Account, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	account, err := s.accountRepository.GetAccount(requestCtx, dbExecutor, types.AccountQueryOptions{
+		AccountID: &accountID,
+		ForUpdate: true, // lock the row so that the balance cannot change while the new limit is being validated
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Type != model.CurrentAccount {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("Overdraft facility is only available for %s", model.CurrentAccount),
+		}
+	}
+
+	// an overdrawn account cannot have its limit reduced below the amount it is already overdrawn by
+	if account.Balance+overdraftLimit < 0 {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("Overdraft limit cannot be less than the overdrawn amount of %d", -account.Balance),
+		}
+	}
+
+	return s.accountRepository.UpdateAccount(requestCtx, dbExecutor, accountID, types.AccountUpdateOptions{
+		NewOverdraftLimit: &overdraftLimit,
+	})
+}
+
+/*
+ChargeOverdraftInterest debits a single day's interest on the overdrawn balance of the account
+
+It must be called within a database transaction because it locks the account row for update.
+It is idempotent per charge date (UTC) so that a retried task does not charge the interest of a day twice.
+It returns a nil transaction when there is nothing to charge.
+*/
+func (s *accountService) ChargeOverdraftInterest(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, annualInterestRateInBasisPoints int64, chargeDate time.Time) (*model.Transaction, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	account, err := s.accountRepository.GetAccount(requestCtx, dbExecutor, types.AccountQueryOptions{
+		AccountID: &accountID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Balance >= 0 {
+		return nil, nil
+	}
+
+	// the interest is looked up by the day it is charged for rather than the day it was booked, a task retried on the next day must not charge it again
+	chargeDay := time.Date(chargeDate.Year(), chargeDate.Month(), chargeDate.Day(), 0, 0, 0, 0, time.UTC)
+	alreadyChargedCount, err := s.accountRepository.CountTransactions(requestCtx, dbExecutor, types.TransactionQueryOptions{
+		AccountID:  &accountID,
+		Types:      []model.TransactionType{model.OverdraftInterest},
+		ChargeDate: &chargeDay,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if alreadyChargedCount > 0 {
+		return nil, nil
+	}
+
+	interest := calculateDailyInterest(-account.Balance, annualInterestRateInBasisPoints)
+
+	/*
+		The interest is charged only up to the remaining overdraft headroom because
+		the balance of an account can never go below its sanctioned overdraft limit
+
+		Any shortfall is logged so that it can be recovered manually
+	*/
+	if interest > account.AvailableBalance() {
+		logger.Warn(requestCtx, "Overdraft interest of %d for accountID: %d exceeds the available headroom of %d, charging only the headroom", interest, accountID, account.AvailableBalance())
+		interest = account.AvailableBalance()
+	}
+	if interest <= 0 {
+		return nil, nil
+	}
+
+	newBalance := account.Balance - interest
+	account, err = s.accountRepository.UpdateAccount(requestCtx, dbExecutor, accountID, types.AccountUpdateOptions{
+		NewBalance: &newBalance,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.accountRepository.CreateTransactionRecord(requestCtx, dbExecutor, &model.Transaction{
+		AccountID:    accountID,
+		Amount:       interest,
+		BalanceAfter: account.Balance,
+		ChargeDate:   &chargeDay,
+		Type:         model.OverdraftInterest,
+	})
+}
+
+// calculateDailyInterest returns the interest for a single day on the principal, rounded to the nearest smallest currency unit
+func calculateDailyInterest(principal int64, annualInterestRateInBasisPoints int64) int64 {
+	const denominator = 10000 * 365 // basis points * days in a year
+	return (principal*annualInterestRateInBasisPoints + denominator/2) / denominator
+}
+
+/*
+ResolveIBAN returns the account number of the IBAN, which must be of an account held at this bank
+
+The branch code of the IBAN must be the one of the branch the account is held at.
+*/
+func (s *accountService) ResolveIBAN(requestCtx context.Context, dbExecutor bun.IDB, iban string) (int64, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
 
-	// calculate the range
-	rangeNum := big.NewInt(max - min + 1)
+	ibanComponents, err := accountnumber.ParseIBAN(iban)
+	if err != nil {
+		return 0, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid IBAN",
+		}
+	}
+
+	bankConfig := config.GetBankConfig()
+	if ibanComponents.CountryCode != bankConfig.CountryCode || ibanComponents.BankCode != bankConfig.Code {
+		return 0, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "IBAN does not belong to an account at this bank",
+		}
+	}
 
-	// generate random number in [0, rangeNum)
-	n, _ := rand.Int(rand.Reader, rangeNum)
+	account, err := s.accountRepository.GetAccount(requestCtx, dbExecutor, types.AccountQueryOptions{
+		AccountID: &ibanComponents.AccountNumber,
+		Columns:   []string{"id", "branch_code"},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if account.BranchCode != ibanComponents.BranchCode {
+		return 0, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "IBAN does not belong to an account at this bank",
+		}
+	}
 
-	// shift to the desired range
-	accountID := n.Int64() + min
-	return accountID
+	return account.ID, nil
 }
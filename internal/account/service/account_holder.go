@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/uptrace/bun"
+)
+
+// number of holders a joint account can have besides its primary holder, counting the pending invitations
+const maxJointHolders int = 3
+
+// GetAccountsOfHolder returns every account the user can operate, their own accounts followed by the joint accounts they hold
+func (s *accountService) GetAccountsOfHolder(requestCtx context.Context, dbExecutor bun.IDB, userID uuid.UUID) ([]model.Account, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.accountRepository.GetAccountsOfHolder(requestCtx, dbExecutor, userID)
+}
+
+// IsAccountHolder reports whether the user is the primary holder or an active joint holder of the account
+func (s *accountService) IsAccountHolder(requestCtx context.Context, dbExecutor bun.IDB, account *model.Account, userID uuid.UUID) (bool, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	if account.UserID == userID {
+		return true, nil
+	}
+
+	accountHolders, err := s.accountRepository.ListAccountHolders(requestCtx, dbExecutor, types.AccountHolderListOptions{
+		AccountID: &account.ID,
+		UserID:    &userID,
+		Statuses:  []model.AccountHolderStatus{model.AccountHolderActive},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return len(accountHolders) > 0, nil
+}
+
+// ListAccountHolderUserIDs returns the users holding the account, the primary holder first and then the active joint holders
+func (s *accountService) ListAccountHolderUserIDs(requestCtx context.Context, dbExecutor bun.IDB, account *model.Account) ([]uuid.UUID, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	accountHolders, err := s.accountRepository.ListAccountHolders(requestCtx, dbExecutor, types.AccountHolderListOptions{
+		AccountID: &account.ID,
+		Statuses:  []model.AccountHolderStatus{model.AccountHolderActive},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := []uuid.UUID{account.UserID}
+	for _, accountHolder := range accountHolders {
+		userIDs = append(userIDs, accountHolder.UserID)
+	}
+	return userIDs, nil
+}
+
+func (s *accountService) ListAccountHolders(requestCtx context.Context, dbExecutor bun.IDB, options types.AccountHolderListOptions) ([]model.AccountHolder, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.accountRepository.ListAccountHolders(requestCtx, dbExecutor, options)
+}
+
+/*
+InviteAccountHolder invites the user to become a joint holder of the account, which turns it into a joint account once they accept
+
+Only the primary holder can invite, the caller must have checked that invitedByUserID is the primary holder.
+It must be called within a database transaction because it locks the account row for update,
+so that the same user cannot be invited twice at the same time.
+*/
+func (s *accountService) InviteAccountHolder(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, invitedByUserID uuid.UUID, userID uuid.UUID) (*model.AccountHolder, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	account, err := s.accountRepository.GetAccount(requestCtx, dbExecutor, types.AccountQueryOptions{
+		AccountID: &accountID,
+		ForUpdate: true, // lock the row so that the holders of the account cannot change while the invitation is being validated
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !account.Type.AllowsTransfers() {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Only savings and current accounts can be held jointly",
+		}
+	}
+
+	if account.UserID == userID {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "You are already the primary holder of this account",
+		}
+	}
+
+	accountHolders, err := s.accountRepository.ListAccountHolders(requestCtx, dbExecutor, types.AccountHolderListOptions{
+		AccountID: &accountID,
+		Statuses:  []model.AccountHolderStatus{model.AccountHolderInvited, model.AccountHolderActive},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, accountHolder := range accountHolders {
+		if accountHolder.UserID != userID {
+			continue
+		}
+		if accountHolder.Status == model.AccountHolderActive {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusConflict,
+				Message:        "The user is already a holder of this account",
+			}
+		}
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusConflict,
+			Message:        "The user is already invited to this account",
+		}
+	}
+
+	if len(accountHolders) >= maxJointHolders {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("An account can have at most %d joint holders", maxJointHolders),
+		}
+	}
+
+	return s.accountRepository.CreateAccountHolder(requestCtx, dbExecutor, &model.AccountHolder{
+		AccountID:       accountID,
+		UserID:          userID,
+		InvitedByUserID: invitedByUserID,
+		Status:          model.AccountHolderInvited,
+	})
+}
+
+// AcceptAccountHolderInvitation makes the invited user a holder of the account, it must be called within a database transaction
+func (s *accountService) AcceptAccountHolderInvitation(requestCtx context.Context, dbExecutor bun.IDB, accountHolderID uuid.UUID, userID uuid.UUID) (*model.AccountHolder, error) {
+	return s.respondToAccountHolderInvitation(requestCtx, dbExecutor, accountHolderID, userID, model.AccountHolderActive)
+}
+
+// DeclineAccountHolderInvitation declines the invitation, the user can be invited to the account again, it must be called within a database transaction
+func (s *accountService) DeclineAccountHolderInvitation(requestCtx context.Context, dbExecutor bun.IDB, accountHolderID uuid.UUID, userID uuid.UUID) (*model.AccountHolder, error) {
+	return s.respondToAccountHolderInvitation(requestCtx, dbExecutor, accountHolderID, userID, model.AccountHolderDeclined)
+}
+
+func (s *accountService) respondToAccountHolderInvitation(requestCtx context.Context, dbExecutor bun.IDB, accountHolderID uuid.UUID, userID uuid.UUID, newStatus model.AccountHolderStatus) (*model.AccountHolder, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	// an invitation of another user is reported as not found, so that its existence is not revealed
+	accountHolder, err := s.accountRepository.GetAccountHolder(requestCtx, dbExecutor, types.AccountHolderQueryOptions{
+		ID:        &accountHolderID,
+		UserID:    &userID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if accountHolder.Status != model.AccountHolderInvited {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "The invitation is no longer pending",
+		}
+	}
+
+	respondedAt := time.Now().UTC()
+	return s.accountRepository.UpdateAccountHolder(requestCtx, dbExecutor, accountHolder.ID, types.AccountHolderUpdateOptions{
+		NewStatus:      &newStatus,
+		NewRespondedAt: &respondedAt,
+	})
+}
+
+/*
+RemoveAccountHolder removes a joint holder from the account, or withdraws their invitation when they have not accepted it yet
+
+The primary holder can remove any joint holder, a joint holder can only remove themselves, i.e. leave the account.
+It must be called within a database transaction because it locks the account holder row for update.
+*/
+func (s *accountService) RemoveAccountHolder(requestCtx context.Context, dbExecutor bun.IDB, account *model.Account, accountHolderID uuid.UUID, requestedByUserID uuid.UUID) (*model.AccountHolder, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	accountHolder, err := s.accountRepository.GetAccountHolder(requestCtx, dbExecutor, types.AccountHolderQueryOptions{
+		ID:        &accountHolderID,
+		AccountID: &account.ID,
+		Statuses:  []model.AccountHolderStatus{model.AccountHolderInvited, model.AccountHolderActive},
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if requestedByUserID != account.UserID && requestedByUserID != accountHolder.UserID {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "Only the primary holder can remove another holder of this account",
+		}
+	}
+
+	removedStatus := model.AccountHolderRemoved
+	removedAt := time.Now().UTC()
+	return s.accountRepository.UpdateAccountHolder(requestCtx, dbExecutor, accountHolder.ID, types.AccountHolderUpdateOptions{
+		NewStatus:    &removedStatus,
+		NewRemovedAt: &removedAt,
+	})
+}
+
+/*
+SetMandate changes how the holders of the account operate it, the caller must have checked that the change is made by its primary holder
+
+Under JOINTLY, the debits above the threshold need the approval of every holder, the threshold is ignored under EITHER_OR_SURVIVOR.
+*/
+func (s *accountService) SetMandate(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, mandate model.AccountMandate, jointApprovalThreshold int64) (*model.Account, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	if mandate != model.MandateJointly {
+		jointApprovalThreshold = 0
+	}
+
+	return s.accountRepository.UpdateAccount(requestCtx, dbExecutor, accountID, types.AccountUpdateOptions{
+		NewMandate:                &mandate,
+		NewJointApprovalThreshold: &jointApprovalThreshold,
+	})
+}
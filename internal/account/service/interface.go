@@ -2,17 +2,54 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/skamranahmed/go-bank/internal/account/model"
 	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/money"
 	"github.com/uptrace/bun"
 )
 
 type AccountService interface {
-	CreateAccount(requestCtx context.Context, dbExecutor bun.IDB, userID uuid.UUID, accountType model.AccountType) error
+	CreateAccount(requestCtx context.Context, dbExecutor bun.IDB, userID uuid.UUID, accountType model.AccountType, currency money.Currency) (*model.Account, error)
+	OpenAccount(requestCtx context.Context, dbExecutor bun.IDB, userID uuid.UUID, accountType model.AccountType, currency money.Currency) (*model.Account, error)
 	GetAccountsByUserID(requestCtx context.Context, dbExecutor bun.IDB, userID uuid.UUID) ([]model.Account, error)
 	GetAccount(requestCtx context.Context, dbExecutor bun.IDB, options types.AccountQueryOptions) (*model.Account, error)
+	ListAccounts(requestCtx context.Context, dbExecutor bun.IDB, options types.AccountListOptions) ([]model.Account, error)
 	UpdateAccount(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, options types.AccountUpdateOptions) (*model.Account, error)
 	CreateTransactionRecord(requestCtx context.Context, dbExecutor bun.IDB, transaction *model.Transaction) (*model.Transaction, error)
+	GetTransaction(requestCtx context.Context, dbExecutor bun.IDB, options types.TransactionGetOptions) (*model.Transaction, error)
+	UpdateTransaction(requestCtx context.Context, dbExecutor bun.IDB, transactionID uuid.UUID, options types.TransactionUpdateOptions) (*model.Transaction, error)
+	ListTransactions(requestCtx context.Context, dbExecutor bun.IDB, options types.TransactionQueryOptions) ([]model.Transaction, error)
+	CountTransactions(requestCtx context.Context, dbExecutor bun.IDB, options types.TransactionQueryOptions) (int, error)
+	SumTransactions(requestCtx context.Context, dbExecutor bun.IDB, options types.TransactionQueryOptions) (int64, error)
+	SetOverdraftLimit(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, overdraftLimit int64) (*model.Account, error)
+	ChargeOverdraftInterest(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, annualInterestRateInBasisPoints int64, chargeDate time.Time) (*model.Transaction, error)
+	ResolveIBAN(requestCtx context.Context, dbExecutor bun.IDB, iban string) (int64, error)
+	BuildEndOfDayStatement(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, statementDate time.Time) ([]byte, error)
+	CreatePocket(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, params types.CreatePocketParams) (*model.Pocket, error)
+	GetPocket(requestCtx context.Context, dbExecutor bun.IDB, options types.PocketQueryOptions) (*model.Pocket, error)
+	ListPockets(requestCtx context.Context, dbExecutor bun.IDB, options types.PocketListOptions) ([]model.Pocket, error)
+	UpdatePocket(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, pocketID uuid.UUID, params types.UpdatePocketParams) (*model.Pocket, error)
+	DepositToPocket(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, pocketID uuid.UUID, amount int64) (*model.Pocket, error)
+	WithdrawFromPocket(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, pocketID uuid.UUID, amount int64) (*model.Pocket, error)
+	ClosePocket(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, pocketID uuid.UUID) (*model.Pocket, error)
+	ListPocketMovements(requestCtx context.Context, dbExecutor bun.IDB, options types.PocketMovementListOptions) ([]model.PocketMovement, error)
+	SweepRoundUp(requestCtx context.Context, dbExecutor bun.IDB, transaction *model.Transaction) error
+	ReleaseRoundUp(requestCtx context.Context, dbExecutor bun.IDB, transactionID uuid.UUID) error
+	GetAccountsOfHolder(requestCtx context.Context, dbExecutor bun.IDB, userID uuid.UUID) ([]model.Account, error)
+	IsAccountHolder(requestCtx context.Context, dbExecutor bun.IDB, account *model.Account, userID uuid.UUID) (bool, error)
+	ListAccountHolderUserIDs(requestCtx context.Context, dbExecutor bun.IDB, account *model.Account) ([]uuid.UUID, error)
+	ListAccountHolders(requestCtx context.Context, dbExecutor bun.IDB, options types.AccountHolderListOptions) ([]model.AccountHolder, error)
+	InviteAccountHolder(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, invitedByUserID uuid.UUID, userID uuid.UUID) (*model.AccountHolder, error)
+	AcceptAccountHolderInvitation(requestCtx context.Context, dbExecutor bun.IDB, accountHolderID uuid.UUID, userID uuid.UUID) (*model.AccountHolder, error)
+	DeclineAccountHolderInvitation(requestCtx context.Context, dbExecutor bun.IDB, accountHolderID uuid.UUID, userID uuid.UUID) (*model.AccountHolder, error)
+	RemoveAccountHolder(requestCtx context.Context, dbExecutor bun.IDB, account *model.Account, accountHolderID uuid.UUID, requestedByUserID uuid.UUID) (*model.AccountHolder, error)
+	SetMandate(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, mandate model.AccountMandate, jointApprovalThreshold int64) (*model.Account, error)
+	ListNominees(requestCtx context.Context, dbExecutor bun.IDB, options types.NomineeListOptions) ([]model.Nominee, error)
+	AddNominee(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, params types.NomineeParams, otherShares []types.NomineeShare) (*model.Nominee, error)
+	UpdateNominee(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, nomineeID uuid.UUID, params types.NomineeParams, otherShares []types.NomineeShare) (*model.Nominee, error)
+	RemoveNominee(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, nomineeID uuid.UUID, otherShares []types.NomineeShare) (*model.Nominee, error)
+	DescribeNominees(requestCtx context.Context, dbExecutor bun.IDB, accountID int64) (string, error)
 }
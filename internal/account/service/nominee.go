@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/uptrace/bun"
+)
+
+// number of active nominees an account can have
+const maxNominees int = 4
+
+func (s *accountService) ListNominees(requestCtx context.Context, dbExecutor bun.IDB, options types.NomineeListOptions) ([]model.Nominee, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.accountRepository.ListNominees(requestCtx, dbExecutor, options)
+}
+
+/*
+AddNominee registers a nominee of the account, the caller must have checked that it is added by the primary holder
+
+The shares of the nominees must still add up to 100 once the nominee is added, otherShares gives the new shares
+of the other nominees of the account when they have to make room for the new one, the first nominee is given 100.
+It must be called within a database transaction because it locks the account row for update,
+so that the nominees of the account cannot change while their shares are being validated.
+*/
+func (s *accountService) AddNominee(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, params types.NomineeParams, otherShares []types.NomineeShare) (*model.Nominee, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	nominee := &model.Nominee{
+		AccountID:            accountID,
+		Name:                 params.Name,
+		Relationship:         params.Relationship,
+		DateOfBirth:          params.DateOfBirth,
+		SharePercentage:      params.SharePercentage,
+		GuardianName:         params.GuardianName,
+		GuardianRelationship: params.GuardianRelationship,
+		Status:               model.NomineeActive,
+	}
+	err := validateNominee(nominee)
+	if err != nil {
+		return nil, err
+	}
+
+	otherNominees, err := s.lockAccountAndListNominees(requestCtx, dbExecutor, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(otherNominees) >= maxNominees {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("An account can have at most %d nominees", maxNominees),
+		}
+	}
+
+	err = s.rebalanceNominees(requestCtx, dbExecutor, otherNominees, nominee.SharePercentage, otherShares)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.accountRepository.CreateNominee(requestCtx, dbExecutor, nominee)
+}
+
+/*
+UpdateNominee replaces the details of an active nominee of the account, the caller must have checked that it is updated by the primary holder
+
+Like for AddNominee, otherShares gives the new shares of the other nominees when the share of the nominee changes.
+It must be called within a database transaction because it locks the account row for update.
+*/
+func (s *accountService) UpdateNominee(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, nomineeID uuid.UUID, params types.NomineeParams, otherShares []types.NomineeShare) (*model.Nominee, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	err := validateNominee(&model.Nominee{
+		DateOfBirth:          params.DateOfBirth,
+		GuardianName:         params.GuardianName,
+		GuardianRelationship: params.GuardianRelationship,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	nominees, err := s.lockAccountAndListNominees(requestCtx, dbExecutor, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	otherNominees, found := withoutNominee(nominees, nomineeID)
+	if !found {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusNotFound,
+			Message:        "Nominee not found",
+		}
+	}
+
+	err = s.rebalanceNominees(requestCtx, dbExecutor, otherNominees, params.SharePercentage, otherShares)
+	if err != nil {
+		return nil, err
+	}
+
+	// a nil guardian is stored as an empty one, which clears the guardian of a nominee who is no longer a minor
+	var guardianName, guardianRelationship string
+	if params.GuardianName != nil {
+		guardianName = *params.GuardianName
+		guardianRelationship = *params.GuardianRelationship
+	}
+
+	return s.accountRepository.UpdateNominee(requestCtx, dbExecutor, nomineeID, types.NomineeUpdateOptions{
+		NewName:                 &params.Name,
+		NewRelationship:         &params.Relationship,
+		NewDateOfBirth:          &params.DateOfBirth,
+		NewSharePercentage:      &params.SharePercentage,
+		NewGuardianName:         &guardianName,
+		NewGuardianRelationship: &guardianRelationship,
+	})
+}
+
+/*
+RemoveNominee removes an active nominee of the account, the caller must have checked that it is removed by the primary holder
+
+Unless it is the last nominee of the account, otherShares must hand its share over to the remaining nominees.
+It must be called within a database transaction because it locks the account row for update.
+*/
+func (s *accountService) RemoveNominee(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, nomineeID uuid.UUID, otherShares []types.NomineeShare) (*model.Nominee, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	nominees, err := s.lockAccountAndListNominees(requestCtx, dbExecutor, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	otherNominees, found := withoutNominee(nominees, nomineeID)
+	if !found {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusNotFound,
+			Message:        "Nominee not found",
+		}
+	}
+
+	err = s.rebalanceNominees(requestCtx, dbExecutor, otherNominees, 0, otherShares)
+	if err != nil {
+		return nil, err
+	}
+
+	removedStatus := model.NomineeRemoved
+	removedAt := time.Now().UTC()
+	return s.accountRepository.UpdateNominee(requestCtx, dbExecutor, nomineeID, types.NomineeUpdateOptions{
+		NewStatus:    &removedStatus,
+		NewRemovedAt: &removedAt,
+	})
+}
+
+// lockAccountAndListNominees locks the account row for update and returns its active nominees
+func (s *accountService) lockAccountAndListNominees(requestCtx context.Context, dbExecutor bun.IDB, accountID int64) ([]model.Nominee, error) {
+	_, err := s.accountRepository.GetAccount(requestCtx, dbExecutor, types.AccountQueryOptions{
+		AccountID: &accountID,
+		Columns:   []string{"id"},
+		ForUpdate: true, // lock the row so that the nominees of the account cannot change while their shares are being validated
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	activeStatus := model.NomineeActive
+	return s.accountRepository.ListNominees(requestCtx, dbExecutor, types.NomineeListOptions{
+		AccountID: &accountID,
+		Status:    &activeStatus,
+	})
+}
+
+/*
+rebalanceNominees gives the other nominees of the account the new shares listed in otherShares, the others keep their share
+
+otherNominees are the active nominees besides the one being added, updated or removed, whose share is sharePercentage (0 once removed).
+The shares must add up to 100 once they are applied, unless no nominee is left.
+*/
+func (s *accountService) rebalanceNominees(requestCtx context.Context, dbExecutor bun.IDB, otherNominees []model.Nominee, sharePercentage int, otherShares []types.NomineeShare) error {
+	newShares := make(map[uuid.UUID]int, len(otherShares))
+	for _, otherShare := range otherShares {
+		isOtherNominee := slices.ContainsFunc(otherNominees, func(nominee model.Nominee) bool {
+			return nominee.ID == otherShare.NomineeID
+		})
+		if !isOtherNominee {
+			return &server.ApiError{
+				HttpStatusCode: http.StatusBadRequest,
+				Message:        fmt.Sprintf("%s is not one of the other nominees of this account", otherShare.NomineeID),
+			}
+		}
+
+		_, isRepeated := newShares[otherShare.NomineeID]
+		if isRepeated {
+			return &server.ApiError{
+				HttpStatusCode: http.StatusBadRequest,
+				Message:        fmt.Sprintf("The share of nominee %s is given more than once", otherShare.NomineeID),
+			}
+		}
+		newShares[otherShare.NomineeID] = otherShare.SharePercentage
+	}
+
+	if len(otherNominees) == 0 && sharePercentage == 0 {
+		return nil
+	}
+
+	totalSharePercentage := sharePercentage
+	for _, nominee := range otherNominees {
+		newShare, ok := newShares[nominee.ID]
+		if !ok {
+			newShare = nominee.SharePercentage
+		}
+		totalSharePercentage += newShare
+	}
+
+	if totalSharePercentage != 100 {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("The shares of the nominees must add up to 100, they would add up to %d", totalSharePercentage),
+		}
+	}
+
+	for _, nominee := range otherNominees {
+		newShare, ok := newShares[nominee.ID]
+		if !ok || newShare == nominee.SharePercentage {
+			continue
+		}
+
+		_, err := s.accountRepository.UpdateNominee(requestCtx, dbExecutor, nominee.ID, types.NomineeUpdateOptions{
+			NewSharePercentage: &newShare,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateNominee verifies the date of birth of the nominee and that a guardian is appointed if, and only if, they are a minor
+func validateNominee(nominee *model.Nominee) error {
+	now := time.Now().UTC()
+	if !nominee.DateOfBirth.Before(now) {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "The date of birth of the nominee must be in the past",
+		}
+	}
+
+	if (nominee.GuardianName == nil) != (nominee.GuardianRelationship == nil) {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "guardian_name and guardian_relationship must be given together",
+		}
+	}
+
+	hasGuardian := nominee.GuardianName != nil
+	if nominee.IsMinorOn(now) && !hasGuardian {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "A guardian is required for a minor nominee",
+		}
+	}
+	if !nominee.IsMinorOn(now) && hasGuardian {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "A guardian can only be appointed for a minor nominee",
+		}
+	}
+
+	return nil
+}
+
+// withoutNominee returns the nominees other than the one with the ID, and whether it was one of them
+func withoutNominee(nominees []model.Nominee, nomineeID uuid.UUID) ([]model.Nominee, bool) {
+	otherNominees := slices.DeleteFunc(slices.Clone(nominees), func(nominee model.Nominee) bool {
+		return nominee.ID == nomineeID
+	})
+	return otherNominees, len(otherNominees) < len(nominees)
+}
+
+// DescribeNominees describes the active nominees of the account for its statements, it is empty when the account has none
+func (s *accountService) DescribeNominees(requestCtx context.Context, dbExecutor bun.IDB, accountID int64) (string, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	activeNomineeStatus := model.NomineeActive
+	nominees, err := s.accountRepository.ListNominees(requestCtx, dbExecutor, types.NomineeListOptions{
+		AccountID: &accountID,
+		Status:    &activeNomineeStatus,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return describeNominees(nominees), nil
+}
+
+// describeNominees lists the nominees for the additional information of a statement, eg: "Nominees: Jane Doe (SPOUSE, 60%); Sam Doe (CHILD, 40%, guardian Jane Doe)"
+func describeNominees(nominees []model.Nominee) string {
+	if len(nominees) == 0 {
+		return ""
+	}
+
+	descriptions := make([]string, 0, len(nominees))
+	for _, nominee := range nominees {
+		description := fmt.Sprintf("%s (%s, %d%%", nominee.Name, nominee.Relationship, nominee.SharePercentage)
+		if nominee.GuardianName != nil {
+			description += ", guardian " + *nominee.GuardianName
+		}
+		descriptions = append(descriptions, description+")")
+	}
+	return "Nominees: " + strings.Join(descriptions, "; ")
+}
@@ -0,0 +1,453 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/skamranahmed/go-bank/pkg/money"
+	"github.com/uptrace/bun"
+)
+
+/*
+CreatePocket creates a pocket in the savings account, with nothing in it yet
+
+It must be called within a database transaction because it locks the account row for update,
+so that two pockets of the same name cannot be created at the same time.
+*/
+func (s *accountService) CreatePocket(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, params types.CreatePocketParams) (*model.Pocket, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	account, err := s.accountRepository.GetAccount(requestCtx, dbExecutor, types.AccountQueryOptions{
+		AccountID: &accountID,
+		ForUpdate: true, // lock the row so that the pockets of the account cannot change while the new one is being validated
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Type != model.SavingsAccount {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("Pockets are only available for %s", model.SavingsAccount),
+		}
+	}
+
+	err = validatePocketTargetDate(params.TargetDate)
+	if err != nil {
+		return nil, err
+	}
+
+	activeStatus := model.PocketActive
+	activePockets, err := s.accountRepository.ListPockets(requestCtx, dbExecutor, types.PocketListOptions{
+		AccountID: &accountID,
+		Status:    &activeStatus,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(activePockets) >= s.pocketConfig.MaxPerAccount {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("An account can have at most %d pockets", s.pocketConfig.MaxPerAccount),
+		}
+	}
+
+	err = validatePocketAgainstOthers(activePockets, uuid.Nil, &params.Name, params.RoundUpTo)
+	if err != nil {
+		return nil, err
+	}
+
+	pocket := &model.Pocket{
+		AccountID:    accountID,
+		Name:         params.Name,
+		TargetAmount: params.TargetAmount,
+		TargetDate:   params.TargetDate,
+	}
+	if params.RoundUpTo != nil {
+		roundUpTo := money.FromWholeUnits(*params.RoundUpTo, account.Currency).Amount
+		pocket.RoundUpTo = &roundUpTo
+	}
+
+	return s.accountRepository.CreatePocket(requestCtx, dbExecutor, pocket)
+}
+
+func (s *accountService) GetPocket(requestCtx context.Context, dbExecutor bun.IDB, options types.PocketQueryOptions) (*model.Pocket, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.accountRepository.GetPocket(requestCtx, dbExecutor, options)
+}
+
+func (s *accountService) ListPockets(requestCtx context.Context, dbExecutor bun.IDB, options types.PocketListOptions) ([]model.Pocket, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.accountRepository.ListPockets(requestCtx, dbExecutor, options)
+}
+
+// UpdatePocket must be called within a database transaction because it locks the account and pocket rows for update
+func (s *accountService) UpdatePocket(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, pocketID uuid.UUID, params types.UpdatePocketParams) (*model.Pocket, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	account, pocket, err := s.lockActivePocket(requestCtx, dbExecutor, accountID, pocketID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validatePocketTargetDate(params.TargetDate)
+	if err != nil {
+		return nil, err
+	}
+
+	activeStatus := model.PocketActive
+	activePockets, err := s.accountRepository.ListPockets(requestCtx, dbExecutor, types.PocketListOptions{
+		AccountID: &accountID,
+		Status:    &activeStatus,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// turning the round-ups off never conflicts with another pocket
+	roundUpTo := params.RoundUpTo
+	if roundUpTo != nil && *roundUpTo == 0 {
+		roundUpTo = nil
+	}
+	err = validatePocketAgainstOthers(activePockets, pocket.ID, params.Name, roundUpTo)
+	if err != nil {
+		return nil, err
+	}
+
+	updateOptions := types.PocketUpdateOptions{
+		NewName:         params.Name,
+		NewTargetAmount: params.TargetAmount,
+		NewTargetDate:   params.TargetDate,
+	}
+	if params.RoundUpTo != nil {
+		newRoundUpTo := money.FromWholeUnits(*params.RoundUpTo, account.Currency).Amount
+		updateOptions.NewRoundUpTo = &newRoundUpTo
+	}
+
+	return s.accountRepository.UpdatePocket(requestCtx, dbExecutor, pocket.ID, updateOptions)
+}
+
+/*
+DepositToPocket moves money from the main balance of the account into the pocket
+
+Only the account holder's own money can be moved, never the overdraft or an amount on hold.
+It must be called within a database transaction because it locks the account and pocket rows for update.
+*/
+func (s *accountService) DepositToPocket(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, pocketID uuid.UUID, amount int64) (*model.Pocket, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	account, pocket, err := s.lockActivePocket(requestCtx, dbExecutor, accountID, pocketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.OwnFunds() < amount {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Insufficient balance",
+		}
+	}
+
+	return s.movePocketFunds(requestCtx, dbExecutor, account, pocket, amount, model.PocketDeposit, nil)
+}
+
+// WithdrawFromPocket moves money from the pocket back to the main balance of the account, it must be called within a database transaction
+func (s *accountService) WithdrawFromPocket(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, pocketID uuid.UUID, amount int64) (*model.Pocket, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	account, pocket, err := s.lockActivePocket(requestCtx, dbExecutor, accountID, pocketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pocket.Balance < amount {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("Insufficient balance in pocket %s", pocket.Name),
+		}
+	}
+
+	return s.movePocketFunds(requestCtx, dbExecutor, account, pocket, -amount, model.PocketWithdrawal, nil)
+}
+
+// ClosePocket moves the whole balance of the pocket back to the main balance of the account and closes it, it must be called within a database transaction
+func (s *accountService) ClosePocket(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, pocketID uuid.UUID) (*model.Pocket, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	account, pocket, err := s.lockActivePocket(requestCtx, dbExecutor, accountID, pocketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pocket.Balance > 0 {
+		pocket, err = s.movePocketFunds(requestCtx, dbExecutor, account, pocket, -pocket.Balance, model.PocketWithdrawal, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	closedStatus := model.PocketClosed
+	closedAt := time.Now().UTC()
+	noRoundUps := int64(0)
+	return s.accountRepository.UpdatePocket(requestCtx, dbExecutor, pocket.ID, types.PocketUpdateOptions{
+		NewStatus:    &closedStatus,
+		NewClosedAt:  &closedAt,
+		NewRoundUpTo: &noRoundUps, // a closed pocket no longer takes the round-ups of the account
+	})
+}
+
+func (s *accountService) ListPocketMovements(requestCtx context.Context, dbExecutor bun.IDB, options types.PocketMovementListOptions) ([]model.PocketMovement, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.accountRepository.ListPocketMovements(requestCtx, dbExecutor, options)
+}
+
+/*
+SweepRoundUp moves the spare change of a payment made by the account holder into the round-up pocket of the account
+
+The payment is rounded up to the next multiple of the RoundUpTo of the pocket, eg: a payment of 123.45 INR
+rounded up to 10 INR sweeps 6.55 INR. Nothing is swept when the account has no round-up pocket, when the payment
+is already a multiple or when the account holder's own money, after the payment and its fee, does not cover the
+spare change, a round-up never overdraws the account nor fails the payment it is made for.
+
+It must be called within the database transaction of the payment, once the payment and its fee are posted.
+*/
+func (s *accountService) SweepRoundUp(requestCtx context.Context, dbExecutor bun.IDB, transaction *model.Transaction) error {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	activeStatus := model.PocketActive
+	roundUpPockets, err := s.accountRepository.ListPockets(requestCtx, dbExecutor, types.PocketListOptions{
+		AccountID:      &transaction.AccountID,
+		Status:         &activeStatus,
+		RoundUpEnabled: true,
+	})
+	if err != nil {
+		return err
+	}
+	if len(roundUpPockets) == 0 {
+		return nil
+	}
+
+	roundUpTo := *roundUpPockets[0].RoundUpTo
+	remainder := transaction.Amount % roundUpTo
+	if remainder == 0 {
+		return nil
+	}
+	spareChange := roundUpTo - remainder
+
+	account, pocket, err := s.lockActivePocket(requestCtx, dbExecutor, transaction.AccountID, roundUpPockets[0].ID)
+	if err != nil {
+		return err
+	}
+
+	if account.OwnFunds() < spareChange {
+		logger.Info(requestCtx, "Skipping round-up of %d for transactionID: %s, accountID: %d does not have enough funds", spareChange, transaction.ID, account.ID)
+		return nil
+	}
+
+	_, err = s.movePocketFunds(requestCtx, dbExecutor, account, pocket, spareChange, model.PocketRoundUp, &transaction.ID)
+	return err
+}
+
+/*
+ReleaseRoundUp moves the spare change swept for a payment back to the main balance, when the payment is returned
+
+Nothing is moved when no spare change was swept for the payment or when its pocket has since been closed, the
+closing already moved it back. When the account holder has withdrawn from the pocket in the meantime, only what
+is left in the pocket is moved back.
+
+It must be called within a database transaction because it locks the account and pocket rows for update.
+*/
+func (s *accountService) ReleaseRoundUp(requestCtx context.Context, dbExecutor bun.IDB, transactionID uuid.UUID) error {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	roundUpType := model.PocketRoundUp
+	roundUps, err := s.accountRepository.ListPocketMovements(requestCtx, dbExecutor, types.PocketMovementListOptions{
+		Type:          &roundUpType,
+		TransactionID: &transactionID,
+	})
+	if err != nil {
+		return err
+	}
+	if len(roundUps) == 0 {
+		return nil
+	}
+
+	pocket, err := s.accountRepository.GetPocket(requestCtx, dbExecutor, types.PocketQueryOptions{
+		ID: &roundUps[0].PocketID,
+	})
+	if err != nil {
+		return err
+	}
+	if pocket.Status != model.PocketActive {
+		return nil
+	}
+
+	account, pocket, err := s.lockActivePocket(requestCtx, dbExecutor, pocket.AccountID, pocket.ID)
+	if err != nil {
+		return err
+	}
+
+	amount := min(roundUps[0].Amount, pocket.Balance)
+	if amount == 0 {
+		return nil
+	}
+
+	_, err = s.movePocketFunds(requestCtx, dbExecutor, account, pocket, -amount, model.PocketWithdrawal, &transactionID)
+	return err
+}
+
+// lockActivePocket locks the account and then its pocket for update, the pocket must be an active pocket of the account
+func (s *accountService) lockActivePocket(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, pocketID uuid.UUID) (*model.Account, *model.Pocket, error) {
+	// the account is always locked before its pocket, in the same order as every other change of the account balance
+	account, err := s.accountRepository.GetAccount(requestCtx, dbExecutor, types.AccountQueryOptions{
+		AccountID: &accountID,
+		ForUpdate: true,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pocket, err := s.accountRepository.GetPocket(requestCtx, dbExecutor, types.PocketQueryOptions{
+		ID:        &pocketID,
+		ForUpdate: true,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// a pocket of another account is reported as not found, so that its existence is not revealed
+	if pocket.AccountID != account.ID {
+		return nil, nil, &server.ApiError{
+			HttpStatusCode: http.StatusNotFound,
+			Message:        "Pocket not found",
+		}
+	}
+
+	if pocket.Status != model.PocketActive {
+		return nil, nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("Pocket %s is closed", pocket.Name),
+		}
+	}
+
+	return account, pocket, nil
+}
+
+/*
+movePocketFunds moves the amount from the main balance into the pocket, a negative amount moves it back
+
+The balance of the account is left as it is, only the part of it that is pocketed changes.
+The caller must have locked both the account and the pocket and checked that the amount can be moved.
+*/
+func (s *accountService) movePocketFunds(requestCtx context.Context, dbExecutor bun.IDB, account *model.Account, pocket *model.Pocket, amount int64, movementType model.PocketMovementType, transactionID *uuid.UUID) (*model.Pocket, error) {
+	newPocketedAmount := account.PocketedAmount + amount
+	_, err := s.accountRepository.UpdateAccount(requestCtx, dbExecutor, account.ID, types.AccountUpdateOptions{
+		NewPocketedAmount: &newPocketedAmount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	newBalance := pocket.Balance + amount
+	pocket, err = s.accountRepository.UpdatePocket(requestCtx, dbExecutor, pocket.ID, types.PocketUpdateOptions{
+		NewBalance: &newBalance,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	movementAmount := amount
+	if movementAmount < 0 {
+		movementAmount = -movementAmount
+	}
+	_, err = s.accountRepository.CreatePocketMovement(requestCtx, dbExecutor, &model.PocketMovement{
+		PocketID:      pocket.ID,
+		Type:          movementType,
+		Amount:        movementAmount,
+		BalanceAfter:  pocket.Balance,
+		TransactionID: transactionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pocket, nil
+}
+
+// validatePocketTargetDate checks that the target date of a pocket, when it is set, is still ahead
+func validatePocketTargetDate(targetDate *time.Time) error {
+	if targetDate == nil {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if !targetDate.After(today) {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Target date must be in the future",
+		}
+	}
+	return nil
+}
+
+/*
+validatePocketAgainstOthers checks a new or changed pocket against the other active pockets of the account
+
+The name of a pocket must be unique among them, ignoring case, and the round-ups of an account
+can only be swept into one of them. The pocket being changed is skipped, uuid.Nil for a new pocket.
+*/
+func validatePocketAgainstOthers(activePockets []model.Pocket, pocketID uuid.UUID, name *string, roundUpTo *int64) error {
+	for _, otherPocket := range activePockets {
+		if otherPocket.ID == pocketID {
+			continue
+		}
+
+		if name != nil && strings.EqualFold(otherPocket.Name, *name) {
+			return &server.ApiError{
+				HttpStatusCode: http.StatusConflict,
+				Message:        fmt.Sprintf("You already have a pocket named %s", otherPocket.Name),
+			}
+		}
+
+		if roundUpTo != nil && otherPocket.RoundUpTo != nil {
+			return &server.ApiError{
+				HttpStatusCode: http.StatusConflict,
+				Message:        fmt.Sprintf("Round-ups already go to the pocket %s, turn them off there first", otherPocket.Name),
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/config"
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/accountnumber"
+	"github.com/skamranahmed/go-bank/pkg/iso20022"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+/*
+BuildEndOfDayStatement returns the camt.053 statement of the account for a day (UTC) that has already ended
+
+The closing balance is the current balance less every transaction booked after the day ended,
+the opening balance is the closing balance less every transaction booked during the day.
+It must be called within a database transaction because it locks the account row for update,
+so that no transaction is booked while the balances are worked out.
+*/
+func (s *accountService) BuildEndOfDayStatement(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, statementDate time.Time) ([]byte, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	dayStart := time.Date(statementDate.Year(), statementDate.Month(), statementDate.Day(), 0, 0, 0, 0, time.UTC)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	now := time.Now().UTC()
+	if now.Before(dayEnd) {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "An end of day statement is only available once the day has ended",
+		}
+	}
+
+	account, err := s.accountRepository.GetAccount(requestCtx, dbExecutor, types.AccountQueryOptions{
+		AccountID: &accountID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bookedAfterDay, err := s.netTransactionAmount(requestCtx, dbExecutor, types.TransactionQueryOptions{
+		AccountID:    &account.ID,
+		CreatedAfter: &dayEnd,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.accountRepository.ListTransactions(requestCtx, dbExecutor, types.TransactionQueryOptions{
+		AccountID:     &account.ID,
+		CreatedAfter:  &dayStart,
+		CreatedBefore: &dayEnd,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the transactions are listed newest first, the statement lists them in the order they were booked
+	slices.Reverse(transactions)
+
+	closingBalance := account.Balance - bookedAfterDay
+	openingBalance := closingBalance
+
+	entries := make([]iso20022.StatementEntry, 0, len(transactions))
+	for _, transaction := range transactions {
+		entry := iso20022.StatementEntry{
+			Reference:       strings.ReplaceAll(transaction.ID.String(), "-", ""),
+			Amount:          transaction.Amount,
+			IsDebit:         transaction.Type.IsDebit(),
+			BookingTime:     transaction.CreatedAt,
+			TransactionCode: string(transaction.Type),
+		}
+		if transaction.ClientReference != nil {
+			entry.EndToEndID = *transaction.ClientReference
+		}
+		if transaction.Description != nil {
+			entry.Description = *transaction.Description
+		}
+		if transaction.Narration != nil {
+			entry.Description = strings.TrimSpace(entry.Description + " " + *transaction.Narration)
+		}
+
+		if entry.IsDebit {
+			openingBalance += transaction.Amount
+		} else {
+			openingBalance -= transaction.Amount
+		}
+		entries = append(entries, entry)
+	}
+
+	// the nominees are those registered when the statement is built
+	nominees, err := s.DescribeNominees(requestCtx, dbExecutor, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	bankConfig := config.GetBankConfig()
+	accountIBAN, err := accountnumber.IBAN(bankConfig.CountryCode, bankConfig.Code, account.BranchCode, account.ID)
+	if err != nil {
+		logger.Error(requestCtx, "Error while building IBAN of accountID: %d, error: %+v", account.ID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't build the statement at the moment. Please try again later.",
+		}
+	}
+
+	statementID := fmt.Sprintf("%d-%s", account.ID, dayStart.Format("20060102"))
+	content, err := iso20022.EncodeStatement(iso20022.Statement{
+		MessageID:      statementID + "-" + now.Format("150405"),
+		StatementID:    statementID,
+		CreationTime:   now,
+		From:           dayStart,
+		To:             dayEnd.Add(-time.Second),
+		AccountIBAN:    accountIBAN,
+		Currency:       string(account.Currency),
+		ServicerIFSC:   accountnumber.IFSC(bankConfig.Code, account.BranchCode),
+		OpeningBalance: openingBalance,
+		ClosingBalance: closingBalance,
+		Entries:        entries,
+
+		AdditionalInformation: nominees,
+	})
+	if err != nil {
+		logger.Error(requestCtx, "Error while encoding camt.053 statement of accountID: %d for date: %s, error: %+v", account.ID, dayStart.Format(time.DateOnly), err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't build the statement at the moment. Please try again later.",
+		}
+	}
+
+	return content, nil
+}
+
+// netTransactionAmount returns how much the matching transactions moved the balance of the account by, credits less debits
+func (s *accountService) netTransactionAmount(requestCtx context.Context, dbExecutor bun.IDB, options types.TransactionQueryOptions) (int64, error) {
+	total, err := s.accountRepository.SumTransactions(requestCtx, dbExecutor, options)
+	if err != nil {
+		return 0, err
+	}
+
+	options.Types = model.DebitTransactionTypes()
+	debitTotal, err := s.accountRepository.SumTransactions(requestCtx, dbExecutor, options)
+	if err != nil {
+		return 0, err
+	}
+
+	return total - 2*debitTotal, nil
+}
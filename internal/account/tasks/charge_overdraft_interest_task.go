@@ -0,0 +1,131 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/skamranahmed/go-bank/config"
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/uptrace/bun"
+)
+
+const ChargeOverdraftInterestTaskName string = "periodic_task:charge_overdraft_interest"
+
+// number of overdrawn accounts fetched from the database in one go
+const chargeOverdraftInterestBatchSize int = 100
+
+type ChargeOverdraftInterestTaskPayload struct {
+}
+
+type ChargeOverdraftInterestTask struct {
+	name          string
+	queue         string
+	cronSpec      string
+	maxRetryCount int
+	payload       ChargeOverdraftInterestTaskPayload
+}
+
+func NewChargeOverdraftInterestTask() tasksHelper.SchedulableTask {
+	return &ChargeOverdraftInterestTask{
+		name:          ChargeOverdraftInterestTaskName,
+		queue:         tasksHelper.DefaultQueue,
+		cronSpec:      "30 0 * * *", // run every day at 00:30
+		maxRetryCount: 3,
+		payload:       ChargeOverdraftInterestTaskPayload{},
+	}
+}
+
+func (t *ChargeOverdraftInterestTask) Name() string {
+	return t.name
+}
+
+func (t *ChargeOverdraftInterestTask) Queue() string {
+	return t.queue
+}
+
+func (t *ChargeOverdraftInterestTask) CronSpec() string {
+	return t.cronSpec
+}
+
+func (t *ChargeOverdraftInterestTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *ChargeOverdraftInterestTask) Payload() any {
+	return t.payload
+}
+
+type ChargeOverdraftInterestTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewChargeOverdraftInterestTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &ChargeOverdraftInterestTaskProcessor{
+		services: services,
+	}
+}
+
+/*
+ProcessTask charges a day's interest on every overdrawn account
+
+Each account is charged in its own database transaction so that a failure for one account
+does not roll back the interest already charged for the others. Since charging is idempotent
+per day, retrying the task after a partial failure only charges the accounts that were missed.
+*/
+func (processor *ChargeOverdraftInterestTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[ChargeOverdraftInterestTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	annualInterestRateInBasisPoints := config.GetOverdraftConfig().AnnualInterestRateInBasisPoints
+	chargeDate := time.Now().UTC()
+	accountType := model.CurrentAccount
+	zeroBalance := int64(0)
+
+	var afterID *int64
+	var failedCount int
+	for {
+		accounts, err := processor.services.AccountService.ListAccounts(ctx, nil, types.AccountListOptions{
+			Type:         &accountType,
+			BalanceBelow: &zeroBalance,
+			AfterID:      afterID,
+			Limit:        chargeOverdraftInterestBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, account := range accounts {
+			err := database.RunInTransaction(ctx, "chargeOverdraftInterest", processor.services.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+				_, err := processor.services.AccountService.ChargeOverdraftInterest(txCtx, tx, account.ID, annualInterestRateInBasisPoints, chargeDate)
+				return err
+			})
+			if err != nil {
+				failedCount++
+				logger.Error(ctx, "Unable to charge overdraft interest for accountID: %d, error: %+v", account.ID, err)
+			}
+		}
+
+		if len(accounts) < chargeOverdraftInterestBatchSize {
+			break
+		}
+		afterID = &accounts[len(accounts)-1].ID
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf("Unable to charge overdraft interest for %d account(s)", failedCount)
+	}
+
+	logger.Info(ctx, "Overdraft interest charged for date: %s", chargeDate.Format(time.DateOnly))
+	return nil
+}
@@ -0,0 +1,94 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+)
+
+const GenerateAccountStatementTaskName string = "task:generate_account_statement"
+
+type GenerateAccountStatementTaskPayload struct {
+	StatementRequestID string
+	AccountID          int64
+	FromDate           string
+	ToDate             string
+}
+
+type GenerateAccountStatementTask struct {
+	name          string
+	queue         string
+	maxRetryCount int
+	payload       GenerateAccountStatementTaskPayload
+}
+
+func NewGenerateAccountStatementTask(statementRequestID string, accountID int64, fromDate, toDate string) tasksHelper.Task {
+	return &GenerateAccountStatementTask{
+		name:          GenerateAccountStatementTaskName,
+		queue:         tasksHelper.DefaultQueue,
+		maxRetryCount: 3,
+		payload: GenerateAccountStatementTaskPayload{
+			StatementRequestID: statementRequestID,
+			AccountID:          accountID,
+			FromDate:           fromDate,
+			ToDate:             toDate,
+		},
+	}
+}
+
+func (t *GenerateAccountStatementTask) Name() string {
+	return t.name
+}
+
+func (t *GenerateAccountStatementTask) Queue() string {
+	return t.queue
+}
+
+func (t *GenerateAccountStatementTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *GenerateAccountStatementTask) Payload() any {
+	return t.payload
+}
+
+type GenerateAccountStatementTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewGenerateAccountStatementTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &GenerateAccountStatementTaskProcessor{
+		services: services,
+	}
+}
+
+/*
+ProcessTask generates the statement of the account for the requested period
+
+The statement lists the nominees of the account as registered when it is generated, rather than when it was requested.
+*/
+func (processor *GenerateAccountStatementTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[GenerateAccountStatementTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	nominees, err := processor.services.AccountService.DescribeNominees(ctx, nil, payload.Data.AccountID)
+	if err != nil {
+		logger.Error(ctx, "Unable to describe the nominees of accountID: %d for statement: %s, error: %+v", payload.Data.AccountID, payload.Data.StatementRequestID, err)
+		return err
+	}
+	if nominees == "" {
+		nominees = "No nominees registered"
+	}
+
+	// TODO: render the statement with its nominees and email it to the account holder
+	logger.Info(ctx, "[Dummy] generate statement: %s for accountID: %d from: %s to: %s, %s", payload.Data.StatementRequestID, payload.Data.AccountID, payload.Data.FromDate, payload.Data.ToDate, nominees)
+	return nil
+}
@@ -3,34 +3,94 @@ package types
 import (
 	"time"
 
+	"github.com/skamranahmed/go-bank/config"
 	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/pkg/accountnumber"
+	"github.com/skamranahmed/go-bank/pkg/money"
 )
 
 type AccountDto struct {
-	ID        int64             `json:"id"`
-	CreatedAt time.Time         `json:"created_at"`
-	UpdatedAt time.Time         `json:"updated_at"`
-	UserID    string            `json:"user_id"`
-	Balance   int64             `json:"balance"`
-	Type      model.AccountType `json:"type"`
+	ID               int64             `json:"id"`
+	CreatedAt        time.Time         `json:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+	UserID           string            `json:"user_id"`
+	Currency         string            `json:"currency"`
+	Balance          int64             `json:"balance"`
+	OverdraftLimit   int64             `json:"overdraft_limit"`
+	HeldAmount       int64             `json:"held_amount"`
+	PocketedAmount   int64             `json:"pocketed_amount"`
+	AvailableBalance int64             `json:"available_balance"`
+	Type             model.AccountType `json:"type"`
+
+	Mandate                model.AccountMandate `json:"mandate"`
+	JointApprovalThreshold int64                `json:"joint_approval_threshold"`
+
+	// external identifiers partners address the account by, the IBAN is left out when the codes of the bank cannot form one
+	IFSC string `json:"ifsc"`
+	IBAN string `json:"iban,omitempty"`
 }
 
 type GetAccountsResponse struct {
 	Data []AccountDto `json:"data"`
 }
 
+// AccountDetailsDto is the account along with its joint holders, the active pockets it earmarks part of its balance in and its nominees
+type AccountDetailsDto struct {
+	AccountDto
+	Holders  []AccountHolderDto `json:"holders"`
+	Pockets  []PocketDto        `json:"pockets"`
+	Nominees []NomineeDto       `json:"nominees"`
+}
+
 type GetAccountByIDResponse struct {
+	Data AccountDetailsDto `json:"data"`
+}
+
+type OpenAccountRequest struct {
+	Data OpenAccountRequestData `json:"data" binding:"required"`
+}
+
+type OpenAccountRequestData struct {
+	Type     string `json:"type" binding:"required,oneof=SAVINGS_ACCOUNT CURRENT_ACCOUNT"`
+	Currency string `json:"currency" binding:"required,currency"`
+}
+
+type OpenAccountResponse struct {
+	Data AccountDto `json:"data"`
+}
+
+type UpdateOverdraftLimitRequest struct {
+	Data UpdateOverdraftLimitRequestData `json:"data" binding:"required"`
+}
+
+type UpdateOverdraftLimitRequestData struct {
+	OverdraftLimit *int64 `json:"overdraft_limit" binding:"required,gte=0"`
+}
+
+type UpdateOverdraftLimitResponse struct {
 	Data AccountDto `json:"data"`
 }
 
 func TransformToAccountDto(account *model.Account) *AccountDto {
+	bankConfig := config.GetBankConfig()
+	iban, _ := accountnumber.IBAN(bankConfig.CountryCode, bankConfig.Code, account.BranchCode, account.ID)
 	return &AccountDto{
-		ID:        account.ID,
-		CreatedAt: account.CreatedAt,
-		UpdatedAt: account.UpdatedAt,
-		UserID:    account.UserID.String(),
-		Balance:   account.Balance,
-		Type:      account.Type,
+		ID:               account.ID,
+		CreatedAt:        account.CreatedAt,
+		UpdatedAt:        account.UpdatedAt,
+		UserID:           account.UserID.String(),
+		Currency:         string(account.Currency),
+		Balance:          account.Balance,
+		OverdraftLimit:   account.OverdraftLimit,
+		HeldAmount:       account.HeldAmount,
+		PocketedAmount:   account.PocketedAmount,
+		AvailableBalance: account.AvailableBalance(),
+		Type:             account.Type,
+
+		Mandate:                account.Mandate,
+		JointApprovalThreshold: account.JointApprovalThreshold,
+		IFSC:                   accountnumber.IFSC(bankConfig.Code, account.BranchCode),
+		IBAN:                   iban,
 	}
 }
 
@@ -48,16 +108,420 @@ type TransactionDto struct {
 	AccountID    int64     `json:"account_id"`
 	Amount       int64     `json:"amount"`
 	Type         string    `json:"type"`
+	Status       string    `json:"status"`
 	BalanceAfter int64     `json:"balance_after"`
+
+	Description     *string `json:"description"`
+	Narration       *string `json:"narration"`
+	ClientReference *string `json:"client_reference"`
+	Category        *string `json:"category"`
 }
 
 func TransformToTransactionDto(transaction *model.Transaction) *TransactionDto {
+	var category *string
+	if transaction.Category != nil {
+		value := string(*transaction.Category)
+		category = &value
+	}
+
 	return &TransactionDto{
 		ID:           transaction.ID.String(),
 		CreatedAt:    transaction.CreatedAt,
 		AccountID:    transaction.AccountID,
 		Amount:       transaction.Amount,
 		Type:         string(transaction.Type),
+		Status:       string(transaction.Status),
 		BalanceAfter: transaction.BalanceAfter,
+
+		Description:     transaction.Description,
+		Narration:       transaction.Narration,
+		ClientReference: transaction.ClientReference,
+		Category:        category,
+	}
+}
+
+type NameEnquiryRequestQuery struct {
+	// the account is referenced either by its account number or by its IBAN
+	AccountID int64  `form:"account_id" binding:"required_without=IBAN,omitempty,account_number"`
+	IBAN      string `form:"iban"`
+}
+
+type NameEnquiryDto struct {
+	AccountID  int64  `json:"account_id"`
+	HolderName string `json:"holder_name"`
+}
+
+type NameEnquiryResponse struct {
+	Data NameEnquiryDto `json:"data"`
+}
+
+type GetTransactionsRequestQuery struct {
+	Limit  int `form:"limit" binding:"omitempty,gte=1,lte=100"`
+	Offset int `form:"offset" binding:"omitempty,gte=0"`
+}
+
+type GetTransactionsResponse struct {
+	Data []TransactionDto `json:"data"`
+}
+
+func TransformToTransactionDtoList(transactions []model.Transaction) []TransactionDto {
+	transactionDtos := make([]TransactionDto, 0, len(transactions))
+	for _, transaction := range transactions {
+		transactionDtos = append(transactionDtos, *TransformToTransactionDto(&transaction))
+	}
+	return transactionDtos
+}
+
+type RequestStatementRequest struct {
+	Data RequestStatementRequestData `json:"data" binding:"required"`
+}
+
+type RequestStatementRequestData struct {
+	FromDate string `json:"from_date" binding:"required,datetime=2006-01-02"`
+	ToDate   string `json:"to_date" binding:"required,datetime=2006-01-02"`
+}
+
+type StatementRequestDto struct {
+	ID        string `json:"id"`
+	AccountID int64  `json:"account_id"`
+	FromDate  string `json:"from_date"`
+	ToDate    string `json:"to_date"`
+
+	// FeeCharged is the fee (including tax) debited for the statement, zero when covered by the free quota
+	FeeCharged int64 `json:"fee_charged"`
+}
+
+type RequestStatementResponse struct {
+	Data StatementRequestDto `json:"data"`
+}
+
+type GetEndOfDayStatementRequestQuery struct {
+	Date string `form:"date" binding:"required,datetime=2006-01-02"`
+}
+
+type EndOfDayStatementDto struct {
+	FileName string `json:"file_name"`
+
+	// Content is the camt.053 XML Document of the statement
+	Content string `json:"content"`
+}
+
+type GetEndOfDayStatementResponse struct {
+	Data EndOfDayStatementDto `json:"data"`
+}
+
+type CreatePocketRequest struct {
+	Data CreatePocketRequestData `json:"data" binding:"required"`
+}
+
+type CreatePocketRequestData struct {
+	Name         string `json:"name" binding:"required,min=1,max=50"`
+	TargetAmount int64  `json:"target_amount" binding:"required,gt=0"`
+	TargetDate   string `json:"target_date" binding:"omitempty,datetime=2006-01-02"`
+
+	// RoundUpTo is in whole units of the currency of the account, eg: 10 to round payments up to the next 10 INR
+	RoundUpTo *int64 `json:"round_up_to" binding:"omitempty,oneof=1 10 100"`
+}
+
+type CreatePocketResponse struct {
+	Data PocketDto `json:"data"`
+}
+
+type UpdatePocketRequest struct {
+	Data UpdatePocketRequestData `json:"data" binding:"required"`
+}
+
+// UpdatePocketRequestData holds the fields of the pocket being changed, a round_up_to of 0 turns the round-ups off
+type UpdatePocketRequestData struct {
+	Name         *string `json:"name" binding:"omitempty,min=1,max=50"`
+	TargetAmount *int64  `json:"target_amount" binding:"omitempty,gt=0"`
+	TargetDate   string  `json:"target_date" binding:"omitempty,datetime=2006-01-02"`
+	RoundUpTo    *int64  `json:"round_up_to" binding:"omitempty,oneof=0 1 10 100"`
+}
+
+type UpdatePocketResponse struct {
+	Data PocketDto `json:"data"`
+}
+
+type MovePocketFundsRequest struct {
+	Data MovePocketFundsRequestData `json:"data" binding:"required"`
+}
+
+type MovePocketFundsRequestData struct {
+	Amount int64 `json:"amount" binding:"required,gt=0"`
+}
+
+type MovePocketFundsResponse struct {
+	Data PocketDto `json:"data"`
+}
+
+type ClosePocketResponse struct {
+	Data PocketDto `json:"data"`
+}
+
+type GetPocketsResponse struct {
+	Data []PocketDto `json:"data"`
+}
+
+type GetPocketMovementsRequestQuery struct {
+	Limit  int `form:"limit" binding:"omitempty,gte=1,lte=100"`
+	Offset int `form:"offset" binding:"omitempty,gte=0"`
+}
+
+type GetPocketMovementsResponse struct {
+	Data []PocketMovementDto `json:"data"`
+}
+
+type PocketDto struct {
+	ID           string     `json:"id"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	AccountID    int64      `json:"account_id"`
+	Name         string     `json:"name"`
+	Balance      int64      `json:"balance"`
+	TargetAmount int64      `json:"target_amount"`
+	TargetDate   *string    `json:"target_date"`
+	Status       string     `json:"status"`
+	ClosedAt     *time.Time `json:"closed_at"`
+
+	// RoundUpTo is in whole units of the currency of the account, nil when the round-ups of the account go elsewhere
+	RoundUpTo *int64 `json:"round_up_to"`
+}
+
+// TransformToPocketDto needs the currency of the account of the pocket to give its round-up in whole units
+func TransformToPocketDto(pocket *model.Pocket, currency money.Currency) *PocketDto {
+	var targetDate *string
+	if pocket.TargetDate != nil {
+		value := pocket.TargetDate.Format("2006-01-02")
+		targetDate = &value
+	}
+
+	var roundUpTo *int64
+	if pocket.RoundUpTo != nil {
+		value := *pocket.RoundUpTo / money.FromWholeUnits(1, currency).Amount
+		roundUpTo = &value
+	}
+
+	return &PocketDto{
+		ID:           pocket.ID.String(),
+		CreatedAt:    pocket.CreatedAt,
+		UpdatedAt:    pocket.UpdatedAt,
+		AccountID:    pocket.AccountID,
+		Name:         pocket.Name,
+		Balance:      pocket.Balance,
+		TargetAmount: pocket.TargetAmount,
+		TargetDate:   targetDate,
+		Status:       string(pocket.Status),
+		ClosedAt:     pocket.ClosedAt,
+		RoundUpTo:    roundUpTo,
+	}
+}
+
+func TransformToPocketDtoList(pockets []model.Pocket, currency money.Currency) []PocketDto {
+	pocketDtos := make([]PocketDto, 0, len(pockets))
+	for _, pocket := range pockets {
+		pocketDtos = append(pocketDtos, *TransformToPocketDto(&pocket, currency))
+	}
+	return pocketDtos
+}
+
+type PocketMovementDto struct {
+	ID            string    `json:"id"`
+	CreatedAt     time.Time `json:"created_at"`
+	PocketID      string    `json:"pocket_id"`
+	Type          string    `json:"type"`
+	Amount        int64     `json:"amount"`
+	BalanceAfter  int64     `json:"balance_after"`
+	TransactionID *string   `json:"transaction_id"`
+}
+
+func TransformToPocketMovementDto(pocketMovement *model.PocketMovement) *PocketMovementDto {
+	var transactionID *string
+	if pocketMovement.TransactionID != nil {
+		value := pocketMovement.TransactionID.String()
+		transactionID = &value
+	}
+
+	return &PocketMovementDto{
+		ID:            pocketMovement.ID.String(),
+		CreatedAt:     pocketMovement.CreatedAt,
+		PocketID:      pocketMovement.PocketID.String(),
+		Type:          string(pocketMovement.Type),
+		Amount:        pocketMovement.Amount,
+		BalanceAfter:  pocketMovement.BalanceAfter,
+		TransactionID: transactionID,
+	}
+}
+
+func TransformToPocketMovementDtoList(pocketMovements []model.PocketMovement) []PocketMovementDto {
+	pocketMovementDtos := make([]PocketMovementDto, 0, len(pocketMovements))
+	for _, pocketMovement := range pocketMovements {
+		pocketMovementDtos = append(pocketMovementDtos, *TransformToPocketMovementDto(&pocketMovement))
+	}
+	return pocketMovementDtos
+}
+
+type InviteAccountHolderRequest struct {
+	Data InviteAccountHolderRequestData `json:"data" binding:"required"`
+}
+
+type InviteAccountHolderRequestData struct {
+	Username string `json:"username" binding:"required"`
+}
+
+type InviteAccountHolderResponse struct {
+	Data AccountHolderDto `json:"data"`
+}
+
+type GetAccountHoldersResponse struct {
+	Data []AccountHolderDto `json:"data"`
+}
+
+type RemoveAccountHolderResponse struct {
+	Data AccountHolderDto `json:"data"`
+}
+
+type GetAccountHolderInvitationsResponse struct {
+	Data []AccountHolderDto `json:"data"`
+}
+
+type RespondToAccountHolderInvitationResponse struct {
+	Data AccountHolderDto `json:"data"`
+}
+
+type UpdateMandateRequest struct {
+	Data UpdateMandateRequestData `json:"data" binding:"required"`
+}
+
+type UpdateMandateRequestData struct {
+	Mandate string `json:"mandate" binding:"required,oneof=EITHER_OR_SURVIVOR JOINTLY"`
+
+	// JointApprovalThreshold is required for the JOINTLY mandate, debits above it need the approval of every holder
+	JointApprovalThreshold *int64 `json:"joint_approval_threshold" binding:"omitempty,gte=0"`
+}
+
+type UpdateMandateResponse struct {
+	Data AccountDto `json:"data"`
+}
+
+type AccountHolderDto struct {
+	ID              string     `json:"id"`
+	CreatedAt       time.Time  `json:"created_at"`
+	AccountID       int64      `json:"account_id"`
+	UserID          string     `json:"user_id"`
+	InvitedByUserID string     `json:"invited_by_user_id"`
+	Status          string     `json:"status"`
+	RespondedAt     *time.Time `json:"responded_at"`
+}
+
+func TransformToAccountHolderDto(accountHolder *model.AccountHolder) *AccountHolderDto {
+	return &AccountHolderDto{
+		ID:              accountHolder.ID.String(),
+		CreatedAt:       accountHolder.CreatedAt,
+		AccountID:       accountHolder.AccountID,
+		UserID:          accountHolder.UserID.String(),
+		InvitedByUserID: accountHolder.InvitedByUserID.String(),
+		Status:          string(accountHolder.Status),
+		RespondedAt:     accountHolder.RespondedAt,
+	}
+}
+
+func TransformToAccountHolderDtoList(accountHolders []model.AccountHolder) []AccountHolderDto {
+	accountHolderDtos := make([]AccountHolderDto, 0, len(accountHolders))
+	for _, accountHolder := range accountHolders {
+		accountHolderDtos = append(accountHolderDtos, *TransformToAccountHolderDto(&accountHolder))
+	}
+	return accountHolderDtos
+}
+
+type NomineeRequestData struct {
+	Name            string `json:"name" binding:"required,min=1,max=100"`
+	Relationship    string `json:"relationship" binding:"required,oneof=SPOUSE CHILD PARENT SIBLING OTHER"`
+	DateOfBirth     string `json:"date_of_birth" binding:"required,datetime=2006-01-02"`
+	SharePercentage int    `json:"share_percentage" binding:"required,gte=1,lte=100"`
+
+	// the guardian is required for a minor nominee and not allowed for an adult one
+	GuardianName         *string `json:"guardian_name" binding:"omitempty,min=1,max=100"`
+	GuardianRelationship *string `json:"guardian_relationship" binding:"omitempty,min=1,max=50"`
+
+	// OtherShares are the new shares of the other nominees of the account, so that the shares still add up to 100
+	OtherShares []NomineeShareRequestData `json:"other_shares" binding:"omitempty,dive"`
+}
+
+type NomineeShareRequestData struct {
+	NomineeID       string `json:"nominee_id" binding:"required,uuid"`
+	SharePercentage int    `json:"share_percentage" binding:"required,gte=1,lte=100"`
+}
+
+type AddNomineeRequest struct {
+	Data NomineeRequestData `json:"data" binding:"required"`
+}
+
+type AddNomineeResponse struct {
+	Data NomineeDto `json:"data"`
+}
+
+type UpdateNomineeRequest struct {
+	Data NomineeRequestData `json:"data" binding:"required"`
+}
+
+type UpdateNomineeResponse struct {
+	Data NomineeDto `json:"data"`
+}
+
+// RemoveNomineeRequest hands the share of the removed nominee over to the remaining ones, it is not needed to remove the last nominee
+type RemoveNomineeRequest struct {
+	Data RemoveNomineeRequestData `json:"data" binding:"required"`
+}
+
+type RemoveNomineeRequestData struct {
+	OtherShares []NomineeShareRequestData `json:"other_shares" binding:"omitempty,dive"`
+}
+
+type RemoveNomineeResponse struct {
+	Data NomineeDto `json:"data"`
+}
+
+type GetNomineesResponse struct {
+	Data []NomineeDto `json:"data"`
+}
+
+type NomineeDto struct {
+	ID                   string     `json:"id"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+	AccountID            int64      `json:"account_id"`
+	Name                 string     `json:"name"`
+	Relationship         string     `json:"relationship"`
+	DateOfBirth          string     `json:"date_of_birth"`
+	SharePercentage      int        `json:"share_percentage"`
+	GuardianName         *string    `json:"guardian_name"`
+	GuardianRelationship *string    `json:"guardian_relationship"`
+	Status               string     `json:"status"`
+	RemovedAt            *time.Time `json:"removed_at"`
+}
+
+func TransformToNomineeDto(nominee *model.Nominee) *NomineeDto {
+	return &NomineeDto{
+		ID:                   nominee.ID.String(),
+		CreatedAt:            nominee.CreatedAt,
+		UpdatedAt:            nominee.UpdatedAt,
+		AccountID:            nominee.AccountID,
+		Name:                 nominee.Name,
+		Relationship:         string(nominee.Relationship),
+		DateOfBirth:          nominee.DateOfBirth.Format("2006-01-02"),
+		SharePercentage:      nominee.SharePercentage,
+		GuardianName:         nominee.GuardianName,
+		GuardianRelationship: nominee.GuardianRelationship,
+		Status:               string(nominee.Status),
+		RemovedAt:            nominee.RemovedAt,
+	}
+}
+
+func TransformToNomineeDtoList(nominees []model.Nominee) []NomineeDto {
+	nomineeDtos := make([]NomineeDto, 0, len(nominees))
+	for _, nominee := range nominees {
+		nomineeDtos = append(nomineeDtos, *TransformToNomineeDto(&nominee))
 	}
+	return nomineeDtos
 }
@@ -1,5 +1,12 @@
 package types
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/account/model"
+)
+
 type AccountQueryOptions struct {
 	AccountID *int64
 	Columns   []string
@@ -8,6 +15,142 @@ type AccountQueryOptions struct {
 	ForUpdate bool
 }
 
+type AccountListOptions struct {
+	Type *model.AccountType
+
+	// When set, only accounts with a balance strictly below this value are returned
+	BalanceBelow *int64
+
+	// keyset pagination: only accounts with an ID greater than AfterID are returned, ordered by ID
+	AfterID *int64
+	Limit   int
+}
+
 type AccountUpdateOptions struct {
-	NewBalance *int64
+	NewBalance        *int64
+	NewOverdraftLimit *int64
+	NewHeldAmount     *int64
+	NewPocketedAmount *int64
+
+	NewMandate                *model.AccountMandate
+	NewJointApprovalThreshold *int64
+}
+
+type TransactionGetOptions struct {
+	ID *uuid.UUID
+
+	// When set, the CREDIT of the transfer paid by this DEBIT is returned
+	CounterpartTransactionID *uuid.UUID
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type TransactionUpdateOptions struct {
+	NewStatus *model.TransactionStatus
+}
+
+type TransactionQueryOptions struct {
+	AccountID    *int64
+	Types        []model.TransactionType
+	CreatedAfter *time.Time
+
+	// CreatedBefore is exclusive, a transaction created at exactly this time is left out
+	CreatedBefore *time.Time
+
+	// ChargeDate matches the OVERDRAFT_INTEREST charged for the day, see model.Transaction.ChargeDate
+	ChargeDate *time.Time
+
+	// When set, the DEBITs whose CREDIT was paid to an account of one of these types are left out, see model.Transaction.CounterpartTransactionID
+	ExcludeCounterpartAccountTypes []model.AccountType
+
+	// pagination, only applied when listing transactions, ignored when counting them
+	Limit  int
+	Offset int
+}
+
+type PocketQueryOptions struct {
+	ID *uuid.UUID
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type PocketListOptions struct {
+	AccountID *int64
+	Status    *model.PocketStatus
+
+	// When true, only the pocket the round-ups of the account are swept into is listed
+	RoundUpEnabled bool
+}
+
+type PocketUpdateOptions struct {
+	NewName         *string
+	NewTargetAmount *int64
+	NewTargetDate   *time.Time
+	NewBalance      *int64
+	NewStatus       *model.PocketStatus
+	NewClosedAt     *time.Time
+
+	// a zero NewRoundUpTo turns the round-ups of the pocket off
+	NewRoundUpTo *int64
+}
+
+type PocketMovementListOptions struct {
+	PocketID      *uuid.UUID
+	Type          *model.PocketMovementType
+	TransactionID *uuid.UUID
+
+	// pagination
+	Limit  int
+	Offset int
+}
+
+type AccountHolderQueryOptions struct {
+	ID        *uuid.UUID
+	AccountID *int64
+	UserID    *uuid.UUID
+	Statuses  []model.AccountHolderStatus
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type AccountHolderListOptions struct {
+	AccountID *int64
+	UserID    *uuid.UUID
+	Statuses  []model.AccountHolderStatus
+}
+
+type AccountHolderUpdateOptions struct {
+	NewStatus      *model.AccountHolderStatus
+	NewRespondedAt *time.Time
+	NewRemovedAt   *time.Time
+}
+
+type NomineeQueryOptions struct {
+	ID        *uuid.UUID
+	AccountID *int64
+	Status    *model.NomineeStatus
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type NomineeListOptions struct {
+	AccountID *int64
+	Status    *model.NomineeStatus
+}
+
+type NomineeUpdateOptions struct {
+	NewName            *string
+	NewRelationship    *model.NomineeRelationship
+	NewDateOfBirth     *time.Time
+	NewSharePercentage *int
+	NewStatus          *model.NomineeStatus
+	NewRemovedAt       *time.Time
+
+	// an empty NewGuardianName or NewGuardianRelationship clears it, eg: once the nominee is no longer a minor
+	NewGuardianName         *string
+	NewGuardianRelationship *string
 }
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/account/model"
+)
+
+// Remittance is the information a sender attaches to a transfer, every field is optional
+type Remittance struct {
+	Narration       *string
+	ClientReference *string
+	Category        *model.TransactionCategory
+}
+
+type CreatePocketParams struct {
+	Name         string
+	TargetAmount int64
+	TargetDate   *time.Time
+
+	// RoundUpTo is in whole units of the currency of the account, eg: 10 to round payments up to the next 10 INR
+	RoundUpTo *int64
+}
+
+// UpdatePocketParams holds the fields of a pocket being changed, a nil field is left as it is
+type UpdatePocketParams struct {
+	Name         *string
+	TargetAmount *int64
+	TargetDate   *time.Time
+
+	// RoundUpTo is in whole units of the currency of the account, zero turns the round-ups of the pocket off
+	RoundUpTo *int64
+}
+
+// NomineeParams holds the details of a nominee being added or replacing those of an existing nominee
+type NomineeParams struct {
+	Name            string
+	Relationship    model.NomineeRelationship
+	DateOfBirth     time.Time
+	SharePercentage int
+
+	// the guardian is required for a minor nominee and not allowed for an adult one
+	GuardianName         *string
+	GuardianRelationship *string
+}
+
+// NomineeShare is the new share of one of the other nominees of the account, given so that the shares still add up to 100
+type NomineeShare struct {
+	NomineeID       uuid.UUID
+	SharePercentage int
+}
@@ -7,7 +7,9 @@ import (
 
 	"github.com/alexedwards/argon2id"
 	"github.com/gin-gonic/gin"
+	"github.com/skamranahmed/go-bank/cmd/middleware"
 	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/config"
 	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
 	accountService "github.com/skamranahmed/go-bank/internal/account/service"
 	"github.com/skamranahmed/go-bank/internal/authentication/dto"
@@ -17,6 +19,7 @@ import (
 	"github.com/skamranahmed/go-bank/internal/user/types"
 	"github.com/skamranahmed/go-bank/pkg/database"
 	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/skamranahmed/go-bank/pkg/money"
 	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
 	"github.com/uptrace/bun"
 )
@@ -51,7 +54,7 @@ func (c *authenticationController) SignUp(ginCtx *gin.Context) {
 	var userID, accessToken string
 	err := database.RunInTransaction(requestCtx, "signUpTx", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
 		// create user record
-		userDto, err := c.userService.CreateUser(txCtx, tx, payload.Data.Email, payload.Data.Password, payload.Data.Username)
+		userDto, err := c.userService.CreateUser(txCtx, tx, payload.Data.Email, payload.Data.Password, payload.Data.Username, payload.Data.FirstName, payload.Data.LastName)
 		if err != nil {
 			return err
 		}
@@ -60,8 +63,8 @@ func (c *authenticationController) SignUp(ginCtx *gin.Context) {
 
 		// create an account for user
 		// currently the API doesn't provide the option to the user to choose the account type during user registration
-		// default account type is SAVINGS_ACCOUNT
-		err = c.accountService.CreateAccount(txCtx, tx, userDto.ID, accountModel.SavingsAccount)
+		// default account type is SAVINGS_ACCOUNT, held in the home currency of the bank
+		_, err = c.accountService.CreateAccount(txCtx, tx, userDto.ID, accountModel.SavingsAccount, money.Currency(config.GetBankConfig().Currency))
 		if err != nil {
 			return err
 		}
@@ -163,3 +166,53 @@ func (c *authenticationController) Login(ginCtx *gin.Context) {
 		AccessToken: accessToken,
 	})
 }
+
+/*
+StepUp confirms the identity of the authenticated user by their password and returns a step-up token
+
+Sensitive actions, like changing the nominees of an account, require the token on top of the access token,
+so that a stolen access token alone is not enough to perform them.
+*/
+func (c *authenticationController) StepUp(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userID, ok := requestCtx.Value(middleware.ContextUserIDKey).(string)
+	if !ok || userID == "" {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusUnauthorized,
+			Message:        "User not authenticated",
+		})
+		return
+	}
+
+	var payload dto.StepUpRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	doesPasswordMatch, err := c.userService.VerifyPassword(requestCtx, nil, userID, payload.Data.Password)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	if !doesPasswordMatch {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusUnauthorized,
+			Message:        "Password is incorrect",
+		})
+		return
+	}
+
+	stepUpToken, err := c.authenticationService.CreateStepUpToken(requestCtx, userID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, dto.StepUpResponse{
+		StepUpToken:      stepUpToken,
+		ExpiresInSeconds: config.GetAuthConfig().StepUpTokenExpiryDurationInSeconds,
+	})
+}
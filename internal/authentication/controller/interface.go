@@ -5,4 +5,5 @@ import "github.com/gin-gonic/gin"
 type AuthenticationController interface {
 	SignUp(ginCtx *gin.Context)
 	Login(ginCtx *gin.Context)
+	StepUp(ginCtx *gin.Context)
 }
@@ -1,10 +1,15 @@
 package controller
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/skamranahmed/go-bank/cmd/middleware"
+	"github.com/skamranahmed/go-bank/config"
 	accountService "github.com/skamranahmed/go-bank/internal/account/service"
 	authenticationService "github.com/skamranahmed/go-bank/internal/authentication/service"
 	userService "github.com/skamranahmed/go-bank/internal/user/service"
+	"github.com/skamranahmed/go-bank/pkg/cache"
 	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
 	"github.com/uptrace/bun"
 )
@@ -15,10 +20,15 @@ type Dependency struct {
 	UserService           userService.UserService
 	AccountService        accountService.AccountService
 	TaskEnqueuer          tasksHelper.TaskEnqueuer
+	CacheClient           cache.CacheClient
 }
 
 func Register(router *gin.Engine, dependency Dependency) {
 	authenticationController := newAuthenticationController(dependency)
 	router.POST("/v1/sign-up", authenticationController.SignUp)
 	router.POST("/v1/login", authenticationController.Login)
+
+	// a stolen access token could be used to guess the password through step-ups, so they are rate limited per user
+	authConfig := config.GetAuthConfig()
+	router.POST("/v1/step-up", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.RateLimitMiddleware(dependency.CacheClient, "step_up", authConfig.StepUpMaxRequestsPerWindow, time.Duration(authConfig.StepUpWindowInSeconds)*time.Second), authenticationController.StepUp)
 }
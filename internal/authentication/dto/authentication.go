@@ -8,6 +8,10 @@ type SignUpData struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=8"`
 	Username string `json:"username" binding:"required,min=8"`
+
+	// the legal name of the customer, shown masked to those who look up the holder of one of their accounts
+	FirstName string `json:"first_name" binding:"required,max=50"`
+	LastName  string `json:"last_name" binding:"required,max=50"`
 }
 
 type SignUpResponse struct {
@@ -26,3 +30,17 @@ type LoginData struct {
 type LoginResponse struct {
 	AccessToken string `json:"access_token"`
 }
+
+type StepUpRequest struct {
+	Data StepUpData `json:"data" binding:"required"`
+}
+
+type StepUpData struct {
+	Password string `json:"password" binding:"required"`
+}
+
+type StepUpResponse struct {
+	// StepUpToken is sent in the X-Step-Up-Token header of the sensitive action it confirms, it can be used once
+	StepUpToken      string `json:"step_up_token"`
+	ExpiresInSeconds int    `json:"expires_in_seconds"`
+}
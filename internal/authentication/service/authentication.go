@@ -133,6 +133,44 @@ func (s *authenticationService) VerifyAccessToken(requestCtx context.Context, to
 	}, nil
 }
 
+/*
+CreateStepUpToken returns a token confirming that the user has just re-entered their password, the caller must have verified it
+
+The token is an opaque random value stored in the cache for a few minutes, sensitive actions require it on top of the access token.
+It can be used once, see ConsumeStepUpToken.
+*/
+func (s *authenticationService) CreateStepUpToken(requestCtx context.Context, userID string) (string, error) {
+	stepUpTokenExpiryTTL := time.Duration(config.GetAuthConfig().StepUpTokenExpiryDurationInSeconds) * time.Second
+
+	stepUpToken := uuid.NewString()
+	stepUpTokenCacheKey := fmt.Sprintf("auth:step_up_token:%v:user_id:%v", stepUpToken, userID)
+	err := s.cacheClient.SetWithTTL(requestCtx, stepUpTokenCacheKey, "", stepUpTokenExpiryTTL)
+	if err != nil {
+		logger.Error(requestCtx, "Failed to cache step-up token, error: %+v", err)
+		return "", &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "Unable to confirm your identity. Please try again later.",
+		}
+	}
+
+	return stepUpToken, nil
+}
+
+// ConsumeStepUpToken verifies that the step-up token was issued to the user and has not expired, it invalidates the token so that it confirms a single action
+func (s *authenticationService) ConsumeStepUpToken(requestCtx context.Context, userID string, stepUpToken string) error {
+	stepUpTokenCacheKey := fmt.Sprintf("auth:step_up_token:%v:user_id:%v", stepUpToken, userID)
+	_, err := s.cacheClient.GetAndDelete(requestCtx, stepUpTokenCacheKey)
+	if err != nil {
+		// a missing key means the token was never issued to the user, has expired or was already used
+		return &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "This action needs a valid step-up token, confirm your password to get one",
+		}
+	}
+
+	return nil
+}
+
 func (s *authenticationService) createToken(requestCtx context.Context, payload any, secretSigningKey string) (string, error) {
 	claims := jwt.MapClaims{}
 
@@ -5,4 +5,6 @@ import "context"
 type AuthenticationService interface {
 	CreateAccessToken(requestCtx context.Context, userID string) (string, error)
 	VerifyAccessToken(requestCtx context.Context, tokenString string) (*AccessTokenPayload, error)
+	CreateStepUpToken(requestCtx context.Context, userID string) (string, error)
+	ConsumeStepUpToken(requestCtx context.Context, userID string, stepUpToken string) error
 }
@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/middleware"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	accountService "github.com/skamranahmed/go-bank/internal/account/service"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	balanceService "github.com/skamranahmed/go-bank/internal/balance/service"
+	"github.com/skamranahmed/go-bank/internal/balance/types"
+	"github.com/skamranahmed/go-bank/pkg/accountnumber"
+)
+
+const (
+	// number of days returned when the client does not specify a range
+	defaultBalanceHistoryDays = 30
+
+	// upper bound on the number of days that can be requested in one go
+	maxBalanceHistoryDays = 366
+)
+
+type balanceController struct {
+	accountService accountService.AccountService
+	balanceService balanceService.BalanceService
+}
+
+func newBalanceController(dependency Dependency) BalanceController {
+	return &balanceController{
+		accountService: dependency.AccountService,
+		balanceService: dependency.BalanceService,
+	}
+}
+
+func (c *balanceController) GetBalanceHistory(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	// extract user ID from the request context
+	userID, ok := requestCtx.Value(middleware.ContextUserIDKey).(string)
+	if !ok || userID == "" {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusUnauthorized,
+			Message:        "User not authenticated",
+		})
+		return
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid user ID",
+		})
+		return
+	}
+
+	// extract account ID from URL parameter
+	accountID, err := strconv.ParseInt(ginCtx.Param("account_id"), 10, 64)
+	if err != nil || !accountnumber.IsValid(accountID) {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid account ID",
+		})
+		return
+	}
+
+	var query types.GetBalanceHistoryRequestQuery
+	isSuccess := server.BindAndValidateIncomingRequestQuery(ginCtx, &query)
+	if !isSuccess {
+		return
+	}
+
+	// by default, the range ends on the last day that has a snapshot i.e yesterday
+	now := time.Now().UTC()
+	toDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+	if query.ToDate != "" {
+		// the format is already validated by the binding
+		toDate, _ = time.Parse(time.DateOnly, query.ToDate)
+	}
+
+	fromDate := toDate.AddDate(0, 0, -(defaultBalanceHistoryDays - 1))
+	if query.FromDate != "" {
+		fromDate, _ = time.Parse(time.DateOnly, query.FromDate)
+	}
+
+	if fromDate.After(toDate) {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "from_date cannot be after to_date",
+		})
+		return
+	}
+
+	if toDate.Sub(fromDate) >= maxBalanceHistoryDays*24*time.Hour {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("Balance history can be fetched for at most %d days at a time", maxBalanceHistoryDays),
+		})
+		return
+	}
+
+	account, err := c.accountService.GetAccount(requestCtx, nil, accountTypes.AccountQueryOptions{
+		AccountID: &accountID,
+		Columns:   []string{"id", "user_id"},
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// authorization check: verify the authenticated user is one of the holders of the account
+	isAccountHolder, err := c.accountService.IsAccountHolder(requestCtx, nil, account, userUUID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+	if !isAccountHolder {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You do not have permission to access this account",
+		})
+		return
+	}
+
+	dailyBalances, err := c.balanceService.GetDailyBalances(requestCtx, nil, types.DailyBalanceQueryOptions{
+		AccountID: &account.ID,
+		FromDate:  &fromDate,
+		ToDate:    &toDate,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	dailyBalanceDtos := types.TransformToDailyBalanceDtoList(dailyBalances)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetBalanceHistoryResponse{
+		Data: dailyBalanceDtos,
+	})
+}
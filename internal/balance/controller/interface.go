@@ -0,0 +1,7 @@
+package controller
+
+import "github.com/gin-gonic/gin"
+
+type BalanceController interface {
+	GetBalanceHistory(ginCtx *gin.Context)
+}
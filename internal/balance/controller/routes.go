@@ -0,0 +1,20 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skamranahmed/go-bank/cmd/middleware"
+	accountService "github.com/skamranahmed/go-bank/internal/account/service"
+	authenticationService "github.com/skamranahmed/go-bank/internal/authentication/service"
+	balanceService "github.com/skamranahmed/go-bank/internal/balance/service"
+)
+
+type Dependency struct {
+	AuthenticationService authenticationService.AuthenticationService
+	AccountService        accountService.AccountService
+	BalanceService        balanceService.BalanceService
+}
+
+func Register(router *gin.Engine, dependency Dependency) {
+	balanceController := newBalanceController(dependency)
+	router.GET("/v1/accounts/:account_id/balance-history", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), balanceController.GetBalanceHistory)
+}
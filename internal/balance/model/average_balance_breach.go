@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/uptrace/bun"
+)
+
+// AverageBalanceBreach records a month in which the average balance of an account fell short of the requirement
+type AverageBalanceBreach struct {
+	bun.BaseModel `bun:"table:average_balance_breaches"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+
+	// foreign key to "accounts" table
+	AccountID int64                 `bun:"account_id,notnull,unique:average_balance_breaches_account_id_month_unique"`
+	Account   *accountModel.Account `bun:"rel:belongs-to,join:account_id=id"`
+
+	// Month is the first day of the month for which the average balance was computed
+	Month time.Time `bun:"month,notnull,type:date,unique:average_balance_breaches_account_id_month_unique"`
+
+	// AverageBalance and RequiredAverageBalance are stored in the smallest currency unit (paise for INR)
+	AverageBalance         int64 `bun:"average_balance,notnull"`
+	RequiredAverageBalance int64 `bun:"required_average_balance,notnull"`
+
+	// FeeChargeID is the penalty charged for the breach, nil when no penalty was charged
+	FeeChargeID *uuid.UUID `bun:"fee_charge_id,type:uuid"`
+}
+
+// Shortfall returns how much the average balance fell short of the requirement
+func (b *AverageBalanceBreach) Shortfall() int64 {
+	return b.RequiredAverageBalance - b.AverageBalance
+}
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/uptrace/bun"
+)
+
+// DailyBalance is the end of day balance snapshot of an account
+type DailyBalance struct {
+	bun.BaseModel `bun:"table:daily_balances"`
+
+	// foreign key to "accounts" table
+	AccountID int64                 `bun:"account_id,pk,notnull"`
+	Account   *accountModel.Account `bun:"rel:belongs-to,join:account_id=id"`
+
+	BalanceDate time.Time `bun:"balance_date,pk,notnull,type:date"`
+	CreatedAt   time.Time `bun:"created_at,notnull,default:current_timestamp"`
+
+	// ClosingBalance is stored in the smallest currency unit (paise for INR)
+	ClosingBalance int64 `bun:"closing_balance,notnull"`
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/balance/model"
+	"github.com/skamranahmed/go-bank/internal/balance/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+type balanceRepository struct {
+	db *bun.DB
+}
+
+func NewBalanceRepository(db *bun.DB) BalanceRepository {
+	return &balanceRepository{
+		db: db,
+	}
+}
+
+// CreateDailyBalances skips the snapshots that already exist, so that re-running the snapshot for a day is harmless
+func (r *balanceRepository) CreateDailyBalances(requestCtx context.Context, dbExecutor bun.IDB, dailyBalances []model.DailyBalance) error {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	if len(dailyBalances) == 0 {
+		return nil
+	}
+
+	_, err := dbExecutor.NewInsert().
+		Model(&dailyBalances).
+		On("CONFLICT (account_id, balance_date) DO NOTHING").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating %d daily balances, error: %+v", len(dailyBalances), err)
+		return &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "Unable to save daily balances at this time. Please try again later.",
+		}
+	}
+
+	return nil
+}
+
+func (r *balanceRepository) GetDailyBalances(requestCtx context.Context, dbExecutor bun.IDB, options types.DailyBalanceQueryOptions) ([]model.DailyBalance, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var dailyBalances []model.DailyBalance
+	query := dbExecutor.NewSelect().Model(&dailyBalances)
+
+	// dynamically construct the query based on which fields are set
+	if options.AccountID != nil {
+		query = query.Where("account_id = ?", *options.AccountID)
+	}
+	if options.FromDate != nil {
+		query = query.Where("balance_date >= ?", options.FromDate.Format(time.DateOnly))
+	}
+	if options.ToDate != nil {
+		query = query.Where("balance_date <= ?", options.ToDate.Format(time.DateOnly))
+	}
+
+	err := query.Order("balance_date ASC").Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while finding daily balances with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch your balance history at the moment. Please try again later.",
+		}
+	}
+
+	return dailyBalances, nil
+}
+
+// GetAverageDailyBalance returns the average closing balance (rounded) and the number of snapshots between the dates (both inclusive)
+func (r *balanceRepository) GetAverageDailyBalance(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, fromDate, toDate time.Time) (int64, int, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var averageBalance int64
+	var snapshotCount int
+	err := dbExecutor.NewSelect().
+		Model((*model.DailyBalance)(nil)).
+		ColumnExpr("COALESCE(ROUND(AVG(closing_balance)), 0)::BIGINT").
+		ColumnExpr("COUNT(*)").
+		Where("account_id = ?", accountID).
+		Where("balance_date >= ?", fromDate.Format(time.DateOnly)).
+		Where("balance_date <= ?", toDate.Format(time.DateOnly)).
+		Scan(requestCtx, &averageBalance, &snapshotCount)
+	if err != nil {
+		logger.Error(requestCtx, "Error while computing average daily balance for accountID: %d, error: %+v", accountID, err)
+		return 0, 0, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "Unable to compute the average balance at this time. Please try again later.",
+		}
+	}
+
+	return averageBalance, snapshotCount, nil
+}
+
+// CreateAverageBalanceBreach returns nil without an error when the breach was already recorded for the account and month
+func (r *balanceRepository) CreateAverageBalanceBreach(requestCtx context.Context, dbExecutor bun.IDB, breach *model.AverageBalanceBreach) (*model.AverageBalanceBreach, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	err := dbExecutor.NewInsert().
+		Model(breach).
+		On("CONFLICT (account_id, month) DO NOTHING").
+		Returning("*").
+		Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		logger.Error(requestCtx, "Error while creating average balance breach for accountID: %d, error: %+v", breach.AccountID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "Unable to record the average balance breach at this time. Please try again later.",
+		}
+	}
+
+	return breach, nil
+}
+
+func (r *balanceRepository) SetAverageBalanceBreachFeeCharge(requestCtx context.Context, dbExecutor bun.IDB, breachID, feeChargeID uuid.UUID) error {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewUpdate().
+		Model((*model.AverageBalanceBreach)(nil)).
+		Set("fee_charge_id = ?", feeChargeID).
+		Where("id = ?", breachID).
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating average balance breach with ID: %s, error: %+v", breachID, err)
+		return &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "Unable to record the average balance breach at this time. Please try again later.",
+		}
+	}
+
+	return nil
+}
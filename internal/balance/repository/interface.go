@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/skamranahmed/go-bank/internal/balance/model"
+	"github.com/skamranahmed/go-bank/internal/balance/types"
+	"github.com/uptrace/bun"
+)
+
+type BalanceRepository interface {
+	CreateDailyBalances(requestCtx context.Context, dbExecutor bun.IDB, dailyBalances []model.DailyBalance) error
+	GetDailyBalances(requestCtx context.Context, dbExecutor bun.IDB, options types.DailyBalanceQueryOptions) ([]model.DailyBalance, error)
+	GetAverageDailyBalance(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, fromDate, toDate time.Time) (int64, int, error)
+	CreateAverageBalanceBreach(requestCtx context.Context, dbExecutor bun.IDB, breach *model.AverageBalanceBreach) (*model.AverageBalanceBreach, error)
+	SetAverageBalanceBreachFeeCharge(requestCtx context.Context, dbExecutor bun.IDB, breachID, feeChargeID uuid.UUID) error
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/balance/model"
+	"github.com/skamranahmed/go-bank/internal/balance/repository"
+	"github.com/skamranahmed/go-bank/internal/balance/types"
+	"github.com/uptrace/bun"
+)
+
+type balanceService struct {
+	db                *bun.DB
+	balanceRepository repository.BalanceRepository
+	breachChargeHook  BreachChargeHook
+}
+
+func NewBalanceService(db *bun.DB, balanceRepository repository.BalanceRepository, breachChargeHook BreachChargeHook) BalanceService {
+	return &balanceService{
+		db:                db,
+		balanceRepository: balanceRepository,
+		breachChargeHook:  breachChargeHook,
+	}
+}
+
+// SnapshotDailyBalances records the current balance of the accounts as their closing balance for the date
+func (s *balanceService) SnapshotDailyBalances(requestCtx context.Context, dbExecutor bun.IDB, accounts []accountModel.Account, balanceDate time.Time) error {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	dailyBalances := make([]model.DailyBalance, 0, len(accounts))
+	for _, account := range accounts {
+		dailyBalances = append(dailyBalances, model.DailyBalance{
+			AccountID:      account.ID,
+			BalanceDate:    balanceDate,
+			ClosingBalance: account.Balance,
+		})
+	}
+
+	return s.balanceRepository.CreateDailyBalances(requestCtx, dbExecutor, dailyBalances)
+}
+
+func (s *balanceService) GetDailyBalances(requestCtx context.Context, dbExecutor bun.IDB, options types.DailyBalanceQueryOptions) ([]model.DailyBalance, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.balanceRepository.GetDailyBalances(requestCtx, dbExecutor, options)
+}
+
+/*
+EvaluateAverageBalance computes the average of the daily balance snapshots of the account for the month
+and records a breach when it falls short of the required average balance
+
+The average is taken over the days that have a snapshot, so an account opened in the middle of the month
+is only measured from the day it was opened.
+
+It returns a nil breach when the requirement is met, when there are no snapshots for the month,
+or when the breach for the month was already recorded, which makes re-running the month-end job harmless.
+It must be called within a database transaction so that the breach and its penalty are committed together.
+*/
+func (s *balanceService) EvaluateAverageBalance(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, month time.Time, requiredAverageBalance int64) (*model.AverageBalanceBreach, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	startOfMonth := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	endOfMonth := startOfMonth.AddDate(0, 1, -1)
+
+	averageBalance, snapshotCount, err := s.balanceRepository.GetAverageDailyBalance(requestCtx, dbExecutor, accountID, startOfMonth, endOfMonth)
+	if err != nil {
+		return nil, err
+	}
+	if snapshotCount == 0 || averageBalance >= requiredAverageBalance {
+		return nil, nil
+	}
+
+	breach, err := s.balanceRepository.CreateAverageBalanceBreach(requestCtx, dbExecutor, &model.AverageBalanceBreach{
+		AccountID:              accountID,
+		Month:                  startOfMonth,
+		AverageBalance:         averageBalance,
+		RequiredAverageBalance: requiredAverageBalance,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if breach == nil {
+		return nil, nil
+	}
+
+	feeChargeID, err := s.breachChargeHook.ChargeBreach(requestCtx, dbExecutor, breach)
+	if err != nil {
+		return nil, err
+	}
+	if feeChargeID != nil {
+		err = s.balanceRepository.SetAverageBalanceBreachFeeCharge(requestCtx, dbExecutor, breach.ID, *feeChargeID)
+		if err != nil {
+			return nil, err
+		}
+		breach.FeeChargeID = feeChargeID
+	}
+
+	return breach, nil
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/balance/model"
+	feeModel "github.com/skamranahmed/go-bank/internal/fee/model"
+	feeService "github.com/skamranahmed/go-bank/internal/fee/service"
+	feeTypes "github.com/skamranahmed/go-bank/internal/fee/types"
+	"github.com/uptrace/bun"
+)
+
+// noopBreachChargeHook only flags the breach, the customer is notified but not charged
+type noopBreachChargeHook struct{}
+
+func NewNoopBreachChargeHook() BreachChargeHook {
+	return &noopBreachChargeHook{}
+}
+
+func (h *noopBreachChargeHook) ChargeBreach(requestCtx context.Context, dbExecutor bun.IDB, breach *model.AverageBalanceBreach) (*uuid.UUID, error) {
+	return nil, nil
+}
+
+// feeBreachChargeHook charges the MINIMUM_BALANCE_BREACH fee from the fee schedule
+type feeBreachChargeHook struct {
+	feeService feeService.FeeService
+}
+
+func NewFeeBreachChargeHook(feeService feeService.FeeService) BreachChargeHook {
+	return &feeBreachChargeHook{
+		feeService: feeService,
+	}
+}
+
+func (h *feeBreachChargeHook) ChargeBreach(requestCtx context.Context, dbExecutor bun.IDB, breach *model.AverageBalanceBreach) (*uuid.UUID, error) {
+	feeCharge, err := h.feeService.ChargeFee(requestCtx, dbExecutor, feeTypes.ChargeFeeParams{
+		AccountID: breach.AccountID,
+		Event:     feeModel.MinimumBalanceBreach,
+
+		// the shortfall is used as the base so that a PERCENTAGE rule charges in proportion to it
+		BaseAmount: breach.Shortfall(),
+		Reference:  breach.ID.String(),
+
+		// the penalty is levied by the bank on its own, it must not fail because the balance is already low
+		CapAtAvailableBalance: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if feeCharge == nil || feeCharge.Status != feeModel.Charged {
+		return nil, nil
+	}
+	return &feeCharge.ID, nil
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/balance/model"
+	"github.com/skamranahmed/go-bank/internal/balance/types"
+	"github.com/uptrace/bun"
+)
+
+type BalanceService interface {
+	SnapshotDailyBalances(requestCtx context.Context, dbExecutor bun.IDB, accounts []accountModel.Account, balanceDate time.Time) error
+	GetDailyBalances(requestCtx context.Context, dbExecutor bun.IDB, options types.DailyBalanceQueryOptions) ([]model.DailyBalance, error)
+	EvaluateAverageBalance(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, month time.Time, requiredAverageBalance int64) (*model.AverageBalanceBreach, error)
+}
+
+// BreachChargeHook decides what, if anything, is charged to an account when its monthly average balance falls short
+type BreachChargeHook interface {
+	// ChargeBreach returns the ID of the fee charge, or nil when nothing was charged
+	ChargeBreach(requestCtx context.Context, dbExecutor bun.IDB, breach *model.AverageBalanceBreach) (*uuid.UUID, error)
+}
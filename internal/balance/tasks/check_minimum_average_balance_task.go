@@ -0,0 +1,143 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/skamranahmed/go-bank/config"
+	"github.com/skamranahmed/go-bank/internal"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/balance/model"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/uptrace/bun"
+)
+
+const CheckMinimumAverageBalanceTaskName string = "periodic_task:check_minimum_average_balance"
+
+// number of savings accounts fetched from the database in one go
+const checkMinimumAverageBalanceBatchSize int = 100
+
+type CheckMinimumAverageBalanceTaskPayload struct {
+}
+
+type CheckMinimumAverageBalanceTask struct {
+	name          string
+	queue         string
+	cronSpec      string
+	maxRetryCount int
+	payload       CheckMinimumAverageBalanceTaskPayload
+}
+
+func NewCheckMinimumAverageBalanceTask() tasksHelper.SchedulableTask {
+	return &CheckMinimumAverageBalanceTask{
+		name:          CheckMinimumAverageBalanceTaskName,
+		queue:         tasksHelper.DefaultQueue,
+		cronSpec:      "0 2 1 * *", // run on the 1st of every month at 02:00, after the last snapshot of the previous month is taken
+		maxRetryCount: 3,
+		payload:       CheckMinimumAverageBalanceTaskPayload{},
+	}
+}
+
+func (t *CheckMinimumAverageBalanceTask) Name() string {
+	return t.name
+}
+
+func (t *CheckMinimumAverageBalanceTask) Queue() string {
+	return t.queue
+}
+
+func (t *CheckMinimumAverageBalanceTask) CronSpec() string {
+	return t.cronSpec
+}
+
+func (t *CheckMinimumAverageBalanceTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *CheckMinimumAverageBalanceTask) Payload() any {
+	return t.payload
+}
+
+type CheckMinimumAverageBalanceTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewCheckMinimumAverageBalanceTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &CheckMinimumAverageBalanceTaskProcessor{
+		services: services,
+	}
+}
+
+/*
+ProcessTask checks the average balance of every savings account for the previous month
+
+Each account is evaluated in its own database transaction so that a failure for one account
+does not roll back the breaches (and penalties) already recorded for the others. Since a breach
+is recorded only once per account and month, retrying the task only evaluates the accounts that were missed.
+*/
+func (processor *CheckMinimumAverageBalanceTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[CheckMinimumAverageBalanceTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	requiredAverageBalance := config.GetMinimumAverageBalanceConfig().SavingsAccountRequiredAverageBalance
+	now := time.Now().UTC()
+	previousMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+	accountType := accountModel.SavingsAccount
+
+	var afterID *int64
+	var failedCount, breachCount int
+	for {
+		accounts, err := processor.services.AccountService.ListAccounts(ctx, nil, accountTypes.AccountListOptions{
+			Type:    &accountType,
+			AfterID: afterID,
+			Limit:   checkMinimumAverageBalanceBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, account := range accounts {
+			var breach *model.AverageBalanceBreach
+			err := database.RunInTransaction(ctx, "checkMinimumAverageBalance", processor.services.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+				var err error
+				breach, err = processor.services.BalanceService.EvaluateAverageBalance(txCtx, tx, account.ID, previousMonth, requiredAverageBalance)
+				return err
+			})
+			if err != nil {
+				failedCount++
+				logger.Error(ctx, "Unable to check minimum average balance for accountID: %d, error: %+v", account.ID, err)
+				continue
+			}
+			if breach == nil {
+				continue
+			}
+
+			breachCount++
+			err = processor.services.TaskEnqueuer.Enqueue(ctx, NewSendAverageBalanceBreachNotificationTask(breach.ID.String(), account.ID), nil, nil)
+			if err != nil {
+				logger.Error(ctx, "Unable to enqueue SendAverageBalanceBreachNotificationTask for breachID: %s, error: %+v", breach.ID, err)
+			}
+		}
+
+		if len(accounts) < checkMinimumAverageBalanceBatchSize {
+			break
+		}
+		afterID = &accounts[len(accounts)-1].ID
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf("Unable to check minimum average balance for %d account(s)", failedCount)
+	}
+
+	logger.Info(ctx, "Minimum average balance checked for month: %s, %d breach(es) found", previousMonth.Format("2006-01"), breachCount)
+	return nil
+}
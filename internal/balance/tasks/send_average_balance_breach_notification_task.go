@@ -0,0 +1,76 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+)
+
+const SendAverageBalanceBreachNotificationTaskName string = "task:send_average_balance_breach_notification"
+
+type SendAverageBalanceBreachNotificationTaskPayload struct {
+	BreachID  string
+	AccountID int64
+}
+
+type SendAverageBalanceBreachNotificationTask struct {
+	name          string
+	queue         string
+	maxRetryCount int
+	payload       SendAverageBalanceBreachNotificationTaskPayload
+}
+
+func NewSendAverageBalanceBreachNotificationTask(breachID string, accountID int64) tasksHelper.Task {
+	return &SendAverageBalanceBreachNotificationTask{
+		name:          SendAverageBalanceBreachNotificationTaskName,
+		queue:         tasksHelper.DefaultQueue,
+		maxRetryCount: 3,
+		payload: SendAverageBalanceBreachNotificationTaskPayload{
+			BreachID:  breachID,
+			AccountID: accountID,
+		},
+	}
+}
+
+func (t *SendAverageBalanceBreachNotificationTask) Name() string {
+	return t.name
+}
+
+func (t *SendAverageBalanceBreachNotificationTask) Queue() string {
+	return t.queue
+}
+
+func (t *SendAverageBalanceBreachNotificationTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *SendAverageBalanceBreachNotificationTask) Payload() any {
+	return t.payload
+}
+
+type SendAverageBalanceBreachNotificationTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewSendAverageBalanceBreachNotificationTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &SendAverageBalanceBreachNotificationTaskProcessor{
+		services: services,
+	}
+}
+
+func (processor *SendAverageBalanceBreachNotificationTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[SendAverageBalanceBreachNotificationTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	// TODO: maybe add a real email/push provider here in the future
+	logger.Info(ctx, "[Dummy] send average balance breach notification for breachID: %s to accountID: %d", payload.Data.BreachID, payload.Data.AccountID)
+	return nil
+}
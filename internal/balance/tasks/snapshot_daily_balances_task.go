@@ -0,0 +1,113 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/skamranahmed/go-bank/internal"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+)
+
+const SnapshotDailyBalancesTaskName string = "periodic_task:snapshot_daily_balances"
+
+// number of accounts fetched from the database in one go
+const snapshotDailyBalancesBatchSize int = 500
+
+type SnapshotDailyBalancesTaskPayload struct {
+}
+
+type SnapshotDailyBalancesTask struct {
+	name          string
+	queue         string
+	cronSpec      string
+	maxRetryCount int
+	payload       SnapshotDailyBalancesTaskPayload
+}
+
+func NewSnapshotDailyBalancesTask() tasksHelper.SchedulableTask {
+	return &SnapshotDailyBalancesTask{
+		name:          SnapshotDailyBalancesTaskName,
+		queue:         tasksHelper.DefaultQueue,
+		cronSpec:      "5 0 * * *", // run every day at 00:05, right after the day it snapshots has ended
+		maxRetryCount: 3,
+		payload:       SnapshotDailyBalancesTaskPayload{},
+	}
+}
+
+func (t *SnapshotDailyBalancesTask) Name() string {
+	return t.name
+}
+
+func (t *SnapshotDailyBalancesTask) Queue() string {
+	return t.queue
+}
+
+func (t *SnapshotDailyBalancesTask) CronSpec() string {
+	return t.cronSpec
+}
+
+func (t *SnapshotDailyBalancesTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *SnapshotDailyBalancesTask) Payload() any {
+	return t.payload
+}
+
+type SnapshotDailyBalancesTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewSnapshotDailyBalancesTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &SnapshotDailyBalancesTaskProcessor{
+		services: services,
+	}
+}
+
+/*
+ProcessTask records the balance of every account as its closing balance for the previous day (UTC)
+
+The task runs a few minutes after midnight, so the current balance is taken as the closing balance of the day that just ended.
+Existing snapshots are left untouched, so retrying the task after a partial failure only fills in the accounts that were missed.
+*/
+func (processor *SnapshotDailyBalancesTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[SnapshotDailyBalancesTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	now := time.Now().UTC()
+	balanceDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+
+	var afterID *int64
+	var snapshotCount int
+	for {
+		accounts, err := processor.services.AccountService.ListAccounts(ctx, nil, accountTypes.AccountListOptions{
+			AfterID: afterID,
+			Limit:   snapshotDailyBalancesBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = processor.services.BalanceService.SnapshotDailyBalances(ctx, nil, accounts, balanceDate)
+		if err != nil {
+			return err
+		}
+		snapshotCount += len(accounts)
+
+		if len(accounts) < snapshotDailyBalancesBatchSize {
+			break
+		}
+		afterID = &accounts[len(accounts)-1].ID
+	}
+
+	logger.Info(ctx, "Daily balances of %d account(s) snapshotted for date: %s", snapshotCount, balanceDate.Format(time.DateOnly))
+	return nil
+}
@@ -0,0 +1,32 @@
+package types
+
+import (
+	"time"
+
+	"github.com/skamranahmed/go-bank/internal/balance/model"
+)
+
+type GetBalanceHistoryRequestQuery struct {
+	FromDate string `form:"from_date" binding:"omitempty,datetime=2006-01-02"`
+	ToDate   string `form:"to_date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+type DailyBalanceDto struct {
+	Date           string `json:"date"`
+	ClosingBalance int64  `json:"closing_balance"`
+}
+
+type GetBalanceHistoryResponse struct {
+	Data []DailyBalanceDto `json:"data"`
+}
+
+func TransformToDailyBalanceDtoList(dailyBalances []model.DailyBalance) []DailyBalanceDto {
+	dailyBalanceDtos := make([]DailyBalanceDto, 0, len(dailyBalances))
+	for _, dailyBalance := range dailyBalances {
+		dailyBalanceDtos = append(dailyBalanceDtos, DailyBalanceDto{
+			Date:           dailyBalance.BalanceDate.Format(time.DateOnly),
+			ClosingBalance: dailyBalance.ClosingBalance,
+		})
+	}
+	return dailyBalanceDtos
+}
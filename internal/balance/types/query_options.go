@@ -0,0 +1,11 @@
+package types
+
+import "time"
+
+type DailyBalanceQueryOptions struct {
+	AccountID *int64
+
+	// both dates are inclusive
+	FromDate *time.Time
+	ToDate   *time.Time
+}
@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/middleware"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	accountService "github.com/skamranahmed/go-bank/internal/account/service"
+	beneficiaryService "github.com/skamranahmed/go-bank/internal/beneficiary/service"
+	beneficiaryTasks "github.com/skamranahmed/go-bank/internal/beneficiary/tasks"
+	"github.com/skamranahmed/go-bank/internal/beneficiary/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+)
+
+type beneficiaryController struct {
+	accountService     accountService.AccountService
+	beneficiaryService beneficiaryService.BeneficiaryService
+	taskEnqueuer       tasksHelper.TaskEnqueuer
+}
+
+func newBeneficiaryController(dependency Dependency) BeneficiaryController {
+	return &beneficiaryController{
+		accountService:     dependency.AccountService,
+		beneficiaryService: dependency.BeneficiaryService,
+		taskEnqueuer:       dependency.TaskEnqueuer,
+	}
+}
+
+func (c *beneficiaryController) AddBeneficiary(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.AddBeneficiaryRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	// resolve the account referenced by its IBAN to its account number
+	accountID := payload.Data.AccountID
+	if payload.Data.IBAN != "" {
+		ibanAccountID, err := c.accountService.ResolveIBAN(requestCtx, nil, payload.Data.IBAN)
+		if err != nil {
+			server.SendErrorResponse(ginCtx, err)
+			return
+		}
+
+		if accountID != 0 && accountID != ibanAccountID {
+			server.SendErrorResponse(ginCtx, &server.ApiError{
+				HttpStatusCode: http.StatusBadRequest,
+				Message:        "account_id and iban refer to different accounts",
+			})
+			return
+		}
+		accountID = ibanAccountID
+	}
+
+	beneficiary, err := c.beneficiaryService.AddBeneficiary(requestCtx, nil, types.AddBeneficiaryParams{
+		UserID:    userUUID,
+		Nickname:  payload.Data.Nickname,
+		AccountID: accountID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// alert the user about the new beneficiary, in case it was not added by them
+	err = c.taskEnqueuer.Enqueue(requestCtx, beneficiaryTasks.NewSendBeneficiaryAddedNotificationTask(beneficiary.ID.String(), userUUID.String()), nil, nil)
+	if err != nil {
+		logger.Error(requestCtx, "Unable to enqueue SendBeneficiaryAddedNotificationTask for beneficiaryID: %s, error: %+v", beneficiary.ID, err)
+	}
+
+	// transform to DTO and return response
+	beneficiaryDto := types.TransformToBeneficiaryDto(beneficiary)
+	server.SendSuccessResponse(ginCtx, http.StatusCreated, types.AddBeneficiaryResponse{
+		Data: *beneficiaryDto,
+	})
+}
+
+func (c *beneficiaryController) GetBeneficiaries(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	beneficiaries, err := c.beneficiaryService.ListBeneficiaries(requestCtx, nil, types.BeneficiaryListOptions{
+		UserID: &userUUID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	beneficiaryDtos := types.TransformToBeneficiaryDtoList(beneficiaries)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetBeneficiariesResponse{
+		Data: beneficiaryDtos,
+	})
+}
+
+func (c *beneficiaryController) DeleteBeneficiary(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	beneficiaryID, err := uuid.Parse(ginCtx.Param("beneficiary_id"))
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid beneficiary ID",
+		})
+		return
+	}
+
+	beneficiary, err := c.beneficiaryService.GetBeneficiary(requestCtx, nil, types.BeneficiaryQueryOptions{
+		ID: &beneficiaryID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// authorization check: verify beneficiary belongs to authenticated user
+	if beneficiary.UserID != userUUID {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You do not have permission to access this beneficiary",
+		})
+		return
+	}
+
+	err = c.beneficiaryService.DeleteBeneficiary(requestCtx, nil, beneficiary.ID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusNoContent, nil)
+}
+
+// getAuthenticatedUserID extracts the ID of the authenticated user from the request context, sending the error response when it is missing
+func getAuthenticatedUserID(ginCtx *gin.Context) (uuid.UUID, bool) {
+	userID, ok := ginCtx.Request.Context().Value(middleware.ContextUserIDKey).(string)
+	if !ok || userID == "" {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusUnauthorized,
+			Message:        "User not authenticated",
+		})
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid user ID",
+		})
+		return uuid.Nil, false
+	}
+
+	return userUUID, true
+}
@@ -0,0 +1,9 @@
+package controller
+
+import "github.com/gin-gonic/gin"
+
+type BeneficiaryController interface {
+	AddBeneficiary(ginCtx *gin.Context)
+	GetBeneficiaries(ginCtx *gin.Context)
+	DeleteBeneficiary(ginCtx *gin.Context)
+}
@@ -0,0 +1,32 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skamranahmed/go-bank/cmd/middleware"
+	"github.com/skamranahmed/go-bank/config"
+	accountService "github.com/skamranahmed/go-bank/internal/account/service"
+	authenticationService "github.com/skamranahmed/go-bank/internal/authentication/service"
+	beneficiaryService "github.com/skamranahmed/go-bank/internal/beneficiary/service"
+	"github.com/skamranahmed/go-bank/pkg/cache"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+)
+
+type Dependency struct {
+	AuthenticationService authenticationService.AuthenticationService
+	AccountService        accountService.AccountService
+	BeneficiaryService    beneficiaryService.BeneficiaryService
+	TaskEnqueuer          tasksHelper.TaskEnqueuer
+	CacheClient           cache.CacheClient
+}
+
+func Register(router *gin.Engine, dependency Dependency) {
+	beneficiaryController := newBeneficiaryController(dependency)
+
+	// adding a beneficiary returns the masked holder name of the account, so it shares the rate limit of name enquiries
+	nameEnquiryConfig := config.GetNameEnquiryConfig()
+	router.POST("/v1/beneficiaries", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.RateLimitMiddleware(dependency.CacheClient, "name_enquiry", nameEnquiryConfig.MaxRequestsPerWindow, time.Duration(nameEnquiryConfig.WindowInSeconds)*time.Second), beneficiaryController.AddBeneficiary)
+	router.GET("/v1/beneficiaries", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), beneficiaryController.GetBeneficiaries)
+	router.DELETE("/v1/beneficiaries/:beneficiary_id", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), beneficiaryController.DeleteBeneficiary)
+}
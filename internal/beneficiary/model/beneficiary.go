@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/uptrace/bun"
+)
+
+// Beneficiary is a payee saved by a user so that they can transfer to it without typing the account number every time
+type Beneficiary struct {
+	bun.BaseModel `bun:"table:beneficiaries"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "users" table, the user who saved the beneficiary
+	UserID uuid.UUID       `bun:"user_id,notnull,type:uuid,unique:beneficiaries_user_id_account_id_unique"`
+	User   *userModel.User `bun:"rel:belongs-to,join:user_id=id"`
+
+	Nickname string `bun:"nickname,notnull,type:varchar(50)"`
+
+	// foreign key to "accounts" table, the account the transfers to the beneficiary are credited to
+	AccountID int64                 `bun:"account_id,notnull,unique:beneficiaries_user_id_account_id_unique"`
+	Account   *accountModel.Account `bun:"rel:belongs-to,join:account_id=id"`
+
+	// HolderName is the masked name of the holder of the account, looked up when the beneficiary is added
+	HolderName string `bun:"holder_name,notnull,type:varchar(100)"`
+
+	// Until CoolingPeriodEndsAt, the total transferred to the beneficiary is capped by the cooling period transfer limit
+	CoolingPeriodEndsAt            time.Time `bun:"cooling_period_ends_at,notnull"`
+	CoolingPeriodTransferredAmount int64     `bun:"cooling_period_transferred_amount,notnull,default:0"`
+}
+
+// IsInCoolingPeriod reports whether the beneficiary was added too recently to receive transfers without the reduced limit
+func (b *Beneficiary) IsInCoolingPeriod(at time.Time) bool {
+	return at.Before(b.CoolingPeriodEndsAt)
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/beneficiary/model"
+	"github.com/skamranahmed/go-bank/internal/beneficiary/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+type beneficiaryRepository struct {
+	db *bun.DB
+}
+
+func NewBeneficiaryRepository(db *bun.DB) BeneficiaryRepository {
+	return &beneficiaryRepository{
+		db: db,
+	}
+}
+
+func (r *beneficiaryRepository) CreateBeneficiary(requestCtx context.Context, dbExecutor bun.IDB, beneficiary *model.Beneficiary) (*model.Beneficiary, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	// an account already saved by the user is skipped instead of failing the insert, so that it can be reported as a conflict
+	err := dbExecutor.NewInsert().
+		Model(beneficiary).
+		On("CONFLICT (user_id, account_id) DO NOTHING").
+		Returning("*").
+		Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusConflict,
+				Message:        "This account is already saved as a beneficiary",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while creating beneficiary for userID: %s, error: %+v", beneficiary.UserID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't add the beneficiary at the moment. Please try again later.",
+		}
+	}
+
+	return beneficiary, nil
+}
+
+func (r *beneficiaryRepository) GetBeneficiary(requestCtx context.Context, dbExecutor bun.IDB, options types.BeneficiaryQueryOptions) (*model.Beneficiary, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var beneficiary model.Beneficiary
+	query := dbExecutor.NewSelect().Model(&beneficiary)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+	if options.UserID != nil {
+		query = query.Where("user_id = ?", *options.UserID)
+	}
+	if options.AccountID != nil {
+		query = query.Where("account_id = ?", *options.AccountID)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Beneficiary not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding beneficiary with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the beneficiary at the moment. Please try again later.",
+		}
+	}
+
+	return &beneficiary, nil
+}
+
+func (r *beneficiaryRepository) ListBeneficiaries(requestCtx context.Context, dbExecutor bun.IDB, options types.BeneficiaryListOptions) ([]model.Beneficiary, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var beneficiaries []model.Beneficiary
+	query := dbExecutor.NewSelect().Model(&beneficiaries)
+
+	// dynamically construct the query based on which fields are set
+	if options.UserID != nil {
+		query = query.Where("user_id = ?", *options.UserID)
+	}
+
+	err := query.Order("nickname ASC").Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing beneficiaries with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the beneficiaries at the moment. Please try again later.",
+		}
+	}
+
+	return beneficiaries, nil
+}
+
+func (r *beneficiaryRepository) UpdateBeneficiary(requestCtx context.Context, dbExecutor bun.IDB, beneficiaryID uuid.UUID, options types.BeneficiaryUpdateOptions) (*model.Beneficiary, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var beneficiary model.Beneficiary
+	query := dbExecutor.NewUpdate().Model(&beneficiary)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewCoolingPeriodTransferredAmount != nil {
+		query = query.Set("cooling_period_transferred_amount = ?", *options.NewCoolingPeriodTransferredAmount)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", beneficiaryID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating beneficiary with ID: %s, error: %+v", beneficiaryID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the beneficiary at the moment. Please try again later.",
+		}
+	}
+
+	return &beneficiary, nil
+}
+
+func (r *beneficiaryRepository) DeleteBeneficiary(requestCtx context.Context, dbExecutor bun.IDB, beneficiaryID uuid.UUID) error {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewDelete().
+		Model((*model.Beneficiary)(nil)).
+		Where("id = ?", beneficiaryID).
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while deleting beneficiary with ID: %s, error: %+v", beneficiaryID, err)
+		return &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't delete the beneficiary at the moment. Please try again later.",
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/beneficiary/model"
+	"github.com/skamranahmed/go-bank/internal/beneficiary/types"
+	"github.com/uptrace/bun"
+)
+
+type BeneficiaryRepository interface {
+	CreateBeneficiary(requestCtx context.Context, dbExecutor bun.IDB, beneficiary *model.Beneficiary) (*model.Beneficiary, error)
+	GetBeneficiary(requestCtx context.Context, dbExecutor bun.IDB, options types.BeneficiaryQueryOptions) (*model.Beneficiary, error)
+	ListBeneficiaries(requestCtx context.Context, dbExecutor bun.IDB, options types.BeneficiaryListOptions) ([]model.Beneficiary, error)
+	UpdateBeneficiary(requestCtx context.Context, dbExecutor bun.IDB, beneficiaryID uuid.UUID, options types.BeneficiaryUpdateOptions) (*model.Beneficiary, error)
+	DeleteBeneficiary(requestCtx context.Context, dbExecutor bun.IDB, beneficiaryID uuid.UUID) error
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/config"
+	accountService "github.com/skamranahmed/go-bank/internal/account/service"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/beneficiary/model"
+	"github.com/skamranahmed/go-bank/internal/beneficiary/repository"
+	"github.com/skamranahmed/go-bank/internal/beneficiary/types"
+	userService "github.com/skamranahmed/go-bank/internal/user/service"
+	userTypes "github.com/skamranahmed/go-bank/internal/user/types"
+	"github.com/uptrace/bun"
+)
+
+type beneficiaryService struct {
+	db                    *bun.DB
+	beneficiaryRepository repository.BeneficiaryRepository
+	accountService        accountService.AccountService
+	userService           userService.UserService
+	beneficiaryConfig     config.BeneficiaryConfig
+}
+
+func NewBeneficiaryService(
+	db *bun.DB,
+	beneficiaryRepository repository.BeneficiaryRepository,
+	accountService accountService.AccountService,
+	userService userService.UserService,
+	beneficiaryConfig config.BeneficiaryConfig,
+) BeneficiaryService {
+	return &beneficiaryService{
+		db:                    db,
+		beneficiaryRepository: beneficiaryRepository,
+		accountService:        accountService,
+		userService:           userService,
+		beneficiaryConfig:     beneficiaryConfig,
+	}
+}
+
+/*
+AddBeneficiary saves the account as a payee of the user, along with the masked name of its holder so that the user can verify whom they are paying
+
+A newly added beneficiary starts in a cooling period, during which only a reduced amount can be transferred to it.
+This limits the damage when someone who took over the user's session adds their own account as a beneficiary.
+*/
+func (s *beneficiaryService) AddBeneficiary(requestCtx context.Context, dbExecutor bun.IDB, params types.AddBeneficiaryParams) (*model.Beneficiary, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	account, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &params.AccountID,
+		Columns:   []string{"id", "user_id", "type"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !account.Type.AllowsTransfers() {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Only a savings or current account can be added as a beneficiary",
+		}
+	}
+
+	if account.UserID == params.UserID {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "You cannot add your own account as a beneficiary",
+		}
+	}
+
+	holderID := account.UserID.String()
+	holder, err := s.userService.GetUser(requestCtx, dbExecutor, userTypes.UserQueryOptions{
+		ID:      &holderID,
+		Columns: []string{"username", "first_name", "last_name"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	coolingPeriod := time.Duration(s.beneficiaryConfig.CoolingPeriodInHours) * time.Hour
+	return s.beneficiaryRepository.CreateBeneficiary(requestCtx, dbExecutor, &model.Beneficiary{
+		UserID:              params.UserID,
+		Nickname:            params.Nickname,
+		AccountID:           account.ID,
+		HolderName:          holder.MaskedName(),
+		CoolingPeriodEndsAt: time.Now().UTC().Add(coolingPeriod),
+	})
+}
+
+func (s *beneficiaryService) GetBeneficiary(requestCtx context.Context, dbExecutor bun.IDB, options types.BeneficiaryQueryOptions) (*model.Beneficiary, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.beneficiaryRepository.GetBeneficiary(requestCtx, dbExecutor, options)
+}
+
+func (s *beneficiaryService) ListBeneficiaries(requestCtx context.Context, dbExecutor bun.IDB, options types.BeneficiaryListOptions) ([]model.Beneficiary, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.beneficiaryRepository.ListBeneficiaries(requestCtx, dbExecutor, options)
+}
+
+func (s *beneficiaryService) DeleteBeneficiary(requestCtx context.Context, dbExecutor bun.IDB, beneficiaryID uuid.UUID) error {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.beneficiaryRepository.DeleteBeneficiary(requestCtx, dbExecutor, beneficiaryID)
+}
+
+/*
+RecordTransfer checks a transfer to the beneficiary against its cooling period limit and adds it to the amount transferred during the cooling period
+
+It must be called within the database transaction of the transfer, because it locks the beneficiary row for update
+so that concurrent transfers cannot exceed the limit together. Transfers after the cooling period are not limited.
+*/
+func (s *beneficiaryService) RecordTransfer(requestCtx context.Context, dbExecutor bun.IDB, beneficiaryID uuid.UUID, amount int64, transferTime time.Time) (*model.Beneficiary, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	beneficiary, err := s.beneficiaryRepository.GetBeneficiary(requestCtx, dbExecutor, types.BeneficiaryQueryOptions{
+		ID:        &beneficiaryID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !beneficiary.IsInCoolingPeriod(transferTime) {
+		return beneficiary, nil
+	}
+
+	remainingLimit := s.beneficiaryConfig.CoolingPeriodTransferLimit - beneficiary.CoolingPeriodTransferredAmount
+	if amount > remainingLimit {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message: fmt.Sprintf(
+				"A newly added beneficiary can only receive up to %d in total until %s, the remaining limit is %d",
+				s.beneficiaryConfig.CoolingPeriodTransferLimit,
+				beneficiary.CoolingPeriodEndsAt.UTC().Format(time.RFC3339),
+				max(remainingLimit, 0),
+			),
+		}
+	}
+
+	transferredAmount := beneficiary.CoolingPeriodTransferredAmount + amount
+	return s.beneficiaryRepository.UpdateBeneficiary(requestCtx, dbExecutor, beneficiary.ID, types.BeneficiaryUpdateOptions{
+		NewCoolingPeriodTransferredAmount: &transferredAmount,
+	})
+}
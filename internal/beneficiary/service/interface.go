@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/beneficiary/model"
+	"github.com/skamranahmed/go-bank/internal/beneficiary/types"
+	"github.com/uptrace/bun"
+)
+
+type BeneficiaryService interface {
+	AddBeneficiary(requestCtx context.Context, dbExecutor bun.IDB, params types.AddBeneficiaryParams) (*model.Beneficiary, error)
+	GetBeneficiary(requestCtx context.Context, dbExecutor bun.IDB, options types.BeneficiaryQueryOptions) (*model.Beneficiary, error)
+	ListBeneficiaries(requestCtx context.Context, dbExecutor bun.IDB, options types.BeneficiaryListOptions) ([]model.Beneficiary, error)
+	DeleteBeneficiary(requestCtx context.Context, dbExecutor bun.IDB, beneficiaryID uuid.UUID) error
+	RecordTransfer(requestCtx context.Context, dbExecutor bun.IDB, beneficiaryID uuid.UUID, amount int64, transferTime time.Time) (*model.Beneficiary, error)
+}
@@ -0,0 +1,10 @@
+package tasks
+
+import (
+	"github.com/skamranahmed/go-bank/internal"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+)
+
+func RegisterTaskProcessors(taskRouter tasksHelper.TaskRouter, services *internal.Services) {
+	taskRouter.RegisterTaskProcessor(SendBeneficiaryAddedNotificationTaskName, NewSendBeneficiaryAddedNotificationTaskProcessor(services))
+}
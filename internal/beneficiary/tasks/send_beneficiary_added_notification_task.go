@@ -0,0 +1,77 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+)
+
+const SendBeneficiaryAddedNotificationTaskName string = "task:send_beneficiary_added_notification"
+
+// The notification alerts the user about every new beneficiary, so that they can delete it if it was not added by them
+type SendBeneficiaryAddedNotificationTaskPayload struct {
+	BeneficiaryID string
+	UserID        string
+}
+
+type SendBeneficiaryAddedNotificationTask struct {
+	name          string
+	queue         string
+	maxRetryCount int
+	payload       SendBeneficiaryAddedNotificationTaskPayload
+}
+
+func NewSendBeneficiaryAddedNotificationTask(beneficiaryID string, userID string) tasksHelper.Task {
+	return &SendBeneficiaryAddedNotificationTask{
+		name:          SendBeneficiaryAddedNotificationTaskName,
+		queue:         tasksHelper.PriorityQueue,
+		maxRetryCount: 3,
+		payload: SendBeneficiaryAddedNotificationTaskPayload{
+			BeneficiaryID: beneficiaryID,
+			UserID:        userID,
+		},
+	}
+}
+
+func (t *SendBeneficiaryAddedNotificationTask) Name() string {
+	return t.name
+}
+
+func (t *SendBeneficiaryAddedNotificationTask) Queue() string {
+	return t.queue
+}
+
+func (t *SendBeneficiaryAddedNotificationTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *SendBeneficiaryAddedNotificationTask) Payload() any {
+	return t.payload
+}
+
+type SendBeneficiaryAddedNotificationTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewSendBeneficiaryAddedNotificationTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &SendBeneficiaryAddedNotificationTaskProcessor{
+		services: services,
+	}
+}
+
+func (processor *SendBeneficiaryAddedNotificationTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[SendBeneficiaryAddedNotificationTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	// TODO: maybe add a real email/push provider here in the future
+	logger.Info(ctx, "[Dummy] send beneficiary added notification for beneficiaryID: %s to userID: %s", payload.Data.BeneficiaryID, payload.Data.UserID)
+	return nil
+}
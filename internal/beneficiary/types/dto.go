@@ -0,0 +1,57 @@
+package types
+
+import (
+	"time"
+
+	"github.com/skamranahmed/go-bank/internal/beneficiary/model"
+)
+
+type AddBeneficiaryRequest struct {
+	Data AddBeneficiaryRequestData `json:"data" binding:"required"`
+}
+
+type AddBeneficiaryRequestData struct {
+	Nickname string `json:"nickname" binding:"required,min=1,max=50"`
+
+	// the account is referenced either by its account number or by its IBAN
+	AccountID int64  `json:"account_id" binding:"required_without=IBAN,omitempty,account_number"`
+	IBAN      string `json:"iban"`
+}
+
+type BeneficiaryDto struct {
+	ID                             string    `json:"id"`
+	CreatedAt                      time.Time `json:"created_at"`
+	Nickname                       string    `json:"nickname"`
+	AccountID                      int64     `json:"account_id"`
+	HolderName                     string    `json:"holder_name"`
+	CoolingPeriodEndsAt            time.Time `json:"cooling_period_ends_at"`
+	CoolingPeriodTransferredAmount int64     `json:"cooling_period_transferred_amount"`
+}
+
+type AddBeneficiaryResponse struct {
+	Data BeneficiaryDto `json:"data"`
+}
+
+type GetBeneficiariesResponse struct {
+	Data []BeneficiaryDto `json:"data"`
+}
+
+func TransformToBeneficiaryDto(beneficiary *model.Beneficiary) *BeneficiaryDto {
+	return &BeneficiaryDto{
+		ID:                             beneficiary.ID.String(),
+		CreatedAt:                      beneficiary.CreatedAt,
+		Nickname:                       beneficiary.Nickname,
+		AccountID:                      beneficiary.AccountID,
+		HolderName:                     beneficiary.HolderName,
+		CoolingPeriodEndsAt:            beneficiary.CoolingPeriodEndsAt,
+		CoolingPeriodTransferredAmount: beneficiary.CoolingPeriodTransferredAmount,
+	}
+}
+
+func TransformToBeneficiaryDtoList(beneficiaries []model.Beneficiary) []BeneficiaryDto {
+	beneficiaryDtos := make([]BeneficiaryDto, 0, len(beneficiaries))
+	for _, beneficiary := range beneficiaries {
+		beneficiaryDtos = append(beneficiaryDtos, *TransformToBeneficiaryDto(&beneficiary))
+	}
+	return beneficiaryDtos
+}
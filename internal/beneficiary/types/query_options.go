@@ -0,0 +1,22 @@
+package types
+
+import (
+	"github.com/google/uuid"
+)
+
+type BeneficiaryQueryOptions struct {
+	ID        *uuid.UUID
+	UserID    *uuid.UUID
+	AccountID *int64
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type BeneficiaryListOptions struct {
+	UserID *uuid.UUID
+}
+
+type BeneficiaryUpdateOptions struct {
+	NewCoolingPeriodTransferredAmount *int64
+}
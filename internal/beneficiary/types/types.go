@@ -0,0 +1,9 @@
+package types
+
+import "github.com/google/uuid"
+
+type AddBeneficiaryParams struct {
+	UserID    uuid.UUID
+	Nickname  string
+	AccountID int64
+}
@@ -0,0 +1,194 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/middleware"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	accountService "github.com/skamranahmed/go-bank/internal/account/service"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/deposit/model"
+	depositService "github.com/skamranahmed/go-bank/internal/deposit/service"
+	"github.com/skamranahmed/go-bank/internal/deposit/types"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/uptrace/bun"
+)
+
+type depositController struct {
+	db             *bun.DB
+	accountService accountService.AccountService
+	depositService depositService.DepositService
+}
+
+func newDepositController(dependency Dependency) DepositController {
+	return &depositController{
+		db:             dependency.Db,
+		accountService: dependency.AccountService,
+		depositService: dependency.DepositService,
+	}
+}
+
+func (c *depositController) BookFixedDeposit(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.BookFixedDepositRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	// existence check for the account the deposit is funded from
+	linkedAccount, err := c.accountService.GetAccount(requestCtx, nil, accountTypes.AccountQueryOptions{
+		AccountID: &payload.Data.LinkedAccountID,
+		Columns:   []string{"id", "user_id"},
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// authorization check: verify the authenticated user is one of the holders of the account
+	isAccountHolder, err := c.accountService.IsAccountHolder(requestCtx, nil, linkedAccount, userUUID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+	if !isAccountHolder {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You do not have permission to access this account",
+		})
+		return
+	}
+
+	var fixedDeposit *model.FixedDeposit
+	err = database.RunInTransaction(requestCtx, "bookFixedDeposit", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		fixedDeposit, err = c.depositService.BookFixedDeposit(txCtx, tx, types.BookFixedDepositParams{
+			UserID:              userUUID,
+			LinkedAccountID:     payload.Data.LinkedAccountID,
+			Amount:              *payload.Data.Amount,
+			TenureInMonths:      *payload.Data.TenureInMonths,
+			MaturityInstruction: model.MaturityInstruction(payload.Data.MaturityInstruction),
+		})
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	fixedDepositDto := types.TransformToFixedDepositDto(fixedDeposit)
+	server.SendSuccessResponse(ginCtx, http.StatusCreated, types.BookFixedDepositResponse{
+		Data: *fixedDepositDto,
+	})
+}
+
+func (c *depositController) GetFixedDeposits(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	fixedDeposits, err := c.depositService.ListFixedDeposits(requestCtx, nil, types.FixedDepositListOptions{
+		UserID: &userUUID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	fixedDepositDtos := types.TransformToFixedDepositDtoList(fixedDeposits)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetFixedDepositsResponse{
+		Data: fixedDepositDtos,
+	})
+}
+
+func (c *depositController) CloseFixedDeposit(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	// extract fixed deposit ID from URL parameter
+	fixedDepositID, err := uuid.Parse(ginCtx.Param("fixed_deposit_id"))
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid fixed deposit ID",
+		})
+		return
+	}
+
+	fixedDeposit, err := c.depositService.GetFixedDeposit(requestCtx, nil, types.FixedDepositQueryOptions{
+		ID: &fixedDepositID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// authorization check: verify fixed deposit belongs to authenticated user
+	if fixedDeposit.UserID != userUUID {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You do not have permission to access this fixed deposit",
+		})
+		return
+	}
+
+	now := time.Now().UTC()
+	closureDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	err = database.RunInTransaction(requestCtx, "closeFixedDeposit", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		fixedDeposit, err = c.depositService.CloseFixedDepositPrematurely(txCtx, tx, fixedDepositID, closureDate)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	fixedDepositDto := types.TransformToFixedDepositDto(fixedDeposit)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.CloseFixedDepositResponse{
+		Data: *fixedDepositDto,
+	})
+}
+
+// getAuthenticatedUserID extracts the ID of the authenticated user from the request context, sending the error response when it is missing
+func getAuthenticatedUserID(ginCtx *gin.Context) (uuid.UUID, bool) {
+	userID, ok := ginCtx.Request.Context().Value(middleware.ContextUserIDKey).(string)
+	if !ok || userID == "" {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusUnauthorized,
+			Message:        "User not authenticated",
+		})
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid user ID",
+		})
+		return uuid.Nil, false
+	}
+
+	return userUUID, true
+}
@@ -0,0 +1,13 @@
+package controller
+
+import "github.com/gin-gonic/gin"
+
+type DepositController interface {
+	BookFixedDeposit(ginCtx *gin.Context)
+	GetFixedDeposits(ginCtx *gin.Context)
+	CloseFixedDeposit(ginCtx *gin.Context)
+
+	BookRecurringDeposit(ginCtx *gin.Context)
+	GetRecurringDeposits(ginCtx *gin.Context)
+	GetRecurringDepositSchedule(ginCtx *gin.Context)
+}
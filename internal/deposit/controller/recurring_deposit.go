@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/deposit/model"
+	"github.com/skamranahmed/go-bank/internal/deposit/types"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/uptrace/bun"
+)
+
+func (c *depositController) BookRecurringDeposit(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.BookRecurringDepositRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	// existence check for the account the installments are debited from
+	linkedAccount, err := c.accountService.GetAccount(requestCtx, nil, accountTypes.AccountQueryOptions{
+		AccountID: &payload.Data.LinkedAccountID,
+		Columns:   []string{"id", "user_id"},
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// authorization check: verify the authenticated user is one of the holders of the account
+	isAccountHolder, err := c.accountService.IsAccountHolder(requestCtx, nil, linkedAccount, userUUID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+	if !isAccountHolder {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You do not have permission to access this account",
+		})
+		return
+	}
+
+	var recurringDeposit *model.RecurringDeposit
+	err = database.RunInTransaction(requestCtx, "bookRecurringDeposit", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		recurringDeposit, err = c.depositService.BookRecurringDeposit(txCtx, tx, types.BookRecurringDepositParams{
+			UserID:            userUUID,
+			LinkedAccountID:   payload.Data.LinkedAccountID,
+			InstallmentAmount: *payload.Data.InstallmentAmount,
+			TenureInMonths:    *payload.Data.TenureInMonths,
+			DebitDayOfMonth:   *payload.Data.DebitDayOfMonth,
+		})
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	recurringDepositDto := types.TransformToRecurringDepositDto(recurringDeposit)
+	server.SendSuccessResponse(ginCtx, http.StatusCreated, types.BookRecurringDepositResponse{
+		Data: *recurringDepositDto,
+	})
+}
+
+func (c *depositController) GetRecurringDeposits(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	recurringDeposits, err := c.depositService.ListRecurringDeposits(requestCtx, nil, types.RecurringDepositListOptions{
+		UserID: &userUUID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	recurringDepositDtos := types.TransformToRecurringDepositDtoList(recurringDeposits)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetRecurringDepositsResponse{
+		Data: recurringDepositDtos,
+	})
+}
+
+func (c *depositController) GetRecurringDepositSchedule(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	// extract recurring deposit ID from URL parameter
+	recurringDepositID, err := uuid.Parse(ginCtx.Param("recurring_deposit_id"))
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid recurring deposit ID",
+		})
+		return
+	}
+
+	recurringDeposit, err := c.depositService.GetRecurringDeposit(requestCtx, nil, types.RecurringDepositQueryOptions{
+		ID: &recurringDepositID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// authorization check: verify recurring deposit belongs to authenticated user
+	if recurringDeposit.UserID != userUUID {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You do not have permission to access this recurring deposit",
+		})
+		return
+	}
+
+	installments, err := c.depositService.ListInstallments(requestCtx, nil, types.InstallmentListOptions{
+		RecurringDepositID: &recurringDeposit.ID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	installmentDtos := types.TransformToRecurringDepositInstallmentDtoList(installments)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetRecurringDepositScheduleResponse{
+		Data: installmentDtos,
+	})
+}
@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skamranahmed/go-bank/cmd/middleware"
+	accountService "github.com/skamranahmed/go-bank/internal/account/service"
+	authenticationService "github.com/skamranahmed/go-bank/internal/authentication/service"
+	depositService "github.com/skamranahmed/go-bank/internal/deposit/service"
+	"github.com/uptrace/bun"
+)
+
+type Dependency struct {
+	Db                    *bun.DB
+	AuthenticationService authenticationService.AuthenticationService
+	AccountService        accountService.AccountService
+	DepositService        depositService.DepositService
+}
+
+func Register(router *gin.Engine, dependency Dependency) {
+	depositController := newDepositController(dependency)
+	router.POST("/v1/fixed-deposits", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), depositController.BookFixedDeposit)
+	router.GET("/v1/fixed-deposits", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), depositController.GetFixedDeposits)
+	router.POST("/v1/fixed-deposits/:fixed_deposit_id/close", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), depositController.CloseFixedDeposit)
+
+	router.POST("/v1/recurring-deposits", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), depositController.BookRecurringDeposit)
+	router.GET("/v1/recurring-deposits", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), depositController.GetRecurringDeposits)
+	router.GET("/v1/recurring-deposits/:recurring_deposit_id/schedule", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), depositController.GetRecurringDepositSchedule)
+}
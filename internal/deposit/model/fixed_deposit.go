@@ -0,0 +1,77 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/uptrace/bun"
+)
+
+// FixedDeposit is a lump sum locked in for a fixed tenure, its funds are held in a dedicated FIXED_DEPOSIT account
+type FixedDeposit struct {
+	bun.BaseModel `bun:"table:fixed_deposits"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "users" table
+	UserID uuid.UUID       `bun:"user_id,notnull,type:uuid"`
+	User   *userModel.User `bun:"rel:belongs-to,join:user_id=id"`
+
+	// foreign key to "accounts" table, the FIXED_DEPOSIT account that holds the deposit
+	AccountID int64                 `bun:"account_id,notnull,unique"`
+	Account   *accountModel.Account `bun:"rel:belongs-to,join:account_id=id"`
+
+	// foreign key to "accounts" table, the account the deposit was funded from and is paid out to
+	LinkedAccountID int64                 `bun:"linked_account_id,notnull"`
+	LinkedAccount   *accountModel.Account `bun:"rel:belongs-to,join:linked_account_id=id"`
+
+	// PrincipalAmount is the principal of the current term, stored in the smallest currency unit (paise for INR)
+	PrincipalAmount int64 `bun:"principal_amount,notnull"`
+
+	// AnnualInterestRateInBasisPoints is locked in when the deposit is booked (or renewed), 1 basis point = 0.01%
+	AnnualInterestRateInBasisPoints int64 `bun:"annual_interest_rate_in_basis_points,notnull"`
+
+	TenureInMonths int `bun:"tenure_in_months,notnull"`
+
+	// StartDate and MaturityDate bound the current term, both move forward when the deposit is renewed
+	StartDate    time.Time `bun:"start_date,notnull,type:date"`
+	MaturityDate time.Time `bun:"maturity_date,notnull,type:date"`
+
+	MaturityInstruction MaturityInstruction `bun:"maturity_instruction,notnull"`
+	Status              FixedDepositStatus  `bun:"status,notnull,default:'ACTIVE'"`
+	RenewalCount        int                 `bun:"renewal_count,notnull,default:0"`
+
+	// InterestPaid is the total interest credited over all terms, stored in the smallest currency unit (paise for INR)
+	InterestPaid int64 `bun:"interest_paid,notnull,default:0"`
+
+	ClosedAt *time.Time `bun:"closed_at"`
+}
+
+type MaturityInstruction string
+
+const (
+	Payout    MaturityInstruction = "PAYOUT"     // pay the principal and interest out to the linked account
+	AutoRenew MaturityInstruction = "AUTO_RENEW" // renew the principal and interest for another term of the same tenure
+)
+
+type FixedDepositStatus string
+
+const (
+	Active  FixedDepositStatus = "ACTIVE"
+	Matured FixedDepositStatus = "MATURED" // paid out on the maturity date
+	Closed  FixedDepositStatus = "CLOSED"  // closed by the customer before the maturity date
+)
+
+// InterestEarned returns the interest earned on the principal of the current term from the start date until the given date, at the given annual rate
+func (fd *FixedDeposit) InterestEarned(until time.Time, annualInterestRateInBasisPoints int64) int64 {
+	return CompoundInterest(fd.PrincipalAmount, annualInterestRateInBasisPoints, fd.StartDate, until)
+}
+
+// MaturityAmount returns the principal and interest that will be paid out on the maturity date of the current term
+func (fd *FixedDeposit) MaturityAmount() int64 {
+	return fd.PrincipalAmount + fd.InterestEarned(fd.MaturityDate, fd.AnnualInterestRateInBasisPoints)
+}
@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// quarterly compounding
+const compoundingPeriodInMonths = 3
+
+/*
+CompoundInterest returns the interest earned on the principal between the two dates at the given annual rate,
+compounded quarterly and rounded to the nearest smallest currency unit
+
+Simple interest is paid on the compounded amount for the days of the last, incomplete quarter
+*/
+func CompoundInterest(principal int64, annualInterestRateInBasisPoints int64, from time.Time, until time.Time) int64 {
+	amount := principal
+
+	// quarters are counted from the start date, so that a start on the 31st does not drift at shorter months
+	quarterStart := from
+	for quarter := 1; ; quarter++ {
+		quarterEnd := from.AddDate(0, quarter*compoundingPeriodInMonths, 0)
+		if quarterEnd.After(until) {
+			break
+		}
+		amount += roundedDivision(amount*annualInterestRateInBasisPoints, 10000*(12/compoundingPeriodInMonths))
+		quarterStart = quarterEnd
+	}
+
+	remainingDays := int64(until.Sub(quarterStart).Hours() / 24)
+	if remainingDays > 0 {
+		amount += roundedDivision(amount*annualInterestRateInBasisPoints*remainingDays, 10000*365)
+	}
+
+	return amount - principal
+}
+
+func roundedDivision(numerator int64, denominator int64) int64 {
+	return (numerator + denominator/2) / denominator
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/uptrace/bun"
+)
+
+// RecurringDeposit is built up by a fixed monthly installment auto-debited from the linked account, its funds are held in a dedicated RECURRING_DEPOSIT account
+type RecurringDeposit struct {
+	bun.BaseModel `bun:"table:recurring_deposits"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "users" table
+	UserID uuid.UUID       `bun:"user_id,notnull,type:uuid"`
+	User   *userModel.User `bun:"rel:belongs-to,join:user_id=id"`
+
+	// foreign key to "accounts" table, the RECURRING_DEPOSIT account that holds the installments
+	AccountID int64                 `bun:"account_id,notnull,unique"`
+	Account   *accountModel.Account `bun:"rel:belongs-to,join:account_id=id"`
+
+	// foreign key to "accounts" table, the account the installments are debited from and the deposit is paid out to
+	LinkedAccountID int64                 `bun:"linked_account_id,notnull"`
+	LinkedAccount   *accountModel.Account `bun:"rel:belongs-to,join:linked_account_id=id"`
+
+	// InstallmentAmount is stored in the smallest currency unit (paise for INR)
+	InstallmentAmount int64 `bun:"installment_amount,notnull"`
+
+	// AnnualInterestRateInBasisPoints is locked in when the deposit is booked, 1 basis point = 0.01%
+	AnnualInterestRateInBasisPoints int64 `bun:"annual_interest_rate_in_basis_points,notnull"`
+
+	// TenureInMonths is also the number of installments
+	TenureInMonths int `bun:"tenure_in_months,notnull"`
+
+	// DebitDayOfMonth is the day every installment after the first one is due on, between 1 and 28 so that it exists in every month
+	DebitDayOfMonth int `bun:"debit_day_of_month,notnull"`
+
+	StartDate    time.Time `bun:"start_date,notnull,type:date"`
+	MaturityDate time.Time `bun:"maturity_date,notnull,type:date"`
+
+	Status RecurringDepositStatus `bun:"status,notnull,default:'ACTIVE'"`
+
+	// InterestPaid is the interest credited at maturity, stored in the smallest currency unit (paise for INR)
+	InterestPaid int64 `bun:"interest_paid,notnull,default:0"`
+
+	ClosedAt *time.Time `bun:"closed_at"`
+}
+
+type RecurringDepositStatus string
+
+const (
+	RecurringDepositActive  RecurringDepositStatus = "ACTIVE"
+	RecurringDepositMatured RecurringDepositStatus = "MATURED" // paid out on the maturity date
+)
+
+// InstallmentDueDate returns the due date of the given installment, the first installment is due on the day the deposit is booked
+func (rd *RecurringDeposit) InstallmentDueDate(installmentNumber int) time.Time {
+	if installmentNumber == 1 {
+		return rd.StartDate
+	}
+	return time.Date(rd.StartDate.Year(), rd.StartDate.Month()+time.Month(installmentNumber-1), rd.DebitDayOfMonth, 0, 0, 0, 0, time.UTC)
+}
+
+// CalculateMaturityDate returns the maturity date, one month after the due date of the last installment
+func (rd *RecurringDeposit) CalculateMaturityDate() time.Time {
+	return time.Date(rd.StartDate.Year(), rd.StartDate.Month()+time.Month(rd.TenureInMonths), rd.DebitDayOfMonth, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/uptrace/bun"
+)
+
+// RecurringDepositInstallment is a single monthly installment of a recurring deposit, all of them are created when the deposit is booked
+type RecurringDepositInstallment struct {
+	bun.BaseModel `bun:"table:recurring_deposit_installments"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "recurring_deposits" table
+	RecurringDepositID uuid.UUID         `bun:"recurring_deposit_id,notnull,type:uuid,unique:recurring_deposit_installments_recurring_deposit_id_installment_number_unique"`
+	RecurringDeposit   *RecurringDeposit `bun:"rel:belongs-to,join:recurring_deposit_id=id"`
+
+	InstallmentNumber int       `bun:"installment_number,notnull,unique:recurring_deposit_installments_recurring_deposit_id_installment_number_unique"`
+	DueDate           time.Time `bun:"due_date,notnull,type:date"`
+
+	// Amount is stored in the smallest currency unit (paise for INR)
+	Amount int64 `bun:"amount,notnull"`
+
+	Status InstallmentStatus `bun:"status,notnull,default:'PENDING'"`
+
+	// AttemptCount is the number of auto-debit attempts that failed for lack of funds
+	AttemptCount    int        `bun:"attempt_count,notnull,default:0"`
+	LastAttemptedAt *time.Time `bun:"last_attempted_at"`
+
+	PaidAt *time.Time `bun:"paid_at"`
+
+	// foreign key to "transactions" table, the debit of the linked account that paid the installment
+	TransactionID *uuid.UUID                `bun:"transaction_id,type:uuid"`
+	Transaction   *accountModel.Transaction `bun:"rel:belongs-to,join:transaction_id=id"`
+}
+
+type InstallmentStatus string
+
+const (
+	InstallmentPending InstallmentStatus = "PENDING"
+	InstallmentPaid    InstallmentStatus = "PAID"
+	InstallmentMissed  InstallmentStatus = "MISSED" // not paid within the grace period, penalised at maturity
+)
+
+// InterestEarned returns the interest earned on the installment from the day it was paid until the given date, an unpaid installment earns nothing
+func (i *RecurringDepositInstallment) InterestEarned(until time.Time, annualInterestRateInBasisPoints int64) int64 {
+	if i.Status != InstallmentPaid || i.PaidAt == nil {
+		return 0
+	}
+	paidOn := time.Date(i.PaidAt.Year(), i.PaidAt.Month(), i.PaidAt.Day(), 0, 0, 0, 0, time.UTC)
+	return CompoundInterest(i.Amount, annualInterestRateInBasisPoints, paidOn, until)
+}
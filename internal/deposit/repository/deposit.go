@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/deposit/model"
+	"github.com/skamranahmed/go-bank/internal/deposit/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+type depositRepository struct {
+	db *bun.DB
+}
+
+func NewDepositRepository(db *bun.DB) DepositRepository {
+	return &depositRepository{
+		db: db,
+	}
+}
+
+func (r *depositRepository) CreateFixedDeposit(requestCtx context.Context, dbExecutor bun.IDB, fixedDeposit *model.FixedDeposit) (*model.FixedDeposit, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	err := dbExecutor.NewInsert().
+		Model(fixedDeposit).
+		Returning("*").
+		Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating fixed deposit for accountID: %d, error: %+v", fixedDeposit.AccountID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't book your fixed deposit at the moment. Please try again later.",
+		}
+	}
+
+	return fixedDeposit, nil
+}
+
+func (r *depositRepository) GetFixedDeposit(requestCtx context.Context, dbExecutor bun.IDB, options types.FixedDepositQueryOptions) (*model.FixedDeposit, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var fixedDeposit model.FixedDeposit
+	query := dbExecutor.NewSelect().Model(&fixedDeposit)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Fixed deposit not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding fixed deposit with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the fixed deposit at the moment. Please try again later.",
+		}
+	}
+
+	return &fixedDeposit, nil
+}
+
+func (r *depositRepository) ListFixedDeposits(requestCtx context.Context, dbExecutor bun.IDB, options types.FixedDepositListOptions) ([]model.FixedDeposit, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var fixedDeposits []model.FixedDeposit
+	query := dbExecutor.NewSelect().Model(&fixedDeposits)
+
+	// dynamically construct the query based on which fields are set
+	if options.UserID != nil {
+		query = query.Where("user_id = ?", *options.UserID)
+	}
+	if options.Status != nil {
+		query = query.Where("status = ?", *options.Status)
+	}
+	if options.MaturityDateOnOrBefore != nil {
+		query = query.Where("maturity_date <= ?", options.MaturityDateOnOrBefore.Format(time.DateOnly))
+	}
+	if options.AfterID != nil {
+		query = query.Where("id > ?", *options.AfterID)
+	}
+	if options.Limit > 0 {
+		query = query.Order("id ASC").Limit(options.Limit)
+	} else {
+		query = query.Order("created_at DESC")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing fixed deposits with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the fixed deposits at the moment. Please try again later.",
+		}
+	}
+
+	return fixedDeposits, nil
+}
+
+func (r *depositRepository) UpdateFixedDeposit(requestCtx context.Context, dbExecutor bun.IDB, fixedDepositID uuid.UUID, options types.FixedDepositUpdateOptions) (*model.FixedDeposit, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var fixedDeposit model.FixedDeposit
+	query := dbExecutor.NewUpdate().Model(&fixedDeposit)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewPrincipalAmount != nil {
+		query = query.Set("principal_amount = ?", *options.NewPrincipalAmount)
+	}
+	if options.NewAnnualInterestRateInBasisPoints != nil {
+		query = query.Set("annual_interest_rate_in_basis_points = ?", *options.NewAnnualInterestRateInBasisPoints)
+	}
+	if options.NewStartDate != nil {
+		query = query.Set("start_date = ?", options.NewStartDate.Format(time.DateOnly))
+	}
+	if options.NewMaturityDate != nil {
+		query = query.Set("maturity_date = ?", options.NewMaturityDate.Format(time.DateOnly))
+	}
+	if options.NewStatus != nil {
+		query = query.Set("status = ?", *options.NewStatus)
+	}
+	if options.NewRenewalCount != nil {
+		query = query.Set("renewal_count = ?", *options.NewRenewalCount)
+	}
+	if options.NewInterestPaid != nil {
+		query = query.Set("interest_paid = ?", *options.NewInterestPaid)
+	}
+	if options.NewClosedAt != nil {
+		query = query.Set("closed_at = ?", *options.NewClosedAt)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", fixedDepositID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating fixed deposit with ID: %s, error: %+v", fixedDepositID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the fixed deposit at the moment. Please try again later.",
+		}
+	}
+
+	return &fixedDeposit, nil
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/deposit/model"
+	"github.com/skamranahmed/go-bank/internal/deposit/types"
+	"github.com/uptrace/bun"
+)
+
+type DepositRepository interface {
+	CreateFixedDeposit(requestCtx context.Context, dbExecutor bun.IDB, fixedDeposit *model.FixedDeposit) (*model.FixedDeposit, error)
+	GetFixedDeposit(requestCtx context.Context, dbExecutor bun.IDB, options types.FixedDepositQueryOptions) (*model.FixedDeposit, error)
+	ListFixedDeposits(requestCtx context.Context, dbExecutor bun.IDB, options types.FixedDepositListOptions) ([]model.FixedDeposit, error)
+	UpdateFixedDeposit(requestCtx context.Context, dbExecutor bun.IDB, fixedDepositID uuid.UUID, options types.FixedDepositUpdateOptions) (*model.FixedDeposit, error)
+
+	CreateRecurringDeposit(requestCtx context.Context, dbExecutor bun.IDB, recurringDeposit *model.RecurringDeposit) (*model.RecurringDeposit, error)
+	GetRecurringDeposit(requestCtx context.Context, dbExecutor bun.IDB, options types.RecurringDepositQueryOptions) (*model.RecurringDeposit, error)
+	ListRecurringDeposits(requestCtx context.Context, dbExecutor bun.IDB, options types.RecurringDepositListOptions) ([]model.RecurringDeposit, error)
+	UpdateRecurringDeposit(requestCtx context.Context, dbExecutor bun.IDB, recurringDepositID uuid.UUID, options types.RecurringDepositUpdateOptions) (*model.RecurringDeposit, error)
+
+	CreateInstallments(requestCtx context.Context, dbExecutor bun.IDB, installments []model.RecurringDepositInstallment) ([]model.RecurringDepositInstallment, error)
+	GetInstallment(requestCtx context.Context, dbExecutor bun.IDB, options types.InstallmentQueryOptions) (*model.RecurringDepositInstallment, error)
+	ListInstallments(requestCtx context.Context, dbExecutor bun.IDB, options types.InstallmentListOptions) ([]model.RecurringDepositInstallment, error)
+	UpdateInstallment(requestCtx context.Context, dbExecutor bun.IDB, installmentID uuid.UUID, options types.InstallmentUpdateOptions) (*model.RecurringDepositInstallment, error)
+}
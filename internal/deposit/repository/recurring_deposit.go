@@ -0,0 +1,289 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/deposit/model"
+	"github.com/skamranahmed/go-bank/internal/deposit/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+func (r *depositRepository) CreateRecurringDeposit(requestCtx context.Context, dbExecutor bun.IDB, recurringDeposit *model.RecurringDeposit) (*model.RecurringDeposit, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	err := dbExecutor.NewInsert().
+		Model(recurringDeposit).
+		Returning("*").
+		Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating recurring deposit for accountID: %d, error: %+v", recurringDeposit.AccountID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't book your recurring deposit at the moment. Please try again later.",
+		}
+	}
+
+	return recurringDeposit, nil
+}
+
+func (r *depositRepository) GetRecurringDeposit(requestCtx context.Context, dbExecutor bun.IDB, options types.RecurringDepositQueryOptions) (*model.RecurringDeposit, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var recurringDeposit model.RecurringDeposit
+	query := dbExecutor.NewSelect().Model(&recurringDeposit)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Recurring deposit not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding recurring deposit with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the recurring deposit at the moment. Please try again later.",
+		}
+	}
+
+	return &recurringDeposit, nil
+}
+
+func (r *depositRepository) ListRecurringDeposits(requestCtx context.Context, dbExecutor bun.IDB, options types.RecurringDepositListOptions) ([]model.RecurringDeposit, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var recurringDeposits []model.RecurringDeposit
+	query := dbExecutor.NewSelect().Model(&recurringDeposits)
+
+	// dynamically construct the query based on which fields are set
+	if options.UserID != nil {
+		query = query.Where("user_id = ?", *options.UserID)
+	}
+	if options.Status != nil {
+		query = query.Where("status = ?", *options.Status)
+	}
+	if options.MaturityDateOnOrBefore != nil {
+		query = query.Where("maturity_date <= ?", options.MaturityDateOnOrBefore.Format(time.DateOnly))
+	}
+	if options.AfterID != nil {
+		query = query.Where("id > ?", *options.AfterID)
+	}
+	if options.Limit > 0 {
+		query = query.Order("id ASC").Limit(options.Limit)
+	} else {
+		query = query.Order("created_at DESC")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing recurring deposits with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the recurring deposits at the moment. Please try again later.",
+		}
+	}
+
+	return recurringDeposits, nil
+}
+
+func (r *depositRepository) UpdateRecurringDeposit(requestCtx context.Context, dbExecutor bun.IDB, recurringDepositID uuid.UUID, options types.RecurringDepositUpdateOptions) (*model.RecurringDeposit, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var recurringDeposit model.RecurringDeposit
+	query := dbExecutor.NewUpdate().Model(&recurringDeposit)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewStatus != nil {
+		query = query.Set("status = ?", *options.NewStatus)
+	}
+	if options.NewInterestPaid != nil {
+		query = query.Set("interest_paid = ?", *options.NewInterestPaid)
+	}
+	if options.NewClosedAt != nil {
+		query = query.Set("closed_at = ?", *options.NewClosedAt)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", recurringDepositID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating recurring deposit with ID: %s, error: %+v", recurringDepositID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the recurring deposit at the moment. Please try again later.",
+		}
+	}
+
+	return &recurringDeposit, nil
+}
+
+func (r *depositRepository) CreateInstallments(requestCtx context.Context, dbExecutor bun.IDB, installments []model.RecurringDepositInstallment) ([]model.RecurringDepositInstallment, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	err := dbExecutor.NewInsert().
+		Model(&installments).
+		Returning("*").
+		Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating %d installment(s) for recurring deposit, error: %+v", len(installments), err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't book your recurring deposit at the moment. Please try again later.",
+		}
+	}
+
+	return installments, nil
+}
+
+func (r *depositRepository) GetInstallment(requestCtx context.Context, dbExecutor bun.IDB, options types.InstallmentQueryOptions) (*model.RecurringDepositInstallment, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var installment model.RecurringDepositInstallment
+	query := dbExecutor.NewSelect().Model(&installment)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Installment not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding installment with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the installment at the moment. Please try again later.",
+		}
+	}
+
+	return &installment, nil
+}
+
+func (r *depositRepository) ListInstallments(requestCtx context.Context, dbExecutor bun.IDB, options types.InstallmentListOptions) ([]model.RecurringDepositInstallment, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var installments []model.RecurringDepositInstallment
+	query := dbExecutor.NewSelect().Model(&installments)
+
+	// dynamically construct the query based on which fields are set
+	if options.RecurringDepositID != nil {
+		query = query.Where("recurring_deposit_id = ?", *options.RecurringDepositID)
+	}
+	if options.Status != nil {
+		query = query.Where("status = ?", *options.Status)
+	}
+	if options.DueDateOnOrBefore != nil {
+		query = query.Where("due_date <= ?", options.DueDateOnOrBefore.Format(time.DateOnly))
+	}
+	if options.AfterID != nil {
+		query = query.Where("id > ?", *options.AfterID)
+	}
+	if options.Limit > 0 {
+		query = query.Order("id ASC").Limit(options.Limit)
+	} else {
+		query = query.Order("installment_number ASC")
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing installments with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the installments at the moment. Please try again later.",
+		}
+	}
+
+	return installments, nil
+}
+
+func (r *depositRepository) UpdateInstallment(requestCtx context.Context, dbExecutor bun.IDB, installmentID uuid.UUID, options types.InstallmentUpdateOptions) (*model.RecurringDepositInstallment, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var installment model.RecurringDepositInstallment
+	query := dbExecutor.NewUpdate().Model(&installment)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewStatus != nil {
+		query = query.Set("status = ?", *options.NewStatus)
+	}
+	if options.NewAttemptCount != nil {
+		query = query.Set("attempt_count = ?", *options.NewAttemptCount)
+	}
+	if options.NewLastAttemptedAt != nil {
+		query = query.Set("last_attempted_at = ?", *options.NewLastAttemptedAt)
+	}
+	if options.NewPaidAt != nil {
+		query = query.Set("paid_at = ?", *options.NewPaidAt)
+	}
+	if options.NewTransactionID != nil {
+		query = query.Set("transaction_id = ?", *options.NewTransactionID)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", installmentID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating installment with ID: %s, error: %+v", installmentID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the installment at the moment. Please try again later.",
+		}
+	}
+
+	return &installment, nil
+}
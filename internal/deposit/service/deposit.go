@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/config"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	accountService "github.com/skamranahmed/go-bank/internal/account/service"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/deposit/model"
+	"github.com/skamranahmed/go-bank/internal/deposit/repository"
+	"github.com/skamranahmed/go-bank/internal/deposit/types"
+	feeService "github.com/skamranahmed/go-bank/internal/fee/service"
+	ledgerModel "github.com/skamranahmed/go-bank/internal/ledger/model"
+	ledgerService "github.com/skamranahmed/go-bank/internal/ledger/service"
+	ledgerTypes "github.com/skamranahmed/go-bank/internal/ledger/types"
+	transferService "github.com/skamranahmed/go-bank/internal/transfer/service"
+	"github.com/uptrace/bun"
+)
+
+type depositService struct {
+	db                *bun.DB
+	depositRepository repository.DepositRepository
+	accountService    accountService.AccountService
+	transferService   transferService.TransferService
+	ledgerService     ledgerService.LedgerService
+	feeService        feeService.FeeService
+
+	fixedDepositConfig     config.FixedDepositConfig
+	recurringDepositConfig config.RecurringDepositConfig
+}
+
+func NewDepositService(
+	db *bun.DB,
+	depositRepository repository.DepositRepository,
+	accountService accountService.AccountService,
+	transferService transferService.TransferService,
+	ledgerService ledgerService.LedgerService,
+	feeService feeService.FeeService,
+	fixedDepositConfig config.FixedDepositConfig,
+	recurringDepositConfig config.RecurringDepositConfig,
+) DepositService {
+	return &depositService{
+		db:                db,
+		depositRepository: depositRepository,
+		accountService:    accountService,
+		transferService:   transferService,
+		ledgerService:     ledgerService,
+		feeService:        feeService,
+
+		fixedDepositConfig:     fixedDepositConfig,
+		recurringDepositConfig: recurringDepositConfig,
+	}
+}
+
+/*
+BookFixedDeposit opens a new FIXED_DEPOSIT account and moves the amount into it from the linked savings account
+
+It must be called within a database transaction so that the deposit account is not left behind when the funds cannot be moved.
+The interest rate is the one configured at the time of booking and stays the same for the whole term.
+*/
+func (s *depositService) BookFixedDeposit(requestCtx context.Context, dbExecutor bun.IDB, params types.BookFixedDepositParams) (*model.FixedDeposit, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	if params.Amount < s.fixedDepositConfig.MinimumAmount {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("Fixed deposit amount must be at least %d", s.fixedDepositConfig.MinimumAmount),
+		}
+	}
+
+	linkedAccount, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &params.LinkedAccountID,
+		Columns:   []string{"id", "user_id", "type", "currency", "mandate", "joint_approval_threshold"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if linkedAccount.Type != accountModel.SavingsAccount {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("Fixed deposits can only be booked from a %s", accountModel.SavingsAccount),
+		}
+	}
+
+	// the interest on deposits is paid out of the INR books of the bank
+	if string(linkedAccount.Currency) != config.GetBankConfig().Currency {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("Fixed deposits can only be booked from an account held in %s", config.GetBankConfig().Currency),
+		}
+	}
+
+	// a deposit cannot wait for the approval of every holder of a jointly operated account
+	err = s.transferService.EnforceJointMandate(requestCtx, dbExecutor, linkedAccount, params.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	depositAccount, err := s.accountService.CreateAccount(requestCtx, dbExecutor, params.UserID, accountModel.FixedDeposit, linkedAccount.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.transferService.MoveFunds(requestCtx, dbExecutor, linkedAccount.ID, depositAccount.ID, params.Amount, accountTypes.Remittance{})
+	if err != nil {
+		return nil, err
+	}
+
+	startDate := startOfDay(time.Now().UTC())
+	return s.depositRepository.CreateFixedDeposit(requestCtx, dbExecutor, &model.FixedDeposit{
+		UserID:                          params.UserID,
+		AccountID:                       depositAccount.ID,
+		LinkedAccountID:                 linkedAccount.ID,
+		PrincipalAmount:                 params.Amount,
+		AnnualInterestRateInBasisPoints: s.fixedDepositConfig.AnnualInterestRateInBasisPoints,
+		TenureInMonths:                  params.TenureInMonths,
+		StartDate:                       startDate,
+		MaturityDate:                    startDate.AddDate(0, params.TenureInMonths, 0),
+		MaturityInstruction:             params.MaturityInstruction,
+		Status:                          model.Active,
+	})
+}
+
+func (s *depositService) GetFixedDeposit(requestCtx context.Context, dbExecutor bun.IDB, options types.FixedDepositQueryOptions) (*model.FixedDeposit, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.depositRepository.GetFixedDeposit(requestCtx, dbExecutor, options)
+}
+
+func (s *depositService) ListFixedDeposits(requestCtx context.Context, dbExecutor bun.IDB, options types.FixedDepositListOptions) ([]model.FixedDeposit, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.depositRepository.ListFixedDeposits(requestCtx, dbExecutor, options)
+}
+
+/*
+ProcessFixedDepositMaturity credits the interest of the current term and then, as per the maturity instruction,
+either pays the principal and interest out to the linked account or renews them for another term at the current rate
+
+It must be called within a database transaction because it locks the deposit row for update.
+It returns a nil deposit when the deposit is not active or not due yet, so that a retried task does not process it twice.
+*/
+func (s *depositService) ProcessFixedDepositMaturity(requestCtx context.Context, dbExecutor bun.IDB, fixedDepositID uuid.UUID, processingDate time.Time) (*model.FixedDeposit, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	fixedDeposit, err := s.depositRepository.GetFixedDeposit(requestCtx, dbExecutor, types.FixedDepositQueryOptions{
+		ID:        &fixedDepositID,
+		ForUpdate: true, // lock the row so that the deposit cannot be closed while it is being processed
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if fixedDeposit.Status != model.Active || fixedDeposit.MaturityDate.After(processingDate) {
+		return nil, nil
+	}
+
+	interest := fixedDeposit.InterestEarned(fixedDeposit.MaturityDate, fixedDeposit.AnnualInterestRateInBasisPoints)
+	err = s.creditInterest(requestCtx, dbExecutor, fixedDeposit.AccountID, interest, fixedDeposit.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	totalInterestPaid := fixedDeposit.InterestPaid + interest
+
+	if fixedDeposit.MaturityInstruction == model.AutoRenew {
+		newPrincipalAmount := fixedDeposit.PrincipalAmount + interest
+		newStartDate := fixedDeposit.MaturityDate
+		newMaturityDate := newStartDate.AddDate(0, fixedDeposit.TenureInMonths, 0)
+		newRenewalCount := fixedDeposit.RenewalCount + 1
+		return s.depositRepository.UpdateFixedDeposit(requestCtx, dbExecutor, fixedDeposit.ID, types.FixedDepositUpdateOptions{
+			NewPrincipalAmount:                 &newPrincipalAmount,
+			NewAnnualInterestRateInBasisPoints: &s.fixedDepositConfig.AnnualInterestRateInBasisPoints,
+			NewStartDate:                       &newStartDate,
+			NewMaturityDate:                    &newMaturityDate,
+			NewRenewalCount:                    &newRenewalCount,
+			NewInterestPaid:                    &totalInterestPaid,
+		})
+	}
+
+	_, err = s.transferService.MoveFunds(requestCtx, dbExecutor, fixedDeposit.AccountID, fixedDeposit.LinkedAccountID, fixedDeposit.PrincipalAmount+interest, accountTypes.Remittance{})
+	if err != nil {
+		return nil, err
+	}
+
+	maturedStatus := model.Matured
+	closedAt := time.Now().UTC()
+	return s.depositRepository.UpdateFixedDeposit(requestCtx, dbExecutor, fixedDeposit.ID, types.FixedDepositUpdateOptions{
+		NewStatus:       &maturedStatus,
+		NewInterestPaid: &totalInterestPaid,
+		NewClosedAt:     &closedAt,
+	})
+}
+
+/*
+CloseFixedDepositPrematurely pays the deposit out to the linked account before its maturity date
+
+The interest for the time the deposit was held is paid at the booked rate reduced by the premature closure penalty.
+It must be called within a database transaction because it locks the deposit row for update.
+*/
+func (s *depositService) CloseFixedDepositPrematurely(requestCtx context.Context, dbExecutor bun.IDB, fixedDepositID uuid.UUID, closureDate time.Time) (*model.FixedDeposit, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	fixedDeposit, err := s.depositRepository.GetFixedDeposit(requestCtx, dbExecutor, types.FixedDepositQueryOptions{
+		ID:        &fixedDepositID,
+		ForUpdate: true, // lock the row so that the deposit cannot be processed for maturity while it is being closed
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if fixedDeposit.Status != model.Active {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Fixed deposit is not active",
+		}
+	}
+
+	// a deposit that is due is paid out in full by the maturity task, the penalty does not apply to it
+	if !fixedDeposit.MaturityDate.After(closureDate) {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Fixed deposit has already matured and will be processed shortly",
+		}
+	}
+
+	penaltyRate := max(fixedDeposit.AnnualInterestRateInBasisPoints-s.fixedDepositConfig.PrematureClosurePenaltyInBasisPoints, 0)
+	interest := fixedDeposit.InterestEarned(closureDate, penaltyRate)
+	err = s.creditInterest(requestCtx, dbExecutor, fixedDeposit.AccountID, interest, fixedDeposit.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.transferService.MoveFunds(requestCtx, dbExecutor, fixedDeposit.AccountID, fixedDeposit.LinkedAccountID, fixedDeposit.PrincipalAmount+interest, accountTypes.Remittance{})
+	if err != nil {
+		return nil, err
+	}
+
+	closedStatus := model.Closed
+	totalInterestPaid := fixedDeposit.InterestPaid + interest
+	closedAt := time.Now().UTC()
+	return s.depositRepository.UpdateFixedDeposit(requestCtx, dbExecutor, fixedDeposit.ID, types.FixedDepositUpdateOptions{
+		NewStatus:       &closedStatus,
+		NewInterestPaid: &totalInterestPaid,
+		NewClosedAt:     &closedAt,
+	})
+}
+
+// creditInterest credits the interest to the deposit account, the bank's side of it is booked as an interest expense against the given reference
+func (s *depositService) creditInterest(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, interest int64, reference string) error {
+	if interest <= 0 {
+		return nil
+	}
+
+	account, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &accountID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return err
+	}
+
+	newBalance := account.Balance + interest
+	account, err = s.accountService.UpdateAccount(requestCtx, dbExecutor, account.ID, accountTypes.AccountUpdateOptions{
+		NewBalance: &newBalance,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.accountService.CreateTransactionRecord(requestCtx, dbExecutor, &accountModel.Transaction{
+		AccountID:    account.ID,
+		Amount:       interest,
+		BalanceAfter: account.Balance,
+		Type:         accountModel.InterestCredit,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.ledgerService.PostEntry(requestCtx, dbExecutor, ledgerTypes.PostEntryParams{
+		InternalAccountCode: ledgerModel.InterestExpense,
+		Type:                ledgerModel.Debit,
+		Amount:              interest,
+		Reference:           reference,
+	})
+	return err
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
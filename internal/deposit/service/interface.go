@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/deposit/model"
+	"github.com/skamranahmed/go-bank/internal/deposit/types"
+	"github.com/uptrace/bun"
+)
+
+type DepositService interface {
+	BookFixedDeposit(requestCtx context.Context, dbExecutor bun.IDB, params types.BookFixedDepositParams) (*model.FixedDeposit, error)
+	GetFixedDeposit(requestCtx context.Context, dbExecutor bun.IDB, options types.FixedDepositQueryOptions) (*model.FixedDeposit, error)
+	ListFixedDeposits(requestCtx context.Context, dbExecutor bun.IDB, options types.FixedDepositListOptions) ([]model.FixedDeposit, error)
+	ProcessFixedDepositMaturity(requestCtx context.Context, dbExecutor bun.IDB, fixedDepositID uuid.UUID, processingDate time.Time) (*model.FixedDeposit, error)
+	CloseFixedDepositPrematurely(requestCtx context.Context, dbExecutor bun.IDB, fixedDepositID uuid.UUID, closureDate time.Time) (*model.FixedDeposit, error)
+
+	BookRecurringDeposit(requestCtx context.Context, dbExecutor bun.IDB, params types.BookRecurringDepositParams) (*model.RecurringDeposit, error)
+	GetRecurringDeposit(requestCtx context.Context, dbExecutor bun.IDB, options types.RecurringDepositQueryOptions) (*model.RecurringDeposit, error)
+	ListRecurringDeposits(requestCtx context.Context, dbExecutor bun.IDB, options types.RecurringDepositListOptions) ([]model.RecurringDeposit, error)
+	ListInstallments(requestCtx context.Context, dbExecutor bun.IDB, options types.InstallmentListOptions) ([]model.RecurringDepositInstallment, error)
+	CollectRecurringDepositInstallment(requestCtx context.Context, dbExecutor bun.IDB, installmentID uuid.UUID, collectionDate time.Time) (*model.RecurringDepositInstallment, error)
+	ProcessRecurringDepositMaturity(requestCtx context.Context, dbExecutor bun.IDB, recurringDepositID uuid.UUID, processingDate time.Time) (*model.RecurringDeposit, error)
+}
@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/config"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/deposit/model"
+	"github.com/skamranahmed/go-bank/internal/deposit/types"
+	feeModel "github.com/skamranahmed/go-bank/internal/fee/model"
+	feeTypes "github.com/skamranahmed/go-bank/internal/fee/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+/*
+BookRecurringDeposit opens a new RECURRING_DEPOSIT account, creates the whole installment schedule
+and collects the first installment from the linked savings account right away
+
+It must be called within a database transaction so that the deposit is not left behind when the first installment cannot be collected.
+The remaining installments are auto-debited on the chosen day of every following month by the collection task.
+*/
+func (s *depositService) BookRecurringDeposit(requestCtx context.Context, dbExecutor bun.IDB, params types.BookRecurringDepositParams) (*model.RecurringDeposit, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	if params.InstallmentAmount < s.recurringDepositConfig.MinimumInstallmentAmount {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("Recurring deposit installment amount must be at least %d", s.recurringDepositConfig.MinimumInstallmentAmount),
+		}
+	}
+
+	linkedAccount, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &params.LinkedAccountID,
+		Columns:   []string{"id", "user_id", "type", "currency", "mandate", "joint_approval_threshold"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if linkedAccount.Type != accountModel.SavingsAccount {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("Recurring deposits can only be booked from a %s", accountModel.SavingsAccount),
+		}
+	}
+
+	// the interest on deposits is paid out of the INR books of the bank
+	if string(linkedAccount.Currency) != config.GetBankConfig().Currency {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("Recurring deposits can only be booked from an account held in %s", config.GetBankConfig().Currency),
+		}
+	}
+
+	// a deposit cannot wait for the approval of every holder of a jointly operated account
+	err = s.transferService.EnforceJointMandate(requestCtx, dbExecutor, linkedAccount, params.InstallmentAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	depositAccount, err := s.accountService.CreateAccount(requestCtx, dbExecutor, params.UserID, accountModel.RecurringDeposit, linkedAccount.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	recurringDeposit := &model.RecurringDeposit{
+		UserID:                          params.UserID,
+		AccountID:                       depositAccount.ID,
+		LinkedAccountID:                 linkedAccount.ID,
+		InstallmentAmount:               params.InstallmentAmount,
+		AnnualInterestRateInBasisPoints: s.recurringDepositConfig.AnnualInterestRateInBasisPoints,
+		TenureInMonths:                  params.TenureInMonths,
+		DebitDayOfMonth:                 params.DebitDayOfMonth,
+		StartDate:                       startOfDay(time.Now().UTC()),
+		Status:                          model.RecurringDepositActive,
+	}
+	recurringDeposit.MaturityDate = recurringDeposit.CalculateMaturityDate()
+
+	recurringDeposit, err = s.depositRepository.CreateRecurringDeposit(requestCtx, dbExecutor, recurringDeposit)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.transferService.MoveFunds(requestCtx, dbExecutor, linkedAccount.ID, depositAccount.ID, params.InstallmentAmount, accountTypes.Remittance{})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	installments := make([]model.RecurringDepositInstallment, 0, params.TenureInMonths)
+	for installmentNumber := 1; installmentNumber <= params.TenureInMonths; installmentNumber++ {
+		installment := model.RecurringDepositInstallment{
+			RecurringDepositID: recurringDeposit.ID,
+			InstallmentNumber:  installmentNumber,
+			DueDate:            recurringDeposit.InstallmentDueDate(installmentNumber),
+			Amount:             params.InstallmentAmount,
+			Status:             model.InstallmentPending,
+		}
+
+		// the first installment is collected while booking
+		if installmentNumber == 1 {
+			installment.Status = model.InstallmentPaid
+			installment.AttemptCount = 1
+			installment.LastAttemptedAt = &now
+			installment.PaidAt = &now
+			installment.TransactionID = &transaction.ID
+		}
+
+		installments = append(installments, installment)
+	}
+
+	_, err = s.depositRepository.CreateInstallments(requestCtx, dbExecutor, installments)
+	if err != nil {
+		return nil, err
+	}
+
+	return recurringDeposit, nil
+}
+
+func (s *depositService) GetRecurringDeposit(requestCtx context.Context, dbExecutor bun.IDB, options types.RecurringDepositQueryOptions) (*model.RecurringDeposit, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.depositRepository.GetRecurringDeposit(requestCtx, dbExecutor, options)
+}
+
+func (s *depositService) ListRecurringDeposits(requestCtx context.Context, dbExecutor bun.IDB, options types.RecurringDepositListOptions) ([]model.RecurringDeposit, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.depositRepository.ListRecurringDeposits(requestCtx, dbExecutor, options)
+}
+
+func (s *depositService) ListInstallments(requestCtx context.Context, dbExecutor bun.IDB, options types.InstallmentListOptions) ([]model.RecurringDepositInstallment, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.depositRepository.ListInstallments(requestCtx, dbExecutor, options)
+}
+
+/*
+CollectRecurringDepositInstallment auto-debits a due installment from the linked account
+
+When the linked account does not have enough funds, the attempt is recorded and the installment stays PENDING so that
+it is retried the next day. It is marked MISSED when the last attempt within the grace period fails.
+
+It must be called within a database transaction because it locks the deposit and the installment rows for update.
+It returns a nil installment when the installment is not pending, not due yet or was already attempted on the collection date,
+so that a duplicate or retried task never debits the same installment twice.
+*/
+func (s *depositService) CollectRecurringDepositInstallment(requestCtx context.Context, dbExecutor bun.IDB, installmentID uuid.UUID, collectionDate time.Time) (*model.RecurringDepositInstallment, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	installment, err := s.depositRepository.GetInstallment(requestCtx, dbExecutor, types.InstallmentQueryOptions{
+		ID: &installmentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the deposit is locked before the installment, in the same order as the maturity processing, to prevent deadlocks
+	recurringDeposit, err := s.depositRepository.GetRecurringDeposit(requestCtx, dbExecutor, types.RecurringDepositQueryOptions{
+		ID:        &installment.RecurringDepositID,
+		ForUpdate: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	installment, err = s.depositRepository.GetInstallment(requestCtx, dbExecutor, types.InstallmentQueryOptions{
+		ID:        &installmentID,
+		ForUpdate: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if recurringDeposit.Status != model.RecurringDepositActive || installment.Status != model.InstallmentPending || installment.DueDate.After(collectionDate) {
+		return nil, nil
+	}
+	if installment.LastAttemptedAt != nil && startOfDay(*installment.LastAttemptedAt).Equal(collectionDate) {
+		return nil, nil
+	}
+
+	/*
+		Only the linked account is locked here, the deposit account is locked later by MoveFunds.
+		The deposit account is only ever used by this deposit, whose row is already locked above,
+		so no other transaction can be waiting on it while holding the linked account
+	*/
+	linkedAccount, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &recurringDeposit.LinkedAccountID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	newAttemptCount := installment.AttemptCount + 1
+
+	if linkedAccount.AvailableBalance() < installment.Amount {
+		updateOptions := types.InstallmentUpdateOptions{
+			NewAttemptCount:    &newAttemptCount,
+			NewLastAttemptedAt: &now,
+		}
+
+		lastAttemptDate := installment.DueDate.AddDate(0, 0, s.recurringDepositConfig.GracePeriodInDays)
+		if !collectionDate.Before(lastAttemptDate) {
+			missedStatus := model.InstallmentMissed
+			updateOptions.NewStatus = &missedStatus
+			logger.Warn(requestCtx, "Installment %d of recurringDepositID: %s missed after %d attempt(s)", installment.InstallmentNumber, recurringDeposit.ID, newAttemptCount)
+		}
+
+		return s.depositRepository.UpdateInstallment(requestCtx, dbExecutor, installment.ID, updateOptions)
+	}
+
+	transaction, err := s.transferService.MoveFunds(requestCtx, dbExecutor, linkedAccount.ID, recurringDeposit.AccountID, installment.Amount, accountTypes.Remittance{})
+	if err != nil {
+		return nil, err
+	}
+
+	paidStatus := model.InstallmentPaid
+	return s.depositRepository.UpdateInstallment(requestCtx, dbExecutor, installment.ID, types.InstallmentUpdateOptions{
+		NewStatus:          &paidStatus,
+		NewAttemptCount:    &newAttemptCount,
+		NewLastAttemptedAt: &now,
+		NewPaidAt:          &now,
+		NewTransactionID:   &transaction.ID,
+	})
+}
+
+/*
+ProcessRecurringDepositMaturity pays a due recurring deposit out to the linked account
+
+Installments that are still pending are marked MISSED. Every paid installment earns interest from the day it was paid,
+and a penalty is charged for every missed installment before the whole balance is paid out.
+
+It must be called within a database transaction because it locks the deposit and its installments for update.
+It returns a nil deposit when the deposit is not active or not due yet, so that a retried task does not process it twice.
+*/
+func (s *depositService) ProcessRecurringDepositMaturity(requestCtx context.Context, dbExecutor bun.IDB, recurringDepositID uuid.UUID, processingDate time.Time) (*model.RecurringDeposit, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	recurringDeposit, err := s.depositRepository.GetRecurringDeposit(requestCtx, dbExecutor, types.RecurringDepositQueryOptions{
+		ID:        &recurringDepositID,
+		ForUpdate: true, // lock the row so that no installment is collected while the deposit is being paid out
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if recurringDeposit.Status != model.RecurringDepositActive || recurringDeposit.MaturityDate.After(processingDate) {
+		return nil, nil
+	}
+
+	installments, err := s.depositRepository.ListInstallments(requestCtx, dbExecutor, types.InstallmentListOptions{
+		RecurringDepositID: &recurringDeposit.ID,
+		ForUpdate:          true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var interest int64
+	var missedInstallments []model.RecurringDepositInstallment
+	for _, installment := range installments {
+		switch installment.Status {
+		case model.InstallmentPaid:
+			interest += installment.InterestEarned(recurringDeposit.MaturityDate, recurringDeposit.AnnualInterestRateInBasisPoints)
+		case model.InstallmentPending:
+			missedStatus := model.InstallmentMissed
+			_, err = s.depositRepository.UpdateInstallment(requestCtx, dbExecutor, installment.ID, types.InstallmentUpdateOptions{
+				NewStatus: &missedStatus,
+			})
+			if err != nil {
+				return nil, err
+			}
+			missedInstallments = append(missedInstallments, installment)
+		case model.InstallmentMissed:
+			missedInstallments = append(missedInstallments, installment)
+		}
+	}
+
+	err = s.creditInterest(requestCtx, dbExecutor, recurringDeposit.AccountID, interest, recurringDeposit.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, installment := range missedInstallments {
+		_, err = s.feeService.ChargeFee(requestCtx, dbExecutor, feeTypes.ChargeFeeParams{
+			AccountID:  recurringDeposit.AccountID,
+			Event:      feeModel.RecurringDepositMissedInstallment,
+			BaseAmount: installment.Amount,
+			Reference:  installment.ID.String(),
+
+			// the penalty is levied by the bank on its own, it must not fail the payout
+			CapAtAvailableBalance: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	depositAccount, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &recurringDeposit.AccountID,
+		Columns:   []string{"id", "balance"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if depositAccount.Balance > 0 {
+		_, err = s.transferService.MoveFunds(requestCtx, dbExecutor, recurringDeposit.AccountID, recurringDeposit.LinkedAccountID, depositAccount.Balance, accountTypes.Remittance{})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	maturedStatus := model.RecurringDepositMatured
+	totalInterestPaid := recurringDeposit.InterestPaid + interest
+	closedAt := time.Now().UTC()
+	return s.depositRepository.UpdateRecurringDeposit(requestCtx, dbExecutor, recurringDeposit.ID, types.RecurringDepositUpdateOptions{
+		NewStatus:       &maturedStatus,
+		NewInterestPaid: &totalInterestPaid,
+		NewClosedAt:     &closedAt,
+	})
+}
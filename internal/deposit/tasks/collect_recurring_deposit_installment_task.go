@@ -0,0 +1,113 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/internal/deposit/model"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/uptrace/bun"
+)
+
+const CollectRecurringDepositInstallmentTaskName string = "task:collect_recurring_deposit_installment"
+
+type CollectRecurringDepositInstallmentTaskPayload struct {
+	InstallmentID string
+
+	// CollectionDate is the day the collection was scheduled for, in YYYY-MM-DD format
+	CollectionDate string
+}
+
+type CollectRecurringDepositInstallmentTask struct {
+	name          string
+	queue         string
+	maxRetryCount int
+	payload       CollectRecurringDepositInstallmentTaskPayload
+}
+
+func NewCollectRecurringDepositInstallmentTask(installmentID string, collectionDate string) tasksHelper.Task {
+	return &CollectRecurringDepositInstallmentTask{
+		name:          CollectRecurringDepositInstallmentTaskName,
+		queue:         tasksHelper.DefaultQueue,
+		maxRetryCount: 3,
+		payload: CollectRecurringDepositInstallmentTaskPayload{
+			InstallmentID:  installmentID,
+			CollectionDate: collectionDate,
+		},
+	}
+}
+
+func (t *CollectRecurringDepositInstallmentTask) Name() string {
+	return t.name
+}
+
+func (t *CollectRecurringDepositInstallmentTask) Queue() string {
+	return t.queue
+}
+
+func (t *CollectRecurringDepositInstallmentTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *CollectRecurringDepositInstallmentTask) Payload() any {
+	return t.payload
+}
+
+type CollectRecurringDepositInstallmentTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewCollectRecurringDepositInstallmentTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &CollectRecurringDepositInstallmentTaskProcessor{
+		services: services,
+	}
+}
+
+/*
+ProcessTask auto-debits a single installment from the linked account
+
+Insufficient funds is not an error of the task, the attempt is recorded and the installment is picked up again
+by the next day's collection. The task is only retried for unexpected errors, which roll back the whole attempt.
+*/
+func (processor *CollectRecurringDepositInstallmentTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[CollectRecurringDepositInstallmentTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	installmentID, err := uuid.Parse(payload.Data.InstallmentID)
+	if err != nil {
+		return fmt.Errorf("Invalid installmentID: %s in payload for task: %s, error: %v", payload.Data.InstallmentID, t.Name(), err)
+	}
+
+	collectionDate, err := time.Parse(time.DateOnly, payload.Data.CollectionDate)
+	if err != nil {
+		return fmt.Errorf("Invalid collectionDate: %s in payload for task: %s, error: %v", payload.Data.CollectionDate, t.Name(), err)
+	}
+
+	var installment *model.RecurringDepositInstallment
+	err = database.RunInTransaction(ctx, "collectRecurringDepositInstallment", processor.services.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		installment, err = processor.services.DepositService.CollectRecurringDepositInstallment(txCtx, tx, installmentID, collectionDate)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if installment == nil {
+		logger.Info(ctx, "Installment with installmentID: %s is not due for collection", installmentID)
+		return nil
+	}
+
+	logger.Info(ctx, "Collection attempt %d for installmentID: %s finished with status: %s", installment.AttemptCount, installment.ID, installment.Status)
+	return nil
+}
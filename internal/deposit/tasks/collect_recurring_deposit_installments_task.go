@@ -0,0 +1,127 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/internal/deposit/model"
+	"github.com/skamranahmed/go-bank/internal/deposit/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+)
+
+const CollectRecurringDepositInstallmentsTaskName string = "periodic_task:collect_recurring_deposit_installments"
+
+// number of due installments fetched from the database in one go
+const collectRecurringDepositInstallmentsBatchSize int = 100
+
+type CollectRecurringDepositInstallmentsTaskPayload struct {
+}
+
+type CollectRecurringDepositInstallmentsTask struct {
+	name          string
+	queue         string
+	cronSpec      string
+	maxRetryCount int
+	payload       CollectRecurringDepositInstallmentsTaskPayload
+}
+
+func NewCollectRecurringDepositInstallmentsTask() tasksHelper.SchedulableTask {
+	return &CollectRecurringDepositInstallmentsTask{
+		name:          CollectRecurringDepositInstallmentsTaskName,
+		queue:         tasksHelper.DefaultQueue,
+		cronSpec:      "0 6 * * *", // run every day at 06:00
+		maxRetryCount: 3,
+		payload:       CollectRecurringDepositInstallmentsTaskPayload{},
+	}
+}
+
+func (t *CollectRecurringDepositInstallmentsTask) Name() string {
+	return t.name
+}
+
+func (t *CollectRecurringDepositInstallmentsTask) Queue() string {
+	return t.queue
+}
+
+func (t *CollectRecurringDepositInstallmentsTask) CronSpec() string {
+	return t.cronSpec
+}
+
+func (t *CollectRecurringDepositInstallmentsTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *CollectRecurringDepositInstallmentsTask) Payload() any {
+	return t.payload
+}
+
+type CollectRecurringDepositInstallmentsTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewCollectRecurringDepositInstallmentsTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &CollectRecurringDepositInstallmentsTaskProcessor{
+		services: services,
+	}
+}
+
+/*
+ProcessTask enqueues a collection task for every pending installment that is due, including the ones
+that could not be collected on earlier days for lack of funds
+
+The installments are debited by the per-installment task, so that one slow or failing debit does not hold up the others.
+An installment that was already attempted today is skipped by the collection itself, so retrying this task is safe.
+*/
+func (processor *CollectRecurringDepositInstallmentsTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[CollectRecurringDepositInstallmentsTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	now := time.Now().UTC()
+	collectionDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	pendingStatus := model.InstallmentPending
+
+	var afterID *uuid.UUID
+	var failedCount, enqueuedCount int
+	for {
+		installments, err := processor.services.DepositService.ListInstallments(ctx, nil, types.InstallmentListOptions{
+			Status:            &pendingStatus,
+			DueDateOnOrBefore: &collectionDate,
+			AfterID:           afterID,
+			Limit:             collectRecurringDepositInstallmentsBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, installment := range installments {
+			err := processor.services.TaskEnqueuer.Enqueue(ctx, NewCollectRecurringDepositInstallmentTask(installment.ID.String(), collectionDate.Format(time.DateOnly)), nil, nil)
+			if err != nil {
+				failedCount++
+				logger.Error(ctx, "Unable to enqueue CollectRecurringDepositInstallmentTask for installmentID: %s, error: %+v", installment.ID, err)
+				continue
+			}
+			enqueuedCount++
+		}
+
+		if len(installments) < collectRecurringDepositInstallmentsBatchSize {
+			break
+		}
+		afterID = &installments[len(installments)-1].ID
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf("Unable to enqueue collection of %d installment(s)", failedCount)
+	}
+
+	logger.Info(ctx, "Collection enqueued for %d installment(s)", enqueuedCount)
+	return nil
+}
@@ -0,0 +1,142 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/internal/deposit/model"
+	"github.com/skamranahmed/go-bank/internal/deposit/types"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/uptrace/bun"
+)
+
+const ProcessFixedDepositMaturitiesTaskName string = "periodic_task:process_fixed_deposit_maturities"
+
+// number of due fixed deposits fetched from the database in one go
+const processFixedDepositMaturitiesBatchSize int = 100
+
+type ProcessFixedDepositMaturitiesTaskPayload struct {
+}
+
+type ProcessFixedDepositMaturitiesTask struct {
+	name          string
+	queue         string
+	cronSpec      string
+	maxRetryCount int
+	payload       ProcessFixedDepositMaturitiesTaskPayload
+}
+
+func NewProcessFixedDepositMaturitiesTask() tasksHelper.SchedulableTask {
+	return &ProcessFixedDepositMaturitiesTask{
+		name:          ProcessFixedDepositMaturitiesTaskName,
+		queue:         tasksHelper.DefaultQueue,
+		cronSpec:      "30 0 * * *", // run every day at 00:30
+		maxRetryCount: 3,
+		payload:       ProcessFixedDepositMaturitiesTaskPayload{},
+	}
+}
+
+func (t *ProcessFixedDepositMaturitiesTask) Name() string {
+	return t.name
+}
+
+func (t *ProcessFixedDepositMaturitiesTask) Queue() string {
+	return t.queue
+}
+
+func (t *ProcessFixedDepositMaturitiesTask) CronSpec() string {
+	return t.cronSpec
+}
+
+func (t *ProcessFixedDepositMaturitiesTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *ProcessFixedDepositMaturitiesTask) Payload() any {
+	return t.payload
+}
+
+type ProcessFixedDepositMaturitiesTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewProcessFixedDepositMaturitiesTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &ProcessFixedDepositMaturitiesTaskProcessor{
+		services: services,
+	}
+}
+
+/*
+ProcessTask pays out or renews every active fixed deposit that is due, including the ones missed on earlier days
+
+Each deposit is processed in its own database transaction so that a failure for one deposit
+does not roll back the others. A processed deposit is either no longer active or has moved to its next term,
+so retrying the task only processes the deposits that were missed.
+*/
+func (processor *ProcessFixedDepositMaturitiesTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[ProcessFixedDepositMaturitiesTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	now := time.Now().UTC()
+	processingDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	activeStatus := model.Active
+
+	var afterID *uuid.UUID
+	var failedCount, processedCount int
+	for {
+		fixedDeposits, err := processor.services.DepositService.ListFixedDeposits(ctx, nil, types.FixedDepositListOptions{
+			Status:                 &activeStatus,
+			MaturityDateOnOrBefore: &processingDate,
+			AfterID:                afterID,
+			Limit:                  processFixedDepositMaturitiesBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, fixedDeposit := range fixedDeposits {
+			var processedFixedDeposit *model.FixedDeposit
+			err := database.RunInTransaction(ctx, "processFixedDepositMaturity", processor.services.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+				var err error
+				processedFixedDeposit, err = processor.services.DepositService.ProcessFixedDepositMaturity(txCtx, tx, fixedDeposit.ID, processingDate)
+				return err
+			})
+			if err != nil {
+				failedCount++
+				logger.Error(ctx, "Unable to process maturity of fixedDepositID: %s, error: %+v", fixedDeposit.ID, err)
+				continue
+			}
+			if processedFixedDeposit == nil {
+				continue
+			}
+
+			processedCount++
+			err = processor.services.TaskEnqueuer.Enqueue(ctx, NewSendFixedDepositMaturityNotificationTask(processedFixedDeposit.ID.String(), string(processedFixedDeposit.Status)), nil, nil)
+			if err != nil {
+				logger.Error(ctx, "Unable to enqueue SendFixedDepositMaturityNotificationTask for fixedDepositID: %s, error: %+v", processedFixedDeposit.ID, err)
+			}
+		}
+
+		if len(fixedDeposits) < processFixedDepositMaturitiesBatchSize {
+			break
+		}
+		afterID = &fixedDeposits[len(fixedDeposits)-1].ID
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf("Unable to process maturity of %d fixed deposit(s)", failedCount)
+	}
+
+	logger.Info(ctx, "Maturity processed for %d fixed deposit(s)", processedCount)
+	return nil
+}
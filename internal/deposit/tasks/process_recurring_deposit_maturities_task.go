@@ -0,0 +1,135 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/internal/deposit/model"
+	"github.com/skamranahmed/go-bank/internal/deposit/types"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/uptrace/bun"
+)
+
+const ProcessRecurringDepositMaturitiesTaskName string = "periodic_task:process_recurring_deposit_maturities"
+
+// number of due recurring deposits fetched from the database in one go
+const processRecurringDepositMaturitiesBatchSize int = 100
+
+type ProcessRecurringDepositMaturitiesTaskPayload struct {
+}
+
+type ProcessRecurringDepositMaturitiesTask struct {
+	name          string
+	queue         string
+	cronSpec      string
+	maxRetryCount int
+	payload       ProcessRecurringDepositMaturitiesTaskPayload
+}
+
+func NewProcessRecurringDepositMaturitiesTask() tasksHelper.SchedulableTask {
+	return &ProcessRecurringDepositMaturitiesTask{
+		name:          ProcessRecurringDepositMaturitiesTaskName,
+		queue:         tasksHelper.DefaultQueue,
+		cronSpec:      "45 0 * * *", // run every day at 00:45
+		maxRetryCount: 3,
+		payload:       ProcessRecurringDepositMaturitiesTaskPayload{},
+	}
+}
+
+func (t *ProcessRecurringDepositMaturitiesTask) Name() string {
+	return t.name
+}
+
+func (t *ProcessRecurringDepositMaturitiesTask) Queue() string {
+	return t.queue
+}
+
+func (t *ProcessRecurringDepositMaturitiesTask) CronSpec() string {
+	return t.cronSpec
+}
+
+func (t *ProcessRecurringDepositMaturitiesTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *ProcessRecurringDepositMaturitiesTask) Payload() any {
+	return t.payload
+}
+
+type ProcessRecurringDepositMaturitiesTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewProcessRecurringDepositMaturitiesTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &ProcessRecurringDepositMaturitiesTaskProcessor{
+		services: services,
+	}
+}
+
+/*
+ProcessTask pays out every active recurring deposit that is due, including the ones missed on earlier days
+
+Each deposit is processed in its own database transaction so that a failure for one deposit
+does not roll back the others. A processed deposit is no longer active, so retrying the task only processes the deposits that were missed.
+*/
+func (processor *ProcessRecurringDepositMaturitiesTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[ProcessRecurringDepositMaturitiesTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	now := time.Now().UTC()
+	processingDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	activeStatus := model.RecurringDepositActive
+
+	var afterID *uuid.UUID
+	var failedCount, processedCount int
+	for {
+		recurringDeposits, err := processor.services.DepositService.ListRecurringDeposits(ctx, nil, types.RecurringDepositListOptions{
+			Status:                 &activeStatus,
+			MaturityDateOnOrBefore: &processingDate,
+			AfterID:                afterID,
+			Limit:                  processRecurringDepositMaturitiesBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, recurringDeposit := range recurringDeposits {
+			var processedRecurringDeposit *model.RecurringDeposit
+			err := database.RunInTransaction(ctx, "processRecurringDepositMaturity", processor.services.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+				var err error
+				processedRecurringDeposit, err = processor.services.DepositService.ProcessRecurringDepositMaturity(txCtx, tx, recurringDeposit.ID, processingDate)
+				return err
+			})
+			if err != nil {
+				failedCount++
+				logger.Error(ctx, "Unable to process maturity of recurringDepositID: %s, error: %+v", recurringDeposit.ID, err)
+				continue
+			}
+			if processedRecurringDeposit != nil {
+				processedCount++
+			}
+		}
+
+		if len(recurringDeposits) < processRecurringDepositMaturitiesBatchSize {
+			break
+		}
+		afterID = &recurringDeposits[len(recurringDeposits)-1].ID
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf("Unable to process maturity of %d recurring deposit(s)", failedCount)
+	}
+
+	logger.Info(ctx, "Maturity processed for %d recurring deposit(s)", processedCount)
+	return nil
+}
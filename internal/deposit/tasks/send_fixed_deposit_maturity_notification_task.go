@@ -0,0 +1,78 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+)
+
+const SendFixedDepositMaturityNotificationTaskName string = "task:send_fixed_deposit_maturity_notification"
+
+type SendFixedDepositMaturityNotificationTaskPayload struct {
+	FixedDepositID string
+
+	// Status after processing, MATURED when paid out and ACTIVE when renewed
+	Status string
+}
+
+type SendFixedDepositMaturityNotificationTask struct {
+	name          string
+	queue         string
+	maxRetryCount int
+	payload       SendFixedDepositMaturityNotificationTaskPayload
+}
+
+func NewSendFixedDepositMaturityNotificationTask(fixedDepositID string, status string) tasksHelper.Task {
+	return &SendFixedDepositMaturityNotificationTask{
+		name:          SendFixedDepositMaturityNotificationTaskName,
+		queue:         tasksHelper.DefaultQueue,
+		maxRetryCount: 3,
+		payload: SendFixedDepositMaturityNotificationTaskPayload{
+			FixedDepositID: fixedDepositID,
+			Status:         status,
+		},
+	}
+}
+
+func (t *SendFixedDepositMaturityNotificationTask) Name() string {
+	return t.name
+}
+
+func (t *SendFixedDepositMaturityNotificationTask) Queue() string {
+	return t.queue
+}
+
+func (t *SendFixedDepositMaturityNotificationTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *SendFixedDepositMaturityNotificationTask) Payload() any {
+	return t.payload
+}
+
+type SendFixedDepositMaturityNotificationTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewSendFixedDepositMaturityNotificationTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &SendFixedDepositMaturityNotificationTaskProcessor{
+		services: services,
+	}
+}
+
+func (processor *SendFixedDepositMaturityNotificationTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[SendFixedDepositMaturityNotificationTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	// TODO: maybe add a real email/push provider here in the future
+	logger.Info(ctx, "[Dummy] send fixed deposit maturity notification for fixedDepositID: %s with status: %s", payload.Data.FixedDepositID, payload.Data.Status)
+	return nil
+}
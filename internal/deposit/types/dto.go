@@ -0,0 +1,189 @@
+package types
+
+import (
+	"time"
+
+	"github.com/skamranahmed/go-bank/internal/deposit/model"
+)
+
+type BookFixedDepositRequest struct {
+	Data BookFixedDepositRequestData `json:"data" binding:"required"`
+}
+
+type BookFixedDepositRequestData struct {
+	LinkedAccountID     int64  `json:"linked_account_id" binding:"required,account_number"`
+	Amount              *int64 `json:"amount" binding:"required,gt=0"`
+	TenureInMonths      *int   `json:"tenure_in_months" binding:"required,gte=1,lte=120"`
+	MaturityInstruction string `json:"maturity_instruction" binding:"required,oneof=PAYOUT AUTO_RENEW"`
+}
+
+type FixedDepositDto struct {
+	ID                              string                    `json:"id"`
+	CreatedAt                       time.Time                 `json:"created_at"`
+	AccountID                       int64                     `json:"account_id"`
+	LinkedAccountID                 int64                     `json:"linked_account_id"`
+	PrincipalAmount                 int64                     `json:"principal_amount"`
+	AnnualInterestRateInBasisPoints int64                     `json:"annual_interest_rate_in_basis_points"`
+	TenureInMonths                  int                       `json:"tenure_in_months"`
+	StartDate                       string                    `json:"start_date"`
+	MaturityDate                    string                    `json:"maturity_date"`
+	MaturityInstruction             model.MaturityInstruction `json:"maturity_instruction"`
+	Status                          model.FixedDepositStatus  `json:"status"`
+	RenewalCount                    int                       `json:"renewal_count"`
+	InterestPaid                    int64                     `json:"interest_paid"`
+	ClosedAt                        *time.Time                `json:"closed_at"`
+
+	// MaturityAmount is the projected payout of the current term, only set for active deposits
+	MaturityAmount *int64 `json:"maturity_amount"`
+}
+
+type BookFixedDepositResponse struct {
+	Data FixedDepositDto `json:"data"`
+}
+
+type GetFixedDepositsResponse struct {
+	Data []FixedDepositDto `json:"data"`
+}
+
+type CloseFixedDepositResponse struct {
+	Data FixedDepositDto `json:"data"`
+}
+
+func TransformToFixedDepositDto(fixedDeposit *model.FixedDeposit) *FixedDepositDto {
+	var maturityAmount *int64
+	if fixedDeposit.Status == model.Active {
+		amount := fixedDeposit.MaturityAmount()
+		maturityAmount = &amount
+	}
+
+	return &FixedDepositDto{
+		ID:                              fixedDeposit.ID.String(),
+		CreatedAt:                       fixedDeposit.CreatedAt,
+		AccountID:                       fixedDeposit.AccountID,
+		LinkedAccountID:                 fixedDeposit.LinkedAccountID,
+		PrincipalAmount:                 fixedDeposit.PrincipalAmount,
+		AnnualInterestRateInBasisPoints: fixedDeposit.AnnualInterestRateInBasisPoints,
+		TenureInMonths:                  fixedDeposit.TenureInMonths,
+		StartDate:                       fixedDeposit.StartDate.Format(time.DateOnly),
+		MaturityDate:                    fixedDeposit.MaturityDate.Format(time.DateOnly),
+		MaturityInstruction:             fixedDeposit.MaturityInstruction,
+		Status:                          fixedDeposit.Status,
+		RenewalCount:                    fixedDeposit.RenewalCount,
+		InterestPaid:                    fixedDeposit.InterestPaid,
+		ClosedAt:                        fixedDeposit.ClosedAt,
+		MaturityAmount:                  maturityAmount,
+	}
+}
+
+func TransformToFixedDepositDtoList(fixedDeposits []model.FixedDeposit) []FixedDepositDto {
+	fixedDepositDtos := make([]FixedDepositDto, 0, len(fixedDeposits))
+	for _, fixedDeposit := range fixedDeposits {
+		fixedDepositDtos = append(fixedDepositDtos, *TransformToFixedDepositDto(&fixedDeposit))
+	}
+	return fixedDepositDtos
+}
+
+type BookRecurringDepositRequest struct {
+	Data BookRecurringDepositRequestData `json:"data" binding:"required"`
+}
+
+type BookRecurringDepositRequestData struct {
+	LinkedAccountID   int64  `json:"linked_account_id" binding:"required,account_number"`
+	InstallmentAmount *int64 `json:"installment_amount" binding:"required,gt=0"`
+	TenureInMonths    *int   `json:"tenure_in_months" binding:"required,gte=6,lte=120"`
+	DebitDayOfMonth   *int   `json:"debit_day_of_month" binding:"required,gte=1,lte=28"`
+}
+
+type RecurringDepositDto struct {
+	ID                              string                       `json:"id"`
+	CreatedAt                       time.Time                    `json:"created_at"`
+	AccountID                       int64                        `json:"account_id"`
+	LinkedAccountID                 int64                        `json:"linked_account_id"`
+	InstallmentAmount               int64                        `json:"installment_amount"`
+	AnnualInterestRateInBasisPoints int64                        `json:"annual_interest_rate_in_basis_points"`
+	TenureInMonths                  int                          `json:"tenure_in_months"`
+	DebitDayOfMonth                 int                          `json:"debit_day_of_month"`
+	StartDate                       string                       `json:"start_date"`
+	MaturityDate                    string                       `json:"maturity_date"`
+	Status                          model.RecurringDepositStatus `json:"status"`
+	InterestPaid                    int64                        `json:"interest_paid"`
+	ClosedAt                        *time.Time                   `json:"closed_at"`
+}
+
+type RecurringDepositInstallmentDto struct {
+	ID                string                  `json:"id"`
+	InstallmentNumber int                     `json:"installment_number"`
+	DueDate           string                  `json:"due_date"`
+	Amount            int64                   `json:"amount"`
+	Status            model.InstallmentStatus `json:"status"`
+	AttemptCount      int                     `json:"attempt_count"`
+	LastAttemptedAt   *time.Time              `json:"last_attempted_at"`
+	PaidAt            *time.Time              `json:"paid_at"`
+	TransactionID     *string                 `json:"transaction_id"`
+}
+
+type BookRecurringDepositResponse struct {
+	Data RecurringDepositDto `json:"data"`
+}
+
+type GetRecurringDepositsResponse struct {
+	Data []RecurringDepositDto `json:"data"`
+}
+
+type GetRecurringDepositScheduleResponse struct {
+	Data []RecurringDepositInstallmentDto `json:"data"`
+}
+
+func TransformToRecurringDepositDto(recurringDeposit *model.RecurringDeposit) *RecurringDepositDto {
+	return &RecurringDepositDto{
+		ID:                              recurringDeposit.ID.String(),
+		CreatedAt:                       recurringDeposit.CreatedAt,
+		AccountID:                       recurringDeposit.AccountID,
+		LinkedAccountID:                 recurringDeposit.LinkedAccountID,
+		InstallmentAmount:               recurringDeposit.InstallmentAmount,
+		AnnualInterestRateInBasisPoints: recurringDeposit.AnnualInterestRateInBasisPoints,
+		TenureInMonths:                  recurringDeposit.TenureInMonths,
+		DebitDayOfMonth:                 recurringDeposit.DebitDayOfMonth,
+		StartDate:                       recurringDeposit.StartDate.Format(time.DateOnly),
+		MaturityDate:                    recurringDeposit.MaturityDate.Format(time.DateOnly),
+		Status:                          recurringDeposit.Status,
+		InterestPaid:                    recurringDeposit.InterestPaid,
+		ClosedAt:                        recurringDeposit.ClosedAt,
+	}
+}
+
+func TransformToRecurringDepositDtoList(recurringDeposits []model.RecurringDeposit) []RecurringDepositDto {
+	recurringDepositDtos := make([]RecurringDepositDto, 0, len(recurringDeposits))
+	for _, recurringDeposit := range recurringDeposits {
+		recurringDepositDtos = append(recurringDepositDtos, *TransformToRecurringDepositDto(&recurringDeposit))
+	}
+	return recurringDepositDtos
+}
+
+func TransformToRecurringDepositInstallmentDto(installment *model.RecurringDepositInstallment) *RecurringDepositInstallmentDto {
+	var transactionID *string
+	if installment.TransactionID != nil {
+		id := installment.TransactionID.String()
+		transactionID = &id
+	}
+
+	return &RecurringDepositInstallmentDto{
+		ID:                installment.ID.String(),
+		InstallmentNumber: installment.InstallmentNumber,
+		DueDate:           installment.DueDate.Format(time.DateOnly),
+		Amount:            installment.Amount,
+		Status:            installment.Status,
+		AttemptCount:      installment.AttemptCount,
+		LastAttemptedAt:   installment.LastAttemptedAt,
+		PaidAt:            installment.PaidAt,
+		TransactionID:     transactionID,
+	}
+}
+
+func TransformToRecurringDepositInstallmentDtoList(installments []model.RecurringDepositInstallment) []RecurringDepositInstallmentDto {
+	installmentDtos := make([]RecurringDepositInstallmentDto, 0, len(installments))
+	for _, installment := range installments {
+		installmentDtos = append(installmentDtos, *TransformToRecurringDepositInstallmentDto(&installment))
+	}
+	return installmentDtos
+}
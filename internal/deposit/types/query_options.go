@@ -0,0 +1,93 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/deposit/model"
+)
+
+type FixedDepositQueryOptions struct {
+	ID *uuid.UUID
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type FixedDepositListOptions struct {
+	UserID *uuid.UUID
+	Status *model.FixedDepositStatus
+
+	// When set, only deposits maturing on or before this date are returned
+	MaturityDateOnOrBefore *time.Time
+
+	// keyset pagination: only deposits with an ID greater than AfterID are returned, ordered by ID
+	AfterID *uuid.UUID
+	Limit   int
+}
+
+type FixedDepositUpdateOptions struct {
+	NewPrincipalAmount                 *int64
+	NewAnnualInterestRateInBasisPoints *int64
+	NewStartDate                       *time.Time
+	NewMaturityDate                    *time.Time
+	NewStatus                          *model.FixedDepositStatus
+	NewRenewalCount                    *int
+	NewInterestPaid                    *int64
+	NewClosedAt                        *time.Time
+}
+
+type RecurringDepositQueryOptions struct {
+	ID *uuid.UUID
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type RecurringDepositListOptions struct {
+	UserID *uuid.UUID
+	Status *model.RecurringDepositStatus
+
+	// When set, only deposits maturing on or before this date are returned
+	MaturityDateOnOrBefore *time.Time
+
+	// keyset pagination: only deposits with an ID greater than AfterID are returned, ordered by ID
+	AfterID *uuid.UUID
+	Limit   int
+}
+
+type RecurringDepositUpdateOptions struct {
+	NewStatus       *model.RecurringDepositStatus
+	NewInterestPaid *int64
+	NewClosedAt     *time.Time
+}
+
+type InstallmentQueryOptions struct {
+	ID *uuid.UUID
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type InstallmentListOptions struct {
+	RecurringDepositID *uuid.UUID
+	Status             *model.InstallmentStatus
+
+	// When set, only installments due on or before this date are returned
+	DueDateOnOrBefore *time.Time
+
+	// When true, the query will lock the selected rows for update
+	ForUpdate bool
+
+	// keyset pagination: only installments with an ID greater than AfterID are returned, ordered by ID
+	AfterID *uuid.UUID
+	Limit   int
+}
+
+type InstallmentUpdateOptions struct {
+	NewStatus          *model.InstallmentStatus
+	NewAttemptCount    *int
+	NewLastAttemptedAt *time.Time
+	NewPaidAt          *time.Time
+	NewTransactionID   *uuid.UUID
+}
@@ -0,0 +1,22 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/deposit/model"
+)
+
+type BookFixedDepositParams struct {
+	UserID              uuid.UUID
+	LinkedAccountID     int64
+	Amount              int64
+	TenureInMonths      int
+	MaturityInstruction model.MaturityInstruction
+}
+
+type BookRecurringDepositParams struct {
+	UserID            uuid.UUID
+	LinkedAccountID   int64
+	InstallmentAmount int64
+	TenureInMonths    int
+	DebitDayOfMonth   int
+}
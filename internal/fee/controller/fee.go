@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/middleware"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/fee/model"
+	feeService "github.com/skamranahmed/go-bank/internal/fee/service"
+	"github.com/skamranahmed/go-bank/internal/fee/types"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/uptrace/bun"
+)
+
+type feeController struct {
+	db         *bun.DB
+	feeService feeService.FeeService
+}
+
+func newFeeController(dependency Dependency) FeeController {
+	return &feeController{
+		db:         dependency.Db,
+		feeService: dependency.FeeService,
+	}
+}
+
+func (c *feeController) WaiveFee(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	// extract admin user ID from the request context
+	userID, ok := requestCtx.Value(middleware.ContextUserIDKey).(string)
+	if !ok || userID == "" {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusUnauthorized,
+			Message:        "User not authenticated",
+		})
+		return
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid user ID",
+		})
+		return
+	}
+
+	// extract fee charge ID from URL parameter
+	feeChargeID, err := uuid.Parse(ginCtx.Param("fee_charge_id"))
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid fee charge ID",
+		})
+		return
+	}
+
+	var payload types.WaiveFeeRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	var feeCharge *model.FeeCharge
+	err = database.RunInTransaction(requestCtx, "waiveFee", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		feeCharge, err = c.feeService.WaiveFee(txCtx, tx, feeChargeID, userUUID, payload.Data.Reason)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	feeChargeDto := types.TransformToFeeChargeDto(feeCharge)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.WaiveFeeResponse{
+		Data: *feeChargeDto,
+	})
+}
@@ -0,0 +1,7 @@
+package controller
+
+import "github.com/gin-gonic/gin"
+
+type FeeController interface {
+	WaiveFee(ginCtx *gin.Context)
+}
@@ -0,0 +1,24 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skamranahmed/go-bank/cmd/middleware"
+	authenticationService "github.com/skamranahmed/go-bank/internal/authentication/service"
+	feeService "github.com/skamranahmed/go-bank/internal/fee/service"
+	userService "github.com/skamranahmed/go-bank/internal/user/service"
+	"github.com/uptrace/bun"
+)
+
+type Dependency struct {
+	Db                    *bun.DB
+	AuthenticationService authenticationService.AuthenticationService
+	FeeService            feeService.FeeService
+	UserService           userService.UserService
+}
+
+func Register(router *gin.Engine, dependency Dependency) {
+	feeController := newFeeController(dependency)
+
+	// admin routes
+	router.POST("/v1/admin/fees/:fee_charge_id/waive", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.AdminMiddleware(dependency.UserService), feeController.WaiveFee)
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/uptrace/bun"
+)
+
+// FeeCharge records every occurrence of a chargeable event, including the ones covered by the free quota
+type FeeCharge struct {
+	bun.BaseModel `bun:"table:fee_charges"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "accounts" table
+	AccountID int64                 `bun:"account_id,notnull"`
+	Account   *accountModel.Account `bun:"rel:belongs-to,join:account_id=id"`
+
+	// foreign key to "fee_rules" table
+	FeeRuleID uuid.UUID `bun:"fee_rule_id,notnull,type:uuid"`
+	FeeRule   *FeeRule  `bun:"rel:belongs-to,join:fee_rule_id=id"`
+
+	Event Event `bun:"event,notnull"`
+
+	// Reference identifies the operation that triggered the fee (eg: the debit transaction ID of a transfer)
+	Reference string `bun:"reference,notnull,type:varchar(100)"`
+
+	// BaseAmount is the amount of the triggering operation, stored in the smallest currency unit (paise for INR)
+	BaseAmount int64 `bun:"base_amount,notnull,default:0"`
+
+	// FeeAmount and TaxAmount are stored in the smallest currency unit (paise for INR)
+	// The account is debited FeeAmount + TaxAmount
+	FeeAmount int64 `bun:"fee_amount,notnull"`
+	TaxAmount int64 `bun:"tax_amount,notnull"`
+
+	Status FeeChargeStatus `bun:"status,notnull"`
+
+	// foreign key to "transactions" table, the FEE transaction. It is nil for FREE charges
+	TransactionID *uuid.UUID `bun:"transaction_id,type:uuid"`
+
+	// waiver details, set only when Status is WAIVED
+	WaivedBy            *uuid.UUID      `bun:"waived_by,type:uuid"`
+	WaivedByUser        *userModel.User `bun:"rel:belongs-to,join:waived_by=id"`
+	WaivedAt            *time.Time      `bun:"waived_at"`
+	WaiverReason        *string         `bun:"waiver_reason"`
+	WaiverTransactionID *uuid.UUID      `bun:"waiver_transaction_id,type:uuid"`
+}
+
+type FeeChargeStatus string
+
+const (
+	Free    FeeChargeStatus = "FREE"    // covered by the free quota, nothing was debited
+	Charged FeeChargeStatus = "CHARGED" // debited from the account
+	Waived  FeeChargeStatus = "WAIVED"  // debited from the account and later refunded by an admin
+)
+
+// TotalAmount returns the amount debited from the account for this charge
+func (c *FeeCharge) TotalAmount() int64 {
+	return c.FeeAmount + c.TaxAmount
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/uptrace/bun"
+)
+
+// FeeRule is a row of the fee schedule, it defines how much is charged to an account type when an event occurs
+type FeeRule struct {
+	bun.BaseModel `bun:"table:fee_rules"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	AccountType     accountModel.AccountType `bun:"account_type,notnull"`
+	Event           Event                    `bun:"event,notnull"`
+	CalculationType CalculationType          `bun:"calculation_type,notnull"`
+
+	// FlatAmount is the fee for FLAT rules, stored in the smallest currency unit (paise for INR)
+	FlatAmount int64 `bun:"flat_amount,notnull,default:0"`
+
+	// PercentageInBasisPoints is the fee for PERCENTAGE rules as a share of the amount of the triggering operation (1 bps = 0.01%)
+	PercentageInBasisPoints int64 `bun:"percentage_in_basis_points,notnull,default:0"`
+
+	// MinAmount and MaxAmount cap the fee before tax, stored in the smallest currency unit (paise for INR)
+	// A nil value means the fee is not capped on that side
+	MinAmount *int64 `bun:"min_amount"`
+	MaxAmount *int64 `bun:"max_amount"`
+
+	// TaxRateInBasisPoints is the tax levied on top of the fee (1 bps = 0.01%)
+	TaxRateInBasisPoints int64 `bun:"tax_rate_in_basis_points,notnull,default:0"`
+
+	// FreeQuotaPerMonth is the number of occurrences of the event per calendar month that are not charged
+	FreeQuotaPerMonth int `bun:"free_quota_per_month,notnull,default:0"`
+
+	IsActive bool `bun:"is_active,notnull,default:true"`
+}
+
+type Event string
+
+const (
+	InternalTransfer Event = "INTERNAL_TRANSFER"
+	StatementRequest Event = "STATEMENT_REQUEST"
+
+	MinimumBalanceBreach Event = "MINIMUM_BALANCE_BREACH" // monthly average balance of the account fell short of the requirement
+
+	RecurringDepositMissedInstallment Event = "RECURRING_DEPOSIT_MISSED_INSTALLMENT" // charged at maturity for every installment that was not paid within the grace period
+
+	LoanLatePayment Event = "LOAN_LATE_PAYMENT" // EMI of a loan still unpaid after the grace period
+)
+
+type CalculationType string
+
+const (
+	Flat       CalculationType = "FLAT"
+	Percentage CalculationType = "PERCENTAGE"
+)
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/fee/model"
+	"github.com/skamranahmed/go-bank/internal/fee/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+type feeRepository struct {
+	db *bun.DB
+}
+
+func NewFeeRepository(db *bun.DB) FeeRepository {
+	return &feeRepository{
+		db: db,
+	}
+}
+
+// GetActiveFeeRule returns nil without an error when no active rule exists, as not every event is chargeable for every account type
+func (r *feeRepository) GetActiveFeeRule(requestCtx context.Context, dbExecutor bun.IDB, accountType accountModel.AccountType, event model.Event) (*model.FeeRule, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var feeRule model.FeeRule
+	err := dbExecutor.NewSelect().
+		Model(&feeRule).
+		Where("account_type = ?", accountType).
+		Where("event = ?", event).
+		Where("is_active = TRUE").
+		Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		logger.Error(requestCtx, "Error while finding fee rule for accountType: %s and event: %s, error: %+v", accountType, event, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't process your transaction at the moment. Please try again later.",
+		}
+	}
+
+	return &feeRule, nil
+}
+
+func (r *feeRepository) CreateFeeCharge(requestCtx context.Context, dbExecutor bun.IDB, feeCharge *model.FeeCharge) (*model.FeeCharge, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	err := dbExecutor.NewInsert().
+		Model(feeCharge).
+		Returning("*").
+		Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating fee charge for accountID: %d, error: %+v", feeCharge.AccountID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't process your transaction at the moment. Please try again later.",
+		}
+	}
+
+	return feeCharge, nil
+}
+
+func (r *feeRepository) GetFeeCharge(requestCtx context.Context, dbExecutor bun.IDB, options types.FeeChargeQueryOptions) (*model.FeeCharge, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var feeCharge model.FeeCharge
+	query := dbExecutor.NewSelect().Model(&feeCharge)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Fee charge not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding fee charge with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the fee charge at the moment. Please try again later.",
+		}
+	}
+
+	return &feeCharge, nil
+}
+
+func (r *feeRepository) CountFeeCharges(requestCtx context.Context, dbExecutor bun.IDB, options types.FeeChargeCountOptions) (int, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	query := dbExecutor.NewSelect().Model((*model.FeeCharge)(nil))
+
+	// dynamically construct the query based on which fields are set
+	if options.AccountID != nil {
+		query = query.Where("account_id = ?", *options.AccountID)
+	}
+	if options.Event != nil {
+		query = query.Where("event = ?", *options.Event)
+	}
+	if options.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *options.CreatedAfter)
+	}
+
+	count, err := query.Count(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while counting fee charges with options: %+v, error: %+v", options, err)
+		return 0, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't process your transaction at the moment. Please try again later.",
+		}
+	}
+
+	return count, nil
+}
+
+func (r *feeRepository) UpdateFeeCharge(requestCtx context.Context, dbExecutor bun.IDB, feeChargeID uuid.UUID, options types.FeeChargeUpdateOptions) (*model.FeeCharge, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var feeCharge model.FeeCharge
+	query := dbExecutor.NewUpdate().Model(&feeCharge)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewStatus != nil {
+		query = query.Set("status = ?", *options.NewStatus)
+	}
+	if options.WaivedBy != nil {
+		query = query.Set("waived_by = ?", *options.WaivedBy)
+	}
+	if options.WaivedAt != nil {
+		query = query.Set("waived_at = ?", *options.WaivedAt)
+	}
+	if options.WaiverReason != nil {
+		query = query.Set("waiver_reason = ?", *options.WaiverReason)
+	}
+	if options.WaiverTransactionID != nil {
+		query = query.Set("waiver_transaction_id = ?", *options.WaiverTransactionID)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", feeChargeID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating fee charge with ID: %s, error: %+v", feeChargeID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the fee charge at the moment. Please try again later.",
+		}
+	}
+
+	return &feeCharge, nil
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/fee/model"
+	"github.com/skamranahmed/go-bank/internal/fee/types"
+	"github.com/uptrace/bun"
+)
+
+type FeeRepository interface {
+	GetActiveFeeRule(requestCtx context.Context, dbExecutor bun.IDB, accountType accountModel.AccountType, event model.Event) (*model.FeeRule, error)
+	CreateFeeCharge(requestCtx context.Context, dbExecutor bun.IDB, feeCharge *model.FeeCharge) (*model.FeeCharge, error)
+	GetFeeCharge(requestCtx context.Context, dbExecutor bun.IDB, options types.FeeChargeQueryOptions) (*model.FeeCharge, error)
+	CountFeeCharges(requestCtx context.Context, dbExecutor bun.IDB, options types.FeeChargeCountOptions) (int, error)
+	UpdateFeeCharge(requestCtx context.Context, dbExecutor bun.IDB, feeChargeID uuid.UUID, options types.FeeChargeUpdateOptions) (*model.FeeCharge, error)
+}
@@ -0,0 +1,332 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/config"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	accountService "github.com/skamranahmed/go-bank/internal/account/service"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/fee/model"
+	"github.com/skamranahmed/go-bank/internal/fee/repository"
+	"github.com/skamranahmed/go-bank/internal/fee/types"
+	ledgerModel "github.com/skamranahmed/go-bank/internal/ledger/model"
+	ledgerService "github.com/skamranahmed/go-bank/internal/ledger/service"
+	ledgerTypes "github.com/skamranahmed/go-bank/internal/ledger/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+type feeService struct {
+	db             *bun.DB
+	feeRepository  repository.FeeRepository
+	accountService accountService.AccountService
+	ledgerService  ledgerService.LedgerService
+}
+
+func NewFeeService(db *bun.DB, feeRepository repository.FeeRepository, accountService accountService.AccountService, ledgerService ledgerService.LedgerService) FeeService {
+	return &feeService{
+		db:             db,
+		feeRepository:  feeRepository,
+		accountService: accountService,
+		ledgerService:  ledgerService,
+	}
+}
+
+/*
+ChargeFee applies the fee schedule to an event that occurred on the account
+
+It must be called within the same database transaction as the operation that triggered the event,
+so that the fee is rolled back together with the operation. It locks the account row for update.
+
+It returns a nil fee charge when the account type has no active rule for the event, or the account is held in a foreign currency.
+Occurrences covered by the free monthly quota are recorded with the FREE status and nothing is debited.
+*/
+func (s *feeService) ChargeFee(requestCtx context.Context, dbExecutor bun.IDB, params types.ChargeFeeParams) (*model.FeeCharge, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	account, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &params.AccountID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the fee schedule is priced in the home currency of the bank, accounts held in another currency are not charged from it
+	if string(account.Currency) != config.GetBankConfig().Currency {
+		return nil, nil
+	}
+
+	feeRule, err := s.feeRepository.GetActiveFeeRule(requestCtx, dbExecutor, account.Type, params.Event)
+	if err != nil {
+		return nil, err
+	}
+	if feeRule == nil {
+		return nil, nil
+	}
+
+	feeCharge := &model.FeeCharge{
+		ID:         uuid.New(),
+		AccountID:  account.ID,
+		FeeRuleID:  feeRule.ID,
+		Event:      params.Event,
+		Reference:  params.Reference,
+		BaseAmount: params.BaseAmount,
+		Status:     model.Free,
+	}
+
+	now := time.Now().UTC()
+	startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	occurrencesThisMonth, err := s.feeRepository.CountFeeCharges(requestCtx, dbExecutor, types.FeeChargeCountOptions{
+		AccountID:    &account.ID,
+		Event:        &params.Event,
+		CreatedAfter: &startOfMonth,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if occurrencesThisMonth < feeRule.FreeQuotaPerMonth {
+		return s.feeRepository.CreateFeeCharge(requestCtx, dbExecutor, feeCharge)
+	}
+
+	feeCharge.FeeAmount, feeCharge.TaxAmount = calculateFee(feeRule, params.BaseAmount)
+
+	if feeCharge.TotalAmount() > account.AvailableBalance() {
+		if !params.CapAtAvailableBalance {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusBadRequest,
+				Message:        fmt.Sprintf("You do not have sufficient balance in your account to pay the fee of %d", feeCharge.TotalAmount()),
+			}
+		}
+
+		logger.Warn(requestCtx, "Fee of %d for accountID: %d and event: %s exceeds the available balance of %d, charging only the available balance", feeCharge.TotalAmount(), account.ID, params.Event, account.AvailableBalance())
+		feeCharge.FeeAmount, feeCharge.TaxAmount = splitTotalIntoFeeAndTax(max(account.AvailableBalance(), 0), feeRule.TaxRateInBasisPoints)
+	}
+
+	// a fee that rounds down to zero is not debited, but the occurrence still counts towards the monthly quota
+	if feeCharge.TotalAmount() <= 0 {
+		feeCharge.FeeAmount, feeCharge.TaxAmount = 0, 0
+		return s.feeRepository.CreateFeeCharge(requestCtx, dbExecutor, feeCharge)
+	}
+
+	newBalance := account.Balance - feeCharge.TotalAmount()
+	account, err = s.accountService.UpdateAccount(requestCtx, dbExecutor, account.ID, accountTypes.AccountUpdateOptions{
+		NewBalance: &newBalance,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	feeTransaction, err := s.accountService.CreateTransactionRecord(requestCtx, dbExecutor, &accountModel.Transaction{
+		AccountID:    account.ID,
+		Amount:       feeCharge.TotalAmount(),
+		BalanceAfter: account.Balance,
+		Type:         accountModel.Fee,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the other side of the debit: the fee is the bank's revenue while the tax is owed to the government
+	err = s.postToLedger(requestCtx, dbExecutor, feeCharge, ledgerModel.Credit)
+	if err != nil {
+		return nil, err
+	}
+
+	feeCharge.Status = model.Charged
+	feeCharge.TransactionID = &feeTransaction.ID
+	return s.feeRepository.CreateFeeCharge(requestCtx, dbExecutor, feeCharge)
+}
+
+/*
+EstimateFee returns the total fee (including its tax) that charging every occurrence of BaseAmounts would cost the account
+
+It follows the fee schedule the same way as ChargeFee, the occurrences still covered by the free monthly quota cost nothing.
+Nothing is charged or recorded, so the actual fee can differ if other events are charged to the account in the meantime.
+*/
+func (s *feeService) EstimateFee(requestCtx context.Context, dbExecutor bun.IDB, params types.EstimateFeeParams) (int64, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	account, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &params.AccountID,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// accounts held in another currency than the home currency of the bank are not charged, see ChargeFee
+	if string(account.Currency) != config.GetBankConfig().Currency {
+		return 0, nil
+	}
+
+	feeRule, err := s.feeRepository.GetActiveFeeRule(requestCtx, dbExecutor, account.Type, params.Event)
+	if err != nil {
+		return 0, err
+	}
+	if feeRule == nil {
+		return 0, nil
+	}
+
+	now := time.Now().UTC()
+	startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	occurrencesThisMonth, err := s.feeRepository.CountFeeCharges(requestCtx, dbExecutor, types.FeeChargeCountOptions{
+		AccountID:    &account.ID,
+		Event:        &params.Event,
+		CreatedAfter: &startOfMonth,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var totalFee int64
+	for _, baseAmount := range params.BaseAmounts {
+		if occurrencesThisMonth < feeRule.FreeQuotaPerMonth {
+			occurrencesThisMonth++
+			continue
+		}
+
+		feeAmount, taxAmount := calculateFee(feeRule, baseAmount)
+		totalFee += feeAmount + taxAmount
+	}
+
+	return totalFee, nil
+}
+
+/*
+WaiveFee refunds a charged fee (including its tax) back to the account
+
+It must be called within a database transaction because it locks both the fee charge and the account rows for update
+*/
+func (s *feeService) WaiveFee(requestCtx context.Context, dbExecutor bun.IDB, feeChargeID uuid.UUID, waivedBy uuid.UUID, reason string) (*model.FeeCharge, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	feeCharge, err := s.feeRepository.GetFeeCharge(requestCtx, dbExecutor, types.FeeChargeQueryOptions{
+		ID:        &feeChargeID,
+		ForUpdate: true, // lock the row so that the same fee cannot be waived twice concurrently
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch feeCharge.Status {
+	case model.Waived:
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusConflict,
+			Message:        "Fee has already been waived",
+		}
+	case model.Free:
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Fee was not charged, there is nothing to waive",
+		}
+	}
+
+	account, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &feeCharge.AccountID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	newBalance := account.Balance + feeCharge.TotalAmount()
+	account, err = s.accountService.UpdateAccount(requestCtx, dbExecutor, account.ID, accountTypes.AccountUpdateOptions{
+		NewBalance: &newBalance,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	waiverTransaction, err := s.accountService.CreateTransactionRecord(requestCtx, dbExecutor, &accountModel.Transaction{
+		AccountID:    account.ID,
+		Amount:       feeCharge.TotalAmount(),
+		BalanceAfter: account.Balance,
+		Type:         accountModel.FeeWaiver,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.postToLedger(requestCtx, dbExecutor, feeCharge, ledgerModel.Debit)
+	if err != nil {
+		return nil, err
+	}
+
+	waivedStatus := model.Waived
+	waivedAt := time.Now().UTC()
+	return s.feeRepository.UpdateFeeCharge(requestCtx, dbExecutor, feeCharge.ID, types.FeeChargeUpdateOptions{
+		NewStatus:           &waivedStatus,
+		WaivedBy:            &waivedBy,
+		WaivedAt:            &waivedAt,
+		WaiverReason:        &reason,
+		WaiverTransactionID: &waiverTransaction.ID,
+	})
+}
+
+// postToLedger posts the fee to the fee revenue account and the tax to the tax payable account
+func (s *feeService) postToLedger(requestCtx context.Context, dbExecutor bun.IDB, feeCharge *model.FeeCharge, entryType ledgerModel.EntryType) error {
+	postings := []struct {
+		code   ledgerModel.InternalAccountCode
+		amount int64
+	}{
+		{code: ledgerModel.FeeRevenue, amount: feeCharge.FeeAmount},
+		{code: ledgerModel.TaxPayable, amount: feeCharge.TaxAmount},
+	}
+
+	for _, posting := range postings {
+		if posting.amount == 0 {
+			continue
+		}
+
+		_, err := s.ledgerService.PostEntry(requestCtx, dbExecutor, ledgerTypes.PostEntryParams{
+			InternalAccountCode: posting.code,
+			Type:                entryType,
+			Amount:              posting.amount,
+			Reference:           feeCharge.ID.String(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// calculateFee returns the fee (after applying the min/max caps) and the tax on it, both rounded to the nearest smallest currency unit
+func calculateFee(feeRule *model.FeeRule, baseAmount int64) (int64, int64) {
+	var feeAmount int64
+	switch feeRule.CalculationType {
+	case model.Flat:
+		feeAmount = feeRule.FlatAmount
+	case model.Percentage:
+		feeAmount = (baseAmount*feeRule.PercentageInBasisPoints + 5000) / 10000
+	}
+
+	if feeRule.MinAmount != nil && feeAmount < *feeRule.MinAmount {
+		feeAmount = *feeRule.MinAmount
+	}
+	if feeRule.MaxAmount != nil && feeAmount > *feeRule.MaxAmount {
+		feeAmount = *feeRule.MaxAmount
+	}
+
+	taxAmount := (feeAmount*feeRule.TaxRateInBasisPoints + 5000) / 10000
+	return feeAmount, taxAmount
+}
+
+// splitTotalIntoFeeAndTax is the inverse of calculateFee, it splits a tax-inclusive total into the fee and the tax on it
+func splitTotalIntoFeeAndTax(totalAmount int64, taxRateInBasisPoints int64) (int64, int64) {
+	feeAmount := totalAmount * 10000 / (10000 + taxRateInBasisPoints)
+	return feeAmount, totalAmount - feeAmount
+}
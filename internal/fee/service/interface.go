@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/fee/model"
+	"github.com/skamranahmed/go-bank/internal/fee/types"
+	"github.com/uptrace/bun"
+)
+
+type FeeService interface {
+	ChargeFee(requestCtx context.Context, dbExecutor bun.IDB, params types.ChargeFeeParams) (*model.FeeCharge, error)
+	EstimateFee(requestCtx context.Context, dbExecutor bun.IDB, params types.EstimateFeeParams) (int64, error)
+	WaiveFee(requestCtx context.Context, dbExecutor bun.IDB, feeChargeID uuid.UUID, waivedBy uuid.UUID, reason string) (*model.FeeCharge, error)
+}
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"time"
+
+	"github.com/skamranahmed/go-bank/internal/fee/model"
+)
+
+type FeeChargeDto struct {
+	ID           string                `json:"id"`
+	CreatedAt    time.Time             `json:"created_at"`
+	AccountID    int64                 `json:"account_id"`
+	Event        model.Event           `json:"event"`
+	Reference    string                `json:"reference"`
+	FeeAmount    int64                 `json:"fee_amount"`
+	TaxAmount    int64                 `json:"tax_amount"`
+	TotalAmount  int64                 `json:"total_amount"`
+	Status       model.FeeChargeStatus `json:"status"`
+	WaivedAt     *time.Time            `json:"waived_at"`
+	WaiverReason *string               `json:"waiver_reason"`
+}
+
+type WaiveFeeRequest struct {
+	Data WaiveFeeRequestData `json:"data" binding:"required"`
+}
+
+type WaiveFeeRequestData struct {
+	Reason string `json:"reason" binding:"required,min=1"`
+}
+
+type WaiveFeeResponse struct {
+	Data FeeChargeDto `json:"data"`
+}
+
+func TransformToFeeChargeDto(feeCharge *model.FeeCharge) *FeeChargeDto {
+	return &FeeChargeDto{
+		ID:           feeCharge.ID.String(),
+		CreatedAt:    feeCharge.CreatedAt,
+		AccountID:    feeCharge.AccountID,
+		Event:        feeCharge.Event,
+		Reference:    feeCharge.Reference,
+		FeeAmount:    feeCharge.FeeAmount,
+		TaxAmount:    feeCharge.TaxAmount,
+		TotalAmount:  feeCharge.TotalAmount(),
+		Status:       feeCharge.Status,
+		WaivedAt:     feeCharge.WaivedAt,
+		WaiverReason: feeCharge.WaiverReason,
+	}
+}
@@ -0,0 +1,29 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/fee/model"
+)
+
+type FeeChargeQueryOptions struct {
+	ID *uuid.UUID
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type FeeChargeCountOptions struct {
+	AccountID    *int64
+	Event        *model.Event
+	CreatedAfter *time.Time
+}
+
+type FeeChargeUpdateOptions struct {
+	NewStatus           *model.FeeChargeStatus
+	WaivedBy            *uuid.UUID
+	WaivedAt            *time.Time
+	WaiverReason        *string
+	WaiverTransactionID *uuid.UUID
+}
@@ -0,0 +1,25 @@
+package types
+
+import "github.com/skamranahmed/go-bank/internal/fee/model"
+
+type ChargeFeeParams struct {
+	AccountID  int64
+	Event      model.Event
+	BaseAmount int64
+	Reference  string
+
+	/*
+		When true, a fee that exceeds the available balance of the account is reduced to the available balance
+		instead of failing the operation. This is meant for fees that are charged by the bank on its own,
+		eg: penalties, where there is no customer operation that could be rejected
+	*/
+	CapAtAvailableBalance bool
+}
+
+type EstimateFeeParams struct {
+	AccountID int64
+	Event     model.Event
+
+	// BaseAmounts are the amounts of the upcoming occurrences of the event, in the order they will be charged in
+	BaseAmounts []int64
+}
@@ -0,0 +1,213 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/middleware"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/fx/model"
+	fxService "github.com/skamranahmed/go-bank/internal/fx/service"
+	"github.com/skamranahmed/go-bank/internal/fx/types"
+	"github.com/skamranahmed/go-bank/pkg/fxratefile"
+	"github.com/skamranahmed/go-bank/pkg/money"
+	"github.com/uptrace/bun"
+)
+
+type fxController struct {
+	db        *bun.DB
+	fxService fxService.FXService
+}
+
+func newFXController(dependency Dependency) FXController {
+	return &fxController{
+		db:        dependency.Db,
+		fxService: dependency.FXService,
+	}
+}
+
+func (c *fxController) GetFXRates(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	fxRates, err := c.fxService.ListLatestFXRates(requestCtx, nil)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	fxRateDtos := types.TransformToFXRateDtoList(fxRates)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetFXRatesResponse{
+		Data: fxRateDtos,
+	})
+}
+
+func (c *fxController) CreateFXQuote(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.CreateFXQuoteRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	fxQuote, err := c.fxService.CreateFXQuote(requestCtx, nil, types.CreateFXQuoteParams{
+		UserID:        userUUID,
+		FromAccountID: payload.Data.FromAccountID,
+		ToAccountID:   payload.Data.ToAccountID,
+		Amount:        *payload.Data.Amount,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	fxQuoteDto := types.TransformToFXQuoteDto(fxQuote)
+	server.SendSuccessResponse(ginCtx, http.StatusCreated, types.FXQuoteResponse{
+		Data: *fxQuoteDto,
+	})
+}
+
+func (c *fxController) GetFXQuote(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	fxQuoteID, err := uuid.Parse(ginCtx.Param("fx_quote_id"))
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid FX quote ID",
+		})
+		return
+	}
+
+	fxQuote, err := c.fxService.GetFXQuote(requestCtx, nil, types.FXQuoteQueryOptions{
+		ID: &fxQuoteID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// authorization check: verify the quote was given to the authenticated user
+	if fxQuote.UserID != userUUID {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You do not have permission to access this FX quote",
+		})
+		return
+	}
+
+	// transform to DTO and return response
+	fxQuoteDto := types.TransformToFXQuoteDto(fxQuote)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.FXQuoteResponse{
+		Data: *fxQuoteDto,
+	})
+}
+
+func (c *fxController) PublishFXRate(ginCtx *gin.Context) {
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.PublishFXRateRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	// the currencies have already been validated by the binding
+	rate, err := money.ParseRate(money.Currency(payload.Data.BaseCurrency), money.Currency(payload.Data.QuoteCurrency), payload.Data.Rate)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        err.Error(),
+		})
+		return
+	}
+
+	c.publishFXRates(ginCtx, types.PublishFXRatesParams{
+		Rates:             []money.Rate{rate},
+		Source:            model.FXRateSourceAdmin,
+		PublishedByUserID: userUUID,
+	})
+}
+
+func (c *fxController) ImportFXRates(ginCtx *gin.Context) {
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.ImportFXRatesRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	rates, err := fxratefile.Parse([]byte(payload.Data.Content))
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("The FX rate file could not be read: %s", err.Error()),
+		})
+		return
+	}
+
+	c.publishFXRates(ginCtx, types.PublishFXRatesParams{
+		Rates:             rates,
+		Source:            model.FXRateSourceFile,
+		PublishedByUserID: userUUID,
+	})
+}
+
+// publishFXRates publishes the rates and sends them back as the response
+func (c *fxController) publishFXRates(ginCtx *gin.Context, params types.PublishFXRatesParams) {
+	fxRates, err := c.fxService.PublishFXRates(ginCtx.Request.Context(), nil, params)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	fxRateDtos := types.TransformToFXRateDtoList(fxRates)
+	server.SendSuccessResponse(ginCtx, http.StatusCreated, types.PublishFXRatesResponse{
+		Data: fxRateDtos,
+	})
+}
+
+// getAuthenticatedUserID extracts the ID of the authenticated user from the request context, sending the error response when it is missing
+func getAuthenticatedUserID(ginCtx *gin.Context) (uuid.UUID, bool) {
+	userID, ok := ginCtx.Request.Context().Value(middleware.ContextUserIDKey).(string)
+	if !ok || userID == "" {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusUnauthorized,
+			Message:        "User not authenticated",
+		})
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid user ID",
+		})
+		return uuid.Nil, false
+	}
+
+	return userUUID, true
+}
@@ -0,0 +1,12 @@
+package controller
+
+import "github.com/gin-gonic/gin"
+
+type FXController interface {
+	GetFXRates(ginCtx *gin.Context)
+	CreateFXQuote(ginCtx *gin.Context)
+	GetFXQuote(ginCtx *gin.Context)
+
+	PublishFXRate(ginCtx *gin.Context)
+	ImportFXRates(ginCtx *gin.Context)
+}
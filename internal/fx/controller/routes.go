@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skamranahmed/go-bank/cmd/middleware"
+	authenticationService "github.com/skamranahmed/go-bank/internal/authentication/service"
+	fxService "github.com/skamranahmed/go-bank/internal/fx/service"
+	userService "github.com/skamranahmed/go-bank/internal/user/service"
+	"github.com/uptrace/bun"
+)
+
+type Dependency struct {
+	Db                    *bun.DB
+	AuthenticationService authenticationService.AuthenticationService
+	FXService             fxService.FXService
+	UserService           userService.UserService
+}
+
+func Register(router *gin.Engine, dependency Dependency) {
+	fxController := newFXController(dependency)
+	router.GET("/v1/fx-rates", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), fxController.GetFXRates)
+	router.POST("/v1/fx/quotes", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), fxController.CreateFXQuote)
+	router.GET("/v1/fx/quotes/:fx_quote_id", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), fxController.GetFXQuote)
+
+	// admin routes
+	router.POST("/v1/admin/fx-rates", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.AdminMiddleware(dependency.UserService), fxController.PublishFXRate)
+	router.POST("/v1/admin/fx-rates/import", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.AdminMiddleware(dependency.UserService), fxController.ImportFXRates)
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/skamranahmed/go-bank/pkg/money"
+	"github.com/uptrace/bun"
+)
+
+/*
+FXQuote fixes the amounts of a transfer between two accounts held in different currencies, until it expires
+
+The customer asks for a quote before making the transfer, so that the amount credited to the other account
+is known upfront and does not move with the rates while the transfer is being confirmed. A quote can only be used once.
+*/
+type FXQuote struct {
+	bun.BaseModel `bun:"table:fx_quotes"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "users" table, the user the quote was given to
+	UserID uuid.UUID       `bun:"user_id,notnull,type:uuid"`
+	User   *userModel.User `bun:"rel:belongs-to,join:user_id=id"`
+
+	// foreign keys to "accounts" table, the accounts of the transfer the quote was given for
+	FromAccountID int64                 `bun:"from_account_id,notnull"`
+	FromAccount   *accountModel.Account `bun:"rel:belongs-to,join:from_account_id=id"`
+	ToAccountID   int64                 `bun:"to_account_id,notnull"`
+	ToAccount     *accountModel.Account `bun:"rel:belongs-to,join:to_account_id=id"`
+
+	// SourceAmount is debited from the from account in its currency, TargetAmount is credited to the to account in its currency
+	// Both are stored in the smallest unit of their currency (paise for INR, cents for USD)
+	SourceCurrency money.Currency `bun:"source_currency,notnull,type:varchar(3)"`
+	SourceAmount   int64          `bun:"source_amount,notnull"`
+	TargetCurrency money.Currency `bun:"target_currency,notnull,type:varchar(3)"`
+	TargetAmount   int64          `bun:"target_amount,notnull"`
+
+	// foreign key to "fx_rates" table, the published rate the quote was priced from
+	FXRateID uuid.UUID `bun:"fx_rate_id,notnull,type:uuid"`
+	FXRate   *FXRate   `bun:"rel:belongs-to,join:fx_rate_id=id"`
+
+	// Rate is the price of one unit of the source currency in the target currency the customer is given,
+	// the margin of the bank already taken off, scaled by money.RateScale
+	Rate                int64 `bun:"rate,notnull"`
+	MarginInBasisPoints int64 `bun:"margin_in_basis_points,notnull,default:0"`
+
+	Status FXQuoteStatus `bun:"status,notnull,default:'OPEN'"`
+
+	// ExpiresAt is the time the quote can no longer be used at
+	ExpiresAt time.Time `bun:"expires_at,notnull"`
+
+	// foreign key to "transactions" table, the debit transaction of the from account once the quote is used
+	TransactionID *uuid.UUID                `bun:"transaction_id,type:uuid"`
+	Transaction   *accountModel.Transaction `bun:"rel:belongs-to,join:transaction_id=id"`
+
+	UsedAt *time.Time `bun:"used_at"`
+}
+
+// IsExpired reports whether the quote can no longer be used
+func (q *FXQuote) IsExpired(at time.Time) bool {
+	return !at.Before(q.ExpiresAt)
+}
+
+// SourceMoney returns the amount debited from the from account
+func (q *FXQuote) SourceMoney() money.Money {
+	return money.New(q.SourceAmount, q.SourceCurrency)
+}
+
+// TargetMoney returns the amount credited to the to account
+func (q *FXQuote) TargetMoney() money.Money {
+	return money.New(q.TargetAmount, q.TargetCurrency)
+}
+
+type FXQuoteStatus string
+
+const (
+	FXQuoteOpen FXQuoteStatus = "OPEN" // can be used until it expires
+	FXQuoteUsed FXQuoteStatus = "USED" // the transfer was made at the quoted amounts
+)
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/skamranahmed/go-bank/pkg/money"
+	"github.com/uptrace/bun"
+)
+
+/*
+FXRate is the exchange rate of a currency pair as published by an admin, either one at a time or as a file
+
+Rates are never updated, publishing a new rate for a pair adds a row, so that the rate every quote was priced from is kept.
+The latest rate of a pair is the one quoted, the rate of the opposite pair is derived from it when only one of them is published.
+*/
+type FXRate struct {
+	bun.BaseModel `bun:"table:fx_rates"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+
+	BaseCurrency  money.Currency `bun:"base_currency,notnull,type:varchar(3)"`
+	QuoteCurrency money.Currency `bun:"quote_currency,notnull,type:varchar(3)"`
+
+	// Rate is the price of one unit of the base currency in the quote currency, scaled by money.RateScale
+	Rate int64 `bun:"rate,notnull"`
+
+	// Source of the rate: ADMIN, FILE
+	Source FXRateSource `bun:"source,notnull"`
+
+	// foreign key to "users" table, the admin who published the rate
+	PublishedByUserID uuid.UUID       `bun:"published_by_user_id,notnull,type:uuid"`
+	PublishedByUser   *userModel.User `bun:"rel:belongs-to,join:published_by_user_id=id"`
+}
+
+// MoneyRate returns the rate to convert amounts of the base currency to the quote currency with
+func (r *FXRate) MoneyRate() money.Rate {
+	return money.Rate{
+		Base:  r.BaseCurrency,
+		Quote: r.QuoteCurrency,
+		Value: r.Rate,
+	}
+}
+
+type FXRateSource string
+
+const (
+	FXRateSourceAdmin FXRateSource = "ADMIN" // published on its own by an admin
+	FXRateSourceFile  FXRateSource = "FILE"  // imported from the rate file of the treasury, see the "fxratefile" package
+)
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/fx/model"
+	"github.com/skamranahmed/go-bank/internal/fx/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+type fxRepository struct {
+	db *bun.DB
+}
+
+func NewFXRepository(db *bun.DB) FXRepository {
+	return &fxRepository{
+		db: db,
+	}
+}
+
+func (r *fxRepository) CreateFXRates(requestCtx context.Context, dbExecutor bun.IDB, fxRates []model.FXRate) ([]model.FXRate, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewInsert().
+		Model(&fxRates).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating %d FX rates, error: %+v", len(fxRates), err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't publish the FX rates at the moment. Please try again later.",
+		}
+	}
+
+	return fxRates, nil
+}
+
+// GetLatestFXRate returns the most recently published rate matching the options, nil when no rate matches them
+func (r *fxRepository) GetLatestFXRate(requestCtx context.Context, dbExecutor bun.IDB, options types.FXRateQueryOptions) (*model.FXRate, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var fxRate model.FXRate
+	query := dbExecutor.NewSelect().Model(&fxRate)
+
+	// dynamically construct the query based on which fields are set
+	if options.BaseCurrency != nil {
+		query = query.Where("base_currency = ?", *options.BaseCurrency)
+	}
+	if options.QuoteCurrency != nil {
+		query = query.Where("quote_currency = ?", *options.QuoteCurrency)
+	}
+	if options.PublishedAfter != nil {
+		query = query.Where("created_at >= ?", *options.PublishedAfter)
+	}
+
+	err := query.Order("created_at DESC").Limit(1).Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		logger.Error(requestCtx, "Error while finding latest FX rate with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the FX rate at the moment. Please try again later.",
+		}
+	}
+
+	return &fxRate, nil
+}
+
+// ListLatestFXRates returns the most recently published rate of every currency pair
+func (r *fxRepository) ListLatestFXRates(requestCtx context.Context, dbExecutor bun.IDB) ([]model.FXRate, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var fxRates []model.FXRate
+	err := dbExecutor.NewSelect().
+		Model(&fxRates).
+		DistinctOn("base_currency, quote_currency").
+		Order("base_currency ASC", "quote_currency ASC", "created_at DESC").
+		Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing latest FX rates, error: %+v", err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the FX rates at the moment. Please try again later.",
+		}
+	}
+
+	return fxRates, nil
+}
+
+func (r *fxRepository) CreateFXQuote(requestCtx context.Context, dbExecutor bun.IDB, fxQuote *model.FXQuote) (*model.FXQuote, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewInsert().
+		Model(fxQuote).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating FX quote for userID: %s, error: %+v", fxQuote.UserID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't give an FX quote at the moment. Please try again later.",
+		}
+	}
+
+	return fxQuote, nil
+}
+
+func (r *fxRepository) GetFXQuote(requestCtx context.Context, dbExecutor bun.IDB, options types.FXQuoteQueryOptions) (*model.FXQuote, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var fxQuote model.FXQuote
+	query := dbExecutor.NewSelect().Model(&fxQuote)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "FX quote not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding FX quote with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the FX quote at the moment. Please try again later.",
+		}
+	}
+
+	return &fxQuote, nil
+}
+
+func (r *fxRepository) UpdateFXQuote(requestCtx context.Context, dbExecutor bun.IDB, fxQuoteID uuid.UUID, options types.FXQuoteUpdateOptions) (*model.FXQuote, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var fxQuote model.FXQuote
+	query := dbExecutor.NewUpdate().Model(&fxQuote)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewStatus != nil {
+		query = query.Set("status = ?", *options.NewStatus)
+	}
+	if options.NewTransactionID != nil {
+		query = query.Set("transaction_id = ?", *options.NewTransactionID)
+	}
+	if options.NewUsedAt != nil {
+		query = query.Set("used_at = ?", *options.NewUsedAt)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", fxQuoteID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating FX quote with ID: %s, error: %+v", fxQuoteID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the FX quote at the moment. Please try again later.",
+		}
+	}
+
+	return &fxQuote, nil
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/fx/model"
+	"github.com/skamranahmed/go-bank/internal/fx/types"
+	"github.com/uptrace/bun"
+)
+
+type FXRepository interface {
+	CreateFXRates(requestCtx context.Context, dbExecutor bun.IDB, fxRates []model.FXRate) ([]model.FXRate, error)
+	GetLatestFXRate(requestCtx context.Context, dbExecutor bun.IDB, options types.FXRateQueryOptions) (*model.FXRate, error)
+	ListLatestFXRates(requestCtx context.Context, dbExecutor bun.IDB) ([]model.FXRate, error)
+
+	CreateFXQuote(requestCtx context.Context, dbExecutor bun.IDB, fxQuote *model.FXQuote) (*model.FXQuote, error)
+	GetFXQuote(requestCtx context.Context, dbExecutor bun.IDB, options types.FXQuoteQueryOptions) (*model.FXQuote, error)
+	UpdateFXQuote(requestCtx context.Context, dbExecutor bun.IDB, fxQuoteID uuid.UUID, options types.FXQuoteUpdateOptions) (*model.FXQuote, error)
+}
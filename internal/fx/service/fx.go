@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/config"
+	accountService "github.com/skamranahmed/go-bank/internal/account/service"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/fx/model"
+	"github.com/skamranahmed/go-bank/internal/fx/repository"
+	"github.com/skamranahmed/go-bank/internal/fx/types"
+	"github.com/skamranahmed/go-bank/pkg/money"
+	"github.com/uptrace/bun"
+)
+
+type fxService struct {
+	db             *bun.DB
+	fxRepository   repository.FXRepository
+	accountService accountService.AccountService
+	fxConfig       config.FXConfig
+}
+
+func NewFXService(
+	db *bun.DB,
+	fxRepository repository.FXRepository,
+	accountService accountService.AccountService,
+	fxConfig config.FXConfig,
+) FXService {
+	return &fxService{
+		db:             db,
+		fxRepository:   fxRepository,
+		accountService: accountService,
+		fxConfig:       fxConfig,
+	}
+}
+
+// PublishFXRates adds the rates, each of them becomes the rate quoted for its currency pair
+func (s *fxService) PublishFXRates(requestCtx context.Context, dbExecutor bun.IDB, params types.PublishFXRatesParams) ([]model.FXRate, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	fxRates := make([]model.FXRate, 0, len(params.Rates))
+	for _, rate := range params.Rates {
+		fxRates = append(fxRates, model.FXRate{
+			BaseCurrency:      rate.Base,
+			QuoteCurrency:     rate.Quote,
+			Rate:              rate.Value,
+			Source:            params.Source,
+			PublishedByUserID: params.PublishedByUserID,
+		})
+	}
+
+	return s.fxRepository.CreateFXRates(requestCtx, dbExecutor, fxRates)
+}
+
+func (s *fxService) ListLatestFXRates(requestCtx context.Context, dbExecutor bun.IDB) ([]model.FXRate, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.fxRepository.ListLatestFXRates(requestCtx, dbExecutor)
+}
+
+/*
+CreateFXQuote fixes the amount credited to the to account for a transfer of params.Amount from the from account
+
+The amount is converted at the latest published rate of the pair, less the margin of the bank.
+When only the rate of the opposite pair is published, it is inverted. Rates older than the configured age are not quoted.
+The quote expires after the configured number of seconds.
+*/
+func (s *fxService) CreateFXQuote(requestCtx context.Context, dbExecutor bun.IDB, params types.CreateFXQuoteParams) (*model.FXQuote, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	if params.FromAccountID == params.ToAccountID {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Sender and recipient account ids must be different",
+		}
+	}
+
+	fromAccount, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &params.FromAccountID,
+		Columns:   []string{"id", "user_id", "type", "currency"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// authorization check that the user asking for the quote holds the sender account, either as its primary or as a joint holder
+	isAccountHolder, err := s.accountService.IsAccountHolder(requestCtx, dbExecutor, fromAccount, params.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAccountHolder {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You are not authorized to perform transfer from this account",
+		}
+	}
+
+	toAccount, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &params.ToAccountID,
+		Columns:   []string{"id", "type", "currency"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// deposit accounts are funded and paid out only by the deposit product itself
+	if !fromAccount.Type.AllowsTransfers() || !toAccount.Type.AllowsTransfers() {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Transfers are only allowed between savings and current accounts",
+		}
+	}
+
+	if fromAccount.Currency == toAccount.Currency {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("Both accounts are held in %s, a transfer between them needs no FX quote", fromAccount.Currency),
+		}
+	}
+
+	fxRate, rate, err := s.findRate(requestCtx, dbExecutor, fromAccount.Currency, toAccount.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	rate = rate.WithMargin(s.fxConfig.MarginInBasisPoints)
+	targetAmount, err := rate.Convert(fromAccount.Money(params.Amount))
+	if err != nil || !targetAmount.IsPositive() {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("The amount is too small to be converted to %s", toAccount.Currency),
+		}
+	}
+
+	return s.fxRepository.CreateFXQuote(requestCtx, dbExecutor, &model.FXQuote{
+		UserID:              params.UserID,
+		FromAccountID:       fromAccount.ID,
+		ToAccountID:         toAccount.ID,
+		SourceCurrency:      fromAccount.Currency,
+		SourceAmount:        params.Amount,
+		TargetCurrency:      toAccount.Currency,
+		TargetAmount:        targetAmount.Amount,
+		FXRateID:            fxRate.ID,
+		Rate:                rate.Value,
+		MarginInBasisPoints: s.fxConfig.MarginInBasisPoints,
+		Status:              model.FXQuoteOpen,
+		ExpiresAt:           time.Now().UTC().Add(time.Duration(s.fxConfig.QuoteExpiryInSeconds) * time.Second),
+	})
+}
+
+// GetRate returns the latest rate that is recent enough to convert from one currency to the other, without the margin of a quote
+func (s *fxService) GetRate(requestCtx context.Context, dbExecutor bun.IDB, fromCurrency, toCurrency money.Currency) (money.Rate, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	_, rate, err := s.findRate(requestCtx, dbExecutor, fromCurrency, toCurrency)
+	return rate, err
+}
+
+// findRate returns the latest rate that is recent enough to convert from one currency to the other, along with the published rate it was derived from
+func (s *fxService) findRate(requestCtx context.Context, dbExecutor bun.IDB, fromCurrency, toCurrency money.Currency) (*model.FXRate, money.Rate, error) {
+	publishedAfter := time.Now().UTC().Add(-time.Duration(s.fxConfig.RateMaxAgeInHours) * time.Hour)
+
+	fxRate, err := s.fxRepository.GetLatestFXRate(requestCtx, dbExecutor, types.FXRateQueryOptions{
+		BaseCurrency:   &fromCurrency,
+		QuoteCurrency:  &toCurrency,
+		PublishedAfter: &publishedAfter,
+	})
+	if err != nil {
+		return nil, money.Rate{}, err
+	}
+	if fxRate != nil {
+		return fxRate, fxRate.MoneyRate(), nil
+	}
+
+	// fall back to the rate of the opposite pair, eg: the USD to INR rate is used to convert INR to USD
+	fxRate, err = s.fxRepository.GetLatestFXRate(requestCtx, dbExecutor, types.FXRateQueryOptions{
+		BaseCurrency:   &toCurrency,
+		QuoteCurrency:  &fromCurrency,
+		PublishedAfter: &publishedAfter,
+	})
+	if err != nil {
+		return nil, money.Rate{}, err
+	}
+	if fxRate != nil {
+		return fxRate, fxRate.MoneyRate().Invert(), nil
+	}
+
+	return nil, money.Rate{}, &server.ApiError{
+		HttpStatusCode: http.StatusBadRequest,
+		Message:        fmt.Sprintf("No FX rate is available to convert %s to %s", fromCurrency, toCurrency),
+	}
+}
+
+func (s *fxService) GetFXQuote(requestCtx context.Context, dbExecutor bun.IDB, options types.FXQuoteQueryOptions) (*model.FXQuote, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.fxRepository.GetFXQuote(requestCtx, dbExecutor, options)
+}
+
+// UseFXQuote marks the quote as used by the transfer whose debit transaction is given, the caller is responsible for checking it could still be used
+func (s *fxService) UseFXQuote(requestCtx context.Context, dbExecutor bun.IDB, fxQuoteID uuid.UUID, transactionID uuid.UUID, usedAt time.Time) (*model.FXQuote, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	usedStatus := model.FXQuoteUsed
+	return s.fxRepository.UpdateFXQuote(requestCtx, dbExecutor, fxQuoteID, types.FXQuoteUpdateOptions{
+		NewStatus:        &usedStatus,
+		NewTransactionID: &transactionID,
+		NewUsedAt:        &usedAt,
+	})
+}
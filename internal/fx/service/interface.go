@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/fx/model"
+	"github.com/skamranahmed/go-bank/internal/fx/types"
+	"github.com/skamranahmed/go-bank/pkg/money"
+	"github.com/uptrace/bun"
+)
+
+type FXService interface {
+	PublishFXRates(requestCtx context.Context, dbExecutor bun.IDB, params types.PublishFXRatesParams) ([]model.FXRate, error)
+	ListLatestFXRates(requestCtx context.Context, dbExecutor bun.IDB) ([]model.FXRate, error)
+
+	CreateFXQuote(requestCtx context.Context, dbExecutor bun.IDB, params types.CreateFXQuoteParams) (*model.FXQuote, error)
+	GetFXQuote(requestCtx context.Context, dbExecutor bun.IDB, options types.FXQuoteQueryOptions) (*model.FXQuote, error)
+	UseFXQuote(requestCtx context.Context, dbExecutor bun.IDB, fxQuoteID uuid.UUID, transactionID uuid.UUID, usedAt time.Time) (*model.FXQuote, error)
+
+	GetRate(requestCtx context.Context, dbExecutor bun.IDB, fromCurrency, toCurrency money.Currency) (money.Rate, error)
+}
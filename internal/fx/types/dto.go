@@ -0,0 +1,121 @@
+package types
+
+import (
+	"time"
+
+	"github.com/skamranahmed/go-bank/internal/fx/model"
+	"github.com/skamranahmed/go-bank/pkg/money"
+)
+
+type PublishFXRateRequest struct {
+	Data PublishFXRateRequestData `json:"data" binding:"required"`
+}
+
+type PublishFXRateRequestData struct {
+	BaseCurrency  string `json:"base_currency" binding:"required,currency"`
+	QuoteCurrency string `json:"quote_currency" binding:"required,currency"`
+
+	// Rate is the price of one unit of the base currency in the quote currency, as a decimal number eg: "83.25"
+	Rate string `json:"rate" binding:"required,max=30"`
+}
+
+type ImportFXRatesRequest struct {
+	Data ImportFXRatesRequestData `json:"data" binding:"required"`
+}
+
+type ImportFXRatesRequestData struct {
+	// Content is the text of the CSV file, with a base_currency,quote_currency,rate header
+	Content string `json:"content" binding:"required,min=1"`
+}
+
+type FXRateDto struct {
+	ID            string             `json:"id"`
+	CreatedAt     time.Time          `json:"created_at"`
+	BaseCurrency  string             `json:"base_currency"`
+	QuoteCurrency string             `json:"quote_currency"`
+	Rate          string             `json:"rate"`
+	Source        model.FXRateSource `json:"source"`
+}
+
+type PublishFXRatesResponse struct {
+	Data []FXRateDto `json:"data"`
+}
+
+type GetFXRatesResponse struct {
+	Data []FXRateDto `json:"data"`
+}
+
+type CreateFXQuoteRequest struct {
+	Data CreateFXQuoteRequestData `json:"data" binding:"required"`
+}
+
+type CreateFXQuoteRequestData struct {
+	FromAccountID int64 `json:"from_account_id" binding:"required,account_number"`
+	ToAccountID   int64 `json:"to_account_id" binding:"required,account_number"`
+
+	// Amount is debited from the from account, in the smallest unit of its currency
+	Amount *int64 `json:"amount" binding:"required,gt=0"`
+}
+
+type FXQuoteDto struct {
+	ID             string              `json:"id"`
+	CreatedAt      time.Time           `json:"created_at"`
+	FromAccountID  int64               `json:"from_account_id"`
+	ToAccountID    int64               `json:"to_account_id"`
+	SourceCurrency string              `json:"source_currency"`
+	SourceAmount   int64               `json:"source_amount"`
+	TargetCurrency string              `json:"target_currency"`
+	TargetAmount   int64               `json:"target_amount"`
+	Rate           string              `json:"rate"`
+	Status         model.FXQuoteStatus `json:"status"`
+	ExpiresAt      time.Time           `json:"expires_at"`
+	TransactionID  *string             `json:"transaction_id"`
+	UsedAt         *time.Time          `json:"used_at"`
+}
+
+type FXQuoteResponse struct {
+	Data FXQuoteDto `json:"data"`
+}
+
+func TransformToFXRateDto(fxRate *model.FXRate) *FXRateDto {
+	return &FXRateDto{
+		ID:            fxRate.ID.String(),
+		CreatedAt:     fxRate.CreatedAt,
+		BaseCurrency:  string(fxRate.BaseCurrency),
+		QuoteCurrency: string(fxRate.QuoteCurrency),
+		Rate:          fxRate.MoneyRate().String(),
+		Source:        fxRate.Source,
+	}
+}
+
+func TransformToFXRateDtoList(fxRates []model.FXRate) []FXRateDto {
+	fxRateDtos := make([]FXRateDto, 0, len(fxRates))
+	for _, fxRate := range fxRates {
+		fxRateDtos = append(fxRateDtos, *TransformToFXRateDto(&fxRate))
+	}
+	return fxRateDtos
+}
+
+func TransformToFXQuoteDto(fxQuote *model.FXQuote) *FXQuoteDto {
+	var transactionID *string
+	if fxQuote.TransactionID != nil {
+		id := fxQuote.TransactionID.String()
+		transactionID = &id
+	}
+
+	return &FXQuoteDto{
+		ID:             fxQuote.ID.String(),
+		CreatedAt:      fxQuote.CreatedAt,
+		FromAccountID:  fxQuote.FromAccountID,
+		ToAccountID:    fxQuote.ToAccountID,
+		SourceCurrency: string(fxQuote.SourceCurrency),
+		SourceAmount:   fxQuote.SourceAmount,
+		TargetCurrency: string(fxQuote.TargetCurrency),
+		TargetAmount:   fxQuote.TargetAmount,
+		Rate:           money.Rate{Base: fxQuote.SourceCurrency, Quote: fxQuote.TargetCurrency, Value: fxQuote.Rate}.String(),
+		Status:         fxQuote.Status,
+		ExpiresAt:      fxQuote.ExpiresAt,
+		TransactionID:  transactionID,
+		UsedAt:         fxQuote.UsedAt,
+	}
+}
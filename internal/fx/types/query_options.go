@@ -0,0 +1,30 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/fx/model"
+	"github.com/skamranahmed/go-bank/pkg/money"
+)
+
+type FXRateQueryOptions struct {
+	BaseCurrency  *money.Currency
+	QuoteCurrency *money.Currency
+
+	// When set, only rates published at or after this time are looked up
+	PublishedAfter *time.Time
+}
+
+type FXQuoteQueryOptions struct {
+	ID *uuid.UUID
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type FXQuoteUpdateOptions struct {
+	NewStatus        *model.FXQuoteStatus
+	NewTransactionID *uuid.UUID
+	NewUsedAt        *time.Time
+}
@@ -0,0 +1,22 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/fx/model"
+	"github.com/skamranahmed/go-bank/pkg/money"
+)
+
+type PublishFXRatesParams struct {
+	Rates             []money.Rate
+	Source            model.FXRateSource
+	PublishedByUserID uuid.UUID
+}
+
+type CreateFXQuoteParams struct {
+	UserID        uuid.UUID
+	FromAccountID int64
+	ToAccountID   int64
+
+	// Amount is debited from the from account, in the smallest unit of its currency
+	Amount int64
+}
@@ -1,25 +1,56 @@
 package internal
 
 import (
+	"github.com/skamranahmed/go-bank/config"
 	accountRepository "github.com/skamranahmed/go-bank/internal/account/repository"
 	accountService "github.com/skamranahmed/go-bank/internal/account/service"
 	authenticationService "github.com/skamranahmed/go-bank/internal/authentication/service"
+	balanceRepository "github.com/skamranahmed/go-bank/internal/balance/repository"
+	balanceService "github.com/skamranahmed/go-bank/internal/balance/service"
+	beneficiaryRepository "github.com/skamranahmed/go-bank/internal/beneficiary/repository"
+	beneficiaryService "github.com/skamranahmed/go-bank/internal/beneficiary/service"
+	depositRepository "github.com/skamranahmed/go-bank/internal/deposit/repository"
+	depositService "github.com/skamranahmed/go-bank/internal/deposit/service"
+	feeRepository "github.com/skamranahmed/go-bank/internal/fee/repository"
+	feeService "github.com/skamranahmed/go-bank/internal/fee/service"
+	fxRepository "github.com/skamranahmed/go-bank/internal/fx/repository"
+	fxService "github.com/skamranahmed/go-bank/internal/fx/service"
 	healthzService "github.com/skamranahmed/go-bank/internal/healthz/service"
+	ledgerRepository "github.com/skamranahmed/go-bank/internal/ledger/repository"
+	ledgerService "github.com/skamranahmed/go-bank/internal/ledger/service"
+	loanRepository "github.com/skamranahmed/go-bank/internal/loan/repository"
+	loanService "github.com/skamranahmed/go-bank/internal/loan/service"
+	paymentRequestRepository "github.com/skamranahmed/go-bank/internal/paymentrequest/repository"
+	paymentRequestService "github.com/skamranahmed/go-bank/internal/paymentrequest/service"
+	transferRepository "github.com/skamranahmed/go-bank/internal/transfer/repository"
 	transferService "github.com/skamranahmed/go-bank/internal/transfer/service"
 	userRepository "github.com/skamranahmed/go-bank/internal/user/repository"
 	userService "github.com/skamranahmed/go-bank/internal/user/service"
+	vpaRepository "github.com/skamranahmed/go-bank/internal/vpa/repository"
+	vpaService "github.com/skamranahmed/go-bank/internal/vpa/service"
 	"github.com/skamranahmed/go-bank/pkg/cache"
 	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
 	"github.com/uptrace/bun"
 )
 
 type Services struct {
+	Db                    *bun.DB
+	Cache                 cache.CacheClient
 	AccountService        accountService.AccountService
 	AuthenticationService authenticationService.AuthenticationService
+	BalanceService        balanceService.BalanceService
+	BeneficiaryService    beneficiaryService.BeneficiaryService
+	DepositService        depositService.DepositService
+	FeeService            feeService.FeeService
+	FXService             fxService.FXService
 	HealthzService        healthzService.HealthzService
+	LedgerService         ledgerService.LedgerService
+	LoanService           loanService.LoanService
+	PaymentRequestService paymentRequestService.PaymentRequestService
 	TaskEnqueuer          tasksHelper.TaskEnqueuer
 	TransferService       transferService.TransferService
 	UserService           userService.UserService
+	VPAService            vpaService.VPAService
 }
 
 func BootstrapServices(db *bun.DB, cacheClient cache.CacheClient, taskEnqueuer tasksHelper.TaskEnqueuer) (*Services, error) {
@@ -35,17 +66,72 @@ func BootstrapServices(db *bun.DB, cacheClient cache.CacheClient, taskEnqueuer t
 
 	// account service
 	accountRepository := accountRepository.NewAccountRepository(db)
-	accountService := accountService.NewAccountService(db, accountRepository)
+	accountService := accountService.NewAccountService(db, accountRepository, config.GetPocketConfig())
+
+	// ledger service
+	ledgerRepository := ledgerRepository.NewLedgerRepository(db)
+	ledgerService := ledgerService.NewLedgerService(db, ledgerRepository)
+
+	// fee service
+	feeRepository := feeRepository.NewFeeRepository(db)
+	feeService := feeService.NewFeeService(db, feeRepository, accountService, ledgerService)
+
+	// balance service
+	// the penalty for falling short of the minimum average balance is pluggable, by default it is charged from the fee schedule
+	var breachChargeHook balanceService.BreachChargeHook = balanceService.NewFeeBreachChargeHook(feeService)
+	if !config.GetMinimumAverageBalanceConfig().ChargePenalty {
+		breachChargeHook = balanceService.NewNoopBreachChargeHook()
+	}
+	balanceRepository := balanceRepository.NewBalanceRepository(db)
+	balanceService := balanceService.NewBalanceService(db, balanceRepository, breachChargeHook)
+
+	// fx service
+	fxRepository := fxRepository.NewFXRepository(db)
+	fxService := fxService.NewFXService(db, fxRepository, accountService, config.GetFXConfig())
 
 	// transfer service
-	transferService := transferService.NewTransferService(db, accountService)
+	// external transfers are sent over a local simulator of the NEFT, IMPS and RTGS rails
+	paymentRail := transferService.NewSimulatedPaymentRail(config.GetBankConfig().Code, config.GetPaymentRailConfig())
+	transferRepository := transferRepository.NewTransferRepository(db)
+	transferService := transferService.NewTransferService(db, transferRepository, accountService, feeService, ledgerService, fxService, paymentRail, config.GetTransferLimitConfig(), config.GetStandingInstructionConfig(), config.GetBulkTransferConfig())
+
+	// deposit service
+	depositRepository := depositRepository.NewDepositRepository(db)
+	depositService := depositService.NewDepositService(db, depositRepository, accountService, transferService, ledgerService, feeService, config.GetFixedDepositConfig(), config.GetRecurringDepositConfig())
+
+	// loan service
+	loanRepository := loanRepository.NewLoanRepository(db)
+	loanService := loanService.NewLoanService(db, loanRepository, accountService, ledgerService, feeService, config.GetLoanConfig())
+
+	// beneficiary service
+	beneficiaryRepository := beneficiaryRepository.NewBeneficiaryRepository(db)
+	beneficiaryService := beneficiaryService.NewBeneficiaryService(db, beneficiaryRepository, accountService, userService, config.GetBeneficiaryConfig())
+
+	// vpa service
+	vpaRepository := vpaRepository.NewVPARepository(db)
+	vpaService := vpaService.NewVPAService(db, vpaRepository, accountService, transferService, config.GetVPAConfig())
+
+	// payment request service
+	paymentRequestRepository := paymentRequestRepository.NewPaymentRequestRepository(db)
+	paymentRequestService := paymentRequestService.NewPaymentRequestService(db, paymentRequestRepository, accountService, transferService, config.GetPaymentRequestConfig())
 
 	return &Services{
+		Db:                    db,
+		Cache:                 cacheClient,
 		AccountService:        accountService,
 		AuthenticationService: authenticationService,
+		BalanceService:        balanceService,
+		BeneficiaryService:    beneficiaryService,
+		DepositService:        depositService,
+		FeeService:            feeService,
+		FXService:             fxService,
 		HealthzService:        healthzService,
+		LedgerService:         ledgerService,
+		LoanService:           loanService,
+		PaymentRequestService: paymentRequestService,
 		TaskEnqueuer:          taskEnqueuer,
 		TransferService:       transferService,
 		UserService:           userService,
+		VPAService:            vpaService,
 	}, nil
 }
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/skamranahmed/go-bank/pkg/money"
+	"github.com/uptrace/bun"
+)
+
+/*
+InternalAccount is a bank-owned general ledger account (eg: fee revenue, tax payable)
+
+Unlike customer accounts, internal accounts are identified by a well-known code,
+are not owned by any user and their balance is allowed to go below zero
+*/
+type InternalAccount struct {
+	bun.BaseModel `bun:"table:internal_accounts"`
+
+	Code      InternalAccountCode `bun:"code,pk,notnull,type:varchar(50)"`
+	CreatedAt time.Time           `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time           `bun:"updated_at,notnull,default:current_timestamp"`
+	Name      string              `bun:"name,notnull,type:varchar(100)"`
+
+	// Currency is the ISO 4217 code of the currency the balance is held in, only the FX position accounts hold another currency than INR
+	Currency money.Currency `bun:"currency,notnull,type:varchar(3),default:'INR'"`
+
+	// Balance is stored in the smallest currency unit (paise for INR)
+	Balance int64 `bun:"balance,notnull,default:0"`
+}
+
+type InternalAccountCode string
+
+const (
+	FeeRevenue InternalAccountCode = "FEE_REVENUE" // fees earned from customers
+	TaxPayable InternalAccountCode = "TAX_PAYABLE" // tax collected on fees, owed to the government
+
+	InterestExpense InternalAccountCode = "INTEREST_EXPENSE" // interest paid out to customers on deposits
+
+	LoansOutstanding InternalAccountCode = "LOANS_OUTSTANDING" // principal lent to customers, goes below zero by the principal yet to be repaid
+	InterestIncome   InternalAccountCode = "INTEREST_INCOME"   // interest earned from customers on loans
+
+	OutboundClearing InternalAccountCode = "OUTBOUND_CLEARING" // external transfers debited from customers that have not settled or been returned yet
+	InboundClearing  InternalAccountCode = "INBOUND_CLEARING"  // payments received from other banks, goes below zero by the amount owed to us by the clearing house
+	InboundSuspense  InternalAccountCode = "INBOUND_SUSPENSE"  // inbound payments that could not be matched to an account, waiting for an admin
+)
+
+// fxPositionPrefix is followed by the currency code in the code of an FX position account, eg: FX_POSITION_USD
+const fxPositionPrefix = "FX_POSITION_"
+
+/*
+FXPosition returns the code of the FX position account of the currency
+
+The bank holds one position account per currency, a cross-currency transfer credits the position of the currency
+it is paid in and debits the position of the currency it is paid out in, so that each side of it balances in its own currency.
+The balance of a position is the amount of the currency the bank has bought (above zero) or sold (below zero).
+*/
+func FXPosition(currency money.Currency) InternalAccountCode {
+	return InternalAccountCode(fxPositionPrefix + string(currency))
+}
+
+// internalAccountNames maps every known internal account to its human readable name
+var internalAccountNames = map[InternalAccountCode]string{
+	FeeRevenue: "Fee Revenue",
+	TaxPayable: "Tax Payable",
+
+	InterestExpense: "Interest Expense",
+
+	LoansOutstanding: "Loans Outstanding",
+	InterestIncome:   "Interest Income",
+
+	OutboundClearing: "Outbound Clearing",
+	InboundClearing:  "Inbound Clearing",
+	InboundSuspense:  "Inbound Suspense",
+}
+
+func (c InternalAccountCode) Name() string {
+	if currency, ok := strings.CutPrefix(string(c), fxPositionPrefix); ok {
+		return "FX Position " + currency
+	}
+
+	name, ok := internalAccountNames[c]
+	if !ok {
+		return string(c)
+	}
+	return name
+}
+
+// Currency returns the currency the internal account is held in, the currency of its position for an FX position account and INR otherwise
+func (c InternalAccountCode) Currency() money.Currency {
+	if currency, ok := strings.CutPrefix(string(c), fxPositionPrefix); ok {
+		return money.Currency(currency)
+	}
+	return money.INR
+}
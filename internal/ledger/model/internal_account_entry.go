@@ -0,0 +1,38 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type InternalAccountEntry struct {
+	bun.BaseModel `bun:"table:internal_account_entries"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:now()"`
+
+	// foreign key to "internal_accounts" table
+	InternalAccountCode InternalAccountCode `bun:"internal_account_code,notnull,type:varchar(50)"`
+	InternalAccount     *InternalAccount    `bun:"rel:belongs-to,join:internal_account_code=code"`
+
+	// Amount is stored in the smallest currency unit (paise for INR)
+	Amount int64 `bun:"amount,notnull"`
+
+	// BalanceAfter is the internal account balance after this entry, stored in the smallest currency unit (paise for INR)
+	BalanceAfter int64 `bun:"balance_after,notnull"`
+
+	// Type of entry: DEBIT, CREDIT
+	Type EntryType `bun:"type,notnull"`
+
+	// Reference identifies the business event that caused the entry (eg: the ID of a fee charge)
+	Reference string `bun:"reference,notnull,type:varchar(100)"`
+}
+
+type EntryType string
+
+const (
+	Debit  EntryType = "DEBIT"
+	Credit EntryType = "CREDIT"
+)
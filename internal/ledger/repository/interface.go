@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/skamranahmed/go-bank/internal/ledger/model"
+	"github.com/uptrace/bun"
+)
+
+type LedgerRepository interface {
+	GetInternalAccountForUpdate(requestCtx context.Context, dbExecutor bun.IDB, code model.InternalAccountCode) (*model.InternalAccount, error)
+	UpdateInternalAccountBalance(requestCtx context.Context, dbExecutor bun.IDB, code model.InternalAccountCode, newBalance int64) (*model.InternalAccount, error)
+	CreateEntry(requestCtx context.Context, dbExecutor bun.IDB, entry *model.InternalAccountEntry) (*model.InternalAccountEntry, error)
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/ledger/model"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+type ledgerRepository struct {
+	db *bun.DB
+}
+
+func NewLedgerRepository(db *bun.DB) LedgerRepository {
+	return &ledgerRepository{
+		db: db,
+	}
+}
+
+/*
+GetInternalAccountForUpdate fetches the internal account and locks its row for update
+
+Internal accounts are well-known and are created lazily the first time they are used,
+so that a new internal account does not need a data migration before it can be posted to
+*/
+func (r *ledgerRepository) GetInternalAccountForUpdate(requestCtx context.Context, dbExecutor bun.IDB, code model.InternalAccountCode) (*model.InternalAccount, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewInsert().
+		Model(&model.InternalAccount{
+			Code:     code,
+			Name:     code.Name(),
+			Currency: code.Currency(),
+		}).
+		On("CONFLICT (code) DO NOTHING").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating internal account with code: %s, error: %+v", code, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't process your transaction at the moment. Please try again later.",
+		}
+	}
+
+	var internalAccount model.InternalAccount
+	err = dbExecutor.NewSelect().
+		Model(&internalAccount).
+		Where("code = ?", code).
+		For("UPDATE").
+		Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while fetching internal account with code: %s, error: %+v", code, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't process your transaction at the moment. Please try again later.",
+		}
+	}
+
+	return &internalAccount, nil
+}
+
+func (r *ledgerRepository) UpdateInternalAccountBalance(requestCtx context.Context, dbExecutor bun.IDB, code model.InternalAccountCode, newBalance int64) (*model.InternalAccount, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var internalAccount model.InternalAccount
+	_, err := dbExecutor.NewUpdate().
+		Model(&internalAccount).
+		Set("balance = ?", newBalance).
+		Set("updated_at = NOW()").
+		Where("code = ?", code).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating internal account with code: %s, error: %+v", code, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't process your transaction at the moment. Please try again later.",
+		}
+	}
+
+	return &internalAccount, nil
+}
+
+func (r *ledgerRepository) CreateEntry(requestCtx context.Context, dbExecutor bun.IDB, entry *model.InternalAccountEntry) (*model.InternalAccountEntry, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	err := dbExecutor.NewInsert().
+		Model(entry).
+		Returning("*").
+		Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating entry for internal account with code: %s, error: %+v", entry.InternalAccountCode, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't process your transaction at the moment. Please try again later.",
+		}
+	}
+
+	return entry, nil
+}
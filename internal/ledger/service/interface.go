@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"github.com/skamranahmed/go-bank/internal/ledger/model"
+	"github.com/skamranahmed/go-bank/internal/ledger/types"
+	"github.com/uptrace/bun"
+)
+
+type LedgerService interface {
+	PostEntry(requestCtx context.Context, dbExecutor bun.IDB, params types.PostEntryParams) (*model.InternalAccountEntry, error)
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+
+	"github.com/skamranahmed/go-bank/internal/ledger/model"
+	"github.com/skamranahmed/go-bank/internal/ledger/repository"
+	"github.com/skamranahmed/go-bank/internal/ledger/types"
+	"github.com/uptrace/bun"
+)
+
+type ledgerService struct {
+	db               *bun.DB
+	ledgerRepository repository.LedgerRepository
+}
+
+func NewLedgerService(db *bun.DB, ledgerRepository repository.LedgerRepository) LedgerService {
+	return &ledgerService{
+		db:               db,
+		ledgerRepository: ledgerRepository,
+	}
+}
+
+/*
+PostEntry debits or credits an internal account and records the entry
+
+It should be called within the same database transaction as the customer account
+movement it balances, so that both sides of the money movement are committed together
+*/
+func (s *ledgerService) PostEntry(requestCtx context.Context, dbExecutor bun.IDB, params types.PostEntryParams) (*model.InternalAccountEntry, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	internalAccount, err := s.ledgerRepository.GetInternalAccountForUpdate(requestCtx, dbExecutor, params.InternalAccountCode)
+	if err != nil {
+		return nil, err
+	}
+
+	newBalance := internalAccount.Balance + params.Amount
+	if params.Type == model.Debit {
+		newBalance = internalAccount.Balance - params.Amount
+	}
+
+	internalAccount, err = s.ledgerRepository.UpdateInternalAccountBalance(requestCtx, dbExecutor, params.InternalAccountCode, newBalance)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ledgerRepository.CreateEntry(requestCtx, dbExecutor, &model.InternalAccountEntry{
+		InternalAccountCode: params.InternalAccountCode,
+		Amount:              params.Amount,
+		BalanceAfter:        internalAccount.Balance,
+		Type:                params.Type,
+		Reference:           params.Reference,
+	})
+}
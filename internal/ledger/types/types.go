@@ -0,0 +1,10 @@
+package types
+
+import "github.com/skamranahmed/go-bank/internal/ledger/model"
+
+type PostEntryParams struct {
+	InternalAccountCode model.InternalAccountCode
+	Type                model.EntryType
+	Amount              int64
+	Reference           string
+}
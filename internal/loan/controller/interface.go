@@ -0,0 +1,16 @@
+package controller
+
+import "github.com/gin-gonic/gin"
+
+type LoanController interface {
+	ApplyForLoan(ginCtx *gin.Context)
+	GetLoans(ginCtx *gin.Context)
+	GetLoan(ginCtx *gin.Context)
+	GetLoanSchedule(ginCtx *gin.Context)
+	PrepayLoan(ginCtx *gin.Context)
+	ForecloseLoan(ginCtx *gin.Context)
+
+	GetLoansForReview(ginCtx *gin.Context)
+	ApproveLoan(ginCtx *gin.Context)
+	RejectLoan(ginCtx *gin.Context)
+}
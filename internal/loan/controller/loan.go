@@ -0,0 +1,374 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/middleware"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	accountService "github.com/skamranahmed/go-bank/internal/account/service"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/loan/model"
+	loanService "github.com/skamranahmed/go-bank/internal/loan/service"
+	"github.com/skamranahmed/go-bank/internal/loan/types"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/uptrace/bun"
+)
+
+type loanController struct {
+	db             *bun.DB
+	accountService accountService.AccountService
+	loanService    loanService.LoanService
+}
+
+func newLoanController(dependency Dependency) LoanController {
+	return &loanController{
+		db:             dependency.Db,
+		accountService: dependency.AccountService,
+		loanService:    dependency.LoanService,
+	}
+}
+
+func (c *loanController) ApplyForLoan(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.ApplyForLoanRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	// existence check for the account the loan is disbursed into
+	account, err := c.accountService.GetAccount(requestCtx, nil, accountTypes.AccountQueryOptions{
+		AccountID: &payload.Data.AccountID,
+		Columns:   []string{"id", "user_id"},
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// authorization check: verify the authenticated user is one of the holders of the account
+	isAccountHolder, err := c.accountService.IsAccountHolder(requestCtx, nil, account, userUUID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+	if !isAccountHolder {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You do not have permission to access this account",
+		})
+		return
+	}
+
+	loan, err := c.loanService.ApplyForLoan(requestCtx, nil, types.ApplyForLoanParams{
+		UserID:         userUUID,
+		AccountID:      payload.Data.AccountID,
+		Amount:         *payload.Data.Amount,
+		TenureInMonths: *payload.Data.TenureInMonths,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	loanDto := types.TransformToLoanDto(loan)
+	server.SendSuccessResponse(ginCtx, http.StatusCreated, types.ApplyForLoanResponse{
+		Data: *loanDto,
+	})
+}
+
+func (c *loanController) GetLoans(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	loans, err := c.loanService.ListLoans(requestCtx, nil, types.LoanListOptions{
+		UserID: &userUUID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	loanDtos := types.TransformToLoanDtoList(loans)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetLoansResponse{
+		Data: loanDtos,
+	})
+}
+
+func (c *loanController) GetLoan(ginCtx *gin.Context) {
+	loan, ok := c.getOwnedLoan(ginCtx)
+	if !ok {
+		return
+	}
+
+	// transform to DTO and return response
+	loanDto := types.TransformToLoanDto(loan)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetLoanResponse{
+		Data: *loanDto,
+	})
+}
+
+func (c *loanController) GetLoanSchedule(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	loan, ok := c.getOwnedLoan(ginCtx)
+	if !ok {
+		return
+	}
+
+	installments, err := c.loanService.ListInstallments(requestCtx, nil, types.InstallmentListOptions{
+		LoanID: &loan.ID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	installmentDtos := types.TransformToInstallmentDtoList(installments)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetLoanScheduleResponse{
+		Data: installmentDtos,
+	})
+}
+
+func (c *loanController) PrepayLoan(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	loan, ok := c.getOwnedLoan(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.PrepayLoanRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	var err error
+	err = database.RunInTransaction(requestCtx, "prepayLoan", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		loan, err = c.loanService.PrepayLoan(txCtx, tx, loan.ID, *payload.Data.Amount, today())
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	loanDto := types.TransformToLoanDto(loan)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.RepayLoanResponse{
+		Data: *loanDto,
+	})
+}
+
+func (c *loanController) ForecloseLoan(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	loan, ok := c.getOwnedLoan(ginCtx)
+	if !ok {
+		return
+	}
+
+	var err error
+	err = database.RunInTransaction(requestCtx, "forecloseLoan", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		loan, err = c.loanService.ForecloseLoan(txCtx, tx, loan.ID, today())
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	loanDto := types.TransformToLoanDto(loan)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.RepayLoanResponse{
+		Data: *loanDto,
+	})
+}
+
+func (c *loanController) GetLoansForReview(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	var query types.GetLoansRequestQuery
+	isSuccess := server.BindAndValidateIncomingRequestQuery(ginCtx, &query)
+	if !isSuccess {
+		return
+	}
+
+	var listOptions types.LoanListOptions
+	if query.Status != "" {
+		status := model.LoanStatus(query.Status)
+		listOptions.Status = &status
+	}
+
+	loans, err := c.loanService.ListLoans(requestCtx, nil, listOptions)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	loanDtos := types.TransformToLoanDtoList(loans)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetLoansResponse{
+		Data: loanDtos,
+	})
+}
+
+func (c *loanController) ApproveLoan(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	adminUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	loanID, ok := getLoanID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var loan *model.Loan
+	var err error
+	err = database.RunInTransaction(requestCtx, "approveLoan", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		loan, err = c.loanService.ApproveLoan(txCtx, tx, loanID, adminUUID)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	loanDto := types.TransformToLoanDto(loan)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.ReviewLoanResponse{
+		Data: *loanDto,
+	})
+}
+
+func (c *loanController) RejectLoan(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	adminUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	loanID, ok := getLoanID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.RejectLoanRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	var loan *model.Loan
+	var err error
+	err = database.RunInTransaction(requestCtx, "rejectLoan", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		loan, err = c.loanService.RejectLoan(txCtx, tx, loanID, adminUUID, payload.Data.Reason)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	loanDto := types.TransformToLoanDto(loan)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.ReviewLoanResponse{
+		Data: *loanDto,
+	})
+}
+
+// getOwnedLoan fetches the loan in the URL and verifies it belongs to the authenticated user, sending the error response when it does not
+func (c *loanController) getOwnedLoan(ginCtx *gin.Context) (*model.Loan, bool) {
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return nil, false
+	}
+
+	loanID, ok := getLoanID(ginCtx)
+	if !ok {
+		return nil, false
+	}
+
+	loan, err := c.loanService.GetLoan(ginCtx.Request.Context(), nil, types.LoanQueryOptions{
+		ID: &loanID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return nil, false
+	}
+
+	// authorization check: verify loan belongs to authenticated user
+	if loan.UserID != userUUID {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You do not have permission to access this loan",
+		})
+		return nil, false
+	}
+
+	return loan, true
+}
+
+// getLoanID extracts the loan ID from the URL parameter, sending the error response when it is invalid
+func getLoanID(ginCtx *gin.Context) (uuid.UUID, bool) {
+	loanID, err := uuid.Parse(ginCtx.Param("loan_id"))
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid loan ID",
+		})
+		return uuid.Nil, false
+	}
+
+	return loanID, true
+}
+
+// getAuthenticatedUserID extracts the ID of the authenticated user from the request context, sending the error response when it is missing
+func getAuthenticatedUserID(ginCtx *gin.Context) (uuid.UUID, bool) {
+	userID, ok := ginCtx.Request.Context().Value(middleware.ContextUserIDKey).(string)
+	if !ok || userID == "" {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusUnauthorized,
+			Message:        "User not authenticated",
+		})
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid user ID",
+		})
+		return uuid.Nil, false
+	}
+
+	return userUUID, true
+}
+
+func today() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skamranahmed/go-bank/cmd/middleware"
+	accountService "github.com/skamranahmed/go-bank/internal/account/service"
+	authenticationService "github.com/skamranahmed/go-bank/internal/authentication/service"
+	loanService "github.com/skamranahmed/go-bank/internal/loan/service"
+	userService "github.com/skamranahmed/go-bank/internal/user/service"
+	"github.com/uptrace/bun"
+)
+
+type Dependency struct {
+	Db                    *bun.DB
+	AuthenticationService authenticationService.AuthenticationService
+	AccountService        accountService.AccountService
+	LoanService           loanService.LoanService
+	UserService           userService.UserService
+}
+
+func Register(router *gin.Engine, dependency Dependency) {
+	loanController := newLoanController(dependency)
+	router.POST("/v1/loans", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), loanController.ApplyForLoan)
+	router.GET("/v1/loans", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), loanController.GetLoans)
+	router.GET("/v1/loans/:loan_id", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), loanController.GetLoan)
+	router.GET("/v1/loans/:loan_id/schedule", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), loanController.GetLoanSchedule)
+	router.POST("/v1/loans/:loan_id/prepay", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), loanController.PrepayLoan)
+	router.POST("/v1/loans/:loan_id/foreclose", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), loanController.ForecloseLoan)
+
+	// admin routes
+	router.GET("/v1/admin/loans", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.AdminMiddleware(dependency.UserService), loanController.GetLoansForReview)
+	router.POST("/v1/admin/loans/:loan_id/approve", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.AdminMiddleware(dependency.UserService), loanController.ApproveLoan)
+	router.POST("/v1/admin/loans/:loan_id/reject", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.AdminMiddleware(dependency.UserService), loanController.RejectLoan)
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/uptrace/bun"
+)
+
+// Installment is a single EMI of a loan, the whole schedule is created when the loan is disbursed
+type Installment struct {
+	bun.BaseModel `bun:"table:loan_installments"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "loans" table
+	LoanID uuid.UUID `bun:"loan_id,notnull,type:uuid,unique:loan_installments_loan_id_installment_number_unique"`
+	Loan   *Loan     `bun:"rel:belongs-to,join:loan_id=id"`
+
+	InstallmentNumber int       `bun:"installment_number,notnull,unique:loan_installments_loan_id_installment_number_unique"`
+	DueDate           time.Time `bun:"due_date,notnull,type:date"`
+
+	// OpeningPrincipal is the principal outstanding at the start of the period, the interest component is charged on it
+	OpeningPrincipal int64 `bun:"opening_principal,notnull"`
+
+	// PrincipalComponent and InterestComponent add up to the EMI, stored in the smallest currency unit (paise for INR)
+	PrincipalComponent int64 `bun:"principal_component,notnull"`
+	InterestComponent  int64 `bun:"interest_component,notnull"`
+
+	Status InstallmentStatus `bun:"status,notnull,default:'PENDING'"`
+
+	// AttemptCount is the number of auto-debit attempts that failed for lack of funds
+	AttemptCount     int        `bun:"attempt_count,notnull,default:0"`
+	LastAttemptedAt  *time.Time `bun:"last_attempted_at"`
+	LateFeeChargedAt *time.Time `bun:"late_fee_charged_at"`
+
+	PaidAt *time.Time `bun:"paid_at"`
+
+	// foreign key to "transactions" table, the debit of the customer account that paid the installment
+	TransactionID *uuid.UUID                `bun:"transaction_id,type:uuid"`
+	Transaction   *accountModel.Transaction `bun:"rel:belongs-to,join:transaction_id=id"`
+}
+
+type InstallmentStatus string
+
+const (
+	InstallmentPending   InstallmentStatus = "PENDING"
+	InstallmentPaid      InstallmentStatus = "PAID"
+	InstallmentCancelled InstallmentStatus = "CANCELLED" // no longer due because the loan was foreclosed
+)
+
+// EmiAmount returns the total amount debited for the installment
+func (i *Installment) EmiAmount() int64 {
+	return i.PrincipalComponent + i.InterestComponent
+}
@@ -0,0 +1,40 @@
+package model
+
+import "math"
+
+/*
+CalculateEmi returns the equated monthly installment that repays the principal with interest on the reducing balance
+over the given number of months, rounded up to the next smallest currency unit so that the last installment is never larger than the others
+
+EMI = P * r * (1 + r)^n / ((1 + r)^n - 1), where r is the monthly interest rate
+*/
+func CalculateEmi(principal int64, annualInterestRateInBasisPoints int64, tenureInMonths int) int64 {
+	if tenureInMonths <= 0 {
+		return principal
+	}
+
+	monthlyRate := float64(annualInterestRateInBasisPoints) / 10000 / 12
+	if monthlyRate == 0 {
+		return int64(math.Ceil(float64(principal) / float64(tenureInMonths)))
+	}
+
+	growth := math.Pow(1+monthlyRate, float64(tenureInMonths))
+	return int64(math.Ceil(float64(principal) * monthlyRate * growth / (growth - 1)))
+}
+
+// MonthlyInterest returns the interest for one month on the outstanding principal, rounded to the nearest smallest currency unit
+func MonthlyInterest(outstandingPrincipal int64, annualInterestRateInBasisPoints int64) int64 {
+	return roundedDivision(outstandingPrincipal*annualInterestRateInBasisPoints, 10000*12)
+}
+
+// AccruedInterest returns the simple interest on the outstanding principal for the given number of days
+func AccruedInterest(outstandingPrincipal int64, annualInterestRateInBasisPoints int64, days int64) int64 {
+	if days <= 0 {
+		return 0
+	}
+	return roundedDivision(outstandingPrincipal*annualInterestRateInBasisPoints*days, 10000*365)
+}
+
+func roundedDivision(numerator int64, denominator int64) int64 {
+	return (numerator + denominator/2) / denominator
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/uptrace/bun"
+)
+
+type Loan struct {
+	bun.BaseModel `bun:"table:loans"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "users" table
+	UserID uuid.UUID       `bun:"user_id,notnull,type:uuid"`
+	User   *userModel.User `bun:"rel:belongs-to,join:user_id=id"`
+
+	// foreign key to "accounts" table, the account the loan is disbursed to and the EMIs are debited from
+	AccountID int64                 `bun:"account_id,notnull"`
+	Account   *accountModel.Account `bun:"rel:belongs-to,join:account_id=id"`
+
+	// PrincipalAmount is stored in the smallest currency unit (paise for INR)
+	PrincipalAmount int64 `bun:"principal_amount,notnull"`
+
+	// AnnualInterestRateInBasisPoints is locked in when the loan is applied for, 1 basis point = 0.01%
+	AnnualInterestRateInBasisPoints int64 `bun:"annual_interest_rate_in_basis_points,notnull"`
+
+	TenureInMonths int `bun:"tenure_in_months,notnull"`
+
+	// EmiAmount is the current monthly installment, it is reduced when the loan is prepaid
+	EmiAmount int64 `bun:"emi_amount,notnull"`
+
+	// OutstandingPrincipal is the principal yet to be repaid, stored in the smallest currency unit (paise for INR)
+	OutstandingPrincipal int64 `bun:"outstanding_principal,notnull,default:0"`
+
+	Status LoanStatus `bun:"status,notnull,default:'PENDING_APPROVAL'"`
+
+	// foreign key to "users" table, the admin who approved or rejected the application
+	ReviewedBy      *uuid.UUID `bun:"reviewed_by,type:uuid"`
+	ReviewedAt      *time.Time `bun:"reviewed_at"`
+	RejectionReason *string    `bun:"rejection_reason"`
+
+	DisbursedAt *time.Time `bun:"disbursed_at"`
+	ClosedAt    *time.Time `bun:"closed_at"`
+}
+
+type LoanStatus string
+
+const (
+	PendingApproval LoanStatus = "PENDING_APPROVAL"
+	Rejected        LoanStatus = "REJECTED"
+	Active          LoanStatus = "ACTIVE" // disbursed and being repaid
+	Closed          LoanStatus = "CLOSED" // fully repaid or foreclosed
+)
+
+// maximum day of month an EMI can be due on, so that the due date exists in every month
+const maxEmiDayOfMonth = 28
+
+/*
+BuildSchedule returns the amortization schedule of the outstanding principal over the given number of monthly installments,
+starting with the given installment number and due date
+
+Every installment is of the EMI amount, the interest component is charged on the principal outstanding at the start of the period
+and the rest of the EMI repays the principal. The last installment repays whatever principal is left so that rounding does not leave a balance.
+*/
+func (l *Loan) BuildSchedule(outstandingPrincipal int64, emiAmount int64, firstInstallmentNumber int, firstDueDate time.Time, installmentCount int) []Installment {
+	installments := make([]Installment, 0, installmentCount)
+	openingPrincipal := outstandingPrincipal
+	for i := 0; i < installmentCount; i++ {
+		interestComponent := MonthlyInterest(openingPrincipal, l.AnnualInterestRateInBasisPoints)
+		principalComponent := min(max(emiAmount-interestComponent, 0), openingPrincipal)
+		if i == installmentCount-1 {
+			principalComponent = openingPrincipal
+		}
+
+		installments = append(installments, Installment{
+			LoanID:             l.ID,
+			InstallmentNumber:  firstInstallmentNumber + i,
+			DueDate:            time.Date(firstDueDate.Year(), firstDueDate.Month()+time.Month(i), firstDueDate.Day(), 0, 0, 0, 0, time.UTC),
+			OpeningPrincipal:   openingPrincipal,
+			PrincipalComponent: principalComponent,
+			InterestComponent:  interestComponent,
+			Status:             InstallmentPending,
+		})
+		openingPrincipal -= principalComponent
+	}
+	return installments
+}
+
+// FirstEmiDueDate returns the due date of the first EMI, one month after the disbursement date
+func FirstEmiDueDate(disbursementDate time.Time) time.Time {
+	day := min(disbursementDate.Day(), maxEmiDayOfMonth)
+	return time.Date(disbursementDate.Year(), disbursementDate.Month()+1, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/loan/model"
+	"github.com/skamranahmed/go-bank/internal/loan/types"
+	"github.com/uptrace/bun"
+)
+
+type LoanRepository interface {
+	CreateLoan(requestCtx context.Context, dbExecutor bun.IDB, loan *model.Loan) (*model.Loan, error)
+	GetLoan(requestCtx context.Context, dbExecutor bun.IDB, options types.LoanQueryOptions) (*model.Loan, error)
+	ListLoans(requestCtx context.Context, dbExecutor bun.IDB, options types.LoanListOptions) ([]model.Loan, error)
+	UpdateLoan(requestCtx context.Context, dbExecutor bun.IDB, loanID uuid.UUID, options types.LoanUpdateOptions) (*model.Loan, error)
+
+	CreateInstallments(requestCtx context.Context, dbExecutor bun.IDB, installments []model.Installment) ([]model.Installment, error)
+	GetInstallment(requestCtx context.Context, dbExecutor bun.IDB, options types.InstallmentQueryOptions) (*model.Installment, error)
+	ListInstallments(requestCtx context.Context, dbExecutor bun.IDB, options types.InstallmentListOptions) ([]model.Installment, error)
+	UpdateInstallment(requestCtx context.Context, dbExecutor bun.IDB, installmentID uuid.UUID, options types.InstallmentUpdateOptions) (*model.Installment, error)
+}
@@ -0,0 +1,315 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/loan/model"
+	"github.com/skamranahmed/go-bank/internal/loan/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+type loanRepository struct {
+	db *bun.DB
+}
+
+func NewLoanRepository(db *bun.DB) LoanRepository {
+	return &loanRepository{
+		db: db,
+	}
+}
+
+func (r *loanRepository) CreateLoan(requestCtx context.Context, dbExecutor bun.IDB, loan *model.Loan) (*model.Loan, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	err := dbExecutor.NewInsert().
+		Model(loan).
+		Returning("*").
+		Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating loan for accountID: %d, error: %+v", loan.AccountID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't submit your loan application at the moment. Please try again later.",
+		}
+	}
+
+	return loan, nil
+}
+
+func (r *loanRepository) GetLoan(requestCtx context.Context, dbExecutor bun.IDB, options types.LoanQueryOptions) (*model.Loan, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var loan model.Loan
+	query := dbExecutor.NewSelect().Model(&loan)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Loan not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding loan with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the loan at the moment. Please try again later.",
+		}
+	}
+
+	return &loan, nil
+}
+
+func (r *loanRepository) ListLoans(requestCtx context.Context, dbExecutor bun.IDB, options types.LoanListOptions) ([]model.Loan, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var loans []model.Loan
+	query := dbExecutor.NewSelect().Model(&loans)
+
+	// dynamically construct the query based on which fields are set
+	if options.UserID != nil {
+		query = query.Where("user_id = ?", *options.UserID)
+	}
+	if options.Status != nil {
+		query = query.Where("status = ?", *options.Status)
+	}
+
+	err := query.Order("created_at DESC").Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing loans with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the loans at the moment. Please try again later.",
+		}
+	}
+
+	return loans, nil
+}
+
+func (r *loanRepository) UpdateLoan(requestCtx context.Context, dbExecutor bun.IDB, loanID uuid.UUID, options types.LoanUpdateOptions) (*model.Loan, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var loan model.Loan
+	query := dbExecutor.NewUpdate().Model(&loan)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewEmiAmount != nil {
+		query = query.Set("emi_amount = ?", *options.NewEmiAmount)
+	}
+	if options.NewOutstandingPrincipal != nil {
+		query = query.Set("outstanding_principal = ?", *options.NewOutstandingPrincipal)
+	}
+	if options.NewStatus != nil {
+		query = query.Set("status = ?", *options.NewStatus)
+	}
+	if options.NewReviewedBy != nil {
+		query = query.Set("reviewed_by = ?", *options.NewReviewedBy)
+	}
+	if options.NewReviewedAt != nil {
+		query = query.Set("reviewed_at = ?", *options.NewReviewedAt)
+	}
+	if options.NewRejectionReason != nil {
+		query = query.Set("rejection_reason = ?", *options.NewRejectionReason)
+	}
+	if options.NewDisbursedAt != nil {
+		query = query.Set("disbursed_at = ?", *options.NewDisbursedAt)
+	}
+	if options.NewClosedAt != nil {
+		query = query.Set("closed_at = ?", *options.NewClosedAt)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", loanID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating loan with ID: %s, error: %+v", loanID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the loan at the moment. Please try again later.",
+		}
+	}
+
+	return &loan, nil
+}
+
+func (r *loanRepository) CreateInstallments(requestCtx context.Context, dbExecutor bun.IDB, installments []model.Installment) ([]model.Installment, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	err := dbExecutor.NewInsert().
+		Model(&installments).
+		Returning("*").
+		Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating %d installment(s) for loan, error: %+v", len(installments), err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't create the repayment schedule at the moment. Please try again later.",
+		}
+	}
+
+	return installments, nil
+}
+
+func (r *loanRepository) GetInstallment(requestCtx context.Context, dbExecutor bun.IDB, options types.InstallmentQueryOptions) (*model.Installment, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var installment model.Installment
+	query := dbExecutor.NewSelect().Model(&installment)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Installment not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding loan installment with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the installment at the moment. Please try again later.",
+		}
+	}
+
+	return &installment, nil
+}
+
+func (r *loanRepository) ListInstallments(requestCtx context.Context, dbExecutor bun.IDB, options types.InstallmentListOptions) ([]model.Installment, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var installments []model.Installment
+	query := dbExecutor.NewSelect().Model(&installments)
+
+	// dynamically construct the query based on which fields are set
+	if options.LoanID != nil {
+		query = query.Where("loan_id = ?", *options.LoanID)
+	}
+	if options.Status != nil {
+		query = query.Where("status = ?", *options.Status)
+	}
+	if options.DueDateOnOrBefore != nil {
+		query = query.Where("due_date <= ?", options.DueDateOnOrBefore.Format(time.DateOnly))
+	}
+	if options.AfterID != nil {
+		query = query.Where("id > ?", *options.AfterID)
+	}
+	if options.Limit > 0 {
+		query = query.Order("id ASC").Limit(options.Limit)
+	} else {
+		query = query.Order("installment_number ASC")
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing loan installments with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the installments at the moment. Please try again later.",
+		}
+	}
+
+	return installments, nil
+}
+
+func (r *loanRepository) UpdateInstallment(requestCtx context.Context, dbExecutor bun.IDB, installmentID uuid.UUID, options types.InstallmentUpdateOptions) (*model.Installment, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var installment model.Installment
+	query := dbExecutor.NewUpdate().Model(&installment)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewOpeningPrincipal != nil {
+		query = query.Set("opening_principal = ?", *options.NewOpeningPrincipal)
+	}
+	if options.NewPrincipalComponent != nil {
+		query = query.Set("principal_component = ?", *options.NewPrincipalComponent)
+	}
+	if options.NewInterestComponent != nil {
+		query = query.Set("interest_component = ?", *options.NewInterestComponent)
+	}
+	if options.NewStatus != nil {
+		query = query.Set("status = ?", *options.NewStatus)
+	}
+	if options.NewAttemptCount != nil {
+		query = query.Set("attempt_count = ?", *options.NewAttemptCount)
+	}
+	if options.NewLastAttemptedAt != nil {
+		query = query.Set("last_attempted_at = ?", *options.NewLastAttemptedAt)
+	}
+	if options.NewLateFeeChargedAt != nil {
+		query = query.Set("late_fee_charged_at = ?", *options.NewLateFeeChargedAt)
+	}
+	if options.NewPaidAt != nil {
+		query = query.Set("paid_at = ?", *options.NewPaidAt)
+	}
+	if options.NewTransactionID != nil {
+		query = query.Set("transaction_id = ?", *options.NewTransactionID)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", installmentID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating loan installment with ID: %s, error: %+v", installmentID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the installment at the moment. Please try again later.",
+		}
+	}
+
+	return &installment, nil
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/loan/model"
+	"github.com/skamranahmed/go-bank/internal/loan/types"
+	"github.com/uptrace/bun"
+)
+
+type LoanService interface {
+	ApplyForLoan(requestCtx context.Context, dbExecutor bun.IDB, params types.ApplyForLoanParams) (*model.Loan, error)
+	GetLoan(requestCtx context.Context, dbExecutor bun.IDB, options types.LoanQueryOptions) (*model.Loan, error)
+	ListLoans(requestCtx context.Context, dbExecutor bun.IDB, options types.LoanListOptions) ([]model.Loan, error)
+	ListInstallments(requestCtx context.Context, dbExecutor bun.IDB, options types.InstallmentListOptions) ([]model.Installment, error)
+	ApproveLoan(requestCtx context.Context, dbExecutor bun.IDB, loanID uuid.UUID, reviewedBy uuid.UUID) (*model.Loan, error)
+	RejectLoan(requestCtx context.Context, dbExecutor bun.IDB, loanID uuid.UUID, reviewedBy uuid.UUID, reason string) (*model.Loan, error)
+	CollectEmi(requestCtx context.Context, dbExecutor bun.IDB, installmentID uuid.UUID, collectionDate time.Time) (*model.Installment, error)
+	PrepayLoan(requestCtx context.Context, dbExecutor bun.IDB, loanID uuid.UUID, amount int64, prepaymentDate time.Time) (*model.Loan, error)
+	ForecloseLoan(requestCtx context.Context, dbExecutor bun.IDB, loanID uuid.UUID, foreclosureDate time.Time) (*model.Loan, error)
+}
@@ -0,0 +1,565 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/config"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	accountService "github.com/skamranahmed/go-bank/internal/account/service"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	feeModel "github.com/skamranahmed/go-bank/internal/fee/model"
+	feeService "github.com/skamranahmed/go-bank/internal/fee/service"
+	feeTypes "github.com/skamranahmed/go-bank/internal/fee/types"
+	ledgerModel "github.com/skamranahmed/go-bank/internal/ledger/model"
+	ledgerService "github.com/skamranahmed/go-bank/internal/ledger/service"
+	ledgerTypes "github.com/skamranahmed/go-bank/internal/ledger/types"
+	"github.com/skamranahmed/go-bank/internal/loan/model"
+	"github.com/skamranahmed/go-bank/internal/loan/repository"
+	"github.com/skamranahmed/go-bank/internal/loan/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+type loanService struct {
+	db             *bun.DB
+	loanRepository repository.LoanRepository
+	accountService accountService.AccountService
+	ledgerService  ledgerService.LedgerService
+	feeService     feeService.FeeService
+	loanConfig     config.LoanConfig
+}
+
+func NewLoanService(
+	db *bun.DB,
+	loanRepository repository.LoanRepository,
+	accountService accountService.AccountService,
+	ledgerService ledgerService.LedgerService,
+	feeService feeService.FeeService,
+	loanConfig config.LoanConfig,
+) LoanService {
+	return &loanService{
+		db:             db,
+		loanRepository: loanRepository,
+		accountService: accountService,
+		ledgerService:  ledgerService,
+		feeService:     feeService,
+		loanConfig:     loanConfig,
+	}
+}
+
+/*
+ApplyForLoan records a loan application that is disbursed once an admin approves it
+
+The interest rate is the one configured at the time of the application and stays the same for the whole tenure
+*/
+func (s *loanService) ApplyForLoan(requestCtx context.Context, dbExecutor bun.IDB, params types.ApplyForLoanParams) (*model.Loan, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	if params.Amount < s.loanConfig.MinimumAmount || params.Amount > s.loanConfig.MaximumAmount {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("Loan amount must be between %d and %d", s.loanConfig.MinimumAmount, s.loanConfig.MaximumAmount),
+		}
+	}
+
+	account, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &params.AccountID,
+		Columns:   []string{"id", "type", "currency"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !account.Type.AllowsTransfers() {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Loans can only be disbursed to a savings or current account",
+		}
+	}
+
+	// loans are lent and repaid out of the INR books of the bank
+	if string(account.Currency) != config.GetBankConfig().Currency {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("Loans can only be disbursed to an account held in %s", config.GetBankConfig().Currency),
+		}
+	}
+
+	return s.loanRepository.CreateLoan(requestCtx, dbExecutor, &model.Loan{
+		UserID:                          params.UserID,
+		AccountID:                       account.ID,
+		PrincipalAmount:                 params.Amount,
+		AnnualInterestRateInBasisPoints: s.loanConfig.AnnualInterestRateInBasisPoints,
+		TenureInMonths:                  params.TenureInMonths,
+		EmiAmount:                       model.CalculateEmi(params.Amount, s.loanConfig.AnnualInterestRateInBasisPoints, params.TenureInMonths),
+		Status:                          model.PendingApproval,
+	})
+}
+
+func (s *loanService) GetLoan(requestCtx context.Context, dbExecutor bun.IDB, options types.LoanQueryOptions) (*model.Loan, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.loanRepository.GetLoan(requestCtx, dbExecutor, options)
+}
+
+func (s *loanService) ListLoans(requestCtx context.Context, dbExecutor bun.IDB, options types.LoanListOptions) ([]model.Loan, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.loanRepository.ListLoans(requestCtx, dbExecutor, options)
+}
+
+func (s *loanService) ListInstallments(requestCtx context.Context, dbExecutor bun.IDB, options types.InstallmentListOptions) ([]model.Installment, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.loanRepository.ListInstallments(requestCtx, dbExecutor, options)
+}
+
+/*
+ApproveLoan disburses the loan into the customer's account and creates its amortization schedule
+
+It must be called within a database transaction because it locks the loan and the account rows for update.
+The first EMI is due one month after the disbursement.
+*/
+func (s *loanService) ApproveLoan(requestCtx context.Context, dbExecutor bun.IDB, loanID uuid.UUID, reviewedBy uuid.UUID) (*model.Loan, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	loan, err := s.getLoanPendingApproval(requestCtx, dbExecutor, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &loan.AccountID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	newBalance := account.Balance + loan.PrincipalAmount
+	account, err = s.accountService.UpdateAccount(requestCtx, dbExecutor, account.ID, accountTypes.AccountUpdateOptions{
+		NewBalance: &newBalance,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.accountService.CreateTransactionRecord(requestCtx, dbExecutor, &accountModel.Transaction{
+		AccountID:    account.ID,
+		Amount:       loan.PrincipalAmount,
+		BalanceAfter: account.Balance,
+		Type:         accountModel.LoanDisbursement,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the other side of the credit: the principal is owed to the bank until it is repaid
+	_, err = s.ledgerService.PostEntry(requestCtx, dbExecutor, ledgerTypes.PostEntryParams{
+		InternalAccountCode: ledgerModel.LoansOutstanding,
+		Type:                ledgerModel.Debit,
+		Amount:              loan.PrincipalAmount,
+		Reference:           loan.ID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	installments := loan.BuildSchedule(loan.PrincipalAmount, loan.EmiAmount, 1, model.FirstEmiDueDate(now), loan.TenureInMonths)
+	_, err = s.loanRepository.CreateInstallments(requestCtx, dbExecutor, installments)
+	if err != nil {
+		return nil, err
+	}
+
+	activeStatus := model.Active
+	return s.loanRepository.UpdateLoan(requestCtx, dbExecutor, loan.ID, types.LoanUpdateOptions{
+		NewOutstandingPrincipal: &loan.PrincipalAmount,
+		NewStatus:               &activeStatus,
+		NewReviewedBy:           &reviewedBy,
+		NewReviewedAt:           &now,
+		NewDisbursedAt:          &now,
+	})
+}
+
+// RejectLoan rejects a loan application, it must be called within a database transaction because it locks the loan row for update
+func (s *loanService) RejectLoan(requestCtx context.Context, dbExecutor bun.IDB, loanID uuid.UUID, reviewedBy uuid.UUID, reason string) (*model.Loan, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	loan, err := s.getLoanPendingApproval(requestCtx, dbExecutor, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	rejectedStatus := model.Rejected
+	now := time.Now().UTC()
+	return s.loanRepository.UpdateLoan(requestCtx, dbExecutor, loan.ID, types.LoanUpdateOptions{
+		NewStatus:          &rejectedStatus,
+		NewReviewedBy:      &reviewedBy,
+		NewReviewedAt:      &now,
+		NewRejectionReason: &reason,
+	})
+}
+
+/*
+CollectEmi auto-debits a due EMI from the customer's account
+
+When the account does not have enough funds, the attempt is recorded and the EMI stays PENDING so that it is retried the next day.
+The late payment fee is charged once, when the EMI is still unpaid at the end of the grace period.
+
+It must be called within a database transaction because it locks the loan, the installment and the account rows for update.
+It returns a nil installment when the installment is not pending, not due yet or was already attempted on the collection date,
+so that a duplicate or retried task never debits the same EMI twice.
+*/
+func (s *loanService) CollectEmi(requestCtx context.Context, dbExecutor bun.IDB, installmentID uuid.UUID, collectionDate time.Time) (*model.Installment, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	installment, err := s.loanRepository.GetInstallment(requestCtx, dbExecutor, types.InstallmentQueryOptions{
+		ID: &installmentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the loan is locked before the installment, in the same order as prepayment and foreclosure, to prevent deadlocks
+	loan, err := s.loanRepository.GetLoan(requestCtx, dbExecutor, types.LoanQueryOptions{
+		ID:        &installment.LoanID,
+		ForUpdate: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	installment, err = s.loanRepository.GetInstallment(requestCtx, dbExecutor, types.InstallmentQueryOptions{
+		ID:        &installmentID,
+		ForUpdate: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if loan.Status != model.Active || installment.Status != model.InstallmentPending || installment.DueDate.After(collectionDate) {
+		return nil, nil
+	}
+	if installment.LastAttemptedAt != nil && startOfDay(*installment.LastAttemptedAt).Equal(collectionDate) {
+		return nil, nil
+	}
+
+	account, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &loan.AccountID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	newAttemptCount := installment.AttemptCount + 1
+
+	if account.AvailableBalance() < installment.EmiAmount() {
+		updateOptions := types.InstallmentUpdateOptions{
+			NewAttemptCount:    &newAttemptCount,
+			NewLastAttemptedAt: &now,
+		}
+
+		lateFeeDate := installment.DueDate.AddDate(0, 0, s.loanConfig.GracePeriodInDays)
+		if installment.LateFeeChargedAt == nil && !collectionDate.Before(lateFeeDate) {
+			_, err = s.feeService.ChargeFee(requestCtx, dbExecutor, feeTypes.ChargeFeeParams{
+				AccountID:  account.ID,
+				Event:      feeModel.LoanLatePayment,
+				BaseAmount: installment.EmiAmount(),
+				Reference:  installment.ID.String(),
+
+				// the penalty is levied by the bank on its own, it must not fail because the balance is already low
+				CapAtAvailableBalance: true,
+			})
+			if err != nil {
+				return nil, err
+			}
+			updateOptions.NewLateFeeChargedAt = &now
+			logger.Warn(requestCtx, "EMI %d of loanID: %s is unpaid after the grace period, late payment fee charged", installment.InstallmentNumber, loan.ID)
+		}
+
+		return s.loanRepository.UpdateInstallment(requestCtx, dbExecutor, installment.ID, updateOptions)
+	}
+
+	transaction, err := s.repay(requestCtx, dbExecutor, loan, installment.PrincipalComponent, installment.InterestComponent)
+	if err != nil {
+		return nil, err
+	}
+
+	paidStatus := model.InstallmentPaid
+	installment, err = s.loanRepository.UpdateInstallment(requestCtx, dbExecutor, installment.ID, types.InstallmentUpdateOptions{
+		NewStatus:          &paidStatus,
+		NewAttemptCount:    &newAttemptCount,
+		NewLastAttemptedAt: &now,
+		NewPaidAt:          &now,
+		NewTransactionID:   &transaction.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the last principal component repays whatever is left, so the loan is fully repaid once every EMI is paid
+	newOutstandingPrincipal := loan.OutstandingPrincipal - installment.PrincipalComponent
+	updateOptions := types.LoanUpdateOptions{
+		NewOutstandingPrincipal: &newOutstandingPrincipal,
+	}
+	if newOutstandingPrincipal == 0 {
+		closedStatus := model.Closed
+		updateOptions.NewStatus = &closedStatus
+		updateOptions.NewClosedAt = &now
+	}
+
+	_, err = s.loanRepository.UpdateLoan(requestCtx, dbExecutor, loan.ID, updateOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return installment, nil
+}
+
+/*
+PrepayLoan repays part of the outstanding principal ahead of the schedule
+
+The remaining EMIs are recalculated for the reduced principal over the same number of months, so the tenure stays the same and the EMI goes down.
+It must be called within a database transaction because it locks the loan, its installments and the account rows for update.
+*/
+func (s *loanService) PrepayLoan(requestCtx context.Context, dbExecutor bun.IDB, loanID uuid.UUID, amount int64, prepaymentDate time.Time) (*model.Loan, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	loan, pendingInstallments, err := s.getActiveLoanWithPendingInstallments(requestCtx, dbExecutor, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if amount >= loan.OutstandingPrincipal {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("Prepayment amount must be less than the outstanding principal of %d, foreclose the loan to repay it in full", loan.OutstandingPrincipal),
+		}
+	}
+
+	// the schedule is recalculated from the next EMI onwards, which is only correct when none of the EMIs is overdue
+	if len(pendingInstallments) == 0 || !pendingInstallments[0].DueDate.After(prepaymentDate) {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Overdue EMIs must be paid before the loan can be prepaid",
+		}
+	}
+
+	_, err = s.repay(requestCtx, dbExecutor, loan, amount, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	newOutstandingPrincipal := loan.OutstandingPrincipal - amount
+	newEmiAmount := model.CalculateEmi(newOutstandingPrincipal, loan.AnnualInterestRateInBasisPoints, len(pendingInstallments))
+	newSchedule := loan.BuildSchedule(newOutstandingPrincipal, newEmiAmount, pendingInstallments[0].InstallmentNumber, pendingInstallments[0].DueDate, len(pendingInstallments))
+	for i, installment := range pendingInstallments {
+		_, err = s.loanRepository.UpdateInstallment(requestCtx, dbExecutor, installment.ID, types.InstallmentUpdateOptions{
+			NewOpeningPrincipal:   &newSchedule[i].OpeningPrincipal,
+			NewPrincipalComponent: &newSchedule[i].PrincipalComponent,
+			NewInterestComponent:  &newSchedule[i].InterestComponent,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.loanRepository.UpdateLoan(requestCtx, dbExecutor, loan.ID, types.LoanUpdateOptions{
+		NewEmiAmount:            &newEmiAmount,
+		NewOutstandingPrincipal: &newOutstandingPrincipal,
+	})
+}
+
+/*
+ForecloseLoan repays the loan in full and cancels the remaining EMIs
+
+The foreclosure amount is the outstanding principal plus the simple interest accrued on it since the start of the period of the earliest unpaid EMI.
+It must be called within a database transaction because it locks the loan, its installments and the account rows for update.
+*/
+func (s *loanService) ForecloseLoan(requestCtx context.Context, dbExecutor bun.IDB, loanID uuid.UUID, foreclosureDate time.Time) (*model.Loan, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	loan, pendingInstallments, err := s.getActiveLoanWithPendingInstallments(requestCtx, dbExecutor, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	// interest for the periods before the earliest unpaid EMI has already been paid with the earlier EMIs
+	interestAccruedFrom := startOfDay(*loan.DisbursedAt)
+	if len(pendingInstallments) > 0 && pendingInstallments[0].InstallmentNumber > 1 {
+		interestAccruedFrom = pendingInstallments[0].DueDate.AddDate(0, -1, 0)
+	}
+	days := int64(foreclosureDate.Sub(interestAccruedFrom).Hours() / 24)
+	accruedInterest := model.AccruedInterest(loan.OutstandingPrincipal, loan.AnnualInterestRateInBasisPoints, days)
+
+	_, err = s.repay(requestCtx, dbExecutor, loan, loan.OutstandingPrincipal, accruedInterest)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelledStatus := model.InstallmentCancelled
+	for _, installment := range pendingInstallments {
+		_, err = s.loanRepository.UpdateInstallment(requestCtx, dbExecutor, installment.ID, types.InstallmentUpdateOptions{
+			NewStatus: &cancelledStatus,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var newOutstandingPrincipal int64
+	closedStatus := model.Closed
+	closedAt := time.Now().UTC()
+	return s.loanRepository.UpdateLoan(requestCtx, dbExecutor, loan.ID, types.LoanUpdateOptions{
+		NewOutstandingPrincipal: &newOutstandingPrincipal,
+		NewStatus:               &closedStatus,
+		NewClosedAt:             &closedAt,
+	})
+}
+
+/*
+repay debits the principal and interest from the customer's account and books them against the loan
+
+The account row is locked and its balance checked before the debit, in the same way as an internal transfer,
+so that concurrent debits cannot take the account below its available balance
+*/
+func (s *loanService) repay(requestCtx context.Context, dbExecutor bun.IDB, loan *model.Loan, principal int64, interest int64) (*accountModel.Transaction, error) {
+	amount := principal + interest
+
+	account, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &loan.AccountID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if account.AvailableBalance() < amount {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("You do not have sufficient balance in your account to repay %d", amount),
+		}
+	}
+
+	newBalance := account.Balance - amount
+	account, err = s.accountService.UpdateAccount(requestCtx, dbExecutor, account.ID, accountTypes.AccountUpdateOptions{
+		NewBalance: &newBalance,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.accountService.CreateTransactionRecord(requestCtx, dbExecutor, &accountModel.Transaction{
+		AccountID:    account.ID,
+		Amount:       amount,
+		BalanceAfter: account.Balance,
+		Type:         accountModel.LoanRepayment,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the other side of the debit: the principal reduces what is owed to the bank while the interest is the bank's income
+	if principal > 0 {
+		_, err = s.ledgerService.PostEntry(requestCtx, dbExecutor, ledgerTypes.PostEntryParams{
+			InternalAccountCode: ledgerModel.LoansOutstanding,
+			Type:                ledgerModel.Credit,
+			Amount:              principal,
+			Reference:           loan.ID.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if interest > 0 {
+		_, err = s.ledgerService.PostEntry(requestCtx, dbExecutor, ledgerTypes.PostEntryParams{
+			InternalAccountCode: ledgerModel.InterestIncome,
+			Type:                ledgerModel.Credit,
+			Amount:              interest,
+			Reference:           loan.ID.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return transaction, nil
+}
+
+func (s *loanService) getLoanPendingApproval(requestCtx context.Context, dbExecutor bun.IDB, loanID uuid.UUID) (*model.Loan, error) {
+	loan, err := s.loanRepository.GetLoan(requestCtx, dbExecutor, types.LoanQueryOptions{
+		ID:        &loanID,
+		ForUpdate: true, // lock the row so that the application cannot be reviewed twice concurrently
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if loan.Status != model.PendingApproval {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusConflict,
+			Message:        "Loan application has already been reviewed",
+		}
+	}
+
+	return loan, nil
+}
+
+func (s *loanService) getActiveLoanWithPendingInstallments(requestCtx context.Context, dbExecutor bun.IDB, loanID uuid.UUID) (*model.Loan, []model.Installment, error) {
+	loan, err := s.loanRepository.GetLoan(requestCtx, dbExecutor, types.LoanQueryOptions{
+		ID:        &loanID,
+		ForUpdate: true, // lock the row so that no EMI is collected while the loan is being repaid
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if loan.Status != model.Active {
+		return nil, nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Loan is not active",
+		}
+	}
+
+	pendingStatus := model.InstallmentPending
+	pendingInstallments, err := s.loanRepository.ListInstallments(requestCtx, dbExecutor, types.InstallmentListOptions{
+		LoanID:    &loan.ID,
+		Status:    &pendingStatus,
+		ForUpdate: true,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return loan, pendingInstallments, nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
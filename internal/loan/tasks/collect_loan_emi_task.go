@@ -0,0 +1,108 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/internal/loan/model"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/uptrace/bun"
+)
+
+const CollectLoanEmiTaskName string = "task:collect_loan_emi"
+
+type CollectLoanEmiTaskPayload struct {
+	InstallmentID string
+
+	// CollectionDate is the day the collection was scheduled for, in YYYY-MM-DD format
+	CollectionDate string
+}
+
+type CollectLoanEmiTask struct {
+	name          string
+	queue         string
+	maxRetryCount int
+	payload       CollectLoanEmiTaskPayload
+}
+
+func NewCollectLoanEmiTask(installmentID string, collectionDate string) tasksHelper.Task {
+	return &CollectLoanEmiTask{
+		name:          CollectLoanEmiTaskName,
+		queue:         tasksHelper.DefaultQueue,
+		maxRetryCount: 3,
+		payload: CollectLoanEmiTaskPayload{
+			InstallmentID:  installmentID,
+			CollectionDate: collectionDate,
+		},
+	}
+}
+
+func (t *CollectLoanEmiTask) Name() string {
+	return t.name
+}
+
+func (t *CollectLoanEmiTask) Queue() string {
+	return t.queue
+}
+
+func (t *CollectLoanEmiTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *CollectLoanEmiTask) Payload() any {
+	return t.payload
+}
+
+type CollectLoanEmiTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewCollectLoanEmiTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &CollectLoanEmiTaskProcessor{
+		services: services,
+	}
+}
+
+// ProcessTask auto-debits a single EMI from the account the loan was disbursed into
+func (processor *CollectLoanEmiTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[CollectLoanEmiTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	installmentID, err := uuid.Parse(payload.Data.InstallmentID)
+	if err != nil {
+		return fmt.Errorf("Invalid installmentID: %s in payload for task: %s, error: %v", payload.Data.InstallmentID, t.Name(), err)
+	}
+
+	collectionDate, err := time.Parse(time.DateOnly, payload.Data.CollectionDate)
+	if err != nil {
+		return fmt.Errorf("Invalid collectionDate: %s in payload for task: %s, error: %v", payload.Data.CollectionDate, t.Name(), err)
+	}
+
+	var installment *model.Installment
+	err = database.RunInTransaction(ctx, "collectLoanEmi", processor.services.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		installment, err = processor.services.LoanService.CollectEmi(txCtx, tx, installmentID, collectionDate)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if installment == nil {
+		logger.Info(ctx, "EMI with installmentID: %s is not due for collection", installmentID)
+		return nil
+	}
+
+	logger.Info(ctx, "Collection attempt %d for EMI with installmentID: %s finished with status: %s", installment.AttemptCount, installment.ID, installment.Status)
+	return nil
+}
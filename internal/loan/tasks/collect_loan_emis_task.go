@@ -0,0 +1,126 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/internal/loan/model"
+	"github.com/skamranahmed/go-bank/internal/loan/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+)
+
+const CollectLoanEmisTaskName string = "periodic_task:collect_loan_emis"
+
+// number of due EMIs fetched from the database in one go
+const collectLoanEmisBatchSize int = 100
+
+type CollectLoanEmisTaskPayload struct {
+}
+
+type CollectLoanEmisTask struct {
+	name          string
+	queue         string
+	cronSpec      string
+	maxRetryCount int
+	payload       CollectLoanEmisTaskPayload
+}
+
+func NewCollectLoanEmisTask() tasksHelper.SchedulableTask {
+	return &CollectLoanEmisTask{
+		name:          CollectLoanEmisTaskName,
+		queue:         tasksHelper.DefaultQueue,
+		cronSpec:      "30 6 * * *", // run every day at 06:30
+		maxRetryCount: 3,
+		payload:       CollectLoanEmisTaskPayload{},
+	}
+}
+
+func (t *CollectLoanEmisTask) Name() string {
+	return t.name
+}
+
+func (t *CollectLoanEmisTask) Queue() string {
+	return t.queue
+}
+
+func (t *CollectLoanEmisTask) CronSpec() string {
+	return t.cronSpec
+}
+
+func (t *CollectLoanEmisTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *CollectLoanEmisTask) Payload() any {
+	return t.payload
+}
+
+type CollectLoanEmisTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewCollectLoanEmisTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &CollectLoanEmisTaskProcessor{
+		services: services,
+	}
+}
+
+/*
+ProcessTask enqueues a collection task for every pending EMI that is due, including the ones
+that could not be collected on earlier days for lack of funds
+
+An EMI that was already attempted today is skipped by the collection itself, so retrying this task is safe.
+*/
+func (processor *CollectLoanEmisTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[CollectLoanEmisTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	now := time.Now().UTC()
+	collectionDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	pendingStatus := model.InstallmentPending
+
+	var afterID *uuid.UUID
+	var failedCount, enqueuedCount int
+	for {
+		installments, err := processor.services.LoanService.ListInstallments(ctx, nil, types.InstallmentListOptions{
+			Status:            &pendingStatus,
+			DueDateOnOrBefore: &collectionDate,
+			AfterID:           afterID,
+			Limit:             collectLoanEmisBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, installment := range installments {
+			err := processor.services.TaskEnqueuer.Enqueue(ctx, NewCollectLoanEmiTask(installment.ID.String(), collectionDate.Format(time.DateOnly)), nil, nil)
+			if err != nil {
+				failedCount++
+				logger.Error(ctx, "Unable to enqueue CollectLoanEmiTask for installmentID: %s, error: %+v", installment.ID, err)
+				continue
+			}
+			enqueuedCount++
+		}
+
+		if len(installments) < collectLoanEmisBatchSize {
+			break
+		}
+		afterID = &installments[len(installments)-1].ID
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf("Unable to enqueue collection of %d EMI(s)", failedCount)
+	}
+
+	logger.Info(ctx, "Collection enqueued for %d EMI(s)", enqueuedCount)
+	return nil
+}
@@ -0,0 +1,151 @@
+package types
+
+import (
+	"time"
+
+	"github.com/skamranahmed/go-bank/internal/loan/model"
+)
+
+type ApplyForLoanRequest struct {
+	Data ApplyForLoanRequestData `json:"data" binding:"required"`
+}
+
+type ApplyForLoanRequestData struct {
+	AccountID      int64  `json:"account_id" binding:"required,account_number"`
+	Amount         *int64 `json:"amount" binding:"required,gt=0"`
+	TenureInMonths *int   `json:"tenure_in_months" binding:"required,gte=3,lte=84"`
+}
+
+type RejectLoanRequest struct {
+	Data RejectLoanRequestData `json:"data" binding:"required"`
+}
+
+type RejectLoanRequestData struct {
+	Reason string `json:"reason" binding:"required,min=1"`
+}
+
+type PrepayLoanRequest struct {
+	Data PrepayLoanRequestData `json:"data" binding:"required"`
+}
+
+type PrepayLoanRequestData struct {
+	Amount *int64 `json:"amount" binding:"required,gt=0"`
+}
+
+type GetLoansRequestQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=PENDING_APPROVAL REJECTED ACTIVE CLOSED"`
+}
+
+type LoanDto struct {
+	ID                              string           `json:"id"`
+	CreatedAt                       time.Time        `json:"created_at"`
+	UserID                          string           `json:"user_id"`
+	AccountID                       int64            `json:"account_id"`
+	PrincipalAmount                 int64            `json:"principal_amount"`
+	AnnualInterestRateInBasisPoints int64            `json:"annual_interest_rate_in_basis_points"`
+	TenureInMonths                  int              `json:"tenure_in_months"`
+	EmiAmount                       int64            `json:"emi_amount"`
+	OutstandingPrincipal            int64            `json:"outstanding_principal"`
+	Status                          model.LoanStatus `json:"status"`
+	ReviewedAt                      *time.Time       `json:"reviewed_at"`
+	RejectionReason                 *string          `json:"rejection_reason"`
+	DisbursedAt                     *time.Time       `json:"disbursed_at"`
+	ClosedAt                        *time.Time       `json:"closed_at"`
+}
+
+type InstallmentDto struct {
+	ID                 string                  `json:"id"`
+	InstallmentNumber  int                     `json:"installment_number"`
+	DueDate            string                  `json:"due_date"`
+	OpeningPrincipal   int64                   `json:"opening_principal"`
+	PrincipalComponent int64                   `json:"principal_component"`
+	InterestComponent  int64                   `json:"interest_component"`
+	EmiAmount          int64                   `json:"emi_amount"`
+	Status             model.InstallmentStatus `json:"status"`
+	AttemptCount       int                     `json:"attempt_count"`
+	LateFeeChargedAt   *time.Time              `json:"late_fee_charged_at"`
+	PaidAt             *time.Time              `json:"paid_at"`
+	TransactionID      *string                 `json:"transaction_id"`
+}
+
+type ApplyForLoanResponse struct {
+	Data LoanDto `json:"data"`
+}
+
+type GetLoansResponse struct {
+	Data []LoanDto `json:"data"`
+}
+
+type GetLoanResponse struct {
+	Data LoanDto `json:"data"`
+}
+
+type GetLoanScheduleResponse struct {
+	Data []InstallmentDto `json:"data"`
+}
+
+type ReviewLoanResponse struct {
+	Data LoanDto `json:"data"`
+}
+
+type RepayLoanResponse struct {
+	Data LoanDto `json:"data"`
+}
+
+func TransformToLoanDto(loan *model.Loan) *LoanDto {
+	return &LoanDto{
+		ID:                              loan.ID.String(),
+		CreatedAt:                       loan.CreatedAt,
+		UserID:                          loan.UserID.String(),
+		AccountID:                       loan.AccountID,
+		PrincipalAmount:                 loan.PrincipalAmount,
+		AnnualInterestRateInBasisPoints: loan.AnnualInterestRateInBasisPoints,
+		TenureInMonths:                  loan.TenureInMonths,
+		EmiAmount:                       loan.EmiAmount,
+		OutstandingPrincipal:            loan.OutstandingPrincipal,
+		Status:                          loan.Status,
+		ReviewedAt:                      loan.ReviewedAt,
+		RejectionReason:                 loan.RejectionReason,
+		DisbursedAt:                     loan.DisbursedAt,
+		ClosedAt:                        loan.ClosedAt,
+	}
+}
+
+func TransformToLoanDtoList(loans []model.Loan) []LoanDto {
+	loanDtos := make([]LoanDto, 0, len(loans))
+	for _, loan := range loans {
+		loanDtos = append(loanDtos, *TransformToLoanDto(&loan))
+	}
+	return loanDtos
+}
+
+func TransformToInstallmentDto(installment *model.Installment) *InstallmentDto {
+	var transactionID *string
+	if installment.TransactionID != nil {
+		id := installment.TransactionID.String()
+		transactionID = &id
+	}
+
+	return &InstallmentDto{
+		ID:                 installment.ID.String(),
+		InstallmentNumber:  installment.InstallmentNumber,
+		DueDate:            installment.DueDate.Format(time.DateOnly),
+		OpeningPrincipal:   installment.OpeningPrincipal,
+		PrincipalComponent: installment.PrincipalComponent,
+		InterestComponent:  installment.InterestComponent,
+		EmiAmount:          installment.EmiAmount(),
+		Status:             installment.Status,
+		AttemptCount:       installment.AttemptCount,
+		LateFeeChargedAt:   installment.LateFeeChargedAt,
+		PaidAt:             installment.PaidAt,
+		TransactionID:      transactionID,
+	}
+}
+
+func TransformToInstallmentDtoList(installments []model.Installment) []InstallmentDto {
+	installmentDtos := make([]InstallmentDto, 0, len(installments))
+	for _, installment := range installments {
+		installmentDtos = append(installmentDtos, *TransformToInstallmentDto(&installment))
+	}
+	return installmentDtos
+}
@@ -0,0 +1,65 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/loan/model"
+)
+
+type LoanQueryOptions struct {
+	ID *uuid.UUID
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type LoanListOptions struct {
+	UserID *uuid.UUID
+	Status *model.LoanStatus
+}
+
+type LoanUpdateOptions struct {
+	NewEmiAmount            *int64
+	NewOutstandingPrincipal *int64
+	NewStatus               *model.LoanStatus
+	NewReviewedBy           *uuid.UUID
+	NewReviewedAt           *time.Time
+	NewRejectionReason      *string
+	NewDisbursedAt          *time.Time
+	NewClosedAt             *time.Time
+}
+
+type InstallmentQueryOptions struct {
+	ID *uuid.UUID
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type InstallmentListOptions struct {
+	LoanID *uuid.UUID
+	Status *model.InstallmentStatus
+
+	// When set, only installments due on or before this date are returned
+	DueDateOnOrBefore *time.Time
+
+	// When true, the query will lock the selected rows for update
+	ForUpdate bool
+
+	// keyset pagination: only installments with an ID greater than AfterID are returned, ordered by ID
+	AfterID *uuid.UUID
+	Limit   int
+}
+
+type InstallmentUpdateOptions struct {
+	NewOpeningPrincipal   *int64
+	NewPrincipalComponent *int64
+	NewInterestComponent  *int64
+	NewStatus             *model.InstallmentStatus
+	NewAttemptCount       *int
+	NewLastAttemptedAt    *time.Time
+	NewLateFeeChargedAt   *time.Time
+	NewPaidAt             *time.Time
+	NewTransactionID      *uuid.UUID
+}
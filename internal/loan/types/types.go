@@ -0,0 +1,10 @@
+package types
+
+import "github.com/google/uuid"
+
+type ApplyForLoanParams struct {
+	UserID         uuid.UUID
+	AccountID      int64
+	Amount         int64
+	TenureInMonths int
+}
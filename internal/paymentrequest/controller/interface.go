@@ -0,0 +1,13 @@
+package controller
+
+import "github.com/gin-gonic/gin"
+
+type PaymentRequestController interface {
+	CreatePaymentRequest(ginCtx *gin.Context)
+	GetPaymentRequests(ginCtx *gin.Context)
+	GetPaymentRequestByID(ginCtx *gin.Context)
+	CancelPaymentRequest(ginCtx *gin.Context)
+
+	GetPaymentLink(ginCtx *gin.Context)
+	PayPaymentLink(ginCtx *gin.Context)
+}
@@ -0,0 +1,310 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/middleware"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/paymentrequest/model"
+	paymentRequestService "github.com/skamranahmed/go-bank/internal/paymentrequest/service"
+	paymentRequestTasks "github.com/skamranahmed/go-bank/internal/paymentrequest/tasks"
+	"github.com/skamranahmed/go-bank/internal/paymentrequest/types"
+	userService "github.com/skamranahmed/go-bank/internal/user/service"
+	userTypes "github.com/skamranahmed/go-bank/internal/user/types"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/uptrace/bun"
+)
+
+type paymentRequestController struct {
+	db                    *bun.DB
+	paymentRequestService paymentRequestService.PaymentRequestService
+	userService           userService.UserService
+	taskEnqueuer          tasksHelper.TaskEnqueuer
+}
+
+func newPaymentRequestController(dependency Dependency) PaymentRequestController {
+	return &paymentRequestController{
+		db:                    dependency.Db,
+		paymentRequestService: dependency.PaymentRequestService,
+		userService:           dependency.UserService,
+		taskEnqueuer:          dependency.TaskEnqueuer,
+	}
+}
+
+func (c *paymentRequestController) CreatePaymentRequest(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.CreatePaymentRequestRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	paymentRequest, err := c.paymentRequestService.CreatePaymentRequest(requestCtx, nil, types.CreatePaymentRequestParams{
+		RequesterUserID: userUUID,
+		AccountID:       payload.Data.AccountID,
+		Amount:          *payload.Data.Amount,
+		Note:            payload.Data.Note,
+		ExpiresInHours:  payload.Data.ExpiresInHours,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	paymentRequestDto := types.TransformToPaymentRequestDto(paymentRequest)
+	server.SendSuccessResponse(ginCtx, http.StatusCreated, types.PaymentRequestResponse{
+		Data: *paymentRequestDto,
+	})
+}
+
+func (c *paymentRequestController) GetPaymentRequests(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var query types.GetPaymentRequestsRequestQuery
+	isSuccess := server.BindAndValidateIncomingRequestQuery(ginCtx, &query)
+	if !isSuccess {
+		return
+	}
+
+	options := types.PaymentRequestListOptions{
+		RequesterUserID: &userUUID,
+	}
+	if query.Status != "" {
+		status := model.PaymentRequestStatus(query.Status)
+		options.Status = &status
+	}
+
+	paymentRequests, err := c.paymentRequestService.ListPaymentRequests(requestCtx, nil, options)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	paymentRequestDtos := types.TransformToPaymentRequestDtoList(paymentRequests)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetPaymentRequestsResponse{
+		Data: paymentRequestDtos,
+	})
+}
+
+func (c *paymentRequestController) GetPaymentRequestByID(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	paymentRequestID, ok := getPaymentRequestID(ginCtx)
+	if !ok {
+		return
+	}
+
+	paymentRequest, err := c.paymentRequestService.GetPaymentRequest(requestCtx, nil, types.PaymentRequestQueryOptions{
+		ID: &paymentRequestID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// authorization check: verify payment request belongs to authenticated user
+	if paymentRequest.RequesterUserID != userUUID {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You do not have permission to access this payment request",
+		})
+		return
+	}
+
+	// transform to DTO and return response
+	paymentRequestDto := types.TransformToPaymentRequestDto(paymentRequest)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.PaymentRequestResponse{
+		Data: *paymentRequestDto,
+	})
+}
+
+func (c *paymentRequestController) CancelPaymentRequest(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	paymentRequestID, ok := getPaymentRequestID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var paymentRequest *model.PaymentRequest
+	err := database.RunInTransaction(requestCtx, "cancelPaymentRequest", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		paymentRequest, err = c.paymentRequestService.CancelPaymentRequest(txCtx, tx, types.CancelPaymentRequestParams{
+			PaymentRequestID: paymentRequestID,
+			RequesterUserID:  userUUID,
+		})
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	paymentRequestDto := types.TransformToPaymentRequestDto(paymentRequest)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.PaymentRequestResponse{
+		Data: *paymentRequestDto,
+	})
+}
+
+func (c *paymentRequestController) GetPaymentLink(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	_, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	token := ginCtx.Param("token")
+	paymentRequest, err := c.paymentRequestService.GetPaymentRequest(requestCtx, nil, types.PaymentRequestQueryOptions{
+		Token: &token,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	requesterName, ok := c.getRequesterName(ginCtx, paymentRequest)
+	if !ok {
+		return
+	}
+
+	// transform to DTO and return response
+	paymentLinkDto := types.TransformToPaymentLinkDto(paymentRequest, requesterName)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetPaymentLinkResponse{
+		Data: *paymentLinkDto,
+	})
+}
+
+func (c *paymentRequestController) PayPaymentLink(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.PayPaymentRequestRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	// the transfer and the update of the paid amount must succeed or fail together
+	var payment *model.PaymentRequestPayment
+	err := database.RunInTransaction(requestCtx, "payPaymentRequest", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		payment, err = c.paymentRequestService.PayPaymentRequest(txCtx, tx, types.PayPaymentRequestParams{
+			Token:         ginCtx.Param("token"),
+			PayerUserID:   userUUID,
+			FromAccountID: payload.Data.FromAccountID,
+			Amount:        payload.Data.Amount,
+		})
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// let the requester know that they have been paid
+	paymentRequest := payment.PaymentRequest
+	err = c.taskEnqueuer.Enqueue(requestCtx, paymentRequestTasks.NewSendPaymentRequestPaidNotificationTask(paymentRequest.ID.String(), payment.ID.String(), paymentRequest.RequesterUserID.String()), nil, nil)
+	if err != nil {
+		logger.Error(requestCtx, "Unable to enqueue SendPaymentRequestPaidNotificationTask for paymentRequestID: %s, error: %+v", paymentRequest.ID, err)
+	}
+
+	requesterName, ok := c.getRequesterName(ginCtx, paymentRequest)
+	if !ok {
+		return
+	}
+
+	// transform to DTO and return response
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.PayPaymentRequestResponse{
+		Data: types.PayPaymentRequestResponseData{
+			PaymentRequest: *types.TransformToPaymentLinkDto(paymentRequest, requesterName),
+			Transaction:    *accountTypes.TransformToTransactionDto(payment.Transaction),
+		},
+	})
+}
+
+// getRequesterName looks up the masked name of the requester shown to the payers, sending the error response when it fails
+func (c *paymentRequestController) getRequesterName(ginCtx *gin.Context, paymentRequest *model.PaymentRequest) (string, bool) {
+	requesterUserID := paymentRequest.RequesterUserID.String()
+	requester, err := c.userService.GetUser(ginCtx.Request.Context(), nil, userTypes.UserQueryOptions{
+		ID:      &requesterUserID,
+		Columns: []string{"username", "first_name", "last_name"},
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return "", false
+	}
+
+	return requester.MaskedName(), true
+}
+
+// getPaymentRequestID parses the ID of the payment request referenced in the path, sending the error response when it is invalid
+func getPaymentRequestID(ginCtx *gin.Context) (uuid.UUID, bool) {
+	paymentRequestID, err := uuid.Parse(ginCtx.Param("payment_request_id"))
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid payment request ID",
+		})
+		return uuid.Nil, false
+	}
+
+	return paymentRequestID, true
+}
+
+// getAuthenticatedUserID extracts the ID of the authenticated user from the request context, sending the error response when it is missing
+func getAuthenticatedUserID(ginCtx *gin.Context) (uuid.UUID, bool) {
+	userID, ok := ginCtx.Request.Context().Value(middleware.ContextUserIDKey).(string)
+	if !ok || userID == "" {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusUnauthorized,
+			Message:        "User not authenticated",
+		})
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid user ID",
+		})
+		return uuid.Nil, false
+	}
+
+	return userUUID, true
+}
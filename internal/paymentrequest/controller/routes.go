@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skamranahmed/go-bank/cmd/middleware"
+	authenticationService "github.com/skamranahmed/go-bank/internal/authentication/service"
+	paymentRequestService "github.com/skamranahmed/go-bank/internal/paymentrequest/service"
+	userService "github.com/skamranahmed/go-bank/internal/user/service"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/uptrace/bun"
+)
+
+type Dependency struct {
+	Db                    *bun.DB
+	AuthenticationService authenticationService.AuthenticationService
+	PaymentRequestService paymentRequestService.PaymentRequestService
+	UserService           userService.UserService
+	TaskEnqueuer          tasksHelper.TaskEnqueuer
+}
+
+func Register(router *gin.Engine, dependency Dependency) {
+	paymentRequestController := newPaymentRequestController(dependency)
+	router.POST("/v1/payment-requests", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), paymentRequestController.CreatePaymentRequest)
+	router.GET("/v1/payment-requests", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), paymentRequestController.GetPaymentRequests)
+	router.GET("/v1/payment-requests/:payment_request_id", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), paymentRequestController.GetPaymentRequestByID)
+	router.POST("/v1/payment-requests/:payment_request_id/cancel", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), paymentRequestController.CancelPaymentRequest)
+
+	// the links shared with the payers, a payment request is referenced by its token instead of its ID there
+	router.GET("/v1/payment-links/:token", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), paymentRequestController.GetPaymentLink)
+	router.POST("/v1/payment-links/:token/pay", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), paymentRequestController.PayPaymentLink)
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/uptrace/bun"
+)
+
+// PaymentRequest is a request for money shared as a link, any other user holding the link can pay it, in one or more payments
+type PaymentRequest struct {
+	bun.BaseModel `bun:"table:payment_requests"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "users" table, the user requesting the money
+	RequesterUserID uuid.UUID       `bun:"requester_user_id,notnull,type:uuid"`
+	RequesterUser   *userModel.User `bun:"rel:belongs-to,join:requester_user_id=id"`
+
+	// foreign key to "accounts" table, the account of the requester the payments are credited to
+	AccountID int64                 `bun:"account_id,notnull"`
+	Account   *accountModel.Account `bun:"rel:belongs-to,join:account_id=id"`
+
+	// Token identifies the request in the shareable link, it is random so that the links cannot be guessed
+	Token string `bun:"token,notnull,unique,type:varchar(32)"`
+
+	// Amount and PaidAmount are stored in the smallest currency unit (paise for INR)
+	Amount     int64   `bun:"amount,notnull"`
+	PaidAmount int64   `bun:"paid_amount,notnull,default:0"`
+	Note       *string `bun:"note,type:varchar(140)"`
+
+	Status PaymentRequestStatus `bun:"status,notnull,default:'PENDING'"`
+
+	// ExpiresAt is the time the request can no longer be paid at, it is then marked expired by the worker
+	ExpiresAt time.Time `bun:"expires_at,notnull"`
+
+	// ClosedAt is the time the request was fully paid, cancelled or expired at
+	ClosedAt *time.Time `bun:"closed_at"`
+}
+
+// RemainingAmount returns the amount that is still to be paid
+func (p *PaymentRequest) RemainingAmount() int64 {
+	return p.Amount - p.PaidAmount
+}
+
+// IsOpen reports whether the request is waiting for payments, either none or only a part of the amount has been paid
+func (p *PaymentRequest) IsOpen() bool {
+	return p.Status == PaymentRequestPending || p.Status == PaymentRequestPartiallyPaid
+}
+
+// IsExpired reports whether the request can no longer be paid, even if the worker has not marked it expired yet
+func (p *PaymentRequest) IsExpired(at time.Time) bool {
+	return !at.Before(p.ExpiresAt)
+}
+
+type PaymentRequestStatus string
+
+const (
+	PaymentRequestPending       PaymentRequestStatus = "PENDING"
+	PaymentRequestPartiallyPaid PaymentRequestStatus = "PARTIALLY_PAID" // a part of the amount has been paid, the rest can still be paid
+	PaymentRequestPaid          PaymentRequestStatus = "PAID"
+	PaymentRequestCancelled     PaymentRequestStatus = "CANCELLED" // cancelled by the requester, the payments made before stay with them
+	PaymentRequestExpired       PaymentRequestStatus = "EXPIRED"   // the request was not fully paid in time
+)
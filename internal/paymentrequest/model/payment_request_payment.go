@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/uptrace/bun"
+)
+
+// PaymentRequestPayment records a payment made towards a payment request, a request can be paid in several parts
+type PaymentRequestPayment struct {
+	bun.BaseModel `bun:"table:payment_request_payments"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+
+	// foreign key to "payment_requests" table
+	PaymentRequestID uuid.UUID       `bun:"payment_request_id,notnull,type:uuid"`
+	PaymentRequest   *PaymentRequest `bun:"rel:belongs-to,join:payment_request_id=id"`
+
+	// foreign key to "users" table, the user who paid
+	PayerUserID uuid.UUID       `bun:"payer_user_id,notnull,type:uuid"`
+	PayerUser   *userModel.User `bun:"rel:belongs-to,join:payer_user_id=id"`
+
+	// Amount is stored in the smallest currency unit (paise for INR)
+	Amount int64 `bun:"amount,notnull"`
+
+	// foreign key to "transactions" table, the debit transaction of the account the payment was made from
+	TransactionID uuid.UUID                 `bun:"transaction_id,notnull,type:uuid"`
+	Transaction   *accountModel.Transaction `bun:"rel:belongs-to,join:transaction_id=id"`
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/paymentrequest/model"
+	"github.com/skamranahmed/go-bank/internal/paymentrequest/types"
+	"github.com/uptrace/bun"
+)
+
+type PaymentRequestRepository interface {
+	CreatePaymentRequest(requestCtx context.Context, dbExecutor bun.IDB, paymentRequest *model.PaymentRequest) (*model.PaymentRequest, error)
+	GetPaymentRequest(requestCtx context.Context, dbExecutor bun.IDB, options types.PaymentRequestQueryOptions) (*model.PaymentRequest, error)
+	ListPaymentRequests(requestCtx context.Context, dbExecutor bun.IDB, options types.PaymentRequestListOptions) ([]model.PaymentRequest, error)
+	UpdatePaymentRequest(requestCtx context.Context, dbExecutor bun.IDB, paymentRequestID uuid.UUID, options types.PaymentRequestUpdateOptions) (*model.PaymentRequest, error)
+	ExpirePaymentRequests(requestCtx context.Context, dbExecutor bun.IDB, expiryTime time.Time) ([]model.PaymentRequest, error)
+
+	CreatePaymentRequestPayment(requestCtx context.Context, dbExecutor bun.IDB, payment *model.PaymentRequestPayment) (*model.PaymentRequestPayment, error)
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/paymentrequest/model"
+	"github.com/skamranahmed/go-bank/internal/paymentrequest/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+type paymentRequestRepository struct {
+	db *bun.DB
+}
+
+func NewPaymentRequestRepository(db *bun.DB) PaymentRequestRepository {
+	return &paymentRequestRepository{
+		db: db,
+	}
+}
+
+func (r *paymentRequestRepository) CreatePaymentRequest(requestCtx context.Context, dbExecutor bun.IDB, paymentRequest *model.PaymentRequest) (*model.PaymentRequest, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewInsert().
+		Model(paymentRequest).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating payment request for requesterUserID: %s, error: %+v", paymentRequest.RequesterUserID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't create the payment request at the moment. Please try again later.",
+		}
+	}
+
+	return paymentRequest, nil
+}
+
+func (r *paymentRequestRepository) GetPaymentRequest(requestCtx context.Context, dbExecutor bun.IDB, options types.PaymentRequestQueryOptions) (*model.PaymentRequest, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var paymentRequest model.PaymentRequest
+	query := dbExecutor.NewSelect().Model(&paymentRequest)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+	if options.Token != nil {
+		query = query.Where("token = ?", *options.Token)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Payment request not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding payment request with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the payment request at the moment. Please try again later.",
+		}
+	}
+
+	return &paymentRequest, nil
+}
+
+func (r *paymentRequestRepository) ListPaymentRequests(requestCtx context.Context, dbExecutor bun.IDB, options types.PaymentRequestListOptions) ([]model.PaymentRequest, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var paymentRequests []model.PaymentRequest
+	query := dbExecutor.NewSelect().Model(&paymentRequests)
+
+	// dynamically construct the query based on which fields are set
+	if options.RequesterUserID != nil {
+		query = query.Where("requester_user_id = ?", *options.RequesterUserID)
+	}
+	if options.Status != nil {
+		query = query.Where("status = ?", *options.Status)
+	}
+
+	err := query.Order("created_at DESC").Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing payment requests with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the payment requests at the moment. Please try again later.",
+		}
+	}
+
+	return paymentRequests, nil
+}
+
+func (r *paymentRequestRepository) UpdatePaymentRequest(requestCtx context.Context, dbExecutor bun.IDB, paymentRequestID uuid.UUID, options types.PaymentRequestUpdateOptions) (*model.PaymentRequest, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var paymentRequest model.PaymentRequest
+	query := dbExecutor.NewUpdate().Model(&paymentRequest)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewStatus != nil {
+		query = query.Set("status = ?", *options.NewStatus)
+	}
+	if options.NewPaidAmount != nil {
+		query = query.Set("paid_amount = ?", *options.NewPaidAmount)
+	}
+	if options.NewClosedAt != nil {
+		query = query.Set("closed_at = ?", *options.NewClosedAt)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", paymentRequestID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating payment request with ID: %s, error: %+v", paymentRequestID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the payment request at the moment. Please try again later.",
+		}
+	}
+
+	return &paymentRequest, nil
+}
+
+// ExpirePaymentRequests marks every open payment request whose expiry time has passed as expired, in a single statement
+func (r *paymentRequestRepository) ExpirePaymentRequests(requestCtx context.Context, dbExecutor bun.IDB, expiryTime time.Time) ([]model.PaymentRequest, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var paymentRequests []model.PaymentRequest
+	_, err := dbExecutor.NewUpdate().
+		Model((*model.PaymentRequest)(nil)).
+		Set("status = ?", model.PaymentRequestExpired).
+		Set("closed_at = ?", expiryTime).
+		Set("updated_at = NOW()").
+		Where("status IN (?)", bun.In([]model.PaymentRequestStatus{model.PaymentRequestPending, model.PaymentRequestPartiallyPaid})).
+		Where("expires_at <= ?", expiryTime).
+		Returning("*").
+		Exec(requestCtx, &paymentRequests)
+	if err != nil {
+		logger.Error(requestCtx, "Error while expiring payment requests due by: %s, error: %+v", expiryTime, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't expire the payment requests at the moment. Please try again later.",
+		}
+	}
+
+	return paymentRequests, nil
+}
+
+func (r *paymentRequestRepository) CreatePaymentRequestPayment(requestCtx context.Context, dbExecutor bun.IDB, payment *model.PaymentRequestPayment) (*model.PaymentRequestPayment, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewInsert().
+		Model(payment).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while recording payment for paymentRequestID: %s, error: %+v", payment.PaymentRequestID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't pay the payment request at the moment. Please try again later.",
+		}
+	}
+
+	return payment, nil
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/skamranahmed/go-bank/internal/paymentrequest/model"
+	"github.com/skamranahmed/go-bank/internal/paymentrequest/types"
+	"github.com/uptrace/bun"
+)
+
+type PaymentRequestService interface {
+	CreatePaymentRequest(requestCtx context.Context, dbExecutor bun.IDB, params types.CreatePaymentRequestParams) (*model.PaymentRequest, error)
+	GetPaymentRequest(requestCtx context.Context, dbExecutor bun.IDB, options types.PaymentRequestQueryOptions) (*model.PaymentRequest, error)
+	ListPaymentRequests(requestCtx context.Context, dbExecutor bun.IDB, options types.PaymentRequestListOptions) ([]model.PaymentRequest, error)
+	PayPaymentRequest(requestCtx context.Context, dbExecutor bun.IDB, params types.PayPaymentRequestParams) (*model.PaymentRequestPayment, error)
+	CancelPaymentRequest(requestCtx context.Context, dbExecutor bun.IDB, params types.CancelPaymentRequestParams) (*model.PaymentRequest, error)
+	ExpirePaymentRequests(requestCtx context.Context, dbExecutor bun.IDB, expiryTime time.Time) ([]model.PaymentRequest, error)
+}
@@ -0,0 +1,351 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/config"
+	accountService "github.com/skamranahmed/go-bank/internal/account/service"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/paymentrequest/model"
+	"github.com/skamranahmed/go-bank/internal/paymentrequest/repository"
+	"github.com/skamranahmed/go-bank/internal/paymentrequest/types"
+	transferService "github.com/skamranahmed/go-bank/internal/transfer/service"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+// paymentRequestTokenLength is the number of random bytes in the token of a payment request, before it is encoded
+const paymentRequestTokenLength = 16
+
+type paymentRequestService struct {
+	db                       *bun.DB
+	paymentRequestRepository repository.PaymentRequestRepository
+	accountService           accountService.AccountService
+	transferService          transferService.TransferService
+	paymentRequestConfig     config.PaymentRequestConfig
+}
+
+func NewPaymentRequestService(
+	db *bun.DB,
+	paymentRequestRepository repository.PaymentRequestRepository,
+	accountService accountService.AccountService,
+	transferService transferService.TransferService,
+	paymentRequestConfig config.PaymentRequestConfig,
+) PaymentRequestService {
+	return &paymentRequestService{
+		db:                       db,
+		paymentRequestRepository: paymentRequestRepository,
+		accountService:           accountService,
+		transferService:          transferService,
+		paymentRequestConfig:     paymentRequestConfig,
+	}
+}
+
+/*
+CreatePaymentRequest creates a request for the amount to be paid into the given account of the requester
+
+The request is identified by a random token, which is shared as a link with the users asked to pay it.
+It can be paid until it expires, after the given number of hours or the configured default.
+*/
+func (s *paymentRequestService) CreatePaymentRequest(requestCtx context.Context, dbExecutor bun.IDB, params types.CreatePaymentRequestParams) (*model.PaymentRequest, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	expiresInHours := s.paymentRequestConfig.DefaultExpiryInHours
+	if params.ExpiresInHours != nil {
+		expiresInHours = *params.ExpiresInHours
+	}
+	if expiresInHours > s.paymentRequestConfig.MaxExpiryInHours {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("A payment request can expire at most %d hours from now", s.paymentRequestConfig.MaxExpiryInHours),
+		}
+	}
+
+	account, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &params.AccountID,
+		Columns:   []string{"id", "user_id", "type"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	isAccountHolder, err := s.accountService.IsAccountHolder(requestCtx, dbExecutor, account, params.RequesterUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAccountHolder {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You can only request money into your own account",
+		}
+	}
+
+	if !account.Type.AllowsTransfers() {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Only a savings or current account can receive payment requests",
+		}
+	}
+
+	token, err := generatePaymentRequestToken()
+	if err != nil {
+		logger.Error(requestCtx, "Error while generating payment request token for requesterUserID: %s, error: %+v", params.RequesterUserID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't create the payment request at the moment. Please try again later.",
+		}
+	}
+
+	var note *string
+	if params.Note != "" {
+		note = &params.Note
+	}
+
+	return s.paymentRequestRepository.CreatePaymentRequest(requestCtx, dbExecutor, &model.PaymentRequest{
+		RequesterUserID: params.RequesterUserID,
+		AccountID:       params.AccountID,
+		Token:           token,
+		Amount:          params.Amount,
+		Note:            note,
+		Status:          model.PaymentRequestPending,
+		ExpiresAt:       time.Now().UTC().Add(time.Duration(expiresInHours) * time.Hour),
+	})
+}
+
+func (s *paymentRequestService) GetPaymentRequest(requestCtx context.Context, dbExecutor bun.IDB, options types.PaymentRequestQueryOptions) (*model.PaymentRequest, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.paymentRequestRepository.GetPaymentRequest(requestCtx, dbExecutor, options)
+}
+
+func (s *paymentRequestService) ListPaymentRequests(requestCtx context.Context, dbExecutor bun.IDB, options types.PaymentRequestListOptions) ([]model.PaymentRequest, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.paymentRequestRepository.ListPaymentRequests(requestCtx, dbExecutor, options)
+}
+
+/*
+PayPaymentRequest pays the given amount, or else the whole remaining amount, of the payment request from an account of the payer
+
+A request can be paid in several parts by any user other than the requester, it is marked paid once nothing remains.
+It must be called within a database transaction because it locks the payment request row for update,
+so that concurrent payments cannot pay more than the requested amount.
+The returned payment carries the updated payment request and the debit transaction of the payer.
+*/
+func (s *paymentRequestService) PayPaymentRequest(requestCtx context.Context, dbExecutor bun.IDB, params types.PayPaymentRequestParams) (*model.PaymentRequestPayment, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	paymentRequest, err := s.paymentRequestRepository.GetPaymentRequest(requestCtx, dbExecutor, types.PaymentRequestQueryOptions{
+		Token:     &params.Token,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if paymentRequest.RequesterUserID == params.PayerUserID {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "You cannot pay your own payment request",
+		}
+	}
+
+	now := time.Now().UTC()
+	err = verifyPaymentRequestIsOpen(paymentRequest, now)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := paymentRequest.RemainingAmount()
+	if params.Amount != nil {
+		amount = *params.Amount
+	}
+	if amount > paymentRequest.RemainingAmount() {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("Amount cannot exceed the remaining amount of %d", paymentRequest.RemainingAmount()),
+		}
+	}
+
+	err = s.verifyPayingAccount(requestCtx, dbExecutor, paymentRequest, params.PayerUserID, params.FromAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	narration := "Payment request"
+	if paymentRequest.Note != nil {
+		narration = *paymentRequest.Note
+	}
+	clientReference := strings.ReplaceAll(paymentRequest.ID.String(), "-", "")
+
+	transaction, err := s.transferService.CreateInternalTransfer(
+		requestCtx,
+		dbExecutor,
+		params.PayerUserID,
+		params.FromAccountID,
+		paymentRequest.AccountID,
+		amount,
+		accountTypes.Remittance{
+			Narration:       &narration,
+			ClientReference: &clientReference,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := s.paymentRequestRepository.CreatePaymentRequestPayment(requestCtx, dbExecutor, &model.PaymentRequestPayment{
+		PaymentRequestID: paymentRequest.ID,
+		PayerUserID:      params.PayerUserID,
+		Amount:           amount,
+		TransactionID:    transaction.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	paidAmount := paymentRequest.PaidAmount + amount
+	updateOptions := types.PaymentRequestUpdateOptions{
+		NewPaidAmount: &paidAmount,
+	}
+	if paidAmount == paymentRequest.Amount {
+		paidStatus := model.PaymentRequestPaid
+		updateOptions.NewStatus = &paidStatus
+		updateOptions.NewClosedAt = &now
+	} else {
+		partiallyPaidStatus := model.PaymentRequestPartiallyPaid
+		updateOptions.NewStatus = &partiallyPaidStatus
+	}
+
+	paymentRequest, err = s.paymentRequestRepository.UpdatePaymentRequest(requestCtx, dbExecutor, paymentRequest.ID, updateOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	payment.PaymentRequest = paymentRequest
+	payment.Transaction = transaction
+	return payment, nil
+}
+
+// verifyPayingAccount verifies that the payment request can be paid from the account, a savings or current account of the payer
+func (s *paymentRequestService) verifyPayingAccount(requestCtx context.Context, dbExecutor bun.IDB, paymentRequest *model.PaymentRequest, payerUserID uuid.UUID, fromAccountID int64) error {
+	fromAccount, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &fromAccountID,
+		Columns:   []string{"id", "user_id", "type"},
+	})
+	if err != nil {
+		return err
+	}
+
+	isAccountHolder, err := s.accountService.IsAccountHolder(requestCtx, dbExecutor, fromAccount, payerUserID)
+	if err != nil {
+		return err
+	}
+	if !isAccountHolder {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You are not authorized to perform transfer from this account",
+		}
+	}
+
+	if !fromAccount.Type.AllowsTransfers() {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Transfers are only allowed between savings and current accounts",
+		}
+	}
+
+	return nil
+}
+
+// CancelPaymentRequest cancels an open payment request of the requester, the payments made towards it before are not refunded
+func (s *paymentRequestService) CancelPaymentRequest(requestCtx context.Context, dbExecutor bun.IDB, params types.CancelPaymentRequestParams) (*model.PaymentRequest, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	paymentRequest, err := s.paymentRequestRepository.GetPaymentRequest(requestCtx, dbExecutor, types.PaymentRequestQueryOptions{
+		ID:        &params.PaymentRequestID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// authorization check: verify payment request belongs to authenticated user
+	if paymentRequest.RequesterUserID != params.RequesterUserID {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You do not have permission to access this payment request",
+		}
+	}
+
+	now := time.Now().UTC()
+	err = verifyPaymentRequestIsOpen(paymentRequest, now)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelledStatus := model.PaymentRequestCancelled
+	return s.paymentRequestRepository.UpdatePaymentRequest(requestCtx, dbExecutor, paymentRequest.ID, types.PaymentRequestUpdateOptions{
+		NewStatus:   &cancelledStatus,
+		NewClosedAt: &now,
+	})
+}
+
+// ExpirePaymentRequests marks every open payment request whose expiry time has passed by the given time as expired
+func (s *paymentRequestService) ExpirePaymentRequests(requestCtx context.Context, dbExecutor bun.IDB, expiryTime time.Time) ([]model.PaymentRequest, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.paymentRequestRepository.ExpirePaymentRequests(requestCtx, dbExecutor, expiryTime)
+}
+
+// verifyPaymentRequestIsOpen verifies that the payment request can still be paid or cancelled
+func verifyPaymentRequestIsOpen(paymentRequest *model.PaymentRequest, now time.Time) error {
+	// the worker marks expired requests at an interval, a request past its expiry is expired even if it is not marked yet
+	isExpired := paymentRequest.Status == model.PaymentRequestExpired ||
+		(paymentRequest.IsOpen() && paymentRequest.IsExpired(now))
+	if isExpired {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusConflict,
+			Message:        "Payment request has expired",
+		}
+	}
+
+	if !paymentRequest.IsOpen() {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusConflict,
+			Message:        fmt.Sprintf("Payment request has already been %s", strings.ToLower(string(paymentRequest.Status))),
+		}
+	}
+
+	return nil
+}
+
+// generatePaymentRequestToken returns a random URL safe token for a payment request
+func generatePaymentRequestToken() (string, error) {
+	tokenBytes := make([]byte, paymentRequestTokenLength)
+	_, err := rand.Read(tokenBytes)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(tokenBytes), nil
+}
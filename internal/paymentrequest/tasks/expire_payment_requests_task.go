@@ -0,0 +1,88 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+)
+
+const ExpirePaymentRequestsTaskName string = "periodic_task:expire_payment_requests"
+
+type ExpirePaymentRequestsTaskPayload struct {
+}
+
+type ExpirePaymentRequestsTask struct {
+	name          string
+	queue         string
+	cronSpec      string
+	maxRetryCount int
+	payload       ExpirePaymentRequestsTaskPayload
+}
+
+func NewExpirePaymentRequestsTask() tasksHelper.SchedulableTask {
+	return &ExpirePaymentRequestsTask{
+		name:          ExpirePaymentRequestsTaskName,
+		queue:         tasksHelper.DefaultQueue,
+		cronSpec:      "*/15 * * * *", // run every 15 minutes
+		maxRetryCount: 3,
+		payload:       ExpirePaymentRequestsTaskPayload{},
+	}
+}
+
+func (t *ExpirePaymentRequestsTask) Name() string {
+	return t.name
+}
+
+func (t *ExpirePaymentRequestsTask) Queue() string {
+	return t.queue
+}
+
+func (t *ExpirePaymentRequestsTask) CronSpec() string {
+	return t.cronSpec
+}
+
+func (t *ExpirePaymentRequestsTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *ExpirePaymentRequestsTask) Payload() any {
+	return t.payload
+}
+
+type ExpirePaymentRequestsTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewExpirePaymentRequestsTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &ExpirePaymentRequestsTaskProcessor{
+		services: services,
+	}
+}
+
+/*
+ProcessTask closes every payment request that was not fully paid in time by marking it expired
+
+The requests are expired by a single statement that skips the ones paid or cancelled in the meantime, so retrying this task is safe.
+A request past its expiry cannot be paid even before it is marked, the marking only keeps its status accurate.
+*/
+func (processor *ExpirePaymentRequestsTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[ExpirePaymentRequestsTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	expiredPaymentRequests, err := processor.services.PaymentRequestService.ExpirePaymentRequests(ctx, nil, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	logger.Info(ctx, "Expired %d payment request(s)", len(expiredPaymentRequests))
+	return nil
+}
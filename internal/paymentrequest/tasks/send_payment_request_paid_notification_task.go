@@ -0,0 +1,79 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+)
+
+const SendPaymentRequestPaidNotificationTaskName string = "task:send_payment_request_paid_notification"
+
+// The notification tells the requester that a payment was made towards their payment request
+type SendPaymentRequestPaidNotificationTaskPayload struct {
+	PaymentRequestID string
+	PaymentID        string
+	RequesterUserID  string
+}
+
+type SendPaymentRequestPaidNotificationTask struct {
+	name          string
+	queue         string
+	maxRetryCount int
+	payload       SendPaymentRequestPaidNotificationTaskPayload
+}
+
+func NewSendPaymentRequestPaidNotificationTask(paymentRequestID string, paymentID string, requesterUserID string) tasksHelper.Task {
+	return &SendPaymentRequestPaidNotificationTask{
+		name:          SendPaymentRequestPaidNotificationTaskName,
+		queue:         tasksHelper.PriorityQueue,
+		maxRetryCount: 3,
+		payload: SendPaymentRequestPaidNotificationTaskPayload{
+			PaymentRequestID: paymentRequestID,
+			PaymentID:        paymentID,
+			RequesterUserID:  requesterUserID,
+		},
+	}
+}
+
+func (t *SendPaymentRequestPaidNotificationTask) Name() string {
+	return t.name
+}
+
+func (t *SendPaymentRequestPaidNotificationTask) Queue() string {
+	return t.queue
+}
+
+func (t *SendPaymentRequestPaidNotificationTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *SendPaymentRequestPaidNotificationTask) Payload() any {
+	return t.payload
+}
+
+type SendPaymentRequestPaidNotificationTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewSendPaymentRequestPaidNotificationTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &SendPaymentRequestPaidNotificationTaskProcessor{
+		services: services,
+	}
+}
+
+func (processor *SendPaymentRequestPaidNotificationTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[SendPaymentRequestPaidNotificationTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	// TODO: maybe add a real email/push provider here in the future
+	logger.Info(ctx, "[Dummy] send payment request paid notification for paymentRequestID: %s, paymentID: %s to userID: %s", payload.Data.PaymentRequestID, payload.Data.PaymentID, payload.Data.RequesterUserID)
+	return nil
+}
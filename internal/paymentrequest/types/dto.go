@@ -0,0 +1,124 @@
+package types
+
+import (
+	"time"
+
+	"github.com/skamranahmed/go-bank/config"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/paymentrequest/model"
+)
+
+type CreatePaymentRequestRequest struct {
+	Data CreatePaymentRequestRequestData `json:"data" binding:"required"`
+}
+
+type CreatePaymentRequestRequestData struct {
+	// AccountID is the account of the requester the payments are credited to
+	AccountID      int64  `json:"account_id" binding:"required,account_number"`
+	Amount         *int64 `json:"amount" binding:"required,gt=0"`
+	Note           string `json:"note" binding:"omitempty,max=140"`
+	ExpiresInHours *int   `json:"expires_in_hours" binding:"omitempty,gt=0"`
+}
+
+type GetPaymentRequestsRequestQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=PENDING PARTIALLY_PAID PAID CANCELLED EXPIRED"`
+}
+
+// PaymentRequestDto is the view of a payment request for its requester
+type PaymentRequestDto struct {
+	ID              string                     `json:"id"`
+	CreatedAt       time.Time                  `json:"created_at"`
+	Token           string                     `json:"token"`
+	Link            string                     `json:"link"`
+	AccountID       int64                      `json:"account_id"`
+	Amount          int64                      `json:"amount"`
+	PaidAmount      int64                      `json:"paid_amount"`
+	RemainingAmount int64                      `json:"remaining_amount"`
+	Note            *string                    `json:"note"`
+	Status          model.PaymentRequestStatus `json:"status"`
+	ExpiresAt       time.Time                  `json:"expires_at"`
+	ClosedAt        *time.Time                 `json:"closed_at"`
+}
+
+type PaymentRequestResponse struct {
+	Data PaymentRequestDto `json:"data"`
+}
+
+type GetPaymentRequestsResponse struct {
+	Data []PaymentRequestDto `json:"data"`
+}
+
+func TransformToPaymentRequestDto(paymentRequest *model.PaymentRequest) *PaymentRequestDto {
+	paymentRequestConfig := config.GetPaymentRequestConfig()
+	return &PaymentRequestDto{
+		ID:              paymentRequest.ID.String(),
+		CreatedAt:       paymentRequest.CreatedAt,
+		Token:           paymentRequest.Token,
+		Link:            paymentRequestConfig.LinkBaseURL + "/" + paymentRequest.Token,
+		AccountID:       paymentRequest.AccountID,
+		Amount:          paymentRequest.Amount,
+		PaidAmount:      paymentRequest.PaidAmount,
+		RemainingAmount: paymentRequest.RemainingAmount(),
+		Note:            paymentRequest.Note,
+		Status:          paymentRequest.Status,
+		ExpiresAt:       paymentRequest.ExpiresAt,
+		ClosedAt:        paymentRequest.ClosedAt,
+	}
+}
+
+func TransformToPaymentRequestDtoList(paymentRequests []model.PaymentRequest) []PaymentRequestDto {
+	paymentRequestDtos := make([]PaymentRequestDto, 0, len(paymentRequests))
+	for _, paymentRequest := range paymentRequests {
+		paymentRequestDtos = append(paymentRequestDtos, *TransformToPaymentRequestDto(&paymentRequest))
+	}
+	return paymentRequestDtos
+}
+
+// PaymentLinkDto is the view of a payment request for the users the link is shared with, it does not reveal the account of the requester
+type PaymentLinkDto struct {
+	Token           string                     `json:"token"`
+	RequesterName   string                     `json:"requester_name"`
+	Amount          int64                      `json:"amount"`
+	PaidAmount      int64                      `json:"paid_amount"`
+	RemainingAmount int64                      `json:"remaining_amount"`
+	Note            *string                    `json:"note"`
+	Status          model.PaymentRequestStatus `json:"status"`
+	ExpiresAt       time.Time                  `json:"expires_at"`
+}
+
+type GetPaymentLinkResponse struct {
+	Data PaymentLinkDto `json:"data"`
+}
+
+func TransformToPaymentLinkDto(paymentRequest *model.PaymentRequest, requesterName string) *PaymentLinkDto {
+	return &PaymentLinkDto{
+		Token:           paymentRequest.Token,
+		RequesterName:   requesterName,
+		Amount:          paymentRequest.Amount,
+		PaidAmount:      paymentRequest.PaidAmount,
+		RemainingAmount: paymentRequest.RemainingAmount(),
+		Note:            paymentRequest.Note,
+		Status:          paymentRequest.Status,
+		ExpiresAt:       paymentRequest.ExpiresAt,
+	}
+}
+
+type PayPaymentRequestRequest struct {
+	Data PayPaymentRequestRequestData `json:"data" binding:"required"`
+}
+
+type PayPaymentRequestRequestData struct {
+	FromAccountID int64 `json:"from_account_id" binding:"required,account_number"`
+
+	// Amount is optional, by default the whole remaining amount is paid
+	Amount *int64 `json:"amount" binding:"omitempty,gt=0"`
+}
+
+type PayPaymentRequestResponse struct {
+	Data PayPaymentRequestResponseData `json:"data"`
+}
+
+type PayPaymentRequestResponseData struct {
+	PaymentRequest PaymentLinkDto              `json:"payment_request"`
+	Transaction    accountTypes.TransactionDto `json:"transaction"`
+}
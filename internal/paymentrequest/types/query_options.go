@@ -0,0 +1,27 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/paymentrequest/model"
+)
+
+type PaymentRequestQueryOptions struct {
+	ID    *uuid.UUID
+	Token *string
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type PaymentRequestListOptions struct {
+	RequesterUserID *uuid.UUID
+	Status          *model.PaymentRequestStatus
+}
+
+type PaymentRequestUpdateOptions struct {
+	NewStatus     *model.PaymentRequestStatus
+	NewPaidAmount *int64
+	NewClosedAt   *time.Time
+}
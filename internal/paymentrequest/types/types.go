@@ -0,0 +1,27 @@
+package types
+
+import "github.com/google/uuid"
+
+type CreatePaymentRequestParams struct {
+	RequesterUserID uuid.UUID
+	AccountID       int64
+	Amount          int64
+	Note            string
+
+	// ExpiresInHours is the number of hours the request can be paid for, when not set the configured default is used
+	ExpiresInHours *int
+}
+
+type PayPaymentRequestParams struct {
+	Token         string
+	PayerUserID   uuid.UUID
+	FromAccountID int64
+
+	// Amount is the part of the request being paid, when not set the whole remaining amount is paid
+	Amount *int64
+}
+
+type CancelPaymentRequestParams struct {
+	PaymentRequestID uuid.UUID
+	RequesterUserID  uuid.UUID
+}
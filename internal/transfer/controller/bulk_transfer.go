@@ -0,0 +1,250 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	transferTasks "github.com/skamranahmed/go-bank/internal/transfer/tasks"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/uptrace/bun"
+)
+
+func (c *transferController) UploadBulkTransfer(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.UploadBulkTransferRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	// existence check for the sender account
+	fromAccount, err := c.accountService.GetAccount(requestCtx, nil, accountTypes.AccountQueryOptions{
+		AccountID: &payload.Data.FromAccountID,
+		Columns:   []string{"id", "user_id"},
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// authorization check that the authenticated user is one of the holders of the sender account
+	isAccountHolder, err := c.accountService.IsAccountHolder(requestCtx, nil, fromAccount, userID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+	if !isAccountHolder {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You are not authorized to perform transfer from this account",
+		})
+		return
+	}
+
+	var bulkTransfer *model.BulkTransfer
+	err = database.RunInTransaction(requestCtx, "createBulkTransfer", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		bulkTransfer, err = c.transferService.CreateBulkTransfer(txCtx, tx, types.CreateBulkTransferParams{
+			UserID:                  userID,
+			FromAccountID:           payload.Data.FromAccountID,
+			FileName:                payload.Data.FileName,
+			Content:                 []byte(payload.Data.Content),
+			StopOnInsufficientFunds: payload.Data.StopOnInsufficientFunds,
+		})
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// the rows are returned with the batch, so that every invalid row can be corrected at once
+	items, err := c.transferService.ListBulkTransferItems(requestCtx, nil, types.BulkTransferItemListOptions{
+		BulkTransferID: &bulkTransfer.ID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusCreated, types.BulkTransferResponse{
+		Data: *types.TransformToBulkTransferDto(bulkTransfer, items),
+	})
+}
+
+func (c *transferController) GetBulkTransfers(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var query types.GetBulkTransfersRequestQuery
+	isSuccess := server.BindAndValidateIncomingRequestQuery(ginCtx, &query)
+	if !isSuccess {
+		return
+	}
+
+	options := types.BulkTransferListOptions{
+		UserID: &userID,
+	}
+	if query.Status != "" {
+		status := model.BulkTransferStatus(query.Status)
+		options.Status = &status
+	}
+
+	bulkTransfers, err := c.transferService.ListBulkTransfers(requestCtx, nil, options)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetBulkTransfersResponse{
+		Data: types.TransformToBulkTransferDtoList(bulkTransfers),
+	})
+}
+
+func (c *transferController) GetBulkTransfer(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	bulkTransfer, ok := c.getOwnedBulkTransfer(ginCtx)
+	if !ok {
+		return
+	}
+
+	items, err := c.transferService.ListBulkTransferItems(requestCtx, nil, types.BulkTransferItemListOptions{
+		BulkTransferID: &bulkTransfer.ID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.BulkTransferResponse{
+		Data: *types.TransformToBulkTransferDto(bulkTransfer, items),
+	})
+}
+
+func (c *transferController) ConfirmBulkTransfer(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	bulkTransfer, ok := c.getOwnedBulkTransfer(ginCtx)
+	if !ok {
+		return
+	}
+
+	/*
+		The execution is enqueued within the database transaction, so that a confirmed batch is never left without it.
+		If the transaction fails to commit after enqueuing, the task finds the batch not queued and does nothing.
+	*/
+	err := database.RunInTransaction(requestCtx, "confirmBulkTransfer", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		bulkTransfer, err = c.transferService.ConfirmBulkTransfer(txCtx, tx, bulkTransfer.ID)
+		if err != nil {
+			return err
+		}
+
+		return c.taskEnqueuer.Enqueue(txCtx, transferTasks.NewExecuteBulkTransferTask(bulkTransfer.ID.String()), nil, nil)
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.BulkTransferResponse{
+		Data: *types.TransformToBulkTransferDto(bulkTransfer, nil),
+	})
+}
+
+func (c *transferController) CancelBulkTransfer(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	bulkTransfer, ok := c.getOwnedBulkTransfer(ginCtx)
+	if !ok {
+		return
+	}
+
+	err := database.RunInTransaction(requestCtx, "cancelBulkTransfer", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		bulkTransfer, err = c.transferService.CancelBulkTransfer(txCtx, tx, bulkTransfer.ID)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.BulkTransferResponse{
+		Data: *types.TransformToBulkTransferDto(bulkTransfer, nil),
+	})
+}
+
+func (c *transferController) GetBulkTransferResult(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	bulkTransfer, ok := c.getOwnedBulkTransfer(ginCtx)
+	if !ok {
+		return
+	}
+
+	result, err := c.transferService.BuildBulkTransferResult(requestCtx, nil, bulkTransfer.ID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetBulkTransferResultResponse{
+		Data: *types.TransformToBulkTransferResultDto(result),
+	})
+}
+
+// getOwnedBulkTransfer returns the bulk transfer of the URL parameter, sending the error response unless it belongs to the authenticated user
+func (c *transferController) getOwnedBulkTransfer(ginCtx *gin.Context) (*model.BulkTransfer, bool) {
+	requestCtx := ginCtx.Request.Context()
+
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return nil, false
+	}
+
+	bulkTransferID, err := uuid.Parse(ginCtx.Param("bulk_transfer_id"))
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid bulk transfer ID",
+		})
+		return nil, false
+	}
+
+	bulkTransfer, err := c.transferService.GetBulkTransfer(requestCtx, nil, types.BulkTransferQueryOptions{
+		ID: &bulkTransferID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return nil, false
+	}
+
+	// authorization check: verify bulk transfer belongs to authenticated user
+	if bulkTransfer.UserID != userID {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You do not have permission to access this bulk transfer",
+		})
+		return nil, false
+	}
+
+	return bulkTransfer, true
+}
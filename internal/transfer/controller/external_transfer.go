@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	transferTasks "github.com/skamranahmed/go-bank/internal/transfer/tasks"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/uptrace/bun"
+)
+
+func (c *transferController) CreateExternalTransfer(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.ExternalTransferRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	// existence check for the sender account
+	fromAccount, err := c.accountService.GetAccount(requestCtx, nil, accountTypes.AccountQueryOptions{
+		AccountID: &payload.Data.FromAccountID,
+		Columns:   []string{"id", "user_id"},
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// authorization check that the authenticated user is one of the holders of the sender account
+	isAccountHolder, err := c.accountService.IsAccountHolder(requestCtx, nil, fromAccount, userID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+	if !isAccountHolder {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You are not authorized to perform transfer from this account",
+		})
+		return
+	}
+
+	// the mandate of a joint account is enforced by CreateExternalTransfer, an external transfer cannot wait for the approval of every holder
+	/*
+		The dispatch is enqueued within the database transaction, so that a payment order is never stored without it.
+		If the transaction fails to commit after enqueuing, the task finds no payment order and does nothing.
+	*/
+	var paymentOrder *model.PaymentOrder
+	err = database.RunInTransaction(requestCtx, "createExternalTransfer", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		paymentOrder, err = c.transferService.CreateExternalTransfer(txCtx, tx, types.CreateExternalTransferParams{
+			UserID:                   userID,
+			FromAccountID:            payload.Data.FromAccountID,
+			Amount:                   *payload.Data.Amount,
+			Rail:                     model.Rail(payload.Data.Rail),
+			BeneficiaryName:          payload.Data.BeneficiaryName,
+			BeneficiaryAccountNumber: payload.Data.BeneficiaryAccountNumber,
+			BeneficiaryIFSC:          payload.Data.BeneficiaryIFSC,
+			Remittance:               toRemittance(payload.Data.Narration, payload.Data.ClientReference, payload.Data.Category),
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.taskEnqueuer.EnqueueAt(txCtx, transferTasks.NewDispatchPaymentOrderTask(paymentOrder.ID.String()), paymentOrder.DispatchAt, nil, nil)
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusCreated, types.ExternalTransferResponse{
+		Data: *types.TransformToPaymentOrderDto(paymentOrder),
+	})
+}
+
+func (c *transferController) GetExternalTransfers(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var query types.GetExternalTransfersRequestQuery
+	isSuccess := server.BindAndValidateIncomingRequestQuery(ginCtx, &query)
+	if !isSuccess {
+		return
+	}
+
+	listOptions := types.PaymentOrderListOptions{
+		UserID: &userID,
+	}
+	if query.Status != "" {
+		status := model.PaymentOrderStatus(query.Status)
+		listOptions.Status = &status
+	}
+
+	paymentOrders, err := c.transferService.ListPaymentOrders(requestCtx, nil, listOptions)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetExternalTransfersResponse{
+		Data: types.TransformToPaymentOrderDtoList(paymentOrders),
+	})
+}
@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/clearingfile"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/uptrace/bun"
+)
+
+func (c *transferController) UploadInboundPaymentFile(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	// the authenticated user is the admin uploading the file
+	adminID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.UploadInboundPaymentFileRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	var inboundPaymentFile *model.InboundPaymentFile
+	err := database.RunInTransaction(requestCtx, "ingestInboundPaymentFile", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		inboundPaymentFile, err = c.transferService.IngestInboundPaymentFile(txCtx, tx, types.IngestInboundPaymentFileParams{
+			FileName:   payload.Data.FileName,
+			Format:     clearingfile.Format(payload.Data.Format),
+			Content:    []byte(payload.Data.Content),
+			Source:     model.InboundPaymentFileUpload,
+			UploadedBy: &adminID,
+		})
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusCreated, types.UploadInboundPaymentFileResponse{
+		Data: *types.TransformToInboundPaymentFileDto(inboundPaymentFile),
+	})
+}
+
+func (c *transferController) GetInboundPaymentAcknowledgment(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	fileID, err := uuid.Parse(ginCtx.Param("file_id"))
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid inbound payment file ID",
+		})
+		return
+	}
+
+	acknowledgment, err := c.transferService.BuildInboundPaymentAcknowledgment(requestCtx, nil, fileID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetInboundPaymentAcknowledgmentResponse{
+		Data: *types.TransformToInboundPaymentAcknowledgmentDto(acknowledgment),
+	})
+}
+
+func (c *transferController) GetInboundPayments(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	var query types.GetInboundPaymentsRequestQuery
+	isSuccess := server.BindAndValidateIncomingRequestQuery(ginCtx, &query)
+	if !isSuccess {
+		return
+	}
+
+	var listOptions types.InboundPaymentListOptions
+	if query.FileID != "" {
+		// the format has already been validated by the binding
+		fileID := uuid.MustParse(query.FileID)
+		listOptions.FileID = &fileID
+	}
+	if query.Status != "" {
+		status := model.InboundPaymentStatus(query.Status)
+		listOptions.Status = &status
+	}
+
+	inboundPayments, err := c.transferService.ListInboundPayments(requestCtx, nil, listOptions)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetInboundPaymentsResponse{
+		Data: types.TransformToInboundPaymentDtoList(inboundPayments),
+	})
+}
+
+func (c *transferController) AssignInboundPayment(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	// the authenticated user is the admin assigning the payment
+	adminID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	inboundPaymentID, ok := getInboundPaymentID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.AssignInboundPaymentRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	var inboundPayment *model.InboundPayment
+	err := database.RunInTransaction(requestCtx, "assignInboundPayment", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		inboundPayment, err = c.transferService.AssignInboundPayment(txCtx, tx, types.AssignInboundPaymentParams{
+			InboundPaymentID: inboundPaymentID,
+			AccountID:        payload.Data.AccountID,
+			AssignedBy:       adminID,
+		})
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.InboundPaymentResponse{
+		Data: *types.TransformToInboundPaymentDto(inboundPayment),
+	})
+}
+
+func (c *transferController) ReturnInboundPayment(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	// the authenticated user is the admin returning the payment
+	adminID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	inboundPaymentID, ok := getInboundPaymentID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.ReturnInboundPaymentRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	var inboundPayment *model.InboundPayment
+	err := database.RunInTransaction(requestCtx, "returnInboundPayment", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		inboundPayment, err = c.transferService.ReturnInboundPayment(txCtx, tx, types.ReturnInboundPaymentParams{
+			InboundPaymentID: inboundPaymentID,
+			Reason:           payload.Data.Reason,
+			ReturnedBy:       adminID,
+		})
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.InboundPaymentResponse{
+		Data: *types.TransformToInboundPaymentDto(inboundPayment),
+	})
+}
+
+// getInboundPaymentID parses the inbound payment ID from the path, it sends the error response and returns false if it is invalid
+func getInboundPaymentID(ginCtx *gin.Context) (uuid.UUID, bool) {
+	inboundPaymentID, err := uuid.Parse(ginCtx.Param("inbound_payment_id"))
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid inbound payment ID",
+		})
+		return uuid.Nil, false
+	}
+	return inboundPaymentID, true
+}
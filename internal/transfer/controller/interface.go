@@ -4,4 +4,36 @@ import "github.com/gin-gonic/gin"
 
 type TransferController interface {
 	PerformInternalTransfer(ginCtx *gin.Context)
+	CreateScheduledTransfer(ginCtx *gin.Context)
+	GetScheduledTransfers(ginCtx *gin.Context)
+	CancelScheduledTransfer(ginCtx *gin.Context)
+	CreateStandingInstruction(ginCtx *gin.Context)
+	GetStandingInstructions(ginCtx *gin.Context)
+	GetStandingInstructionExecutions(ginCtx *gin.Context)
+	CancelStandingInstruction(ginCtx *gin.Context)
+	ResumeStandingInstruction(ginCtx *gin.Context)
+	GetTransferLimits(ginCtx *gin.Context)
+	UpdateTransferLimits(ginCtx *gin.Context)
+	UpdateUserTransferLimits(ginCtx *gin.Context)
+	ReverseTransfer(ginCtx *gin.Context)
+	GetTransferReversals(ginCtx *gin.Context)
+	CreateExternalTransfer(ginCtx *gin.Context)
+	GetExternalTransfers(ginCtx *gin.Context)
+	UploadBulkTransfer(ginCtx *gin.Context)
+	GetBulkTransfers(ginCtx *gin.Context)
+	GetBulkTransfer(ginCtx *gin.Context)
+	ConfirmBulkTransfer(ginCtx *gin.Context)
+	CancelBulkTransfer(ginCtx *gin.Context)
+	GetBulkTransferResult(ginCtx *gin.Context)
+	UploadInboundPaymentFile(ginCtx *gin.Context)
+	GetInboundPaymentAcknowledgment(ginCtx *gin.Context)
+	GetInboundPayments(ginCtx *gin.Context)
+	AssignInboundPayment(ginCtx *gin.Context)
+	ReturnInboundPayment(ginCtx *gin.Context)
+	ReceiveISO20022Messages(ginCtx *gin.Context)
+	GetPaymentOrderCreditTransfer(ginCtx *gin.Context)
+	GetJointTransfers(ginCtx *gin.Context)
+	GetJointTransfer(ginCtx *gin.Context)
+	ApproveJointTransfer(ginCtx *gin.Context)
+	RejectJointTransfer(ginCtx *gin.Context)
 }
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	userTypes "github.com/skamranahmed/go-bank/internal/user/types"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/uptrace/bun"
+)
+
+/*
+ReceiveISO20022Messages processes a batch of ISO 20022 messages received from the clearing house
+
+Each message is processed in its own database transaction, so a message that is invalid or rejected
+does not hold back the others. The outcome of every message is reported in the order they were sent.
+*/
+func (c *transferController) ReceiveISO20022Messages(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	// the authenticated user is the admin uploading the messages
+	adminID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.ReceiveISO20022MessagesRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	results := make([]types.ISO20022MessageResultDto, 0, len(payload.Data.Messages))
+	for _, message := range payload.Data.Messages {
+		var result *types.ISO20022MessageResult
+		err := database.RunInTransaction(requestCtx, "receiveISO20022Message", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+			var err error
+			result, err = c.transferService.ReceiveISO20022Message(txCtx, tx, types.ReceiveISO20022MessageParams{
+				Content:    []byte(message.Content),
+				Source:     model.InboundPaymentFileUpload,
+				ReceivedBy: &adminID,
+			})
+			return err
+		})
+		if err != nil {
+			errorMessage := "We couldn't process the message at the moment. Please try again later."
+			var apiErr *server.ApiError
+			if errors.As(err, &apiErr) {
+				errorMessage = apiErr.Message
+			}
+
+			results = append(results, types.ISO20022MessageResultDto{
+				Status:        types.ISO20022MessageRejected,
+				Errors:        []types.ISO20022MessageErrorDto{{Message: errorMessage}},
+				PaymentOrders: []types.PaymentOrderDto{},
+			})
+			continue
+		}
+
+		results = append(results, *types.TransformToISO20022MessageResultDto(result))
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.ReceiveISO20022MessagesResponse{
+		Data: results,
+	})
+}
+
+func (c *transferController) GetPaymentOrderCreditTransfer(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	paymentOrderID, err := uuid.Parse(ginCtx.Param("payment_order_id"))
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid payment order ID",
+		})
+		return
+	}
+
+	paymentOrder, err := c.transferService.GetPaymentOrder(requestCtx, nil, types.PaymentOrderQueryOptions{
+		ID: &paymentOrderID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// the debtor of the message is the customer who made the transfer
+	userID := paymentOrder.UserID.String()
+	user, err := c.userService.GetUser(requestCtx, nil, userTypes.UserQueryOptions{
+		ID:      &userID,
+		Columns: []string{"username", "first_name", "last_name"},
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	content, err := c.transferService.BuildPaymentOrderCreditTransfer(requestCtx, nil, types.BuildPaymentOrderCreditTransferParams{
+		PaymentOrderID: paymentOrder.ID,
+		DebtorName:     user.FullName(),
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetPaymentOrderCreditTransferResponse{
+		Data: types.ISO20022MessageDto{
+			FileName: paymentOrder.ID.String() + ".xml",
+			Content:  string(content),
+		},
+	})
+}
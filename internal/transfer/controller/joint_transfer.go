@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/uptrace/bun"
+)
+
+// GetJointTransfers lists the joint transfers out of an account, to any of its holders
+func (c *transferController) GetJointTransfers(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var query types.GetJointTransfersRequestQuery
+	isSuccess := server.BindAndValidateIncomingRequestQuery(ginCtx, &query)
+	if !isSuccess {
+		return
+	}
+
+	ok = c.verifyAccountHolder(ginCtx, query.AccountID, userID)
+	if !ok {
+		return
+	}
+
+	listOptions := types.JointTransferListOptions{
+		FromAccountID: &query.AccountID,
+	}
+	if query.Status != "" {
+		status := model.JointTransferStatus(query.Status)
+		listOptions.Status = &status
+	}
+
+	jointTransfers, err := c.transferService.ListJointTransfers(requestCtx, nil, listOptions)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetJointTransfersResponse{
+		Data: types.TransformToJointTransferDtoList(jointTransfers),
+	})
+}
+
+// GetJointTransfer returns the joint transfer along with the decisions taken on it so far
+func (c *transferController) GetJointTransfer(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	jointTransferID, ok := getJointTransferID(ginCtx)
+	if !ok {
+		return
+	}
+
+	jointTransfer, err := c.transferService.GetJointTransfer(requestCtx, nil, types.JointTransferQueryOptions{
+		ID: &jointTransferID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	ok = c.verifyAccountHolder(ginCtx, jointTransfer.FromAccountID, userID)
+	if !ok {
+		return
+	}
+
+	approvals, err := c.transferService.ListJointTransferApprovals(requestCtx, nil, types.JointTransferApprovalListOptions{
+		JointTransferID: &jointTransfer.ID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetJointTransferResponse{
+		Data: *types.TransformToJointTransferDto(jointTransfer, approvals),
+	})
+}
+
+// ApproveJointTransfer records the approval of the authenticated holder, the transfer is executed once every holder has approved it
+func (c *transferController) ApproveJointTransfer(ginCtx *gin.Context) {
+	c.decideJointTransfer(ginCtx, "approveJointTransfer", c.transferService.ApproveJointTransfer)
+}
+
+// RejectJointTransfer records the rejection of the authenticated holder, which rejects the whole transfer
+func (c *transferController) RejectJointTransfer(ginCtx *gin.Context) {
+	c.decideJointTransfer(ginCtx, "rejectJointTransfer", c.transferService.RejectJointTransfer)
+}
+
+// decideJointTransfer handles both the approval and the rejection of a joint transfer by one of the holders of its account
+func (c *transferController) decideJointTransfer(ginCtx *gin.Context, transactionName string, decide func(requestCtx context.Context, dbExecutor bun.IDB, jointTransferID uuid.UUID, userID uuid.UUID) (*model.JointTransfer, error)) {
+	requestCtx := ginCtx.Request.Context()
+
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	jointTransferID, ok := getJointTransferID(ginCtx)
+	if !ok {
+		return
+	}
+
+	// the service verifies that the user is a holder of the account of the transfer
+	var jointTransfer *model.JointTransfer
+	err := database.RunInTransaction(requestCtx, transactionName, c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		jointTransfer, err = decide(txCtx, tx, jointTransferID, userID)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	approvals, err := c.transferService.ListJointTransferApprovals(requestCtx, nil, types.JointTransferApprovalListOptions{
+		JointTransferID: &jointTransfer.ID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.DecideJointTransferResponse{
+		Data: *types.TransformToJointTransferDto(jointTransfer, approvals),
+	})
+}
+
+// verifyAccountHolder verifies that the user is one of the holders of the account, sending the error response when they are not
+func (c *transferController) verifyAccountHolder(ginCtx *gin.Context, accountID int64, userID uuid.UUID) bool {
+	requestCtx := ginCtx.Request.Context()
+
+	account, err := c.accountService.GetAccount(requestCtx, nil, accountTypes.AccountQueryOptions{
+		AccountID: &accountID,
+		Columns:   []string{"id", "user_id"},
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return false
+	}
+
+	isAccountHolder, err := c.accountService.IsAccountHolder(requestCtx, nil, account, userID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return false
+	}
+	if !isAccountHolder {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You do not have permission to access this joint transfer",
+		})
+		return false
+	}
+
+	return true
+}
+
+// getJointTransferID extracts the joint transfer ID from the URL parameter, on failure the error response is already sent and false is returned
+func getJointTransferID(ginCtx *gin.Context) (uuid.UUID, bool) {
+	jointTransferID, err := uuid.Parse(ginCtx.Param("joint_transfer_id"))
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid joint transfer ID",
+		})
+		return uuid.Nil, false
+	}
+	return jointTransferID, true
+}
@@ -5,7 +5,11 @@ import (
 	"github.com/skamranahmed/go-bank/cmd/middleware"
 	accountService "github.com/skamranahmed/go-bank/internal/account/service"
 	authenticationService "github.com/skamranahmed/go-bank/internal/authentication/service"
+	beneficiaryService "github.com/skamranahmed/go-bank/internal/beneficiary/service"
 	transferService "github.com/skamranahmed/go-bank/internal/transfer/service"
+	userService "github.com/skamranahmed/go-bank/internal/user/service"
+	vpaService "github.com/skamranahmed/go-bank/internal/vpa/service"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
 	"github.com/uptrace/bun"
 )
 
@@ -14,9 +18,47 @@ type Dependency struct {
 	AuthenticationService authenticationService.AuthenticationService
 	AccountService        accountService.AccountService
 	TransferService       transferService.TransferService
+	BeneficiaryService    beneficiaryService.BeneficiaryService
+	UserService           userService.UserService
+	VPAService            vpaService.VPAService
+	TaskEnqueuer          tasksHelper.TaskEnqueuer
 }
 
 func Register(router *gin.Engine, dependency Dependency) {
 	transferController := newTransferController(dependency)
 	router.POST("/v1/transfers/internal", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.PerformInternalTransfer)
+	router.POST("/v1/transfers/external", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.CreateExternalTransfer)
+	router.GET("/v1/transfers/external", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.GetExternalTransfers)
+	router.POST("/v1/transfers/bulk", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.UploadBulkTransfer)
+	router.GET("/v1/transfers/bulk", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.GetBulkTransfers)
+	router.GET("/v1/transfers/bulk/:bulk_transfer_id", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.GetBulkTransfer)
+	router.POST("/v1/transfers/bulk/:bulk_transfer_id/confirm", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.ConfirmBulkTransfer)
+	router.POST("/v1/transfers/bulk/:bulk_transfer_id/cancel", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.CancelBulkTransfer)
+	router.GET("/v1/transfers/bulk/:bulk_transfer_id/result", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.GetBulkTransferResult)
+	router.POST("/v1/transfers/scheduled", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.CreateScheduledTransfer)
+	router.GET("/v1/transfers/scheduled", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.GetScheduledTransfers)
+	router.POST("/v1/transfers/scheduled/:scheduled_transfer_id/cancel", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.CancelScheduledTransfer)
+	router.GET("/v1/joint-transfers", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.GetJointTransfers)
+	router.GET("/v1/joint-transfers/:joint_transfer_id", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.GetJointTransfer)
+	router.POST("/v1/joint-transfers/:joint_transfer_id/approve", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.ApproveJointTransfer)
+	router.POST("/v1/joint-transfers/:joint_transfer_id/reject", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.RejectJointTransfer)
+	router.POST("/v1/standing-instructions", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.CreateStandingInstruction)
+	router.GET("/v1/standing-instructions", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.GetStandingInstructions)
+	router.GET("/v1/standing-instructions/:standing_instruction_id/executions", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.GetStandingInstructionExecutions)
+	router.POST("/v1/standing-instructions/:standing_instruction_id/cancel", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.CancelStandingInstruction)
+	router.POST("/v1/standing-instructions/:standing_instruction_id/resume", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.ResumeStandingInstruction)
+	router.GET("/v1/transfer-limits", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.GetTransferLimits)
+	router.PUT("/v1/transfer-limits", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), transferController.UpdateTransferLimits)
+
+	// admin routes
+	router.PUT("/v1/admin/users/:user_id/transfer-limits", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.AdminMiddleware(dependency.UserService), transferController.UpdateUserTransferLimits)
+	router.POST("/v1/admin/transactions/:transaction_id/reversal", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.AdminMiddleware(dependency.UserService), transferController.ReverseTransfer)
+	router.GET("/v1/admin/transfer-reversals", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.AdminMiddleware(dependency.UserService), transferController.GetTransferReversals)
+	router.POST("/v1/admin/inbound-payment-files", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.AdminMiddleware(dependency.UserService), transferController.UploadInboundPaymentFile)
+	router.GET("/v1/admin/inbound-payment-files/:file_id/acknowledgment", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.AdminMiddleware(dependency.UserService), transferController.GetInboundPaymentAcknowledgment)
+	router.GET("/v1/admin/inbound-payments", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.AdminMiddleware(dependency.UserService), transferController.GetInboundPayments)
+	router.POST("/v1/admin/inbound-payments/:inbound_payment_id/assign", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.AdminMiddleware(dependency.UserService), transferController.AssignInboundPayment)
+	router.POST("/v1/admin/inbound-payments/:inbound_payment_id/return", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.AdminMiddleware(dependency.UserService), transferController.ReturnInboundPayment)
+	router.POST("/v1/admin/iso20022-messages", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.AdminMiddleware(dependency.UserService), transferController.ReceiveISO20022Messages)
+	router.GET("/v1/admin/payment-orders/:payment_order_id/pacs008", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), middleware.AdminMiddleware(dependency.UserService), transferController.GetPaymentOrderCreditTransfer)
 }
@@ -0,0 +1,212 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/uptrace/bun"
+)
+
+func (c *transferController) CreateStandingInstruction(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.CreateStandingInstructionRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	// the formats have already been validated by the binding
+	startDate, _ := time.Parse(time.DateOnly, payload.Data.StartDate)
+	var endDate *time.Time
+	if payload.Data.EndDate != "" {
+		date, _ := time.Parse(time.DateOnly, payload.Data.EndDate)
+		endDate = &date
+	}
+
+	toAccountID, ok := c.resolveRecipientAccountID(ginCtx, payload.Data.ToAccountID, payload.Data.ToIBAN)
+	if !ok {
+		return
+	}
+
+	fromAccount, ok := c.getTransferAccounts(ginCtx, userID, payload.Data.FromAccountID, toAccountID)
+	if !ok {
+		return
+	}
+
+	ok = c.rejectJointApprovalTransfer(ginCtx, fromAccount, *payload.Data.Amount)
+	if !ok {
+		return
+	}
+
+	standingInstruction, err := c.transferService.CreateStandingInstruction(requestCtx, nil, types.CreateStandingInstructionParams{
+		UserID:         userID,
+		FromAccountID:  payload.Data.FromAccountID,
+		ToAccountID:    toAccountID,
+		Amount:         *payload.Data.Amount,
+		Frequency:      model.StandingInstructionFrequency(payload.Data.Frequency),
+		DayOfMonth:     payload.Data.DayOfMonth,
+		StartDate:      startDate,
+		EndDate:        endDate,
+		MaxOccurrences: payload.Data.MaxOccurrences,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusCreated, types.CreateStandingInstructionResponse{
+		Data: *types.TransformToStandingInstructionDto(standingInstruction),
+	})
+}
+
+func (c *transferController) GetStandingInstructions(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var query types.GetStandingInstructionsRequestQuery
+	isSuccess := server.BindAndValidateIncomingRequestQuery(ginCtx, &query)
+	if !isSuccess {
+		return
+	}
+
+	listOptions := types.StandingInstructionListOptions{
+		UserID: &userID,
+	}
+	if query.Status != "" {
+		status := model.StandingInstructionStatus(query.Status)
+		listOptions.Status = &status
+	}
+
+	standingInstructions, err := c.transferService.ListStandingInstructions(requestCtx, nil, listOptions)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetStandingInstructionsResponse{
+		Data: types.TransformToStandingInstructionDtoList(standingInstructions),
+	})
+}
+
+func (c *transferController) GetStandingInstructionExecutions(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	standingInstruction, ok := c.getOwnedStandingInstruction(ginCtx)
+	if !ok {
+		return
+	}
+
+	executions, err := c.transferService.ListStandingInstructionExecutions(requestCtx, nil, types.StandingInstructionExecutionListOptions{
+		StandingInstructionID: &standingInstruction.ID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetStandingInstructionExecutionsResponse{
+		Data: types.TransformToStandingInstructionExecutionDtoList(executions),
+	})
+}
+
+func (c *transferController) CancelStandingInstruction(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	standingInstruction, ok := c.getOwnedStandingInstruction(ginCtx)
+	if !ok {
+		return
+	}
+
+	err := database.RunInTransaction(requestCtx, "cancelStandingInstruction", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		standingInstruction, err = c.transferService.CancelStandingInstruction(txCtx, tx, standingInstruction.ID)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.CancelStandingInstructionResponse{
+		Data: *types.TransformToStandingInstructionDto(standingInstruction),
+	})
+}
+
+func (c *transferController) ResumeStandingInstruction(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	standingInstruction, ok := c.getOwnedStandingInstruction(ginCtx)
+	if !ok {
+		return
+	}
+
+	err := database.RunInTransaction(requestCtx, "resumeStandingInstruction", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		standingInstruction, err = c.transferService.ResumeStandingInstruction(txCtx, tx, standingInstruction.ID)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.ResumeStandingInstructionResponse{
+		Data: *types.TransformToStandingInstructionDto(standingInstruction),
+	})
+}
+
+// getOwnedStandingInstruction fetches the standing instruction from the URL parameter and verifies it belongs to the authenticated user, it sends the error response itself
+func (c *transferController) getOwnedStandingInstruction(ginCtx *gin.Context) (*model.StandingInstruction, bool) {
+	requestCtx := ginCtx.Request.Context()
+
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return nil, false
+	}
+
+	// extract standing instruction ID from URL parameter
+	standingInstructionID, err := uuid.Parse(ginCtx.Param("standing_instruction_id"))
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid standing instruction ID",
+		})
+		return nil, false
+	}
+
+	standingInstruction, err := c.transferService.GetStandingInstruction(requestCtx, nil, types.StandingInstructionQueryOptions{
+		ID: &standingInstructionID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return nil, false
+	}
+
+	// authorization check: verify standing instruction belongs to authenticated user
+	if standingInstruction.UserID != userID {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You do not have permission to access this standing instruction",
+		})
+		return nil, false
+	}
+
+	return standingInstruction, true
+}
@@ -3,101 +3,577 @@ package controller
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/skamranahmed/go-bank/cmd/middleware"
 	"github.com/skamranahmed/go-bank/cmd/server"
 	"github.com/skamranahmed/go-bank/internal/account/model"
 	accountService "github.com/skamranahmed/go-bank/internal/account/service"
 	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	beneficiaryModel "github.com/skamranahmed/go-bank/internal/beneficiary/model"
+	beneficiaryService "github.com/skamranahmed/go-bank/internal/beneficiary/service"
+	beneficiaryTypes "github.com/skamranahmed/go-bank/internal/beneficiary/types"
+	transferModel "github.com/skamranahmed/go-bank/internal/transfer/model"
 	transferService "github.com/skamranahmed/go-bank/internal/transfer/service"
+	transferTasks "github.com/skamranahmed/go-bank/internal/transfer/tasks"
 	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	userService "github.com/skamranahmed/go-bank/internal/user/service"
+	userTypes "github.com/skamranahmed/go-bank/internal/user/types"
+	vpaService "github.com/skamranahmed/go-bank/internal/vpa/service"
 	"github.com/skamranahmed/go-bank/pkg/database"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
 	"github.com/uptrace/bun"
 )
 
 type transferController struct {
-	db              *bun.DB
-	transferService transferService.TransferService
-	accountService  accountService.AccountService
+	db                 *bun.DB
+	transferService    transferService.TransferService
+	accountService     accountService.AccountService
+	beneficiaryService beneficiaryService.BeneficiaryService
+	userService        userService.UserService
+	vpaService         vpaService.VPAService
+	taskEnqueuer       tasksHelper.TaskEnqueuer
 }
 
 func newTransferController(dependency Dependency) TransferController {
 	return &transferController{
-		db:              dependency.Db,
-		transferService: dependency.TransferService,
-		accountService:  dependency.AccountService,
+		db:                 dependency.Db,
+		transferService:    dependency.TransferService,
+		accountService:     dependency.AccountService,
+		beneficiaryService: dependency.BeneficiaryService,
+		userService:        dependency.UserService,
+		vpaService:         dependency.VPAService,
+		taskEnqueuer:       dependency.TaskEnqueuer,
 	}
 }
 
 func (c *transferController) PerformInternalTransfer(ginCtx *gin.Context) {
 	requestCtx := ginCtx.Request.Context()
 
-	// extract user ID from the request context
-	userID, ok := requestCtx.Value(middleware.ContextUserIDKey).(string)
-	if !ok || userID == "" {
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.InternalTransferRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	// resolve the recipient referenced by a saved beneficiary to its account number
+	var beneficiary *beneficiaryModel.Beneficiary
+	if payload.Data.BeneficiaryID != "" {
+		if payload.Data.ToAccountID != 0 || payload.Data.ToIBAN != "" {
+			server.SendErrorResponse(ginCtx, &server.ApiError{
+				HttpStatusCode: http.StatusBadRequest,
+				Message:        "beneficiary_id cannot be combined with to_account_id or to_iban",
+			})
+			return
+		}
+
+		beneficiary, ok = c.getOwnedBeneficiary(ginCtx, userID.String(), payload.Data.BeneficiaryID)
+		if !ok {
+			return
+		}
+		payload.Data.ToAccountID = beneficiary.AccountID
+	}
+
+	// resolve the recipient referenced by its VPA to the account linked to it
+	if payload.Data.ToVPA != "" {
+		if payload.Data.ToAccountID != 0 || payload.Data.ToIBAN != "" || payload.Data.BeneficiaryID != "" {
+			server.SendErrorResponse(ginCtx, &server.ApiError{
+				HttpStatusCode: http.StatusBadRequest,
+				Message:        "to_vpa cannot be combined with to_account_id, to_iban or beneficiary_id",
+			})
+			return
+		}
+
+		vpa, err := c.vpaService.ResolveVPA(requestCtx, nil, payload.Data.ToVPA)
+		if err != nil {
+			server.SendErrorResponse(ginCtx, err)
+			return
+		}
+		payload.Data.ToAccountID = vpa.AccountID
+	}
+
+	toAccountID, ok := c.resolveRecipientAccountID(ginCtx, payload.Data.ToAccountID, payload.Data.ToIBAN)
+	if !ok {
+		return
+	}
+	payload.Data.ToAccountID = toAccountID
+
+	fromAccount, ok := c.getTransferAccounts(ginCtx, userID, payload.Data.FromAccountID, payload.Data.ToAccountID)
+	if !ok {
+		return
+	}
+
+	// under the JOINTLY mandate, a transfer above the threshold of the account waits for the approval of every holder
+	requiresJointApproval, err := c.transferService.RequiresJointApproval(requestCtx, nil, fromAccount, *payload.Data.Amount)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+	if requiresJointApproval {
+		c.createJointTransfer(ginCtx, userID, beneficiary, payload.Data)
+		return
+	}
+
+	var senderAccountTransaction *model.Transaction
+	err = database.RunInTransaction(requestCtx, "createInternalTransfer", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		// transfers to a newly added beneficiary count towards its cooling period limit
+		if beneficiary != nil {
+			_, err := c.beneficiaryService.RecordTransfer(txCtx, tx, beneficiary.ID, *payload.Data.Amount, time.Now().UTC())
+			if err != nil {
+				return err
+			}
+		}
+
+		remittance := toRemittance(payload.Data.Narration, payload.Data.ClientReference, payload.Data.Category)
+
+		// a transfer between accounts held in different currencies is made at the amounts of its FX quote
+		if payload.Data.FXQuoteID != "" {
+			var err error
+			senderAccountTransaction, err = c.transferService.CreateCrossCurrencyTransfer(txCtx, tx, types.CreateCrossCurrencyTransferParams{
+				UserID:        userID,
+				FXQuoteID:     uuid.MustParse(payload.Data.FXQuoteID), // the format has already been validated by the binding
+				FromAccountID: payload.Data.FromAccountID,
+				ToAccountID:   payload.Data.ToAccountID,
+				Amount:        *payload.Data.Amount,
+				Remittance:    remittance,
+			})
+			return err
+		}
+
+		var err error
+		senderAccountTransaction, err = c.transferService.CreateInternalTransfer(
+			txCtx,
+			tx,
+			userID,
+			payload.Data.FromAccountID,
+			payload.Data.ToAccountID,
+			*payload.Data.Amount,
+			remittance,
+		)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	transactionDto := accountTypes.TransformToTransactionDto(senderAccountTransaction)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.InternalTransferResponse{
+		Data: types.InternalTransferResponseData{
+			Transaction: *transactionDto,
+		},
+	})
+}
+
+// createJointTransfer records the internal transfer as a joint transfer, approved by its initiator, and responds with 202 as it is not executed yet
+func (c *transferController) createJointTransfer(ginCtx *gin.Context, userID uuid.UUID, beneficiary *beneficiaryModel.Beneficiary, data types.InternalTransferRequestData) {
+	requestCtx := ginCtx.Request.Context()
+
+	// the rate of an FX quote would expire long before the other holders approve the transfer
+	if data.FXQuoteID != "" {
 		server.SendErrorResponse(ginCtx, &server.ApiError{
-			HttpStatusCode: http.StatusUnauthorized,
-			Message:        "User not authenticated",
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "A transfer that needs the approval of every holder of the account cannot be made at the rate of an FX quote",
 		})
 		return
 	}
 
-	var payload types.InternalTransferRequest
+	var jointTransfer *transferModel.JointTransfer
+	err := database.RunInTransaction(requestCtx, "createJointTransfer", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		// transfers to a newly added beneficiary count towards its cooling period limit as soon as they are initiated
+		if beneficiary != nil {
+			_, err := c.beneficiaryService.RecordTransfer(txCtx, tx, beneficiary.ID, *data.Amount, time.Now().UTC())
+			if err != nil {
+				return err
+			}
+		}
+
+		var err error
+		jointTransfer, err = c.transferService.CreateJointTransfer(txCtx, tx, types.CreateJointTransferParams{
+			InitiatedByUserID: userID,
+			FromAccountID:     data.FromAccountID,
+			ToAccountID:       data.ToAccountID,
+			Amount:            *data.Amount,
+			Remittance:        toRemittance(data.Narration, data.ClientReference, data.Category),
+		})
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	jointTransferDto := types.TransformToJointTransferDto(jointTransfer, nil)
+	server.SendSuccessResponse(ginCtx, http.StatusAccepted, types.JointTransferResponse{
+		Data: *jointTransferDto,
+	})
+}
+
+func (c *transferController) CreateScheduledTransfer(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.CreateScheduledTransferRequest
 	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
 	if !isSuccess {
 		return
 	}
 
+	// the format has already been validated by the binding
+	scheduledAt, _ := time.Parse(time.RFC3339, payload.Data.ScheduledAt)
+
+	toAccountID, ok := c.resolveRecipientAccountID(ginCtx, payload.Data.ToAccountID, payload.Data.ToIBAN)
+	if !ok {
+		return
+	}
+
+	fromAccount, ok := c.getTransferAccounts(ginCtx, userID, payload.Data.FromAccountID, toAccountID)
+	if !ok {
+		return
+	}
+
+	ok = c.rejectJointApprovalTransfer(ginCtx, fromAccount, *payload.Data.Amount)
+	if !ok {
+		return
+	}
+
+	/*
+		The execution is enqueued within the database transaction, so that a scheduled transfer is never stored without it.
+		If the transaction fails to commit after enqueuing, the task finds no scheduled transfer and does nothing.
+		Should the enqueued task be lost, the transfer is picked up once overdue by ExecuteOverdueScheduledTransfersTask.
+	*/
+	var scheduledTransfer *transferModel.ScheduledTransfer
+	err := database.RunInTransaction(requestCtx, "createScheduledTransfer", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		scheduledTransfer, err = c.transferService.CreateScheduledTransfer(txCtx, tx, types.CreateScheduledTransferParams{
+			UserID:        userID,
+			FromAccountID: payload.Data.FromAccountID,
+			ToAccountID:   toAccountID,
+			Amount:        *payload.Data.Amount,
+			ScheduledAt:   scheduledAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.taskEnqueuer.EnqueueAt(txCtx, transferTasks.NewExecuteScheduledTransferTask(scheduledTransfer.ID.String()), scheduledTransfer.ScheduledAt, nil, nil)
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusCreated, types.CreateScheduledTransferResponse{
+		Data: *types.TransformToScheduledTransferDto(scheduledTransfer),
+	})
+}
+
+func (c *transferController) GetScheduledTransfers(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var query types.GetScheduledTransfersRequestQuery
+	isSuccess := server.BindAndValidateIncomingRequestQuery(ginCtx, &query)
+	if !isSuccess {
+		return
+	}
+
+	listOptions := types.ScheduledTransferListOptions{
+		UserID: &userID,
+	}
+	if query.Status != "" {
+		status := transferModel.ScheduledTransferStatus(query.Status)
+		listOptions.Status = &status
+	}
+
+	scheduledTransfers, err := c.transferService.ListScheduledTransfers(requestCtx, nil, listOptions)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetScheduledTransfersResponse{
+		Data: types.TransformToScheduledTransferDtoList(scheduledTransfers),
+	})
+}
+
+func (c *transferController) CancelScheduledTransfer(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	// extract scheduled transfer ID from URL parameter
+	scheduledTransferID, err := uuid.Parse(ginCtx.Param("scheduled_transfer_id"))
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid scheduled transfer ID",
+		})
+		return
+	}
+
+	scheduledTransfer, err := c.transferService.GetScheduledTransfer(requestCtx, nil, types.ScheduledTransferQueryOptions{
+		ID: &scheduledTransferID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// authorization check: verify scheduled transfer belongs to authenticated user
+	if scheduledTransfer.UserID != userID {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You do not have permission to access this scheduled transfer",
+		})
+		return
+	}
+
+	err = database.RunInTransaction(requestCtx, "cancelScheduledTransfer", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		scheduledTransfer, err = c.transferService.CancelScheduledTransfer(txCtx, tx, scheduledTransferID)
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.CancelScheduledTransferResponse{
+		Data: *types.TransformToScheduledTransferDto(scheduledTransfer),
+	})
+}
+
+// resolveRecipientAccountID resolves the recipient referenced by its IBAN to its account number, sending the error response when it cannot be resolved
+func (c *transferController) resolveRecipientAccountID(ginCtx *gin.Context, toAccountID int64, toIBAN string) (int64, bool) {
+	if toIBAN == "" {
+		return toAccountID, true
+	}
+
+	ibanAccountID, err := c.accountService.ResolveIBAN(ginCtx.Request.Context(), nil, toIBAN)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return 0, false
+	}
+
+	if toAccountID != 0 && toAccountID != ibanAccountID {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "to_account_id and to_iban refer to different accounts",
+		})
+		return 0, false
+	}
+
+	return ibanAccountID, true
+}
+
+// rejectJointApprovalTransfer sends the error response and returns false when a transfer of the amount needs the approval of every holder of the account,
+// for the transfers that are not made right away and cannot wait for those approvals
+func (c *transferController) rejectJointApprovalTransfer(ginCtx *gin.Context, fromAccount *model.Account, amount int64) bool {
+	err := c.transferService.EnforceJointMandate(ginCtx.Request.Context(), nil, fromAccount, amount)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return false
+	}
+	return true
+}
+
+// getTransferAccounts verifies that the authenticated user can transfer between the two accounts and returns the sender account, sending the error response when they cannot
+func (c *transferController) getTransferAccounts(ginCtx *gin.Context, userID uuid.UUID, fromAccountID, toAccountID int64) (*model.Account, bool) {
+	requestCtx := ginCtx.Request.Context()
+
 	// validate that from and to account ids are different
 	// because transferring to the same account doesn't make sense
-	if payload.Data.FromAccountID == payload.Data.ToAccountID {
+	if fromAccountID == toAccountID {
 		server.SendErrorResponse(ginCtx, &server.ApiError{
 			HttpStatusCode: http.StatusBadRequest,
 			Message:        "Sender and recipient account ids must be different",
 		})
-		return
+		return nil, false
 	}
 
 	// existence check for the sender account
 	fromAccount, err := c.accountService.GetAccount(requestCtx, nil, accountTypes.AccountQueryOptions{
-		AccountID: &payload.Data.FromAccountID,
-		Columns:   []string{"user_id"},
+		AccountID: &fromAccountID,
+		Columns:   []string{"id", "user_id", "type", "mandate", "joint_approval_threshold"},
 	})
 	if err != nil {
 		server.SendErrorResponse(ginCtx, err)
-		return
+		return nil, false
 	}
 
-	// authorization check that sender account belongs to the authenticated user
-	if fromAccount.UserID.String() != userID {
+	// authorization check that the authenticated user is one of the holders of the sender account
+	isAccountHolder, err := c.accountService.IsAccountHolder(requestCtx, nil, fromAccount, userID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return nil, false
+	}
+	if !isAccountHolder {
 		server.SendErrorResponse(ginCtx, &server.ApiError{
 			HttpStatusCode: http.StatusForbidden,
 			Message:        "You are not authorized to perform transfer from this account",
 		})
-		return
+		return nil, false
 	}
 
 	// existence check for the receiver account
-	_, err = c.accountService.GetAccount(requestCtx, nil, accountTypes.AccountQueryOptions{
-		AccountID: &payload.Data.ToAccountID,
-		Columns:   []string{"id"},
+	toAccount, err := c.accountService.GetAccount(requestCtx, nil, accountTypes.AccountQueryOptions{
+		AccountID: &toAccountID,
+		Columns:   []string{"id", "type"},
 	})
 	if err != nil {
 		server.SendErrorResponse(ginCtx, err)
+		return nil, false
+	}
+
+	// deposit accounts are funded and paid out only by the deposit product itself
+	if !fromAccount.Type.AllowsTransfers() || !toAccount.Type.AllowsTransfers() {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Transfers are only allowed between savings and current accounts",
+		})
+		return nil, false
+	}
+
+	return fromAccount, true
+}
+
+// getOwnedBeneficiary fetches the beneficiary and verifies it belongs to the authenticated user, sending the error response when it does not
+func (c *transferController) getOwnedBeneficiary(ginCtx *gin.Context, userID string, beneficiaryID string) (*beneficiaryModel.Beneficiary, bool) {
+	beneficiaryUUID, err := uuid.Parse(beneficiaryID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid beneficiary ID",
+		})
+		return nil, false
+	}
+
+	beneficiary, err := c.beneficiaryService.GetBeneficiary(ginCtx.Request.Context(), nil, beneficiaryTypes.BeneficiaryQueryOptions{
+		ID: &beneficiaryUUID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return nil, false
+	}
+
+	// authorization check: verify beneficiary belongs to authenticated user
+	if beneficiary.UserID.String() != userID {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You do not have permission to access this beneficiary",
+		})
+		return nil, false
+	}
+
+	return beneficiary, true
+}
+
+// GetTransferLimits returns the limits in effect on the transfers out of the savings and current accounts of the authenticated user
+func (c *transferController) GetTransferLimits(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
 		return
 	}
 
-	var senderAccountTransaction *model.Transaction
-	err = database.RunInTransaction(requestCtx, "createInternalTransfer", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
-		senderAccountTransaction, err = c.transferService.CreateInternalTransfer(
-			txCtx,
-			tx,
-			fromAccount.UserID,
-			payload.Data.FromAccountID,
-			payload.Data.ToAccountID,
-			*payload.Data.Amount,
-		)
+	transferLimitsDtos := []types.TransferLimitsDto{}
+	for _, accountType := range []model.AccountType{model.SavingsAccount, model.CurrentAccount} {
+		limits, err := c.transferService.GetTransferLimits(requestCtx, nil, userID, accountType)
+		if err != nil {
+			server.SendErrorResponse(ginCtx, err)
+			return
+		}
+		transferLimitsDtos = append(transferLimitsDtos, *types.TransformToTransferLimitsDto(accountType, limits))
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetTransferLimitsResponse{
+		Data: transferLimitsDtos,
+	})
+}
+
+// UpdateTransferLimits lets the authenticated user lower their own transfer limits, raising them is reserved to admins
+func (c *transferController) UpdateTransferLimits(ginCtx *gin.Context) {
+	userID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	c.setTransferLimits(ginCtx, userID, false)
+}
+
+// UpdateUserTransferLimits lets an admin set the transfer limits of any user, including raising them
+func (c *transferController) UpdateUserTransferLimits(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	// extract user ID from URL parameter
+	userIDParam := ginCtx.Param("user_id")
+	userID, err := uuid.Parse(userIDParam)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid user ID",
+		})
+		return
+	}
+
+	// existence check for the user
+	_, err = c.userService.GetUser(requestCtx, nil, userTypes.UserQueryOptions{
+		ID:      &userIDParam,
+		Columns: []string{"id"},
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	c.setTransferLimits(ginCtx, userID, true)
+}
+
+func (c *transferController) setTransferLimits(ginCtx *gin.Context, userID uuid.UUID, allowRaise bool) {
+	requestCtx := ginCtx.Request.Context()
+
+	var payload types.UpdateTransferLimitsRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	accountType := model.AccountType(payload.Data.AccountType)
+
+	var limits *types.TransferLimits
+	err := database.RunInTransaction(requestCtx, "setTransferLimits", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		limits, err = c.transferService.SetTransferLimits(txCtx, tx, types.SetTransferLimitsParams{
+			UserID:               userID,
+			AccountType:          accountType,
+			PerTransactionAmount: payload.Data.PerTransactionAmount,
+			DailyCount:           payload.Data.DailyCount,
+			DailyAmount:          payload.Data.DailyAmount,
+			MonthlyAmount:        payload.Data.MonthlyAmount,
+			AllowRaise:           allowRaise,
+		})
 		return err
 	})
 	if err != nil {
@@ -105,11 +581,46 @@ func (c *transferController) PerformInternalTransfer(ginCtx *gin.Context) {
 		return
 	}
 
-	// transform to DTO and return response
-	transactionDto := accountTypes.TransformToTransactionDto(senderAccountTransaction)
-	server.SendSuccessResponse(ginCtx, http.StatusOK, types.InternalTransferResponse{
-		Data: types.InternalTransferResponseData{
-			Transaction: *transactionDto,
-		},
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.UpdateTransferLimitsResponse{
+		Data: *types.TransformToTransferLimitsDto(accountType, limits),
 	})
 }
+
+// toRemittance converts the optional remittance fields of a transfer request, leaving out the ones that were not provided
+func toRemittance(narration, clientReference, category string) accountTypes.Remittance {
+	var remittance accountTypes.Remittance
+	if narration != "" {
+		remittance.Narration = &narration
+	}
+	if clientReference != "" {
+		remittance.ClientReference = &clientReference
+	}
+	if category != "" {
+		transactionCategory := model.TransactionCategory(category)
+		remittance.Category = &transactionCategory
+	}
+	return remittance
+}
+
+// getAuthenticatedUserID extracts the ID of the authenticated user from the request context, sending the error response when it is missing
+func getAuthenticatedUserID(ginCtx *gin.Context) (uuid.UUID, bool) {
+	userID, ok := ginCtx.Request.Context().Value(middleware.ContextUserIDKey).(string)
+	if !ok || userID == "" {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusUnauthorized,
+			Message:        "User not authenticated",
+		})
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid user ID",
+		})
+		return uuid.Nil, false
+	}
+
+	return userUUID, true
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/uptrace/bun"
+)
+
+func (c *transferController) ReverseTransfer(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	// the authenticated user is the admin reversing the transfer
+	adminID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	transactionID, err := uuid.Parse(ginCtx.Param("transaction_id"))
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid transaction ID",
+		})
+		return
+	}
+
+	var payload types.ReverseTransferRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	var transferReversal *model.TransferReversal
+	err = database.RunInTransaction(requestCtx, "reverseTransfer", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		transferReversal, err = c.transferService.ReverseTransfer(txCtx, tx, types.ReverseTransferParams{
+			TransactionID: transactionID,
+			InitiatedBy:   adminID,
+			Reason:        payload.Data.Reason,
+		})
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusCreated, types.ReverseTransferResponse{
+		Data: *types.TransformToTransferReversalDto(transferReversal),
+	})
+}
+
+func (c *transferController) GetTransferReversals(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	var query types.GetTransferReversalsRequestQuery
+	isSuccess := server.BindAndValidateIncomingRequestQuery(ginCtx, &query)
+	if !isSuccess {
+		return
+	}
+
+	var listOptions types.TransferReversalListOptions
+	if query.Status != "" {
+		status := model.TransferReversalStatus(query.Status)
+		listOptions.Status = &status
+	}
+
+	transferReversals, err := c.transferService.ListTransferReversals(requestCtx, nil, listOptions)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetTransferReversalsResponse{
+		Data: types.TransformToTransferReversalDtoList(transferReversals),
+	})
+}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/uptrace/bun"
+)
+
+/*
+BulkTransfer is a batch of internal transfers uploaded by a business customer as a CSV file, every row of it is a BulkTransferItem
+
+The file is validated as soon as it is uploaded, a batch without any invalid row waits for the customer to confirm it
+after reviewing its total amount and fee. A confirmed batch is executed row by row by the worker.
+*/
+type BulkTransfer struct {
+	bun.BaseModel `bun:"table:bulk_transfers"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "users" table
+	UserID uuid.UUID       `bun:"user_id,notnull,type:uuid"`
+	User   *userModel.User `bun:"rel:belongs-to,join:user_id=id"`
+
+	// foreign key to "accounts" table, the current account every transfer of the batch is paid from
+	FromAccountID int64                 `bun:"from_account_id,notnull"`
+	FromAccount   *accountModel.Account `bun:"rel:belongs-to,join:from_account_id=id"`
+
+	FileName string             `bun:"file_name,notnull"`
+	Status   BulkTransferStatus `bun:"status,notnull"`
+
+	// StopOnInsufficientFunds stops the whole batch at the first row the account cannot afford, instead of failing the row and moving on
+	StopOnInsufficientFunds bool `bun:"stop_on_insufficient_funds,notnull,default:false"`
+
+	// ItemCount is the number of rows of the file, TotalAmount the sum of their amounts in the smallest currency unit (paise for INR)
+	ItemCount   int   `bun:"item_count,notnull,default:0"`
+	TotalAmount int64 `bun:"total_amount,notnull,default:0"`
+
+	// EstimatedFee is the fee (including its tax) the transfers were estimated to cost at the time of the upload
+	EstimatedFee int64 `bun:"estimated_fee,notnull,default:0"`
+
+	// number of rows by their outcome, set once the batch has been executed
+	SucceededCount int `bun:"succeeded_count,notnull,default:0"`
+	FailedCount    int `bun:"failed_count,notnull,default:0"`
+	SkippedCount   int `bun:"skipped_count,notnull,default:0"`
+
+	// StoppedAtLineNumber is the row the account could not afford, for a batch stopped on insufficient funds
+	StoppedAtLineNumber *int `bun:"stopped_at_line_number"`
+
+	ConfirmedAt *time.Time `bun:"confirmed_at"`
+	CompletedAt *time.Time `bun:"completed_at"`
+}
+
+type BulkTransferStatus string
+
+const (
+	BulkTransferValidated          BulkTransferStatus = "VALIDATED"           // every row is valid, waiting for the customer to confirm the batch
+	BulkTransferInvalid            BulkTransferStatus = "INVALID"             // some rows are invalid, the batch cannot be confirmed
+	BulkTransferCancelled          BulkTransferStatus = "CANCELLED"           // cancelled by the customer before confirming it
+	BulkTransferQueued             BulkTransferStatus = "QUEUED"              // confirmed, waiting for the worker
+	BulkTransferProcessing         BulkTransferStatus = "PROCESSING"          // being executed by the worker
+	BulkTransferCompleted          BulkTransferStatus = "COMPLETED"           // every row succeeded
+	BulkTransferPartiallyCompleted BulkTransferStatus = "PARTIALLY_COMPLETED" // some rows failed, the others succeeded
+	BulkTransferFailed             BulkTransferStatus = "FAILED"              // no row succeeded
+	BulkTransferStopped            BulkTransferStatus = "STOPPED"             // stopped on insufficient funds, the rows after it were skipped
+)
+
+// IsFinished reports whether the batch has been executed, whatever the outcome of its rows
+func (b *BulkTransfer) IsFinished() bool {
+	return b.CompletedAt != nil
+}
+
+// BulkTransferItem is a single row of a bulk transfer file, an internal transfer of its batch
+type BulkTransferItem struct {
+	bun.BaseModel `bun:"table:bulk_transfer_items"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "bulk_transfers" table, the line number is unique within the batch
+	BulkTransferID uuid.UUID     `bun:"bulk_transfer_id,notnull,type:uuid,unique:bulk_transfer_items_bulk_transfer_id_line_number_unique"`
+	BulkTransfer   *BulkTransfer `bun:"rel:belongs-to,join:bulk_transfer_id=id"`
+	LineNumber     int           `bun:"line_number,notnull,unique:bulk_transfer_items_bulk_transfer_id_line_number_unique"`
+
+	// ToAccountID and Amount are 0 for an invalid row that could not be parsed, Amount is in the smallest currency unit (paise for INR)
+	ToAccountID int64   `bun:"to_account_id,notnull"`
+	Amount      int64   `bun:"amount,notnull"`
+	Narration   *string `bun:"narration"`
+
+	Status BulkTransferItemStatus `bun:"status,notnull"`
+
+	// Reason is why the row is invalid, failed or was skipped
+	Reason *string `bun:"reason"`
+
+	// RawRow is the line of the file, only kept for an invalid row since it may not have been parsed
+	RawRow *string `bun:"raw_row"`
+
+	// foreign key to "transactions" table, the debit of the account the batch is paid from
+	TransactionID *uuid.UUID                `bun:"transaction_id,type:uuid"`
+	Transaction   *accountModel.Transaction `bun:"rel:belongs-to,join:transaction_id=id"`
+
+	ProcessedAt *time.Time `bun:"processed_at"`
+}
+
+type BulkTransferItemStatus string
+
+const (
+	BulkTransferItemPending   BulkTransferItemStatus = "PENDING"   // valid, waiting for the batch to be executed
+	BulkTransferItemInvalid   BulkTransferItemStatus = "INVALID"   // rejected when the file was validated
+	BulkTransferItemSucceeded BulkTransferItemStatus = "SUCCEEDED" // the transfer was made
+	BulkTransferItemFailed    BulkTransferItemStatus = "FAILED"    // the transfer was rejected, see the reason
+	BulkTransferItemSkipped   BulkTransferItemStatus = "SKIPPED"   // not attempted because the batch was stopped on insufficient funds
+)
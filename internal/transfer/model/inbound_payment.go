@@ -0,0 +1,71 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/uptrace/bun"
+)
+
+/*
+InboundPayment is a payment received from another bank, a single record of a clearing file
+
+A payment matched to an account is credited to it straight away, one that cannot be matched is parked in the suspense queue
+until an admin assigns it to an account or returns it to the remitter. A record that is not a valid payment is rejected.
+*/
+type InboundPayment struct {
+	bun.BaseModel `bun:"table:inbound_payments"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "inbound_payment_files" table
+	FileID     uuid.UUID           `bun:"file_id,notnull,type:uuid"`
+	File       *InboundPaymentFile `bun:"rel:belongs-to,join:file_id=id"`
+	LineNumber int                 `bun:"line_number,notnull"`
+
+	// UTR is the reference assigned to the payment by the rail, a payment is only accepted once per UTR
+	UTR string `bun:"utr,notnull"`
+
+	// Amount is stored in the smallest currency unit (paise for INR), it is 0 for a record rejected for its amount
+	Amount int64 `bun:"amount,notnull"`
+
+	BeneficiaryAccountNumber string `bun:"beneficiary_account_number,notnull"`
+	BeneficiaryIFSC          string `bun:"beneficiary_ifsc,notnull"`
+	RemitterName             string `bun:"remitter_name,notnull"`
+	RemitterAccountNumber    string `bun:"remitter_account_number,notnull"`
+	RemitterIFSC             string `bun:"remitter_ifsc,notnull"`
+
+	Status InboundPaymentStatus `bun:"status,notnull"`
+
+	// Reason is why the payment was parked in the suspense queue, rejected or returned
+	Reason *string `bun:"reason"`
+
+	// RawRecord is the line of the clearing file, only kept for a rejected record since it may not have been parsed
+	RawRecord *string `bun:"raw_record"`
+
+	// foreign key to "accounts" table, the account the payment was credited to
+	AccountID *int64                `bun:"account_id"`
+	Account   *accountModel.Account `bun:"rel:belongs-to,join:account_id=id"`
+
+	// foreign key to "transactions" table, the credit of the account
+	TransactionID *uuid.UUID                `bun:"transaction_id,type:uuid"`
+	Transaction   *accountModel.Transaction `bun:"rel:belongs-to,join:transaction_id=id"`
+
+	// foreign key to "users" table, the admin who assigned or returned a payment of the suspense queue
+	ResolvedBy     *uuid.UUID      `bun:"resolved_by,type:uuid"`
+	ResolvedByUser *userModel.User `bun:"rel:belongs-to,join:resolved_by=id"`
+	ResolvedAt     *time.Time      `bun:"resolved_at"`
+}
+
+type InboundPaymentStatus string
+
+const (
+	InboundPaymentCredited InboundPaymentStatus = "CREDITED" // credited to the account of the beneficiary
+	InboundPaymentSuspense InboundPaymentStatus = "SUSPENSE" // could not be matched to an account, waiting for an admin
+	InboundPaymentReturned InboundPaymentStatus = "RETURNED" // taken out of the suspense queue and returned to the remitter by an admin
+	InboundPaymentRejected InboundPaymentStatus = "REJECTED" // not a valid payment, it was never accepted
+)
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/skamranahmed/go-bank/pkg/clearingfile"
+	"github.com/uptrace/bun"
+)
+
+// InboundPaymentFile is a clearing file listing the payments received from other banks, every record of it is an InboundPayment
+type InboundPaymentFile struct {
+	bun.BaseModel `bun:"table:inbound_payment_files"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	FileName string                   `bun:"file_name,notnull"`
+	Format   clearingfile.Format      `bun:"format,notnull"`
+	Source   InboundPaymentFileSource `bun:"source,notnull"`
+
+	// Checksum is the SHA-256 of the content of the file, it is unique so that the same file is never ingested twice
+	Checksum string `bun:"checksum,notnull,unique"`
+
+	// foreign key to "users" table, the admin who uploaded the file, nil for a file picked up from the inbound directory
+	UploadedBy     *uuid.UUID      `bun:"uploaded_by,type:uuid"`
+	UploadedByUser *userModel.User `bun:"rel:belongs-to,join:uploaded_by=id"`
+
+	// number of records of the file by their outcome at the time of ingestion
+	RecordCount   int `bun:"record_count,notnull,default:0"`
+	CreditedCount int `bun:"credited_count,notnull,default:0"`
+	SuspenseCount int `bun:"suspense_count,notnull,default:0"`
+	RejectedCount int `bun:"rejected_count,notnull,default:0"`
+}
+
+type InboundPaymentFileSource string
+
+const (
+	InboundPaymentFileUpload    InboundPaymentFileSource = "UPLOAD"    // uploaded by an admin
+	InboundPaymentFileDirectory InboundPaymentFileSource = "DIRECTORY" // picked up from the inbound directory by the worker
+)
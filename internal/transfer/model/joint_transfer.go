@@ -0,0 +1,78 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/uptrace/bun"
+)
+
+// JointTransfer is an internal transfer out of a joint account held under the JOINTLY mandate, it is executed once every holder has approved it
+type JointTransfer struct {
+	bun.BaseModel `bun:"table:joint_transfers"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "users" table, the holder who initiated the transfer
+	InitiatedByUserID uuid.UUID       `bun:"initiated_by_user_id,notnull,type:uuid"`
+	InitiatedByUser   *userModel.User `bun:"rel:belongs-to,join:initiated_by_user_id=id"`
+
+	// foreign keys to "accounts" table
+	FromAccountID int64                 `bun:"from_account_id,notnull"`
+	FromAccount   *accountModel.Account `bun:"rel:belongs-to,join:from_account_id=id"`
+	ToAccountID   int64                 `bun:"to_account_id,notnull"`
+	ToAccount     *accountModel.Account `bun:"rel:belongs-to,join:to_account_id=id"`
+
+	// Amount is stored in the smallest currency unit (paise for INR)
+	Amount int64 `bun:"amount,notnull"`
+
+	// the remittance information of the initiator, attached to the transfer once it is executed
+	Narration       *string                           `bun:"narration"`
+	ClientReference *string                           `bun:"client_reference"`
+	Category        *accountModel.TransactionCategory `bun:"category"`
+
+	Status JointTransferStatus `bun:"status,notnull,default:'PENDING'"`
+
+	// foreign key to "transactions" table, the debit transaction of the sender account once the transfer is executed
+	TransactionID *uuid.UUID                `bun:"transaction_id,type:uuid"`
+	Transaction   *accountModel.Transaction `bun:"rel:belongs-to,join:transaction_id=id"`
+
+	ExecutedAt *time.Time `bun:"executed_at"`
+}
+
+type JointTransferStatus string
+
+const (
+	JointTransferPending   JointTransferStatus = "PENDING"
+	JointTransferCompleted JointTransferStatus = "COMPLETED"
+	JointTransferRejected  JointTransferStatus = "REJECTED" // one of the holders rejected the transfer, or its initiator left the account
+)
+
+// JointTransferApproval is the decision of one of the holders of the account on a joint transfer
+type JointTransferApproval struct {
+	bun.BaseModel `bun:"table:joint_transfer_approvals"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+
+	// foreign key to "joint_transfers" table
+	JointTransferID uuid.UUID      `bun:"joint_transfer_id,notnull,type:uuid"`
+	JointTransfer   *JointTransfer `bun:"rel:belongs-to,join:joint_transfer_id=id"`
+
+	// foreign key to "users" table, a holder decides on a transfer only once
+	UserID uuid.UUID       `bun:"user_id,notnull,type:uuid"`
+	User   *userModel.User `bun:"rel:belongs-to,join:user_id=id"`
+
+	Decision JointTransferDecision `bun:"decision,notnull"`
+}
+
+type JointTransferDecision string
+
+const (
+	JointTransferApproved         JointTransferDecision = "APPROVED"
+	JointTransferDecisionRejected JointTransferDecision = "REJECTED"
+)
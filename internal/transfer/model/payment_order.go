@@ -0,0 +1,84 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/uptrace/bun"
+)
+
+/*
+PaymentOrder is a transfer to an account at another bank, sent over one of the payment rails
+
+The amount is debited from the customer into the outbound clearing account as soon as the order is created,
+it leaves the bank once the rail settles the order or is credited back to the customer when the beneficiary bank returns it.
+*/
+type PaymentOrder struct {
+	bun.BaseModel `bun:"table:payment_orders"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "users" table
+	UserID uuid.UUID       `bun:"user_id,notnull,type:uuid"`
+	User   *userModel.User `bun:"rel:belongs-to,join:user_id=id"`
+
+	// foreign key to "accounts" table
+	FromAccountID int64                 `bun:"from_account_id,notnull"`
+	FromAccount   *accountModel.Account `bun:"rel:belongs-to,join:from_account_id=id"`
+
+	// Amount is stored in the smallest currency unit (paise for INR)
+	Amount int64 `bun:"amount,notnull"`
+
+	Rail Rail `bun:"rail,notnull"`
+
+	// the beneficiary is identified by the account number at its bank and the IFSC of its branch
+	BeneficiaryName          string `bun:"beneficiary_name,notnull"`
+	BeneficiaryAccountNumber string `bun:"beneficiary_account_number,notnull"`
+	BeneficiaryIFSC          string `bun:"beneficiary_ifsc,notnull"`
+
+	Status PaymentOrderStatus `bun:"status,notnull,default:'INITIATED'"`
+
+	// RailReference is the reference assigned to the order by the rail once it is sent (the UTR)
+	RailReference *string `bun:"rail_reference"`
+	ReturnReason  *string `bun:"return_reason"`
+
+	// foreign keys to "transactions" table
+	// DebitTransactionID is the debit of the customer account when the order was created
+	// ReturnTransactionID is the credit back to the customer account when the order is returned
+	DebitTransactionID  uuid.UUID                 `bun:"debit_transaction_id,notnull,type:uuid"`
+	DebitTransaction    *accountModel.Transaction `bun:"rel:belongs-to,join:debit_transaction_id=id"`
+	ReturnTransactionID *uuid.UUID                `bun:"return_transaction_id,type:uuid"`
+	ReturnTransaction   *accountModel.Transaction `bun:"rel:belongs-to,join:return_transaction_id=id"`
+
+	// DispatchAt is the time the order is sent over the rail at, e.g. the next NEFT batch
+	DispatchAt time.Time `bun:"dispatch_at,notnull"`
+
+	// OutcomeDueAt is the time the rail settles or returns the order at, it is set once the order is sent
+	OutcomeDueAt *time.Time `bun:"outcome_due_at"`
+
+	SentAt     *time.Time `bun:"sent_at"`
+	SettledAt  *time.Time `bun:"settled_at"`
+	ReturnedAt *time.Time `bun:"returned_at"`
+}
+
+// Rail is the clearing system an external transfer is sent over
+type Rail string
+
+const (
+	NEFT Rail = "NEFT" // sent in half-hourly batches within the day's window
+	IMPS Rail = "IMPS" // sent immediately, up to a maximum amount
+	RTGS Rail = "RTGS" // sent immediately, from a minimum amount
+)
+
+type PaymentOrderStatus string
+
+const (
+	PaymentOrderInitiated PaymentOrderStatus = "INITIATED" // debited from the customer, waiting to be sent over the rail
+	PaymentOrderSent      PaymentOrderStatus = "SENT"      // sent over the rail, waiting for its outcome
+	PaymentOrderSettled   PaymentOrderStatus = "SETTLED"   // credited to the beneficiary by its bank
+	PaymentOrderReturned  PaymentOrderStatus = "RETURNED"  // rejected by the beneficiary bank, the amount is credited back to the customer
+)
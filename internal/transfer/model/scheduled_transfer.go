@@ -0,0 +1,53 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/uptrace/bun"
+)
+
+// ScheduledTransfer is an internal transfer a user has asked to be executed at a future time
+type ScheduledTransfer struct {
+	bun.BaseModel `bun:"table:scheduled_transfers"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "users" table
+	UserID uuid.UUID       `bun:"user_id,notnull,type:uuid"`
+	User   *userModel.User `bun:"rel:belongs-to,join:user_id=id"`
+
+	// foreign keys to "accounts" table
+	FromAccountID int64                 `bun:"from_account_id,notnull"`
+	FromAccount   *accountModel.Account `bun:"rel:belongs-to,join:from_account_id=id"`
+	ToAccountID   int64                 `bun:"to_account_id,notnull"`
+	ToAccount     *accountModel.Account `bun:"rel:belongs-to,join:to_account_id=id"`
+
+	// Amount is stored in the smallest currency unit (paise for INR)
+	Amount int64 `bun:"amount,notnull"`
+
+	// ScheduledAt is the time the transfer is due to be executed at by the worker
+	ScheduledAt time.Time `bun:"scheduled_at,notnull"`
+
+	Status        ScheduledTransferStatus `bun:"status,notnull,default:'PENDING'"`
+	FailureReason *string                 `bun:"failure_reason"`
+
+	// foreign key to "transactions" table, the debit transaction of the sender account once the transfer is executed
+	TransactionID *uuid.UUID                `bun:"transaction_id,type:uuid"`
+	Transaction   *accountModel.Transaction `bun:"rel:belongs-to,join:transaction_id=id"`
+
+	ExecutedAt *time.Time `bun:"executed_at"`
+}
+
+type ScheduledTransferStatus string
+
+const (
+	ScheduledTransferPending   ScheduledTransferStatus = "PENDING"
+	ScheduledTransferCompleted ScheduledTransferStatus = "COMPLETED"
+	ScheduledTransferFailed    ScheduledTransferStatus = "FAILED" // the transfer was rejected at the due time, see the failure reason
+	ScheduledTransferCancelled ScheduledTransferStatus = "CANCELLED"
+)
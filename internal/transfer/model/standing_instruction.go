@@ -0,0 +1,97 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/uptrace/bun"
+)
+
+// StandingInstruction is a recurring internal transfer of a fixed amount, paid by the worker on every occurrence until its end date or number of occurrences
+type StandingInstruction struct {
+	bun.BaseModel `bun:"table:standing_instructions"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "users" table
+	UserID uuid.UUID       `bun:"user_id,notnull,type:uuid"`
+	User   *userModel.User `bun:"rel:belongs-to,join:user_id=id"`
+
+	// foreign keys to "accounts" table
+	FromAccountID int64                 `bun:"from_account_id,notnull"`
+	FromAccount   *accountModel.Account `bun:"rel:belongs-to,join:from_account_id=id"`
+	ToAccountID   int64                 `bun:"to_account_id,notnull"`
+	ToAccount     *accountModel.Account `bun:"rel:belongs-to,join:to_account_id=id"`
+
+	// Amount is stored in the smallest currency unit (paise for INR)
+	Amount int64 `bun:"amount,notnull"`
+
+	Frequency StandingInstructionFrequency `bun:"frequency,notnull"`
+
+	// DayOfMonth is the day a MONTHLY instruction is paid on, between 1 and 28 so that it exists in every month, a WEEKLY instruction is paid on the weekday of its start date
+	DayOfMonth *int `bun:"day_of_month"`
+
+	StartDate time.Time `bun:"start_date,notnull,type:date"`
+
+	// the instruction ends with the last occurrence due on or before EndDate, or after MaxOccurrences occurrences, whichever comes first
+	EndDate        *time.Time `bun:"end_date,type:date"`
+	MaxOccurrences *int       `bun:"max_occurrences"`
+
+	// OccurrenceCount is the number of occurrences that are over, whether they were paid or skipped
+	OccurrenceCount int `bun:"occurrence_count,notnull,default:0"`
+
+	// NextExecutionDate is the due date of the next occurrence, nil once the instruction has no occurrences left
+	NextExecutionDate *time.Time `bun:"next_execution_date,type:date"`
+
+	Status StandingInstructionStatus `bun:"status,notnull,default:'ACTIVE'"`
+
+	// ConsecutiveFailureCount is the number of failed attempts since the last successful payment
+	ConsecutiveFailureCount int        `bun:"consecutive_failure_count,notnull,default:0"`
+	LastAttemptedAt         *time.Time `bun:"last_attempted_at"`
+}
+
+type StandingInstructionFrequency string
+
+const (
+	StandingInstructionWeekly  StandingInstructionFrequency = "WEEKLY"
+	StandingInstructionMonthly StandingInstructionFrequency = "MONTHLY"
+)
+
+type StandingInstructionStatus string
+
+const (
+	StandingInstructionActive    StandingInstructionStatus = "ACTIVE"
+	StandingInstructionSuspended StandingInstructionStatus = "SUSPENDED" // too many failed attempts in a row, until the user resumes it
+	StandingInstructionCompleted StandingInstructionStatus = "COMPLETED" // no occurrences left
+	StandingInstructionCancelled StandingInstructionStatus = "CANCELLED"
+)
+
+// OccurrenceDate returns the due date of the given occurrence, the first occurrence is due on or after the start date
+func (si *StandingInstruction) OccurrenceDate(occurrenceNumber int) time.Time {
+	if si.Frequency == StandingInstructionWeekly {
+		return si.StartDate.AddDate(0, 0, 7*(occurrenceNumber-1))
+	}
+
+	firstMonth := si.StartDate.Month()
+	if *si.DayOfMonth < si.StartDate.Day() {
+		firstMonth++
+	}
+	return time.Date(si.StartDate.Year(), firstMonth+time.Month(occurrenceNumber-1), *si.DayOfMonth, 0, 0, 0, 0, time.UTC)
+}
+
+// NextOccurrenceDate returns the due date of the occurrence following the given number of occurrences, nil when the instruction has no occurrences left
+func (si *StandingInstruction) NextOccurrenceDate(occurrenceCount int) *time.Time {
+	if si.MaxOccurrences != nil && occurrenceCount >= *si.MaxOccurrences {
+		return nil
+	}
+
+	occurrenceDate := si.OccurrenceDate(occurrenceCount + 1)
+	if si.EndDate != nil && occurrenceDate.After(*si.EndDate) {
+		return nil
+	}
+	return &occurrenceDate
+}
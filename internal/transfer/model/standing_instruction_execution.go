@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/uptrace/bun"
+)
+
+// StandingInstructionExecution records a single attempt to pay an occurrence of a standing instruction
+type StandingInstructionExecution struct {
+	bun.BaseModel `bun:"table:standing_instruction_executions"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+
+	// foreign key to "standing_instructions" table
+	StandingInstructionID uuid.UUID            `bun:"standing_instruction_id,notnull,type:uuid"`
+	StandingInstruction   *StandingInstruction `bun:"rel:belongs-to,join:standing_instruction_id=id"`
+
+	OccurrenceNumber int       `bun:"occurrence_number,notnull"`
+	DueDate          time.Time `bun:"due_date,notnull,type:date"`
+
+	Status        StandingInstructionExecutionStatus `bun:"status,notnull"`
+	FailureReason *string                            `bun:"failure_reason"`
+
+	// foreign key to "transactions" table, the debit transaction of the sender account when the occurrence was paid
+	TransactionID *uuid.UUID                `bun:"transaction_id,type:uuid"`
+	Transaction   *accountModel.Transaction `bun:"rel:belongs-to,join:transaction_id=id"`
+}
+
+type StandingInstructionExecutionStatus string
+
+const (
+	StandingInstructionExecutionSucceeded StandingInstructionExecutionStatus = "SUCCEEDED"
+	StandingInstructionExecutionFailed    StandingInstructionExecutionStatus = "FAILED"  // the occurrence is attempted again the next day
+	StandingInstructionExecutionSkipped   StandingInstructionExecutionStatus = "SKIPPED" // the last failed attempt of the occurrence, which is not attempted again
+)
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/uptrace/bun"
+)
+
+/*
+TransferLimitOverride holds the transfer limits of a user that differ from the defaults of an account type
+
+A user can lower their own limits, only an admin can raise them above the current ones.
+A nil limit falls back to the default of the account type from the config.
+*/
+type TransferLimitOverride struct {
+	bun.BaseModel `bun:"table:transfer_limit_overrides"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "users" table
+	UserID uuid.UUID       `bun:"user_id,notnull,type:uuid,unique:transfer_limit_overrides_user_id_account_type_unique"`
+	User   *userModel.User `bun:"rel:belongs-to,join:user_id=id"`
+
+	// the limits apply to the transfers out of every account of the user of this type
+	AccountType accountModel.AccountType `bun:"account_type,notnull,type:varchar(50),unique:transfer_limit_overrides_user_id_account_type_unique"`
+
+	// amounts are stored in the smallest currency unit (paise for INR)
+	PerTransactionAmount *int64 `bun:"per_transaction_amount"`
+	DailyCount           *int   `bun:"daily_count"`
+	DailyAmount          *int64 `bun:"daily_amount"`
+	MonthlyAmount        *int64 `bun:"monthly_amount"`
+}
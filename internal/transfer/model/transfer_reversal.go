@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/uptrace/bun"
+)
+
+// TransferReversal is the audit record of a mistaken transfer an admin has reversed
+type TransferReversal struct {
+	bun.BaseModel `bun:"table:transfer_reversals"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "transactions" table, the debit of the sender account of the reversed transfer
+	// It is unique, a transfer can only be reversed once
+	OriginalTransactionID uuid.UUID                 `bun:"original_transaction_id,notnull,type:uuid,unique"`
+	OriginalTransaction   *accountModel.Transaction `bun:"rel:belongs-to,join:original_transaction_id=id"`
+
+	// foreign keys to "accounts" table
+	// FromAccountID is the receiver of the original transfer, the amount is taken back from it
+	// ToAccountID is the sender of the original transfer, the amount is paid back to it
+	FromAccountID int64                 `bun:"from_account_id,notnull"`
+	FromAccount   *accountModel.Account `bun:"rel:belongs-to,join:from_account_id=id"`
+	ToAccountID   int64                 `bun:"to_account_id,notnull"`
+	ToAccount     *accountModel.Account `bun:"rel:belongs-to,join:to_account_id=id"`
+
+	// Amount, ReversedAmount and HeldAmount are stored in the smallest currency unit (paise for INR)
+	// Amount is the amount of the original transfer, it is always ReversedAmount + HeldAmount
+	Amount         int64 `bun:"amount,notnull"`
+	ReversedAmount int64 `bun:"reversed_amount,notnull,default:0"`
+
+	// HeldAmount is the part of the amount the receiver's available balance could not cover yet
+	// It is held on the receiver account and taken back by the worker as soon as the balance covers it
+	HeldAmount int64 `bun:"held_amount,notnull,default:0"`
+
+	Status TransferReversalStatus `bun:"status,notnull"`
+	Reason string                 `bun:"reason,notnull"`
+
+	// foreign key to "users" table, the admin who initiated the reversal
+	InitiatedBy     uuid.UUID       `bun:"initiated_by,notnull,type:uuid"`
+	InitiatedByUser *userModel.User `bun:"rel:belongs-to,join:initiated_by=id"`
+
+	CompletedAt *time.Time `bun:"completed_at"`
+}
+
+type TransferReversalStatus string
+
+const (
+	TransferReversalOnHold    TransferReversalStatus = "ON_HOLD" // part of the amount is still held on the receiver account
+	TransferReversalCompleted TransferReversalStatus = "COMPLETED"
+)
@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+func (r *transferRepository) CreateBulkTransfer(requestCtx context.Context, dbExecutor bun.IDB, bulkTransfer *model.BulkTransfer) (*model.BulkTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewInsert().
+		Model(bulkTransfer).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating bulk transfer for userID: %s, error: %+v", bulkTransfer.UserID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't upload the bulk transfer file at the moment. Please try again later.",
+		}
+	}
+
+	return bulkTransfer, nil
+}
+
+func (r *transferRepository) GetBulkTransfer(requestCtx context.Context, dbExecutor bun.IDB, options types.BulkTransferQueryOptions) (*model.BulkTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var bulkTransfer model.BulkTransfer
+	query := dbExecutor.NewSelect().Model(&bulkTransfer)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Bulk transfer not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding bulk transfer with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the bulk transfer at the moment. Please try again later.",
+		}
+	}
+
+	return &bulkTransfer, nil
+}
+
+func (r *transferRepository) ListBulkTransfers(requestCtx context.Context, dbExecutor bun.IDB, options types.BulkTransferListOptions) ([]model.BulkTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var bulkTransfers []model.BulkTransfer
+	query := dbExecutor.NewSelect().Model(&bulkTransfers)
+
+	// dynamically construct the query based on which fields are set
+	if options.UserID != nil {
+		query = query.Where("user_id = ?", *options.UserID)
+	}
+	if options.FromAccountID != nil {
+		query = query.Where("from_account_id = ?", *options.FromAccountID)
+	}
+	if options.Status != nil {
+		query = query.Where("status = ?", *options.Status)
+	}
+
+	err := query.Order("created_at DESC").Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing bulk transfers with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the bulk transfers at the moment. Please try again later.",
+		}
+	}
+
+	return bulkTransfers, nil
+}
+
+func (r *transferRepository) UpdateBulkTransfer(requestCtx context.Context, dbExecutor bun.IDB, bulkTransferID uuid.UUID, options types.BulkTransferUpdateOptions) (*model.BulkTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var bulkTransfer model.BulkTransfer
+	query := dbExecutor.NewUpdate().Model(&bulkTransfer)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewStatus != nil {
+		query = query.Set("status = ?", *options.NewStatus)
+	}
+	if options.NewSucceededCount != nil {
+		query = query.Set("succeeded_count = ?", *options.NewSucceededCount)
+	}
+	if options.NewFailedCount != nil {
+		query = query.Set("failed_count = ?", *options.NewFailedCount)
+	}
+	if options.NewSkippedCount != nil {
+		query = query.Set("skipped_count = ?", *options.NewSkippedCount)
+	}
+	if options.NewStoppedAtLineNumber != nil {
+		query = query.Set("stopped_at_line_number = ?", *options.NewStoppedAtLineNumber)
+	}
+	if options.NewConfirmedAt != nil {
+		query = query.Set("confirmed_at = ?", *options.NewConfirmedAt)
+	}
+	if options.NewCompletedAt != nil {
+		query = query.Set("completed_at = ?", *options.NewCompletedAt)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", bulkTransferID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating bulk transfer with ID: %s, error: %+v", bulkTransferID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the bulk transfer at the moment. Please try again later.",
+		}
+	}
+
+	return &bulkTransfer, nil
+}
+
+func (r *transferRepository) CreateBulkTransferItems(requestCtx context.Context, dbExecutor bun.IDB, items []model.BulkTransferItem) ([]model.BulkTransferItem, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewInsert().
+		Model(&items).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating %d bulk transfer items, error: %+v", len(items), err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't upload the bulk transfer file at the moment. Please try again later.",
+		}
+	}
+
+	return items, nil
+}
+
+func (r *transferRepository) GetBulkTransferItem(requestCtx context.Context, dbExecutor bun.IDB, options types.BulkTransferItemQueryOptions) (*model.BulkTransferItem, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var item model.BulkTransferItem
+	query := dbExecutor.NewSelect().Model(&item)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Bulk transfer item not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding bulk transfer item with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the bulk transfer item at the moment. Please try again later.",
+		}
+	}
+
+	return &item, nil
+}
+
+func (r *transferRepository) ListBulkTransferItems(requestCtx context.Context, dbExecutor bun.IDB, options types.BulkTransferItemListOptions) ([]model.BulkTransferItem, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var items []model.BulkTransferItem
+	query := dbExecutor.NewSelect().Model(&items)
+
+	// dynamically construct the query based on which fields are set
+	if options.BulkTransferID != nil {
+		query = query.Where("bulk_transfer_id = ?", *options.BulkTransferID)
+	}
+	if options.Status != nil {
+		query = query.Where("status = ?", *options.Status)
+	}
+
+	err := query.Order("line_number ASC").Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing bulk transfer items with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the bulk transfer items at the moment. Please try again later.",
+		}
+	}
+
+	return items, nil
+}
+
+func (r *transferRepository) UpdateBulkTransferItem(requestCtx context.Context, dbExecutor bun.IDB, itemID uuid.UUID, options types.BulkTransferItemUpdateOptions) (*model.BulkTransferItem, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var item model.BulkTransferItem
+	query := dbExecutor.NewUpdate().Model(&item)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewStatus != nil {
+		query = query.Set("status = ?", *options.NewStatus)
+	}
+	if options.NewReason != nil {
+		query = query.Set("reason = ?", *options.NewReason)
+	}
+	if options.NewTransactionID != nil {
+		query = query.Set("transaction_id = ?", *options.NewTransactionID)
+	}
+	if options.NewProcessedAt != nil {
+		query = query.Set("processed_at = ?", *options.NewProcessedAt)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", itemID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating bulk transfer item with ID: %s, error: %+v", itemID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the bulk transfer item at the moment. Please try again later.",
+		}
+	}
+
+	return &item, nil
+}
+
+// SkipPendingBulkTransferItems marks every row of the batch that is still pending as skipped, in a single statement
+func (r *transferRepository) SkipPendingBulkTransferItems(requestCtx context.Context, dbExecutor bun.IDB, bulkTransferID uuid.UUID, reason string, skippedAt time.Time) error {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewUpdate().
+		Model((*model.BulkTransferItem)(nil)).
+		Set("status = ?", model.BulkTransferItemSkipped).
+		Set("reason = ?", reason).
+		Set("processed_at = ?", skippedAt).
+		Set("updated_at = NOW()").
+		Where("bulk_transfer_id = ?", bulkTransferID).
+		Where("status = ?", model.BulkTransferItemPending).
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while skipping pending items of bulk transfer with ID: %s, error: %+v", bulkTransferID, err)
+		return &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the bulk transfer items at the moment. Please try again later.",
+		}
+	}
+
+	return nil
+}
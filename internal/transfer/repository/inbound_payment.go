@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+// ErrInboundPaymentFileAlreadyIngested is returned by CreateInboundPaymentFile when a file with the same checksum has already been ingested
+var ErrInboundPaymentFileAlreadyIngested = errors.New("inbound payment file already ingested")
+
+func (r *transferRepository) CreateInboundPaymentFile(requestCtx context.Context, dbExecutor bun.IDB, inboundPaymentFile *model.InboundPaymentFile) (*model.InboundPaymentFile, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	// a file that was already ingested is skipped instead of failing the insert, so that the unique violation does not abort the database transaction
+	err := dbExecutor.NewInsert().
+		Model(inboundPaymentFile).
+		On("CONFLICT (checksum) DO NOTHING").
+		Returning("*").
+		Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInboundPaymentFileAlreadyIngested
+		}
+
+		logger.Error(requestCtx, "Error while creating inbound payment file: %s, error: %+v", inboundPaymentFile.FileName, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't ingest the clearing file at the moment. Please try again later.",
+		}
+	}
+
+	return inboundPaymentFile, nil
+}
+
+func (r *transferRepository) GetInboundPaymentFile(requestCtx context.Context, dbExecutor bun.IDB, options types.InboundPaymentFileQueryOptions) (*model.InboundPaymentFile, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var inboundPaymentFile model.InboundPaymentFile
+	query := dbExecutor.NewSelect().Model(&inboundPaymentFile)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Inbound payment file not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding inbound payment file with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the clearing file at the moment. Please try again later.",
+		}
+	}
+
+	return &inboundPaymentFile, nil
+}
+
+func (r *transferRepository) UpdateInboundPaymentFile(requestCtx context.Context, dbExecutor bun.IDB, inboundPaymentFileID uuid.UUID, options types.InboundPaymentFileUpdateOptions) (*model.InboundPaymentFile, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var inboundPaymentFile model.InboundPaymentFile
+	query := dbExecutor.NewUpdate().Model(&inboundPaymentFile)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewRecordCount != nil {
+		query = query.Set("record_count = ?", *options.NewRecordCount)
+	}
+	if options.NewCreditedCount != nil {
+		query = query.Set("credited_count = ?", *options.NewCreditedCount)
+	}
+	if options.NewSuspenseCount != nil {
+		query = query.Set("suspense_count = ?", *options.NewSuspenseCount)
+	}
+	if options.NewRejectedCount != nil {
+		query = query.Set("rejected_count = ?", *options.NewRejectedCount)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", inboundPaymentFileID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating inbound payment file with ID: %s, error: %+v", inboundPaymentFileID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the clearing file at the moment. Please try again later.",
+		}
+	}
+
+	return &inboundPaymentFile, nil
+}
+
+func (r *transferRepository) CreateInboundPayment(requestCtx context.Context, dbExecutor bun.IDB, inboundPayment *model.InboundPayment) (*model.InboundPayment, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewInsert().
+		Model(inboundPayment).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating inbound payment with UTR: %s, error: %+v", inboundPayment.UTR, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't ingest the clearing file at the moment. Please try again later.",
+		}
+	}
+
+	return inboundPayment, nil
+}
+
+func (r *transferRepository) GetInboundPayment(requestCtx context.Context, dbExecutor bun.IDB, options types.InboundPaymentQueryOptions) (*model.InboundPayment, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var inboundPayment model.InboundPayment
+	query := dbExecutor.NewSelect().Model(&inboundPayment)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Inbound payment not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding inbound payment with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the inbound payment at the moment. Please try again later.",
+		}
+	}
+
+	return &inboundPayment, nil
+}
+
+func (r *transferRepository) ListInboundPayments(requestCtx context.Context, dbExecutor bun.IDB, options types.InboundPaymentListOptions) ([]model.InboundPayment, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var inboundPayments []model.InboundPayment
+	query := dbExecutor.NewSelect().Model(&inboundPayments)
+
+	// dynamically construct the query based on which fields are set
+	if options.FileID != nil {
+		query = query.Where("file_id = ?", *options.FileID)
+	}
+	if options.UTR != nil {
+		query = query.Where("utr = ?", *options.UTR)
+	}
+	if options.Status != nil {
+		query = query.Where("status = ?", *options.Status)
+	}
+
+	// the payments of a file are ingested together, so they are kept in the order of the file
+	err := query.Order("created_at DESC", "line_number ASC").Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing inbound payments with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the inbound payments at the moment. Please try again later.",
+		}
+	}
+
+	return inboundPayments, nil
+}
+
+func (r *transferRepository) UpdateInboundPayment(requestCtx context.Context, dbExecutor bun.IDB, inboundPaymentID uuid.UUID, options types.InboundPaymentUpdateOptions) (*model.InboundPayment, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var inboundPayment model.InboundPayment
+	query := dbExecutor.NewUpdate().Model(&inboundPayment)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewStatus != nil {
+		query = query.Set("status = ?", *options.NewStatus)
+	}
+	if options.NewReason != nil {
+		query = query.Set("reason = ?", *options.NewReason)
+	}
+	if options.NewAccountID != nil {
+		query = query.Set("account_id = ?", *options.NewAccountID)
+	}
+	if options.NewTransactionID != nil {
+		query = query.Set("transaction_id = ?", *options.NewTransactionID)
+	}
+	if options.NewResolvedBy != nil {
+		query = query.Set("resolved_by = ?", *options.NewResolvedBy)
+	}
+	if options.NewResolvedAt != nil {
+		query = query.Set("resolved_at = ?", *options.NewResolvedAt)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", inboundPaymentID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating inbound payment with ID: %s, error: %+v", inboundPaymentID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the inbound payment at the moment. Please try again later.",
+		}
+	}
+
+	return &inboundPayment, nil
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/uptrace/bun"
+)
+
+type TransferRepository interface {
+	GetTransferLimitOverride(requestCtx context.Context, dbExecutor bun.IDB, options types.TransferLimitOverrideQueryOptions) (*model.TransferLimitOverride, error)
+	UpsertTransferLimitOverride(requestCtx context.Context, dbExecutor bun.IDB, override *model.TransferLimitOverride) (*model.TransferLimitOverride, error)
+
+	CreateScheduledTransfer(requestCtx context.Context, dbExecutor bun.IDB, scheduledTransfer *model.ScheduledTransfer) (*model.ScheduledTransfer, error)
+	GetScheduledTransfer(requestCtx context.Context, dbExecutor bun.IDB, options types.ScheduledTransferQueryOptions) (*model.ScheduledTransfer, error)
+	ListScheduledTransfers(requestCtx context.Context, dbExecutor bun.IDB, options types.ScheduledTransferListOptions) ([]model.ScheduledTransfer, error)
+	UpdateScheduledTransfer(requestCtx context.Context, dbExecutor bun.IDB, scheduledTransferID uuid.UUID, options types.ScheduledTransferUpdateOptions) (*model.ScheduledTransfer, error)
+
+	CreateStandingInstruction(requestCtx context.Context, dbExecutor bun.IDB, standingInstruction *model.StandingInstruction) (*model.StandingInstruction, error)
+	GetStandingInstruction(requestCtx context.Context, dbExecutor bun.IDB, options types.StandingInstructionQueryOptions) (*model.StandingInstruction, error)
+	ListStandingInstructions(requestCtx context.Context, dbExecutor bun.IDB, options types.StandingInstructionListOptions) ([]model.StandingInstruction, error)
+	UpdateStandingInstruction(requestCtx context.Context, dbExecutor bun.IDB, standingInstructionID uuid.UUID, options types.StandingInstructionUpdateOptions) (*model.StandingInstruction, error)
+	CreateStandingInstructionExecution(requestCtx context.Context, dbExecutor bun.IDB, execution *model.StandingInstructionExecution) (*model.StandingInstructionExecution, error)
+	ListStandingInstructionExecutions(requestCtx context.Context, dbExecutor bun.IDB, options types.StandingInstructionExecutionListOptions) ([]model.StandingInstructionExecution, error)
+
+	CreateTransferReversal(requestCtx context.Context, dbExecutor bun.IDB, transferReversal *model.TransferReversal) (*model.TransferReversal, error)
+	GetTransferReversal(requestCtx context.Context, dbExecutor bun.IDB, options types.TransferReversalQueryOptions) (*model.TransferReversal, error)
+	ListTransferReversals(requestCtx context.Context, dbExecutor bun.IDB, options types.TransferReversalListOptions) ([]model.TransferReversal, error)
+	UpdateTransferReversal(requestCtx context.Context, dbExecutor bun.IDB, transferReversalID uuid.UUID, options types.TransferReversalUpdateOptions) (*model.TransferReversal, error)
+
+	CreatePaymentOrder(requestCtx context.Context, dbExecutor bun.IDB, paymentOrder *model.PaymentOrder) (*model.PaymentOrder, error)
+	GetPaymentOrder(requestCtx context.Context, dbExecutor bun.IDB, options types.PaymentOrderQueryOptions) (*model.PaymentOrder, error)
+	ListPaymentOrders(requestCtx context.Context, dbExecutor bun.IDB, options types.PaymentOrderListOptions) ([]model.PaymentOrder, error)
+	UpdatePaymentOrder(requestCtx context.Context, dbExecutor bun.IDB, paymentOrderID uuid.UUID, options types.PaymentOrderUpdateOptions) (*model.PaymentOrder, error)
+
+	CreateInboundPaymentFile(requestCtx context.Context, dbExecutor bun.IDB, inboundPaymentFile *model.InboundPaymentFile) (*model.InboundPaymentFile, error)
+	GetInboundPaymentFile(requestCtx context.Context, dbExecutor bun.IDB, options types.InboundPaymentFileQueryOptions) (*model.InboundPaymentFile, error)
+	UpdateInboundPaymentFile(requestCtx context.Context, dbExecutor bun.IDB, inboundPaymentFileID uuid.UUID, options types.InboundPaymentFileUpdateOptions) (*model.InboundPaymentFile, error)
+	CreateInboundPayment(requestCtx context.Context, dbExecutor bun.IDB, inboundPayment *model.InboundPayment) (*model.InboundPayment, error)
+	GetInboundPayment(requestCtx context.Context, dbExecutor bun.IDB, options types.InboundPaymentQueryOptions) (*model.InboundPayment, error)
+	ListInboundPayments(requestCtx context.Context, dbExecutor bun.IDB, options types.InboundPaymentListOptions) ([]model.InboundPayment, error)
+	UpdateInboundPayment(requestCtx context.Context, dbExecutor bun.IDB, inboundPaymentID uuid.UUID, options types.InboundPaymentUpdateOptions) (*model.InboundPayment, error)
+
+	CreateBulkTransfer(requestCtx context.Context, dbExecutor bun.IDB, bulkTransfer *model.BulkTransfer) (*model.BulkTransfer, error)
+	GetBulkTransfer(requestCtx context.Context, dbExecutor bun.IDB, options types.BulkTransferQueryOptions) (*model.BulkTransfer, error)
+	ListBulkTransfers(requestCtx context.Context, dbExecutor bun.IDB, options types.BulkTransferListOptions) ([]model.BulkTransfer, error)
+	UpdateBulkTransfer(requestCtx context.Context, dbExecutor bun.IDB, bulkTransferID uuid.UUID, options types.BulkTransferUpdateOptions) (*model.BulkTransfer, error)
+	CreateBulkTransferItems(requestCtx context.Context, dbExecutor bun.IDB, items []model.BulkTransferItem) ([]model.BulkTransferItem, error)
+	GetBulkTransferItem(requestCtx context.Context, dbExecutor bun.IDB, options types.BulkTransferItemQueryOptions) (*model.BulkTransferItem, error)
+	ListBulkTransferItems(requestCtx context.Context, dbExecutor bun.IDB, options types.BulkTransferItemListOptions) ([]model.BulkTransferItem, error)
+	UpdateBulkTransferItem(requestCtx context.Context, dbExecutor bun.IDB, itemID uuid.UUID, options types.BulkTransferItemUpdateOptions) (*model.BulkTransferItem, error)
+	SkipPendingBulkTransferItems(requestCtx context.Context, dbExecutor bun.IDB, bulkTransferID uuid.UUID, reason string, skippedAt time.Time) error
+
+	CreateJointTransfer(requestCtx context.Context, dbExecutor bun.IDB, jointTransfer *model.JointTransfer) (*model.JointTransfer, error)
+	GetJointTransfer(requestCtx context.Context, dbExecutor bun.IDB, options types.JointTransferQueryOptions) (*model.JointTransfer, error)
+	ListJointTransfers(requestCtx context.Context, dbExecutor bun.IDB, options types.JointTransferListOptions) ([]model.JointTransfer, error)
+	UpdateJointTransfer(requestCtx context.Context, dbExecutor bun.IDB, jointTransferID uuid.UUID, options types.JointTransferUpdateOptions) (*model.JointTransfer, error)
+	CreateJointTransferApproval(requestCtx context.Context, dbExecutor bun.IDB, approval *model.JointTransferApproval) (*model.JointTransferApproval, error)
+	ListJointTransferApprovals(requestCtx context.Context, dbExecutor bun.IDB, options types.JointTransferApprovalListOptions) ([]model.JointTransferApproval, error)
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+func (r *transferRepository) CreateJointTransfer(requestCtx context.Context, dbExecutor bun.IDB, jointTransfer *model.JointTransfer) (*model.JointTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewInsert().
+		Model(jointTransfer).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating joint transfer from accountID: %d, error: %+v", jointTransfer.FromAccountID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't initiate your transfer at the moment. Please try again later.",
+		}
+	}
+
+	return jointTransfer, nil
+}
+
+func (r *transferRepository) GetJointTransfer(requestCtx context.Context, dbExecutor bun.IDB, options types.JointTransferQueryOptions) (*model.JointTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var jointTransfer model.JointTransfer
+	query := dbExecutor.NewSelect().Model(&jointTransfer)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Joint transfer not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding joint transfer with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the joint transfer at the moment. Please try again later.",
+		}
+	}
+
+	return &jointTransfer, nil
+}
+
+func (r *transferRepository) ListJointTransfers(requestCtx context.Context, dbExecutor bun.IDB, options types.JointTransferListOptions) ([]model.JointTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var jointTransfers []model.JointTransfer
+	query := dbExecutor.NewSelect().Model(&jointTransfers)
+
+	// dynamically construct the query based on which fields are set
+	if options.FromAccountID != nil {
+		query = query.Where("from_account_id = ?", *options.FromAccountID)
+	}
+	if options.InitiatedByUserID != nil {
+		query = query.Where("initiated_by_user_id = ?", *options.InitiatedByUserID)
+	}
+	if options.Status != nil {
+		query = query.Where("status = ?", *options.Status)
+	}
+
+	err := query.Order("created_at DESC").Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing joint transfers with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the joint transfers at the moment. Please try again later.",
+		}
+	}
+
+	return jointTransfers, nil
+}
+
+func (r *transferRepository) UpdateJointTransfer(requestCtx context.Context, dbExecutor bun.IDB, jointTransferID uuid.UUID, options types.JointTransferUpdateOptions) (*model.JointTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var jointTransfer model.JointTransfer
+	query := dbExecutor.NewUpdate().Model(&jointTransfer)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewStatus != nil {
+		query = query.Set("status = ?", *options.NewStatus)
+	}
+	if options.NewTransactionID != nil {
+		query = query.Set("transaction_id = ?", *options.NewTransactionID)
+	}
+	if options.NewExecutedAt != nil {
+		query = query.Set("executed_at = ?", *options.NewExecutedAt)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", jointTransferID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating joint transfer with ID: %s, error: %+v", jointTransferID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the joint transfer at the moment. Please try again later.",
+		}
+	}
+
+	return &jointTransfer, nil
+}
+
+func (r *transferRepository) CreateJointTransferApproval(requestCtx context.Context, dbExecutor bun.IDB, approval *model.JointTransferApproval) (*model.JointTransferApproval, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewInsert().
+		Model(approval).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while recording the decision of userID: %s on joint transfer with ID: %s, error: %+v", approval.UserID, approval.JointTransferID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't record your decision on the transfer at the moment. Please try again later.",
+		}
+	}
+
+	return approval, nil
+}
+
+func (r *transferRepository) ListJointTransferApprovals(requestCtx context.Context, dbExecutor bun.IDB, options types.JointTransferApprovalListOptions) ([]model.JointTransferApproval, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var approvals []model.JointTransferApproval
+	query := dbExecutor.NewSelect().Model(&approvals)
+
+	// dynamically construct the query based on which fields are set
+	if options.JointTransferID != nil {
+		query = query.Where("joint_transfer_id = ?", *options.JointTransferID)
+	}
+
+	err := query.Order("created_at ASC").Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing joint transfer approvals with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the approvals of the joint transfer at the moment. Please try again later.",
+		}
+	}
+
+	return approvals, nil
+}
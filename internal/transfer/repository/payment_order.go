@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+func (r *transferRepository) CreatePaymentOrder(requestCtx context.Context, dbExecutor bun.IDB, paymentOrder *model.PaymentOrder) (*model.PaymentOrder, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewInsert().
+		Model(paymentOrder).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating payment order for fromAccountID: %d, error: %+v", paymentOrder.FromAccountID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't create your transfer at the moment. Please try again later.",
+		}
+	}
+
+	return paymentOrder, nil
+}
+
+func (r *transferRepository) GetPaymentOrder(requestCtx context.Context, dbExecutor bun.IDB, options types.PaymentOrderQueryOptions) (*model.PaymentOrder, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var paymentOrder model.PaymentOrder
+	query := dbExecutor.NewSelect().Model(&paymentOrder)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+	if options.RailReference != nil {
+		query = query.Where("rail_reference = ?", *options.RailReference)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Payment order not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding payment order with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the transfer at the moment. Please try again later.",
+		}
+	}
+
+	return &paymentOrder, nil
+}
+
+func (r *transferRepository) ListPaymentOrders(requestCtx context.Context, dbExecutor bun.IDB, options types.PaymentOrderListOptions) ([]model.PaymentOrder, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var paymentOrders []model.PaymentOrder
+	query := dbExecutor.NewSelect().Model(&paymentOrders)
+
+	// dynamically construct the query based on which fields are set
+	if options.UserID != nil {
+		query = query.Where("user_id = ?", *options.UserID)
+	}
+	if options.Status != nil {
+		query = query.Where("status = ?", *options.Status)
+	}
+
+	err := query.Order("created_at DESC").Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing payment orders with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch your transfers at the moment. Please try again later.",
+		}
+	}
+
+	return paymentOrders, nil
+}
+
+func (r *transferRepository) UpdatePaymentOrder(requestCtx context.Context, dbExecutor bun.IDB, paymentOrderID uuid.UUID, options types.PaymentOrderUpdateOptions) (*model.PaymentOrder, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var paymentOrder model.PaymentOrder
+	query := dbExecutor.NewUpdate().Model(&paymentOrder)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewStatus != nil {
+		query = query.Set("status = ?", *options.NewStatus)
+	}
+	if options.NewRailReference != nil {
+		query = query.Set("rail_reference = ?", *options.NewRailReference)
+	}
+	if options.NewReturnReason != nil {
+		query = query.Set("return_reason = ?", *options.NewReturnReason)
+	}
+	if options.NewReturnTransactionID != nil {
+		query = query.Set("return_transaction_id = ?", *options.NewReturnTransactionID)
+	}
+	if options.NewOutcomeDueAt != nil {
+		query = query.Set("outcome_due_at = ?", *options.NewOutcomeDueAt)
+	}
+	if options.NewSentAt != nil {
+		query = query.Set("sent_at = ?", *options.NewSentAt)
+	}
+	if options.NewSettledAt != nil {
+		query = query.Set("settled_at = ?", *options.NewSettledAt)
+	}
+	if options.NewReturnedAt != nil {
+		query = query.Set("returned_at = ?", *options.NewReturnedAt)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", paymentOrderID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating payment order with ID: %s, error: %+v", paymentOrderID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the transfer at the moment. Please try again later.",
+		}
+	}
+
+	return &paymentOrder, nil
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+func (r *transferRepository) CreateStandingInstruction(requestCtx context.Context, dbExecutor bun.IDB, standingInstruction *model.StandingInstruction) (*model.StandingInstruction, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewInsert().
+		Model(standingInstruction).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating standing instruction for userID: %s, error: %+v", standingInstruction.UserID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't create your standing instruction at the moment. Please try again later.",
+		}
+	}
+
+	return standingInstruction, nil
+}
+
+func (r *transferRepository) GetStandingInstruction(requestCtx context.Context, dbExecutor bun.IDB, options types.StandingInstructionQueryOptions) (*model.StandingInstruction, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var standingInstruction model.StandingInstruction
+	query := dbExecutor.NewSelect().Model(&standingInstruction)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+	if options.UserID != nil {
+		query = query.Where("user_id = ?", *options.UserID)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Standing instruction not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding standing instruction with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the standing instruction at the moment. Please try again later.",
+		}
+	}
+
+	return &standingInstruction, nil
+}
+
+func (r *transferRepository) ListStandingInstructions(requestCtx context.Context, dbExecutor bun.IDB, options types.StandingInstructionListOptions) ([]model.StandingInstruction, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var standingInstructions []model.StandingInstruction
+	query := dbExecutor.NewSelect().Model(&standingInstructions)
+
+	// dynamically construct the query based on which fields are set
+	if options.UserID != nil {
+		query = query.Where("user_id = ?", *options.UserID)
+	}
+	if options.FromAccountID != nil {
+		query = query.Where("from_account_id = ?", *options.FromAccountID)
+	}
+	if options.Status != nil {
+		query = query.Where("status = ?", *options.Status)
+	}
+	if options.NextExecutionDateOnOrBefore != nil {
+		query = query.Where("next_execution_date <= ?", options.NextExecutionDateOnOrBefore.Format(time.DateOnly))
+	}
+	if options.AfterID != nil {
+		query = query.Where("id > ?", *options.AfterID)
+	}
+	if options.Limit > 0 {
+		query = query.Order("id ASC").Limit(options.Limit)
+	} else {
+		query = query.Order("created_at ASC")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing standing instructions with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the standing instructions at the moment. Please try again later.",
+		}
+	}
+
+	return standingInstructions, nil
+}
+
+func (r *transferRepository) UpdateStandingInstruction(requestCtx context.Context, dbExecutor bun.IDB, standingInstructionID uuid.UUID, options types.StandingInstructionUpdateOptions) (*model.StandingInstruction, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var standingInstruction model.StandingInstruction
+	query := dbExecutor.NewUpdate().Model(&standingInstruction)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewStatus != nil {
+		query = query.Set("status = ?", *options.NewStatus)
+	}
+	if options.NewOccurrenceCount != nil {
+		query = query.Set("occurrence_count = ?", *options.NewOccurrenceCount)
+	}
+	if options.NewNextExecutionDate != nil {
+		query = query.Set("next_execution_date = ?", options.NewNextExecutionDate.Format(time.DateOnly))
+	}
+	if options.ClearNextExecutionDate {
+		query = query.Set("next_execution_date = NULL")
+	}
+	if options.NewConsecutiveFailureCount != nil {
+		query = query.Set("consecutive_failure_count = ?", *options.NewConsecutiveFailureCount)
+	}
+	if options.NewLastAttemptedAt != nil {
+		query = query.Set("last_attempted_at = ?", *options.NewLastAttemptedAt)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", standingInstructionID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating standing instruction with ID: %s, error: %+v", standingInstructionID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the standing instruction at the moment. Please try again later.",
+		}
+	}
+
+	return &standingInstruction, nil
+}
+
+func (r *transferRepository) CreateStandingInstructionExecution(requestCtx context.Context, dbExecutor bun.IDB, execution *model.StandingInstructionExecution) (*model.StandingInstructionExecution, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewInsert().
+		Model(execution).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating execution of occurrence %d for standingInstructionID: %s, error: %+v", execution.OccurrenceNumber, execution.StandingInstructionID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't record the execution of the standing instruction at the moment. Please try again later.",
+		}
+	}
+
+	return execution, nil
+}
+
+func (r *transferRepository) ListStandingInstructionExecutions(requestCtx context.Context, dbExecutor bun.IDB, options types.StandingInstructionExecutionListOptions) ([]model.StandingInstructionExecution, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var executions []model.StandingInstructionExecution
+	query := dbExecutor.NewSelect().Model(&executions)
+
+	// dynamically construct the query based on which fields are set
+	if options.StandingInstructionID != nil {
+		query = query.Where("standing_instruction_id = ?", *options.StandingInstructionID)
+	}
+
+	err := query.Order("created_at ASC").Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing standing instruction executions with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the executions of the standing instruction at the moment. Please try again later.",
+		}
+	}
+
+	return executions, nil
+}
@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+type transferRepository struct {
+	db *bun.DB
+}
+
+func NewTransferRepository(db *bun.DB) TransferRepository {
+	return &transferRepository{
+		db: db,
+	}
+}
+
+// GetTransferLimitOverride returns nil without an error when the user has no overrides for the account type
+func (r *transferRepository) GetTransferLimitOverride(requestCtx context.Context, dbExecutor bun.IDB, options types.TransferLimitOverrideQueryOptions) (*model.TransferLimitOverride, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var override model.TransferLimitOverride
+	query := dbExecutor.NewSelect().Model(&override)
+
+	// dynamically construct the query based on which fields are set
+	if options.UserID != nil {
+		query = query.Where("user_id = ?", *options.UserID)
+	}
+	if options.AccountType != nil {
+		query = query.Where("account_type = ?", *options.AccountType)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		logger.Error(requestCtx, "Error while finding transfer limit override with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch your transfer limits at the moment. Please try again later.",
+		}
+	}
+
+	return &override, nil
+}
+
+func (r *transferRepository) UpsertTransferLimitOverride(requestCtx context.Context, dbExecutor bun.IDB, override *model.TransferLimitOverride) (*model.TransferLimitOverride, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	err := dbExecutor.NewInsert().
+		Model(override).
+		On("CONFLICT (user_id, account_type) DO UPDATE").
+		Set("per_transaction_amount = EXCLUDED.per_transaction_amount").
+		Set("daily_count = EXCLUDED.daily_count").
+		Set("daily_amount = EXCLUDED.daily_amount").
+		Set("monthly_amount = EXCLUDED.monthly_amount").
+		Set("updated_at = NOW()").
+		Returning("*").
+		Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while saving transfer limit override for userID: %s, error: %+v", override.UserID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update your transfer limits at the moment. Please try again later.",
+		}
+	}
+
+	return override, nil
+}
+
+func (r *transferRepository) CreateScheduledTransfer(requestCtx context.Context, dbExecutor bun.IDB, scheduledTransfer *model.ScheduledTransfer) (*model.ScheduledTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewInsert().
+		Model(scheduledTransfer).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating scheduled transfer for userID: %s, error: %+v", scheduledTransfer.UserID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't schedule your transfer at the moment. Please try again later.",
+		}
+	}
+
+	return scheduledTransfer, nil
+}
+
+func (r *transferRepository) GetScheduledTransfer(requestCtx context.Context, dbExecutor bun.IDB, options types.ScheduledTransferQueryOptions) (*model.ScheduledTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var scheduledTransfer model.ScheduledTransfer
+	query := dbExecutor.NewSelect().Model(&scheduledTransfer)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+	if options.UserID != nil {
+		query = query.Where("user_id = ?", *options.UserID)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Scheduled transfer not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding scheduled transfer with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the scheduled transfer at the moment. Please try again later.",
+		}
+	}
+
+	return &scheduledTransfer, nil
+}
+
+func (r *transferRepository) ListScheduledTransfers(requestCtx context.Context, dbExecutor bun.IDB, options types.ScheduledTransferListOptions) ([]model.ScheduledTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var scheduledTransfers []model.ScheduledTransfer
+	query := dbExecutor.NewSelect().Model(&scheduledTransfers)
+
+	// dynamically construct the query based on which fields are set
+	if options.UserID != nil {
+		query = query.Where("user_id = ?", *options.UserID)
+	}
+	if options.FromAccountID != nil {
+		query = query.Where("from_account_id = ?", *options.FromAccountID)
+	}
+	if options.Status != nil {
+		query = query.Where("status = ?", *options.Status)
+	}
+	if options.ScheduledBefore != nil {
+		query = query.Where("scheduled_at < ?", *options.ScheduledBefore)
+	}
+	if options.AfterID != nil {
+		query = query.Where("id > ?", *options.AfterID)
+	}
+	if options.Limit > 0 {
+		query = query.Order("id ASC").Limit(options.Limit)
+	} else {
+		query = query.Order("scheduled_at ASC")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing scheduled transfers with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch your scheduled transfers at the moment. Please try again later.",
+		}
+	}
+
+	return scheduledTransfers, nil
+}
+
+func (r *transferRepository) UpdateScheduledTransfer(requestCtx context.Context, dbExecutor bun.IDB, scheduledTransferID uuid.UUID, options types.ScheduledTransferUpdateOptions) (*model.ScheduledTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var scheduledTransfer model.ScheduledTransfer
+	query := dbExecutor.NewUpdate().Model(&scheduledTransfer)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewStatus != nil {
+		query = query.Set("status = ?", *options.NewStatus)
+	}
+	if options.NewFailureReason != nil {
+		query = query.Set("failure_reason = ?", *options.NewFailureReason)
+	}
+	if options.NewTransactionID != nil {
+		query = query.Set("transaction_id = ?", *options.NewTransactionID)
+	}
+	if options.NewExecutedAt != nil {
+		query = query.Set("executed_at = ?", *options.NewExecutedAt)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", scheduledTransferID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating scheduled transfer with ID: %s, error: %+v", scheduledTransferID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the scheduled transfer at the moment. Please try again later.",
+		}
+	}
+
+	return &scheduledTransfer, nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+func (r *transferRepository) CreateTransferReversal(requestCtx context.Context, dbExecutor bun.IDB, transferReversal *model.TransferReversal) (*model.TransferReversal, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewInsert().
+		Model(transferReversal).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating transfer reversal for originalTransactionID: %s, error: %+v", transferReversal.OriginalTransactionID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't reverse the transfer at the moment. Please try again later.",
+		}
+	}
+
+	return transferReversal, nil
+}
+
+func (r *transferRepository) GetTransferReversal(requestCtx context.Context, dbExecutor bun.IDB, options types.TransferReversalQueryOptions) (*model.TransferReversal, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var transferReversal model.TransferReversal
+	query := dbExecutor.NewSelect().Model(&transferReversal)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+	if options.OriginalTransactionID != nil {
+		query = query.Where("original_transaction_id = ?", *options.OriginalTransactionID)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Transfer reversal not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding transfer reversal with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the transfer reversal at the moment. Please try again later.",
+		}
+	}
+
+	return &transferReversal, nil
+}
+
+func (r *transferRepository) ListTransferReversals(requestCtx context.Context, dbExecutor bun.IDB, options types.TransferReversalListOptions) ([]model.TransferReversal, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var transferReversals []model.TransferReversal
+	query := dbExecutor.NewSelect().Model(&transferReversals)
+
+	// dynamically construct the query based on which fields are set
+	if options.Status != nil {
+		query = query.Where("status = ?", *options.Status)
+	}
+	if options.AfterID != nil {
+		query = query.Where("id > ?", *options.AfterID)
+	}
+	if options.Limit > 0 {
+		query = query.Order("id ASC").Limit(options.Limit)
+	} else {
+		query = query.Order("created_at DESC")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing transfer reversals with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the transfer reversals at the moment. Please try again later.",
+		}
+	}
+
+	return transferReversals, nil
+}
+
+func (r *transferRepository) UpdateTransferReversal(requestCtx context.Context, dbExecutor bun.IDB, transferReversalID uuid.UUID, options types.TransferReversalUpdateOptions) (*model.TransferReversal, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var transferReversal model.TransferReversal
+	query := dbExecutor.NewUpdate().Model(&transferReversal)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewStatus != nil {
+		query = query.Set("status = ?", *options.NewStatus)
+	}
+	if options.NewReversedAmount != nil {
+		query = query.Set("reversed_amount = ?", *options.NewReversedAmount)
+	}
+	if options.NewHeldAmount != nil {
+		query = query.Set("held_amount = ?", *options.NewHeldAmount)
+	}
+	if options.NewCompletedAt != nil {
+		query = query.Set("completed_at = ?", *options.NewCompletedAt)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", transferReversalID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating transfer reversal with ID: %s, error: %+v", transferReversalID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the transfer reversal at the moment. Please try again later.",
+		}
+	}
+
+	return &transferReversal, nil
+}
@@ -0,0 +1,633 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	feeModel "github.com/skamranahmed/go-bank/internal/fee/model"
+	feeTypes "github.com/skamranahmed/go-bank/internal/fee/types"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/bulktransferfile"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+/*
+CreateBulkTransfer records every row of a bulk transfer file, validating each of them on its own
+
+  - a row that cannot be parsed, or is paid to an account that does not exist or does not accept transfers, is invalid
+  - a row above the per transaction limit of the user is invalid, since it would be rejected when executed
+  - a row above the joint approval threshold of a jointly operated account is invalid, a bulk transfer cannot wait for the approval of every holder
+
+A batch with any invalid row is stored as INVALID so that every error is reported at once, it cannot be confirmed.
+Otherwise it waits to be confirmed by the user, after reviewing its total amount and estimated fee.
+*/
+func (s *transferService) CreateBulkTransfer(requestCtx context.Context, dbExecutor bun.IDB, params types.CreateBulkTransferParams) (*model.BulkTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	fromAccount, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &params.FromAccountID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.Type != accountModel.CurrentAccount {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Bulk transfers can only be made from a current account",
+		}
+	}
+
+	rows, err := bulktransferfile.Parse(params.Content)
+	if err != nil {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("The bulk transfer file could not be read: %s", err.Error()),
+		}
+	}
+
+	if len(rows) > s.bulkTransferConfig.MaxRows {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("A bulk transfer file can hold at most %d transfers", s.bulkTransferConfig.MaxRows),
+		}
+	}
+
+	transferLimits, err := s.GetTransferLimits(requestCtx, dbExecutor, params.UserID, fromAccount.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	isJointlyOperated, err := s.isJointlyOperated(requestCtx, dbExecutor, fromAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	// accounts already looked up for an earlier row, a payroll usually pays every account once but nothing prevents repeating one
+	toAccounts := make(map[int64]*accountModel.Account, len(rows))
+
+	items := make([]model.BulkTransferItem, 0, len(rows))
+	validAmounts := make([]int64, 0, len(rows))
+	var totalAmount int64
+	for _, row := range rows {
+		item := model.BulkTransferItem{
+			LineNumber:  row.LineNumber,
+			ToAccountID: row.ToAccountID,
+			Amount:      max(row.Amount, 0),
+			Status:      model.BulkTransferItemPending,
+		}
+		if row.Narration != "" {
+			item.Narration = &row.Narration
+		}
+
+		invalidReason, err := s.bulkTransferRowInvalidReason(requestCtx, dbExecutor, fromAccount, isJointlyOperated, row, transferLimits, toAccounts)
+		if err != nil {
+			return nil, err
+		}
+		if invalidReason != nil {
+			item.Status = model.BulkTransferItemInvalid
+			item.Reason = invalidReason
+			item.RawRow = &row.Raw
+		} else {
+			validAmounts = append(validAmounts, item.Amount)
+		}
+
+		totalAmount += item.Amount
+		items = append(items, item)
+	}
+
+	status := model.BulkTransferValidated
+	if len(validAmounts) < len(items) {
+		status = model.BulkTransferInvalid
+	}
+
+	estimatedFee, err := s.feeService.EstimateFee(requestCtx, dbExecutor, feeTypes.EstimateFeeParams{
+		AccountID:   fromAccount.ID,
+		Event:       feeModel.InternalTransfer,
+		BaseAmounts: validAmounts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bulkTransfer, err := s.transferRepository.CreateBulkTransfer(requestCtx, dbExecutor, &model.BulkTransfer{
+		UserID:                  params.UserID,
+		FromAccountID:           fromAccount.ID,
+		FileName:                params.FileName,
+		Status:                  status,
+		StopOnInsufficientFunds: params.StopOnInsufficientFunds,
+		ItemCount:               len(items),
+		TotalAmount:             totalAmount,
+		EstimatedFee:            estimatedFee,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		items[i].BulkTransferID = bulkTransfer.ID
+	}
+	_, err = s.transferRepository.CreateBulkTransferItems(requestCtx, dbExecutor, items)
+	if err != nil {
+		return nil, err
+	}
+
+	return bulkTransfer, nil
+}
+
+// bulkTransferRowInvalidReason returns why the row of a bulk transfer file cannot be executed, nil when it is valid
+func (s *transferService) bulkTransferRowInvalidReason(
+	requestCtx context.Context,
+	dbExecutor bun.IDB,
+	fromAccount *accountModel.Account,
+	isJointlyOperated bool,
+	row bulktransferfile.Row,
+	transferLimits *types.TransferLimits,
+	toAccounts map[int64]*accountModel.Account,
+) (*string, error) {
+	var reason string
+	switch {
+	case row.Err != nil:
+		reason = row.Err.Error()
+	case row.ToAccountID == fromAccount.ID:
+		reason = "to_account cannot be the account the bulk transfer is paid from"
+	case row.Amount > transferLimits.PerTransactionAmount:
+		reason = fmt.Sprintf("amount exceeds the per transaction limit of %d", transferLimits.PerTransactionAmount)
+	case isJointlyOperated && fromAccount.RequiresJointApproval(row.Amount):
+		reason = fmt.Sprintf("amount exceeds the joint approval threshold of %d, it needs the approval of every holder of the account", fromAccount.JointApprovalThreshold)
+	}
+	if reason != "" {
+		return &reason, nil
+	}
+
+	toAccount, ok := toAccounts[row.ToAccountID]
+	if !ok {
+		var err error
+		toAccount, err = s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+			AccountID: &row.ToAccountID,
+		})
+		if err != nil {
+			var apiErr *server.ApiError
+			if !errors.As(err, &apiErr) || apiErr.HttpStatusCode != http.StatusNotFound {
+				return nil, err
+			}
+			toAccount = nil
+		}
+		toAccounts[row.ToAccountID] = toAccount
+	}
+
+	switch {
+	case toAccount == nil:
+		reason = "to_account does not exist"
+	case !toAccount.Type.AllowsTransfers():
+		reason = "to_account does not accept transfers"
+	case toAccount.Currency != fromAccount.Currency:
+		reason = fmt.Sprintf("to_account is held in %s, a bulk transfer can only pay accounts held in %s", toAccount.Currency, fromAccount.Currency)
+	}
+	if reason != "" {
+		return &reason, nil
+	}
+
+	return nil, nil
+}
+
+func (s *transferService) GetBulkTransfer(requestCtx context.Context, dbExecutor bun.IDB, options types.BulkTransferQueryOptions) (*model.BulkTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.transferRepository.GetBulkTransfer(requestCtx, dbExecutor, options)
+}
+
+func (s *transferService) ListBulkTransfers(requestCtx context.Context, dbExecutor bun.IDB, options types.BulkTransferListOptions) ([]model.BulkTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.transferRepository.ListBulkTransfers(requestCtx, dbExecutor, options)
+}
+
+func (s *transferService) ListBulkTransferItems(requestCtx context.Context, dbExecutor bun.IDB, options types.BulkTransferItemListOptions) ([]model.BulkTransferItem, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.transferRepository.ListBulkTransferItems(requestCtx, dbExecutor, options)
+}
+
+/*
+ConfirmBulkTransfer queues a validated bulk transfer for execution by the worker
+
+The caller is responsible for enqueuing the execution of the batch.
+It must be called within a database transaction because it locks the bulk transfer row for update.
+*/
+func (s *transferService) ConfirmBulkTransfer(requestCtx context.Context, dbExecutor bun.IDB, bulkTransferID uuid.UUID) (*model.BulkTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	bulkTransfer, err := s.transferRepository.GetBulkTransfer(requestCtx, dbExecutor, types.BulkTransferQueryOptions{
+		ID:        &bulkTransferID,
+		ForUpdate: true, // lock the row so that the same batch cannot be confirmed twice concurrently
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch bulkTransfer.Status {
+	case model.BulkTransferValidated:
+	case model.BulkTransferInvalid:
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "This bulk transfer has invalid rows, correct them and upload the file again",
+		}
+	default:
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusConflict,
+			Message:        fmt.Sprintf("Bulk transfer is already %s", strings.ToLower(string(bulkTransfer.Status))),
+		}
+	}
+
+	queuedStatus := model.BulkTransferQueued
+	now := time.Now().UTC()
+	return s.transferRepository.UpdateBulkTransfer(requestCtx, dbExecutor, bulkTransfer.ID, types.BulkTransferUpdateOptions{
+		NewStatus:      &queuedStatus,
+		NewConfirmedAt: &now,
+	})
+}
+
+/*
+CancelBulkTransfer discards a bulk transfer that has not been confirmed yet
+
+It must be called within a database transaction because it locks the bulk transfer row for update.
+*/
+func (s *transferService) CancelBulkTransfer(requestCtx context.Context, dbExecutor bun.IDB, bulkTransferID uuid.UUID) (*model.BulkTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	bulkTransfer, err := s.transferRepository.GetBulkTransfer(requestCtx, dbExecutor, types.BulkTransferQueryOptions{
+		ID:        &bulkTransferID,
+		ForUpdate: true, // lock the row so that the batch cannot be confirmed while it is being cancelled
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if bulkTransfer.Status != model.BulkTransferValidated && bulkTransfer.Status != model.BulkTransferInvalid {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusConflict,
+			Message:        fmt.Sprintf("Bulk transfer is already %s", strings.ToLower(string(bulkTransfer.Status))),
+		}
+	}
+
+	cancelledStatus := model.BulkTransferCancelled
+	return s.transferRepository.UpdateBulkTransfer(requestCtx, dbExecutor, bulkTransfer.ID, types.BulkTransferUpdateOptions{
+		NewStatus: &cancelledStatus,
+	})
+}
+
+/*
+StartBulkTransfer moves a queued bulk transfer to processing, it is called by the worker before executing its rows
+
+A batch already processing is returned as is, so that a retried execution picks up the rows left pending.
+It returns nil when the batch is neither queued nor processing.
+It must be called within a database transaction because it locks the bulk transfer row for update.
+*/
+func (s *transferService) StartBulkTransfer(requestCtx context.Context, dbExecutor bun.IDB, bulkTransferID uuid.UUID) (*model.BulkTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	bulkTransfer, err := s.transferRepository.GetBulkTransfer(requestCtx, dbExecutor, types.BulkTransferQueryOptions{
+		ID:        &bulkTransferID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch bulkTransfer.Status {
+	case model.BulkTransferProcessing:
+		return bulkTransfer, nil
+	case model.BulkTransferQueued:
+		processingStatus := model.BulkTransferProcessing
+		return s.transferRepository.UpdateBulkTransfer(requestCtx, dbExecutor, bulkTransfer.ID, types.BulkTransferUpdateOptions{
+			NewStatus: &processingStatus,
+		})
+	default:
+		return nil, nil
+	}
+}
+
+/*
+ExecuteBulkTransferItem makes the transfer of a single row of a bulk transfer through CreateInternalTransfer,
+so it is subject to the same checks and fees as any other transfer
+
+A row is failed without attempting the transfer when the user who uploaded the batch no longer holds the account,
+or when the account cannot afford it (including its fee).
+When the batch stops on insufficient funds, every row after a row the account cannot afford is skipped and the batch is stopped.
+Any other rejection of the transfer is returned as an error, the caller records it with FailBulkTransferItem
+once the database transaction has been rolled back.
+
+It returns nil when the row is no longer pending or the batch is no longer processing, so that a row is never paid twice.
+It must be called within a database transaction because it locks the row, the bulk transfer and the account rows for update.
+*/
+func (s *transferService) ExecuteBulkTransferItem(requestCtx context.Context, dbExecutor bun.IDB, itemID uuid.UUID, executionTime time.Time) (*model.BulkTransferItem, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	item, err := s.transferRepository.GetBulkTransferItem(requestCtx, dbExecutor, types.BulkTransferItemQueryOptions{
+		ID:        &itemID,
+		ForUpdate: true, // lock the row so that it cannot be executed twice concurrently
+	})
+	if err != nil {
+		return nil, err
+	}
+	if item.Status != model.BulkTransferItemPending {
+		return nil, nil
+	}
+
+	bulkTransfer, err := s.transferRepository.GetBulkTransfer(requestCtx, dbExecutor, types.BulkTransferQueryOptions{
+		ID:        &item.BulkTransferID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+	if bulkTransfer.Status != model.BulkTransferProcessing {
+		return nil, nil
+	}
+
+	holdsAccount, err := s.holdsAccount(requestCtx, dbExecutor, bulkTransfer.FromAccountID, bulkTransfer.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !holdsAccount {
+		reason := "Not attempted, the user who uploaded the bulk transfer no longer holds the account"
+		failedStatus := model.BulkTransferItemFailed
+		return s.transferRepository.UpdateBulkTransferItem(requestCtx, dbExecutor, item.ID, types.BulkTransferItemUpdateOptions{
+			NewStatus:      &failedStatus,
+			NewReason:      &reason,
+			NewProcessedAt: &executionTime,
+		})
+	}
+
+	// the accounts are locked up front, so that the balance checked here is still the balance when the transfer is made
+	fromAccount, _, err := s.lockAccounts(requestCtx, dbExecutor, bulkTransfer.FromAccountID, item.ToAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	fee, err := s.feeService.EstimateFee(requestCtx, dbExecutor, feeTypes.EstimateFeeParams{
+		AccountID:   fromAccount.ID,
+		Event:       feeModel.InternalTransfer,
+		BaseAmounts: []int64{item.Amount},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.AvailableBalance() < item.Amount+fee {
+		return s.failBulkTransferItemOnInsufficientFunds(requestCtx, dbExecutor, bulkTransfer, item, fee, executionTime)
+	}
+
+	transaction, err := s.CreateInternalTransfer(requestCtx, dbExecutor, bulkTransfer.UserID, bulkTransfer.FromAccountID, item.ToAccountID, item.Amount, accountTypes.Remittance{
+		Narration: item.Narration,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	succeededStatus := model.BulkTransferItemSucceeded
+	return s.transferRepository.UpdateBulkTransferItem(requestCtx, dbExecutor, item.ID, types.BulkTransferItemUpdateOptions{
+		NewStatus:        &succeededStatus,
+		NewTransactionID: &transaction.ID,
+		NewProcessedAt:   &executionTime,
+	})
+}
+
+// failBulkTransferItemOnInsufficientFunds fails a row the account cannot afford, stopping the batch at it if the batch stops on insufficient funds
+func (s *transferService) failBulkTransferItemOnInsufficientFunds(
+	requestCtx context.Context,
+	dbExecutor bun.IDB,
+	bulkTransfer *model.BulkTransfer,
+	item *model.BulkTransferItem,
+	fee int64,
+	executionTime time.Time,
+) (*model.BulkTransferItem, error) {
+	reason := fmt.Sprintf("Insufficient balance to transfer %d and pay its fee of %d", item.Amount, fee)
+	failedStatus := model.BulkTransferItemFailed
+	item, err := s.transferRepository.UpdateBulkTransferItem(requestCtx, dbExecutor, item.ID, types.BulkTransferItemUpdateOptions{
+		NewStatus:      &failedStatus,
+		NewReason:      &reason,
+		NewProcessedAt: &executionTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !bulkTransfer.StopOnInsufficientFunds {
+		return item, nil
+	}
+
+	err = s.transferRepository.SkipPendingBulkTransferItems(requestCtx, dbExecutor, bulkTransfer.ID, fmt.Sprintf("Not attempted, the bulk transfer stopped on insufficient balance at line %d", item.LineNumber), executionTime)
+	if err != nil {
+		return nil, err
+	}
+
+	stoppedStatus := model.BulkTransferStopped
+	_, err = s.transferRepository.UpdateBulkTransfer(requestCtx, dbExecutor, bulkTransfer.ID, types.BulkTransferUpdateOptions{
+		NewStatus:              &stoppedStatus,
+		NewStoppedAtLineNumber: &item.LineNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+/*
+FailBulkTransferItem records the reason the transfer of a row was rejected
+
+It returns nil when the row is no longer pending.
+It must be called within a database transaction because it locks the row for update.
+*/
+func (s *transferService) FailBulkTransferItem(requestCtx context.Context, dbExecutor bun.IDB, itemID uuid.UUID, failureReason string, executionTime time.Time) (*model.BulkTransferItem, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	item, err := s.transferRepository.GetBulkTransferItem(requestCtx, dbExecutor, types.BulkTransferItemQueryOptions{
+		ID:        &itemID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+	if item.Status != model.BulkTransferItemPending {
+		return nil, nil
+	}
+
+	failedStatus := model.BulkTransferItemFailed
+	return s.transferRepository.UpdateBulkTransferItem(requestCtx, dbExecutor, item.ID, types.BulkTransferItemUpdateOptions{
+		NewStatus:      &failedStatus,
+		NewReason:      &failureReason,
+		NewProcessedAt: &executionTime,
+	})
+}
+
+/*
+CompleteBulkTransfer counts the outcome of every row of a bulk transfer once none of them is pending anymore
+
+  - COMPLETED when every row succeeded
+  - FAILED when no row succeeded
+  - PARTIALLY_COMPLETED otherwise, a stopped batch keeps the STOPPED status
+
+It returns nil when the batch has already been completed or is not being processed.
+It must be called within a database transaction because it locks the bulk transfer row for update.
+*/
+func (s *transferService) CompleteBulkTransfer(requestCtx context.Context, dbExecutor bun.IDB, bulkTransferID uuid.UUID, completionTime time.Time) (*model.BulkTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	bulkTransfer, err := s.transferRepository.GetBulkTransfer(requestCtx, dbExecutor, types.BulkTransferQueryOptions{
+		ID:        &bulkTransferID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+	if bulkTransfer.IsFinished() || (bulkTransfer.Status != model.BulkTransferProcessing && bulkTransfer.Status != model.BulkTransferStopped) {
+		return nil, nil
+	}
+
+	items, err := s.transferRepository.ListBulkTransferItems(requestCtx, dbExecutor, types.BulkTransferItemListOptions{
+		BulkTransferID: &bulkTransfer.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var succeededCount, failedCount, skippedCount int
+	for _, item := range items {
+		switch item.Status {
+		case model.BulkTransferItemSucceeded:
+			succeededCount++
+		case model.BulkTransferItemFailed:
+			failedCount++
+		case model.BulkTransferItemSkipped:
+			skippedCount++
+		case model.BulkTransferItemPending:
+			logger.Error(requestCtx, "Bulk transfer with ID: %s cannot be completed, line %d is still pending", bulkTransfer.ID, item.LineNumber)
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusInternalServerError,
+				Message:        "We couldn't complete the bulk transfer at the moment. Please try again later.",
+			}
+		}
+	}
+
+	status := bulkTransfer.Status
+	if status != model.BulkTransferStopped {
+		switch {
+		case failedCount == 0:
+			status = model.BulkTransferCompleted
+		case succeededCount == 0:
+			status = model.BulkTransferFailed
+		default:
+			status = model.BulkTransferPartiallyCompleted
+		}
+	}
+
+	return s.transferRepository.UpdateBulkTransfer(requestCtx, dbExecutor, bulkTransfer.ID, types.BulkTransferUpdateOptions{
+		NewStatus:         &status,
+		NewSucceededCount: &succeededCount,
+		NewFailedCount:    &failedCount,
+		NewSkippedCount:   &skippedCount,
+		NewCompletedAt:    &completionTime,
+	})
+}
+
+/*
+BuildBulkTransferResult returns the result file of a bulk transfer, reporting the current status of every row of the file
+
+It can be built at any time, a batch that has not been executed yet reports its valid rows as PENDING.
+*/
+func (s *transferService) BuildBulkTransferResult(requestCtx context.Context, dbExecutor bun.IDB, bulkTransferID uuid.UUID) (*types.BulkTransferResult, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	bulkTransfer, err := s.transferRepository.GetBulkTransfer(requestCtx, dbExecutor, types.BulkTransferQueryOptions{
+		ID: &bulkTransferID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.transferRepository.ListBulkTransferItems(requestCtx, dbExecutor, types.BulkTransferItemListOptions{
+		BulkTransferID: &bulkTransfer.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]bulktransferfile.ResultEntry, 0, len(items))
+	for _, item := range items {
+		entry := bulktransferfile.ResultEntry{
+			LineNumber: item.LineNumber,
+			Amount:     item.Amount,
+			Status:     string(item.Status),
+		}
+		if item.ToAccountID != 0 {
+			entry.ToAccount = strconv.FormatInt(item.ToAccountID, 10)
+		}
+		if item.Narration != nil {
+			entry.Narration = *item.Narration
+		}
+		if item.TransactionID != nil {
+			entry.TransactionID = item.TransactionID.String()
+		}
+		if item.Reason != nil {
+			entry.Reason = *item.Reason
+		}
+		entries = append(entries, entry)
+	}
+
+	content, err := bulktransferfile.WriteResult(entries)
+	if err != nil {
+		logger.Error(requestCtx, "Error while writing result of bulk transfer with ID: %s, error: %+v", bulkTransferID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't build the result file at the moment. Please try again later.",
+		}
+	}
+
+	return &types.BulkTransferResult{
+		FileName: bulkTransferResultFileName(bulkTransfer.FileName),
+		Content:  content,
+	}, nil
+}
+
+// bulkTransferResultFileName returns the name of the result file of a bulk transfer file, eg: payroll_202601.csv -> payroll_202601.result.csv
+func bulkTransferResultFileName(fileName string) string {
+	extension := filepath.Ext(fileName)
+	return strings.TrimSuffix(fileName, extension) + ".result" + extension
+}
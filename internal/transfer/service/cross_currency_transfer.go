@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/config"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	feeModel "github.com/skamranahmed/go-bank/internal/fee/model"
+	feeTypes "github.com/skamranahmed/go-bank/internal/fee/types"
+	fxModel "github.com/skamranahmed/go-bank/internal/fx/model"
+	fxTypes "github.com/skamranahmed/go-bank/internal/fx/types"
+	ledgerModel "github.com/skamranahmed/go-bank/internal/ledger/model"
+	ledgerTypes "github.com/skamranahmed/go-bank/internal/ledger/types"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/money"
+	"github.com/uptrace/bun"
+)
+
+/*
+CreateCrossCurrencyTransfer transfers between two accounts held in different currencies at the amounts fixed by an FX quote
+
+The source amount of the quote is debited from the sender and its target amount is credited to the receiver.
+The bank takes the source amount into the FX position account of the source currency
+and pays the target amount out of the FX position account of the target currency, so that the ledger stays balanced per currency.
+The quote must have been given to the sender for exactly this transfer, it can only be used once and only until it expires.
+It must be called within a database transaction because it locks the quote and both the account rows for update.
+It returns the transaction record of the debited account.
+*/
+func (s *transferService) CreateCrossCurrencyTransfer(requestCtx context.Context, dbExecutor bun.IDB, params types.CreateCrossCurrencyTransferParams) (*accountModel.Transaction, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	now := time.Now().UTC()
+
+	fxQuote, err := s.fxService.GetFXQuote(requestCtx, dbExecutor, fxTypes.FXQuoteQueryOptions{
+		ID:        &params.FXQuoteID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if fxQuote.UserID != params.UserID {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You do not have permission to use this FX quote",
+		}
+	}
+
+	if fxQuote.FromAccountID != params.FromAccountID || fxQuote.ToAccountID != params.ToAccountID || fxQuote.SourceAmount != params.Amount {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "The FX quote was given for another transfer",
+		}
+	}
+
+	if fxQuote.Status == fxModel.FXQuoteUsed {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusConflict,
+			Message:        "FX quote has already been used",
+		}
+	}
+
+	if fxQuote.IsExpired(now) {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "FX quote has expired, ask for a new quote",
+		}
+	}
+
+	params.Remittance.Narration = sanitizeNarration(params.Remittance.Narration)
+
+	senderAccount, receiverAccount, err := s.lockAccounts(requestCtx, dbExecutor, params.FromAccountID, params.ToAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the rate of the quote would expire long before every holder approves the transfer, so it cannot be made as a joint transfer
+	err = s.EnforceJointMandate(requestCtx, dbExecutor, senderAccount, fxQuote.SourceAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	// the available balance includes the sanctioned overdraft limit and excludes any held amount of the sender's account
+	if senderAccount.AvailableBalance() < fxQuote.SourceAmount {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "You do not have sufficient balance in your account to perform the transfer",
+		}
+	}
+
+	transactionRecordForSenderAccount, err := s.postTransfer(requestCtx, dbExecutor, senderAccount, receiverAccount, fxQuote.SourceAmount, fxQuote.TargetAmount, params.Remittance)
+	if err != nil {
+		return nil, err
+	}
+
+	// the other side of the debit and of the credit, each in the currency of its customer account
+	postings := []ledgerTypes.PostEntryParams{
+		{
+			InternalAccountCode: ledgerModel.FXPosition(fxQuote.SourceCurrency),
+			Type:                ledgerModel.Credit,
+			Amount:              fxQuote.SourceAmount,
+			Reference:           fxQuote.ID.String(),
+		},
+		{
+			InternalAccountCode: ledgerModel.FXPosition(fxQuote.TargetCurrency),
+			Type:                ledgerModel.Debit,
+			Amount:              fxQuote.TargetAmount,
+			Reference:           fxQuote.ID.String(),
+		},
+	}
+	for _, posting := range postings {
+		_, err = s.ledgerService.PostEntry(requestCtx, dbExecutor, posting)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// a transfer to the bank's currency counts towards the limits at the rate of its quote, any other at the latest published rate
+	var homeCurrencyRate *money.Rate
+	if string(fxQuote.TargetCurrency) == config.GetBankConfig().Currency {
+		homeCurrencyRate = &money.Rate{Base: fxQuote.SourceCurrency, Quote: fxQuote.TargetCurrency, Value: fxQuote.Rate}
+	}
+	err = s.enforceTransferLimits(requestCtx, dbExecutor, params.UserID, params.FromAccountID, fxQuote.SourceAmount, homeCurrencyRate, now)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.feeService.ChargeFee(requestCtx, dbExecutor, feeTypes.ChargeFeeParams{
+		AccountID:  params.FromAccountID,
+		Event:      feeModel.InternalTransfer,
+		BaseAmount: fxQuote.SourceAmount,
+		Reference:  transactionRecordForSenderAccount.ID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.fxService.UseFXQuote(requestCtx, dbExecutor, fxQuote.ID, transactionRecordForSenderAccount.ID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return transactionRecordForSenderAccount, nil
+}
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/config"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	ledgerModel "github.com/skamranahmed/go-bank/internal/ledger/model"
+	ledgerTypes "github.com/skamranahmed/go-bank/internal/ledger/types"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/accountnumber"
+	"github.com/uptrace/bun"
+)
+
+/*
+CreateExternalTransfer debits the amount from the customer into the outbound clearing account and creates the payment order sending it over the rail
+
+The order is subject to the same transfer limits as internal transfers, a rejected order rolls the whole debit back.
+The caller is responsible for enqueuing the dispatch of the order at its DispatchAt.
+It must be called within a database transaction because it locks the account row for update.
+*/
+func (s *transferService) CreateExternalTransfer(requestCtx context.Context, dbExecutor bun.IDB, params types.CreateExternalTransferParams) (*model.PaymentOrder, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	err := s.paymentRail.ValidateAmount(params.Rail, params.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if accountnumber.IFSCBankCode(params.BeneficiaryIFSC) == config.GetBankConfig().Code {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Transfers to accounts at this bank must be made as internal transfers",
+		}
+	}
+
+	account, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &params.FromAccountID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !account.Type.AllowsTransfers() {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "External transfers are only allowed from savings and current accounts",
+		}
+	}
+
+	// the payment rails only settle in the home currency of the bank
+	if string(account.Currency) != config.GetBankConfig().Currency {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("External transfers can only be made from an account held in %s", config.GetBankConfig().Currency),
+		}
+	}
+
+	err = s.EnforceJointMandate(requestCtx, dbExecutor, account, params.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.AvailableBalance() < params.Amount {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "You do not have sufficient balance in your account to perform the transfer",
+		}
+	}
+
+	newBalance := account.Balance - params.Amount
+	account, err = s.accountService.UpdateAccount(requestCtx, dbExecutor, account.ID, accountTypes.AccountUpdateOptions{
+		NewBalance: &newBalance,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	description := fmt.Sprintf("%s transfer to %s", params.Rail, maskExternalAccountNumber(params.BeneficiaryAccountNumber))
+	debitTransaction, err := s.accountService.CreateTransactionRecord(requestCtx, dbExecutor, &accountModel.Transaction{
+		AccountID:       account.ID,
+		Amount:          params.Amount,
+		BalanceAfter:    account.Balance,
+		Type:            accountModel.ExternalTransfer,
+		Description:     &description,
+		Narration:       sanitizeNarration(params.Remittance.Narration),
+		ClientReference: params.Remittance.ClientReference,
+		Category:        params.Remittance.Category,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	err = s.enforceTransferLimits(requestCtx, dbExecutor, params.UserID, params.FromAccountID, params.Amount, nil, now)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentOrder, err := s.transferRepository.CreatePaymentOrder(requestCtx, dbExecutor, &model.PaymentOrder{
+		UserID:                   params.UserID,
+		FromAccountID:            params.FromAccountID,
+		Amount:                   params.Amount,
+		Rail:                     params.Rail,
+		BeneficiaryName:          params.BeneficiaryName,
+		BeneficiaryAccountNumber: params.BeneficiaryAccountNumber,
+		BeneficiaryIFSC:          params.BeneficiaryIFSC,
+		Status:                   model.PaymentOrderInitiated,
+		DebitTransactionID:       debitTransaction.ID,
+		DispatchAt:               s.paymentRail.NextDispatchTime(params.Rail, now),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the other side of the debit: the amount sits in the outbound clearing account until the order settles or is returned
+	_, err = s.ledgerService.PostEntry(requestCtx, dbExecutor, ledgerTypes.PostEntryParams{
+		InternalAccountCode: ledgerModel.OutboundClearing,
+		Type:                ledgerModel.Credit,
+		Amount:              paymentOrder.Amount,
+		Reference:           paymentOrder.ID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the spare change is swept last and moved back by creditReturnedPaymentOrder if the order is returned
+	err = s.accountService.SweepRoundUp(requestCtx, dbExecutor, debitTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return paymentOrder, nil
+}
+
+func (s *transferService) GetPaymentOrder(requestCtx context.Context, dbExecutor bun.IDB, options types.PaymentOrderQueryOptions) (*model.PaymentOrder, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.transferRepository.GetPaymentOrder(requestCtx, dbExecutor, options)
+}
+
+func (s *transferService) ListPaymentOrders(requestCtx context.Context, dbExecutor bun.IDB, options types.PaymentOrderListOptions) ([]model.PaymentOrder, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.transferRepository.ListPaymentOrders(requestCtx, dbExecutor, options)
+}
+
+/*
+DispatchPaymentOrder sends an initiated payment order over its rail
+
+It returns nil without an error when the order is no longer initiated, e.g. it was already sent by a previous attempt.
+The caller is responsible for enqueuing the completion of the order at its OutcomeDueAt.
+It must be called within a database transaction because it locks the payment order row for update.
+*/
+func (s *transferService) DispatchPaymentOrder(requestCtx context.Context, dbExecutor bun.IDB, paymentOrderID uuid.UUID, dispatchTime time.Time) (*model.PaymentOrder, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	paymentOrder, err := s.transferRepository.GetPaymentOrder(requestCtx, dbExecutor, types.PaymentOrderQueryOptions{
+		ID:        &paymentOrderID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if paymentOrder.Status != model.PaymentOrderInitiated {
+		return nil, nil
+	}
+
+	submission, err := s.paymentRail.Send(requestCtx, paymentOrder, dispatchTime)
+	if err != nil {
+		return nil, err
+	}
+
+	sentStatus := model.PaymentOrderSent
+	return s.transferRepository.UpdatePaymentOrder(requestCtx, dbExecutor, paymentOrder.ID, types.PaymentOrderUpdateOptions{
+		NewStatus:        &sentStatus,
+		NewRailReference: &submission.Reference,
+		NewOutcomeDueAt:  &submission.OutcomeDueAt,
+		NewSentAt:        &dispatchTime,
+	})
+}
+
+/*
+CompletePaymentOrder records the outcome of a sent payment order reported by its rail
+
+A settled order takes the amount out of the outbound clearing account, it has left the bank.
+A returned order takes the amount out of the outbound clearing account and credits it back to the customer.
+It returns nil without an error when the order is no longer sent, e.g. it was already completed by a previous attempt.
+It must be called within a database transaction because it locks the payment order and account rows for update.
+*/
+func (s *transferService) CompletePaymentOrder(requestCtx context.Context, dbExecutor bun.IDB, paymentOrderID uuid.UUID, completionTime time.Time) (*model.PaymentOrder, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	paymentOrder, err := s.transferRepository.GetPaymentOrder(requestCtx, dbExecutor, types.PaymentOrderQueryOptions{
+		ID:        &paymentOrderID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if paymentOrder.Status != model.PaymentOrderSent {
+		return nil, nil
+	}
+
+	returnReason, err := s.paymentRail.Outcome(requestCtx, paymentOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.ledgerService.PostEntry(requestCtx, dbExecutor, ledgerTypes.PostEntryParams{
+		InternalAccountCode: ledgerModel.OutboundClearing,
+		Type:                ledgerModel.Debit,
+		Amount:              paymentOrder.Amount,
+		Reference:           paymentOrder.ID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if returnReason == nil {
+		settledStatus := model.PaymentOrderSettled
+		return s.transferRepository.UpdatePaymentOrder(requestCtx, dbExecutor, paymentOrder.ID, types.PaymentOrderUpdateOptions{
+			NewStatus:    &settledStatus,
+			NewSettledAt: &completionTime,
+		})
+	}
+
+	returnTransaction, err := s.creditReturnedPaymentOrder(requestCtx, dbExecutor, paymentOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	returnedStatus := model.PaymentOrderReturned
+	return s.transferRepository.UpdatePaymentOrder(requestCtx, dbExecutor, paymentOrder.ID, types.PaymentOrderUpdateOptions{
+		NewStatus:              &returnedStatus,
+		NewReturnReason:        returnReason,
+		NewReturnTransactionID: &returnTransaction.ID,
+		NewReturnedAt:          &completionTime,
+	})
+}
+
+/*
+creditReturnedPaymentOrder credits the amount of the returned order back to the customer account and marks its debit as reversed
+
+The spare change swept into a pocket when the order was made is moved back to the main balance as well.
+*/
+func (s *transferService) creditReturnedPaymentOrder(requestCtx context.Context, dbExecutor bun.IDB, paymentOrder *model.PaymentOrder) (*accountModel.Transaction, error) {
+	account, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &paymentOrder.FromAccountID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	newBalance := account.Balance + paymentOrder.Amount
+	account, err = s.accountService.UpdateAccount(requestCtx, dbExecutor, account.ID, accountTypes.AccountUpdateOptions{
+		NewBalance: &newBalance,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	description := fmt.Sprintf("Return of %s transfer to %s", paymentOrder.Rail, maskExternalAccountNumber(paymentOrder.BeneficiaryAccountNumber))
+	returnTransaction, err := s.accountService.CreateTransactionRecord(requestCtx, dbExecutor, &accountModel.Transaction{
+		AccountID:    account.ID,
+		Amount:       paymentOrder.Amount,
+		BalanceAfter: account.Balance,
+		Type:         accountModel.ExternalTransferReturn,
+		Description:  &description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reversedStatus := accountModel.TransactionReversed
+	_, err = s.accountService.UpdateTransaction(requestCtx, dbExecutor, paymentOrder.DebitTransactionID, accountTypes.TransactionUpdateOptions{
+		NewStatus: &reversedStatus,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.accountService.ReleaseRoundUp(requestCtx, dbExecutor, paymentOrder.DebitTransactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return returnTransaction, nil
+}
+
+// maskExternalAccountNumber masks all but the last 4 digits of an account number at another bank, the same way accountnumber.Mask does for ours
+func maskExternalAccountNumber(accountNumber string) string {
+	if len(accountNumber) <= 4 {
+		return "****"
+	}
+	return "****" + accountNumber[len(accountNumber)-4:]
+}
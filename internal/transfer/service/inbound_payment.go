@@ -0,0 +1,504 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/config"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	ledgerModel "github.com/skamranahmed/go-bank/internal/ledger/model"
+	ledgerTypes "github.com/skamranahmed/go-bank/internal/ledger/types"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/repository"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/accountnumber"
+	"github.com/skamranahmed/go-bank/pkg/clearingfile"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+// maxUTRLength is the length of the longest reference assigned by any of the rails (RTGS)
+const maxUTRLength = 22
+
+/*
+IngestInboundPaymentFile records every payment of a clearing file and settles it from the inbound clearing account
+
+  - a payment matched to a savings or current account is credited to it
+  - a payment that cannot be matched to such an account is parked in the suspense queue
+  - a record that is not a valid payment for this bank, or whose UTR was already received, is rejected
+
+The same file (by the checksum of its content) is only ever ingested once.
+It must be called within a database transaction because it locks the account rows for update.
+*/
+func (s *transferService) IngestInboundPaymentFile(requestCtx context.Context, dbExecutor bun.IDB, params types.IngestInboundPaymentFileParams) (*model.InboundPaymentFile, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	records, err := clearingfile.Parse(params.Format, params.Content)
+	if err != nil {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("The clearing file could not be read: %s", err.Error()),
+		}
+	}
+
+	checksum := sha256.Sum256(params.Content)
+	inboundPaymentFile, err := s.transferRepository.CreateInboundPaymentFile(requestCtx, dbExecutor, &model.InboundPaymentFile{
+		FileName:   params.FileName,
+		Format:     params.Format,
+		Source:     params.Source,
+		Checksum:   hex.EncodeToString(checksum[:]),
+		UploadedBy: params.UploadedBy,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrInboundPaymentFileAlreadyIngested) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusConflict,
+				Message:        "This clearing file has already been ingested",
+			}
+		}
+		return nil, err
+	}
+
+	// UTRs accepted so far from this file, a UTR repeated within the file is rejected the same way as one received before
+	acceptedUTRs := make(map[string]bool, len(records))
+
+	var creditedCount, suspenseCount, rejectedCount int
+	for _, record := range records {
+		inboundPayment, err := s.ingestInboundPaymentRecord(requestCtx, dbExecutor, inboundPaymentFile.ID, record, acceptedUTRs)
+		if err != nil {
+			return nil, err
+		}
+
+		switch inboundPayment.Status {
+		case model.InboundPaymentCredited:
+			creditedCount++
+		case model.InboundPaymentSuspense:
+			suspenseCount++
+		case model.InboundPaymentRejected:
+			rejectedCount++
+		}
+	}
+
+	recordCount := len(records)
+	return s.transferRepository.UpdateInboundPaymentFile(requestCtx, dbExecutor, inboundPaymentFile.ID, types.InboundPaymentFileUpdateOptions{
+		NewRecordCount:   &recordCount,
+		NewCreditedCount: &creditedCount,
+		NewSuspenseCount: &suspenseCount,
+		NewRejectedCount: &rejectedCount,
+	})
+}
+
+// ingestInboundPaymentRecord records a single record of a clearing file as an inbound payment, crediting it or parking it in the suspense queue if it is not rejected
+func (s *transferService) ingestInboundPaymentRecord(requestCtx context.Context, dbExecutor bun.IDB, fileID uuid.UUID, record clearingfile.Record, acceptedUTRs map[string]bool) (*model.InboundPayment, error) {
+	inboundPayment := &model.InboundPayment{
+		FileID:                   fileID,
+		LineNumber:               record.LineNumber,
+		UTR:                      record.UTR,
+		Amount:                   max(record.Amount, 0),
+		BeneficiaryAccountNumber: record.BeneficiaryAccountNumber,
+		BeneficiaryIFSC:          record.BeneficiaryIFSC,
+		RemitterName:             record.RemitterName,
+		RemitterAccountNumber:    record.RemitterAccountNumber,
+		RemitterIFSC:             record.RemitterIFSC,
+	}
+
+	rejectionReason, err := s.inboundPaymentRejectionReason(requestCtx, dbExecutor, record, acceptedUTRs)
+	if err != nil {
+		return nil, err
+	}
+	if rejectionReason != nil {
+		inboundPayment.Status = model.InboundPaymentRejected
+		inboundPayment.Reason = rejectionReason
+		inboundPayment.RawRecord = &record.Raw
+		return s.transferRepository.CreateInboundPayment(requestCtx, dbExecutor, inboundPayment)
+	}
+	acceptedUTRs[record.UTR] = true
+
+	account, suspenseReason, err := s.matchInboundPaymentAccount(requestCtx, dbExecutor, record.BeneficiaryAccountNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if suspenseReason != nil {
+		inboundPayment.Status = model.InboundPaymentSuspense
+		inboundPayment.Reason = suspenseReason
+	} else {
+		creditTransaction, err := s.creditInboundPayment(requestCtx, dbExecutor, account, inboundPayment)
+		if err != nil {
+			return nil, err
+		}
+
+		inboundPayment.Status = model.InboundPaymentCredited
+		inboundPayment.AccountID = &account.ID
+		inboundPayment.TransactionID = &creditTransaction.ID
+	}
+
+	inboundPayment, err = s.transferRepository.CreateInboundPayment(requestCtx, dbExecutor, inboundPayment)
+	if err != nil {
+		return nil, err
+	}
+
+	// the clearing house owes the bank every accepted payment, it is settled into the customer account or parked in the suspense account
+	_, err = s.ledgerService.PostEntry(requestCtx, dbExecutor, ledgerTypes.PostEntryParams{
+		InternalAccountCode: ledgerModel.InboundClearing,
+		Type:                ledgerModel.Debit,
+		Amount:              inboundPayment.Amount,
+		Reference:           inboundPayment.ID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if inboundPayment.Status == model.InboundPaymentSuspense {
+		_, err = s.ledgerService.PostEntry(requestCtx, dbExecutor, ledgerTypes.PostEntryParams{
+			InternalAccountCode: ledgerModel.InboundSuspense,
+			Type:                ledgerModel.Credit,
+			Amount:              inboundPayment.Amount,
+			Reference:           inboundPayment.ID.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return inboundPayment, nil
+}
+
+// inboundPaymentRejectionReason returns why the record is not a payment the bank can accept, nil when it can be accepted
+func (s *transferService) inboundPaymentRejectionReason(requestCtx context.Context, dbExecutor bun.IDB, record clearingfile.Record, acceptedUTRs map[string]bool) (*string, error) {
+	var reason string
+	switch {
+	case record.Err != nil:
+		reason = record.Err.Error()
+	case len(record.UTR) > maxUTRLength:
+		reason = fmt.Sprintf("utr must be at most %d characters long", maxUTRLength)
+	case record.Currency != "" && record.Currency != config.GetBankConfig().Currency:
+		reason = fmt.Sprintf("amount must be in %s", config.GetBankConfig().Currency)
+	case !accountnumber.IsValidIFSC(record.BeneficiaryIFSC):
+		reason = "beneficiary_ifsc is not a valid IFSC"
+	case accountnumber.IFSCBankCode(record.BeneficiaryIFSC) != config.GetBankConfig().Code:
+		reason = "beneficiary_ifsc does not belong to this bank"
+	case acceptedUTRs[record.UTR]:
+		reason = "utr is repeated in the clearing file"
+	}
+	if reason != "" {
+		return &reason, nil
+	}
+
+	inboundPayments, err := s.transferRepository.ListInboundPayments(requestCtx, dbExecutor, types.InboundPaymentListOptions{
+		UTR: &record.UTR,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, inboundPayment := range inboundPayments {
+		if inboundPayment.Status != model.InboundPaymentRejected {
+			reason = "a payment with this utr has already been received"
+			return &reason, nil
+		}
+	}
+
+	return nil, nil
+}
+
+/*
+matchInboundPaymentAccount looks up the account an inbound payment is for, locking it for update
+
+The account is identified either by its account number or, for payments received as ISO 20022 messages, by its IBAN.
+It returns the reason the payment must be parked in the suspense queue instead when no savings or current account matches the account number.
+*/
+func (s *transferService) matchInboundPaymentAccount(requestCtx context.Context, dbExecutor bun.IDB, beneficiaryAccountNumber string) (*accountModel.Account, *string, error) {
+	var reason string
+
+	accountID, err := strconv.ParseInt(beneficiaryAccountNumber, 10, 64)
+	if err != nil {
+		accountID, err = s.accountService.ResolveIBAN(requestCtx, dbExecutor, beneficiaryAccountNumber)
+		var apiErr *server.ApiError
+		if errors.As(err, &apiErr) && apiErr.HttpStatusCode == http.StatusInternalServerError {
+			return nil, nil, err
+		}
+	}
+	if err != nil || !accountnumber.IsValid(accountID) {
+		reason = "beneficiary account number is not valid"
+		return nil, &reason, nil
+	}
+
+	account, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &accountID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		var apiErr *server.ApiError
+		if errors.As(err, &apiErr) && apiErr.HttpStatusCode == http.StatusNotFound {
+			reason = "beneficiary account does not exist"
+			return nil, &reason, nil
+		}
+		return nil, nil, err
+	}
+
+	if !account.Type.AllowsTransfers() {
+		reason = "beneficiary account does not accept transfers"
+		return nil, &reason, nil
+	}
+
+	// the clearing house only settles in the home currency of the bank, which is all the inbound payments are in
+	if string(account.Currency) != config.GetBankConfig().Currency {
+		reason = fmt.Sprintf("beneficiary account is not held in %s", config.GetBankConfig().Currency)
+		return nil, &reason, nil
+	}
+
+	return account, nil, nil
+}
+
+// creditInboundPayment credits the amount of the inbound payment to the account, which must already be locked for update
+func (s *transferService) creditInboundPayment(requestCtx context.Context, dbExecutor bun.IDB, account *accountModel.Account, inboundPayment *model.InboundPayment) (*accountModel.Transaction, error) {
+	newBalance := account.Balance + inboundPayment.Amount
+	account, err := s.accountService.UpdateAccount(requestCtx, dbExecutor, account.ID, accountTypes.AccountUpdateOptions{
+		NewBalance: &newBalance,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	description := fmt.Sprintf("Transfer from %s, UTR %s", inboundPayment.RemitterName, inboundPayment.UTR)
+	return s.accountService.CreateTransactionRecord(requestCtx, dbExecutor, &accountModel.Transaction{
+		AccountID:    account.ID,
+		Amount:       inboundPayment.Amount,
+		BalanceAfter: account.Balance,
+		Type:         accountModel.InboundTransfer,
+		Description:  &description,
+	})
+}
+
+func (s *transferService) GetInboundPaymentFile(requestCtx context.Context, dbExecutor bun.IDB, options types.InboundPaymentFileQueryOptions) (*model.InboundPaymentFile, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.transferRepository.GetInboundPaymentFile(requestCtx, dbExecutor, options)
+}
+
+func (s *transferService) ListInboundPayments(requestCtx context.Context, dbExecutor bun.IDB, options types.InboundPaymentListOptions) ([]model.InboundPayment, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.transferRepository.ListInboundPayments(requestCtx, dbExecutor, options)
+}
+
+/*
+AssignInboundPayment takes an inbound payment out of the suspense queue and credits it to the account chosen by an admin
+
+It must be called within a database transaction because it locks the inbound payment and account rows for update.
+*/
+func (s *transferService) AssignInboundPayment(requestCtx context.Context, dbExecutor bun.IDB, params types.AssignInboundPaymentParams) (*model.InboundPayment, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	inboundPayment, err := s.getSuspendedInboundPayment(requestCtx, dbExecutor, params.InboundPaymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &params.AccountID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !account.Type.AllowsTransfers() {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Inbound payments can only be credited to savings and current accounts",
+		}
+	}
+
+	if string(account.Currency) != config.GetBankConfig().Currency {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("Inbound payments can only be credited to accounts held in %s", config.GetBankConfig().Currency),
+		}
+	}
+
+	creditTransaction, err := s.creditInboundPayment(requestCtx, dbExecutor, account, inboundPayment)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.ledgerService.PostEntry(requestCtx, dbExecutor, ledgerTypes.PostEntryParams{
+		InternalAccountCode: ledgerModel.InboundSuspense,
+		Type:                ledgerModel.Debit,
+		Amount:              inboundPayment.Amount,
+		Reference:           inboundPayment.ID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	creditedStatus := model.InboundPaymentCredited
+	now := time.Now().UTC()
+	return s.transferRepository.UpdateInboundPayment(requestCtx, dbExecutor, inboundPayment.ID, types.InboundPaymentUpdateOptions{
+		NewStatus:        &creditedStatus,
+		NewAccountID:     &account.ID,
+		NewTransactionID: &creditTransaction.ID,
+		NewResolvedBy:    &params.AssignedBy,
+		NewResolvedAt:    &now,
+	})
+}
+
+/*
+ReturnInboundPayment takes an inbound payment out of the suspense queue and returns it to the remitter
+
+The amount moves back from the suspense account to the inbound clearing account, it is owed back to the clearing house.
+It must be called within a database transaction because it locks the inbound payment row for update.
+*/
+func (s *transferService) ReturnInboundPayment(requestCtx context.Context, dbExecutor bun.IDB, params types.ReturnInboundPaymentParams) (*model.InboundPayment, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	inboundPayment, err := s.getSuspendedInboundPayment(requestCtx, dbExecutor, params.InboundPaymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.ledgerService.PostEntry(requestCtx, dbExecutor, ledgerTypes.PostEntryParams{
+		InternalAccountCode: ledgerModel.InboundSuspense,
+		Type:                ledgerModel.Debit,
+		Amount:              inboundPayment.Amount,
+		Reference:           inboundPayment.ID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.ledgerService.PostEntry(requestCtx, dbExecutor, ledgerTypes.PostEntryParams{
+		InternalAccountCode: ledgerModel.InboundClearing,
+		Type:                ledgerModel.Credit,
+		Amount:              inboundPayment.Amount,
+		Reference:           inboundPayment.ID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	returnedStatus := model.InboundPaymentReturned
+	now := time.Now().UTC()
+	return s.transferRepository.UpdateInboundPayment(requestCtx, dbExecutor, inboundPayment.ID, types.InboundPaymentUpdateOptions{
+		NewStatus:     &returnedStatus,
+		NewReason:     &params.Reason,
+		NewResolvedBy: &params.ReturnedBy,
+		NewResolvedAt: &now,
+	})
+}
+
+// getSuspendedInboundPayment locks the inbound payment for update, failing unless it is in the suspense queue
+func (s *transferService) getSuspendedInboundPayment(requestCtx context.Context, dbExecutor bun.IDB, inboundPaymentID uuid.UUID) (*model.InboundPayment, error) {
+	inboundPayment, err := s.transferRepository.GetInboundPayment(requestCtx, dbExecutor, types.InboundPaymentQueryOptions{
+		ID:        &inboundPaymentID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if inboundPayment.Status != model.InboundPaymentSuspense {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusConflict,
+			Message:        "Inbound payment is not in the suspense queue",
+		}
+	}
+
+	return inboundPayment, nil
+}
+
+// inboundPaymentAcknowledgmentStatuses maps the status of an inbound payment to the status reported for it in the acknowledgment file
+var inboundPaymentAcknowledgmentStatuses = map[model.InboundPaymentStatus]string{
+	model.InboundPaymentCredited: "ACCEPTED",
+	model.InboundPaymentSuspense: "PENDING",
+	model.InboundPaymentReturned: "RETURNED",
+	model.InboundPaymentRejected: "REJECTED",
+}
+
+/*
+BuildInboundPaymentAcknowledgment returns the acknowledgment file of a clearing file, in the same format as the clearing file
+
+It reports the current status of every record, so a payment still in the suspense queue is reported as PENDING
+until an admin assigns or returns it, and the acknowledgment can be built again.
+*/
+func (s *transferService) BuildInboundPaymentAcknowledgment(requestCtx context.Context, dbExecutor bun.IDB, fileID uuid.UUID) (*types.InboundPaymentAcknowledgment, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	inboundPaymentFile, err := s.transferRepository.GetInboundPaymentFile(requestCtx, dbExecutor, types.InboundPaymentFileQueryOptions{
+		ID: &fileID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if inboundPaymentFile.Format == clearingfile.Pacs008 {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Acknowledgment files are not built for pacs.008 messages",
+		}
+	}
+
+	inboundPayments, err := s.transferRepository.ListInboundPayments(requestCtx, dbExecutor, types.InboundPaymentListOptions{
+		FileID: &fileID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]clearingfile.AcknowledgmentEntry, 0, len(inboundPayments))
+	for _, inboundPayment := range inboundPayments {
+		entry := clearingfile.AcknowledgmentEntry{
+			UTR:    inboundPayment.UTR,
+			Status: inboundPaymentAcknowledgmentStatuses[inboundPayment.Status],
+		}
+		// the reason a payment was parked in the suspense queue is internal, the clearing house only needs it for a payment that was not accepted
+		if inboundPayment.Reason != nil && (inboundPayment.Status == model.InboundPaymentRejected || inboundPayment.Status == model.InboundPaymentReturned) {
+			entry.Reason = *inboundPayment.Reason
+		}
+		entries = append(entries, entry)
+	}
+
+	content, err := clearingfile.WriteAcknowledgment(inboundPaymentFile.Format, entries)
+	if err != nil {
+		logger.Error(requestCtx, "Error while writing acknowledgment of inbound payment file with ID: %s, error: %+v", fileID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't build the acknowledgment file at the moment. Please try again later.",
+		}
+	}
+
+	return &types.InboundPaymentAcknowledgment{
+		FileName: inboundPaymentAcknowledgmentFileName(inboundPaymentFile.FileName),
+		Format:   inboundPaymentFile.Format,
+		Content:  content,
+	}, nil
+}
+
+// inboundPaymentAcknowledgmentFileName returns the name of the acknowledgment file of a clearing file, eg: neft_20251230.csv -> neft_20251230.ack.csv
+func inboundPaymentAcknowledgmentFileName(fileName string) string {
+	extension := filepath.Ext(fileName)
+	return strings.TrimSuffix(fileName, extension) + ".ack" + extension
+}
@@ -2,12 +2,94 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
 	"github.com/uptrace/bun"
 )
 
 type TransferService interface {
-	CreateInternalTransfer(requestCtx context.Context, dbExecutor bun.IDB, senderUserID uuid.UUID, fromAccountID, toAccountID, transferAmount int64) (*accountModel.Transaction, error)
+	CreateInternalTransfer(requestCtx context.Context, dbExecutor bun.IDB, senderUserID uuid.UUID, fromAccountID, toAccountID, transferAmount int64, remittance accountTypes.Remittance) (*accountModel.Transaction, error)
+	CreateCrossCurrencyTransfer(requestCtx context.Context, dbExecutor bun.IDB, params types.CreateCrossCurrencyTransferParams) (*accountModel.Transaction, error)
+	MoveFunds(requestCtx context.Context, dbExecutor bun.IDB, fromAccountID, toAccountID, amount int64, remittance accountTypes.Remittance) (*accountModel.Transaction, error)
+	GetTransferLimits(requestCtx context.Context, dbExecutor bun.IDB, userID uuid.UUID, accountType accountModel.AccountType) (*types.TransferLimits, error)
+	SetTransferLimits(requestCtx context.Context, dbExecutor bun.IDB, params types.SetTransferLimitsParams) (*types.TransferLimits, error)
+
+	CreateScheduledTransfer(requestCtx context.Context, dbExecutor bun.IDB, params types.CreateScheduledTransferParams) (*model.ScheduledTransfer, error)
+	GetScheduledTransfer(requestCtx context.Context, dbExecutor bun.IDB, options types.ScheduledTransferQueryOptions) (*model.ScheduledTransfer, error)
+	ListScheduledTransfers(requestCtx context.Context, dbExecutor bun.IDB, options types.ScheduledTransferListOptions) ([]model.ScheduledTransfer, error)
+	CancelScheduledTransfer(requestCtx context.Context, dbExecutor bun.IDB, scheduledTransferID uuid.UUID) (*model.ScheduledTransfer, error)
+	ExecuteScheduledTransfer(requestCtx context.Context, dbExecutor bun.IDB, scheduledTransferID uuid.UUID, executionTime time.Time) (*model.ScheduledTransfer, error)
+	FailScheduledTransfer(requestCtx context.Context, dbExecutor bun.IDB, scheduledTransferID uuid.UUID, failureReason string, executionTime time.Time) (*model.ScheduledTransfer, error)
+
+	CreateStandingInstruction(requestCtx context.Context, dbExecutor bun.IDB, params types.CreateStandingInstructionParams) (*model.StandingInstruction, error)
+	GetStandingInstruction(requestCtx context.Context, dbExecutor bun.IDB, options types.StandingInstructionQueryOptions) (*model.StandingInstruction, error)
+	ListStandingInstructions(requestCtx context.Context, dbExecutor bun.IDB, options types.StandingInstructionListOptions) ([]model.StandingInstruction, error)
+	ListStandingInstructionExecutions(requestCtx context.Context, dbExecutor bun.IDB, options types.StandingInstructionExecutionListOptions) ([]model.StandingInstructionExecution, error)
+	CancelStandingInstruction(requestCtx context.Context, dbExecutor bun.IDB, standingInstructionID uuid.UUID) (*model.StandingInstruction, error)
+	ResumeStandingInstruction(requestCtx context.Context, dbExecutor bun.IDB, standingInstructionID uuid.UUID) (*model.StandingInstruction, error)
+	ExecuteStandingInstruction(requestCtx context.Context, dbExecutor bun.IDB, standingInstructionID uuid.UUID, executionDate time.Time) (*model.StandingInstructionExecution, error)
+	RecordStandingInstructionFailure(requestCtx context.Context, dbExecutor bun.IDB, standingInstructionID uuid.UUID, executionDate time.Time, failureReason string) (*model.StandingInstruction, error)
+
+	ReverseTransfer(requestCtx context.Context, dbExecutor bun.IDB, params types.ReverseTransferParams) (*model.TransferReversal, error)
+	GetTransferReversal(requestCtx context.Context, dbExecutor bun.IDB, options types.TransferReversalQueryOptions) (*model.TransferReversal, error)
+	ListTransferReversals(requestCtx context.Context, dbExecutor bun.IDB, options types.TransferReversalListOptions) ([]model.TransferReversal, error)
+	CollectTransferReversalHold(requestCtx context.Context, dbExecutor bun.IDB, transferReversalID uuid.UUID) (*model.TransferReversal, error)
+
+	CreateExternalTransfer(requestCtx context.Context, dbExecutor bun.IDB, params types.CreateExternalTransferParams) (*model.PaymentOrder, error)
+	GetPaymentOrder(requestCtx context.Context, dbExecutor bun.IDB, options types.PaymentOrderQueryOptions) (*model.PaymentOrder, error)
+	ListPaymentOrders(requestCtx context.Context, dbExecutor bun.IDB, options types.PaymentOrderListOptions) ([]model.PaymentOrder, error)
+	DispatchPaymentOrder(requestCtx context.Context, dbExecutor bun.IDB, paymentOrderID uuid.UUID, dispatchTime time.Time) (*model.PaymentOrder, error)
+	CompletePaymentOrder(requestCtx context.Context, dbExecutor bun.IDB, paymentOrderID uuid.UUID, completionTime time.Time) (*model.PaymentOrder, error)
+
+	IngestInboundPaymentFile(requestCtx context.Context, dbExecutor bun.IDB, params types.IngestInboundPaymentFileParams) (*model.InboundPaymentFile, error)
+	GetInboundPaymentFile(requestCtx context.Context, dbExecutor bun.IDB, options types.InboundPaymentFileQueryOptions) (*model.InboundPaymentFile, error)
+	ListInboundPayments(requestCtx context.Context, dbExecutor bun.IDB, options types.InboundPaymentListOptions) ([]model.InboundPayment, error)
+	AssignInboundPayment(requestCtx context.Context, dbExecutor bun.IDB, params types.AssignInboundPaymentParams) (*model.InboundPayment, error)
+	ReturnInboundPayment(requestCtx context.Context, dbExecutor bun.IDB, params types.ReturnInboundPaymentParams) (*model.InboundPayment, error)
+	BuildInboundPaymentAcknowledgment(requestCtx context.Context, dbExecutor bun.IDB, fileID uuid.UUID) (*types.InboundPaymentAcknowledgment, error)
+
+	CreateBulkTransfer(requestCtx context.Context, dbExecutor bun.IDB, params types.CreateBulkTransferParams) (*model.BulkTransfer, error)
+	GetBulkTransfer(requestCtx context.Context, dbExecutor bun.IDB, options types.BulkTransferQueryOptions) (*model.BulkTransfer, error)
+	ListBulkTransfers(requestCtx context.Context, dbExecutor bun.IDB, options types.BulkTransferListOptions) ([]model.BulkTransfer, error)
+	ListBulkTransferItems(requestCtx context.Context, dbExecutor bun.IDB, options types.BulkTransferItemListOptions) ([]model.BulkTransferItem, error)
+	ConfirmBulkTransfer(requestCtx context.Context, dbExecutor bun.IDB, bulkTransferID uuid.UUID) (*model.BulkTransfer, error)
+	CancelBulkTransfer(requestCtx context.Context, dbExecutor bun.IDB, bulkTransferID uuid.UUID) (*model.BulkTransfer, error)
+	StartBulkTransfer(requestCtx context.Context, dbExecutor bun.IDB, bulkTransferID uuid.UUID) (*model.BulkTransfer, error)
+	ExecuteBulkTransferItem(requestCtx context.Context, dbExecutor bun.IDB, itemID uuid.UUID, executionTime time.Time) (*model.BulkTransferItem, error)
+	FailBulkTransferItem(requestCtx context.Context, dbExecutor bun.IDB, itemID uuid.UUID, failureReason string, executionTime time.Time) (*model.BulkTransferItem, error)
+	CompleteBulkTransfer(requestCtx context.Context, dbExecutor bun.IDB, bulkTransferID uuid.UUID, completionTime time.Time) (*model.BulkTransfer, error)
+	BuildBulkTransferResult(requestCtx context.Context, dbExecutor bun.IDB, bulkTransferID uuid.UUID) (*types.BulkTransferResult, error)
+
+	RequiresJointApproval(requestCtx context.Context, dbExecutor bun.IDB, account *accountModel.Account, amount int64) (bool, error)
+	EnforceJointMandate(requestCtx context.Context, dbExecutor bun.IDB, account *accountModel.Account, amount int64) error
+	CreateJointTransfer(requestCtx context.Context, dbExecutor bun.IDB, params types.CreateJointTransferParams) (*model.JointTransfer, error)
+	GetJointTransfer(requestCtx context.Context, dbExecutor bun.IDB, options types.JointTransferQueryOptions) (*model.JointTransfer, error)
+	ListJointTransfers(requestCtx context.Context, dbExecutor bun.IDB, options types.JointTransferListOptions) ([]model.JointTransfer, error)
+	ListJointTransferApprovals(requestCtx context.Context, dbExecutor bun.IDB, options types.JointTransferApprovalListOptions) ([]model.JointTransferApproval, error)
+	ApproveJointTransfer(requestCtx context.Context, dbExecutor bun.IDB, jointTransferID uuid.UUID, userID uuid.UUID) (*model.JointTransfer, error)
+	RejectJointTransfer(requestCtx context.Context, dbExecutor bun.IDB, jointTransferID uuid.UUID, userID uuid.UUID) (*model.JointTransfer, error)
+	CancelTransfersOfRemovedHolder(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, userID uuid.UUID) error
+
+	ReceiveISO20022Message(requestCtx context.Context, dbExecutor bun.IDB, params types.ReceiveISO20022MessageParams) (*types.ISO20022MessageResult, error)
+	BuildPaymentOrderCreditTransfer(requestCtx context.Context, dbExecutor bun.IDB, params types.BuildPaymentOrderCreditTransferParams) ([]byte, error)
+}
+
+// PaymentRail sends external transfers over the clearing system of their rail (NEFT, IMPS or RTGS) and reports their outcome
+type PaymentRail interface {
+	// ValidateAmount rejects an amount the rail does not accept
+	ValidateAmount(rail model.Rail, amount int64) error
+
+	// NextDispatchTime returns the earliest time, at or after now, an order can be sent over the rail at
+	NextDispatchTime(rail model.Rail, now time.Time) time.Time
+
+	// Send hands the order over to the rail, it returns the reference assigned by the rail and when the outcome is due
+	Send(requestCtx context.Context, paymentOrder *model.PaymentOrder, now time.Time) (*types.RailSubmission, error)
+
+	// Outcome returns nil when the order has settled, otherwise the reason the beneficiary bank returned it for
+	Outcome(requestCtx context.Context, paymentOrder *model.PaymentOrder) (*string, error)
 }
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/config"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	ledgerModel "github.com/skamranahmed/go-bank/internal/ledger/model"
+	ledgerTypes "github.com/skamranahmed/go-bank/internal/ledger/types"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/accountnumber"
+	"github.com/skamranahmed/go-bank/pkg/clearingfile"
+	"github.com/skamranahmed/go-bank/pkg/iso20022"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+/*
+ReceiveISO20022Message processes an ISO 20022 message received from the clearing house
+
+  - a pacs.008 is ingested the same way as a clearing file, its payments are credited or parked in the suspense queue
+  - a pacs.004 returns the outbound external transfers it lists, crediting them back to the customers
+
+A message violating the schema is not processed, its violations are returned in the ValidationErrors of the result.
+A message that cannot be processed as a whole, e.g. one returning an order that was never sent, fails with an error
+naming the offending transaction.
+It must be called within a database transaction because it locks the account and payment order rows for update.
+*/
+func (s *transferService) ReceiveISO20022Message(requestCtx context.Context, dbExecutor bun.IDB, params types.ReceiveISO20022MessageParams) (*types.ISO20022MessageResult, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	messageType, err := iso20022.DetectMessageType(params.Content)
+	if err != nil {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("The message could not be read: %s", err.Error()),
+		}
+	}
+
+	result := &types.ISO20022MessageResult{MessageType: messageType}
+	switch messageType {
+	case iso20022.Pacs008:
+		creditTransfer, err := iso20022.DecodeCreditTransfer(params.Content)
+		if err != nil {
+			return invalidISO20022Message(result, err)
+		}
+		result.MessageID = creditTransfer.MessageID
+
+		fileName := params.FileName
+		if fileName == "" {
+			fileName = creditTransfer.MessageID + ".xml"
+		}
+
+		result.InboundPaymentFile, err = s.IngestInboundPaymentFile(requestCtx, dbExecutor, types.IngestInboundPaymentFileParams{
+			FileName:   fileName,
+			Format:     clearingfile.Pacs008,
+			Content:    params.Content,
+			Source:     params.Source,
+			UploadedBy: params.ReceivedBy,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+	case iso20022.Pacs004:
+		paymentReturn, err := iso20022.DecodePaymentReturn(params.Content)
+		if err != nil {
+			return invalidISO20022Message(result, err)
+		}
+		result.MessageID = paymentReturn.MessageID
+
+		returnTime := time.Now().UTC()
+		for i, transaction := range paymentReturn.Transactions {
+			paymentOrder, err := s.returnPaymentOrder(requestCtx, dbExecutor, transaction, returnTime)
+			if err != nil {
+				return nil, prefixApiError(err, fmt.Sprintf("TxInf[%d]: ", i+1))
+			}
+			result.PaymentOrders = append(result.PaymentOrders, *paymentOrder)
+		}
+
+	default:
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("%s messages are only sent by the bank, they cannot be received", messageType),
+		}
+	}
+
+	return result, nil
+}
+
+// invalidISO20022Message records the schema violations of the message on the result, any other decoding error fails the message
+func invalidISO20022Message(result *types.ISO20022MessageResult, err error) (*types.ISO20022MessageResult, error) {
+	var validationErrors iso20022.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		result.ValidationErrors = validationErrors
+		return result, nil
+	}
+
+	return nil, &server.ApiError{
+		HttpStatusCode: http.StatusBadRequest,
+		Message:        fmt.Sprintf("The message could not be read: %s", err.Error()),
+	}
+}
+
+// prefixApiError prefixes the message of a client error, so that it names the part of the request it is about
+func prefixApiError(err error, prefix string) error {
+	var apiErr *server.ApiError
+	if errors.As(err, &apiErr) && apiErr.HttpStatusCode < http.StatusInternalServerError {
+		return &server.ApiError{
+			HttpStatusCode: apiErr.HttpStatusCode,
+			Message:        prefix + apiErr.Message,
+		}
+	}
+	return err
+}
+
+/*
+returnPaymentOrder returns the payment order a transaction of a pacs.004 is about, crediting its amount back to the customer
+
+The order is matched by the UETR, the end to end ID or the UTR it was sent with, see BuildPaymentOrderCreditTransfer.
+An order still waiting for its outcome takes the amount out of the outbound clearing account, the same way a return reported
+by its rail does. A settled order has already left the bank, the clearing house now owes the amount back through the inbound clearing account.
+*/
+func (s *transferService) returnPaymentOrder(requestCtx context.Context, dbExecutor bun.IDB, transaction iso20022.PaymentReturnTransaction, returnTime time.Time) (*model.PaymentOrder, error) {
+	queryOptions := types.PaymentOrderQueryOptions{
+		ForUpdate: true, // lock the row for update
+	}
+	switch {
+	case transaction.OriginalUETR != "":
+		// the format has already been validated by the decoder
+		paymentOrderID := uuid.MustParse(transaction.OriginalUETR)
+		queryOptions.ID = &paymentOrderID
+	case transaction.OriginalEndToEndID != "":
+		paymentOrderID, err := uuid.Parse(transaction.OriginalEndToEndID)
+		if err != nil {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Payment order not found",
+			}
+		}
+		queryOptions.ID = &paymentOrderID
+	default:
+		queryOptions.RailReference = &transaction.OriginalTransactionID
+	}
+
+	paymentOrder, err := s.transferRepository.GetPaymentOrder(requestCtx, dbExecutor, queryOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction.Currency != config.GetBankConfig().Currency {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("Returned amount must be in %s", config.GetBankConfig().Currency),
+		}
+	}
+
+	if transaction.Amount != paymentOrder.Amount {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Returned amount must be the full amount of the payment order",
+		}
+	}
+
+	var clearingAccountCode ledgerModel.InternalAccountCode
+	switch paymentOrder.Status {
+	case model.PaymentOrderSent:
+		clearingAccountCode = ledgerModel.OutboundClearing
+	case model.PaymentOrderSettled:
+		clearingAccountCode = ledgerModel.InboundClearing
+	case model.PaymentOrderReturned:
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusConflict,
+			Message:        "Payment order has already been returned",
+		}
+	default:
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusConflict,
+			Message:        "Payment order has not been sent yet",
+		}
+	}
+
+	_, err = s.ledgerService.PostEntry(requestCtx, dbExecutor, ledgerTypes.PostEntryParams{
+		InternalAccountCode: clearingAccountCode,
+		Type:                ledgerModel.Debit,
+		Amount:              paymentOrder.Amount,
+		Reference:           paymentOrder.ID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	returnTransaction, err := s.creditReturnedPaymentOrder(requestCtx, dbExecutor, paymentOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	returnReason := transaction.Reason()
+	returnedStatus := model.PaymentOrderReturned
+	return s.transferRepository.UpdatePaymentOrder(requestCtx, dbExecutor, paymentOrder.ID, types.PaymentOrderUpdateOptions{
+		NewStatus:              &returnedStatus,
+		NewReturnReason:        &returnReason,
+		NewReturnTransactionID: &returnTransaction.ID,
+		NewReturnedAt:          &returnTime,
+	})
+}
+
+/*
+BuildPaymentOrderCreditTransfer returns the pacs.008 message sending the payment order over its rail
+
+The order is identified in the message the way a pacs.004 returning it is matched back to it:
+its ID is the UETR, its ID without dashes is the end to end ID and the UTR assigned by the rail is the transaction ID.
+*/
+func (s *transferService) BuildPaymentOrderCreditTransfer(requestCtx context.Context, dbExecutor bun.IDB, params types.BuildPaymentOrderCreditTransferParams) ([]byte, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	paymentOrder, err := s.transferRepository.GetPaymentOrder(requestCtx, dbExecutor, types.PaymentOrderQueryOptions{
+		ID: &params.PaymentOrderID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	debitTransaction, err := s.accountService.GetTransaction(requestCtx, dbExecutor, accountTypes.TransactionGetOptions{
+		ID: &paymentOrder.DebitTransactionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fromAccount, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &paymentOrder.FromAccountID,
+		Columns:   []string{"id", "branch_code"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bankConfig := config.GetBankConfig()
+	debtorIBAN, err := accountnumber.IBAN(bankConfig.CountryCode, bankConfig.Code, fromAccount.BranchCode, fromAccount.ID)
+	if err != nil {
+		logger.Error(requestCtx, "Error while building IBAN of accountID: %d, error: %+v", fromAccount.ID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't build the payment message at the moment. Please try again later.",
+		}
+	}
+
+	// the message of an order not sent yet carries the ID of the order until the rail assigns it a UTR
+	endToEndID := strings.ReplaceAll(paymentOrder.ID.String(), "-", "")
+	transactionID := endToEndID
+	if paymentOrder.RailReference != nil {
+		transactionID = *paymentOrder.RailReference
+	}
+
+	settlementDate := paymentOrder.DispatchAt
+	if paymentOrder.SentAt != nil {
+		settlementDate = *paymentOrder.SentAt
+	}
+
+	remittanceInformation := ""
+	if debitTransaction.Narration != nil {
+		remittanceInformation = *debitTransaction.Narration
+	}
+
+	content, err := iso20022.EncodeCreditTransfer(iso20022.CreditTransfer{
+		MessageID:      endToEndID,
+		CreationTime:   time.Now().UTC(),
+		ClearingSystem: string(paymentOrder.Rail),
+		Transactions: []iso20022.CreditTransferTransaction{
+			{
+				InstructionID:  endToEndID,
+				EndToEndID:     endToEndID,
+				TransactionID:  transactionID,
+				UETR:           paymentOrder.ID.String(),
+				Amount:         paymentOrder.Amount,
+				Currency:       bankConfig.Currency,
+				SettlementDate: settlementDate,
+				Debtor: iso20022.Party{
+					Name:        params.DebtorName,
+					AccountIBAN: debtorIBAN,
+					AgentIFSC:   accountnumber.IFSC(bankConfig.Code, fromAccount.BranchCode),
+				},
+				Creditor: iso20022.Party{
+					Name:          paymentOrder.BeneficiaryName,
+					AccountNumber: paymentOrder.BeneficiaryAccountNumber,
+					AgentIFSC:     paymentOrder.BeneficiaryIFSC,
+				},
+				RemittanceInformation: remittanceInformation,
+			},
+		},
+	})
+	if err != nil {
+		logger.Error(requestCtx, "Error while encoding pacs.008 message of payment order with ID: %s, error: %+v", paymentOrder.ID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't build the payment message at the moment. Please try again later.",
+		}
+	}
+
+	return content, nil
+}
@@ -0,0 +1,448 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/uptrace/bun"
+)
+
+/*
+RequiresJointApproval reports whether a transfer of the amount out of the account has to be approved by every holder of the account
+
+It is only the case for a joint account held under the JOINTLY mandate, when the amount is above its joint approval threshold.
+An account whose joint holders have all left is operated by its primary holder alone, whatever its mandate.
+*/
+func (s *transferService) RequiresJointApproval(requestCtx context.Context, dbExecutor bun.IDB, account *accountModel.Account, amount int64) (bool, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	if !account.RequiresJointApproval(amount) {
+		return false, nil
+	}
+
+	return s.isJointlyOperated(requestCtx, dbExecutor, account)
+}
+
+/*
+EnforceJointMandate rejects a debit of the amount out of the account when it has to be approved by every holder of the account
+
+Every debit a single holder makes goes through it, whichever product it is made from,
+only a joint transfer approved by every holder can take such an amount out of the account.
+*/
+func (s *transferService) EnforceJointMandate(requestCtx context.Context, dbExecutor bun.IDB, account *accountModel.Account, amount int64) error {
+	requiresJointApproval, err := s.RequiresJointApproval(requestCtx, dbExecutor, account, amount)
+	if err != nil {
+		return err
+	}
+	if requiresJointApproval {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Transfers above the joint approval threshold of this account can only be made as internal transfers approved by every holder",
+		}
+	}
+	return nil
+}
+
+// isJointlyOperated reports whether the account is held under the JOINTLY mandate by more than one holder, so that its debits above the threshold need every holder
+func (s *transferService) isJointlyOperated(requestCtx context.Context, dbExecutor bun.IDB, account *accountModel.Account) (bool, error) {
+	if account.Mandate != accountModel.MandateJointly {
+		return false, nil
+	}
+
+	holderUserIDs, err := s.accountService.ListAccountHolderUserIDs(requestCtx, dbExecutor, account)
+	if err != nil {
+		return false, err
+	}
+
+	return len(holderUserIDs) > 1, nil
+}
+
+/*
+CreateJointTransfer records a transfer out of a joint account that has to be approved by every holder of the account before it is executed
+
+The initiator approves the transfer by initiating it, the other holders approve or reject it using ApproveJointTransfer and RejectJointTransfer.
+It must be called within a database transaction, so that the transfer is never stored without the approval of its initiator.
+*/
+func (s *transferService) CreateJointTransfer(requestCtx context.Context, dbExecutor bun.IDB, params types.CreateJointTransferParams) (*model.JointTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	jointTransfer, err := s.transferRepository.CreateJointTransfer(requestCtx, dbExecutor, &model.JointTransfer{
+		InitiatedByUserID: params.InitiatedByUserID,
+		FromAccountID:     params.FromAccountID,
+		ToAccountID:       params.ToAccountID,
+		Amount:            params.Amount,
+		Narration:         params.Remittance.Narration,
+		ClientReference:   params.Remittance.ClientReference,
+		Category:          params.Remittance.Category,
+		Status:            model.JointTransferPending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.transferRepository.CreateJointTransferApproval(requestCtx, dbExecutor, &model.JointTransferApproval{
+		JointTransferID: jointTransfer.ID,
+		UserID:          params.InitiatedByUserID,
+		Decision:        model.JointTransferApproved,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return jointTransfer, nil
+}
+
+func (s *transferService) GetJointTransfer(requestCtx context.Context, dbExecutor bun.IDB, options types.JointTransferQueryOptions) (*model.JointTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.transferRepository.GetJointTransfer(requestCtx, dbExecutor, options)
+}
+
+func (s *transferService) ListJointTransfers(requestCtx context.Context, dbExecutor bun.IDB, options types.JointTransferListOptions) ([]model.JointTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.transferRepository.ListJointTransfers(requestCtx, dbExecutor, options)
+}
+
+func (s *transferService) ListJointTransferApprovals(requestCtx context.Context, dbExecutor bun.IDB, options types.JointTransferApprovalListOptions) ([]model.JointTransferApproval, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.transferRepository.ListJointTransferApprovals(requestCtx, dbExecutor, options)
+}
+
+/*
+ApproveJointTransfer records the approval of the holder and executes the transfer once every current holder of the account has approved it
+
+The transfer is executed through createInternalTransfer on behalf of its initiator, so it is subject to their transfer limits.
+When the execution is rejected, e.g. for insufficient balance, the error is returned and the whole database transaction must be rolled back,
+the transfer then stays pending and the holder can approve it again later.
+A holder who has already approved it can approve it again, which executes the transfer once the holders who had not approved it left the account.
+It must be called within a database transaction because it locks the joint transfer row for update.
+*/
+func (s *transferService) ApproveJointTransfer(requestCtx context.Context, dbExecutor bun.IDB, jointTransferID uuid.UUID, userID uuid.UUID) (*model.JointTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	jointTransfer, holderUserIDs, approvals, err := s.lockPendingJointTransfer(requestCtx, dbExecutor, jointTransferID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	recordedDecision := jointTransferDecisionOf(approvals, userID)
+	if recordedDecision != nil && *recordedDecision != model.JointTransferApproved {
+		return nil, alreadyDecidedError(*recordedDecision)
+	}
+
+	if recordedDecision == nil {
+		approval, err := s.transferRepository.CreateJointTransferApproval(requestCtx, dbExecutor, &model.JointTransferApproval{
+			JointTransferID: jointTransfer.ID,
+			UserID:          userID,
+			Decision:        model.JointTransferApproved,
+		})
+		if err != nil {
+			return nil, err
+		}
+		approvals = append(approvals, *approval)
+	}
+
+	// a holder who left the account after approving no longer counts, a holder who joined after the transfer was initiated has to approve it too
+	for _, holderUserID := range holderUserIDs {
+		decision := jointTransferDecisionOf(approvals, holderUserID)
+		if decision == nil || *decision != model.JointTransferApproved {
+			if recordedDecision != nil {
+				return nil, alreadyDecidedError(*recordedDecision)
+			}
+			return jointTransfer, nil
+		}
+	}
+
+	// the holders have all approved it, so the mandate of the account is not checked again
+	transaction, err := s.createInternalTransfer(
+		requestCtx,
+		dbExecutor,
+		jointTransfer.InitiatedByUserID,
+		jointTransfer.FromAccountID,
+		jointTransfer.ToAccountID,
+		jointTransfer.Amount,
+		accountTypes.Remittance{
+			Narration:       jointTransfer.Narration,
+			ClientReference: jointTransfer.ClientReference,
+			Category:        jointTransfer.Category,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	completedStatus := model.JointTransferCompleted
+	executedAt := time.Now().UTC()
+	return s.transferRepository.UpdateJointTransfer(requestCtx, dbExecutor, jointTransfer.ID, types.JointTransferUpdateOptions{
+		NewStatus:        &completedStatus,
+		NewTransactionID: &transaction.ID,
+		NewExecutedAt:    &executedAt,
+	})
+}
+
+// RejectJointTransfer records the rejection of the holder, a single rejection rejects the whole transfer, it must be called within a database transaction
+func (s *transferService) RejectJointTransfer(requestCtx context.Context, dbExecutor bun.IDB, jointTransferID uuid.UUID, userID uuid.UUID) (*model.JointTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	jointTransfer, _, approvals, err := s.lockPendingJointTransfer(requestCtx, dbExecutor, jointTransferID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	recordedDecision := jointTransferDecisionOf(approvals, userID)
+	if recordedDecision != nil {
+		return nil, alreadyDecidedError(*recordedDecision)
+	}
+
+	_, err = s.transferRepository.CreateJointTransferApproval(requestCtx, dbExecutor, &model.JointTransferApproval{
+		JointTransferID: jointTransfer.ID,
+		UserID:          userID,
+		Decision:        model.JointTransferDecisionRejected,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rejectedStatus := model.JointTransferRejected
+	return s.transferRepository.UpdateJointTransfer(requestCtx, dbExecutor, jointTransfer.ID, types.JointTransferUpdateOptions{
+		NewStatus: &rejectedStatus,
+	})
+}
+
+/*
+CancelTransfersOfRemovedHolder cancels what the user left to be executed out of the account once they no longer hold it,
+i.e. their pending scheduled transfers, their standing instructions and their bulk transfers not confirmed yet.
+The pending joint transfers they initiated are rejected, since a joint transfer is executed on behalf of its initiator.
+
+A bulk transfer already confirmed is left to the worker, which fails each of its rows as its user no longer holds the account.
+It must be called within the database transaction removing the holder, so that nothing is cancelled when the removal is rolled back.
+*/
+func (s *transferService) CancelTransfersOfRemovedHolder(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, userID uuid.UUID) error {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	pendingStatus := model.ScheduledTransferPending
+	scheduledTransfers, err := s.transferRepository.ListScheduledTransfers(requestCtx, dbExecutor, types.ScheduledTransferListOptions{
+		UserID:        &userID,
+		FromAccountID: &accountID,
+		Status:        &pendingStatus,
+	})
+	if err != nil {
+		return err
+	}
+
+	cancelledScheduledTransferStatus := model.ScheduledTransferCancelled
+	for _, scheduledTransfer := range scheduledTransfers {
+		lockedScheduledTransfer, err := s.transferRepository.GetScheduledTransfer(requestCtx, dbExecutor, types.ScheduledTransferQueryOptions{
+			ID:        &scheduledTransfer.ID,
+			ForUpdate: true, // lock the row, the transfer may have been executed since it was listed
+		})
+		if err != nil {
+			return err
+		}
+		if lockedScheduledTransfer.Status != model.ScheduledTransferPending {
+			continue
+		}
+
+		_, err = s.transferRepository.UpdateScheduledTransfer(requestCtx, dbExecutor, scheduledTransfer.ID, types.ScheduledTransferUpdateOptions{
+			NewStatus: &cancelledScheduledTransferStatus,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cancelledStandingInstructionStatus := model.StandingInstructionCancelled
+	for _, status := range []model.StandingInstructionStatus{model.StandingInstructionActive, model.StandingInstructionSuspended} {
+		standingInstructions, err := s.transferRepository.ListStandingInstructions(requestCtx, dbExecutor, types.StandingInstructionListOptions{
+			UserID:        &userID,
+			FromAccountID: &accountID,
+			Status:        &status,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, standingInstruction := range standingInstructions {
+			lockedStandingInstruction, err := s.transferRepository.GetStandingInstruction(requestCtx, dbExecutor, types.StandingInstructionQueryOptions{
+				ID:        &standingInstruction.ID,
+				ForUpdate: true, // lock the row, an occurrence may have completed the instruction since it was listed
+			})
+			if err != nil {
+				return err
+			}
+			if lockedStandingInstruction.Status != status {
+				continue
+			}
+
+			_, err = s.transferRepository.UpdateStandingInstruction(requestCtx, dbExecutor, standingInstruction.ID, types.StandingInstructionUpdateOptions{
+				NewStatus: &cancelledStandingInstructionStatus,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	cancelledBulkTransferStatus := model.BulkTransferCancelled
+	for _, status := range []model.BulkTransferStatus{model.BulkTransferValidated, model.BulkTransferInvalid} {
+		bulkTransfers, err := s.transferRepository.ListBulkTransfers(requestCtx, dbExecutor, types.BulkTransferListOptions{
+			UserID:        &userID,
+			FromAccountID: &accountID,
+			Status:        &status,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, bulkTransfer := range bulkTransfers {
+			lockedBulkTransfer, err := s.transferRepository.GetBulkTransfer(requestCtx, dbExecutor, types.BulkTransferQueryOptions{
+				ID:        &bulkTransfer.ID,
+				ForUpdate: true, // lock the row, the batch may have been confirmed since it was listed
+			})
+			if err != nil {
+				return err
+			}
+			if lockedBulkTransfer.Status != status {
+				continue
+			}
+
+			_, err = s.transferRepository.UpdateBulkTransfer(requestCtx, dbExecutor, bulkTransfer.ID, types.BulkTransferUpdateOptions{
+				NewStatus: &cancelledBulkTransferStatus,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	pendingJointTransferStatus := model.JointTransferPending
+	jointTransfers, err := s.transferRepository.ListJointTransfers(requestCtx, dbExecutor, types.JointTransferListOptions{
+		FromAccountID:     &accountID,
+		InitiatedByUserID: &userID,
+		Status:            &pendingJointTransferStatus,
+	})
+	if err != nil {
+		return err
+	}
+
+	rejectedJointTransferStatus := model.JointTransferRejected
+	for _, jointTransfer := range jointTransfers {
+		lockedJointTransfer, err := s.transferRepository.GetJointTransfer(requestCtx, dbExecutor, types.JointTransferQueryOptions{
+			ID:        &jointTransfer.ID,
+			ForUpdate: true, // lock the row, the last approval may have executed the transfer since it was listed
+		})
+		if err != nil {
+			return err
+		}
+		if lockedJointTransfer.Status != model.JointTransferPending {
+			continue
+		}
+
+		_, err = s.transferRepository.UpdateJointTransfer(requestCtx, dbExecutor, jointTransfer.ID, types.JointTransferUpdateOptions{
+			NewStatus: &rejectedJointTransferStatus,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lockPendingJointTransfer locks the pending joint transfer and verifies that the user is one of its holders
+// It returns the joint transfer along with the users currently holding its sender account and the decisions recorded on it so far
+func (s *transferService) lockPendingJointTransfer(
+	requestCtx context.Context,
+	dbExecutor bun.IDB,
+	jointTransferID uuid.UUID,
+	userID uuid.UUID,
+) (*model.JointTransfer, []uuid.UUID, []model.JointTransferApproval, error) {
+	jointTransfer, err := s.transferRepository.GetJointTransfer(requestCtx, dbExecutor, types.JointTransferQueryOptions{
+		ID:        &jointTransferID,
+		ForUpdate: true, // lock the row so that the decisions of two holders are not taken at the same time
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	fromAccount, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &jointTransfer.FromAccountID,
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	holderUserIDs, err := s.accountService.ListAccountHolderUserIDs(requestCtx, dbExecutor, fromAccount)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if !slices.Contains(holderUserIDs, userID) {
+		return nil, nil, nil, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You do not have permission to access this joint transfer",
+		}
+	}
+
+	if jointTransfer.Status != model.JointTransferPending {
+		return nil, nil, nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Only a pending joint transfer can be approved or rejected",
+		}
+	}
+
+	approvals, err := s.transferRepository.ListJointTransferApprovals(requestCtx, dbExecutor, types.JointTransferApprovalListOptions{
+		JointTransferID: &jointTransfer.ID,
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return jointTransfer, holderUserIDs, approvals, nil
+}
+
+// jointTransferDecisionOf returns the decision the user has recorded on the joint transfer, nil when they have not decided on it yet
+func jointTransferDecisionOf(approvals []model.JointTransferApproval, userID uuid.UUID) *model.JointTransferDecision {
+	for _, approval := range approvals {
+		if approval.UserID == userID {
+			return &approval.Decision
+		}
+	}
+	return nil
+}
+
+// alreadyDecidedError is returned to a holder deciding on a joint transfer they have already decided on
+func alreadyDecidedError(recordedDecision model.JointTransferDecision) error {
+	return &server.ApiError{
+		HttpStatusCode: http.StatusConflict,
+		Message:        fmt.Sprintf("You have already %s this transfer", strings.ToLower(string(recordedDecision))),
+	}
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/config"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+)
+
+// reasons a beneficiary bank returns an order for, one of them is picked for every simulated return
+var simulatedReturnReasons = []string{
+	"Beneficiary account does not exist",
+	"Beneficiary account is closed",
+	"Beneficiary account is frozen",
+	"Beneficiary name does not match the account",
+}
+
+// simulatedPaymentRail stands in for the clearing systems locally, until the bank is connected to the real rails
+type simulatedPaymentRail struct {
+	bankCode string
+	config   config.PaymentRailConfig
+}
+
+func NewSimulatedPaymentRail(bankCode string, paymentRailConfig config.PaymentRailConfig) PaymentRail {
+	return &simulatedPaymentRail{
+		bankCode: bankCode,
+		config:   paymentRailConfig,
+	}
+}
+
+func (r *simulatedPaymentRail) ValidateAmount(rail model.Rail, amount int64) error {
+	if rail == model.RTGS && amount < r.config.RtgsMinimumAmount {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("RTGS is only available for amounts of at least %d, please use NEFT or IMPS", r.config.RtgsMinimumAmount),
+		}
+	}
+
+	if rail == model.IMPS && amount > r.config.ImpsMaximumAmount {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("IMPS is only available for amounts of up to %d, please use NEFT or RTGS", r.config.ImpsMaximumAmount),
+		}
+	}
+
+	return nil
+}
+
+/*
+NextDispatchTime sends IMPS and RTGS orders immediately
+
+NEFT orders wait for the next batch, the batches run at a fixed interval from the first batch of the day until the cut-off (UTC),
+an order placed after the last batch of the day waits for the first batch of the next day.
+*/
+func (r *simulatedPaymentRail) NextDispatchTime(rail model.Rail, now time.Time) time.Time {
+	now = now.UTC()
+	if rail != model.NEFT {
+		return now
+	}
+
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	firstBatch := startOfDay.Add(time.Duration(r.config.NeftFirstBatchHour) * time.Hour)
+	cutOff := startOfDay.Add(time.Duration(r.config.NeftCutOffHour) * time.Hour)
+	if !now.After(firstBatch) {
+		return firstBatch
+	}
+
+	// round up to the next batch after the first one of the day
+	batchInterval := time.Duration(r.config.NeftBatchIntervalInMinutes) * time.Minute
+	batchesSinceFirst := (now.Sub(firstBatch) + batchInterval - 1) / batchInterval
+	nextBatch := firstBatch.Add(batchesSinceFirst * batchInterval)
+	if nextBatch.After(cutOff) {
+		return firstBatch.AddDate(0, 0, 1)
+	}
+	return nextBatch
+}
+
+// Send assigns a UTR-like reference made of the bank code, the rail, the date and a random number, the outcome is due after the configured latency
+func (r *simulatedPaymentRail) Send(requestCtx context.Context, paymentOrder *model.PaymentOrder, now time.Time) (*types.RailSubmission, error) {
+	now = now.UTC()
+	reference := fmt.Sprintf("%s%c%s%08d", r.bankCode, paymentOrder.Rail[0], now.Format("060102"), rand.IntN(100000000))
+
+	return &types.RailSubmission{
+		Reference:    reference,
+		OutcomeDueAt: now.Add(time.Duration(r.config.LatencyInSeconds) * time.Second),
+	}, nil
+}
+
+// Outcome returns the order with the configured probability, for one of the usual reasons
+func (r *simulatedPaymentRail) Outcome(requestCtx context.Context, paymentOrder *model.PaymentOrder) (*string, error) {
+	if rand.Int64N(10000) >= r.config.ReturnProbabilityInBasisPoints {
+		return nil, nil
+	}
+
+	returnReason := simulatedReturnReasons[rand.IntN(len(simulatedReturnReasons))]
+	return &returnReason, nil
+}
@@ -0,0 +1,352 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/config"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+func (s *transferService) CreateStandingInstruction(requestCtx context.Context, dbExecutor bun.IDB, params types.CreateStandingInstructionParams) (*model.StandingInstruction, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	if params.StartDate.Before(startOfDay(time.Now().UTC())) {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "The start date cannot be in the past",
+		}
+	}
+
+	if params.Frequency == model.StandingInstructionMonthly && params.DayOfMonth == nil {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "The day of the month is required for a monthly standing instruction",
+		}
+	}
+	if params.Frequency != model.StandingInstructionMonthly && params.DayOfMonth != nil {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "The day of the month can only be set for a monthly standing instruction",
+		}
+	}
+
+	if params.EndDate != nil && params.EndDate.Before(params.StartDate) {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "The end date cannot be before the start date",
+		}
+	}
+
+	standingInstruction := &model.StandingInstruction{
+		UserID:         params.UserID,
+		FromAccountID:  params.FromAccountID,
+		ToAccountID:    params.ToAccountID,
+		Amount:         params.Amount,
+		Frequency:      params.Frequency,
+		DayOfMonth:     params.DayOfMonth,
+		StartDate:      params.StartDate,
+		EndDate:        params.EndDate,
+		MaxOccurrences: params.MaxOccurrences,
+		Status:         model.StandingInstructionActive,
+	}
+
+	standingInstruction.NextExecutionDate = standingInstruction.NextOccurrenceDate(0)
+	if standingInstruction.NextExecutionDate == nil {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "The standing instruction has no occurrence on or before its end date",
+		}
+	}
+
+	return s.transferRepository.CreateStandingInstruction(requestCtx, dbExecutor, standingInstruction)
+}
+
+func (s *transferService) GetStandingInstruction(requestCtx context.Context, dbExecutor bun.IDB, options types.StandingInstructionQueryOptions) (*model.StandingInstruction, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.transferRepository.GetStandingInstruction(requestCtx, dbExecutor, options)
+}
+
+func (s *transferService) ListStandingInstructions(requestCtx context.Context, dbExecutor bun.IDB, options types.StandingInstructionListOptions) ([]model.StandingInstruction, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.transferRepository.ListStandingInstructions(requestCtx, dbExecutor, options)
+}
+
+func (s *transferService) ListStandingInstructionExecutions(requestCtx context.Context, dbExecutor bun.IDB, options types.StandingInstructionExecutionListOptions) ([]model.StandingInstructionExecution, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.transferRepository.ListStandingInstructionExecutions(requestCtx, dbExecutor, options)
+}
+
+/*
+CancelStandingInstruction stops an active or suspended standing instruction for good
+
+It must be called within a database transaction because it locks the standing instruction row for update.
+*/
+func (s *transferService) CancelStandingInstruction(requestCtx context.Context, dbExecutor bun.IDB, standingInstructionID uuid.UUID) (*model.StandingInstruction, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	standingInstruction, err := s.transferRepository.GetStandingInstruction(requestCtx, dbExecutor, types.StandingInstructionQueryOptions{
+		ID:        &standingInstructionID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if standingInstruction.Status != model.StandingInstructionActive && standingInstruction.Status != model.StandingInstructionSuspended {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Only an active or suspended standing instruction can be cancelled",
+		}
+	}
+
+	cancelledStatus := model.StandingInstructionCancelled
+	return s.transferRepository.UpdateStandingInstruction(requestCtx, dbExecutor, standingInstruction.ID, types.StandingInstructionUpdateOptions{
+		NewStatus: &cancelledStatus,
+	})
+}
+
+/*
+ResumeStandingInstruction reactivates a standing instruction that was suspended after repeated failures
+
+The occurrences that fell due before today while the instruction was suspended are skipped, they are not paid late.
+It must be called within a database transaction because it locks the standing instruction row for update.
+*/
+func (s *transferService) ResumeStandingInstruction(requestCtx context.Context, dbExecutor bun.IDB, standingInstructionID uuid.UUID) (*model.StandingInstruction, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	standingInstruction, err := s.transferRepository.GetStandingInstruction(requestCtx, dbExecutor, types.StandingInstructionQueryOptions{
+		ID:        &standingInstructionID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if standingInstruction.Status != model.StandingInstructionSuspended {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Only a suspended standing instruction can be resumed",
+		}
+	}
+
+	today := startOfDay(time.Now().UTC())
+	failureReason := "The standing instruction was suspended"
+	occurrenceCount := standingInstruction.OccurrenceCount
+	nextExecutionDate := standingInstruction.NextExecutionDate
+	for nextExecutionDate != nil && nextExecutionDate.Before(today) {
+		occurrenceCount++
+		_, err := s.transferRepository.CreateStandingInstructionExecution(requestCtx, dbExecutor, &model.StandingInstructionExecution{
+			StandingInstructionID: standingInstruction.ID,
+			OccurrenceNumber:      occurrenceCount,
+			DueDate:               *nextExecutionDate,
+			Status:                model.StandingInstructionExecutionSkipped,
+			FailureReason:         &failureReason,
+		})
+		if err != nil {
+			return nil, err
+		}
+		nextExecutionDate = standingInstruction.NextOccurrenceDate(occurrenceCount)
+	}
+
+	consecutiveFailureCount := 0
+	updateOptions := advanceStandingInstruction(occurrenceCount, nextExecutionDate)
+	updateOptions.NewConsecutiveFailureCount = &consecutiveFailureCount
+	if updateOptions.NewStatus == nil {
+		activeStatus := model.StandingInstructionActive
+		updateOptions.NewStatus = &activeStatus
+	}
+
+	return s.transferRepository.UpdateStandingInstruction(requestCtx, dbExecutor, standingInstruction.ID, updateOptions)
+}
+
+/*
+ExecuteStandingInstruction pays the due occurrence of the standing instruction through CreateInternalTransfer,
+so it is subject to the same checks and fees as any other transfer
+
+It returns nil without an error when no occurrence is due on the execution date, or the instruction was already attempted that day,
+so that a retried task never pays an occurrence twice.
+It also returns nil once it has cancelled the instruction of a user who no longer holds the sender account.
+When the transfer is rejected, the error is returned and the whole database transaction must be rolled back,
+the caller can then record the failed attempt using RecordStandingInstructionFailure.
+It must be called within a database transaction because it locks the standing instruction row for update.
+*/
+func (s *transferService) ExecuteStandingInstruction(requestCtx context.Context, dbExecutor bun.IDB, standingInstructionID uuid.UUID, executionDate time.Time) (*model.StandingInstructionExecution, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	standingInstruction, err := s.getDueStandingInstruction(requestCtx, dbExecutor, standingInstructionID, executionDate)
+	if err != nil || standingInstruction == nil {
+		return nil, err
+	}
+
+	holdsAccount, err := s.holdsAccount(requestCtx, dbExecutor, standingInstruction.FromAccountID, standingInstruction.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !holdsAccount {
+		cancelledStatus := model.StandingInstructionCancelled
+		_, err = s.transferRepository.UpdateStandingInstruction(requestCtx, dbExecutor, standingInstruction.ID, types.StandingInstructionUpdateOptions{
+			NewStatus: &cancelledStatus,
+		})
+		return nil, err
+	}
+
+	transaction, err := s.CreateInternalTransfer(
+		requestCtx,
+		dbExecutor,
+		standingInstruction.UserID,
+		standingInstruction.FromAccountID,
+		standingInstruction.ToAccountID,
+		standingInstruction.Amount,
+		accountTypes.Remittance{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrenceCount := standingInstruction.OccurrenceCount + 1
+	execution, err := s.transferRepository.CreateStandingInstructionExecution(requestCtx, dbExecutor, &model.StandingInstructionExecution{
+		StandingInstructionID: standingInstruction.ID,
+		OccurrenceNumber:      occurrenceCount,
+		DueDate:               *standingInstruction.NextExecutionDate,
+		Status:                model.StandingInstructionExecutionSucceeded,
+		TransactionID:         &transaction.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	consecutiveFailureCount := 0
+	updateOptions := advanceStandingInstruction(occurrenceCount, standingInstruction.NextOccurrenceDate(occurrenceCount))
+	updateOptions.NewConsecutiveFailureCount = &consecutiveFailureCount
+	updateOptions.NewLastAttemptedAt = &now
+
+	_, err = s.transferRepository.UpdateStandingInstruction(requestCtx, dbExecutor, standingInstruction.ID, updateOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return execution, nil
+}
+
+/*
+RecordStandingInstructionFailure records a failed attempt to pay the due occurrence of the standing instruction
+
+Depending on the configured failure policy, the occurrence is either attempted again the next day until the end of the retry period,
+or skipped right away. The instruction is suspended once the configured number of attempts in a row have failed.
+It returns nil without an error when no occurrence is due on the execution date, or the instruction was already attempted that day.
+It must be called within a database transaction because it locks the standing instruction row for update.
+*/
+func (s *transferService) RecordStandingInstructionFailure(requestCtx context.Context, dbExecutor bun.IDB, standingInstructionID uuid.UUID, executionDate time.Time, failureReason string) (*model.StandingInstruction, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	standingInstruction, err := s.getDueStandingInstruction(requestCtx, dbExecutor, standingInstructionID, executionDate)
+	if err != nil || standingInstruction == nil {
+		return nil, err
+	}
+
+	lastRetryDate := standingInstruction.NextExecutionDate.AddDate(0, 0, s.standingInstructionConfig.RetryPeriodInDays)
+	skipOccurrence := s.standingInstructionConfig.FailurePolicy == config.StandingInstructionSkip || !executionDate.Before(lastRetryDate)
+
+	executionStatus := model.StandingInstructionExecutionFailed
+	if skipOccurrence {
+		executionStatus = model.StandingInstructionExecutionSkipped
+	}
+
+	_, err = s.transferRepository.CreateStandingInstructionExecution(requestCtx, dbExecutor, &model.StandingInstructionExecution{
+		StandingInstructionID: standingInstruction.ID,
+		OccurrenceNumber:      standingInstruction.OccurrenceCount + 1,
+		DueDate:               *standingInstruction.NextExecutionDate,
+		Status:                executionStatus,
+		FailureReason:         &failureReason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var updateOptions types.StandingInstructionUpdateOptions
+	if skipOccurrence {
+		occurrenceCount := standingInstruction.OccurrenceCount + 1
+		updateOptions = advanceStandingInstruction(occurrenceCount, standingInstruction.NextOccurrenceDate(occurrenceCount))
+	}
+
+	now := time.Now().UTC()
+	consecutiveFailureCount := standingInstruction.ConsecutiveFailureCount + 1
+	updateOptions.NewConsecutiveFailureCount = &consecutiveFailureCount
+	updateOptions.NewLastAttemptedAt = &now
+
+	if updateOptions.NewStatus == nil && consecutiveFailureCount >= s.standingInstructionConfig.MaxConsecutiveFailures {
+		suspendedStatus := model.StandingInstructionSuspended
+		updateOptions.NewStatus = &suspendedStatus
+		logger.Warn(requestCtx, "Standing instruction with standingInstructionID: %s suspended after %d failed attempt(s) in a row", standingInstruction.ID, consecutiveFailureCount)
+	}
+
+	return s.transferRepository.UpdateStandingInstruction(requestCtx, dbExecutor, standingInstruction.ID, updateOptions)
+}
+
+// getDueStandingInstruction locks the standing instruction and returns it only when an occurrence is due on the execution date and it was not attempted that day yet
+func (s *transferService) getDueStandingInstruction(requestCtx context.Context, dbExecutor bun.IDB, standingInstructionID uuid.UUID, executionDate time.Time) (*model.StandingInstruction, error) {
+	standingInstruction, err := s.transferRepository.GetStandingInstruction(requestCtx, dbExecutor, types.StandingInstructionQueryOptions{
+		ID:        &standingInstructionID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if standingInstruction.Status != model.StandingInstructionActive || standingInstruction.NextExecutionDate == nil || standingInstruction.NextExecutionDate.After(executionDate) {
+		return nil, nil
+	}
+	if standingInstruction.LastAttemptedAt != nil && startOfDay(*standingInstruction.LastAttemptedAt).Equal(executionDate) {
+		return nil, nil
+	}
+
+	return standingInstruction, nil
+}
+
+// advanceStandingInstruction returns the update moving a standing instruction on to its next occurrence, completing it when it has none left
+func advanceStandingInstruction(occurrenceCount int, nextExecutionDate *time.Time) types.StandingInstructionUpdateOptions {
+	updateOptions := types.StandingInstructionUpdateOptions{
+		NewOccurrenceCount:   &occurrenceCount,
+		NewNextExecutionDate: nextExecutionDate,
+	}
+
+	if nextExecutionDate == nil {
+		completedStatus := model.StandingInstructionCompleted
+		updateOptions.NewStatus = &completedStatus
+		updateOptions.ClearNextExecutionDate = true
+	}
+
+	return updateOptions
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -2,38 +2,285 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/config"
 	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
 	accountService "github.com/skamranahmed/go-bank/internal/account/service"
 	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	feeModel "github.com/skamranahmed/go-bank/internal/fee/model"
+	feeService "github.com/skamranahmed/go-bank/internal/fee/service"
+	feeTypes "github.com/skamranahmed/go-bank/internal/fee/types"
+	fxService "github.com/skamranahmed/go-bank/internal/fx/service"
+	ledgerService "github.com/skamranahmed/go-bank/internal/ledger/service"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/repository"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/accountnumber"
+	"github.com/skamranahmed/go-bank/pkg/money"
 	"github.com/uptrace/bun"
 )
 
 type transferService struct {
-	db             *bun.DB
-	accountService accountService.AccountService
+	db                        *bun.DB
+	transferRepository        repository.TransferRepository
+	accountService            accountService.AccountService
+	feeService                feeService.FeeService
+	ledgerService             ledgerService.LedgerService
+	fxService                 fxService.FXService
+	paymentRail               PaymentRail
+	transferLimitConfig       config.TransferLimitConfig
+	standingInstructionConfig config.StandingInstructionConfig
+	bulkTransferConfig        config.BulkTransferConfig
 }
 
-func NewTransferService(db *bun.DB, accountService accountService.AccountService) TransferService {
+func NewTransferService(
+	db *bun.DB,
+	transferRepository repository.TransferRepository,
+	accountService accountService.AccountService,
+	feeService feeService.FeeService,
+	ledgerService ledgerService.LedgerService,
+	fxService fxService.FXService,
+	paymentRail PaymentRail,
+	transferLimitConfig config.TransferLimitConfig,
+	standingInstructionConfig config.StandingInstructionConfig,
+	bulkTransferConfig config.BulkTransferConfig,
+) TransferService {
 	return &transferService{
-		db:             db,
-		accountService: accountService,
+		db:                        db,
+		transferRepository:        transferRepository,
+		accountService:            accountService,
+		feeService:                feeService,
+		ledgerService:             ledgerService,
+		fxService:                 fxService,
+		paymentRail:               paymentRail,
+		transferLimitConfig:       transferLimitConfig,
+		standingInstructionConfig: standingInstructionConfig,
+		bulkTransferConfig:        bulkTransferConfig,
 	}
 }
 
+/*
+CreateInternalTransfer moves the amount between two customer accounts, charging the transfer fee to the sender
+
+A transfer that needs the approval of every holder of the sender account under its mandate is rejected,
+it has to be made as a joint transfer, which is executed through createInternalTransfer once approved.
+*/
 func (s *transferService) CreateInternalTransfer(
 	requestCtx context.Context,
 	dbExecutor bun.IDB,
 	senderUserID uuid.UUID,
 	fromAccountID, toAccountID, transferAmount int64,
+	remittance accountTypes.Remittance,
+) (*accountModel.Transaction, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	fromAccount, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &fromAccountID,
+		Columns:   []string{"id", "user_id", "mandate", "joint_approval_threshold"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.EnforceJointMandate(requestCtx, dbExecutor, fromAccount, transferAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createInternalTransfer(requestCtx, dbExecutor, senderUserID, fromAccountID, toAccountID, transferAmount, remittance)
+}
+
+// createInternalTransfer makes the internal transfer without checking the mandate of the sender account, it must be called within a database transaction
+func (s *transferService) createInternalTransfer(
+	requestCtx context.Context,
+	dbExecutor bun.IDB,
+	senderUserID uuid.UUID,
+	fromAccountID, toAccountID, transferAmount int64,
+	remittance accountTypes.Remittance,
 ) (*accountModel.Transaction, error) {
+	transactionRecordForSenderAccount, err := s.MoveFunds(requestCtx, dbExecutor, fromAccountID, toAccountID, transferAmount, remittance)
+	if err != nil {
+		return nil, err
+	}
+
+	/*
+		The transfer limits are checked only after MoveFunds has locked the account rows,
+		so that concurrent transfers from the same account cannot both fit in what remains of a limit.
+		The day's and month's debits therefore already include this transfer, exceeding a limit rolls the whole transfer back.
+	*/
+	err = s.enforceTransferLimits(requestCtx, dbExecutor, senderUserID, fromAccountID, transferAmount, nil, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	/*
+		The transfer fee (if any) is charged to the sender within the same database transaction,
+		so a sender who cannot afford the fee on top of the transfer amount has the whole transfer rolled back
+	*/
+	_, err = s.feeService.ChargeFee(requestCtx, dbExecutor, feeTypes.ChargeFeeParams{
+		AccountID:  fromAccountID,
+		Event:      feeModel.InternalTransfer,
+		BaseAmount: transferAmount,
+		Reference:  transactionRecordForSenderAccount.ID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the spare change is swept last, it is skipped when what is left after the fee does not cover it
+	err = s.accountService.SweepRoundUp(requestCtx, dbExecutor, transactionRecordForSenderAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	return transactionRecordForSenderAccount, nil
+}
+
+/*
+MoveFunds debits the amount from one account and credits it to the other, without charging any fee
+
+It is the building block for every movement of money between two customer accounts,
+products like deposits use it directly to move funds between the accounts of the same customer.
+The remittance information of the sender is stored on both the transactions, next to a description generated for each account holder.
+It must be called within a database transaction because it locks both the account rows for update.
+It returns the transaction record of the debited account.
+*/
+func (s *transferService) MoveFunds(requestCtx context.Context, dbExecutor bun.IDB, fromAccountID, toAccountID, amount int64, remittance accountTypes.Remittance) (*accountModel.Transaction, error) {
 	if dbExecutor == nil {
 		dbExecutor = s.db
 	}
 
+	remittance.Narration = sanitizeNarration(remittance.Narration)
+
+	senderAccount, receiverAccount, err := s.lockAccounts(requestCtx, dbExecutor, fromAccountID, toAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// an amount is only ever moved as is between accounts of the same currency, any other transfer is converted at the rate of an FX quote
+	if senderAccount.Currency != receiverAccount.Currency {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("The accounts are held in %s and %s, a transfer between them needs an FX quote", senderAccount.Currency, receiverAccount.Currency),
+		}
+	}
+
+	// the available balance includes the sanctioned overdraft limit and excludes any held amount of the sender's account
+	if senderAccount.AvailableBalance() < amount {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "You do not have sufficient balance in your account to perform the transfer",
+		}
+	}
+
+	return s.postTransfer(requestCtx, dbExecutor, senderAccount, receiverAccount, amount, amount, remittance)
+}
+
+/*
+postTransfer debits the debit amount from the sender's account and credits the credit amount to the receiver's account,
+the amounts only differ when the transfer is converted between the currencies of the accounts
+
+Both the account rows must already be locked for update. It returns the transaction record of the debited account.
+*/
+func (s *transferService) postTransfer(
+	requestCtx context.Context,
+	dbExecutor bun.IDB,
+	senderAccount, receiverAccount *accountModel.Account,
+	debitAmount, creditAmount int64,
+	remittance accountTypes.Remittance,
+) (*accountModel.Transaction, error) {
+	// update the balance of the sender's account (debit)
+	updatedBalanceAfterDebit := senderAccount.Balance - debitAmount
+	senderAccount, err := s.accountService.UpdateAccount(requestCtx, dbExecutor, senderAccount.ID, accountTypes.AccountUpdateOptions{
+		NewBalance: &updatedBalanceAfterDebit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// create transaction record for sender account
+	senderDescription := "Transfer to " + accountnumber.Mask(receiverAccount.ID)
+	transactionRecordForSenderAccount := &accountModel.Transaction{
+		AccountID:       senderAccount.ID,
+		Amount:          debitAmount,
+		BalanceAfter:    senderAccount.Balance,
+		Type:            accountModel.Debit, // debit transaction
+		Description:     &senderDescription,
+		Narration:       remittance.Narration,
+		ClientReference: remittance.ClientReference,
+		Category:        remittance.Category,
+	}
+	transactionRecordForSenderAccount, err = s.accountService.CreateTransactionRecord(requestCtx, dbExecutor, transactionRecordForSenderAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	// update the balance of the receiver's account (credit)
+	updatedBalanceAfterCredit := receiverAccount.Balance + creditAmount
+	receiverAccount, err = s.accountService.UpdateAccount(requestCtx, dbExecutor, receiverAccount.ID, accountTypes.AccountUpdateOptions{
+		NewBalance: &updatedBalanceAfterCredit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// create transaction record for receiver account, linked to the debit so that the transfer can be reversed as a whole
+	receiverDescription := "Transfer from " + accountnumber.Mask(senderAccount.ID)
+	transactionRecordForReceiverAccount := &accountModel.Transaction{
+		AccountID:                receiverAccount.ID,
+		Amount:                   creditAmount,
+		BalanceAfter:             receiverAccount.Balance,
+		Type:                     accountModel.Credit,
+		CounterpartTransactionID: &transactionRecordForSenderAccount.ID,
+		Description:              &receiverDescription,
+		Narration:                remittance.Narration,
+		ClientReference:          remittance.ClientReference,
+		Category:                 remittance.Category,
+	}
+	_, err = s.accountService.CreateTransactionRecord(requestCtx, dbExecutor, transactionRecordForReceiverAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	return transactionRecordForSenderAccount, nil
+}
+
+/*
+sanitizeNarration strips control and invisible formatting characters from the narration and collapses its whitespace
+
+The narration is shown as is in the statements of both the account holders, so nothing that could alter how it renders is kept.
+It returns nil when nothing is left of the narration.
+*/
+func sanitizeNarration(narration *string) *string {
+	if narration == nil {
+		return nil
+	}
+
+	cleaned := strings.Map(func(r rune) rune {
+		if (unicode.IsControl(r) && !unicode.IsSpace(r)) || unicode.Is(unicode.Cf, r) {
+			return -1
+		}
+		return r
+	}, *narration)
+
+	cleaned = strings.Join(strings.Fields(cleaned), " ")
+	if cleaned == "" {
+		return nil
+	}
+	return &cleaned
+}
+
+// lockAccounts locks the rows of both accounts of a transfer, it returns the from account first and the to account second
+func (s *transferService) lockAccounts(requestCtx context.Context, dbExecutor bun.IDB, fromAccountID, toAccountID int64) (*accountModel.Account, *accountModel.Account, error) {
 	/*
 		Prevent deadlocks by establishing a consistent ordering for row locking
 
@@ -64,7 +311,7 @@ func (s *transferService) CreateInternalTransfer(
 		ForUpdate: true, // lock the row for update
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var secondAccount *accountModel.Account
@@ -73,67 +320,453 @@ func (s *transferService) CreateInternalTransfer(
 		ForUpdate: true, // lock the row for update
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	// check which of the account is the sender account and check if it has enough balance
-	var senderAccount, receiverAccount *accountModel.Account
+	// match the locked accounts back to their roles in the transfer
 	if firstAccount.ID == fromAccountID {
-		senderAccount = firstAccount
-		receiverAccount = secondAccount
-	} else {
-		senderAccount = secondAccount
-		receiverAccount = firstAccount
+		return firstAccount, secondAccount, nil
+	}
+	return secondAccount, firstAccount, nil
+}
+
+// holdsAccount reports whether the user still holds the account, an instruction stored by a joint holder must not be executed once they left the account
+func (s *transferService) holdsAccount(requestCtx context.Context, dbExecutor bun.IDB, accountID int64, userID uuid.UUID) (bool, error) {
+	account, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &accountID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return s.accountService.IsAccountHolder(requestCtx, dbExecutor, account, userID)
+}
+
+// CreateScheduledTransfer stores the instruction of a transfer to be executed at params.ScheduledAt, the caller is responsible for enqueuing its execution
+func (s *transferService) CreateScheduledTransfer(requestCtx context.Context, dbExecutor bun.IDB, params types.CreateScheduledTransferParams) (*model.ScheduledTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
 	}
 
-	if senderAccount.Balance < transferAmount {
+	if !params.ScheduledAt.After(time.Now().UTC()) {
 		return nil, &server.ApiError{
 			HttpStatusCode: http.StatusBadRequest,
-			Message:        "You do not have sufficient balance in your account to perform the transfer",
+			Message:        "A transfer can only be scheduled for a time in the future",
 		}
 	}
 
-	// update the balance of the sender's account (debit)
-	updatedBalanceAfterDebit := senderAccount.Balance - transferAmount
-	senderAccount, err = s.accountService.UpdateAccount(requestCtx, dbExecutor, senderAccount.ID, accountTypes.AccountUpdateOptions{
-		NewBalance: &updatedBalanceAfterDebit,
+	return s.transferRepository.CreateScheduledTransfer(requestCtx, dbExecutor, &model.ScheduledTransfer{
+		UserID:        params.UserID,
+		FromAccountID: params.FromAccountID,
+		ToAccountID:   params.ToAccountID,
+		Amount:        params.Amount,
+		ScheduledAt:   params.ScheduledAt.UTC(),
+		Status:        model.ScheduledTransferPending,
+	})
+}
+
+func (s *transferService) GetScheduledTransfer(requestCtx context.Context, dbExecutor bun.IDB, options types.ScheduledTransferQueryOptions) (*model.ScheduledTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.transferRepository.GetScheduledTransfer(requestCtx, dbExecutor, options)
+}
+
+func (s *transferService) ListScheduledTransfers(requestCtx context.Context, dbExecutor bun.IDB, options types.ScheduledTransferListOptions) ([]model.ScheduledTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.transferRepository.ListScheduledTransfers(requestCtx, dbExecutor, options)
+}
+
+/*
+CancelScheduledTransfer cancels a scheduled transfer that has not been executed yet
+
+Its already enqueued execution is left in the queue, it does nothing once it finds the transfer cancelled.
+It must be called within a database transaction because it locks the scheduled transfer row for update.
+*/
+func (s *transferService) CancelScheduledTransfer(requestCtx context.Context, dbExecutor bun.IDB, scheduledTransferID uuid.UUID) (*model.ScheduledTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	scheduledTransfer, err := s.transferRepository.GetScheduledTransfer(requestCtx, dbExecutor, types.ScheduledTransferQueryOptions{
+		ID:        &scheduledTransferID,
+		ForUpdate: true, // lock the row for update
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// create transaction record for sender account
-	transactionRecordForSenderAccount := &accountModel.Transaction{
-		AccountID:    senderAccount.ID,
-		Amount:       transferAmount,
-		BalanceAfter: senderAccount.Balance,
-		Type:         accountModel.Debit, // debit transaction
+	if scheduledTransfer.Status != model.ScheduledTransferPending {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Only a pending scheduled transfer can be cancelled",
+		}
 	}
-	transactionRecordForSenderAccount, err = s.accountService.CreateTransactionRecord(requestCtx, dbExecutor, transactionRecordForSenderAccount)
+
+	cancelledStatus := model.ScheduledTransferCancelled
+	return s.transferRepository.UpdateScheduledTransfer(requestCtx, dbExecutor, scheduledTransfer.ID, types.ScheduledTransferUpdateOptions{
+		NewStatus: &cancelledStatus,
+	})
+}
+
+/*
+ExecuteScheduledTransfer performs the scheduled transfer through CreateInternalTransfer, so it is subject to the same checks and fees as any other transfer
+
+It returns nil without an error when the transfer is no longer pending, e.g. it was cancelled or already executed.
+It also returns nil once it has cancelled the transfer of a user who no longer holds the sender account.
+When the transfer is rejected, the error is returned and the whole database transaction must be rolled back,
+the caller can then record the failure using FailScheduledTransfer.
+It must be called within a database transaction because it locks the scheduled transfer row for update.
+*/
+func (s *transferService) ExecuteScheduledTransfer(requestCtx context.Context, dbExecutor bun.IDB, scheduledTransferID uuid.UUID, executionTime time.Time) (*model.ScheduledTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	scheduledTransfer, err := s.transferRepository.GetScheduledTransfer(requestCtx, dbExecutor, types.ScheduledTransferQueryOptions{
+		ID:        &scheduledTransferID,
+		ForUpdate: true, // lock the row for update
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// update the balance of the receiver's account (credit)
-	updatedBalanceAfterCredit := receiverAccount.Balance + transferAmount
-	receiverAccount, err = s.accountService.UpdateAccount(requestCtx, dbExecutor, receiverAccount.ID, accountTypes.AccountUpdateOptions{
-		NewBalance: &updatedBalanceAfterCredit,
+	if scheduledTransfer.Status != model.ScheduledTransferPending {
+		return nil, nil
+	}
+
+	holdsAccount, err := s.holdsAccount(requestCtx, dbExecutor, scheduledTransfer.FromAccountID, scheduledTransfer.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !holdsAccount {
+		cancelledStatus := model.ScheduledTransferCancelled
+		_, err = s.transferRepository.UpdateScheduledTransfer(requestCtx, dbExecutor, scheduledTransfer.ID, types.ScheduledTransferUpdateOptions{
+			NewStatus: &cancelledStatus,
+		})
+		return nil, err
+	}
+
+	transaction, err := s.CreateInternalTransfer(
+		requestCtx,
+		dbExecutor,
+		scheduledTransfer.UserID,
+		scheduledTransfer.FromAccountID,
+		scheduledTransfer.ToAccountID,
+		scheduledTransfer.Amount,
+		accountTypes.Remittance{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	completedStatus := model.ScheduledTransferCompleted
+	return s.transferRepository.UpdateScheduledTransfer(requestCtx, dbExecutor, scheduledTransfer.ID, types.ScheduledTransferUpdateOptions{
+		NewStatus:        &completedStatus,
+		NewTransactionID: &transaction.ID,
+		NewExecutedAt:    &executionTime,
+	})
+}
+
+/*
+FailScheduledTransfer records that the scheduled transfer was rejected when it was due, along with the reason
+
+It returns nil without an error when the transfer is no longer pending.
+It must be called within a database transaction because it locks the scheduled transfer row for update.
+*/
+func (s *transferService) FailScheduledTransfer(requestCtx context.Context, dbExecutor bun.IDB, scheduledTransferID uuid.UUID, failureReason string, executionTime time.Time) (*model.ScheduledTransfer, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	scheduledTransfer, err := s.transferRepository.GetScheduledTransfer(requestCtx, dbExecutor, types.ScheduledTransferQueryOptions{
+		ID:        &scheduledTransferID,
+		ForUpdate: true, // lock the row for update
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// create transaction record for receiver account
-	transactionRecordForReceiverAccount := &accountModel.Transaction{
-		AccountID:    receiverAccount.ID,
-		Amount:       transferAmount,
-		BalanceAfter: receiverAccount.Balance,
-		Type:         accountModel.Credit,
+	if scheduledTransfer.Status != model.ScheduledTransferPending {
+		return nil, nil
 	}
-	_, err = s.accountService.CreateTransactionRecord(requestCtx, dbExecutor, transactionRecordForReceiverAccount)
+
+	failedStatus := model.ScheduledTransferFailed
+	return s.transferRepository.UpdateScheduledTransfer(requestCtx, dbExecutor, scheduledTransfer.ID, types.ScheduledTransferUpdateOptions{
+		NewStatus:        &failedStatus,
+		NewFailureReason: &failureReason,
+		NewExecutedAt:    &executionTime,
+	})
+}
+
+// GetTransferLimits returns the limits in effect on the transfers out of the accounts of the user of the given type
+func (s *transferService) GetTransferLimits(requestCtx context.Context, dbExecutor bun.IDB, userID uuid.UUID, accountType accountModel.AccountType) (*types.TransferLimits, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	override, err := s.transferRepository.GetTransferLimitOverride(requestCtx, dbExecutor, types.TransferLimitOverrideQueryOptions{
+		UserID:      &userID,
+		AccountType: &accountType,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return transactionRecordForSenderAccount, nil
+	return s.effectiveTransferLimits(accountType, override)
+}
+
+/*
+SetTransferLimits overrides the transfer limits of the user for the given account type and returns the limits now in effect
+
+Unless params.AllowRaise is set, a limit can only be lowered, raising it is reserved to admins.
+It must be called within a database transaction because it locks the existing overrides of the user for update.
+*/
+func (s *transferService) SetTransferLimits(requestCtx context.Context, dbExecutor bun.IDB, params types.SetTransferLimitsParams) (*types.TransferLimits, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	override, err := s.transferRepository.GetTransferLimitOverride(requestCtx, dbExecutor, types.TransferLimitOverrideQueryOptions{
+		UserID:      &params.UserID,
+		AccountType: &params.AccountType,
+		ForUpdate:   true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	currentLimits, err := s.effectiveTransferLimits(params.AccountType, override)
+	if err != nil {
+		return nil, err
+	}
+
+	if !params.AllowRaise {
+		raisedLimit := ""
+		switch {
+		case params.PerTransactionAmount != nil && *params.PerTransactionAmount > currentLimits.PerTransactionAmount:
+			raisedLimit = "per-transaction"
+		case params.DailyCount != nil && *params.DailyCount > currentLimits.DailyCount:
+			raisedLimit = "daily count"
+		case params.DailyAmount != nil && *params.DailyAmount > currentLimits.DailyAmount:
+			raisedLimit = "daily amount"
+		case params.MonthlyAmount != nil && *params.MonthlyAmount > currentLimits.MonthlyAmount:
+			raisedLimit = "monthly amount"
+		}
+
+		if raisedLimit != "" {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusForbidden,
+				Message:        fmt.Sprintf("You can only lower your %s limit, please contact the bank to raise it", raisedLimit),
+			}
+		}
+	}
+
+	if override == nil {
+		override = &model.TransferLimitOverride{
+			UserID:      params.UserID,
+			AccountType: params.AccountType,
+		}
+	}
+
+	// limits that are not part of the params keep their current override, if any
+	if params.PerTransactionAmount != nil {
+		override.PerTransactionAmount = params.PerTransactionAmount
+	}
+	if params.DailyCount != nil {
+		override.DailyCount = params.DailyCount
+	}
+	if params.DailyAmount != nil {
+		override.DailyAmount = params.DailyAmount
+	}
+	if params.MonthlyAmount != nil {
+		override.MonthlyAmount = params.MonthlyAmount
+	}
+
+	override, err = s.transferRepository.UpsertTransferLimitOverride(requestCtx, dbExecutor, override)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.effectiveTransferLimits(params.AccountType, override)
+}
+
+// effectiveTransferLimits applies the overrides of the user (if any) on top of the default limits of the account type
+func (s *transferService) effectiveTransferLimits(accountType accountModel.AccountType, override *model.TransferLimitOverride) (*types.TransferLimits, error) {
+	var defaultLimits config.TransferLimits
+	switch accountType {
+	case accountModel.SavingsAccount:
+		defaultLimits = s.transferLimitConfig.SavingsAccount
+	case accountModel.CurrentAccount:
+		defaultLimits = s.transferLimitConfig.CurrentAccount
+	default:
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Transfer limits only apply to savings and current accounts",
+		}
+	}
+
+	limits := &types.TransferLimits{
+		PerTransactionAmount: defaultLimits.PerTransactionAmount,
+		DailyCount:           defaultLimits.DailyCount,
+		DailyAmount:          defaultLimits.DailyAmount,
+		MonthlyAmount:        defaultLimits.MonthlyAmount,
+	}
+	if override == nil {
+		return limits, nil
+	}
+
+	if override.PerTransactionAmount != nil {
+		limits.PerTransactionAmount = *override.PerTransactionAmount
+	}
+	if override.DailyCount != nil {
+		limits.DailyCount = *override.DailyCount
+	}
+	if override.DailyAmount != nil {
+		limits.DailyAmount = *override.DailyAmount
+	}
+	if override.MonthlyAmount != nil {
+		limits.MonthlyAmount = *override.MonthlyAmount
+	}
+
+	return limits, nil
+}
+
+// limitedTransactionTypes are the debits that count towards the transfer limits, internal and external transfers share the same limits
+var limitedTransactionTypes = []accountModel.TransactionType{accountModel.Debit, accountModel.ExternalTransfer}
+
+// unlimitedCounterpartAccountTypes are the accounts that funds are moved to without counting towards the transfer limits,
+// booking a deposit or paying its installment is a DEBIT of the linked account but not a transfer
+var unlimitedCounterpartAccountTypes = []accountModel.AccountType{accountModel.FixedDeposit, accountModel.RecurringDeposit}
+
+/*
+enforceTransferLimits rejects a transfer that exceeds any of the transfer limits of the sender's account
+
+It is called after the transfer has been debited from the locked account, so the day's and month's debits include it.
+The days and months are calendar days and months in UTC.
+
+The limits are amounts of the bank's currency, the amounts of an account held in another currency are converted to it
+with the rate given, or with the latest published rate when no rate is given.
+*/
+func (s *transferService) enforceTransferLimits(
+	requestCtx context.Context,
+	dbExecutor bun.IDB,
+	senderUserID uuid.UUID,
+	fromAccountID, transferAmount int64,
+	homeCurrencyRate *money.Rate,
+	transferTime time.Time,
+) error {
+	fromAccount, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &fromAccountID,
+		Columns:   []string{"type", "currency"},
+	})
+	if err != nil {
+		return err
+	}
+
+	limits, err := s.GetTransferLimits(requestCtx, dbExecutor, senderUserID, fromAccount.Type)
+	if err != nil {
+		return err
+	}
+
+	homeCurrency := money.Currency(config.GetBankConfig().Currency)
+	if fromAccount.Currency != homeCurrency && homeCurrencyRate == nil {
+		rate, err := s.fxService.GetRate(requestCtx, dbExecutor, fromAccount.Currency, homeCurrency)
+		if err != nil {
+			return err
+		}
+		homeCurrencyRate = &rate
+	}
+
+	// toHomeCurrency converts an amount of the account's currency to the bank's currency the limits are configured in
+	toHomeCurrency := func(amount int64) (int64, error) {
+		if fromAccount.Currency == homeCurrency {
+			return amount, nil
+		}
+
+		converted, err := homeCurrencyRate.Convert(fromAccount.Money(amount))
+		if err != nil {
+			return 0, err
+		}
+		return converted.Amount, nil
+	}
+
+	transferAmount, err = toHomeCurrency(transferAmount)
+	if err != nil {
+		return err
+	}
+
+	if transferAmount > limits.PerTransactionAmount {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("The amount exceeds the per-transaction limit of %d", limits.PerTransactionAmount),
+		}
+	}
+
+	startOfDay := time.Date(transferTime.Year(), transferTime.Month(), transferTime.Day(), 0, 0, 0, 0, time.UTC)
+	todaysDebits := accountTypes.TransactionQueryOptions{
+		AccountID:                      &fromAccountID,
+		Types:                          limitedTransactionTypes,
+		CreatedAfter:                   &startOfDay,
+		ExcludeCounterpartAccountTypes: unlimitedCounterpartAccountTypes,
+	}
+
+	todaysDebitCount, err := s.accountService.CountTransactions(requestCtx, dbExecutor, todaysDebits)
+	if err != nil {
+		return err
+	}
+	if todaysDebitCount > limits.DailyCount {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("You have reached the daily limit of %d transfers from this account", limits.DailyCount),
+		}
+	}
+
+	todaysDebitAmount, err := s.accountService.SumTransactions(requestCtx, dbExecutor, todaysDebits)
+	if err != nil {
+		return err
+	}
+	todaysDebitAmount, err = toHomeCurrency(todaysDebitAmount)
+	if err != nil {
+		return err
+	}
+	if todaysDebitAmount > limits.DailyAmount {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message: fmt.Sprintf(
+				"The amount exceeds the daily transfer limit of %d, the remaining limit for today is %d",
+				limits.DailyAmount, max(limits.DailyAmount-(todaysDebitAmount-transferAmount), 0),
+			),
+		}
+	}
+
+	startOfMonth := time.Date(transferTime.Year(), transferTime.Month(), 1, 0, 0, 0, 0, time.UTC)
+	monthsDebitAmount, err := s.accountService.SumTransactions(requestCtx, dbExecutor, accountTypes.TransactionQueryOptions{
+		AccountID:                      &fromAccountID,
+		Types:                          limitedTransactionTypes,
+		CreatedAfter:                   &startOfMonth,
+		ExcludeCounterpartAccountTypes: unlimitedCounterpartAccountTypes,
+	})
+	if err != nil {
+		return err
+	}
+	monthsDebitAmount, err = toHomeCurrency(monthsDebitAmount)
+	if err != nil {
+		return err
+	}
+	if monthsDebitAmount > limits.MonthlyAmount {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message: fmt.Sprintf(
+				"The amount exceeds the monthly transfer limit of %d, the remaining limit for this month is %d",
+				limits.MonthlyAmount, max(limits.MonthlyAmount-(monthsDebitAmount-transferAmount), 0),
+			),
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/accountnumber"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+/*
+ReverseTransfer takes the amount of a mistaken transfer back from the receiver account and pays it back to the sender account
+
+The amount is only taken back up to the own funds of the receiver account, its overdraft limit is never drawn on to repay it,
+the rest of it is held on the receiver account and taken back later by CollectTransferReversalHold.
+A transfer can only be reversed once, the original debit and credit are marked REVERSED (or PARTIALLY_REVERSED while a hold is left).
+
+It must be called within a database transaction because it locks the original transactions and both the account rows for update.
+*/
+func (s *transferService) ReverseTransfer(requestCtx context.Context, dbExecutor bun.IDB, params types.ReverseTransferParams) (*model.TransferReversal, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	originalDebit, err := s.accountService.GetTransaction(requestCtx, dbExecutor, accountTypes.TransactionGetOptions{
+		ID:        &params.TransactionID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if originalDebit.Type != accountModel.Debit {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Only the debit transaction of a transfer can be reversed",
+		}
+	}
+
+	if originalDebit.Status != accountModel.TransactionCompleted {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusConflict,
+			Message:        "Transfer has already been reversed",
+		}
+	}
+
+	originalCredit, err := s.accountService.GetTransaction(requestCtx, dbExecutor, accountTypes.TransactionGetOptions{
+		CounterpartTransactionID: &originalDebit.ID,
+		ForUpdate:                true, // lock the row for update
+	})
+	if err != nil {
+		// transfers made before debits and credits were linked cannot be matched with their credit
+		var apiErr *server.ApiError
+		if errors.As(err, &apiErr) && apiErr.HttpStatusCode == http.StatusNotFound {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusBadRequest,
+				Message:        "The credit transaction of this transfer is unknown, it cannot be reversed",
+			}
+		}
+		return nil, err
+	}
+
+	receiverAccount, senderAccount, err := s.lockAccounts(requestCtx, dbExecutor, originalCredit.AccountID, originalDebit.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// money moved into or out of a deposit account belongs to the deposit product, it is unwound by the product itself
+	if !receiverAccount.Type.AllowsTransfers() || !senderAccount.Type.AllowsTransfers() {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Only transfers between savings and current accounts can be reversed",
+		}
+	}
+
+	// the two sides of a cross-currency transfer were converted at a rate that has moved on since, see CreateCrossCurrencyTransfer
+	if senderAccount.Currency != receiverAccount.Currency {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Transfers between accounts held in different currencies cannot be reversed",
+		}
+	}
+
+	// the receiver is never taken below its own funds into its overdraft, whatever it cannot cover now is held on its account
+	reversedAmount := min(originalDebit.Amount, max(receiverAccount.OwnFunds(), 0))
+	heldAmount := originalDebit.Amount - reversedAmount
+
+	err = s.postTransferReversal(requestCtx, dbExecutor, receiverAccount, senderAccount, reversedAmount, receiverAccount.HeldAmount+heldAmount, originalDebit.ID, originalCredit.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	transferReversal := &model.TransferReversal{
+		OriginalTransactionID: originalDebit.ID,
+		FromAccountID:         receiverAccount.ID,
+		ToAccountID:           senderAccount.ID,
+		Amount:                originalDebit.Amount,
+		ReversedAmount:        reversedAmount,
+		HeldAmount:            heldAmount,
+		Status:                model.TransferReversalOnHold,
+		Reason:                params.Reason,
+		InitiatedBy:           params.InitiatedBy,
+	}
+
+	originalTransactionStatus := accountModel.TransactionPartiallyReversed
+	if heldAmount == 0 {
+		now := time.Now().UTC()
+		transferReversal.Status = model.TransferReversalCompleted
+		transferReversal.CompletedAt = &now
+		originalTransactionStatus = accountModel.TransactionReversed
+	}
+
+	err = s.updateOriginalTransactionsStatus(requestCtx, dbExecutor, originalDebit.ID, originalCredit.ID, originalTransactionStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	transferReversal, err = s.transferRepository.CreateTransferReversal(requestCtx, dbExecutor, transferReversal)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(requestCtx, "Transfer with transactionID: %s reversed by adminID: %s, reversed amount: %d, held amount: %d, reason: %s", originalDebit.ID, params.InitiatedBy, reversedAmount, heldAmount, params.Reason)
+	return transferReversal, nil
+}
+
+func (s *transferService) GetTransferReversal(requestCtx context.Context, dbExecutor bun.IDB, options types.TransferReversalQueryOptions) (*model.TransferReversal, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.transferRepository.GetTransferReversal(requestCtx, dbExecutor, options)
+}
+
+func (s *transferService) ListTransferReversals(requestCtx context.Context, dbExecutor bun.IDB, options types.TransferReversalListOptions) ([]model.TransferReversal, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.transferRepository.ListTransferReversals(requestCtx, dbExecutor, options)
+}
+
+/*
+CollectTransferReversalHold takes back as much of the held amount of a reversal as the own funds of the receiver account can cover now
+
+It returns nil when the reversal is not on hold anymore or the receiver account still cannot cover any of it.
+Once the whole held amount is taken back, the reversal is completed and the original transactions are marked REVERSED.
+
+It must be called within a database transaction because it locks the reversal and both the account rows for update.
+*/
+func (s *transferService) CollectTransferReversalHold(requestCtx context.Context, dbExecutor bun.IDB, transferReversalID uuid.UUID) (*model.TransferReversal, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	transferReversal, err := s.transferRepository.GetTransferReversal(requestCtx, dbExecutor, types.TransferReversalQueryOptions{
+		ID:        &transferReversalID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if transferReversal.Status != model.TransferReversalOnHold {
+		return nil, nil
+	}
+
+	receiverAccount, senderAccount, err := s.lockAccounts(requestCtx, dbExecutor, transferReversal.FromAccountID, transferReversal.ToAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the hold of this reversal is what keeps the own funds down, so it is released before checking what can be covered
+	collectedAmount := min(transferReversal.HeldAmount, max(receiverAccount.OwnFunds()+transferReversal.HeldAmount, 0))
+	if collectedAmount == 0 {
+		return nil, nil
+	}
+
+	originalCredit, err := s.accountService.GetTransaction(requestCtx, dbExecutor, accountTypes.TransactionGetOptions{
+		CounterpartTransactionID: &transferReversal.OriginalTransactionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.postTransferReversal(requestCtx, dbExecutor, receiverAccount, senderAccount, collectedAmount, receiverAccount.HeldAmount-collectedAmount, transferReversal.OriginalTransactionID, originalCredit.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	newReversedAmount := transferReversal.ReversedAmount + collectedAmount
+	newHeldAmount := transferReversal.HeldAmount - collectedAmount
+	updateOptions := types.TransferReversalUpdateOptions{
+		NewReversedAmount: &newReversedAmount,
+		NewHeldAmount:     &newHeldAmount,
+	}
+
+	if newHeldAmount == 0 {
+		now := time.Now().UTC()
+		completedStatus := model.TransferReversalCompleted
+		updateOptions.NewStatus = &completedStatus
+		updateOptions.NewCompletedAt = &now
+
+		err = s.updateOriginalTransactionsStatus(requestCtx, dbExecutor, transferReversal.OriginalTransactionID, originalCredit.ID, accountModel.TransactionReversed)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.transferRepository.UpdateTransferReversal(requestCtx, dbExecutor, transferReversal.ID, updateOptions)
+}
+
+// postTransferReversal moves amount from the receiver back to the sender of a transfer with compensating transactions and sets the new held amount of the receiver
+func (s *transferService) postTransferReversal(requestCtx context.Context, dbExecutor bun.IDB, receiverAccount, senderAccount *accountModel.Account, amount, newHeldAmount int64, originalDebitID, originalCreditID uuid.UUID) error {
+	newReceiverBalance := receiverAccount.Balance - amount
+	receiverAccount, err := s.accountService.UpdateAccount(requestCtx, dbExecutor, receiverAccount.ID, accountTypes.AccountUpdateOptions{
+		NewBalance:    &newReceiverBalance,
+		NewHeldAmount: &newHeldAmount,
+	})
+	if err != nil {
+		return err
+	}
+
+	// nothing could be taken back, only the hold is placed
+	if amount == 0 {
+		return nil
+	}
+
+	receiverDescription := "Reversal of transfer from " + accountnumber.Mask(senderAccount.ID)
+	_, err = s.accountService.CreateTransactionRecord(requestCtx, dbExecutor, &accountModel.Transaction{
+		AccountID:             receiverAccount.ID,
+		Amount:                amount,
+		BalanceAfter:          receiverAccount.Balance,
+		Type:                  accountModel.ReversalDebit,
+		ReversedTransactionID: &originalCreditID,
+		Description:           &receiverDescription,
+	})
+	if err != nil {
+		return err
+	}
+
+	newSenderBalance := senderAccount.Balance + amount
+	senderAccount, err = s.accountService.UpdateAccount(requestCtx, dbExecutor, senderAccount.ID, accountTypes.AccountUpdateOptions{
+		NewBalance: &newSenderBalance,
+	})
+	if err != nil {
+		return err
+	}
+
+	senderDescription := "Reversal of transfer to " + accountnumber.Mask(receiverAccount.ID)
+	_, err = s.accountService.CreateTransactionRecord(requestCtx, dbExecutor, &accountModel.Transaction{
+		AccountID:             senderAccount.ID,
+		Amount:                amount,
+		BalanceAfter:          senderAccount.Balance,
+		Type:                  accountModel.ReversalCredit,
+		ReversedTransactionID: &originalDebitID,
+		Description:           &senderDescription,
+	})
+	return err
+}
+
+func (s *transferService) updateOriginalTransactionsStatus(requestCtx context.Context, dbExecutor bun.IDB, originalDebitID, originalCreditID uuid.UUID, status accountModel.TransactionStatus) error {
+	for _, transactionID := range []uuid.UUID{originalDebitID, originalCreditID} {
+		_, err := s.accountService.UpdateTransaction(requestCtx, dbExecutor, transactionID, accountTypes.TransactionUpdateOptions{
+			NewStatus: &status,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/uptrace/bun"
+)
+
+const CollectTransferReversalHoldTaskName string = "task:collect_transfer_reversal_hold"
+
+type CollectTransferReversalHoldTaskPayload struct {
+	TransferReversalID string
+}
+
+type CollectTransferReversalHoldTask struct {
+	name          string
+	queue         string
+	maxRetryCount int
+	payload       CollectTransferReversalHoldTaskPayload
+}
+
+func NewCollectTransferReversalHoldTask(transferReversalID string) tasksHelper.Task {
+	return &CollectTransferReversalHoldTask{
+		name:          CollectTransferReversalHoldTaskName,
+		queue:         tasksHelper.DefaultQueue,
+		maxRetryCount: 3,
+		payload: CollectTransferReversalHoldTaskPayload{
+			TransferReversalID: transferReversalID,
+		},
+	}
+}
+
+func (t *CollectTransferReversalHoldTask) Name() string {
+	return t.name
+}
+
+func (t *CollectTransferReversalHoldTask) Queue() string {
+	return t.queue
+}
+
+func (t *CollectTransferReversalHoldTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *CollectTransferReversalHoldTask) Payload() any {
+	return t.payload
+}
+
+type CollectTransferReversalHoldTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewCollectTransferReversalHoldTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &CollectTransferReversalHoldTaskProcessor{
+		services: services,
+	}
+}
+
+// ProcessTask takes back as much of the held amount of a single transfer reversal as the receiver account can cover now
+func (processor *CollectTransferReversalHoldTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[CollectTransferReversalHoldTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	transferReversalID, err := uuid.Parse(payload.Data.TransferReversalID)
+	if err != nil {
+		return fmt.Errorf("Invalid transferReversalID: %s in payload for task: %s, error: %v", payload.Data.TransferReversalID, t.Name(), err)
+	}
+
+	var transferReversal *model.TransferReversal
+	err = database.RunInTransaction(ctx, "collectTransferReversalHold", processor.services.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		transferReversal, err = processor.services.TransferService.CollectTransferReversalHold(txCtx, tx, transferReversalID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if transferReversal == nil {
+		logger.Info(ctx, "Nothing collected for transfer reversal with transferReversalID: %s", transferReversalID)
+		return nil
+	}
+
+	logger.Info(ctx, "Collected held amount of transfer reversal with transferReversalID: %s, amount still held: %d", transferReversalID, transferReversal.HeldAmount)
+	return nil
+}
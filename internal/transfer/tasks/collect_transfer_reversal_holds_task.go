@@ -0,0 +1,122 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+)
+
+const CollectTransferReversalHoldsTaskName string = "periodic_task:collect_transfer_reversal_holds"
+
+// number of reversals on hold fetched from the database in one go
+const collectTransferReversalHoldsBatchSize int = 100
+
+type CollectTransferReversalHoldsTaskPayload struct {
+}
+
+type CollectTransferReversalHoldsTask struct {
+	name          string
+	queue         string
+	cronSpec      string
+	maxRetryCount int
+	payload       CollectTransferReversalHoldsTaskPayload
+}
+
+func NewCollectTransferReversalHoldsTask() tasksHelper.SchedulableTask {
+	return &CollectTransferReversalHoldsTask{
+		name:          CollectTransferReversalHoldsTaskName,
+		queue:         tasksHelper.DefaultQueue,
+		cronSpec:      "15 * * * *", // run every hour at minute 15
+		maxRetryCount: 3,
+		payload:       CollectTransferReversalHoldsTaskPayload{},
+	}
+}
+
+func (t *CollectTransferReversalHoldsTask) Name() string {
+	return t.name
+}
+
+func (t *CollectTransferReversalHoldsTask) Queue() string {
+	return t.queue
+}
+
+func (t *CollectTransferReversalHoldsTask) CronSpec() string {
+	return t.cronSpec
+}
+
+func (t *CollectTransferReversalHoldsTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *CollectTransferReversalHoldsTask) Payload() any {
+	return t.payload
+}
+
+type CollectTransferReversalHoldsTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewCollectTransferReversalHoldsTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &CollectTransferReversalHoldsTaskProcessor{
+		services: services,
+	}
+}
+
+/*
+ProcessTask enqueues a collection task for every transfer reversal with an amount still held on the receiver account
+
+Each hold is collected by its own task, so that one slow or failing collection does not hold up the others.
+A reversal that was already completed is skipped by the collection itself, so retrying this task is safe.
+*/
+func (processor *CollectTransferReversalHoldsTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[CollectTransferReversalHoldsTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	onHoldStatus := model.TransferReversalOnHold
+
+	var afterID *uuid.UUID
+	var failedCount, enqueuedCount int
+	for {
+		transferReversals, err := processor.services.TransferService.ListTransferReversals(ctx, nil, types.TransferReversalListOptions{
+			Status:  &onHoldStatus,
+			AfterID: afterID,
+			Limit:   collectTransferReversalHoldsBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, transferReversal := range transferReversals {
+			err := processor.services.TaskEnqueuer.Enqueue(ctx, NewCollectTransferReversalHoldTask(transferReversal.ID.String()), nil, nil)
+			if err != nil {
+				failedCount++
+				logger.Error(ctx, "Unable to enqueue CollectTransferReversalHoldTask for transferReversalID: %s, error: %+v", transferReversal.ID, err)
+				continue
+			}
+			enqueuedCount++
+		}
+
+		if len(transferReversals) < collectTransferReversalHoldsBatchSize {
+			break
+		}
+		afterID = &transferReversals[len(transferReversals)-1].ID
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf("Unable to enqueue collection of %d transfer reversal hold(s)", failedCount)
+	}
+
+	logger.Info(ctx, "Collection enqueued for %d transfer reversal hold(s)", enqueuedCount)
+	return nil
+}
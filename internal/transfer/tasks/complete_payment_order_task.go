@@ -0,0 +1,105 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/uptrace/bun"
+)
+
+const CompletePaymentOrderTaskName string = "task:complete_payment_order"
+
+type CompletePaymentOrderTaskPayload struct {
+	PaymentOrderID string
+}
+
+type CompletePaymentOrderTask struct {
+	name          string
+	queue         string
+	maxRetryCount int
+	payload       CompletePaymentOrderTaskPayload
+}
+
+// NewCompletePaymentOrderTask returns the task recording the outcome of a sent payment order, it must be enqueued to be processed when the outcome is due
+func NewCompletePaymentOrderTask(paymentOrderID string) tasksHelper.Task {
+	return &CompletePaymentOrderTask{
+		name:          CompletePaymentOrderTaskName,
+		queue:         tasksHelper.PriorityQueue,
+		maxRetryCount: 5,
+		payload: CompletePaymentOrderTaskPayload{
+			PaymentOrderID: paymentOrderID,
+		},
+	}
+}
+
+func (t *CompletePaymentOrderTask) Name() string {
+	return t.name
+}
+
+func (t *CompletePaymentOrderTask) Queue() string {
+	return t.queue
+}
+
+func (t *CompletePaymentOrderTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *CompletePaymentOrderTask) Payload() any {
+	return t.payload
+}
+
+type CompletePaymentOrderTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewCompletePaymentOrderTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &CompletePaymentOrderTaskProcessor{
+		services: services,
+	}
+}
+
+// ProcessTask settles a sent payment order or, when the beneficiary bank has returned it, credits the amount back to the customer
+func (processor *CompletePaymentOrderTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[CompletePaymentOrderTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	paymentOrderID, err := uuid.Parse(payload.Data.PaymentOrderID)
+	if err != nil {
+		return fmt.Errorf("Invalid paymentOrderID: %s in payload for task: %s, error: %v", payload.Data.PaymentOrderID, t.Name(), err)
+	}
+
+	var paymentOrder *model.PaymentOrder
+	err = database.RunInTransaction(ctx, "completePaymentOrder", processor.services.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		paymentOrder, err = processor.services.TransferService.CompletePaymentOrder(txCtx, tx, paymentOrderID, time.Now().UTC())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if paymentOrder == nil {
+		logger.Info(ctx, "Payment order with paymentOrderID: %s is not waiting for an outcome", paymentOrderID)
+		return nil
+	}
+
+	if paymentOrder.Status == model.PaymentOrderReturned {
+		logger.Warn(ctx, "Payment order with paymentOrderID: %s was returned, reason: %s", paymentOrderID, *paymentOrder.ReturnReason)
+		return nil
+	}
+
+	logger.Info(ctx, "Payment order with paymentOrderID: %s has settled", paymentOrderID)
+	return nil
+}
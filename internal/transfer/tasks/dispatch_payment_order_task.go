@@ -0,0 +1,118 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/uptrace/bun"
+)
+
+const DispatchPaymentOrderTaskName string = "task:dispatch_payment_order"
+
+type DispatchPaymentOrderTaskPayload struct {
+	PaymentOrderID string
+}
+
+type DispatchPaymentOrderTask struct {
+	name          string
+	queue         string
+	maxRetryCount int
+	payload       DispatchPaymentOrderTaskPayload
+}
+
+// NewDispatchPaymentOrderTask returns the task sending the payment order over its rail, it must be enqueued to be processed at the dispatch time of the order
+func NewDispatchPaymentOrderTask(paymentOrderID string) tasksHelper.Task {
+	return &DispatchPaymentOrderTask{
+		name:          DispatchPaymentOrderTaskName,
+		queue:         tasksHelper.PriorityQueue,
+		maxRetryCount: 5,
+		payload: DispatchPaymentOrderTaskPayload{
+			PaymentOrderID: paymentOrderID,
+		},
+	}
+}
+
+func (t *DispatchPaymentOrderTask) Name() string {
+	return t.name
+}
+
+func (t *DispatchPaymentOrderTask) Queue() string {
+	return t.queue
+}
+
+func (t *DispatchPaymentOrderTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *DispatchPaymentOrderTask) Payload() any {
+	return t.payload
+}
+
+type DispatchPaymentOrderTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewDispatchPaymentOrderTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &DispatchPaymentOrderTaskProcessor{
+		services: services,
+	}
+}
+
+/*
+ProcessTask sends a payment order over its rail and enqueues its completion for when its outcome is due
+
+The completion is enqueued within the database transaction, so that a sent order is never left without it.
+If the transaction fails to commit after enqueuing, the completion finds the order not sent yet and does nothing.
+*/
+func (processor *DispatchPaymentOrderTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[DispatchPaymentOrderTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	paymentOrderID, err := uuid.Parse(payload.Data.PaymentOrderID)
+	if err != nil {
+		return fmt.Errorf("Invalid paymentOrderID: %s in payload for task: %s, error: %v", payload.Data.PaymentOrderID, t.Name(), err)
+	}
+
+	var paymentOrder *model.PaymentOrder
+	err = database.RunInTransaction(ctx, "dispatchPaymentOrder", processor.services.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		paymentOrder, err = processor.services.TransferService.DispatchPaymentOrder(txCtx, tx, paymentOrderID, time.Now().UTC())
+		if err != nil || paymentOrder == nil {
+			return err
+		}
+
+		return processor.services.TaskEnqueuer.EnqueueAt(txCtx, NewCompletePaymentOrderTask(paymentOrder.ID.String()), *paymentOrder.OutcomeDueAt, nil, nil)
+	})
+	if err != nil {
+		// the transaction creating the payment order was rolled back after its dispatch was enqueued
+		var apiErr *server.ApiError
+		if errors.As(err, &apiErr) && apiErr.HttpStatusCode == http.StatusNotFound {
+			logger.Warn(ctx, "Payment order with paymentOrderID: %s does not exist", paymentOrderID)
+			return nil
+		}
+		return err
+	}
+
+	if paymentOrder == nil {
+		logger.Info(ctx, "Payment order with paymentOrderID: %s has already been sent", paymentOrderID)
+		return nil
+	}
+
+	logger.Info(ctx, "Sent payment order with paymentOrderID: %s over %s, railReference: %s", paymentOrderID, paymentOrder.Rail, *paymentOrder.RailReference)
+	return nil
+}
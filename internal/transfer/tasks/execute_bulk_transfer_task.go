@@ -0,0 +1,193 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/uptrace/bun"
+)
+
+const ExecuteBulkTransferTaskName string = "task:execute_bulk_transfer"
+
+type ExecuteBulkTransferTaskPayload struct {
+	BulkTransferID string
+}
+
+type ExecuteBulkTransferTask struct {
+	name          string
+	queue         string
+	maxRetryCount int
+	payload       ExecuteBulkTransferTaskPayload
+}
+
+// NewExecuteBulkTransferTask returns the task executing the rows of a confirmed bulk transfer, it must be enqueued once the batch is confirmed
+func NewExecuteBulkTransferTask(bulkTransferID string) tasksHelper.Task {
+	return &ExecuteBulkTransferTask{
+		name:          ExecuteBulkTransferTaskName,
+		queue:         tasksHelper.DefaultQueue,
+		maxRetryCount: 5,
+		payload: ExecuteBulkTransferTaskPayload{
+			BulkTransferID: bulkTransferID,
+		},
+	}
+}
+
+func (t *ExecuteBulkTransferTask) Name() string {
+	return t.name
+}
+
+func (t *ExecuteBulkTransferTask) Queue() string {
+	return t.queue
+}
+
+func (t *ExecuteBulkTransferTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *ExecuteBulkTransferTask) Payload() any {
+	return t.payload
+}
+
+type ExecuteBulkTransferTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewExecuteBulkTransferTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &ExecuteBulkTransferTaskProcessor{
+		services: services,
+	}
+}
+
+/*
+ProcessTask executes every pending row of a bulk transfer in the order of the file, each in its own database transaction
+
+A row is only executed while it is pending, so a retried task carries on from the first row left pending
+without paying any row twice. A transfer rejected by the checks of the bank fails its row, not the task,
+the task is only retried for unexpected errors. Once no row is pending anymore the batch is completed and the user notified.
+*/
+func (processor *ExecuteBulkTransferTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[ExecuteBulkTransferTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	bulkTransferID, err := uuid.Parse(payload.Data.BulkTransferID)
+	if err != nil {
+		return fmt.Errorf("Invalid bulkTransferID: %s in payload for task: %s, error: %v", payload.Data.BulkTransferID, t.Name(), err)
+	}
+
+	var bulkTransfer *model.BulkTransfer
+	err = database.RunInTransaction(ctx, "startBulkTransfer", processor.services.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		bulkTransfer, err = processor.services.TransferService.StartBulkTransfer(txCtx, tx, bulkTransferID)
+		return err
+	})
+	if err != nil {
+		// the transaction confirming the bulk transfer was rolled back after its execution was enqueued
+		var apiErr *server.ApiError
+		if errors.As(err, &apiErr) && apiErr.HttpStatusCode == http.StatusNotFound {
+			logger.Warn(ctx, "Bulk transfer with bulkTransferID: %s does not exist", bulkTransferID)
+			return nil
+		}
+		return err
+	}
+
+	if bulkTransfer == nil {
+		logger.Info(ctx, "Bulk transfer with bulkTransferID: %s is not queued for execution", bulkTransferID)
+		return nil
+	}
+
+	pendingStatus := model.BulkTransferItemPending
+	items, err := processor.services.TransferService.ListBulkTransferItems(ctx, nil, types.BulkTransferItemListOptions{
+		BulkTransferID: &bulkTransferID,
+		Status:         &pendingStatus,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		err = processor.executeItem(ctx, item.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = database.RunInTransaction(ctx, "completeBulkTransfer", processor.services.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		bulkTransfer, err = processor.services.TransferService.CompleteBulkTransfer(txCtx, tx, bulkTransferID, time.Now().UTC())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if bulkTransfer == nil {
+		logger.Info(ctx, "Bulk transfer with bulkTransferID: %s has already been completed", bulkTransferID)
+		return nil
+	}
+
+	logger.Info(
+		ctx,
+		"Executed bulk transfer with bulkTransferID: %s, status: %s, succeeded: %d, failed: %d, skipped: %d",
+		bulkTransferID, bulkTransfer.Status, bulkTransfer.SucceededCount, bulkTransfer.FailedCount, bulkTransfer.SkippedCount,
+	)
+
+	err = processor.services.TaskEnqueuer.Enqueue(
+		ctx,
+		NewSendBulkTransferCompletedNotificationTask(bulkTransfer.ID.String(), bulkTransfer.UserID.String(), string(bulkTransfer.Status)),
+		nil,
+		nil,
+	)
+	if err != nil {
+		// the outcome is already recorded, so the notification is not worth retrying the whole task for
+		logger.Error(ctx, "Unable to enqueue bulk transfer completed notification for bulkTransferID: %s, error: %+v", bulkTransferID, err)
+	}
+
+	return nil
+}
+
+// executeItem executes a single row, recording the reason when its transfer is rejected by the checks of the bank
+func (processor *ExecuteBulkTransferTaskProcessor) executeItem(ctx context.Context, itemID uuid.UUID) error {
+	executionTime := time.Now().UTC()
+
+	err := database.RunInTransaction(ctx, "executeBulkTransferItem", processor.services.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		_, err := processor.services.TransferService.ExecuteBulkTransferItem(txCtx, tx, itemID, executionTime)
+		return err
+	})
+	if err == nil {
+		return nil
+	}
+
+	// only the transfers rejected by the checks of the bank are failed, the task is retried for any other error
+	var apiErr *server.ApiError
+	if !errors.As(err, &apiErr) || apiErr.HttpStatusCode >= http.StatusInternalServerError {
+		return err
+	}
+
+	failureReason := apiErr.Message
+	err = database.RunInTransaction(ctx, "failBulkTransferItem", processor.services.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		_, err := processor.services.TransferService.FailBulkTransferItem(txCtx, tx, itemID, failureReason, executionTime)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Warn(ctx, "Bulk transfer item with itemID: %s failed, reason: %s", itemID, failureReason)
+	return nil
+}
@@ -0,0 +1,131 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+)
+
+const ExecuteOverdueScheduledTransfersTaskName string = "periodic_task:execute_overdue_scheduled_transfers"
+
+// number of overdue scheduled transfers fetched from the database in one go
+const executeOverdueScheduledTransfersBatchSize int = 100
+
+// how long after its scheduled time a pending transfer is considered overdue, leaving time for the task enqueued when it was scheduled to run
+const scheduledTransferOverdueAfter time.Duration = 5 * time.Minute
+
+type ExecuteOverdueScheduledTransfersTaskPayload struct {
+}
+
+type ExecuteOverdueScheduledTransfersTask struct {
+	name          string
+	queue         string
+	cronSpec      string
+	maxRetryCount int
+	payload       ExecuteOverdueScheduledTransfersTaskPayload
+}
+
+func NewExecuteOverdueScheduledTransfersTask() tasksHelper.SchedulableTask {
+	return &ExecuteOverdueScheduledTransfersTask{
+		name:          ExecuteOverdueScheduledTransfersTaskName,
+		queue:         tasksHelper.DefaultQueue,
+		cronSpec:      "*/5 * * * *", // run every 5 minutes
+		maxRetryCount: 3,
+		payload:       ExecuteOverdueScheduledTransfersTaskPayload{},
+	}
+}
+
+func (t *ExecuteOverdueScheduledTransfersTask) Name() string {
+	return t.name
+}
+
+func (t *ExecuteOverdueScheduledTransfersTask) Queue() string {
+	return t.queue
+}
+
+func (t *ExecuteOverdueScheduledTransfersTask) CronSpec() string {
+	return t.cronSpec
+}
+
+func (t *ExecuteOverdueScheduledTransfersTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *ExecuteOverdueScheduledTransfersTask) Payload() any {
+	return t.payload
+}
+
+type ExecuteOverdueScheduledTransfersTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewExecuteOverdueScheduledTransfersTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &ExecuteOverdueScheduledTransfersTaskProcessor{
+		services: services,
+	}
+}
+
+/*
+ProcessTask enqueues an execution task for every scheduled transfer still pending well after its scheduled time
+
+Every scheduled transfer has its execution enqueued for its scheduled time when it is created,
+but that task is lost if the task queue loses it, e.g. when redis is flushed or restored from an older snapshot.
+The execution skips a transfer that is no longer pending, so enqueuing it again alongside the original task is safe.
+*/
+func (processor *ExecuteOverdueScheduledTransfersTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[ExecuteOverdueScheduledTransfersTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	overdueBefore := time.Now().UTC().Add(-scheduledTransferOverdueAfter)
+	pendingStatus := model.ScheduledTransferPending
+
+	var afterID *uuid.UUID
+	var failedCount, enqueuedCount int
+	for {
+		scheduledTransfers, err := processor.services.TransferService.ListScheduledTransfers(ctx, nil, types.ScheduledTransferListOptions{
+			Status:          &pendingStatus,
+			ScheduledBefore: &overdueBefore,
+			AfterID:         afterID,
+			Limit:           executeOverdueScheduledTransfersBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, scheduledTransfer := range scheduledTransfers {
+			err := processor.services.TaskEnqueuer.Enqueue(ctx, NewExecuteScheduledTransferTask(scheduledTransfer.ID.String()), nil, nil)
+			if err != nil {
+				failedCount++
+				logger.Error(ctx, "Unable to enqueue ExecuteScheduledTransferTask for scheduledTransferID: %s, error: %+v", scheduledTransfer.ID, err)
+				continue
+			}
+			enqueuedCount++
+		}
+
+		if len(scheduledTransfers) < executeOverdueScheduledTransfersBatchSize {
+			break
+		}
+		afterID = &scheduledTransfers[len(scheduledTransfers)-1].ID
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf("Unable to enqueue execution of %d overdue scheduled transfer(s)", failedCount)
+	}
+
+	if enqueuedCount > 0 {
+		logger.Warn(ctx, "Execution enqueued for %d overdue scheduled transfer(s)", enqueuedCount)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/uptrace/bun"
+)
+
+const ExecuteScheduledTransferTaskName string = "task:execute_scheduled_transfer"
+
+type ExecuteScheduledTransferTaskPayload struct {
+	ScheduledTransferID string
+}
+
+type ExecuteScheduledTransferTask struct {
+	name          string
+	queue         string
+	maxRetryCount int
+	payload       ExecuteScheduledTransferTaskPayload
+}
+
+// NewExecuteScheduledTransferTask returns the task executing the scheduled transfer, it must be enqueued to be processed at the scheduled time
+func NewExecuteScheduledTransferTask(scheduledTransferID string) tasksHelper.Task {
+	return &ExecuteScheduledTransferTask{
+		name:          ExecuteScheduledTransferTaskName,
+		queue:         tasksHelper.PriorityQueue,
+		maxRetryCount: 3,
+		payload: ExecuteScheduledTransferTaskPayload{
+			ScheduledTransferID: scheduledTransferID,
+		},
+	}
+}
+
+func (t *ExecuteScheduledTransferTask) Name() string {
+	return t.name
+}
+
+func (t *ExecuteScheduledTransferTask) Queue() string {
+	return t.queue
+}
+
+func (t *ExecuteScheduledTransferTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *ExecuteScheduledTransferTask) Payload() any {
+	return t.payload
+}
+
+type ExecuteScheduledTransferTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewExecuteScheduledTransferTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &ExecuteScheduledTransferTaskProcessor{
+		services: services,
+	}
+}
+
+/*
+ProcessTask executes a scheduled transfer that has become due
+
+A transfer rejected by the checks of the bank (e.g. insufficient balance or a transfer limit) is not an error of the task,
+the scheduled transfer is marked as failed with the reason and the user is notified.
+The task is only retried for unexpected errors, which roll back the whole attempt.
+*/
+func (processor *ExecuteScheduledTransferTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[ExecuteScheduledTransferTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	scheduledTransferID, err := uuid.Parse(payload.Data.ScheduledTransferID)
+	if err != nil {
+		return fmt.Errorf("Invalid scheduledTransferID: %s in payload for task: %s, error: %v", payload.Data.ScheduledTransferID, t.Name(), err)
+	}
+
+	executionTime := time.Now().UTC()
+
+	var scheduledTransfer *model.ScheduledTransfer
+	err = database.RunInTransaction(ctx, "executeScheduledTransfer", processor.services.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		scheduledTransfer, err = processor.services.TransferService.ExecuteScheduledTransfer(txCtx, tx, scheduledTransferID, executionTime)
+		return err
+	})
+	if err == nil {
+		if scheduledTransfer == nil {
+			logger.Info(ctx, "Scheduled transfer with scheduledTransferID: %s is no longer pending", scheduledTransferID)
+			return nil
+		}
+
+		logger.Info(ctx, "Executed scheduled transfer with scheduledTransferID: %s", scheduledTransferID)
+		return nil
+	}
+
+	// only the transfers rejected by the checks of the bank are failed, the task is retried for any other error
+	var apiErr *server.ApiError
+	if !errors.As(err, &apiErr) || apiErr.HttpStatusCode >= http.StatusInternalServerError {
+		return err
+	}
+
+	failureReason := apiErr.Message
+	err = database.RunInTransaction(ctx, "failScheduledTransfer", processor.services.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		scheduledTransfer, err = processor.services.TransferService.FailScheduledTransfer(txCtx, tx, scheduledTransferID, failureReason, executionTime)
+		return err
+	})
+	if err != nil {
+		// the transaction creating the scheduled transfer was rolled back after its execution was enqueued
+		if errors.As(err, &apiErr) && apiErr.HttpStatusCode == http.StatusNotFound {
+			logger.Warn(ctx, "Scheduled transfer with scheduledTransferID: %s does not exist", scheduledTransferID)
+			return nil
+		}
+		return err
+	}
+
+	if scheduledTransfer == nil {
+		logger.Info(ctx, "Scheduled transfer with scheduledTransferID: %s is no longer pending", scheduledTransferID)
+		return nil
+	}
+
+	logger.Warn(ctx, "Scheduled transfer with scheduledTransferID: %s failed, reason: %s", scheduledTransferID, failureReason)
+
+	err = processor.services.TaskEnqueuer.Enqueue(
+		ctx,
+		NewSendScheduledTransferFailedNotificationTask(scheduledTransfer.ID.String(), scheduledTransfer.UserID.String(), failureReason),
+		nil,
+		nil,
+	)
+	if err != nil {
+		// the failure is already recorded, so the notification is not worth retrying the whole task for
+		logger.Error(ctx, "Unable to enqueue scheduled transfer failed notification for scheduledTransferID: %s, error: %+v", scheduledTransferID, err)
+	}
+
+	return nil
+}
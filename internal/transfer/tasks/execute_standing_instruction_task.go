@@ -0,0 +1,157 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/uptrace/bun"
+)
+
+const ExecuteStandingInstructionTaskName string = "task:execute_standing_instruction"
+
+type ExecuteStandingInstructionTaskPayload struct {
+	StandingInstructionID string
+
+	// ExecutionDate is the day the execution was scheduled for, in YYYY-MM-DD format
+	ExecutionDate string
+}
+
+type ExecuteStandingInstructionTask struct {
+	name          string
+	queue         string
+	maxRetryCount int
+	payload       ExecuteStandingInstructionTaskPayload
+}
+
+func NewExecuteStandingInstructionTask(standingInstructionID string, executionDate string) tasksHelper.Task {
+	return &ExecuteStandingInstructionTask{
+		name:          ExecuteStandingInstructionTaskName,
+		queue:         tasksHelper.DefaultQueue,
+		maxRetryCount: 3,
+		payload: ExecuteStandingInstructionTaskPayload{
+			StandingInstructionID: standingInstructionID,
+			ExecutionDate:         executionDate,
+		},
+	}
+}
+
+func (t *ExecuteStandingInstructionTask) Name() string {
+	return t.name
+}
+
+func (t *ExecuteStandingInstructionTask) Queue() string {
+	return t.queue
+}
+
+func (t *ExecuteStandingInstructionTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *ExecuteStandingInstructionTask) Payload() any {
+	return t.payload
+}
+
+type ExecuteStandingInstructionTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewExecuteStandingInstructionTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &ExecuteStandingInstructionTaskProcessor{
+		services: services,
+	}
+}
+
+/*
+ProcessTask pays the due occurrence of a single standing instruction
+
+A transfer rejected by the checks of the bank (e.g. insufficient balance or a transfer limit) is not an error of the task,
+the failed attempt is recorded and the occurrence is retried or skipped as per the configured policy.
+The user is notified when the instruction gets suspended after repeated failures.
+The task is only retried for unexpected errors, which roll back the whole attempt.
+*/
+func (processor *ExecuteStandingInstructionTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[ExecuteStandingInstructionTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	standingInstructionID, err := uuid.Parse(payload.Data.StandingInstructionID)
+	if err != nil {
+		return fmt.Errorf("Invalid standingInstructionID: %s in payload for task: %s, error: %v", payload.Data.StandingInstructionID, t.Name(), err)
+	}
+
+	executionDate, err := time.Parse(time.DateOnly, payload.Data.ExecutionDate)
+	if err != nil {
+		return fmt.Errorf("Invalid executionDate: %s in payload for task: %s, error: %v", payload.Data.ExecutionDate, t.Name(), err)
+	}
+
+	var execution *model.StandingInstructionExecution
+	err = database.RunInTransaction(ctx, "executeStandingInstruction", processor.services.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		execution, err = processor.services.TransferService.ExecuteStandingInstruction(txCtx, tx, standingInstructionID, executionDate)
+		return err
+	})
+	if err == nil {
+		if execution == nil {
+			logger.Info(ctx, "Standing instruction with standingInstructionID: %s has no occurrence to execute", standingInstructionID)
+			return nil
+		}
+
+		logger.Info(ctx, "Paid occurrence %d of standing instruction with standingInstructionID: %s", execution.OccurrenceNumber, standingInstructionID)
+		return nil
+	}
+
+	// only the transfers rejected by the checks of the bank are recorded as failed, the task is retried for any other error
+	var apiErr *server.ApiError
+	if !errors.As(err, &apiErr) || apiErr.HttpStatusCode >= http.StatusInternalServerError {
+		return err
+	}
+
+	failureReason := apiErr.Message
+	var standingInstruction *model.StandingInstruction
+	err = database.RunInTransaction(ctx, "recordStandingInstructionFailure", processor.services.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		standingInstruction, err = processor.services.TransferService.RecordStandingInstructionFailure(txCtx, tx, standingInstructionID, executionDate, failureReason)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if standingInstruction == nil {
+		logger.Info(ctx, "Standing instruction with standingInstructionID: %s has no occurrence to execute", standingInstructionID)
+		return nil
+	}
+
+	logger.Warn(ctx, "Execution of standing instruction with standingInstructionID: %s failed, reason: %s", standingInstructionID, failureReason)
+
+	if standingInstruction.Status != model.StandingInstructionSuspended {
+		return nil
+	}
+
+	err = processor.services.TaskEnqueuer.Enqueue(
+		ctx,
+		NewSendStandingInstructionSuspendedNotificationTask(standingInstruction.ID.String(), standingInstruction.UserID.String(), failureReason),
+		nil,
+		nil,
+	)
+	if err != nil {
+		// the suspension is already recorded, so the notification is not worth retrying the whole task for
+		logger.Error(ctx, "Unable to enqueue standing instruction suspended notification for standingInstructionID: %s, error: %+v", standingInstructionID, err)
+	}
+
+	return nil
+}
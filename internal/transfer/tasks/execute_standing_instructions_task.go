@@ -0,0 +1,127 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+)
+
+const ExecuteStandingInstructionsTaskName string = "periodic_task:execute_standing_instructions"
+
+// number of due standing instructions fetched from the database in one go
+const executeStandingInstructionsBatchSize int = 100
+
+type ExecuteStandingInstructionsTaskPayload struct {
+}
+
+type ExecuteStandingInstructionsTask struct {
+	name          string
+	queue         string
+	cronSpec      string
+	maxRetryCount int
+	payload       ExecuteStandingInstructionsTaskPayload
+}
+
+func NewExecuteStandingInstructionsTask() tasksHelper.SchedulableTask {
+	return &ExecuteStandingInstructionsTask{
+		name:          ExecuteStandingInstructionsTaskName,
+		queue:         tasksHelper.DefaultQueue,
+		cronSpec:      "30 6 * * *", // run every day at 06:30
+		maxRetryCount: 3,
+		payload:       ExecuteStandingInstructionsTaskPayload{},
+	}
+}
+
+func (t *ExecuteStandingInstructionsTask) Name() string {
+	return t.name
+}
+
+func (t *ExecuteStandingInstructionsTask) Queue() string {
+	return t.queue
+}
+
+func (t *ExecuteStandingInstructionsTask) CronSpec() string {
+	return t.cronSpec
+}
+
+func (t *ExecuteStandingInstructionsTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *ExecuteStandingInstructionsTask) Payload() any {
+	return t.payload
+}
+
+type ExecuteStandingInstructionsTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewExecuteStandingInstructionsTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &ExecuteStandingInstructionsTaskProcessor{
+		services: services,
+	}
+}
+
+/*
+ProcessTask enqueues an execution task for every active standing instruction with an occurrence due,
+including the occurrences that are retried after failing on earlier days
+
+The occurrences are paid by the per-instruction task, so that one slow or failing transfer does not hold up the others.
+An instruction that was already attempted today is skipped by the execution itself, so retrying this task is safe.
+*/
+func (processor *ExecuteStandingInstructionsTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[ExecuteStandingInstructionsTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	now := time.Now().UTC()
+	executionDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	activeStatus := model.StandingInstructionActive
+
+	var afterID *uuid.UUID
+	var failedCount, enqueuedCount int
+	for {
+		standingInstructions, err := processor.services.TransferService.ListStandingInstructions(ctx, nil, types.StandingInstructionListOptions{
+			Status:                      &activeStatus,
+			NextExecutionDateOnOrBefore: &executionDate,
+			AfterID:                     afterID,
+			Limit:                       executeStandingInstructionsBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, standingInstruction := range standingInstructions {
+			err := processor.services.TaskEnqueuer.Enqueue(ctx, NewExecuteStandingInstructionTask(standingInstruction.ID.String(), executionDate.Format(time.DateOnly)), nil, nil)
+			if err != nil {
+				failedCount++
+				logger.Error(ctx, "Unable to enqueue ExecuteStandingInstructionTask for standingInstructionID: %s, error: %+v", standingInstruction.ID, err)
+				continue
+			}
+			enqueuedCount++
+		}
+
+		if len(standingInstructions) < executeStandingInstructionsBatchSize {
+			break
+		}
+		afterID = &standingInstructions[len(standingInstructions)-1].ID
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf("Unable to enqueue execution of %d standing instruction(s)", failedCount)
+	}
+
+	logger.Info(ctx, "Execution enqueued for %d standing instruction(s)", enqueuedCount)
+	return nil
+}
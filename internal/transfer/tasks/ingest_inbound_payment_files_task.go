@@ -0,0 +1,267 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/config"
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/clearingfile"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/uptrace/bun"
+)
+
+const IngestInboundPaymentFilesTaskName string = "periodic_task:ingest_inbound_payment_files"
+
+// sub-directories of the inbound directory the clearing files are moved to once they have been picked up
+const (
+	processedInboundPaymentFilesDirectory      string = "processed"
+	failedInboundPaymentFilesDirectory         string = "failed"
+	inboundPaymentAcknowledgmentFilesDirectory string = "acknowledgments"
+)
+
+// clearingFileFormats maps the extension of a file dropped into the inbound directory to its format, other files are ignored
+var clearingFileFormats = map[string]clearingfile.Format{
+	".csv": clearingfile.CSV,
+	".txt": clearingfile.FixedWidth,
+}
+
+// iso20022MessageExtension is the extension of an ISO 20022 message (a pacs.008 or a pacs.004) dropped into the inbound directory
+const iso20022MessageExtension string = ".xml"
+
+type IngestInboundPaymentFilesTaskPayload struct {
+}
+
+type IngestInboundPaymentFilesTask struct {
+	name          string
+	queue         string
+	cronSpec      string
+	maxRetryCount int
+	payload       IngestInboundPaymentFilesTaskPayload
+}
+
+func NewIngestInboundPaymentFilesTask() tasksHelper.SchedulableTask {
+	return &IngestInboundPaymentFilesTask{
+		name:          IngestInboundPaymentFilesTaskName,
+		queue:         tasksHelper.DefaultQueue,
+		cronSpec:      "* * * * *", // run every minute
+		maxRetryCount: 3,
+		payload:       IngestInboundPaymentFilesTaskPayload{},
+	}
+}
+
+func (t *IngestInboundPaymentFilesTask) Name() string {
+	return t.name
+}
+
+func (t *IngestInboundPaymentFilesTask) Queue() string {
+	return t.queue
+}
+
+func (t *IngestInboundPaymentFilesTask) CronSpec() string {
+	return t.cronSpec
+}
+
+func (t *IngestInboundPaymentFilesTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *IngestInboundPaymentFilesTask) Payload() any {
+	return t.payload
+}
+
+type IngestInboundPaymentFilesTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewIngestInboundPaymentFilesTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &IngestInboundPaymentFilesTaskProcessor{
+		services: services,
+	}
+}
+
+/*
+ProcessTask ingests every clearing file and ISO 20022 message dropped into the inbound directory
+
+Each file is ingested in its own database transaction and then moved to processed/, with the acknowledgment of a clearing file written to acknowledgments/.
+A file that cannot be read as a clearing file, or a message that is invalid or cannot be processed, is moved to failed/. A file that was already
+ingested, e.g. when the move failed on a previous run, is moved to processed/ without being ingested again, so retrying this task is safe.
+*/
+func (processor *IngestInboundPaymentFilesTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[IngestInboundPaymentFilesTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	directory := config.GetInboundPaymentConfig().Directory
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			logger.Info(ctx, "Inbound payment directory: %s does not exist, skipping", directory)
+			return nil
+		}
+		return err
+	}
+
+	var failedCount, ingestedCount int
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		extension := strings.ToLower(filepath.Ext(entry.Name()))
+		format, ok := clearingFileFormats[extension]
+		switch {
+		case ok:
+			err = processor.ingestFile(ctx, directory, entry.Name(), format)
+		case extension == iso20022MessageExtension:
+			err = processor.receiveMessage(ctx, directory, entry.Name())
+		default:
+			continue
+		}
+		if err != nil {
+			failedCount++
+			logger.Error(ctx, "Unable to ingest inbound payment file: %s, error: %+v", entry.Name(), err)
+			continue
+		}
+		ingestedCount++
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf("Unable to ingest %d inbound payment file(s)", failedCount)
+	}
+
+	if ingestedCount > 0 {
+		logger.Info(ctx, "Ingested %d inbound payment file(s)", ingestedCount)
+	}
+	return nil
+}
+
+func (processor *IngestInboundPaymentFilesTaskProcessor) ingestFile(ctx context.Context, directory string, fileName string, format clearingfile.Format) error {
+	content, err := os.ReadFile(filepath.Join(directory, fileName))
+	if err != nil {
+		return err
+	}
+
+	var inboundPaymentFile *model.InboundPaymentFile
+	err = database.RunInTransaction(ctx, "ingestInboundPaymentFile", processor.services.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		inboundPaymentFile, err = processor.services.TransferService.IngestInboundPaymentFile(txCtx, tx, types.IngestInboundPaymentFileParams{
+			FileName: fileName,
+			Format:   format,
+			Content:  content,
+			Source:   model.InboundPaymentFileDirectory,
+		})
+		return err
+	})
+	if err != nil {
+		var apiErr *server.ApiError
+		if !errors.As(err, &apiErr) || apiErr.HttpStatusCode >= http.StatusInternalServerError {
+			return err
+		}
+
+		if apiErr.HttpStatusCode == http.StatusConflict {
+			logger.Warn(ctx, "Inbound payment file: %s has already been ingested", fileName)
+			return moveFile(directory, processedInboundPaymentFilesDirectory, fileName)
+		}
+
+		logger.Error(ctx, "Inbound payment file: %s cannot be ingested, error: %+v", fileName, err)
+		return moveFile(directory, failedInboundPaymentFilesDirectory, fileName)
+	}
+
+	err = moveFile(directory, processedInboundPaymentFilesDirectory, fileName)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(ctx, "Ingested inbound payment file: %s with inboundPaymentFileID: %s, credited: %d, suspense: %d, rejected: %d",
+		fileName, inboundPaymentFile.ID, inboundPaymentFile.CreditedCount, inboundPaymentFile.SuspenseCount, inboundPaymentFile.RejectedCount)
+
+	// the acknowledgment can always be built again by an admin, so failing to write it does not fail the ingestion
+	acknowledgment, err := processor.services.TransferService.BuildInboundPaymentAcknowledgment(ctx, nil, inboundPaymentFile.ID)
+	if err == nil {
+		err = writeFile(directory, inboundPaymentAcknowledgmentFilesDirectory, acknowledgment.FileName, acknowledgment.Content)
+	}
+	if err != nil {
+		logger.Error(ctx, "Unable to write acknowledgment of inbound payment file: %s, error: %+v", fileName, err)
+	}
+
+	return nil
+}
+
+func (processor *IngestInboundPaymentFilesTaskProcessor) receiveMessage(ctx context.Context, directory string, fileName string) error {
+	content, err := os.ReadFile(filepath.Join(directory, fileName))
+	if err != nil {
+		return err
+	}
+
+	var result *types.ISO20022MessageResult
+	err = database.RunInTransaction(ctx, "receiveISO20022Message", processor.services.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		result, err = processor.services.TransferService.ReceiveISO20022Message(txCtx, tx, types.ReceiveISO20022MessageParams{
+			Content:  content,
+			FileName: fileName,
+			Source:   model.InboundPaymentFileDirectory,
+		})
+		return err
+	})
+	if err != nil {
+		var apiErr *server.ApiError
+		if !errors.As(err, &apiErr) || apiErr.HttpStatusCode >= http.StatusInternalServerError {
+			return err
+		}
+
+		if apiErr.HttpStatusCode == http.StatusConflict {
+			logger.Warn(ctx, "ISO 20022 message: %s has already been processed, error: %+v", fileName, err)
+			return moveFile(directory, processedInboundPaymentFilesDirectory, fileName)
+		}
+
+		logger.Error(ctx, "ISO 20022 message: %s cannot be processed, error: %+v", fileName, err)
+		return moveFile(directory, failedInboundPaymentFilesDirectory, fileName)
+	}
+
+	if len(result.ValidationErrors) > 0 {
+		logger.Error(ctx, "ISO 20022 message: %s is invalid, error: %+v", fileName, result.ValidationErrors)
+		return moveFile(directory, failedInboundPaymentFilesDirectory, fileName)
+	}
+
+	err = moveFile(directory, processedInboundPaymentFilesDirectory, fileName)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(ctx, "Processed %s message: %s with messageID: %s", result.MessageType, fileName, result.MessageID)
+	return nil
+}
+
+// moveFile moves the file into the sub-directory of the directory, creating the sub-directory if needed
+func moveFile(directory string, subDirectory string, fileName string) error {
+	err := os.MkdirAll(filepath.Join(directory, subDirectory), 0o755)
+	if err != nil {
+		return err
+	}
+	return os.Rename(filepath.Join(directory, fileName), filepath.Join(directory, subDirectory, fileName))
+}
+
+// writeFile writes the file into the sub-directory of the directory, creating the sub-directory if needed
+func writeFile(directory string, subDirectory string, fileName string, content []byte) error {
+	err := os.MkdirAll(filepath.Join(directory, subDirectory), 0o755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(directory, subDirectory, fileName), content, 0o644)
+}
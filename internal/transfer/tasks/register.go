@@ -0,0 +1,44 @@
+package tasks
+
+import (
+	"context"
+
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+)
+
+func RegisterTaskProcessors(taskRouter tasksHelper.TaskRouter, services *internal.Services) {
+	taskRouter.RegisterTaskProcessor(ExecuteScheduledTransferTaskName, NewExecuteScheduledTransferTaskProcessor(services))
+	taskRouter.RegisterTaskProcessor(SendScheduledTransferFailedNotificationTaskName, NewSendScheduledTransferFailedNotificationTaskProcessor(services))
+	taskRouter.RegisterTaskProcessor(ExecuteOverdueScheduledTransfersTaskName, NewExecuteOverdueScheduledTransfersTaskProcessor(services))
+	taskRouter.RegisterTaskProcessor(ExecuteStandingInstructionsTaskName, NewExecuteStandingInstructionsTaskProcessor(services))
+	taskRouter.RegisterTaskProcessor(ExecuteStandingInstructionTaskName, NewExecuteStandingInstructionTaskProcessor(services))
+	taskRouter.RegisterTaskProcessor(SendStandingInstructionSuspendedNotificationTaskName, NewSendStandingInstructionSuspendedNotificationTaskProcessor(services))
+	taskRouter.RegisterTaskProcessor(CollectTransferReversalHoldsTaskName, NewCollectTransferReversalHoldsTaskProcessor(services))
+	taskRouter.RegisterTaskProcessor(CollectTransferReversalHoldTaskName, NewCollectTransferReversalHoldTaskProcessor(services))
+	taskRouter.RegisterTaskProcessor(DispatchPaymentOrderTaskName, NewDispatchPaymentOrderTaskProcessor(services))
+	taskRouter.RegisterTaskProcessor(CompletePaymentOrderTaskName, NewCompletePaymentOrderTaskProcessor(services))
+	taskRouter.RegisterTaskProcessor(IngestInboundPaymentFilesTaskName, NewIngestInboundPaymentFilesTaskProcessor(services))
+	taskRouter.RegisterTaskProcessor(ExecuteBulkTransferTaskName, NewExecuteBulkTransferTaskProcessor(services))
+	taskRouter.RegisterTaskProcessor(SendBulkTransferCompletedNotificationTaskName, NewSendBulkTransferCompletedNotificationTaskProcessor(services))
+}
+
+func RegisterSchedulableTasks(taskScheduler tasksHelper.TaskScheduler) {
+	ctx := context.TODO()
+	for _, schedulableTask := range schedulableTasks {
+		entryID, err := taskScheduler.RegisterTask(ctx, schedulableTask)
+		if err != nil {
+			logger.Error(ctx, "Scheduler was unable to register task: %+v, error: %+v", schedulableTask.Name(), err)
+			continue
+		}
+		logger.Info(ctx, "Registered scheduled task: %+v with schedule: %+v, entryID: %+v", schedulableTask.Name(), schedulableTask.CronSpec(), entryID)
+	}
+}
+
+var schedulableTasks []tasksHelper.SchedulableTask = []tasksHelper.SchedulableTask{
+	NewExecuteOverdueScheduledTransfersTask(),
+	NewExecuteStandingInstructionsTask(),
+	NewCollectTransferReversalHoldsTask(),
+	NewIngestInboundPaymentFilesTask(),
+}
@@ -0,0 +1,82 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+)
+
+const SendBulkTransferCompletedNotificationTaskName string = "task:send_bulk_transfer_completed_notification"
+
+type SendBulkTransferCompletedNotificationTaskPayload struct {
+	BulkTransferID string
+	UserID         string
+	Status         string
+}
+
+type SendBulkTransferCompletedNotificationTask struct {
+	name          string
+	queue         string
+	maxRetryCount int
+	payload       SendBulkTransferCompletedNotificationTaskPayload
+}
+
+func NewSendBulkTransferCompletedNotificationTask(bulkTransferID string, userID string, status string) tasksHelper.Task {
+	return &SendBulkTransferCompletedNotificationTask{
+		name:          SendBulkTransferCompletedNotificationTaskName,
+		queue:         tasksHelper.PriorityQueue,
+		maxRetryCount: 3,
+		payload: SendBulkTransferCompletedNotificationTaskPayload{
+			BulkTransferID: bulkTransferID,
+			UserID:         userID,
+			Status:         status,
+		},
+	}
+}
+
+func (t *SendBulkTransferCompletedNotificationTask) Name() string {
+	return t.name
+}
+
+func (t *SendBulkTransferCompletedNotificationTask) Queue() string {
+	return t.queue
+}
+
+func (t *SendBulkTransferCompletedNotificationTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *SendBulkTransferCompletedNotificationTask) Payload() any {
+	return t.payload
+}
+
+type SendBulkTransferCompletedNotificationTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewSendBulkTransferCompletedNotificationTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &SendBulkTransferCompletedNotificationTaskProcessor{
+		services: services,
+	}
+}
+
+func (processor *SendBulkTransferCompletedNotificationTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[SendBulkTransferCompletedNotificationTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	// TODO: maybe add a real email/push provider here in the future
+	logger.Info(
+		ctx,
+		"[Dummy] send bulk transfer completed notification for bulkTransferID: %s to userID: %s, status: %s",
+		payload.Data.BulkTransferID, payload.Data.UserID, payload.Data.Status,
+	)
+	return nil
+}
@@ -0,0 +1,82 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+)
+
+const SendScheduledTransferFailedNotificationTaskName string = "task:send_scheduled_transfer_failed_notification"
+
+type SendScheduledTransferFailedNotificationTaskPayload struct {
+	ScheduledTransferID string
+	UserID              string
+	FailureReason       string
+}
+
+type SendScheduledTransferFailedNotificationTask struct {
+	name          string
+	queue         string
+	maxRetryCount int
+	payload       SendScheduledTransferFailedNotificationTaskPayload
+}
+
+func NewSendScheduledTransferFailedNotificationTask(scheduledTransferID string, userID string, failureReason string) tasksHelper.Task {
+	return &SendScheduledTransferFailedNotificationTask{
+		name:          SendScheduledTransferFailedNotificationTaskName,
+		queue:         tasksHelper.PriorityQueue,
+		maxRetryCount: 3,
+		payload: SendScheduledTransferFailedNotificationTaskPayload{
+			ScheduledTransferID: scheduledTransferID,
+			UserID:              userID,
+			FailureReason:       failureReason,
+		},
+	}
+}
+
+func (t *SendScheduledTransferFailedNotificationTask) Name() string {
+	return t.name
+}
+
+func (t *SendScheduledTransferFailedNotificationTask) Queue() string {
+	return t.queue
+}
+
+func (t *SendScheduledTransferFailedNotificationTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *SendScheduledTransferFailedNotificationTask) Payload() any {
+	return t.payload
+}
+
+type SendScheduledTransferFailedNotificationTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewSendScheduledTransferFailedNotificationTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &SendScheduledTransferFailedNotificationTaskProcessor{
+		services: services,
+	}
+}
+
+func (processor *SendScheduledTransferFailedNotificationTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[SendScheduledTransferFailedNotificationTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	// TODO: maybe add a real email/push provider here in the future
+	logger.Info(
+		ctx,
+		"[Dummy] send scheduled transfer failed notification for scheduledTransferID: %s to userID: %s, reason: %s",
+		payload.Data.ScheduledTransferID, payload.Data.UserID, payload.Data.FailureReason,
+	)
+	return nil
+}
@@ -0,0 +1,82 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+)
+
+const SendStandingInstructionSuspendedNotificationTaskName string = "task:send_standing_instruction_suspended_notification"
+
+type SendStandingInstructionSuspendedNotificationTaskPayload struct {
+	StandingInstructionID string
+	UserID                string
+	FailureReason         string
+}
+
+type SendStandingInstructionSuspendedNotificationTask struct {
+	name          string
+	queue         string
+	maxRetryCount int
+	payload       SendStandingInstructionSuspendedNotificationTaskPayload
+}
+
+func NewSendStandingInstructionSuspendedNotificationTask(standingInstructionID string, userID string, failureReason string) tasksHelper.Task {
+	return &SendStandingInstructionSuspendedNotificationTask{
+		name:          SendStandingInstructionSuspendedNotificationTaskName,
+		queue:         tasksHelper.PriorityQueue,
+		maxRetryCount: 3,
+		payload: SendStandingInstructionSuspendedNotificationTaskPayload{
+			StandingInstructionID: standingInstructionID,
+			UserID:                userID,
+			FailureReason:         failureReason,
+		},
+	}
+}
+
+func (t *SendStandingInstructionSuspendedNotificationTask) Name() string {
+	return t.name
+}
+
+func (t *SendStandingInstructionSuspendedNotificationTask) Queue() string {
+	return t.queue
+}
+
+func (t *SendStandingInstructionSuspendedNotificationTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *SendStandingInstructionSuspendedNotificationTask) Payload() any {
+	return t.payload
+}
+
+type SendStandingInstructionSuspendedNotificationTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewSendStandingInstructionSuspendedNotificationTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &SendStandingInstructionSuspendedNotificationTaskProcessor{
+		services: services,
+	}
+}
+
+func (processor *SendStandingInstructionSuspendedNotificationTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[SendStandingInstructionSuspendedNotificationTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	// TODO: maybe add a real email/push provider here in the future
+	logger.Info(
+		ctx,
+		"[Dummy] send standing instruction suspended notification for standingInstructionID: %s to userID: %s, last failure reason: %s",
+		payload.Data.StandingInstructionID, payload.Data.UserID, payload.Data.FailureReason,
+	)
+	return nil
+}
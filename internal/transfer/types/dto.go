@@ -1,7 +1,12 @@
 package types
 
 import (
+	"time"
+
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
 	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/pkg/clearingfile"
 )
 
 type InternalTransferRequest struct {
@@ -9,9 +14,23 @@ type InternalTransferRequest struct {
 }
 
 type InternalTransferRequestData struct {
-	FromAccountID int64  `json:"from_account_id" binding:"required"`
-	ToAccountID   int64  `json:"to_account_id" binding:"required"`
-	Amount        *int64 `json:"amount" binding:"required,gt=0"`
+	FromAccountID int64 `json:"from_account_id" binding:"required,account_number"`
+
+	// the recipient is referenced by its account number, by its IBAN, by a beneficiary saved by the sender or by its VPA
+	ToAccountID   int64  `json:"to_account_id" binding:"required_without_all=ToIBAN BeneficiaryID ToVPA,omitempty,account_number"`
+	ToIBAN        string `json:"to_iban"`
+	BeneficiaryID string `json:"beneficiary_id"`
+	ToVPA         string `json:"to_vpa" binding:"omitempty,vpa"`
+
+	Amount *int64 `json:"amount" binding:"required,gt=0"`
+
+	// FXQuoteID is required for a transfer between accounts held in different currencies, the amount is then converted as quoted
+	FXQuoteID string `json:"fx_quote_id" binding:"omitempty,uuid"`
+
+	// optional remittance information, stored on the transactions of both the sender and the recipient
+	Narration       string `json:"narration" binding:"omitempty,max=140"`
+	ClientReference string `json:"client_reference" binding:"omitempty,max=35,reference"`
+	Category        string `json:"category" binding:"omitempty,oneof=BILL_PAYMENT EDUCATION FAMILY MEDICAL RENT SALARY SHOPPING TRAVEL OTHER"`
 }
 
 type InternalTransferResponse struct {
@@ -21,3 +40,859 @@ type InternalTransferResponse struct {
 type InternalTransferResponseData struct {
 	Transaction accountTypes.TransactionDto `json:"transaction"`
 }
+
+type TransferLimitsDto struct {
+	AccountType          string `json:"account_type"`
+	PerTransactionAmount int64  `json:"per_transaction_amount"`
+	DailyCount           int    `json:"daily_count"`
+	DailyAmount          int64  `json:"daily_amount"`
+	MonthlyAmount        int64  `json:"monthly_amount"`
+}
+
+type GetTransferLimitsResponse struct {
+	Data []TransferLimitsDto `json:"data"`
+}
+
+type UpdateTransferLimitsRequest struct {
+	Data UpdateTransferLimitsRequestData `json:"data" binding:"required"`
+}
+
+type UpdateTransferLimitsRequestData struct {
+	AccountType string `json:"account_type" binding:"required,oneof=SAVINGS_ACCOUNT CURRENT_ACCOUNT"`
+
+	// limits that are not provided are left unchanged, but at least one of them must be provided
+	PerTransactionAmount *int64 `json:"per_transaction_amount" binding:"required_without_all=DailyCount DailyAmount MonthlyAmount,omitempty,gt=0"`
+	DailyCount           *int   `json:"daily_count" binding:"omitempty,gt=0"`
+	DailyAmount          *int64 `json:"daily_amount" binding:"omitempty,gt=0"`
+	MonthlyAmount        *int64 `json:"monthly_amount" binding:"omitempty,gt=0"`
+}
+
+type UpdateTransferLimitsResponse struct {
+	Data TransferLimitsDto `json:"data"`
+}
+
+func TransformToTransferLimitsDto(accountType accountModel.AccountType, limits *TransferLimits) *TransferLimitsDto {
+	if limits == nil {
+		return nil
+	}
+
+	return &TransferLimitsDto{
+		AccountType:          string(accountType),
+		PerTransactionAmount: limits.PerTransactionAmount,
+		DailyCount:           limits.DailyCount,
+		DailyAmount:          limits.DailyAmount,
+		MonthlyAmount:        limits.MonthlyAmount,
+	}
+}
+
+type CreateScheduledTransferRequest struct {
+	Data CreateScheduledTransferRequestData `json:"data" binding:"required"`
+}
+
+type CreateScheduledTransferRequestData struct {
+	FromAccountID int64 `json:"from_account_id" binding:"required,account_number"`
+
+	// the recipient is referenced either by its account number or by its IBAN
+	ToAccountID int64  `json:"to_account_id" binding:"required_without=ToIBAN,omitempty,account_number"`
+	ToIBAN      string `json:"to_iban"`
+
+	Amount *int64 `json:"amount" binding:"required,gt=0"`
+
+	// ScheduledAt is the time the transfer is executed at, in RFC 3339 format
+	ScheduledAt string `json:"scheduled_at" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+type GetScheduledTransfersRequestQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=PENDING COMPLETED FAILED CANCELLED"`
+}
+
+type ScheduledTransferDto struct {
+	ID            string                        `json:"id"`
+	CreatedAt     time.Time                     `json:"created_at"`
+	FromAccountID int64                         `json:"from_account_id"`
+	ToAccountID   int64                         `json:"to_account_id"`
+	Amount        int64                         `json:"amount"`
+	ScheduledAt   time.Time                     `json:"scheduled_at"`
+	Status        model.ScheduledTransferStatus `json:"status"`
+	FailureReason *string                       `json:"failure_reason"`
+	TransactionID *string                       `json:"transaction_id"`
+	ExecutedAt    *time.Time                    `json:"executed_at"`
+}
+
+type CreateScheduledTransferResponse struct {
+	Data ScheduledTransferDto `json:"data"`
+}
+
+type GetScheduledTransfersResponse struct {
+	Data []ScheduledTransferDto `json:"data"`
+}
+
+type CancelScheduledTransferResponse struct {
+	Data ScheduledTransferDto `json:"data"`
+}
+
+func TransformToScheduledTransferDto(scheduledTransfer *model.ScheduledTransfer) *ScheduledTransferDto {
+	var transactionID *string
+	if scheduledTransfer.TransactionID != nil {
+		id := scheduledTransfer.TransactionID.String()
+		transactionID = &id
+	}
+
+	return &ScheduledTransferDto{
+		ID:            scheduledTransfer.ID.String(),
+		CreatedAt:     scheduledTransfer.CreatedAt,
+		FromAccountID: scheduledTransfer.FromAccountID,
+		ToAccountID:   scheduledTransfer.ToAccountID,
+		Amount:        scheduledTransfer.Amount,
+		ScheduledAt:   scheduledTransfer.ScheduledAt,
+		Status:        scheduledTransfer.Status,
+		FailureReason: scheduledTransfer.FailureReason,
+		TransactionID: transactionID,
+		ExecutedAt:    scheduledTransfer.ExecutedAt,
+	}
+}
+
+func TransformToScheduledTransferDtoList(scheduledTransfers []model.ScheduledTransfer) []ScheduledTransferDto {
+	scheduledTransferDtos := make([]ScheduledTransferDto, 0, len(scheduledTransfers))
+	for _, scheduledTransfer := range scheduledTransfers {
+		scheduledTransferDtos = append(scheduledTransferDtos, *TransformToScheduledTransferDto(&scheduledTransfer))
+	}
+	return scheduledTransferDtos
+}
+
+type CreateStandingInstructionRequest struct {
+	Data CreateStandingInstructionRequestData `json:"data" binding:"required"`
+}
+
+type CreateStandingInstructionRequestData struct {
+	FromAccountID int64 `json:"from_account_id" binding:"required,account_number"`
+
+	// the recipient is referenced either by its account number or by its IBAN
+	ToAccountID int64  `json:"to_account_id" binding:"required_without=ToIBAN,omitempty,account_number"`
+	ToIBAN      string `json:"to_iban"`
+
+	Amount    *int64 `json:"amount" binding:"required,gt=0"`
+	Frequency string `json:"frequency" binding:"required,oneof=WEEKLY MONTHLY"`
+
+	// DayOfMonth is only set for a MONTHLY instruction
+	DayOfMonth *int `json:"day_of_month" binding:"omitempty,gte=1,lte=28"`
+
+	StartDate string `json:"start_date" binding:"required,datetime=2006-01-02"`
+
+	// the instruction ends on its end date or after its number of occurrences, whichever comes first
+	EndDate        string `json:"end_date" binding:"required_without=MaxOccurrences,omitempty,datetime=2006-01-02"`
+	MaxOccurrences *int   `json:"max_occurrences" binding:"omitempty,gt=0"`
+}
+
+type GetStandingInstructionsRequestQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=ACTIVE SUSPENDED COMPLETED CANCELLED"`
+}
+
+type StandingInstructionDto struct {
+	ID                      string                             `json:"id"`
+	CreatedAt               time.Time                          `json:"created_at"`
+	FromAccountID           int64                              `json:"from_account_id"`
+	ToAccountID             int64                              `json:"to_account_id"`
+	Amount                  int64                              `json:"amount"`
+	Frequency               model.StandingInstructionFrequency `json:"frequency"`
+	DayOfMonth              *int                               `json:"day_of_month"`
+	StartDate               string                             `json:"start_date"`
+	EndDate                 *string                            `json:"end_date"`
+	MaxOccurrences          *int                               `json:"max_occurrences"`
+	OccurrenceCount         int                                `json:"occurrence_count"`
+	NextExecutionDate       *string                            `json:"next_execution_date"`
+	Status                  model.StandingInstructionStatus    `json:"status"`
+	ConsecutiveFailureCount int                                `json:"consecutive_failure_count"`
+}
+
+type StandingInstructionExecutionDto struct {
+	ID               string                                   `json:"id"`
+	CreatedAt        time.Time                                `json:"created_at"`
+	OccurrenceNumber int                                      `json:"occurrence_number"`
+	DueDate          string                                   `json:"due_date"`
+	Status           model.StandingInstructionExecutionStatus `json:"status"`
+	FailureReason    *string                                  `json:"failure_reason"`
+	TransactionID    *string                                  `json:"transaction_id"`
+}
+
+type CreateStandingInstructionResponse struct {
+	Data StandingInstructionDto `json:"data"`
+}
+
+type GetStandingInstructionsResponse struct {
+	Data []StandingInstructionDto `json:"data"`
+}
+
+type GetStandingInstructionExecutionsResponse struct {
+	Data []StandingInstructionExecutionDto `json:"data"`
+}
+
+type CancelStandingInstructionResponse struct {
+	Data StandingInstructionDto `json:"data"`
+}
+
+type ResumeStandingInstructionResponse struct {
+	Data StandingInstructionDto `json:"data"`
+}
+
+func TransformToStandingInstructionDto(standingInstruction *model.StandingInstruction) *StandingInstructionDto {
+	var endDate *string
+	if standingInstruction.EndDate != nil {
+		date := standingInstruction.EndDate.Format(time.DateOnly)
+		endDate = &date
+	}
+
+	var nextExecutionDate *string
+	if standingInstruction.NextExecutionDate != nil {
+		date := standingInstruction.NextExecutionDate.Format(time.DateOnly)
+		nextExecutionDate = &date
+	}
+
+	return &StandingInstructionDto{
+		ID:                      standingInstruction.ID.String(),
+		CreatedAt:               standingInstruction.CreatedAt,
+		FromAccountID:           standingInstruction.FromAccountID,
+		ToAccountID:             standingInstruction.ToAccountID,
+		Amount:                  standingInstruction.Amount,
+		Frequency:               standingInstruction.Frequency,
+		DayOfMonth:              standingInstruction.DayOfMonth,
+		StartDate:               standingInstruction.StartDate.Format(time.DateOnly),
+		EndDate:                 endDate,
+		MaxOccurrences:          standingInstruction.MaxOccurrences,
+		OccurrenceCount:         standingInstruction.OccurrenceCount,
+		NextExecutionDate:       nextExecutionDate,
+		Status:                  standingInstruction.Status,
+		ConsecutiveFailureCount: standingInstruction.ConsecutiveFailureCount,
+	}
+}
+
+func TransformToStandingInstructionDtoList(standingInstructions []model.StandingInstruction) []StandingInstructionDto {
+	standingInstructionDtos := make([]StandingInstructionDto, 0, len(standingInstructions))
+	for _, standingInstruction := range standingInstructions {
+		standingInstructionDtos = append(standingInstructionDtos, *TransformToStandingInstructionDto(&standingInstruction))
+	}
+	return standingInstructionDtos
+}
+
+func TransformToStandingInstructionExecutionDtoList(executions []model.StandingInstructionExecution) []StandingInstructionExecutionDto {
+	executionDtos := make([]StandingInstructionExecutionDto, 0, len(executions))
+	for _, execution := range executions {
+		var transactionID *string
+		if execution.TransactionID != nil {
+			id := execution.TransactionID.String()
+			transactionID = &id
+		}
+
+		executionDtos = append(executionDtos, StandingInstructionExecutionDto{
+			ID:               execution.ID.String(),
+			CreatedAt:        execution.CreatedAt,
+			OccurrenceNumber: execution.OccurrenceNumber,
+			DueDate:          execution.DueDate.Format(time.DateOnly),
+			Status:           execution.Status,
+			FailureReason:    execution.FailureReason,
+			TransactionID:    transactionID,
+		})
+	}
+	return executionDtos
+}
+
+type ReverseTransferRequest struct {
+	Data ReverseTransferRequestData `json:"data" binding:"required"`
+}
+
+type ReverseTransferRequestData struct {
+	Reason string `json:"reason" binding:"required,min=1"`
+}
+
+type GetTransferReversalsRequestQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=ON_HOLD COMPLETED"`
+}
+
+type TransferReversalDto struct {
+	ID                    string                       `json:"id"`
+	CreatedAt             time.Time                    `json:"created_at"`
+	OriginalTransactionID string                       `json:"original_transaction_id"`
+	FromAccountID         int64                        `json:"from_account_id"`
+	ToAccountID           int64                        `json:"to_account_id"`
+	Amount                int64                        `json:"amount"`
+	ReversedAmount        int64                        `json:"reversed_amount"`
+	HeldAmount            int64                        `json:"held_amount"`
+	Status                model.TransferReversalStatus `json:"status"`
+	Reason                string                       `json:"reason"`
+	InitiatedBy           string                       `json:"initiated_by"`
+	CompletedAt           *time.Time                   `json:"completed_at"`
+}
+
+type ReverseTransferResponse struct {
+	Data TransferReversalDto `json:"data"`
+}
+
+type GetTransferReversalsResponse struct {
+	Data []TransferReversalDto `json:"data"`
+}
+
+func TransformToTransferReversalDto(transferReversal *model.TransferReversal) *TransferReversalDto {
+	return &TransferReversalDto{
+		ID:                    transferReversal.ID.String(),
+		CreatedAt:             transferReversal.CreatedAt,
+		OriginalTransactionID: transferReversal.OriginalTransactionID.String(),
+		FromAccountID:         transferReversal.FromAccountID,
+		ToAccountID:           transferReversal.ToAccountID,
+		Amount:                transferReversal.Amount,
+		ReversedAmount:        transferReversal.ReversedAmount,
+		HeldAmount:            transferReversal.HeldAmount,
+		Status:                transferReversal.Status,
+		Reason:                transferReversal.Reason,
+		InitiatedBy:           transferReversal.InitiatedBy.String(),
+		CompletedAt:           transferReversal.CompletedAt,
+	}
+}
+
+func TransformToTransferReversalDtoList(transferReversals []model.TransferReversal) []TransferReversalDto {
+	transferReversalDtos := make([]TransferReversalDto, 0, len(transferReversals))
+	for _, transferReversal := range transferReversals {
+		transferReversalDtos = append(transferReversalDtos, *TransformToTransferReversalDto(&transferReversal))
+	}
+	return transferReversalDtos
+}
+
+type ExternalTransferRequest struct {
+	Data ExternalTransferRequestData `json:"data" binding:"required"`
+}
+
+type ExternalTransferRequestData struct {
+	FromAccountID int64  `json:"from_account_id" binding:"required,account_number"`
+	Rail          string `json:"rail" binding:"required,oneof=NEFT IMPS RTGS"`
+
+	// the beneficiary is identified by the account number at its bank and the IFSC of its branch
+	BeneficiaryName          string `json:"beneficiary_name" binding:"required,min=1,max=100"`
+	BeneficiaryAccountNumber string `json:"beneficiary_account_number" binding:"required,numeric,min=9,max=18"`
+	BeneficiaryIFSC          string `json:"beneficiary_ifsc" binding:"required,ifsc"`
+
+	Amount *int64 `json:"amount" binding:"required,gt=0"`
+
+	// optional remittance information, stored on the debit of the sender account
+	Narration       string `json:"narration" binding:"omitempty,max=140"`
+	ClientReference string `json:"client_reference" binding:"omitempty,max=35,reference"`
+	Category        string `json:"category" binding:"omitempty,oneof=BILL_PAYMENT EDUCATION FAMILY MEDICAL RENT SALARY SHOPPING TRAVEL OTHER"`
+}
+
+type GetExternalTransfersRequestQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=INITIATED SENT SETTLED RETURNED"`
+}
+
+type PaymentOrderDto struct {
+	ID                       string                   `json:"id"`
+	CreatedAt                time.Time                `json:"created_at"`
+	FromAccountID            int64                    `json:"from_account_id"`
+	Amount                   int64                    `json:"amount"`
+	Rail                     model.Rail               `json:"rail"`
+	BeneficiaryName          string                   `json:"beneficiary_name"`
+	BeneficiaryAccountNumber string                   `json:"beneficiary_account_number"`
+	BeneficiaryIFSC          string                   `json:"beneficiary_ifsc"`
+	Status                   model.PaymentOrderStatus `json:"status"`
+	RailReference            *string                  `json:"rail_reference"`
+	ReturnReason             *string                  `json:"return_reason"`
+	DebitTransactionID       string                   `json:"debit_transaction_id"`
+	ReturnTransactionID      *string                  `json:"return_transaction_id"`
+	DispatchAt               time.Time                `json:"dispatch_at"`
+	SentAt                   *time.Time               `json:"sent_at"`
+	SettledAt                *time.Time               `json:"settled_at"`
+	ReturnedAt               *time.Time               `json:"returned_at"`
+}
+
+type ExternalTransferResponse struct {
+	Data PaymentOrderDto `json:"data"`
+}
+
+type GetExternalTransfersResponse struct {
+	Data []PaymentOrderDto `json:"data"`
+}
+
+func TransformToPaymentOrderDto(paymentOrder *model.PaymentOrder) *PaymentOrderDto {
+	var returnTransactionID *string
+	if paymentOrder.ReturnTransactionID != nil {
+		id := paymentOrder.ReturnTransactionID.String()
+		returnTransactionID = &id
+	}
+
+	return &PaymentOrderDto{
+		ID:                       paymentOrder.ID.String(),
+		CreatedAt:                paymentOrder.CreatedAt,
+		FromAccountID:            paymentOrder.FromAccountID,
+		Amount:                   paymentOrder.Amount,
+		Rail:                     paymentOrder.Rail,
+		BeneficiaryName:          paymentOrder.BeneficiaryName,
+		BeneficiaryAccountNumber: paymentOrder.BeneficiaryAccountNumber,
+		BeneficiaryIFSC:          paymentOrder.BeneficiaryIFSC,
+		Status:                   paymentOrder.Status,
+		RailReference:            paymentOrder.RailReference,
+		ReturnReason:             paymentOrder.ReturnReason,
+		DebitTransactionID:       paymentOrder.DebitTransactionID.String(),
+		ReturnTransactionID:      returnTransactionID,
+		DispatchAt:               paymentOrder.DispatchAt,
+		SentAt:                   paymentOrder.SentAt,
+		SettledAt:                paymentOrder.SettledAt,
+		ReturnedAt:               paymentOrder.ReturnedAt,
+	}
+}
+
+func TransformToPaymentOrderDtoList(paymentOrders []model.PaymentOrder) []PaymentOrderDto {
+	paymentOrderDtos := make([]PaymentOrderDto, 0, len(paymentOrders))
+	for _, paymentOrder := range paymentOrders {
+		paymentOrderDtos = append(paymentOrderDtos, *TransformToPaymentOrderDto(&paymentOrder))
+	}
+	return paymentOrderDtos
+}
+
+type UploadInboundPaymentFileRequest struct {
+	Data UploadInboundPaymentFileRequestData `json:"data" binding:"required"`
+}
+
+type UploadInboundPaymentFileRequestData struct {
+	FileName string `json:"file_name" binding:"required,min=1,max=255"`
+	Format   string `json:"format" binding:"required,oneof=CSV FIXED_WIDTH"`
+
+	// Content is the text of the clearing file
+	Content string `json:"content" binding:"required,min=1"`
+}
+
+type GetInboundPaymentsRequestQuery struct {
+	FileID string `form:"file_id" binding:"omitempty,uuid"`
+	Status string `form:"status" binding:"omitempty,oneof=CREDITED SUSPENSE RETURNED REJECTED"`
+}
+
+type AssignInboundPaymentRequest struct {
+	Data AssignInboundPaymentRequestData `json:"data" binding:"required"`
+}
+
+type AssignInboundPaymentRequestData struct {
+	AccountID int64 `json:"account_id" binding:"required,account_number"`
+}
+
+type ReturnInboundPaymentRequest struct {
+	Data ReturnInboundPaymentRequestData `json:"data" binding:"required"`
+}
+
+type ReturnInboundPaymentRequestData struct {
+	Reason string `json:"reason" binding:"required,min=1,max=60"`
+}
+
+type InboundPaymentFileDto struct {
+	ID            string                         `json:"id"`
+	CreatedAt     time.Time                      `json:"created_at"`
+	FileName      string                         `json:"file_name"`
+	Format        clearingfile.Format            `json:"format"`
+	Source        model.InboundPaymentFileSource `json:"source"`
+	RecordCount   int                            `json:"record_count"`
+	CreditedCount int                            `json:"credited_count"`
+	SuspenseCount int                            `json:"suspense_count"`
+	RejectedCount int                            `json:"rejected_count"`
+}
+
+type InboundPaymentDto struct {
+	ID                       string                     `json:"id"`
+	CreatedAt                time.Time                  `json:"created_at"`
+	FileID                   string                     `json:"file_id"`
+	LineNumber               int                        `json:"line_number"`
+	UTR                      string                     `json:"utr"`
+	Amount                   int64                      `json:"amount"`
+	BeneficiaryAccountNumber string                     `json:"beneficiary_account_number"`
+	BeneficiaryIFSC          string                     `json:"beneficiary_ifsc"`
+	RemitterName             string                     `json:"remitter_name"`
+	RemitterAccountNumber    string                     `json:"remitter_account_number"`
+	RemitterIFSC             string                     `json:"remitter_ifsc"`
+	Status                   model.InboundPaymentStatus `json:"status"`
+	Reason                   *string                    `json:"reason"`
+	RawRecord                *string                    `json:"raw_record"`
+	AccountID                *int64                     `json:"account_id"`
+	TransactionID            *string                    `json:"transaction_id"`
+	ResolvedBy               *string                    `json:"resolved_by"`
+	ResolvedAt               *time.Time                 `json:"resolved_at"`
+}
+
+type InboundPaymentAcknowledgmentDto struct {
+	FileName string              `json:"file_name"`
+	Format   clearingfile.Format `json:"format"`
+	Content  string              `json:"content"`
+}
+
+type UploadInboundPaymentFileResponse struct {
+	Data InboundPaymentFileDto `json:"data"`
+}
+
+type GetInboundPaymentsResponse struct {
+	Data []InboundPaymentDto `json:"data"`
+}
+
+type InboundPaymentResponse struct {
+	Data InboundPaymentDto `json:"data"`
+}
+
+type GetInboundPaymentAcknowledgmentResponse struct {
+	Data InboundPaymentAcknowledgmentDto `json:"data"`
+}
+
+func TransformToInboundPaymentFileDto(inboundPaymentFile *model.InboundPaymentFile) *InboundPaymentFileDto {
+	return &InboundPaymentFileDto{
+		ID:            inboundPaymentFile.ID.String(),
+		CreatedAt:     inboundPaymentFile.CreatedAt,
+		FileName:      inboundPaymentFile.FileName,
+		Format:        inboundPaymentFile.Format,
+		Source:        inboundPaymentFile.Source,
+		RecordCount:   inboundPaymentFile.RecordCount,
+		CreditedCount: inboundPaymentFile.CreditedCount,
+		SuspenseCount: inboundPaymentFile.SuspenseCount,
+		RejectedCount: inboundPaymentFile.RejectedCount,
+	}
+}
+
+func TransformToInboundPaymentDto(inboundPayment *model.InboundPayment) *InboundPaymentDto {
+	var transactionID *string
+	if inboundPayment.TransactionID != nil {
+		id := inboundPayment.TransactionID.String()
+		transactionID = &id
+	}
+
+	var resolvedBy *string
+	if inboundPayment.ResolvedBy != nil {
+		id := inboundPayment.ResolvedBy.String()
+		resolvedBy = &id
+	}
+
+	return &InboundPaymentDto{
+		ID:                       inboundPayment.ID.String(),
+		CreatedAt:                inboundPayment.CreatedAt,
+		FileID:                   inboundPayment.FileID.String(),
+		LineNumber:               inboundPayment.LineNumber,
+		UTR:                      inboundPayment.UTR,
+		Amount:                   inboundPayment.Amount,
+		BeneficiaryAccountNumber: inboundPayment.BeneficiaryAccountNumber,
+		BeneficiaryIFSC:          inboundPayment.BeneficiaryIFSC,
+		RemitterName:             inboundPayment.RemitterName,
+		RemitterAccountNumber:    inboundPayment.RemitterAccountNumber,
+		RemitterIFSC:             inboundPayment.RemitterIFSC,
+		Status:                   inboundPayment.Status,
+		Reason:                   inboundPayment.Reason,
+		RawRecord:                inboundPayment.RawRecord,
+		AccountID:                inboundPayment.AccountID,
+		TransactionID:            transactionID,
+		ResolvedBy:               resolvedBy,
+		ResolvedAt:               inboundPayment.ResolvedAt,
+	}
+}
+
+func TransformToInboundPaymentDtoList(inboundPayments []model.InboundPayment) []InboundPaymentDto {
+	inboundPaymentDtos := make([]InboundPaymentDto, 0, len(inboundPayments))
+	for _, inboundPayment := range inboundPayments {
+		inboundPaymentDtos = append(inboundPaymentDtos, *TransformToInboundPaymentDto(&inboundPayment))
+	}
+	return inboundPaymentDtos
+}
+
+func TransformToInboundPaymentAcknowledgmentDto(acknowledgment *InboundPaymentAcknowledgment) *InboundPaymentAcknowledgmentDto {
+	return &InboundPaymentAcknowledgmentDto{
+		FileName: acknowledgment.FileName,
+		Format:   acknowledgment.Format,
+		Content:  string(acknowledgment.Content),
+	}
+}
+
+type ReceiveISO20022MessagesRequest struct {
+	Data ReceiveISO20022MessagesRequestData `json:"data" binding:"required"`
+}
+
+type ReceiveISO20022MessagesRequestData struct {
+	Messages []ISO20022MessageRequestData `json:"messages" binding:"required,min=1,max=50,dive"`
+}
+
+type ISO20022MessageRequestData struct {
+	// Content is the XML Document of the message
+	Content string `json:"content" binding:"required,min=1"`
+}
+
+// outcome of a message received from the clearing house, a message that is not processed leaves nothing behind
+const (
+	ISO20022MessageProcessed string = "PROCESSED"
+	ISO20022MessageInvalid   string = "INVALID"  // the message violates the schema
+	ISO20022MessageRejected  string = "REJECTED" // the message cannot be processed, e.g. it returns an unknown payment order
+)
+
+type ISO20022MessageErrorDto struct {
+	// Path locates the offending element of an invalid message, it is empty for a rejected message
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+type ISO20022MessageResultDto struct {
+	MessageType        string                    `json:"message_type"`
+	MessageID          string                    `json:"message_id"`
+	Status             string                    `json:"status"`
+	Errors             []ISO20022MessageErrorDto `json:"errors"`
+	InboundPaymentFile *InboundPaymentFileDto    `json:"inbound_payment_file"`
+	PaymentOrders      []PaymentOrderDto         `json:"payment_orders"`
+}
+
+type ISO20022MessageDto struct {
+	FileName string `json:"file_name"`
+	Content  string `json:"content"`
+}
+
+type ReceiveISO20022MessagesResponse struct {
+	Data []ISO20022MessageResultDto `json:"data"`
+}
+
+type GetPaymentOrderCreditTransferResponse struct {
+	Data ISO20022MessageDto `json:"data"`
+}
+
+func TransformToISO20022MessageResultDto(result *ISO20022MessageResult) *ISO20022MessageResultDto {
+	resultDto := &ISO20022MessageResultDto{
+		MessageType:   string(result.MessageType),
+		MessageID:     result.MessageID,
+		Status:        ISO20022MessageProcessed,
+		Errors:        make([]ISO20022MessageErrorDto, 0, len(result.ValidationErrors)),
+		PaymentOrders: TransformToPaymentOrderDtoList(result.PaymentOrders),
+	}
+
+	if len(result.ValidationErrors) > 0 {
+		resultDto.Status = ISO20022MessageInvalid
+		for _, validationError := range result.ValidationErrors {
+			resultDto.Errors = append(resultDto.Errors, ISO20022MessageErrorDto{
+				Path:    validationError.Path,
+				Message: validationError.Message,
+			})
+		}
+	}
+
+	if result.InboundPaymentFile != nil {
+		resultDto.InboundPaymentFile = TransformToInboundPaymentFileDto(result.InboundPaymentFile)
+	}
+
+	return resultDto
+}
+
+type UploadBulkTransferRequest struct {
+	Data UploadBulkTransferRequestData `json:"data" binding:"required"`
+}
+
+type UploadBulkTransferRequestData struct {
+	FromAccountID int64  `json:"from_account_id" binding:"required,account_number"`
+	FileName      string `json:"file_name" binding:"required,min=1,max=255"`
+
+	// Content is the text of the CSV file, with a to_account,amount,narration header
+	Content string `json:"content" binding:"required,min=1"`
+
+	// StopOnInsufficientFunds stops the batch at the first row the account cannot afford, by default the row fails and the batch moves on
+	StopOnInsufficientFunds bool `json:"stop_on_insufficient_funds"`
+}
+
+type GetBulkTransfersRequestQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=VALIDATED INVALID CANCELLED QUEUED PROCESSING COMPLETED PARTIALLY_COMPLETED FAILED STOPPED"`
+}
+
+type BulkTransferDto struct {
+	ID                      string                   `json:"id"`
+	CreatedAt               time.Time                `json:"created_at"`
+	FromAccountID           int64                    `json:"from_account_id"`
+	FileName                string                   `json:"file_name"`
+	Status                  model.BulkTransferStatus `json:"status"`
+	StopOnInsufficientFunds bool                     `json:"stop_on_insufficient_funds"`
+	ItemCount               int                      `json:"item_count"`
+	TotalAmount             int64                    `json:"total_amount"`
+	EstimatedFee            int64                    `json:"estimated_fee"`
+	SucceededCount          int                      `json:"succeeded_count"`
+	FailedCount             int                      `json:"failed_count"`
+	SkippedCount            int                      `json:"skipped_count"`
+	StoppedAtLineNumber     *int                     `json:"stopped_at_line_number"`
+	ConfirmedAt             *time.Time               `json:"confirmed_at"`
+	CompletedAt             *time.Time               `json:"completed_at"`
+
+	// Items is only set when a single batch is returned
+	Items []BulkTransferItemDto `json:"items,omitempty"`
+}
+
+type BulkTransferItemDto struct {
+	ID            string                       `json:"id"`
+	LineNumber    int                          `json:"line_number"`
+	ToAccountID   int64                        `json:"to_account_id"`
+	Amount        int64                        `json:"amount"`
+	Narration     *string                      `json:"narration"`
+	Status        model.BulkTransferItemStatus `json:"status"`
+	Reason        *string                      `json:"reason"`
+	RawRow        *string                      `json:"raw_row"`
+	TransactionID *string                      `json:"transaction_id"`
+	ProcessedAt   *time.Time                   `json:"processed_at"`
+}
+
+type BulkTransferResultDto struct {
+	FileName string `json:"file_name"`
+	Content  string `json:"content"`
+}
+
+type BulkTransferResponse struct {
+	Data BulkTransferDto `json:"data"`
+}
+
+type GetBulkTransfersResponse struct {
+	Data []BulkTransferDto `json:"data"`
+}
+
+type GetBulkTransferResultResponse struct {
+	Data BulkTransferResultDto `json:"data"`
+}
+
+func TransformToBulkTransferDto(bulkTransfer *model.BulkTransfer, items []model.BulkTransferItem) *BulkTransferDto {
+	bulkTransferDto := &BulkTransferDto{
+		ID:                      bulkTransfer.ID.String(),
+		CreatedAt:               bulkTransfer.CreatedAt,
+		FromAccountID:           bulkTransfer.FromAccountID,
+		FileName:                bulkTransfer.FileName,
+		Status:                  bulkTransfer.Status,
+		StopOnInsufficientFunds: bulkTransfer.StopOnInsufficientFunds,
+		ItemCount:               bulkTransfer.ItemCount,
+		TotalAmount:             bulkTransfer.TotalAmount,
+		EstimatedFee:            bulkTransfer.EstimatedFee,
+		SucceededCount:          bulkTransfer.SucceededCount,
+		FailedCount:             bulkTransfer.FailedCount,
+		SkippedCount:            bulkTransfer.SkippedCount,
+		StoppedAtLineNumber:     bulkTransfer.StoppedAtLineNumber,
+		ConfirmedAt:             bulkTransfer.ConfirmedAt,
+		CompletedAt:             bulkTransfer.CompletedAt,
+	}
+	if items != nil {
+		bulkTransferDto.Items = TransformToBulkTransferItemDtoList(items)
+	}
+	return bulkTransferDto
+}
+
+func TransformToBulkTransferDtoList(bulkTransfers []model.BulkTransfer) []BulkTransferDto {
+	bulkTransferDtos := make([]BulkTransferDto, 0, len(bulkTransfers))
+	for _, bulkTransfer := range bulkTransfers {
+		bulkTransferDtos = append(bulkTransferDtos, *TransformToBulkTransferDto(&bulkTransfer, nil))
+	}
+	return bulkTransferDtos
+}
+
+func TransformToBulkTransferItemDto(item *model.BulkTransferItem) *BulkTransferItemDto {
+	var transactionID *string
+	if item.TransactionID != nil {
+		id := item.TransactionID.String()
+		transactionID = &id
+	}
+
+	return &BulkTransferItemDto{
+		ID:            item.ID.String(),
+		LineNumber:    item.LineNumber,
+		ToAccountID:   item.ToAccountID,
+		Amount:        item.Amount,
+		Narration:     item.Narration,
+		Status:        item.Status,
+		Reason:        item.Reason,
+		RawRow:        item.RawRow,
+		TransactionID: transactionID,
+		ProcessedAt:   item.ProcessedAt,
+	}
+}
+
+func TransformToBulkTransferItemDtoList(items []model.BulkTransferItem) []BulkTransferItemDto {
+	itemDtos := make([]BulkTransferItemDto, 0, len(items))
+	for _, item := range items {
+		itemDtos = append(itemDtos, *TransformToBulkTransferItemDto(&item))
+	}
+	return itemDtos
+}
+
+func TransformToBulkTransferResultDto(result *BulkTransferResult) *BulkTransferResultDto {
+	return &BulkTransferResultDto{
+		FileName: result.FileName,
+		Content:  string(result.Content),
+	}
+}
+
+type GetJointTransfersRequestQuery struct {
+	AccountID int64  `form:"account_id" binding:"required,account_number"`
+	Status    string `form:"status" binding:"omitempty,oneof=PENDING COMPLETED REJECTED"`
+}
+
+type JointTransferDto struct {
+	ID                string                            `json:"id"`
+	CreatedAt         time.Time                         `json:"created_at"`
+	InitiatedByUserID string                            `json:"initiated_by_user_id"`
+	FromAccountID     int64                             `json:"from_account_id"`
+	ToAccountID       int64                             `json:"to_account_id"`
+	Amount            int64                             `json:"amount"`
+	Narration         *string                           `json:"narration"`
+	ClientReference   *string                           `json:"client_reference"`
+	Category          *accountModel.TransactionCategory `json:"category"`
+	Status            model.JointTransferStatus         `json:"status"`
+	TransactionID     *string                           `json:"transaction_id"`
+	ExecutedAt        *time.Time                        `json:"executed_at"`
+
+	// the decisions taken so far, only returned when a single joint transfer is fetched or decided on
+	Approvals []JointTransferApprovalDto `json:"approvals,omitempty"`
+}
+
+type JointTransferApprovalDto struct {
+	UserID    string                      `json:"user_id"`
+	Decision  model.JointTransferDecision `json:"decision"`
+	CreatedAt time.Time                   `json:"created_at"`
+}
+
+// JointTransferResponse is returned with a 202 status code instead of InternalTransferResponse when the transfer awaits the approval of the other holders
+type JointTransferResponse struct {
+	Data JointTransferDto `json:"data"`
+}
+
+type GetJointTransfersResponse struct {
+	Data []JointTransferDto `json:"data"`
+}
+
+type GetJointTransferResponse struct {
+	Data JointTransferDto `json:"data"`
+}
+
+type DecideJointTransferResponse struct {
+	Data JointTransferDto `json:"data"`
+}
+
+func TransformToJointTransferDto(jointTransfer *model.JointTransfer, approvals []model.JointTransferApproval) *JointTransferDto {
+	var transactionID *string
+	if jointTransfer.TransactionID != nil {
+		id := jointTransfer.TransactionID.String()
+		transactionID = &id
+	}
+
+	var approvalDtos []JointTransferApprovalDto
+	for _, approval := range approvals {
+		approvalDtos = append(approvalDtos, JointTransferApprovalDto{
+			UserID:    approval.UserID.String(),
+			Decision:  approval.Decision,
+			CreatedAt: approval.CreatedAt,
+		})
+	}
+
+	return &JointTransferDto{
+		ID:                jointTransfer.ID.String(),
+		CreatedAt:         jointTransfer.CreatedAt,
+		InitiatedByUserID: jointTransfer.InitiatedByUserID.String(),
+		FromAccountID:     jointTransfer.FromAccountID,
+		ToAccountID:       jointTransfer.ToAccountID,
+		Amount:            jointTransfer.Amount,
+		Narration:         jointTransfer.Narration,
+		ClientReference:   jointTransfer.ClientReference,
+		Category:          jointTransfer.Category,
+		Status:            jointTransfer.Status,
+		TransactionID:     transactionID,
+		ExecutedAt:        jointTransfer.ExecutedAt,
+		Approvals:         approvalDtos,
+	}
+}
+
+func TransformToJointTransferDtoList(jointTransfers []model.JointTransfer) []JointTransferDto {
+	jointTransferDtos := make([]JointTransferDto, 0, len(jointTransfers))
+	for _, jointTransfer := range jointTransfers {
+		jointTransferDtos = append(jointTransferDtos, *TransformToJointTransferDto(&jointTransfer, nil))
+	}
+	return jointTransferDtos
+}
@@ -0,0 +1,226 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+)
+
+type TransferLimitOverrideQueryOptions struct {
+	UserID      *uuid.UUID
+	AccountType *accountModel.AccountType
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type ScheduledTransferQueryOptions struct {
+	ID     *uuid.UUID
+	UserID *uuid.UUID
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type ScheduledTransferListOptions struct {
+	UserID        *uuid.UUID
+	FromAccountID *int64
+	Status        *model.ScheduledTransferStatus
+
+	// When set, only transfers scheduled strictly before this time are returned
+	ScheduledBefore *time.Time
+
+	// keyset pagination: only transfers with an ID greater than AfterID are returned, ordered by ID
+	AfterID *uuid.UUID
+	Limit   int
+}
+
+type ScheduledTransferUpdateOptions struct {
+	NewStatus        *model.ScheduledTransferStatus
+	NewFailureReason *string
+	NewTransactionID *uuid.UUID
+	NewExecutedAt    *time.Time
+}
+
+type StandingInstructionQueryOptions struct {
+	ID     *uuid.UUID
+	UserID *uuid.UUID
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type StandingInstructionListOptions struct {
+	UserID        *uuid.UUID
+	FromAccountID *int64
+	Status        *model.StandingInstructionStatus
+
+	// When set, only instructions with an occurrence due on or before this date are returned
+	NextExecutionDateOnOrBefore *time.Time
+
+	// keyset pagination: only instructions with an ID greater than AfterID are returned, ordered by ID
+	AfterID *uuid.UUID
+	Limit   int
+}
+
+type StandingInstructionUpdateOptions struct {
+	NewStatus                  *model.StandingInstructionStatus
+	NewOccurrenceCount         *int
+	NewNextExecutionDate       *time.Time
+	NewConsecutiveFailureCount *int
+	NewLastAttemptedAt         *time.Time
+
+	// When true, the next execution date is cleared, the instruction has no occurrences left
+	ClearNextExecutionDate bool
+}
+
+type StandingInstructionExecutionListOptions struct {
+	StandingInstructionID *uuid.UUID
+}
+
+type TransferReversalQueryOptions struct {
+	ID                    *uuid.UUID
+	OriginalTransactionID *uuid.UUID
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type TransferReversalListOptions struct {
+	Status *model.TransferReversalStatus
+
+	// keyset pagination: only reversals with an ID greater than AfterID are returned, ordered by ID
+	AfterID *uuid.UUID
+	Limit   int
+}
+
+type TransferReversalUpdateOptions struct {
+	NewStatus         *model.TransferReversalStatus
+	NewReversedAmount *int64
+	NewHeldAmount     *int64
+	NewCompletedAt    *time.Time
+}
+
+type PaymentOrderQueryOptions struct {
+	ID            *uuid.UUID
+	RailReference *string
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type PaymentOrderListOptions struct {
+	UserID *uuid.UUID
+	Status *model.PaymentOrderStatus
+}
+
+type PaymentOrderUpdateOptions struct {
+	NewStatus              *model.PaymentOrderStatus
+	NewRailReference       *string
+	NewReturnReason        *string
+	NewReturnTransactionID *uuid.UUID
+	NewOutcomeDueAt        *time.Time
+	NewSentAt              *time.Time
+	NewSettledAt           *time.Time
+	NewReturnedAt          *time.Time
+}
+
+type InboundPaymentFileQueryOptions struct {
+	ID *uuid.UUID
+}
+
+type InboundPaymentFileUpdateOptions struct {
+	NewRecordCount   *int
+	NewCreditedCount *int
+	NewSuspenseCount *int
+	NewRejectedCount *int
+}
+
+type InboundPaymentQueryOptions struct {
+	ID *uuid.UUID
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type InboundPaymentListOptions struct {
+	FileID *uuid.UUID
+	UTR    *string
+	Status *model.InboundPaymentStatus
+}
+
+type InboundPaymentUpdateOptions struct {
+	NewStatus        *model.InboundPaymentStatus
+	NewReason        *string
+	NewAccountID     *int64
+	NewTransactionID *uuid.UUID
+	NewResolvedBy    *uuid.UUID
+	NewResolvedAt    *time.Time
+}
+
+type BulkTransferQueryOptions struct {
+	ID *uuid.UUID
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type BulkTransferListOptions struct {
+	UserID        *uuid.UUID
+	FromAccountID *int64
+	Status        *model.BulkTransferStatus
+}
+
+type BulkTransferUpdateOptions struct {
+	NewStatus              *model.BulkTransferStatus
+	NewSucceededCount      *int
+	NewFailedCount         *int
+	NewSkippedCount        *int
+	NewStoppedAtLineNumber *int
+	NewConfirmedAt         *time.Time
+	NewCompletedAt         *time.Time
+}
+
+type BulkTransferItemQueryOptions struct {
+	ID *uuid.UUID
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type BulkTransferItemListOptions struct {
+	BulkTransferID *uuid.UUID
+	Status         *model.BulkTransferItemStatus
+}
+
+type BulkTransferItemUpdateOptions struct {
+	NewStatus        *model.BulkTransferItemStatus
+	NewReason        *string
+	NewTransactionID *uuid.UUID
+	NewProcessedAt   *time.Time
+}
+
+type JointTransferQueryOptions struct {
+	ID *uuid.UUID
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type JointTransferListOptions struct {
+	FromAccountID     *int64
+	InitiatedByUserID *uuid.UUID
+	Status            *model.JointTransferStatus
+}
+
+type JointTransferUpdateOptions struct {
+	NewStatus        *model.JointTransferStatus
+	NewTransactionID *uuid.UUID
+	NewExecutedAt    *time.Time
+}
+
+type JointTransferApprovalListOptions struct {
+	JointTransferID *uuid.UUID
+}
@@ -0,0 +1,195 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/pkg/clearingfile"
+	"github.com/skamranahmed/go-bank/pkg/iso20022"
+)
+
+// TransferLimits are the limits in effect on the transfers out of an account, amounts are in the smallest currency unit (paise for INR)
+type TransferLimits struct {
+	PerTransactionAmount int64
+	DailyCount           int
+	DailyAmount          int64
+	MonthlyAmount        int64
+}
+
+type SetTransferLimitsParams struct {
+	UserID      uuid.UUID
+	AccountType accountModel.AccountType
+
+	// limits left nil are not changed
+	PerTransactionAmount *int64
+	DailyCount           *int
+	DailyAmount          *int64
+	MonthlyAmount        *int64
+
+	// when false, a limit can only be lowered, raising the limits of a user is reserved to admins
+	AllowRaise bool
+}
+
+type CreateScheduledTransferParams struct {
+	UserID        uuid.UUID
+	FromAccountID int64
+	ToAccountID   int64
+	Amount        int64
+	ScheduledAt   time.Time
+}
+
+type CreateStandingInstructionParams struct {
+	UserID        uuid.UUID
+	FromAccountID int64
+	ToAccountID   int64
+	Amount        int64
+	Frequency     model.StandingInstructionFrequency
+
+	// only set for a MONTHLY instruction
+	DayOfMonth *int
+
+	StartDate time.Time
+
+	// at least one of them must be set
+	EndDate        *time.Time
+	MaxOccurrences *int
+}
+
+type ReverseTransferParams struct {
+	// TransactionID is the debit of the sender account of the transfer to reverse
+	TransactionID uuid.UUID
+
+	// InitiatedBy is the admin reversing the transfer
+	InitiatedBy uuid.UUID
+	Reason      string
+}
+
+type CreateExternalTransferParams struct {
+	UserID        uuid.UUID
+	FromAccountID int64
+	Amount        int64
+	Rail          model.Rail
+
+	BeneficiaryName          string
+	BeneficiaryAccountNumber string
+	BeneficiaryIFSC          string
+
+	// optional remittance information, stored on the debit of the customer account
+	Remittance accountTypes.Remittance
+}
+
+type CreateCrossCurrencyTransferParams struct {
+	UserID        uuid.UUID
+	FXQuoteID     uuid.UUID
+	FromAccountID int64
+	ToAccountID   int64
+
+	// Amount is debited from the from account in its currency, it must be the source amount of the quote
+	Amount int64
+
+	// optional remittance information, stored on the transactions of both the sender and the recipient
+	Remittance accountTypes.Remittance
+}
+
+// RailSubmission is what the rail acknowledges an order it has been sent with
+type RailSubmission struct {
+	// Reference is the reference assigned to the order by the rail (the UTR)
+	Reference string
+
+	// OutcomeDueAt is the time the order is settled or returned at
+	OutcomeDueAt time.Time
+}
+
+type IngestInboundPaymentFileParams struct {
+	FileName string
+	Format   clearingfile.Format
+	Content  []byte
+	Source   model.InboundPaymentFileSource
+
+	// UploadedBy is the admin who uploaded the file, nil for a file picked up from the inbound directory
+	UploadedBy *uuid.UUID
+}
+
+type AssignInboundPaymentParams struct {
+	InboundPaymentID uuid.UUID
+	AccountID        int64
+
+	// AssignedBy is the admin taking the payment out of the suspense queue
+	AssignedBy uuid.UUID
+}
+
+type ReturnInboundPaymentParams struct {
+	InboundPaymentID uuid.UUID
+	Reason           string
+
+	// ReturnedBy is the admin taking the payment out of the suspense queue
+	ReturnedBy uuid.UUID
+}
+
+// InboundPaymentAcknowledgment is the acknowledgment file of a clearing file, reporting the outcome of each of its records back to the clearing house
+type InboundPaymentAcknowledgment struct {
+	FileName string
+	Format   clearingfile.Format
+	Content  []byte
+}
+
+type CreateBulkTransferParams struct {
+	UserID        uuid.UUID
+	FromAccountID int64
+	FileName      string
+	Content       []byte
+
+	// StopOnInsufficientFunds stops the batch at the first row the account cannot afford, the rows after it are skipped
+	StopOnInsufficientFunds bool
+}
+
+// BulkTransferResult is the result file of a bulk transfer, reporting the outcome of each of its rows back to the customer
+type BulkTransferResult struct {
+	FileName string
+	Content  []byte
+}
+
+type ReceiveISO20022MessageParams struct {
+	Content []byte
+
+	// FileName is the name the message was received under, a pacs.008 message is recorded as an inbound payment file named after its MsgId when empty
+	FileName string
+	Source   model.InboundPaymentFileSource
+
+	// ReceivedBy is the admin who uploaded the message, nil for a message picked up from the inbound directory
+	ReceivedBy *uuid.UUID
+}
+
+// ISO20022MessageResult is the outcome of an ISO 20022 message received from the clearing house
+type ISO20022MessageResult struct {
+	MessageType iso20022.MessageType
+	MessageID   string
+
+	// ValidationErrors are the violations of the schema found in the message, when set nothing was processed
+	ValidationErrors iso20022.ValidationErrors
+
+	// InboundPaymentFile is set for a pacs.008 message, the file its payments were recorded under
+	InboundPaymentFile *model.InboundPaymentFile
+
+	// PaymentOrders is set for a pacs.004 message, the outbound external transfers it returned
+	PaymentOrders []model.PaymentOrder
+}
+
+type BuildPaymentOrderCreditTransferParams struct {
+	PaymentOrderID uuid.UUID
+
+	// DebtorName is the name of the customer who made the external transfer
+	DebtorName string
+}
+
+type CreateJointTransferParams struct {
+	// InitiatedByUserID is the holder of the account initiating the transfer, their approval is recorded along with it
+	InitiatedByUserID uuid.UUID
+	FromAccountID     int64
+	ToAccountID       int64
+	Amount            int64
+	Remittance        accountTypes.Remittance
+}
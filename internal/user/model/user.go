@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,4 +18,40 @@ type User struct {
 	Username  string    `bun:"username,notnull,unique,type:varchar(20)"`
 	Password  string    `bun:"password,notnull,type:varchar(255)"`
 	Email     string    `bun:"email,notnull,unique,type:varchar(100)"`
+	FirstName string    `bun:"first_name,notnull,type:varchar(50),default:''"`
+	LastName  string    `bun:"last_name,notnull,type:varchar(50),default:''"`
+
+	// Role of the user: CUSTOMER, ADMIN
+	Role UserRole `bun:"role,notnull,default:'CUSTOMER'"`
+}
+
+type UserRole string
+
+const (
+	Customer UserRole = "CUSTOMER"
+	Admin    UserRole = "ADMIN"
+)
+
+// FullName returns the first and last name of the user, or the username for users who signed up before names were collected
+func (u *User) FullName() string {
+	name := strings.TrimSpace(u.FirstName + " " + u.LastName)
+	if name == "" {
+		return u.Username
+	}
+	return name
+}
+
+/*
+MaskedName returns the name of the user with all but the first letter of every word masked, e.g. "S**** A****"
+
+It lets someone paying the user confirm they have the right person without learning their full name.
+The username is masked instead for users who signed up before names were collected.
+*/
+func (u *User) MaskedName() string {
+	words := strings.Fields(u.FullName())
+	for i, word := range words {
+		letters := []rune(word)
+		words[i] = string(letters[0]) + strings.Repeat("*", len(letters)-1)
+	}
+	return strings.Join(words, " ")
 }
@@ -51,6 +51,9 @@ func (r *userRepository) CreateUser(requestCtx context.Context, dbExecutor bun.I
 		ID:        user.ID,
 		Username:  user.Username,
 		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Role:      user.Role,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 		// Password omitted
@@ -143,6 +146,7 @@ func (r *userRepository) UpdateUser(requestCtx context.Context, dbExecutor bun.I
 		ID:        user.ID,
 		Username:  user.Username,
 		Email:     user.Email,
+		Role:      user.Role,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 		// Password omitted
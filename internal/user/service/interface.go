@@ -9,8 +9,9 @@ import (
 )
 
 type UserService interface {
-	CreateUser(requestCtx context.Context, dbExecutor bun.IDB, email string, password string, username string) (*types.CreateUserDto, error)
+	CreateUser(requestCtx context.Context, dbExecutor bun.IDB, email string, password string, username string, firstName string, lastName string) (*types.CreateUserDto, error)
 	GetUser(requestCtx context.Context, dbExecutor bun.IDB, options types.UserQueryOptions) (*model.User, error)
 	UpdateUser(requestCtx context.Context, dbExecutor bun.IDB, userID string, options types.UserUpdateOptions) (*model.User, error)
 	UpdatePassword(requestCtx context.Context, dbExecutor bun.IDB, userID string, currentPassword string, newPassword string) error
+	VerifyPassword(requestCtx context.Context, dbExecutor bun.IDB, userID string, password string) (bool, error)
 }
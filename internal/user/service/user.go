@@ -25,7 +25,7 @@ func NewUserService(db *bun.DB, userRepository repository.UserRepository) UserSe
 	}
 }
 
-func (u *userService) CreateUser(requestCtx context.Context, dbExecutor bun.IDB, email string, password string, username string) (*types.CreateUserDto, error) {
+func (u *userService) CreateUser(requestCtx context.Context, dbExecutor bun.IDB, email string, password string, username string, firstName string, lastName string) (*types.CreateUserDto, error) {
 	if dbExecutor == nil {
 		dbExecutor = u.db
 	}
@@ -40,9 +40,11 @@ func (u *userService) CreateUser(requestCtx context.Context, dbExecutor bun.IDB,
 	}
 
 	user := &model.User{
-		Email:    email,
-		Password: hashedPassword,
-		Username: username,
+		Email:     email,
+		Password:  hashedPassword,
+		Username:  username,
+		FirstName: firstName,
+		LastName:  lastName,
 	}
 
 	user, err = u.userRepository.CreateUser(requestCtx, dbExecutor, user)
@@ -84,23 +86,10 @@ func (s *userService) UpdatePassword(requestCtx context.Context, dbExecutor bun.
 		dbExecutor = s.db
 	}
 
-	// get user with password field
-	user, err := s.userRepository.GetUser(requestCtx, dbExecutor, types.UserQueryOptions{
-		ID:      &userID,
-		Columns: []string{"id", "password"},
-	})
-	if err != nil {
-		return err
-	}
-
 	// verify current password
-	doesPasswordMatch, err := argon2id.ComparePasswordAndHash(currentPassword, user.Password)
+	doesPasswordMatch, err := s.VerifyPassword(requestCtx, dbExecutor, userID, currentPassword)
 	if err != nil {
-		logger.Error(requestCtx, "Error comparing password and hash, error: %v", err)
-		return &server.ApiError{
-			HttpStatusCode: http.StatusInternalServerError,
-			Message:        "Unable to process your request. Please try again later.",
-		}
+		return err
 	}
 
 	if !doesPasswordMatch {
@@ -130,3 +119,30 @@ func (s *userService) UpdatePassword(requestCtx context.Context, dbExecutor bun.
 
 	return nil
 }
+
+// VerifyPassword reports whether the password is the current password of the user
+func (s *userService) VerifyPassword(requestCtx context.Context, dbExecutor bun.IDB, userID string, password string) (bool, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	// get user with password field
+	user, err := s.userRepository.GetUser(requestCtx, dbExecutor, types.UserQueryOptions{
+		ID:      &userID,
+		Columns: []string{"id", "password"},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	doesPasswordMatch, err := argon2id.ComparePasswordAndHash(password, user.Password)
+	if err != nil {
+		logger.Error(requestCtx, "Error comparing password and hash, error: %v", err)
+		return false, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "Unable to process your request. Please try again later.",
+		}
+	}
+
+	return doesPasswordMatch, nil
+}
@@ -13,6 +13,8 @@ type CreateUserDto struct {
 	UpdatedAt time.Time `json:"updated_at"`
 	Email     string    `json:"email"`
 	Username  string    `json:"username"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
 }
 
 func TransformToCreateUserDto(user *model.User) *CreateUserDto {
@@ -22,6 +24,8 @@ func TransformToCreateUserDto(user *model.User) *CreateUserDto {
 		UpdatedAt: user.UpdatedAt,
 		Email:     user.Email,
 		Username:  user.Username,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
 	}
 }
 
@@ -31,6 +35,8 @@ type GetMeDto struct {
 	UpdatedAt time.Time `json:"updated_at"`
 	Email     string    `json:"email"`
 	Username  string    `json:"username"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
 }
 
 type GetMeResponse struct {
@@ -44,6 +50,8 @@ func TransformToGetMeDto(user *model.User) *GetMeDto {
 		UpdatedAt: user.UpdatedAt,
 		Email:     user.Email,
 		Username:  user.Username,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
 	}
 }
 
@@ -61,6 +69,8 @@ type UpdateUserDto struct {
 	UpdatedAt time.Time `json:"updated_at"`
 	Email     string    `json:"email"`
 	Username  string    `json:"username"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
 }
 
 type UpdateUserResponse struct {
@@ -74,6 +84,8 @@ func TransformToUpdateUserDto(user *model.User) *UpdateUserDto {
 		UpdatedAt: user.UpdatedAt,
 		Email:     user.Email,
 		Username:  user.Username,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
 	}
 }
 
@@ -0,0 +1,15 @@
+package controller
+
+import "github.com/gin-gonic/gin"
+
+type VPAController interface {
+	RegisterVPA(ginCtx *gin.Context)
+	GetVPAs(ginCtx *gin.Context)
+	UpdateVPA(ginCtx *gin.Context)
+	DeleteVPA(ginCtx *gin.Context)
+
+	CreateCollectRequest(ginCtx *gin.Context)
+	GetCollectRequests(ginCtx *gin.Context)
+	ApproveCollectRequest(ginCtx *gin.Context)
+	DeclineCollectRequest(ginCtx *gin.Context)
+}
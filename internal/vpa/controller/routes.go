@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/skamranahmed/go-bank/cmd/middleware"
+	authenticationService "github.com/skamranahmed/go-bank/internal/authentication/service"
+	vpaService "github.com/skamranahmed/go-bank/internal/vpa/service"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/uptrace/bun"
+)
+
+type Dependency struct {
+	Db                    *bun.DB
+	AuthenticationService authenticationService.AuthenticationService
+	VPAService            vpaService.VPAService
+	TaskEnqueuer          tasksHelper.TaskEnqueuer
+}
+
+func Register(router *gin.Engine, dependency Dependency) {
+	vpaController := newVPAController(dependency)
+	router.POST("/v1/vpas", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), vpaController.RegisterVPA)
+	router.GET("/v1/vpas", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), vpaController.GetVPAs)
+	router.PATCH("/v1/vpas/:vpa_id", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), vpaController.UpdateVPA)
+	router.DELETE("/v1/vpas/:vpa_id", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), vpaController.DeleteVPA)
+	router.POST("/v1/collect-requests", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), vpaController.CreateCollectRequest)
+	router.GET("/v1/collect-requests", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), vpaController.GetCollectRequests)
+	router.POST("/v1/collect-requests/:collect_request_id/approve", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), vpaController.ApproveCollectRequest)
+	router.POST("/v1/collect-requests/:collect_request_id/decline", middleware.AuthMiddleware(middleware.AuthMandatory, dependency.AuthenticationService), vpaController.DeclineCollectRequest)
+}
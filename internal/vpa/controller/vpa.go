@@ -0,0 +1,370 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/middleware"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	vpaModel "github.com/skamranahmed/go-bank/internal/vpa/model"
+	vpaService "github.com/skamranahmed/go-bank/internal/vpa/service"
+	vpaTasks "github.com/skamranahmed/go-bank/internal/vpa/tasks"
+	"github.com/skamranahmed/go-bank/internal/vpa/types"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/uptrace/bun"
+)
+
+type vpaController struct {
+	db           *bun.DB
+	vpaService   vpaService.VPAService
+	taskEnqueuer tasksHelper.TaskEnqueuer
+}
+
+func newVPAController(dependency Dependency) VPAController {
+	return &vpaController{
+		db:           dependency.Db,
+		vpaService:   dependency.VPAService,
+		taskEnqueuer: dependency.TaskEnqueuer,
+	}
+}
+
+func (c *vpaController) RegisterVPA(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.RegisterVPARequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	vpa, err := c.vpaService.RegisterVPA(requestCtx, nil, types.RegisterVPAParams{
+		UserID:    userUUID,
+		Address:   payload.Data.Address,
+		AccountID: payload.Data.AccountID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	vpaDto := types.TransformToVPADto(vpa)
+	server.SendSuccessResponse(ginCtx, http.StatusCreated, types.RegisterVPAResponse{
+		Data: *vpaDto,
+	})
+}
+
+func (c *vpaController) GetVPAs(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	vpas, err := c.vpaService.ListVPAs(requestCtx, nil, types.VPAListOptions{
+		UserID: &userUUID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	vpaDtos := types.TransformToVPADtoList(vpas)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetVPAsResponse{
+		Data: vpaDtos,
+	})
+}
+
+func (c *vpaController) UpdateVPA(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	vpa, ok := c.getOwnedVPA(ginCtx, userUUID)
+	if !ok {
+		return
+	}
+
+	var payload types.UpdateVPARequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	vpa, err := c.vpaService.LinkVPAAccount(requestCtx, nil, vpa.ID, payload.Data.AccountID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	vpaDto := types.TransformToVPADto(vpa)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.UpdateVPAResponse{
+		Data: *vpaDto,
+	})
+}
+
+func (c *vpaController) DeleteVPA(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	vpa, ok := c.getOwnedVPA(ginCtx, userUUID)
+	if !ok {
+		return
+	}
+
+	err := c.vpaService.DeleteVPA(requestCtx, nil, vpa.ID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	server.SendSuccessResponse(ginCtx, http.StatusNoContent, nil)
+}
+
+func (c *vpaController) CreateCollectRequest(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var payload types.CreateCollectRequestRequest
+	isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+	if !isSuccess {
+		return
+	}
+
+	collectRequest, err := c.vpaService.CreateCollectRequest(requestCtx, nil, types.CreateCollectRequestParams{
+		PayeeUserID:  userUUID,
+		PayeeAddress: payload.Data.PayeeAddress,
+		PayerAddress: payload.Data.PayerAddress,
+		Amount:       *payload.Data.Amount,
+		Note:         payload.Data.Note,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// let the payer know that they have been asked for money
+	err = c.taskEnqueuer.Enqueue(requestCtx, vpaTasks.NewSendCollectRequestNotificationTask(collectRequest.ID.String(), collectRequest.PayerUserID.String()), nil, nil)
+	if err != nil {
+		logger.Error(requestCtx, "Unable to enqueue SendCollectRequestNotificationTask for collectRequestID: %s, error: %+v", collectRequest.ID, err)
+	}
+
+	// transform to DTO and return response
+	collectRequestDto := types.TransformToCollectRequestDto(collectRequest)
+	server.SendSuccessResponse(ginCtx, http.StatusCreated, types.CollectRequestResponse{
+		Data: *collectRequestDto,
+	})
+}
+
+func (c *vpaController) GetCollectRequests(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var query types.GetCollectRequestsRequestQuery
+	isSuccess := server.BindAndValidateIncomingRequestQuery(ginCtx, &query)
+	if !isSuccess {
+		return
+	}
+
+	options := types.CollectRequestListOptions{}
+	if query.Role == types.CollectRequestRolePayee {
+		options.PayeeUserID = &userUUID
+	} else {
+		options.PayerUserID = &userUUID
+	}
+	if query.Status != "" {
+		status := vpaModel.CollectRequestStatus(query.Status)
+		options.Status = &status
+	}
+
+	collectRequests, err := c.vpaService.ListCollectRequests(requestCtx, nil, options)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	collectRequestDtos := types.TransformToCollectRequestDtoList(collectRequests)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.GetCollectRequestsResponse{
+		Data: collectRequestDtos,
+	})
+}
+
+func (c *vpaController) ApproveCollectRequest(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	collectRequestID, ok := getCollectRequestID(ginCtx)
+	if !ok {
+		return
+	}
+
+	// the request body is optional, it is only needed to pay from an account other than the one linked to the VPA
+	var payload types.ApproveCollectRequestRequest
+	if ginCtx.Request.ContentLength != 0 {
+		isSuccess := server.BindAndValidateIncomingRequestBody(ginCtx, &payload)
+		if !isSuccess {
+			return
+		}
+	}
+
+	var fromAccountID *int64
+	if payload.Data.FromAccountID != 0 {
+		fromAccountID = &payload.Data.FromAccountID
+	}
+
+	// the transfer and the status update of the collect request must succeed or fail together
+	var collectRequest *vpaModel.CollectRequest
+	err := database.RunInTransaction(requestCtx, "approveCollectRequest", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		collectRequest, err = c.vpaService.ApproveCollectRequest(txCtx, tx, types.ApproveCollectRequestParams{
+			CollectRequestID: collectRequestID,
+			PayerUserID:      userUUID,
+			FromAccountID:    fromAccountID,
+		})
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	collectRequestDto := types.TransformToCollectRequestDto(collectRequest)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.CollectRequestResponse{
+		Data: *collectRequestDto,
+	})
+}
+
+func (c *vpaController) DeclineCollectRequest(ginCtx *gin.Context) {
+	requestCtx := ginCtx.Request.Context()
+
+	userUUID, ok := getAuthenticatedUserID(ginCtx)
+	if !ok {
+		return
+	}
+
+	collectRequestID, ok := getCollectRequestID(ginCtx)
+	if !ok {
+		return
+	}
+
+	var collectRequest *vpaModel.CollectRequest
+	err := database.RunInTransaction(requestCtx, "declineCollectRequest", c.db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		collectRequest, err = c.vpaService.DeclineCollectRequest(txCtx, tx, types.RespondToCollectRequestParams{
+			CollectRequestID: collectRequestID,
+			PayerUserID:      userUUID,
+		})
+		return err
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return
+	}
+
+	// transform to DTO and return response
+	collectRequestDto := types.TransformToCollectRequestDto(collectRequest)
+	server.SendSuccessResponse(ginCtx, http.StatusOK, types.CollectRequestResponse{
+		Data: *collectRequestDto,
+	})
+}
+
+// getOwnedVPA fetches the VPA referenced in the path, sending the error response when it is missing or not held by the user
+func (c *vpaController) getOwnedVPA(ginCtx *gin.Context, userUUID uuid.UUID) (*vpaModel.VPA, bool) {
+	vpaID, err := uuid.Parse(ginCtx.Param("vpa_id"))
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid VPA ID",
+		})
+		return nil, false
+	}
+
+	vpa, err := c.vpaService.GetVPA(ginCtx.Request.Context(), nil, types.VPAQueryOptions{
+		ID: &vpaID,
+	})
+	if err != nil {
+		server.SendErrorResponse(ginCtx, err)
+		return nil, false
+	}
+
+	// authorization check: verify VPA belongs to authenticated user
+	if vpa.UserID != userUUID {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You do not have permission to access this VPA",
+		})
+		return nil, false
+	}
+
+	return vpa, true
+}
+
+// getCollectRequestID parses the ID of the collect request referenced in the path, sending the error response when it is invalid
+func getCollectRequestID(ginCtx *gin.Context) (uuid.UUID, bool) {
+	collectRequestID, err := uuid.Parse(ginCtx.Param("collect_request_id"))
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid collect request ID",
+		})
+		return uuid.Nil, false
+	}
+
+	return collectRequestID, true
+}
+
+// getAuthenticatedUserID extracts the ID of the authenticated user from the request context, sending the error response when it is missing
+func getAuthenticatedUserID(ginCtx *gin.Context) (uuid.UUID, bool) {
+	userID, ok := ginCtx.Request.Context().Value(middleware.ContextUserIDKey).(string)
+	if !ok || userID == "" {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusUnauthorized,
+			Message:        "User not authenticated",
+		})
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		server.SendErrorResponse(ginCtx, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid user ID",
+		})
+		return uuid.Nil, false
+	}
+
+	return userUUID, true
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/uptrace/bun"
+)
+
+// CollectRequest is a request for money sent by a payee to the VPA of a payer, the money moves only once the payer approves it
+type CollectRequest struct {
+	bun.BaseModel `bun:"table:collect_requests"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// foreign key to "users" table, the user requesting the money
+	PayeeUserID uuid.UUID       `bun:"payee_user_id,notnull,type:uuid"`
+	PayeeUser   *userModel.User `bun:"rel:belongs-to,join:payee_user_id=id"`
+
+	// PayeeAddress is the VPA of the payee the request was sent from, the money is credited to the account it was linked to at the time
+	PayeeAddress   string                `bun:"payee_address,notnull,type:varchar(71)"`
+	PayeeAccountID int64                 `bun:"payee_account_id,notnull"`
+	PayeeAccount   *accountModel.Account `bun:"rel:belongs-to,join:payee_account_id=id"`
+
+	// foreign key to "users" table, the user asked to pay
+	PayerUserID  uuid.UUID       `bun:"payer_user_id,notnull,type:uuid"`
+	PayerUser    *userModel.User `bun:"rel:belongs-to,join:payer_user_id=id"`
+	PayerAddress string          `bun:"payer_address,notnull,type:varchar(71)"`
+
+	// Amount is stored in the smallest currency unit (paise for INR)
+	Amount int64   `bun:"amount,notnull"`
+	Note   *string `bun:"note,type:varchar(140)"`
+
+	Status CollectRequestStatus `bun:"status,notnull,default:'PENDING'"`
+
+	// ExpiresAt is the time the request expires at if the payer has not answered it, it is then marked expired by the worker
+	ExpiresAt time.Time `bun:"expires_at,notnull"`
+
+	// foreign key to "transactions" table, the debit transaction of the payer account once the request is approved
+	TransactionID *uuid.UUID                `bun:"transaction_id,type:uuid"`
+	Transaction   *accountModel.Transaction `bun:"rel:belongs-to,join:transaction_id=id"`
+
+	RespondedAt *time.Time `bun:"responded_at"`
+}
+
+// IsExpired reports whether the request can no longer be answered, even if the worker has not marked it expired yet
+func (c *CollectRequest) IsExpired(at time.Time) bool {
+	return !at.Before(c.ExpiresAt)
+}
+
+type CollectRequestStatus string
+
+const (
+	CollectRequestPending  CollectRequestStatus = "PENDING"
+	CollectRequestApproved CollectRequestStatus = "APPROVED" // the payer approved the request and the money was transferred
+	CollectRequestDeclined CollectRequestStatus = "DECLINED"
+	CollectRequestExpired  CollectRequestStatus = "EXPIRED" // the payer did not answer the request in time
+)
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	"github.com/uptrace/bun"
+)
+
+// VPA is a virtual payment address, a handle like alice@gobank a user is paid by instead of the number of one of their accounts
+type VPA struct {
+	bun.BaseModel `bun:"table:vpas"`
+
+	ID        uuid.UUID `bun:"id,pk,notnull,type:uuid,default:gen_random_uuid()"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+
+	// Address is always stored in lower case, so that it is unique regardless of the case it is typed in
+	Address string `bun:"address,notnull,unique,type:varchar(71)"`
+
+	// foreign key to "users" table, the holder of the VPA
+	UserID uuid.UUID       `bun:"user_id,notnull,type:uuid"`
+	User   *userModel.User `bun:"rel:belongs-to,join:user_id=id"`
+
+	// foreign key to "accounts" table, the account the payments to the VPA are credited to
+	AccountID int64                 `bun:"account_id,notnull"`
+	Account   *accountModel.Account `bun:"rel:belongs-to,join:account_id=id"`
+}
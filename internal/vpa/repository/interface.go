@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/vpa/model"
+	"github.com/skamranahmed/go-bank/internal/vpa/types"
+	"github.com/uptrace/bun"
+)
+
+type VPARepository interface {
+	CreateVPA(requestCtx context.Context, dbExecutor bun.IDB, vpa *model.VPA) (*model.VPA, error)
+	GetVPA(requestCtx context.Context, dbExecutor bun.IDB, options types.VPAQueryOptions) (*model.VPA, error)
+	ListVPAs(requestCtx context.Context, dbExecutor bun.IDB, options types.VPAListOptions) ([]model.VPA, error)
+	CountVPAs(requestCtx context.Context, dbExecutor bun.IDB, options types.VPAListOptions) (int, error)
+	UpdateVPA(requestCtx context.Context, dbExecutor bun.IDB, vpaID uuid.UUID, options types.VPAUpdateOptions) (*model.VPA, error)
+	DeleteVPA(requestCtx context.Context, dbExecutor bun.IDB, vpaID uuid.UUID) error
+
+	CreateCollectRequest(requestCtx context.Context, dbExecutor bun.IDB, collectRequest *model.CollectRequest) (*model.CollectRequest, error)
+	GetCollectRequest(requestCtx context.Context, dbExecutor bun.IDB, options types.CollectRequestQueryOptions) (*model.CollectRequest, error)
+	ListCollectRequests(requestCtx context.Context, dbExecutor bun.IDB, options types.CollectRequestListOptions) ([]model.CollectRequest, error)
+	UpdateCollectRequest(requestCtx context.Context, dbExecutor bun.IDB, collectRequestID uuid.UUID, options types.CollectRequestUpdateOptions) (*model.CollectRequest, error)
+	ExpireCollectRequests(requestCtx context.Context, dbExecutor bun.IDB, expiryTime time.Time) ([]model.CollectRequest, error)
+}
@@ -0,0 +1,340 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/internal/vpa/model"
+	"github.com/skamranahmed/go-bank/internal/vpa/types"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+type vpaRepository struct {
+	db *bun.DB
+}
+
+func NewVPARepository(db *bun.DB) VPARepository {
+	return &vpaRepository{
+		db: db,
+	}
+}
+
+func (r *vpaRepository) CreateVPA(requestCtx context.Context, dbExecutor bun.IDB, vpa *model.VPA) (*model.VPA, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	// an address already registered by anyone is skipped instead of failing the insert, so that it can be reported as a conflict
+	err := dbExecutor.NewInsert().
+		Model(vpa).
+		On("CONFLICT (address) DO NOTHING").
+		Returning("*").
+		Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusConflict,
+				Message:        "This VPA is already taken",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while creating VPA for userID: %s, error: %+v", vpa.UserID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't register the VPA at the moment. Please try again later.",
+		}
+	}
+
+	return vpa, nil
+}
+
+func (r *vpaRepository) GetVPA(requestCtx context.Context, dbExecutor bun.IDB, options types.VPAQueryOptions) (*model.VPA, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var vpa model.VPA
+	query := dbExecutor.NewSelect().Model(&vpa)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+	if options.Address != nil {
+		query = query.Where("address = ?", *options.Address)
+	}
+	if options.UserID != nil {
+		query = query.Where("user_id = ?", *options.UserID)
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "VPA not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding VPA with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the VPA at the moment. Please try again later.",
+		}
+	}
+
+	return &vpa, nil
+}
+
+func (r *vpaRepository) ListVPAs(requestCtx context.Context, dbExecutor bun.IDB, options types.VPAListOptions) ([]model.VPA, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var vpas []model.VPA
+	query := dbExecutor.NewSelect().Model(&vpas)
+
+	// dynamically construct the query based on which fields are set
+	if options.UserID != nil {
+		query = query.Where("user_id = ?", *options.UserID)
+	}
+
+	err := query.Order("address ASC").Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing VPAs with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the VPAs at the moment. Please try again later.",
+		}
+	}
+
+	return vpas, nil
+}
+
+func (r *vpaRepository) CountVPAs(requestCtx context.Context, dbExecutor bun.IDB, options types.VPAListOptions) (int, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	query := dbExecutor.NewSelect().Model((*model.VPA)(nil))
+
+	// dynamically construct the query based on which fields are set
+	if options.UserID != nil {
+		query = query.Where("user_id = ?", *options.UserID)
+	}
+
+	count, err := query.Count(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while counting VPAs with options: %+v, error: %+v", options, err)
+		return 0, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the VPAs at the moment. Please try again later.",
+		}
+	}
+
+	return count, nil
+}
+
+func (r *vpaRepository) UpdateVPA(requestCtx context.Context, dbExecutor bun.IDB, vpaID uuid.UUID, options types.VPAUpdateOptions) (*model.VPA, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var vpa model.VPA
+	query := dbExecutor.NewUpdate().Model(&vpa)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewAccountID != nil {
+		query = query.Set("account_id = ?", *options.NewAccountID)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", vpaID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating VPA with ID: %s, error: %+v", vpaID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the VPA at the moment. Please try again later.",
+		}
+	}
+
+	return &vpa, nil
+}
+
+func (r *vpaRepository) DeleteVPA(requestCtx context.Context, dbExecutor bun.IDB, vpaID uuid.UUID) error {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewDelete().
+		Model((*model.VPA)(nil)).
+		Where("id = ?", vpaID).
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while deleting VPA with ID: %s, error: %+v", vpaID, err)
+		return &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't delete the VPA at the moment. Please try again later.",
+		}
+	}
+
+	return nil
+}
+
+func (r *vpaRepository) CreateCollectRequest(requestCtx context.Context, dbExecutor bun.IDB, collectRequest *model.CollectRequest) (*model.CollectRequest, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	_, err := dbExecutor.NewInsert().
+		Model(collectRequest).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while creating collect request for payeeUserID: %s, error: %+v", collectRequest.PayeeUserID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't send the collect request at the moment. Please try again later.",
+		}
+	}
+
+	return collectRequest, nil
+}
+
+func (r *vpaRepository) GetCollectRequest(requestCtx context.Context, dbExecutor bun.IDB, options types.CollectRequestQueryOptions) (*model.CollectRequest, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var collectRequest model.CollectRequest
+	query := dbExecutor.NewSelect().Model(&collectRequest)
+
+	// dynamically construct the query based on which fields are set
+	if options.ID != nil {
+		query = query.Where("id = ?", *options.ID)
+	}
+
+	// apply row locking if requested
+	if options.ForUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(requestCtx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &server.ApiError{
+				HttpStatusCode: http.StatusNotFound,
+				Message:        "Collect request not found",
+			}
+		}
+
+		logger.Error(requestCtx, "Error while finding collect request with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the collect request at the moment. Please try again later.",
+		}
+	}
+
+	return &collectRequest, nil
+}
+
+func (r *vpaRepository) ListCollectRequests(requestCtx context.Context, dbExecutor bun.IDB, options types.CollectRequestListOptions) ([]model.CollectRequest, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var collectRequests []model.CollectRequest
+	query := dbExecutor.NewSelect().Model(&collectRequests)
+
+	// dynamically construct the query based on which fields are set
+	if options.PayeeUserID != nil {
+		query = query.Where("payee_user_id = ?", *options.PayeeUserID)
+	}
+	if options.PayerUserID != nil {
+		query = query.Where("payer_user_id = ?", *options.PayerUserID)
+	}
+	if options.Status != nil {
+		query = query.Where("status = ?", *options.Status)
+	}
+
+	err := query.Order("created_at DESC").Scan(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while listing collect requests with options: %+v, error: %+v", options, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't fetch the collect requests at the moment. Please try again later.",
+		}
+	}
+
+	return collectRequests, nil
+}
+
+func (r *vpaRepository) UpdateCollectRequest(requestCtx context.Context, dbExecutor bun.IDB, collectRequestID uuid.UUID, options types.CollectRequestUpdateOptions) (*model.CollectRequest, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var collectRequest model.CollectRequest
+	query := dbExecutor.NewUpdate().Model(&collectRequest)
+
+	// dynamically construct the query based on which fields are set
+	if options.NewStatus != nil {
+		query = query.Set("status = ?", *options.NewStatus)
+	}
+	if options.NewTransactionID != nil {
+		query = query.Set("transaction_id = ?", *options.NewTransactionID)
+	}
+	if options.NewRespondedAt != nil {
+		query = query.Set("responded_at = ?", *options.NewRespondedAt)
+	}
+
+	_, err := query.
+		Set("updated_at = NOW()").
+		Where("id = ?", collectRequestID).
+		Returning("*").
+		Exec(requestCtx)
+	if err != nil {
+		logger.Error(requestCtx, "Error while updating collect request with ID: %s, error: %+v", collectRequestID, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't update the collect request at the moment. Please try again later.",
+		}
+	}
+
+	return &collectRequest, nil
+}
+
+// ExpireCollectRequests marks every pending collect request whose expiry time has passed as expired, in a single statement
+func (r *vpaRepository) ExpireCollectRequests(requestCtx context.Context, dbExecutor bun.IDB, expiryTime time.Time) ([]model.CollectRequest, error) {
+	if dbExecutor == nil {
+		dbExecutor = r.db
+	}
+
+	var collectRequests []model.CollectRequest
+	_, err := dbExecutor.NewUpdate().
+		Model((*model.CollectRequest)(nil)).
+		Set("status = ?", model.CollectRequestExpired).
+		Set("updated_at = NOW()").
+		Where("status = ?", model.CollectRequestPending).
+		Where("expires_at <= ?", expiryTime).
+		Returning("*").
+		Exec(requestCtx, &collectRequests)
+	if err != nil {
+		logger.Error(requestCtx, "Error while expiring collect requests due by: %s, error: %+v", expiryTime, err)
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusInternalServerError,
+			Message:        "We couldn't expire the collect requests at the moment. Please try again later.",
+		}
+	}
+
+	return collectRequests, nil
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/vpa/model"
+	"github.com/skamranahmed/go-bank/internal/vpa/types"
+	"github.com/uptrace/bun"
+)
+
+type VPAService interface {
+	RegisterVPA(requestCtx context.Context, dbExecutor bun.IDB, params types.RegisterVPAParams) (*model.VPA, error)
+	GetVPA(requestCtx context.Context, dbExecutor bun.IDB, options types.VPAQueryOptions) (*model.VPA, error)
+	ListVPAs(requestCtx context.Context, dbExecutor bun.IDB, options types.VPAListOptions) ([]model.VPA, error)
+	LinkVPAAccount(requestCtx context.Context, dbExecutor bun.IDB, vpaID uuid.UUID, accountID int64) (*model.VPA, error)
+	DeleteVPA(requestCtx context.Context, dbExecutor bun.IDB, vpaID uuid.UUID) error
+	ResolveVPA(requestCtx context.Context, dbExecutor bun.IDB, address string) (*model.VPA, error)
+
+	CreateCollectRequest(requestCtx context.Context, dbExecutor bun.IDB, params types.CreateCollectRequestParams) (*model.CollectRequest, error)
+	GetCollectRequest(requestCtx context.Context, dbExecutor bun.IDB, options types.CollectRequestQueryOptions) (*model.CollectRequest, error)
+	ListCollectRequests(requestCtx context.Context, dbExecutor bun.IDB, options types.CollectRequestListOptions) ([]model.CollectRequest, error)
+	ApproveCollectRequest(requestCtx context.Context, dbExecutor bun.IDB, params types.ApproveCollectRequestParams) (*model.CollectRequest, error)
+	DeclineCollectRequest(requestCtx context.Context, dbExecutor bun.IDB, params types.RespondToCollectRequestParams) (*model.CollectRequest, error)
+	ExpireCollectRequests(requestCtx context.Context, dbExecutor bun.IDB, expiryTime time.Time) ([]model.CollectRequest, error)
+}
@@ -0,0 +1,449 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	"github.com/skamranahmed/go-bank/config"
+	accountService "github.com/skamranahmed/go-bank/internal/account/service"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	transferService "github.com/skamranahmed/go-bank/internal/transfer/service"
+	"github.com/skamranahmed/go-bank/internal/vpa/model"
+	"github.com/skamranahmed/go-bank/internal/vpa/repository"
+	"github.com/skamranahmed/go-bank/internal/vpa/types"
+	"github.com/skamranahmed/go-bank/pkg/accountnumber"
+	"github.com/uptrace/bun"
+)
+
+type vpaService struct {
+	db              *bun.DB
+	vpaRepository   repository.VPARepository
+	accountService  accountService.AccountService
+	transferService transferService.TransferService
+	vpaConfig       config.VPAConfig
+}
+
+func NewVPAService(
+	db *bun.DB,
+	vpaRepository repository.VPARepository,
+	accountService accountService.AccountService,
+	transferService transferService.TransferService,
+	vpaConfig config.VPAConfig,
+) VPAService {
+	return &vpaService{
+		db:              db,
+		vpaRepository:   vpaRepository,
+		accountService:  accountService,
+		transferService: transferService,
+		vpaConfig:       vpaConfig,
+	}
+}
+
+/*
+RegisterVPA registers the address as a VPA of the user, the payments to it are credited to the given account
+
+The address must end with the handle of this bank and must not be taken by anyone, it is stored in lower case.
+A user can register up to the configured number of VPAs.
+*/
+func (s *vpaService) RegisterVPA(requestCtx context.Context, dbExecutor bun.IDB, params types.RegisterVPAParams) (*model.VPA, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	address, err := s.parseAddress(params.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.verifyLinkableAccount(requestCtx, dbExecutor, params.UserID, params.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.vpaRepository.CountVPAs(requestCtx, dbExecutor, types.VPAListOptions{
+		UserID: &params.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if count >= s.vpaConfig.MaxPerUser {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("You can register at most %d VPAs", s.vpaConfig.MaxPerUser),
+		}
+	}
+
+	return s.vpaRepository.CreateVPA(requestCtx, dbExecutor, &model.VPA{
+		Address:   address,
+		UserID:    params.UserID,
+		AccountID: params.AccountID,
+	})
+}
+
+func (s *vpaService) GetVPA(requestCtx context.Context, dbExecutor bun.IDB, options types.VPAQueryOptions) (*model.VPA, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.vpaRepository.GetVPA(requestCtx, dbExecutor, options)
+}
+
+func (s *vpaService) ListVPAs(requestCtx context.Context, dbExecutor bun.IDB, options types.VPAListOptions) ([]model.VPA, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.vpaRepository.ListVPAs(requestCtx, dbExecutor, options)
+}
+
+// LinkVPAAccount changes the account the payments to the VPA are credited to, the account must be held by the holder of the VPA
+func (s *vpaService) LinkVPAAccount(requestCtx context.Context, dbExecutor bun.IDB, vpaID uuid.UUID, accountID int64) (*model.VPA, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	vpa, err := s.vpaRepository.GetVPA(requestCtx, dbExecutor, types.VPAQueryOptions{
+		ID: &vpaID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.verifyLinkableAccount(requestCtx, dbExecutor, vpa.UserID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.vpaRepository.UpdateVPA(requestCtx, dbExecutor, vpa.ID, types.VPAUpdateOptions{
+		NewAccountID: &accountID,
+	})
+}
+
+func (s *vpaService) DeleteVPA(requestCtx context.Context, dbExecutor bun.IDB, vpaID uuid.UUID) error {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.vpaRepository.DeleteVPA(requestCtx, dbExecutor, vpaID)
+}
+
+// ResolveVPA returns the VPA registered at the address, which must be a VPA of this bank
+func (s *vpaService) ResolveVPA(requestCtx context.Context, dbExecutor bun.IDB, address string) (*model.VPA, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	address, err := s.parseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.vpaRepository.GetVPA(requestCtx, dbExecutor, types.VPAQueryOptions{
+		Address: &address,
+	})
+}
+
+// parseAddress returns the address in lower case, verifying it is a VPA of this bank
+func (s *vpaService) parseAddress(address string) (string, error) {
+	vpaComponents, err := accountnumber.ParseVPA(address)
+	if err != nil {
+		return "", &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Invalid VPA",
+		}
+	}
+
+	if vpaComponents.Provider != s.vpaConfig.Handle {
+		return "", &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        fmt.Sprintf("VPA does not belong to this bank, it must end with @%s", s.vpaConfig.Handle),
+		}
+	}
+
+	return vpaComponents.Address(), nil
+}
+
+// verifyLinkableAccount verifies that the account is a savings or current account the user is a holder of, so that it can receive the payments to a VPA
+func (s *vpaService) verifyLinkableAccount(requestCtx context.Context, dbExecutor bun.IDB, userID uuid.UUID, accountID int64) error {
+	account, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &accountID,
+		Columns:   []string{"id", "user_id", "type"},
+	})
+	if err != nil {
+		return err
+	}
+
+	// a joint holder can link the account too, the payments to the VPA are credited to the account whoever holds it
+	isAccountHolder, err := s.accountService.IsAccountHolder(requestCtx, dbExecutor, account, userID)
+	if err != nil {
+		return err
+	}
+	if !isAccountHolder {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You can only link your own account to a VPA",
+		}
+	}
+
+	if !account.Type.AllowsTransfers() {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Only a savings or current account can be linked to a VPA",
+		}
+	}
+
+	return nil
+}
+
+/*
+CreateCollectRequest sends a request for money from the VPA of the payee to the VPA of the payer
+
+The money is credited to the account linked to the payee's VPA at the time of the request, even if the VPA is relinked before the payer approves it.
+A request the payer does not answer within the configured expiry is marked expired by the worker.
+*/
+func (s *vpaService) CreateCollectRequest(requestCtx context.Context, dbExecutor bun.IDB, params types.CreateCollectRequestParams) (*model.CollectRequest, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	payeeVPA, err := s.ResolveVPA(requestCtx, dbExecutor, params.PayeeAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if payeeVPA.UserID != params.PayeeUserID {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You can only request money to your own VPA",
+		}
+	}
+
+	payerVPA, err := s.ResolveVPA(requestCtx, dbExecutor, params.PayerAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if payerVPA.UserID == params.PayeeUserID {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "You cannot request money from your own VPA",
+		}
+	}
+
+	collectRequest := &model.CollectRequest{
+		PayeeUserID:    payeeVPA.UserID,
+		PayeeAddress:   payeeVPA.Address,
+		PayeeAccountID: payeeVPA.AccountID,
+		PayerUserID:    payerVPA.UserID,
+		PayerAddress:   payerVPA.Address,
+		Amount:         params.Amount,
+		ExpiresAt:      time.Now().UTC().Add(time.Duration(s.vpaConfig.CollectRequestExpiryInMinutes) * time.Minute),
+	}
+	if params.Note != "" {
+		collectRequest.Note = &params.Note
+	}
+
+	return s.vpaRepository.CreateCollectRequest(requestCtx, dbExecutor, collectRequest)
+}
+
+func (s *vpaService) GetCollectRequest(requestCtx context.Context, dbExecutor bun.IDB, options types.CollectRequestQueryOptions) (*model.CollectRequest, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.vpaRepository.GetCollectRequest(requestCtx, dbExecutor, options)
+}
+
+func (s *vpaService) ListCollectRequests(requestCtx context.Context, dbExecutor bun.IDB, options types.CollectRequestListOptions) ([]model.CollectRequest, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.vpaRepository.ListCollectRequests(requestCtx, dbExecutor, options)
+}
+
+/*
+ApproveCollectRequest pays the collect request, transferring its amount from the payer to the payee
+
+The transfer is subject to the same limits and fees as any internal transfer made by the payer.
+It must be called within a database transaction because it locks the collect request row for update,
+so that a request is never paid twice.
+*/
+func (s *vpaService) ApproveCollectRequest(requestCtx context.Context, dbExecutor bun.IDB, params types.ApproveCollectRequestParams) (*model.CollectRequest, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	now := time.Now().UTC()
+	collectRequest, err := s.getPendingCollectRequest(requestCtx, dbExecutor, params.CollectRequestID, params.PayerUserID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// the money is paid from the account linked to the VPA the request was sent to, unless the payer picks another one
+	var fromAccountID int64
+	if params.FromAccountID != nil {
+		fromAccountID = *params.FromAccountID
+	} else {
+		payerVPA, err := s.vpaRepository.GetVPA(requestCtx, dbExecutor, types.VPAQueryOptions{
+			Address: &collectRequest.PayerAddress,
+			UserID:  &collectRequest.PayerUserID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		fromAccountID = payerVPA.AccountID
+	}
+
+	err = s.verifyPayingAccounts(requestCtx, dbExecutor, collectRequest, fromAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	narration := fmt.Sprintf("Collect request from %s", collectRequest.PayeeAddress)
+	if collectRequest.Note != nil {
+		narration = *collectRequest.Note
+	}
+	clientReference := strings.ReplaceAll(collectRequest.ID.String(), "-", "")
+
+	transaction, err := s.transferService.CreateInternalTransfer(
+		requestCtx,
+		dbExecutor,
+		collectRequest.PayerUserID,
+		fromAccountID,
+		collectRequest.PayeeAccountID,
+		collectRequest.Amount,
+		accountTypes.Remittance{
+			Narration:       &narration,
+			ClientReference: &clientReference,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	approvedStatus := model.CollectRequestApproved
+	return s.vpaRepository.UpdateCollectRequest(requestCtx, dbExecutor, collectRequest.ID, types.CollectRequestUpdateOptions{
+		NewStatus:        &approvedStatus,
+		NewTransactionID: &transaction.ID,
+		NewRespondedAt:   &now,
+	})
+}
+
+// verifyPayingAccounts verifies that the collect request can be paid from the account, a savings or current account of the payer
+func (s *vpaService) verifyPayingAccounts(requestCtx context.Context, dbExecutor bun.IDB, collectRequest *model.CollectRequest, fromAccountID int64) error {
+	if fromAccountID == collectRequest.PayeeAccountID {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Sender and recipient account ids must be different",
+		}
+	}
+
+	fromAccount, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &fromAccountID,
+		Columns:   []string{"id", "user_id", "type"},
+	})
+	if err != nil {
+		return err
+	}
+
+	isAccountHolder, err := s.accountService.IsAccountHolder(requestCtx, dbExecutor, fromAccount, collectRequest.PayerUserID)
+	if err != nil {
+		return err
+	}
+	if !isAccountHolder {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "You are not authorized to perform transfer from this account",
+		}
+	}
+
+	toAccount, err := s.accountService.GetAccount(requestCtx, dbExecutor, accountTypes.AccountQueryOptions{
+		AccountID: &collectRequest.PayeeAccountID,
+		Columns:   []string{"type"},
+	})
+	if err != nil {
+		return err
+	}
+
+	if !fromAccount.Type.AllowsTransfers() || !toAccount.Type.AllowsTransfers() {
+		return &server.ApiError{
+			HttpStatusCode: http.StatusBadRequest,
+			Message:        "Transfers are only allowed between savings and current accounts",
+		}
+	}
+
+	return nil
+}
+
+// DeclineCollectRequest declines the collect request, no money is moved
+func (s *vpaService) DeclineCollectRequest(requestCtx context.Context, dbExecutor bun.IDB, params types.RespondToCollectRequestParams) (*model.CollectRequest, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	now := time.Now().UTC()
+	collectRequest, err := s.getPendingCollectRequest(requestCtx, dbExecutor, params.CollectRequestID, params.PayerUserID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	declinedStatus := model.CollectRequestDeclined
+	return s.vpaRepository.UpdateCollectRequest(requestCtx, dbExecutor, collectRequest.ID, types.CollectRequestUpdateOptions{
+		NewStatus:      &declinedStatus,
+		NewRespondedAt: &now,
+	})
+}
+
+// getPendingCollectRequest locks the collect request for update and verifies that it is still waiting for an answer from the payer
+func (s *vpaService) getPendingCollectRequest(requestCtx context.Context, dbExecutor bun.IDB, collectRequestID uuid.UUID, payerUserID uuid.UUID, now time.Time) (*model.CollectRequest, error) {
+	collectRequest, err := s.vpaRepository.GetCollectRequest(requestCtx, dbExecutor, types.CollectRequestQueryOptions{
+		ID:        &collectRequestID,
+		ForUpdate: true, // lock the row for update
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// authorization check: only the payer can answer the request
+	if collectRequest.PayerUserID != payerUserID {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusForbidden,
+			Message:        "Only the payer can approve or decline this collect request",
+		}
+	}
+
+	// the worker marks expired requests at an interval, a request past its expiry is expired even if it is not marked yet
+	isExpired := collectRequest.Status == model.CollectRequestExpired ||
+		(collectRequest.Status == model.CollectRequestPending && collectRequest.IsExpired(now))
+	if isExpired {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusConflict,
+			Message:        "Collect request has expired",
+		}
+	}
+
+	if collectRequest.Status != model.CollectRequestPending {
+		return nil, &server.ApiError{
+			HttpStatusCode: http.StatusConflict,
+			Message:        fmt.Sprintf("Collect request has already been %s", strings.ToLower(string(collectRequest.Status))),
+		}
+	}
+
+	return collectRequest, nil
+}
+
+// ExpireCollectRequests marks every pending collect request whose expiry time has passed by the given time as expired
+func (s *vpaService) ExpireCollectRequests(requestCtx context.Context, dbExecutor bun.IDB, expiryTime time.Time) ([]model.CollectRequest, error) {
+	if dbExecutor == nil {
+		dbExecutor = s.db
+	}
+
+	return s.vpaRepository.ExpireCollectRequests(requestCtx, dbExecutor, expiryTime)
+}
@@ -0,0 +1,88 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+)
+
+const ExpireCollectRequestsTaskName string = "periodic_task:expire_collect_requests"
+
+type ExpireCollectRequestsTaskPayload struct {
+}
+
+type ExpireCollectRequestsTask struct {
+	name          string
+	queue         string
+	cronSpec      string
+	maxRetryCount int
+	payload       ExpireCollectRequestsTaskPayload
+}
+
+func NewExpireCollectRequestsTask() tasksHelper.SchedulableTask {
+	return &ExpireCollectRequestsTask{
+		name:          ExpireCollectRequestsTaskName,
+		queue:         tasksHelper.DefaultQueue,
+		cronSpec:      "* * * * *", // run every minute
+		maxRetryCount: 3,
+		payload:       ExpireCollectRequestsTaskPayload{},
+	}
+}
+
+func (t *ExpireCollectRequestsTask) Name() string {
+	return t.name
+}
+
+func (t *ExpireCollectRequestsTask) Queue() string {
+	return t.queue
+}
+
+func (t *ExpireCollectRequestsTask) CronSpec() string {
+	return t.cronSpec
+}
+
+func (t *ExpireCollectRequestsTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *ExpireCollectRequestsTask) Payload() any {
+	return t.payload
+}
+
+type ExpireCollectRequestsTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewExpireCollectRequestsTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &ExpireCollectRequestsTaskProcessor{
+		services: services,
+	}
+}
+
+/*
+ProcessTask marks every collect request the payer has not answered in time as expired
+
+The requests are expired by a single statement that skips the ones answered in the meantime, so retrying this task is safe.
+A request past its expiry cannot be answered even before it is marked, the marking only keeps its status accurate.
+*/
+func (processor *ExpireCollectRequestsTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[ExpireCollectRequestsTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	expiredCollectRequests, err := processor.services.VPAService.ExpireCollectRequests(ctx, nil, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	logger.Info(ctx, "Expired %d collect request(s)", len(expiredCollectRequests))
+	return nil
+}
@@ -0,0 +1,77 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skamranahmed/go-bank/internal"
+	"github.com/skamranahmed/go-bank/pkg/logger"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+)
+
+const SendCollectRequestNotificationTaskName string = "task:send_collect_request_notification"
+
+// The notification asks the payer to approve or decline a collect request before it expires
+type SendCollectRequestNotificationTaskPayload struct {
+	CollectRequestID string
+	PayerUserID      string
+}
+
+type SendCollectRequestNotificationTask struct {
+	name          string
+	queue         string
+	maxRetryCount int
+	payload       SendCollectRequestNotificationTaskPayload
+}
+
+func NewSendCollectRequestNotificationTask(collectRequestID string, payerUserID string) tasksHelper.Task {
+	return &SendCollectRequestNotificationTask{
+		name:          SendCollectRequestNotificationTaskName,
+		queue:         tasksHelper.PriorityQueue,
+		maxRetryCount: 3,
+		payload: SendCollectRequestNotificationTaskPayload{
+			CollectRequestID: collectRequestID,
+			PayerUserID:      payerUserID,
+		},
+	}
+}
+
+func (t *SendCollectRequestNotificationTask) Name() string {
+	return t.name
+}
+
+func (t *SendCollectRequestNotificationTask) Queue() string {
+	return t.queue
+}
+
+func (t *SendCollectRequestNotificationTask) MaxRetryCount() int {
+	return t.maxRetryCount
+}
+
+func (t *SendCollectRequestNotificationTask) Payload() any {
+	return t.payload
+}
+
+type SendCollectRequestNotificationTaskProcessor struct {
+	services *internal.Services
+}
+
+func NewSendCollectRequestNotificationTaskProcessor(services *internal.Services) tasksHelper.TaskProcessor {
+	return &SendCollectRequestNotificationTaskProcessor{
+		services: services,
+	}
+}
+
+func (processor *SendCollectRequestNotificationTaskProcessor) ProcessTask(ctx context.Context, t tasksHelper.Task) error {
+	taskPayloadInBytes := t.Payload().([]byte)
+	payload, err := tasksHelper.ExtractPayload[SendCollectRequestNotificationTaskPayload](taskPayloadInBytes)
+	if err != nil {
+		return fmt.Errorf("Unable to extract payload for task: %s, error: %v", t.Name(), err)
+	}
+
+	ctx = context.WithValue(ctx, "correlation_id", payload.CorrelationID)
+
+	// TODO: maybe add a real email/push provider here in the future
+	logger.Info(ctx, "[Dummy] send collect request notification for collectRequestID: %s to userID: %s", payload.Data.CollectRequestID, payload.Data.PayerUserID)
+	return nil
+}
@@ -0,0 +1,140 @@
+package types
+
+import (
+	"time"
+
+	"github.com/skamranahmed/go-bank/internal/vpa/model"
+)
+
+type RegisterVPARequest struct {
+	Data RegisterVPARequestData `json:"data" binding:"required"`
+}
+
+type RegisterVPARequestData struct {
+	Address   string `json:"address" binding:"required,vpa"`
+	AccountID int64  `json:"account_id" binding:"required,account_number"`
+}
+
+type UpdateVPARequest struct {
+	Data UpdateVPARequestData `json:"data" binding:"required"`
+}
+
+type UpdateVPARequestData struct {
+	AccountID int64 `json:"account_id" binding:"required,account_number"`
+}
+
+type VPADto struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Address   string    `json:"address"`
+	AccountID int64     `json:"account_id"`
+}
+
+type RegisterVPAResponse struct {
+	Data VPADto `json:"data"`
+}
+
+type UpdateVPAResponse struct {
+	Data VPADto `json:"data"`
+}
+
+type GetVPAsResponse struct {
+	Data []VPADto `json:"data"`
+}
+
+func TransformToVPADto(vpa *model.VPA) *VPADto {
+	return &VPADto{
+		ID:        vpa.ID.String(),
+		CreatedAt: vpa.CreatedAt,
+		Address:   vpa.Address,
+		AccountID: vpa.AccountID,
+	}
+}
+
+func TransformToVPADtoList(vpas []model.VPA) []VPADto {
+	vpaDtos := make([]VPADto, 0, len(vpas))
+	for _, vpa := range vpas {
+		vpaDtos = append(vpaDtos, *TransformToVPADto(&vpa))
+	}
+	return vpaDtos
+}
+
+type CreateCollectRequestRequest struct {
+	Data CreateCollectRequestRequestData `json:"data" binding:"required"`
+}
+
+type CreateCollectRequestRequestData struct {
+	// PayeeAddress is the VPA of the authenticated user the money is requested to, PayerAddress the VPA it is requested from
+	PayeeAddress string `json:"payee_address" binding:"required,vpa"`
+	PayerAddress string `json:"payer_address" binding:"required,vpa"`
+	Amount       *int64 `json:"amount" binding:"required,gt=0"`
+	Note         string `json:"note" binding:"omitempty,max=140"`
+}
+
+type ApproveCollectRequestRequest struct {
+	Data ApproveCollectRequestRequestData `json:"data"`
+}
+
+type ApproveCollectRequestRequestData struct {
+	// FromAccountID is optional, by default the request is paid from the account linked to the VPA it was sent to
+	FromAccountID int64 `json:"from_account_id" binding:"omitempty,account_number"`
+}
+
+type GetCollectRequestsRequestQuery struct {
+	// Role selects the requests sent by the authenticated user (PAYEE) or the requests they are asked to pay (PAYER)
+	Role   string `form:"role" binding:"required,oneof=PAYEE PAYER"`
+	Status string `form:"status" binding:"omitempty,oneof=PENDING APPROVED DECLINED EXPIRED"`
+}
+
+const (
+	CollectRequestRolePayee string = "PAYEE"
+	CollectRequestRolePayer string = "PAYER"
+)
+
+type CollectRequestDto struct {
+	ID            string                     `json:"id"`
+	CreatedAt     time.Time                  `json:"created_at"`
+	PayeeAddress  string                     `json:"payee_address"`
+	PayerAddress  string                     `json:"payer_address"`
+	Amount        int64                      `json:"amount"`
+	Note          *string                    `json:"note"`
+	Status        model.CollectRequestStatus `json:"status"`
+	ExpiresAt     time.Time                  `json:"expires_at"`
+	TransactionID *string                    `json:"transaction_id"`
+	RespondedAt   *time.Time                 `json:"responded_at"`
+}
+
+type CollectRequestResponse struct {
+	Data CollectRequestDto `json:"data"`
+}
+
+type GetCollectRequestsResponse struct {
+	Data []CollectRequestDto `json:"data"`
+}
+
+func TransformToCollectRequestDto(collectRequest *model.CollectRequest) *CollectRequestDto {
+	collectRequestDto := &CollectRequestDto{
+		ID:           collectRequest.ID.String(),
+		CreatedAt:    collectRequest.CreatedAt,
+		PayeeAddress: collectRequest.PayeeAddress,
+		PayerAddress: collectRequest.PayerAddress,
+		Amount:       collectRequest.Amount,
+		Note:         collectRequest.Note,
+		Status:       collectRequest.Status,
+		ExpiresAt:    collectRequest.ExpiresAt,
+		RespondedAt:  collectRequest.RespondedAt,
+	}
+	if collectRequest.TransactionID != nil {
+		transactionID := collectRequest.TransactionID.String()
+		collectRequestDto.TransactionID = &transactionID
+	}
+	return collectRequestDto
+}
+
+func TransformToCollectRequestDtoList(collectRequests []model.CollectRequest) []CollectRequestDto {
+	collectRequestDtos := make([]CollectRequestDto, 0, len(collectRequests))
+	for _, collectRequest := range collectRequests {
+		collectRequestDtos = append(collectRequestDtos, *TransformToCollectRequestDto(&collectRequest))
+	}
+	return collectRequestDtos
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/vpa/model"
+)
+
+type VPAQueryOptions struct {
+	ID      *uuid.UUID
+	Address *string
+	UserID  *uuid.UUID
+}
+
+type VPAListOptions struct {
+	UserID *uuid.UUID
+}
+
+type VPAUpdateOptions struct {
+	NewAccountID *int64
+}
+
+type CollectRequestQueryOptions struct {
+	ID *uuid.UUID
+
+	// When true, the query will lock the selected row for update
+	ForUpdate bool
+}
+
+type CollectRequestListOptions struct {
+	PayeeUserID *uuid.UUID
+	PayerUserID *uuid.UUID
+	Status      *model.CollectRequestStatus
+}
+
+type CollectRequestUpdateOptions struct {
+	NewStatus        *model.CollectRequestStatus
+	NewTransactionID *uuid.UUID
+	NewRespondedAt   *time.Time
+}
@@ -0,0 +1,30 @@
+package types
+
+import "github.com/google/uuid"
+
+type RegisterVPAParams struct {
+	UserID    uuid.UUID
+	Address   string
+	AccountID int64
+}
+
+type CreateCollectRequestParams struct {
+	PayeeUserID  uuid.UUID
+	PayeeAddress string
+	PayerAddress string
+	Amount       int64
+	Note         string
+}
+
+type ApproveCollectRequestParams struct {
+	CollectRequestID uuid.UUID
+	PayerUserID      uuid.UUID
+
+	// FromAccountID is the account the money is paid from, when not set it is the account linked to the VPA the request was sent to
+	FromAccountID *int64
+}
+
+type RespondToCollectRequestParams struct {
+	CollectRequestID uuid.UUID
+	PayerUserID      uuid.UUID
+}
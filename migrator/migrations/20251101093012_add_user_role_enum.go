@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddUserRoleEnum, downAddUserRoleEnum)
+}
+
+func upAddUserRoleEnum(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_users_role AS ENUM ('CUSTOMER', 'ADMIN');
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downAddUserRoleEnum(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`DROP TYPE enum_users_role`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddRoleColumnToUsersTable, downAddRoleColumnToUsersTable)
+}
+
+func upAddRoleColumnToUsersTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		ALTER TABLE users
+		ADD COLUMN role enum_users_role NOT NULL DEFAULT 'CUSTOMER';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downAddRoleColumnToUsersTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`ALTER TABLE users DROP COLUMN role`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
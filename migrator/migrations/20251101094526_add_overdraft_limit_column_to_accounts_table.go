@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddOverdraftLimitColumnToAccountsTable, downAddOverdraftLimitColumnToAccountsTable)
+}
+
+func upAddOverdraftLimitColumnToAccountsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		ALTER TABLE accounts
+		ADD COLUMN overdraft_limit BIGINT NOT NULL DEFAULT 0 CHECK (overdraft_limit >= 0),
+		ADD CONSTRAINT accounts_overdraft_limit_current_account_only CHECK (overdraft_limit = 0 OR type = 'CURRENT_ACCOUNT');
+
+		COMMENT ON COLUMN "accounts"."overdraft_limit" IS 'Sanctioned overdraft limit stored in the smallest currency unit (paise for INR). Only CURRENT_ACCOUNT can have a non-zero limit';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downAddOverdraftLimitColumnToAccountsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		ALTER TABLE accounts
+		DROP CONSTRAINT accounts_overdraft_limit_current_account_only,
+		DROP COLUMN overdraft_limit;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
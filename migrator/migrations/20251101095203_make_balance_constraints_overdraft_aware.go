@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upMakeBalanceConstraintsOverdraftAware, downMakeBalanceConstraintsOverdraftAware)
+}
+
+func upMakeBalanceConstraintsOverdraftAware(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	/*
+		The balance of an account is now allowed to go below zero, but never below its sanctioned overdraft limit
+
+		The invariant lives on the "accounts" table because that is where the limit is stored.
+		A check constraint on "transactions" cannot reference the limit of the account,
+		so "balance_after" is only guarded by the "accounts" row that is updated in the same transaction
+	*/
+	_, err := tx.Exec(`
+		ALTER TABLE accounts
+		DROP CONSTRAINT accounts_balance_check,
+		ADD CONSTRAINT accounts_balance_within_overdraft_limit CHECK (balance + overdraft_limit >= 0);
+
+		ALTER TABLE transactions
+		DROP CONSTRAINT transactions_balance_after_check;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downMakeBalanceConstraintsOverdraftAware(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	// NOTE: the rollback fails if any account is currently overdrawn, which is intentional
+	_, err := tx.Exec(`
+		ALTER TABLE transactions
+		ADD CONSTRAINT transactions_balance_after_check CHECK (balance_after >= 0);
+
+		ALTER TABLE accounts
+		DROP CONSTRAINT accounts_balance_within_overdraft_limit,
+		ADD CONSTRAINT accounts_balance_check CHECK (balance >= 0);
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,61 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddOverdraftLimitColumnToTransactionsTable, downAddOverdraftLimitColumnToTransactionsTable)
+}
+
+func upAddOverdraftLimitColumnToTransactionsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	/*
+		"transactions_balance_after_check" was dropped once accounts could be overdrawn, leaving "balance_after" unguarded
+
+		A check constraint cannot read the overdraft limit from the "accounts" table, so every transaction records
+		the overdraft limit of its account when it was booked and "balance_after" must stay within it.
+		The limit the existing overdrawn transactions were booked against is not known, they are given the smallest one they fit in
+	*/
+	_, err := tx.Exec(`
+		ALTER TABLE transactions
+		ADD COLUMN overdraft_limit BIGINT NOT NULL DEFAULT 0 CHECK (overdraft_limit >= 0);
+
+		UPDATE transactions SET overdraft_limit = -balance_after WHERE balance_after < 0;
+
+		ALTER TABLE transactions
+		ADD CONSTRAINT transactions_balance_after_within_overdraft_limit CHECK (balance_after + overdraft_limit >= 0);
+
+		COMMENT ON COLUMN "transactions"."overdraft_limit" IS 'Overdraft limit of the account when the transaction was booked, stored in the smallest currency unit (paise for INR)';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downAddOverdraftLimitColumnToTransactionsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		ALTER TABLE transactions
+		DROP CONSTRAINT transactions_balance_after_within_overdraft_limit,
+		DROP COLUMN overdraft_limit;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,61 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddChargeDateColumnToTransactionsTable, downAddChargeDateColumnToTransactionsTable)
+}
+
+func upAddChargeDateColumnToTransactionsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	/*
+		The overdraft interest of a day can be charged on a later day when the task is retried,
+		so the day it is charged for is recorded and an account can be charged for each day only once.
+		The existing interest was charged on the day it was booked
+	*/
+	_, err := tx.Exec(`
+		ALTER TABLE transactions
+		ADD COLUMN charge_date DATE,
+		ADD CONSTRAINT transactions_charge_date_overdraft_interest_only CHECK ((charge_date IS NOT NULL) = (type = 'OVERDRAFT_INTEREST'));
+
+		UPDATE transactions SET charge_date = (created_at AT TIME ZONE 'UTC')::DATE WHERE type = 'OVERDRAFT_INTEREST';
+
+		CREATE UNIQUE INDEX transactions_account_id_charge_date_unique ON transactions (account_id, charge_date) WHERE type = 'OVERDRAFT_INTEREST';
+
+		COMMENT ON COLUMN "transactions"."charge_date" IS 'Day (UTC) the interest of an OVERDRAFT_INTEREST transaction is charged for';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downAddChargeDateColumnToTransactionsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP INDEX transactions_account_id_charge_date_unique;
+
+		ALTER TABLE transactions
+		DROP CONSTRAINT transactions_charge_date_overdraft_interest_only,
+		DROP COLUMN charge_date;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
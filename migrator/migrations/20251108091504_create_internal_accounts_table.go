@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateInternalAccountsTable, downCreateInternalAccountsTable)
+}
+
+func upCreateInternalAccountsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TABLE internal_accounts (
+			code VARCHAR(50) PRIMARY KEY NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			name VARCHAR(100) NOT NULL,
+			balance BIGINT NOT NULL DEFAULT 0
+		);
+
+		COMMENT ON TABLE internal_accounts IS 'Bank owned general ledger accounts, eg: fee revenue, tax payable';
+		COMMENT ON COLUMN internal_accounts.balance IS 'Balance in the lowest currency unit i.e paise for INR, can be negative';
+
+		INSERT INTO internal_accounts (code, name) VALUES
+			('FEE_REVENUE', 'Fee Revenue'),
+			('TAX_PAYABLE', 'Tax Payable');
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateInternalAccountsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`DROP TABLE internal_accounts`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,60 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateInternalAccountEntriesTable, downCreateInternalAccountEntriesTable)
+}
+
+func upCreateInternalAccountEntriesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_internal_account_entries_type AS ENUM ('DEBIT', 'CREDIT');
+
+		CREATE TABLE internal_account_entries (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			internal_account_code VARCHAR(50) NOT NULL REFERENCES internal_accounts(code),
+			amount BIGINT NOT NULL CHECK (amount > 0),
+			balance_after BIGINT NOT NULL,
+			type enum_internal_account_entries_type NOT NULL,
+			reference VARCHAR(100) NOT NULL
+		);
+
+		CREATE INDEX idx_internal_account_entries_internal_account_code_created_at ON internal_account_entries (internal_account_code, created_at);
+
+		COMMENT ON COLUMN internal_account_entries.amount IS 'Amount of the entry, in the lowest currency unit i.e paise for INR';
+		COMMENT ON COLUMN internal_account_entries.reference IS 'Identifier of the business event that caused the entry, eg: a fee charge ID';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateInternalAccountEntriesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE internal_account_entries;
+		DROP TYPE enum_internal_account_entries_type;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,80 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateFeeRulesTable, downCreateFeeRulesTable)
+}
+
+func upCreateFeeRulesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_fee_events AS ENUM ('INTERNAL_TRANSFER', 'STATEMENT_REQUEST');
+		CREATE TYPE enum_fee_rules_calculation_type AS ENUM ('FLAT', 'PERCENTAGE');
+
+		CREATE TABLE fee_rules (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			account_type enum_accounts_type NOT NULL,
+			event enum_fee_events NOT NULL,
+			calculation_type enum_fee_rules_calculation_type NOT NULL,
+			flat_amount BIGINT NOT NULL DEFAULT 0 CHECK (flat_amount >= 0),
+			percentage_in_basis_points BIGINT NOT NULL DEFAULT 0 CHECK (percentage_in_basis_points >= 0),
+			min_amount BIGINT CHECK (min_amount >= 0),
+			max_amount BIGINT CHECK (max_amount >= 0),
+			tax_rate_in_basis_points BIGINT NOT NULL DEFAULT 0 CHECK (tax_rate_in_basis_points >= 0),
+			free_quota_per_month INTEGER NOT NULL DEFAULT 0 CHECK (free_quota_per_month >= 0),
+			is_active BOOLEAN NOT NULL DEFAULT TRUE,
+			CONSTRAINT fee_rules_min_amount_not_above_max_amount CHECK (min_amount IS NULL OR max_amount IS NULL OR min_amount <= max_amount)
+		);
+
+		-- at most one active rule can exist for an account type and event
+		CREATE UNIQUE INDEX idx_fee_rules_account_type_event_active ON fee_rules (account_type, event) WHERE is_active;
+
+		COMMENT ON COLUMN fee_rules.flat_amount IS 'Fee for FLAT rules, in the lowest currency unit i.e paise for INR';
+		COMMENT ON COLUMN fee_rules.percentage_in_basis_points IS 'Fee for PERCENTAGE rules as a share of the amount of the triggering operation, 1 basis point = 0.01%';
+		COMMENT ON COLUMN fee_rules.min_amount IS 'Lower cap on the fee before tax, in the lowest currency unit i.e paise for INR';
+		COMMENT ON COLUMN fee_rules.max_amount IS 'Upper cap on the fee before tax, in the lowest currency unit i.e paise for INR';
+		COMMENT ON COLUMN fee_rules.free_quota_per_month IS 'Number of occurrences of the event per calendar month that are not charged';
+
+		-- default fee schedule, 18% GST is levied on every fee
+		INSERT INTO fee_rules (account_type, event, calculation_type, flat_amount, percentage_in_basis_points, min_amount, max_amount, tax_rate_in_basis_points, free_quota_per_month) VALUES
+			('SAVINGS_ACCOUNT', 'INTERNAL_TRANSFER', 'FLAT', 500, 0, NULL, NULL, 1800, 5),
+			('CURRENT_ACCOUNT', 'INTERNAL_TRANSFER', 'PERCENTAGE', 0, 10, 500, 5000, 1800, 20),
+			('SAVINGS_ACCOUNT', 'STATEMENT_REQUEST', 'FLAT', 5000, 0, NULL, NULL, 1800, 1),
+			('CURRENT_ACCOUNT', 'STATEMENT_REQUEST', 'FLAT', 5000, 0, NULL, NULL, 1800, 1);
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateFeeRulesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE fee_rules;
+		DROP TYPE enum_fee_rules_calculation_type;
+		DROP TYPE enum_fee_events;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateFeeChargesTable, downCreateFeeChargesTable)
+}
+
+func upCreateFeeChargesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_fee_charges_status AS ENUM ('FREE', 'CHARGED', 'WAIVED');
+
+		CREATE TABLE fee_charges (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			account_id BIGINT NOT NULL REFERENCES accounts(id),
+			fee_rule_id UUID NOT NULL REFERENCES fee_rules(id),
+			event enum_fee_events NOT NULL,
+			reference VARCHAR(100) NOT NULL,
+			base_amount BIGINT NOT NULL DEFAULT 0 CHECK (base_amount >= 0),
+			fee_amount BIGINT NOT NULL CHECK (fee_amount >= 0),
+			tax_amount BIGINT NOT NULL CHECK (tax_amount >= 0),
+			status enum_fee_charges_status NOT NULL,
+			transaction_id UUID REFERENCES transactions(id),
+			waived_by UUID REFERENCES users(id),
+			waived_at TIMESTAMPTZ,
+			waiver_reason TEXT,
+			waiver_transaction_id UUID REFERENCES transactions(id)
+		);
+
+		CREATE INDEX idx_fee_charges_account_id_event_created_at ON fee_charges (account_id, event, created_at);
+
+		COMMENT ON TABLE fee_charges IS 'Every occurrence of a chargeable event, including the ones covered by the free quota';
+		COMMENT ON COLUMN fee_charges.reference IS 'Identifier of the operation that triggered the fee, eg: the debit transaction ID of a transfer';
+		COMMENT ON COLUMN fee_charges.fee_amount IS 'Fee before tax, in the lowest currency unit i.e paise for INR';
+		COMMENT ON COLUMN fee_charges.tax_amount IS 'Tax levied on the fee, in the lowest currency unit i.e paise for INR';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateFeeChargesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE fee_charges;
+		DROP TYPE enum_fee_charges_status;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,51 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateDailyBalancesTable, downCreateDailyBalancesTable)
+}
+
+func upCreateDailyBalancesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TABLE daily_balances (
+			account_id BIGINT NOT NULL REFERENCES accounts(id),
+			balance_date DATE NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			closing_balance BIGINT NOT NULL,
+			PRIMARY KEY (account_id, balance_date)
+		);
+
+		COMMENT ON TABLE daily_balances IS 'End of day balance snapshot of every account';
+		COMMENT ON COLUMN daily_balances.closing_balance IS 'Account balance at the end of the day, in the lowest currency unit i.e paise for INR';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateDailyBalancesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`DROP TABLE daily_balances`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateAverageBalanceBreachesTable, downCreateAverageBalanceBreachesTable)
+}
+
+func upCreateAverageBalanceBreachesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TABLE average_balance_breaches (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			account_id BIGINT NOT NULL REFERENCES accounts(id),
+			month DATE NOT NULL,
+			average_balance BIGINT NOT NULL,
+			required_average_balance BIGINT NOT NULL,
+			fee_charge_id UUID REFERENCES fee_charges(id),
+			CONSTRAINT average_balance_breaches_account_id_month_unique UNIQUE (account_id, month)
+		);
+
+		COMMENT ON COLUMN average_balance_breaches.month IS 'First day of the month for which the average balance was computed';
+		COMMENT ON COLUMN average_balance_breaches.average_balance IS 'Monthly average balance, in the lowest currency unit i.e paise for INR';
+		COMMENT ON COLUMN average_balance_breaches.fee_charge_id IS 'Penalty charged for the breach, if any';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateAverageBalanceBreachesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`DROP TABLE average_balance_breaches`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddFixedDepositToAccountTypeEnum, downAddFixedDepositToAccountTypeEnum)
+}
+
+func upAddFixedDepositToAccountTypeEnum(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`ALTER TYPE enum_accounts_type ADD VALUE 'FIXED_DEPOSIT'`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downAddFixedDepositToAccountTypeEnum(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	// postgres does not support dropping a value from an enum, so the enum is recreated without it
+	// NOTE: the rollback fails if any account of type 'FIXED_DEPOSIT' exists, which is intentional
+	_, err := tx.Exec(`
+		ALTER TYPE enum_accounts_type RENAME TO enum_accounts_type_old;
+		CREATE TYPE enum_accounts_type AS ENUM ('SAVINGS_ACCOUNT', 'CURRENT_ACCOUNT');
+
+		ALTER TABLE accounts ALTER COLUMN type DROP DEFAULT;
+		ALTER TABLE accounts ALTER COLUMN type TYPE enum_accounts_type USING type::text::enum_accounts_type;
+		ALTER TABLE accounts ALTER COLUMN type SET DEFAULT 'SAVINGS_ACCOUNT';
+
+		ALTER TABLE fee_rules ALTER COLUMN account_type TYPE enum_accounts_type USING account_type::text::enum_accounts_type;
+
+		DROP TYPE enum_accounts_type_old;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upLimitUserIdTypeUniqueConstraintToOperativeAccounts, downLimitUserIdTypeUniqueConstraintToOperativeAccounts)
+}
+
+func upLimitUserIdTypeUniqueConstraintToOperativeAccounts(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	/*
+		A user can still have at most one savings and one current account,
+		but can book any number of deposits, each of which is held in its own account
+	*/
+	_, err := tx.Exec(`
+		ALTER TABLE accounts DROP CONSTRAINT accounts_user_id_type_unique;
+
+		CREATE UNIQUE INDEX accounts_user_id_type_unique ON accounts (user_id, type) WHERE type IN ('SAVINGS_ACCOUNT', 'CURRENT_ACCOUNT');
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downLimitUserIdTypeUniqueConstraintToOperativeAccounts(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	// NOTE: the rollback fails if any user has more than one deposit account, which is intentional
+	_, err := tx.Exec(`
+		DROP INDEX accounts_user_id_type_unique;
+
+		ALTER TABLE accounts
+		ADD CONSTRAINT accounts_user_id_type_unique UNIQUE (user_id, type);
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,79 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateFixedDepositsTable, downCreateFixedDepositsTable)
+}
+
+func upCreateFixedDepositsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_fixed_deposits_maturity_instruction AS ENUM ('PAYOUT', 'AUTO_RENEW');
+		CREATE TYPE enum_fixed_deposits_status AS ENUM ('ACTIVE', 'MATURED', 'CLOSED');
+
+		CREATE TABLE fixed_deposits (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			user_id UUID NOT NULL REFERENCES users(id),
+			account_id BIGINT NOT NULL UNIQUE REFERENCES accounts(id),
+			linked_account_id BIGINT NOT NULL REFERENCES accounts(id),
+			principal_amount BIGINT NOT NULL CHECK (principal_amount > 0),
+			annual_interest_rate_in_basis_points BIGINT NOT NULL CHECK (annual_interest_rate_in_basis_points >= 0),
+			tenure_in_months INTEGER NOT NULL CHECK (tenure_in_months > 0),
+			start_date DATE NOT NULL,
+			maturity_date DATE NOT NULL,
+			maturity_instruction enum_fixed_deposits_maturity_instruction NOT NULL,
+			status enum_fixed_deposits_status NOT NULL DEFAULT 'ACTIVE',
+			renewal_count INTEGER NOT NULL DEFAULT 0,
+			interest_paid BIGINT NOT NULL DEFAULT 0 CHECK (interest_paid >= 0),
+			closed_at TIMESTAMPTZ,
+			CONSTRAINT fixed_deposits_maturity_date_after_start_date CHECK (maturity_date > start_date)
+		);
+
+		CREATE INDEX idx_fixed_deposits_user_id ON fixed_deposits (user_id);
+
+		-- the maturity task looks up active deposits that are due
+		CREATE INDEX idx_fixed_deposits_status_maturity_date ON fixed_deposits (status, maturity_date);
+
+		COMMENT ON COLUMN fixed_deposits.account_id IS 'FIXED_DEPOSIT account that holds the deposit';
+		COMMENT ON COLUMN fixed_deposits.linked_account_id IS 'Account the deposit was funded from and is paid out to';
+		COMMENT ON COLUMN fixed_deposits.principal_amount IS 'Principal of the current term, in the lowest currency unit i.e paise for INR';
+		COMMENT ON COLUMN fixed_deposits.annual_interest_rate_in_basis_points IS 'Rate locked in when the deposit was booked, compounded quarterly, 1 basis point = 0.01%';
+		COMMENT ON COLUMN fixed_deposits.start_date IS 'Start of the current term, moves forward when the deposit is renewed';
+		COMMENT ON COLUMN fixed_deposits.interest_paid IS 'Total interest credited over all terms, in the lowest currency unit i.e paise for INR';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateFixedDepositsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE fixed_deposits;
+		DROP TYPE enum_fixed_deposits_status;
+		DROP TYPE enum_fixed_deposits_maturity_instruction;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddRecurringDepositMissedInstallmentFeeRule, downAddRecurringDepositMissedInstallmentFeeRule)
+}
+
+func upAddRecurringDepositMissedInstallmentFeeRule(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	// the new enum values cannot be used in the migrations that add them, hence the rule is seeded separately
+	_, err := tx.Exec(`
+		INSERT INTO fee_rules (account_type, event, calculation_type, flat_amount, percentage_in_basis_points, min_amount, max_amount, tax_rate_in_basis_points, free_quota_per_month) VALUES
+			('RECURRING_DEPOSIT', 'RECURRING_DEPOSIT_MISSED_INSTALLMENT', 'FLAT', 5000, 0, NULL, NULL, 1800, 0);
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downAddRecurringDepositMissedInstallmentFeeRule(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`DELETE FROM fee_rules WHERE event = 'RECURRING_DEPOSIT_MISSED_INSTALLMENT'`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
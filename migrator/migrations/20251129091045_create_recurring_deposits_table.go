@@ -0,0 +1,75 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateRecurringDepositsTable, downCreateRecurringDepositsTable)
+}
+
+func upCreateRecurringDepositsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_recurring_deposits_status AS ENUM ('ACTIVE', 'MATURED');
+
+		CREATE TABLE recurring_deposits (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			user_id UUID NOT NULL REFERENCES users(id),
+			account_id BIGINT NOT NULL UNIQUE REFERENCES accounts(id),
+			linked_account_id BIGINT NOT NULL REFERENCES accounts(id),
+			installment_amount BIGINT NOT NULL CHECK (installment_amount > 0),
+			annual_interest_rate_in_basis_points BIGINT NOT NULL CHECK (annual_interest_rate_in_basis_points >= 0),
+			tenure_in_months INTEGER NOT NULL CHECK (tenure_in_months > 0),
+			debit_day_of_month INTEGER NOT NULL CHECK (debit_day_of_month BETWEEN 1 AND 28),
+			start_date DATE NOT NULL,
+			maturity_date DATE NOT NULL,
+			status enum_recurring_deposits_status NOT NULL DEFAULT 'ACTIVE',
+			interest_paid BIGINT NOT NULL DEFAULT 0 CHECK (interest_paid >= 0),
+			closed_at TIMESTAMPTZ,
+			CONSTRAINT recurring_deposits_maturity_date_after_start_date CHECK (maturity_date > start_date)
+		);
+
+		CREATE INDEX idx_recurring_deposits_user_id ON recurring_deposits (user_id);
+
+		-- the maturity task looks up active deposits that are due
+		CREATE INDEX idx_recurring_deposits_status_maturity_date ON recurring_deposits (status, maturity_date);
+
+		COMMENT ON COLUMN recurring_deposits.account_id IS 'RECURRING_DEPOSIT account that holds the installments';
+		COMMENT ON COLUMN recurring_deposits.linked_account_id IS 'Account the installments are debited from and the deposit is paid out to';
+		COMMENT ON COLUMN recurring_deposits.installment_amount IS 'Monthly installment, in the lowest currency unit i.e paise for INR';
+		COMMENT ON COLUMN recurring_deposits.tenure_in_months IS 'Number of monthly installments';
+		COMMENT ON COLUMN recurring_deposits.debit_day_of_month IS 'Day of the month every installment after the first one is due on, capped at 28 so that it exists in every month';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateRecurringDepositsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE recurring_deposits;
+		DROP TYPE enum_recurring_deposits_status;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateRecurringDepositInstallmentsTable, downCreateRecurringDepositInstallmentsTable)
+}
+
+func upCreateRecurringDepositInstallmentsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_recurring_deposit_installments_status AS ENUM ('PENDING', 'PAID', 'MISSED');
+
+		CREATE TABLE recurring_deposit_installments (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			recurring_deposit_id UUID NOT NULL REFERENCES recurring_deposits(id),
+			installment_number INTEGER NOT NULL CHECK (installment_number > 0),
+			due_date DATE NOT NULL,
+			amount BIGINT NOT NULL CHECK (amount > 0),
+			status enum_recurring_deposit_installments_status NOT NULL DEFAULT 'PENDING',
+			attempt_count INTEGER NOT NULL DEFAULT 0,
+			last_attempted_at TIMESTAMPTZ,
+			paid_at TIMESTAMPTZ,
+			transaction_id UUID REFERENCES transactions(id),
+			CONSTRAINT recurring_deposit_installments_recurring_deposit_id_installment_number_unique UNIQUE (recurring_deposit_id, installment_number)
+		);
+
+		-- the collection task looks up pending installments that are due
+		CREATE INDEX idx_recurring_deposit_installments_status_due_date ON recurring_deposit_installments (status, due_date);
+
+		COMMENT ON COLUMN recurring_deposit_installments.attempt_count IS 'Number of auto-debit attempts that failed for lack of funds';
+		COMMENT ON COLUMN recurring_deposit_installments.transaction_id IS 'Debit of the linked account that paid the installment';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateRecurringDepositInstallmentsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE recurring_deposit_installments;
+		DROP TYPE enum_recurring_deposit_installments_status;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddLoanLatePaymentToFeeEventEnum, downAddLoanLatePaymentToFeeEventEnum)
+}
+
+func upAddLoanLatePaymentToFeeEventEnum(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`ALTER TYPE enum_fee_events ADD VALUE 'LOAN_LATE_PAYMENT'`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downAddLoanLatePaymentToFeeEventEnum(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	// postgres does not support dropping a value from an enum, so the enum is recreated without it
+	// NOTE: the rollback fails if any fee rule or fee charge for 'LOAN_LATE_PAYMENT' exists, which is intentional
+	_, err := tx.Exec(`
+		ALTER TYPE enum_fee_events RENAME TO enum_fee_events_old;
+		CREATE TYPE enum_fee_events AS ENUM ('INTERNAL_TRANSFER', 'STATEMENT_REQUEST', 'MINIMUM_BALANCE_BREACH', 'RECURRING_DEPOSIT_MISSED_INSTALLMENT');
+		ALTER TABLE fee_rules ALTER COLUMN event TYPE enum_fee_events USING event::text::enum_fee_events;
+		ALTER TABLE fee_charges ALTER COLUMN event TYPE enum_fee_events USING event::text::enum_fee_events;
+		DROP TYPE enum_fee_events_old;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
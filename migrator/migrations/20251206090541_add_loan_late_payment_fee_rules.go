@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddLoanLatePaymentFeeRules, downAddLoanLatePaymentFeeRules)
+}
+
+func upAddLoanLatePaymentFeeRules(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	// the new enum value cannot be used in the migration that adds it, hence the rules are seeded separately
+	// the late fee is charged to the account the EMIs are debited from
+	_, err := tx.Exec(`
+		INSERT INTO fee_rules (account_type, event, calculation_type, flat_amount, percentage_in_basis_points, min_amount, max_amount, tax_rate_in_basis_points, free_quota_per_month) VALUES
+			('SAVINGS_ACCOUNT', 'LOAN_LATE_PAYMENT', 'FLAT', 50000, 0, NULL, NULL, 1800, 0),
+			('CURRENT_ACCOUNT', 'LOAN_LATE_PAYMENT', 'FLAT', 50000, 0, NULL, NULL, 1800, 0);
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downAddLoanLatePaymentFeeRules(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`DELETE FROM fee_rules WHERE event = 'LOAN_LATE_PAYMENT'`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
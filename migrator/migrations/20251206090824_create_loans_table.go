@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateLoansTable, downCreateLoansTable)
+}
+
+func upCreateLoansTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_loans_status AS ENUM ('PENDING_APPROVAL', 'REJECTED', 'ACTIVE', 'CLOSED');
+
+		CREATE TABLE loans (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			user_id UUID NOT NULL REFERENCES users(id),
+			account_id BIGINT NOT NULL REFERENCES accounts(id),
+			principal_amount BIGINT NOT NULL CHECK (principal_amount > 0),
+			annual_interest_rate_in_basis_points BIGINT NOT NULL CHECK (annual_interest_rate_in_basis_points >= 0),
+			tenure_in_months INTEGER NOT NULL CHECK (tenure_in_months > 0),
+			emi_amount BIGINT NOT NULL CHECK (emi_amount > 0),
+			outstanding_principal BIGINT NOT NULL DEFAULT 0 CHECK (outstanding_principal >= 0),
+			status enum_loans_status NOT NULL DEFAULT 'PENDING_APPROVAL',
+			reviewed_by UUID REFERENCES users(id),
+			reviewed_at TIMESTAMPTZ,
+			rejection_reason TEXT,
+			disbursed_at TIMESTAMPTZ,
+			closed_at TIMESTAMPTZ
+		);
+
+		CREATE INDEX idx_loans_user_id ON loans (user_id);
+		CREATE INDEX idx_loans_status ON loans (status);
+
+		COMMENT ON COLUMN loans.account_id IS 'Customer account the loan is disbursed to and the EMIs are debited from';
+		COMMENT ON COLUMN loans.emi_amount IS 'Current monthly installment, recalculated after every prepayment';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateLoansTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE loans;
+		DROP TYPE enum_loans_status;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
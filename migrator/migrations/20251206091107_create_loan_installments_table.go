@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateLoanInstallmentsTable, downCreateLoanInstallmentsTable)
+}
+
+func upCreateLoanInstallmentsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_loan_installments_status AS ENUM ('PENDING', 'PAID', 'CANCELLED');
+
+		CREATE TABLE loan_installments (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			loan_id UUID NOT NULL REFERENCES loans(id),
+			installment_number INTEGER NOT NULL CHECK (installment_number > 0),
+			due_date DATE NOT NULL,
+			opening_principal BIGINT NOT NULL CHECK (opening_principal >= 0),
+			principal_component BIGINT NOT NULL CHECK (principal_component >= 0),
+			interest_component BIGINT NOT NULL CHECK (interest_component >= 0),
+			status enum_loan_installments_status NOT NULL DEFAULT 'PENDING',
+			attempt_count INTEGER NOT NULL DEFAULT 0,
+			last_attempted_at TIMESTAMPTZ,
+			late_fee_charged_at TIMESTAMPTZ,
+			paid_at TIMESTAMPTZ,
+			transaction_id UUID REFERENCES transactions(id),
+			CONSTRAINT loan_installments_loan_id_installment_number_unique UNIQUE (loan_id, installment_number)
+		);
+
+		-- the collection task looks up pending installments that are due
+		CREATE INDEX idx_loan_installments_status_due_date ON loan_installments (status, due_date);
+
+		COMMENT ON COLUMN loan_installments.opening_principal IS 'Outstanding principal at the start of the period, the interest component is charged on it';
+		COMMENT ON COLUMN loan_installments.attempt_count IS 'Number of auto-debit attempts that failed for lack of funds';
+		COMMENT ON COLUMN loan_installments.transaction_id IS 'Debit of the customer account that paid the installment';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateLoanInstallmentsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE loan_installments;
+		DROP TYPE enum_loan_installments_status;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
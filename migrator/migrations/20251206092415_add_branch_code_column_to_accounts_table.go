@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddBranchCodeColumnToAccountsTable, downAddBranchCodeColumnToAccountsTable)
+}
+
+func upAddBranchCodeColumnToAccountsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	/*
+		Every account is held at a branch, whose code is part of the IFSC and the IBAN of the account
+		The existing accounts were all opened at the single branch the bank had until now
+	*/
+	_, err := tx.Exec(`
+		ALTER TABLE accounts
+		ADD COLUMN branch_code VARCHAR(6) NOT NULL DEFAULT '000001' CHECK (branch_code ~ '^[A-Z0-9]{6}$');
+
+		COMMENT ON COLUMN "accounts"."branch_code" IS '6 character code of the branch the account is held at, the last 6 characters of its IFSC';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downAddBranchCodeColumnToAccountsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		ALTER TABLE accounts
+		DROP COLUMN branch_code;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateBeneficiariesTable, downCreateBeneficiariesTable)
+}
+
+func upCreateBeneficiariesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TABLE beneficiaries (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			user_id UUID NOT NULL REFERENCES users(id),
+			nickname VARCHAR(50) NOT NULL,
+			account_id BIGINT NOT NULL REFERENCES accounts(id),
+			holder_name VARCHAR(100) NOT NULL,
+			cooling_period_ends_at TIMESTAMPTZ NOT NULL,
+			cooling_period_transferred_amount BIGINT NOT NULL DEFAULT 0 CHECK (cooling_period_transferred_amount >= 0),
+			CONSTRAINT beneficiaries_user_id_account_id_unique UNIQUE (user_id, account_id)
+		);
+
+		COMMENT ON COLUMN beneficiaries.holder_name IS 'Name of the holder of the account, looked up when the beneficiary is added';
+		COMMENT ON COLUMN beneficiaries.cooling_period_transferred_amount IS 'Total transferred to the beneficiary before its cooling period ended, capped by the cooling period transfer limit';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateBeneficiariesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE beneficiaries;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddNameColumnsToUsersTable, downAddNameColumnsToUsersTable)
+}
+
+func upAddNameColumnsToUsersTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	// users who signed up before names were collected are left with empty names
+	_, err := tx.Exec(`
+		ALTER TABLE users
+		ADD COLUMN first_name VARCHAR(50) NOT NULL DEFAULT '',
+		ADD COLUMN last_name VARCHAR(50) NOT NULL DEFAULT '';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downAddNameColumnsToUsersTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		ALTER TABLE users
+		DROP COLUMN first_name,
+		DROP COLUMN last_name;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upUpdateHolderNameColumnCommentOfBeneficiariesTable, downUpdateHolderNameColumnCommentOfBeneficiariesTable)
+}
+
+func upUpdateHolderNameColumnCommentOfBeneficiariesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	// the holder name of a beneficiary is now stored masked, the way name enquiries return it
+	_, err := tx.Exec(`
+		COMMENT ON COLUMN beneficiaries.holder_name IS 'Masked name of the holder of the account, looked up when the beneficiary is added';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downUpdateHolderNameColumnCommentOfBeneficiariesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		COMMENT ON COLUMN beneficiaries.holder_name IS 'Name of the holder of the account, looked up when the beneficiary is added';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateTransferLimitOverridesTable, downCreateTransferLimitOverridesTable)
+}
+
+func upCreateTransferLimitOverridesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TABLE transfer_limit_overrides (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			user_id UUID NOT NULL REFERENCES users(id),
+			account_type VARCHAR(50) NOT NULL CHECK (account_type IN ('SAVINGS_ACCOUNT', 'CURRENT_ACCOUNT')),
+			per_transaction_amount BIGINT CHECK (per_transaction_amount > 0),
+			daily_count INTEGER CHECK (daily_count > 0),
+			daily_amount BIGINT CHECK (daily_amount > 0),
+			monthly_amount BIGINT CHECK (monthly_amount > 0),
+			CONSTRAINT transfer_limit_overrides_user_id_account_type_unique UNIQUE (user_id, account_type)
+		);
+
+		COMMENT ON TABLE transfer_limit_overrides IS 'Transfer limits of a user that differ from the defaults of the account type, a NULL limit falls back to the default';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateTransferLimitOverridesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE transfer_limit_overrides;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
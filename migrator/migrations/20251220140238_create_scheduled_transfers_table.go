@@ -0,0 +1,65 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateScheduledTransfersTable, downCreateScheduledTransfersTable)
+}
+
+func upCreateScheduledTransfersTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_scheduled_transfers_status AS ENUM ('PENDING', 'COMPLETED', 'FAILED', 'CANCELLED');
+
+		CREATE TABLE scheduled_transfers (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			user_id UUID NOT NULL REFERENCES users(id),
+			from_account_id BIGINT NOT NULL REFERENCES accounts(id),
+			to_account_id BIGINT NOT NULL REFERENCES accounts(id),
+			amount BIGINT NOT NULL CHECK (amount > 0),
+			scheduled_at TIMESTAMPTZ NOT NULL,
+			status enum_scheduled_transfers_status NOT NULL DEFAULT 'PENDING',
+			failure_reason TEXT,
+			transaction_id UUID REFERENCES transactions(id),
+			executed_at TIMESTAMPTZ
+		);
+
+		CREATE INDEX idx_scheduled_transfers_user_id ON scheduled_transfers (user_id);
+
+		COMMENT ON COLUMN scheduled_transfers.scheduled_at IS 'Time the transfer is due to be executed at by the worker';
+		COMMENT ON COLUMN scheduled_transfers.transaction_id IS 'Debit transaction of the sender account, once the transfer is executed';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateScheduledTransfersTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE scheduled_transfers;
+		DROP TYPE enum_scheduled_transfers_status;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,94 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateStandingInstructionsTable, downCreateStandingInstructionsTable)
+}
+
+func upCreateStandingInstructionsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_standing_instructions_frequency AS ENUM ('WEEKLY', 'MONTHLY');
+		CREATE TYPE enum_standing_instructions_status AS ENUM ('ACTIVE', 'SUSPENDED', 'COMPLETED', 'CANCELLED');
+		CREATE TYPE enum_standing_instruction_executions_status AS ENUM ('SUCCEEDED', 'FAILED', 'SKIPPED');
+
+		CREATE TABLE standing_instructions (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			user_id UUID NOT NULL REFERENCES users(id),
+			from_account_id BIGINT NOT NULL REFERENCES accounts(id),
+			to_account_id BIGINT NOT NULL REFERENCES accounts(id),
+			amount BIGINT NOT NULL CHECK (amount > 0),
+			frequency enum_standing_instructions_frequency NOT NULL,
+			day_of_month INT CHECK (day_of_month BETWEEN 1 AND 28),
+			start_date DATE NOT NULL,
+			end_date DATE,
+			max_occurrences INT CHECK (max_occurrences > 0),
+			occurrence_count INT NOT NULL DEFAULT 0,
+			next_execution_date DATE,
+			status enum_standing_instructions_status NOT NULL DEFAULT 'ACTIVE',
+			consecutive_failure_count INT NOT NULL DEFAULT 0,
+			last_attempted_at TIMESTAMPTZ,
+			CHECK ((frequency = 'MONTHLY') = (day_of_month IS NOT NULL))
+		);
+
+		CREATE INDEX idx_standing_instructions_user_id ON standing_instructions (user_id);
+		CREATE INDEX idx_standing_instructions_status_next_execution_date ON standing_instructions (status, next_execution_date);
+
+		COMMENT ON COLUMN standing_instructions.day_of_month IS 'Day of the month a MONTHLY instruction is paid on, between 1 and 28 so that it exists in every month';
+		COMMENT ON COLUMN standing_instructions.occurrence_count IS 'Number of occurrences that are over, whether they were paid or skipped';
+		COMMENT ON COLUMN standing_instructions.next_execution_date IS 'Due date of the next occurrence, NULL once the instruction has no occurrences left';
+
+		CREATE TABLE standing_instruction_executions (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			standing_instruction_id UUID NOT NULL REFERENCES standing_instructions(id),
+			occurrence_number INT NOT NULL,
+			due_date DATE NOT NULL,
+			status enum_standing_instruction_executions_status NOT NULL,
+			failure_reason TEXT,
+			transaction_id UUID REFERENCES transactions(id)
+		);
+
+		CREATE INDEX idx_standing_instruction_executions_standing_instruction_id ON standing_instruction_executions (standing_instruction_id);
+
+		-- an occurrence can only ever be paid once, however many times its execution is attempted
+		CREATE UNIQUE INDEX idx_standing_instruction_executions_succeeded_occurrence ON standing_instruction_executions (standing_instruction_id, occurrence_number) WHERE status = 'SUCCEEDED';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateStandingInstructionsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE standing_instruction_executions;
+		DROP TABLE standing_instructions;
+		DROP TYPE enum_standing_instruction_executions_status;
+		DROP TYPE enum_standing_instructions_status;
+		DROP TYPE enum_standing_instructions_frequency;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
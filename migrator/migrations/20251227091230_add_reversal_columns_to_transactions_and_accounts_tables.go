@@ -0,0 +1,68 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddReversalColumnsToTransactionsAndAccountsTables, downAddReversalColumnsToTransactionsAndAccountsTables)
+}
+
+func upAddReversalColumnsToTransactionsAndAccountsTables(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_transactions_status AS ENUM ('COMPLETED', 'PARTIALLY_REVERSED', 'REVERSED');
+
+		ALTER TABLE transactions
+		ADD COLUMN status enum_transactions_status NOT NULL DEFAULT 'COMPLETED',
+		ADD COLUMN counterpart_transaction_id UUID REFERENCES transactions(id),
+		ADD COLUMN reversed_transaction_id UUID REFERENCES transactions(id);
+
+		CREATE UNIQUE INDEX idx_transactions_counterpart_transaction_id ON transactions (counterpart_transaction_id);
+
+		COMMENT ON COLUMN "transactions"."counterpart_transaction_id" IS 'On the CREDIT of a transfer, the DEBIT of the sender account it was paid by';
+		COMMENT ON COLUMN "transactions"."reversed_transaction_id" IS 'On a REVERSAL_DEBIT or REVERSAL_CREDIT, the transaction of the same account it compensates';
+
+		ALTER TABLE accounts
+		ADD COLUMN held_amount BIGINT NOT NULL DEFAULT 0 CHECK (held_amount >= 0);
+
+		COMMENT ON COLUMN "accounts"."held_amount" IS 'Amount blocked from being debited, owed back for a transfer reversal the balance could not cover, stored in the smallest currency unit (paise for INR)';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downAddReversalColumnsToTransactionsAndAccountsTables(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		ALTER TABLE accounts DROP COLUMN held_amount;
+
+		DROP INDEX idx_transactions_counterpart_transaction_id;
+
+		ALTER TABLE transactions
+		DROP COLUMN reversed_transaction_id,
+		DROP COLUMN counterpart_transaction_id,
+		DROP COLUMN status;
+
+		DROP TYPE enum_transactions_status;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateTransferReversalsTable, downCreateTransferReversalsTable)
+}
+
+func upCreateTransferReversalsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_transfer_reversals_status AS ENUM ('ON_HOLD', 'COMPLETED');
+
+		CREATE TABLE transfer_reversals (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			original_transaction_id UUID NOT NULL UNIQUE REFERENCES transactions(id),
+			from_account_id BIGINT NOT NULL REFERENCES accounts(id),
+			to_account_id BIGINT NOT NULL REFERENCES accounts(id),
+			amount BIGINT NOT NULL CHECK (amount > 0),
+			reversed_amount BIGINT NOT NULL DEFAULT 0 CHECK (reversed_amount >= 0),
+			held_amount BIGINT NOT NULL DEFAULT 0 CHECK (held_amount >= 0),
+			status enum_transfer_reversals_status NOT NULL,
+			reason TEXT NOT NULL,
+			initiated_by UUID NOT NULL REFERENCES users(id),
+			completed_at TIMESTAMPTZ,
+			CHECK (reversed_amount + held_amount = amount)
+		);
+
+		CREATE INDEX idx_transfer_reversals_status ON transfer_reversals (status);
+
+		COMMENT ON COLUMN transfer_reversals.original_transaction_id IS 'DEBIT of the sender account of the reversed transfer, unique so that a transfer can only be reversed once';
+		COMMENT ON COLUMN transfer_reversals.from_account_id IS 'Account the reversed amount is taken back from, the receiver of the original transfer';
+		COMMENT ON COLUMN transfer_reversals.to_account_id IS 'Account the reversed amount is paid back to, the sender of the original transfer';
+		COMMENT ON COLUMN transfer_reversals.held_amount IS 'Amount still to be taken back, held on the from account until its balance covers it';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateTransferReversalsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE transfer_reversals;
+		DROP TYPE enum_transfer_reversals_status;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
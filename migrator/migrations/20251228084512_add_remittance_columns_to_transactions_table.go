@@ -0,0 +1,60 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddRemittanceColumnsToTransactionsTable, downAddRemittanceColumnsToTransactionsTable)
+}
+
+func upAddRemittanceColumnsToTransactionsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_transactions_category AS ENUM ('BILL_PAYMENT', 'EDUCATION', 'FAMILY', 'MEDICAL', 'RENT', 'SALARY', 'SHOPPING', 'TRAVEL', 'OTHER');
+
+		ALTER TABLE transactions
+		ADD COLUMN description TEXT,
+		ADD COLUMN narration TEXT CHECK (char_length(narration) <= 140),
+		ADD COLUMN client_reference VARCHAR(35),
+		ADD COLUMN category enum_transactions_category;
+
+		COMMENT ON COLUMN "transactions"."description" IS 'Description generated by the bank for the account holder, e.g. "Transfer to ****1234"';
+		COMMENT ON COLUMN "transactions"."narration" IS 'Free text the sender attached to the transfer, stored on both of its transactions';
+		COMMENT ON COLUMN "transactions"."client_reference" IS 'Reference the sender attached to the transfer, stored on both of its transactions';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downAddRemittanceColumnsToTransactionsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		ALTER TABLE transactions
+		DROP COLUMN category,
+		DROP COLUMN client_reference,
+		DROP COLUMN narration,
+		DROP COLUMN description;
+
+		DROP TYPE enum_transactions_category;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
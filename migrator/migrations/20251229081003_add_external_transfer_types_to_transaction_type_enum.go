@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddExternalTransferTypesToTransactionTypeEnum, downAddExternalTransferTypesToTransactionTypeEnum)
+}
+
+func upAddExternalTransferTypesToTransactionTypeEnum(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		ALTER TYPE enum_transactions_type ADD VALUE 'EXTERNAL_TRANSFER';
+		ALTER TYPE enum_transactions_type ADD VALUE 'EXTERNAL_TRANSFER_RETURN';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downAddExternalTransferTypesToTransactionTypeEnum(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	// postgres does not support dropping a value from an enum, so the enum is recreated without them
+	// NOTE: the rollback fails if any transaction of type 'EXTERNAL_TRANSFER' or 'EXTERNAL_TRANSFER_RETURN' exists, which is intentional
+	_, err := tx.Exec(`
+		ALTER TYPE enum_transactions_type RENAME TO enum_transactions_type_old;
+		CREATE TYPE enum_transactions_type AS ENUM ('DEBIT', 'CREDIT', 'OVERDRAFT_INTEREST', 'FEE', 'FEE_WAIVER', 'INTEREST_CREDIT', 'LOAN_DISBURSEMENT', 'LOAN_REPAYMENT', 'REVERSAL_DEBIT', 'REVERSAL_CREDIT');
+		ALTER TABLE transactions ALTER COLUMN type TYPE enum_transactions_type USING type::text::enum_transactions_type;
+		DROP TYPE enum_transactions_type_old;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,79 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreatePaymentOrdersTable, downCreatePaymentOrdersTable)
+}
+
+func upCreatePaymentOrdersTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_payment_orders_rail AS ENUM ('NEFT', 'IMPS', 'RTGS');
+		CREATE TYPE enum_payment_orders_status AS ENUM ('INITIATED', 'SENT', 'SETTLED', 'RETURNED');
+
+		CREATE TABLE payment_orders (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			user_id UUID NOT NULL REFERENCES users(id),
+			from_account_id BIGINT NOT NULL REFERENCES accounts(id),
+			amount BIGINT NOT NULL CHECK (amount > 0),
+			rail enum_payment_orders_rail NOT NULL,
+			beneficiary_name VARCHAR(100) NOT NULL,
+			beneficiary_account_number VARCHAR(18) NOT NULL,
+			beneficiary_ifsc CHAR(11) NOT NULL,
+			status enum_payment_orders_status NOT NULL DEFAULT 'INITIATED',
+			rail_reference VARCHAR(22) UNIQUE,
+			return_reason TEXT,
+			debit_transaction_id UUID NOT NULL UNIQUE REFERENCES transactions(id),
+			return_transaction_id UUID UNIQUE REFERENCES transactions(id),
+			dispatch_at TIMESTAMPTZ NOT NULL,
+			outcome_due_at TIMESTAMPTZ,
+			sent_at TIMESTAMPTZ,
+			settled_at TIMESTAMPTZ,
+			returned_at TIMESTAMPTZ
+		);
+
+		CREATE INDEX idx_payment_orders_user_id ON payment_orders (user_id);
+		CREATE INDEX idx_payment_orders_status ON payment_orders (status);
+
+		COMMENT ON COLUMN payment_orders.rail_reference IS 'Reference assigned to the order by the rail once it is sent (the UTR)';
+		COMMENT ON COLUMN payment_orders.debit_transaction_id IS 'EXTERNAL_TRANSFER debit of the customer account when the order was created';
+		COMMENT ON COLUMN payment_orders.return_transaction_id IS 'EXTERNAL_TRANSFER_RETURN credit of the customer account when the order was returned';
+		COMMENT ON COLUMN payment_orders.dispatch_at IS 'Time the order is sent over the rail at, e.g. the next NEFT batch';
+		COMMENT ON COLUMN payment_orders.outcome_due_at IS 'Time the rail settles or returns the order at, set once the order is sent';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreatePaymentOrdersTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE payment_orders;
+		DROP TYPE enum_payment_orders_status;
+		DROP TYPE enum_payment_orders_rail;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
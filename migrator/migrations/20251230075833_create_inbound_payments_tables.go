@@ -0,0 +1,102 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateInboundPaymentsTables, downCreateInboundPaymentsTables)
+}
+
+func upCreateInboundPaymentsTables(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_inbound_payment_files_format AS ENUM ('CSV', 'FIXED_WIDTH');
+		CREATE TYPE enum_inbound_payment_files_source AS ENUM ('UPLOAD', 'DIRECTORY');
+		CREATE TYPE enum_inbound_payments_status AS ENUM ('CREDITED', 'SUSPENSE', 'RETURNED', 'REJECTED');
+
+		CREATE TABLE inbound_payment_files (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			file_name VARCHAR(255) NOT NULL,
+			format enum_inbound_payment_files_format NOT NULL,
+			source enum_inbound_payment_files_source NOT NULL,
+			checksum CHAR(64) NOT NULL UNIQUE,
+			uploaded_by UUID REFERENCES users(id),
+			record_count INTEGER NOT NULL DEFAULT 0,
+			credited_count INTEGER NOT NULL DEFAULT 0,
+			suspense_count INTEGER NOT NULL DEFAULT 0,
+			rejected_count INTEGER NOT NULL DEFAULT 0
+		);
+
+		-- the fields of a record are kept as they were in the clearing file, so they are not limited in length: a rejected record may hold anything
+		CREATE TABLE inbound_payments (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			file_id UUID NOT NULL REFERENCES inbound_payment_files(id),
+			line_number INTEGER NOT NULL,
+			utr TEXT NOT NULL,
+			amount BIGINT NOT NULL CHECK (amount >= 0),
+			beneficiary_account_number TEXT NOT NULL,
+			beneficiary_ifsc TEXT NOT NULL,
+			remitter_name TEXT NOT NULL,
+			remitter_account_number TEXT NOT NULL,
+			remitter_ifsc TEXT NOT NULL,
+			status enum_inbound_payments_status NOT NULL,
+			reason TEXT,
+			raw_record TEXT,
+			account_id BIGINT REFERENCES accounts(id),
+			transaction_id UUID UNIQUE REFERENCES transactions(id),
+			resolved_by UUID REFERENCES users(id),
+			resolved_at TIMESTAMPTZ
+		);
+
+		CREATE INDEX idx_inbound_payments_file_id ON inbound_payments (file_id);
+		CREATE INDEX idx_inbound_payments_status ON inbound_payments (status);
+
+		-- a payment is only accepted once per UTR, a rejected record never was so it does not count
+		CREATE UNIQUE INDEX idx_inbound_payments_utr ON inbound_payments (utr) WHERE status <> 'REJECTED';
+
+		COMMENT ON COLUMN inbound_payment_files.checksum IS 'SHA-256 of the content of the file, so that the same file is never ingested twice';
+		COMMENT ON COLUMN inbound_payment_files.uploaded_by IS 'Admin who uploaded the file, NULL for a file picked up from the inbound directory';
+		COMMENT ON COLUMN inbound_payments.utr IS 'Reference assigned to the payment by the rail';
+		COMMENT ON COLUMN inbound_payments.reason IS 'Why the payment was parked in the suspense queue, rejected or returned';
+		COMMENT ON COLUMN inbound_payments.raw_record IS 'Line of the clearing file, only kept for a rejected record';
+		COMMENT ON COLUMN inbound_payments.transaction_id IS 'INBOUND_TRANSFER credit of the account the payment was credited to';
+		COMMENT ON COLUMN inbound_payments.resolved_by IS 'Admin who assigned or returned a payment of the suspense queue';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateInboundPaymentsTables(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE inbound_payments;
+		DROP TABLE inbound_payment_files;
+		DROP TYPE enum_inbound_payments_status;
+		DROP TYPE enum_inbound_payment_files_source;
+		DROP TYPE enum_inbound_payment_files_format;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddPacs008FormatToInboundPaymentFileFormatEnum, downAddPacs008FormatToInboundPaymentFileFormatEnum)
+}
+
+func upAddPacs008FormatToInboundPaymentFileFormatEnum(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		ALTER TYPE enum_inbound_payment_files_format ADD VALUE 'PACS_008';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downAddPacs008FormatToInboundPaymentFileFormatEnum(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	// postgres does not support dropping a value from an enum, so the enum is recreated without it
+	// NOTE: the rollback fails if any inbound payment file of format 'PACS_008' exists, which is intentional
+	_, err := tx.Exec(`
+		ALTER TYPE enum_inbound_payment_files_format RENAME TO enum_inbound_payment_files_format_old;
+		CREATE TYPE enum_inbound_payment_files_format AS ENUM ('CSV', 'FIXED_WIDTH');
+		ALTER TABLE inbound_payment_files ALTER COLUMN format TYPE enum_inbound_payment_files_format USING format::text::enum_inbound_payment_files_format;
+		DROP TYPE enum_inbound_payment_files_format_old;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateVpasTable, downCreateVpasTable)
+}
+
+func upCreateVpasTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TABLE vpas (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			address VARCHAR(71) NOT NULL UNIQUE,
+			user_id UUID NOT NULL REFERENCES users(id),
+			account_id BIGINT NOT NULL REFERENCES accounts(id),
+			CONSTRAINT vpas_address_lowercase CHECK (address = LOWER(address))
+		);
+
+		CREATE INDEX idx_vpas_user_id ON vpas (user_id);
+
+		COMMENT ON COLUMN vpas.address IS 'Virtual payment address like alice@gobank, stored in lower case';
+		COMMENT ON COLUMN vpas.account_id IS 'Account the payments to the VPA are credited to and collect requests sent to it are paid from';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateVpasTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE vpas;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateCollectRequestsTable, downCreateCollectRequestsTable)
+}
+
+func upCreateCollectRequestsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_collect_requests_status AS ENUM ('PENDING', 'APPROVED', 'DECLINED', 'EXPIRED');
+
+		CREATE TABLE collect_requests (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			payee_user_id UUID NOT NULL REFERENCES users(id),
+			payee_address VARCHAR(71) NOT NULL,
+			payee_account_id BIGINT NOT NULL REFERENCES accounts(id),
+			payer_user_id UUID NOT NULL REFERENCES users(id),
+			payer_address VARCHAR(71) NOT NULL,
+			amount BIGINT NOT NULL CHECK (amount > 0),
+			note VARCHAR(140),
+			status enum_collect_requests_status NOT NULL DEFAULT 'PENDING',
+			expires_at TIMESTAMPTZ NOT NULL,
+			transaction_id UUID REFERENCES transactions(id),
+			responded_at TIMESTAMPTZ
+		);
+
+		CREATE INDEX idx_collect_requests_payee_user_id ON collect_requests (payee_user_id);
+		CREATE INDEX idx_collect_requests_payer_user_id ON collect_requests (payer_user_id);
+		CREATE INDEX idx_collect_requests_status_expires_at ON collect_requests (status, expires_at);
+
+		COMMENT ON COLUMN collect_requests.payee_account_id IS 'Account linked to the VPA of the payee when the request was sent, the payment is credited to it';
+		COMMENT ON COLUMN collect_requests.transaction_id IS 'Transaction of the payer the request was paid with, set once it is approved';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateCollectRequestsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE collect_requests;
+		DROP TYPE enum_collect_requests_status;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
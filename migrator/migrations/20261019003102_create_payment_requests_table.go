@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreatePaymentRequestsTable, downCreatePaymentRequestsTable)
+}
+
+func upCreatePaymentRequestsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_payment_requests_status AS ENUM ('PENDING', 'PARTIALLY_PAID', 'PAID', 'CANCELLED', 'EXPIRED');
+
+		CREATE TABLE payment_requests (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			requester_user_id UUID NOT NULL REFERENCES users(id),
+			account_id BIGINT NOT NULL REFERENCES accounts(id),
+			token VARCHAR(32) NOT NULL UNIQUE,
+			amount BIGINT NOT NULL CHECK (amount > 0),
+			paid_amount BIGINT NOT NULL DEFAULT 0 CHECK (paid_amount >= 0 AND paid_amount <= amount),
+			note VARCHAR(140),
+			status enum_payment_requests_status NOT NULL DEFAULT 'PENDING',
+			expires_at TIMESTAMPTZ NOT NULL,
+			closed_at TIMESTAMPTZ
+		);
+
+		CREATE INDEX idx_payment_requests_requester_user_id ON payment_requests (requester_user_id);
+		CREATE INDEX idx_payment_requests_status_expires_at ON payment_requests (status, expires_at);
+
+		COMMENT ON COLUMN payment_requests.token IS 'Random token identifying the request in the link shared with the payers';
+		COMMENT ON COLUMN payment_requests.closed_at IS 'Time the request was fully paid, cancelled or expired at';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreatePaymentRequestsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE payment_requests;
+		DROP TYPE enum_payment_requests_status;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
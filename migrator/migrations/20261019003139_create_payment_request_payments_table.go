@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreatePaymentRequestPaymentsTable, downCreatePaymentRequestPaymentsTable)
+}
+
+func upCreatePaymentRequestPaymentsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TABLE payment_request_payments (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			payment_request_id UUID NOT NULL REFERENCES payment_requests(id),
+			payer_user_id UUID NOT NULL REFERENCES users(id),
+			amount BIGINT NOT NULL CHECK (amount > 0),
+			transaction_id UUID NOT NULL REFERENCES transactions(id)
+		);
+
+		CREATE INDEX idx_payment_request_payments_payment_request_id ON payment_request_payments (payment_request_id);
+
+		COMMENT ON COLUMN payment_request_payments.transaction_id IS 'Debit transaction of the account the payment was made from';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreatePaymentRequestPaymentsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE payment_request_payments;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
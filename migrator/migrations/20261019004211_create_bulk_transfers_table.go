@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateBulkTransfersTable, downCreateBulkTransfersTable)
+}
+
+func upCreateBulkTransfersTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_bulk_transfers_status AS ENUM ('VALIDATED', 'INVALID', 'CANCELLED', 'QUEUED', 'PROCESSING', 'COMPLETED', 'PARTIALLY_COMPLETED', 'FAILED', 'STOPPED');
+
+		CREATE TABLE bulk_transfers (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			user_id UUID NOT NULL REFERENCES users(id),
+			from_account_id BIGINT NOT NULL REFERENCES accounts(id),
+			file_name VARCHAR(255) NOT NULL,
+			status enum_bulk_transfers_status NOT NULL,
+			stop_on_insufficient_funds BOOLEAN NOT NULL DEFAULT FALSE,
+			item_count INT NOT NULL DEFAULT 0,
+			total_amount BIGINT NOT NULL DEFAULT 0,
+			estimated_fee BIGINT NOT NULL DEFAULT 0,
+			succeeded_count INT NOT NULL DEFAULT 0,
+			failed_count INT NOT NULL DEFAULT 0,
+			skipped_count INT NOT NULL DEFAULT 0,
+			stopped_at_line_number INT,
+			confirmed_at TIMESTAMPTZ,
+			completed_at TIMESTAMPTZ
+		);
+
+		CREATE INDEX idx_bulk_transfers_user_id ON bulk_transfers (user_id);
+
+		COMMENT ON COLUMN bulk_transfers.estimated_fee IS 'Fee (including its tax) the transfers were estimated to cost at the time of the upload';
+		COMMENT ON COLUMN bulk_transfers.stopped_at_line_number IS 'Row the account could not afford, for a batch stopped on insufficient funds';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateBulkTransfersTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE bulk_transfers;
+		DROP TYPE enum_bulk_transfers_status;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
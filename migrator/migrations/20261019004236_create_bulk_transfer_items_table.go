@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateBulkTransferItemsTable, downCreateBulkTransferItemsTable)
+}
+
+func upCreateBulkTransferItemsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_bulk_transfer_items_status AS ENUM ('PENDING', 'INVALID', 'SUCCEEDED', 'FAILED', 'SKIPPED');
+
+		CREATE TABLE bulk_transfer_items (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			bulk_transfer_id UUID NOT NULL REFERENCES bulk_transfers(id),
+			line_number INT NOT NULL,
+			to_account_id BIGINT NOT NULL,
+			amount BIGINT NOT NULL CHECK (amount >= 0),
+			narration VARCHAR(140),
+			status enum_bulk_transfer_items_status NOT NULL,
+			reason TEXT,
+			raw_row TEXT,
+			transaction_id UUID REFERENCES transactions(id),
+			processed_at TIMESTAMPTZ,
+			CONSTRAINT bulk_transfer_items_bulk_transfer_id_line_number_unique UNIQUE (bulk_transfer_id, line_number)
+		);
+
+		COMMENT ON COLUMN bulk_transfer_items.to_account_id IS 'Not a foreign key, an invalid row may reference an account that does not exist';
+		COMMENT ON COLUMN bulk_transfer_items.raw_row IS 'Line of the file, only kept for an invalid row';
+		COMMENT ON COLUMN bulk_transfer_items.transaction_id IS 'Debit transaction of the account the batch is paid from';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateBulkTransferItemsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE bulk_transfer_items;
+		DROP TYPE enum_bulk_transfer_items_status;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
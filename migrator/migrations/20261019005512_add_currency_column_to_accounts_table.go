@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddCurrencyColumnToAccountsTable, downAddCurrencyColumnToAccountsTable)
+}
+
+func upAddCurrencyColumnToAccountsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	/*
+		Every existing account is held in INR, the currency the bank operated in so far.
+		A user can now have one savings and one current account per currency.
+	*/
+	_, err := tx.Exec(`
+		ALTER TABLE accounts ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'INR';
+
+		COMMENT ON COLUMN accounts.currency IS 'ISO 4217 code of the currency the balance is held in';
+
+		DROP INDEX accounts_user_id_type_unique;
+
+		CREATE UNIQUE INDEX accounts_user_id_type_currency_unique ON accounts (user_id, type, currency) WHERE type IN ('SAVINGS_ACCOUNT', 'CURRENT_ACCOUNT');
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downAddCurrencyColumnToAccountsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	// NOTE: the rollback fails if any user has a savings or current account in more than one currency, which is intentional
+	_, err := tx.Exec(`
+		DROP INDEX accounts_user_id_type_currency_unique;
+
+		CREATE UNIQUE INDEX accounts_user_id_type_unique ON accounts (user_id, type) WHERE type IN ('SAVINGS_ACCOUNT', 'CURRENT_ACCOUNT');
+
+		ALTER TABLE accounts DROP COLUMN currency;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,47 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddCurrencyColumnToInternalAccountsTable, downAddCurrencyColumnToInternalAccountsTable)
+}
+
+func upAddCurrencyColumnToInternalAccountsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	// every existing internal account is held in INR, the currency the bank operated in so far
+	_, err := tx.Exec(`
+		ALTER TABLE internal_accounts ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'INR';
+
+		COMMENT ON COLUMN internal_accounts.currency IS 'ISO 4217 code of the currency the balance is held in';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downAddCurrencyColumnToInternalAccountsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		ALTER TABLE internal_accounts DROP COLUMN currency;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,60 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateFxRatesTable, downCreateFxRatesTable)
+}
+
+func upCreateFxRatesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_fx_rates_source AS ENUM ('ADMIN', 'FILE');
+
+		CREATE TABLE fx_rates (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			base_currency VARCHAR(3) NOT NULL,
+			quote_currency VARCHAR(3) NOT NULL,
+			rate BIGINT NOT NULL CHECK (rate > 0),
+			source enum_fx_rates_source NOT NULL,
+			published_by_user_id UUID NOT NULL REFERENCES users(id),
+			CONSTRAINT fx_rates_currencies_differ CHECK (base_currency <> quote_currency)
+		);
+
+		CREATE INDEX idx_fx_rates_base_currency_quote_currency_created_at ON fx_rates (base_currency, quote_currency, created_at DESC);
+
+		COMMENT ON COLUMN fx_rates.rate IS 'Price of one unit of the base currency in the quote currency, scaled by 10^8';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateFxRatesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE fx_rates;
+		DROP TYPE enum_fx_rates_source;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateFxQuotesTable, downCreateFxQuotesTable)
+}
+
+func upCreateFxQuotesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_fx_quotes_status AS ENUM ('OPEN', 'USED');
+
+		CREATE TABLE fx_quotes (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			user_id UUID NOT NULL REFERENCES users(id),
+			from_account_id BIGINT NOT NULL REFERENCES accounts(id),
+			to_account_id BIGINT NOT NULL REFERENCES accounts(id),
+			source_currency VARCHAR(3) NOT NULL,
+			source_amount BIGINT NOT NULL CHECK (source_amount > 0),
+			target_currency VARCHAR(3) NOT NULL,
+			target_amount BIGINT NOT NULL CHECK (target_amount > 0),
+			fx_rate_id UUID NOT NULL REFERENCES fx_rates(id),
+			rate BIGINT NOT NULL CHECK (rate > 0),
+			margin_in_basis_points BIGINT NOT NULL DEFAULT 0,
+			status enum_fx_quotes_status NOT NULL DEFAULT 'OPEN',
+			expires_at TIMESTAMPTZ NOT NULL,
+			transaction_id UUID REFERENCES transactions(id),
+			used_at TIMESTAMPTZ
+		);
+
+		CREATE INDEX idx_fx_quotes_user_id ON fx_quotes (user_id);
+
+		COMMENT ON COLUMN fx_quotes.rate IS 'Rate the customer is given, the margin already taken off the published rate, scaled by 10^8';
+		COMMENT ON COLUMN fx_quotes.transaction_id IS 'Debit transaction of the from account once the quote is used';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateFxQuotesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE fx_quotes;
+		DROP TYPE enum_fx_quotes_status;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
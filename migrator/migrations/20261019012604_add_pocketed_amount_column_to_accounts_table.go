@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddPocketedAmountColumnToAccountsTable, downAddPocketedAmountColumnToAccountsTable)
+}
+
+func upAddPocketedAmountColumnToAccountsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		ALTER TABLE accounts ADD COLUMN pocketed_amount BIGINT NOT NULL DEFAULT 0 CHECK (pocketed_amount >= 0);
+
+		COMMENT ON COLUMN accounts.pocketed_amount IS 'Total balance of the pockets of the account, part of the balance but not available to be debited';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downAddPocketedAmountColumnToAccountsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		ALTER TABLE accounts DROP COLUMN pocketed_amount;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
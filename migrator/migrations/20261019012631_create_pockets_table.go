@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreatePocketsTable, downCreatePocketsTable)
+}
+
+func upCreatePocketsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_pockets_status AS ENUM ('ACTIVE', 'CLOSED');
+
+		CREATE TABLE pockets (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			account_id BIGINT NOT NULL REFERENCES accounts(id),
+			name VARCHAR(50) NOT NULL,
+			balance BIGINT NOT NULL DEFAULT 0 CHECK (balance >= 0),
+			target_amount BIGINT NOT NULL CHECK (target_amount > 0),
+			target_date DATE,
+			round_up_to BIGINT CHECK (round_up_to > 0),
+			status enum_pockets_status NOT NULL DEFAULT 'ACTIVE',
+			closed_at TIMESTAMPTZ
+		);
+
+		CREATE INDEX idx_pockets_account_id ON pockets (account_id);
+
+		-- the round-ups of an account are swept into at most one of its active pockets
+		CREATE UNIQUE INDEX pockets_account_id_round_up_unique ON pockets (account_id) WHERE status = 'ACTIVE' AND round_up_to IS NOT NULL;
+
+		COMMENT ON COLUMN pockets.round_up_to IS 'Payments of the account are rounded up to a multiple of it and the difference swept into the pocket, in the smallest currency unit';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreatePocketsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE pockets;
+		DROP TYPE enum_pockets_status;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreatePocketMovementsTable, downCreatePocketMovementsTable)
+}
+
+func upCreatePocketMovementsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_pocket_movements_type AS ENUM ('DEPOSIT', 'WITHDRAWAL', 'ROUND_UP');
+
+		CREATE TABLE pocket_movements (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			pocket_id UUID NOT NULL REFERENCES pockets(id),
+			type enum_pocket_movements_type NOT NULL,
+			amount BIGINT NOT NULL CHECK (amount > 0),
+			balance_after BIGINT NOT NULL,
+			transaction_id UUID REFERENCES transactions(id)
+		);
+
+		CREATE INDEX idx_pocket_movements_pocket_id_created_at ON pocket_movements (pocket_id, created_at DESC);
+
+		COMMENT ON COLUMN pocket_movements.transaction_id IS 'Payment whose spare change was swept into the pocket, set on a ROUND_UP';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreatePocketMovementsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE pocket_movements;
+		DROP TYPE enum_pocket_movements_type;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
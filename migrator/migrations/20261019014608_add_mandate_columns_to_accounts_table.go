@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddMandateColumnsToAccountsTable, downAddMandateColumnsToAccountsTable)
+}
+
+func upAddMandateColumnsToAccountsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_accounts_mandate AS ENUM ('EITHER_OR_SURVIVOR', 'JOINTLY');
+
+		ALTER TABLE accounts
+		ADD COLUMN mandate enum_accounts_mandate NOT NULL DEFAULT 'EITHER_OR_SURVIVOR',
+		ADD COLUMN joint_approval_threshold BIGINT NOT NULL DEFAULT 0 CHECK (joint_approval_threshold >= 0);
+
+		COMMENT ON COLUMN accounts.user_id IS 'Primary holder of the account, its joint holders are stored in account_holders';
+		COMMENT ON COLUMN accounts.joint_approval_threshold IS 'Under the JOINTLY mandate, debits above it need the approval of every holder, in the smallest currency unit';
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downAddMandateColumnsToAccountsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		COMMENT ON COLUMN accounts.user_id IS NULL;
+		ALTER TABLE accounts DROP COLUMN joint_approval_threshold, DROP COLUMN mandate;
+		DROP TYPE enum_accounts_mandate;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
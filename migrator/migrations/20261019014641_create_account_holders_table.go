@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateAccountHoldersTable, downCreateAccountHoldersTable)
+}
+
+func upCreateAccountHoldersTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_account_holders_status AS ENUM ('INVITED', 'ACTIVE', 'DECLINED', 'REMOVED');
+
+		CREATE TABLE account_holders (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			account_id BIGINT NOT NULL REFERENCES accounts(id),
+			user_id UUID NOT NULL REFERENCES users(id),
+			invited_by_user_id UUID NOT NULL REFERENCES users(id),
+			status enum_account_holders_status NOT NULL DEFAULT 'INVITED',
+			responded_at TIMESTAMPTZ,
+			removed_at TIMESTAMPTZ
+		);
+
+		-- a user holds or is invited to an account at most once, a declined or removed holder can be invited again
+		CREATE UNIQUE INDEX account_holders_account_id_user_id_unique ON account_holders (account_id, user_id) WHERE status IN ('INVITED', 'ACTIVE');
+
+		CREATE INDEX idx_account_holders_user_id ON account_holders (user_id);
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateAccountHoldersTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE account_holders;
+		DROP TYPE enum_account_holders_status;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
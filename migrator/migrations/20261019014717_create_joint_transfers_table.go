@@ -0,0 +1,63 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateJointTransfersTable, downCreateJointTransfersTable)
+}
+
+func upCreateJointTransfersTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_joint_transfers_status AS ENUM ('PENDING', 'COMPLETED', 'REJECTED');
+
+		CREATE TABLE joint_transfers (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			initiated_by_user_id UUID NOT NULL REFERENCES users(id),
+			from_account_id BIGINT NOT NULL REFERENCES accounts(id),
+			to_account_id BIGINT NOT NULL REFERENCES accounts(id),
+			amount BIGINT NOT NULL CHECK (amount > 0),
+			narration VARCHAR(140),
+			client_reference VARCHAR(35),
+			category enum_transactions_category,
+			status enum_joint_transfers_status NOT NULL DEFAULT 'PENDING',
+			transaction_id UUID REFERENCES transactions(id),
+			executed_at TIMESTAMPTZ
+		);
+
+		CREATE INDEX idx_joint_transfers_from_account_id_created_at ON joint_transfers (from_account_id, created_at DESC);
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateJointTransfersTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE joint_transfers;
+		DROP TYPE enum_joint_transfers_status;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateJointTransferApprovalsTable, downCreateJointTransferApprovalsTable)
+}
+
+func upCreateJointTransferApprovalsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_joint_transfer_approvals_decision AS ENUM ('APPROVED', 'REJECTED');
+
+		CREATE TABLE joint_transfer_approvals (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			joint_transfer_id UUID NOT NULL REFERENCES joint_transfers(id),
+			user_id UUID NOT NULL REFERENCES users(id),
+			decision enum_joint_transfer_approvals_decision NOT NULL,
+			CONSTRAINT joint_transfer_approvals_joint_transfer_id_user_id_unique UNIQUE (joint_transfer_id, user_id)
+		);
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateJointTransferApprovalsTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE joint_transfer_approvals;
+		DROP TYPE enum_joint_transfer_approvals_decision;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateNomineesTable, downCreateNomineesTable)
+}
+
+func upCreateNomineesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	logMigrationStatus("⬆️ Applying migration")
+
+	_, err := tx.Exec(`
+		CREATE TYPE enum_nominees_relationship AS ENUM ('SPOUSE', 'CHILD', 'PARENT', 'SIBLING', 'OTHER');
+		CREATE TYPE enum_nominees_status AS ENUM ('ACTIVE', 'REMOVED');
+
+		CREATE TABLE nominees (
+			id UUID PRIMARY KEY NOT NULL DEFAULT GEN_RANDOM_UUID(),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			account_id BIGINT NOT NULL REFERENCES accounts(id),
+			name VARCHAR(100) NOT NULL,
+			relationship enum_nominees_relationship NOT NULL,
+			date_of_birth DATE NOT NULL,
+			share_percentage INT NOT NULL CHECK (share_percentage BETWEEN 1 AND 100),
+			guardian_name VARCHAR(100),
+			guardian_relationship VARCHAR(50),
+			status enum_nominees_status NOT NULL DEFAULT 'ACTIVE',
+			removed_at TIMESTAMPTZ,
+			CONSTRAINT nominees_guardian_check CHECK ((guardian_name IS NULL) = (guardian_relationship IS NULL))
+		);
+
+		COMMENT ON COLUMN nominees.share_percentage IS 'The shares of the active nominees of an account add up to 100, which is enforced by the application';
+
+		CREATE INDEX idx_nominees_account_id ON nominees (account_id);
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Applying migration failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Migration applied")
+	return nil
+}
+
+func downCreateNomineesTable(ctx context.Context, tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	logMigrationStatus("⬇️ Rolling back migration")
+
+	_, err := tx.Exec(`
+		DROP TABLE nominees;
+		DROP TYPE enum_nominees_status;
+		DROP TYPE enum_nominees_relationship;
+	`)
+	if err != nil {
+		logMigrationStatus("❌ Rollback failed")
+		return err
+	}
+
+	logMigrationStatus("✅ Rollback done")
+	return nil
+}
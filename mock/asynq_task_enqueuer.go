@@ -12,6 +12,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	tasks "github.com/skamranahmed/go-bank/pkg/tasks"
 	gomock "go.uber.org/mock/gomock"
@@ -68,3 +69,17 @@ func (mr *MockTaskEnqueuerMockRecorder) Enqueue(ctx, task, maxRetryCount, queueN
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockTaskEnqueuer)(nil).Enqueue), ctx, task, maxRetryCount, queueName)
 }
+
+// EnqueueAt mocks base method.
+func (m *MockTaskEnqueuer) EnqueueAt(ctx context.Context, task tasks.Task, processAt time.Time, maxRetryCount *int, queueName *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueAt", ctx, task, processAt, maxRetryCount, queueName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueAt indicates an expected call of EnqueueAt.
+func (mr *MockTaskEnqueuerMockRecorder) EnqueueAt(ctx, task, processAt, maxRetryCount, queueName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueAt", reflect.TypeOf((*MockTaskEnqueuer)(nil).EnqueueAt), ctx, task, processAt, maxRetryCount, queueName)
+}
@@ -0,0 +1,91 @@
+package accountnumber
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+/*
+An account number is 16 digits long and is made up of:
+  - a 2 digit product code identifying the type of the account
+  - a 13 digit random body
+  - a Luhn check digit, so that a mistyped digit or two swapped adjacent digits are caught before the account is looked up
+
+Accounts opened before check digits were introduced keep their legacy numbers, random numbers of 10 to 15 digits without a check digit.
+The two formats are told apart by their length, so the check digit is only verified on the numbers of the current format.
+*/
+const (
+	Length = 16
+
+	bodyLength = 13
+
+	legacyMinLength = 10
+	legacyMaxLength = 15
+)
+
+// Generate returns a new random account number for the given 2 digit product code
+func Generate(productCode int) (int64, error) {
+	if productCode < 10 || productCode > 99 {
+		return 0, fmt.Errorf("product code must be 2 digits, got: %d", productCode)
+	}
+
+	body, err := rand.Int(rand.Reader, big.NewInt(pow10(bodyLength)))
+	if err != nil {
+		return 0, err
+	}
+
+	payload := int64(productCode)*pow10(bodyLength) + body.Int64()
+	return payload*10 + checkDigit(payload), nil
+}
+
+// IsValid reports whether the account number is a legacy account number, or has the right length and a correct check digit
+func IsValid(accountNumber int64) bool {
+	if isLegacy(accountNumber) {
+		return true
+	}
+
+	if accountNumber < pow10(Length-1) || accountNumber >= pow10(Length) {
+		return false
+	}
+
+	return checkDigit(accountNumber/10) == accountNumber%10
+}
+
+// isLegacy reports whether the account number has the length of the numbers given to accounts before check digits were introduced
+func isLegacy(accountNumber int64) bool {
+	return accountNumber >= pow10(legacyMinLength-1) && accountNumber < pow10(legacyMaxLength)
+}
+
+// checkDigit returns the Luhn check digit of the payload
+func checkDigit(payload int64) int64 {
+	var sum int64
+	for position := 0; payload > 0; position++ {
+		digit := payload % 10
+		payload /= 10
+
+		// every second digit from the right, starting with the rightmost digit of the payload, is doubled
+		if position%2 == 0 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+
+	return (10 - sum%10) % 10
+}
+
+func pow10(exponent int) int64 {
+	result := int64(1)
+	for i := 0; i < exponent; i++ {
+		result *= 10
+	}
+	return result
+}
+
+// Mask returns the account number with all but its last 4 digits masked, e.g. "****1234"
+func Mask(accountNumber int64) string {
+	return fmt.Sprintf("****%04d", accountNumber%10000)
+}
@@ -0,0 +1,142 @@
+package accountnumber
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	countryCodeLength = 2
+	checkDigitsLength = 2
+	bankCodeLength    = 4
+	branchCodeLength  = 6
+
+	// IBANLength is the length of the IBAN-like identifier of an account, without any spaces
+	IBANLength = countryCodeLength + checkDigitsLength + bankCodeLength + branchCodeLength + Length
+)
+
+// IBANComponents are the parts an IBAN-like identifier is made up of
+type IBANComponents struct {
+	CountryCode   string
+	BankCode      string
+	BranchCode    string
+	AccountNumber int64
+}
+
+// IFSC returns the 11 character code of a branch: the 4 letter bank code, a "0" reserved for future use and the 6 character branch code
+func IFSC(bankCode string, branchCode string) string {
+	return bankCode + "0" + branchCode
+}
+
+// IsValidIFSC reports whether the code has the layout of an IFSC: 4 letters, a "0" and 6 letters or digits
+func IsValidIFSC(ifsc string) bool {
+	if len(ifsc) != bankCodeLength+1+branchCodeLength {
+		return false
+	}
+
+	for i, character := range ifsc {
+		isLetter := character >= 'A' && character <= 'Z'
+		isDigit := character >= '0' && character <= '9'
+		switch {
+		case i < bankCodeLength && !isLetter:
+			return false
+		case i == bankCodeLength && character != '0':
+			return false
+		case i > bankCodeLength && !isLetter && !isDigit:
+			return false
+		}
+	}
+	return true
+}
+
+// IFSCBankCode returns the 4 letter bank code an IFSC starts with, the IFSC must be valid
+func IFSCBankCode(ifsc string) string {
+	return ifsc[:bankCodeLength]
+}
+
+/*
+IBAN returns the IBAN-like identifier of an account, in the ISO 13616 layout:
+the country code, 2 mod-97 check digits and the basic bank account number, which is the bank code, the branch code and the account number
+
+It returns an error when a code does not have its length or has a character that cannot be part of an IBAN.
+*/
+func IBAN(countryCode string, bankCode string, branchCode string, accountNumber int64) (string, error) {
+	if len(bankCode) != bankCodeLength || len(branchCode) != branchCodeLength {
+		return "", fmt.Errorf("IBAN bank code must be %d characters and branch code %d characters", bankCodeLength, branchCodeLength)
+	}
+
+	basicBankAccountNumber := fmt.Sprintf("%s%s%0*d", bankCode, branchCode, Length, accountNumber)
+	checkDigits, err := ibanCheckDigits(countryCode, basicBankAccountNumber)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s%02d%s", countryCode, checkDigits, basicBankAccountNumber), nil
+}
+
+// ParseIBAN verifies the check digits of an IBAN-like identifier and splits it into its components, spaces and letter case are ignored
+func ParseIBAN(iban string) (*IBANComponents, error) {
+	iban = strings.ToUpper(strings.ReplaceAll(iban, " ", ""))
+	if len(iban) != IBANLength {
+		return nil, fmt.Errorf("IBAN must be %d characters long", IBANLength)
+	}
+
+	countryCode := iban[:countryCodeLength]
+	checkDigits := iban[countryCodeLength : countryCodeLength+checkDigitsLength]
+	basicBankAccountNumber := iban[countryCodeLength+checkDigitsLength:]
+
+	expectedCheckDigits, err := ibanCheckDigits(countryCode, basicBankAccountNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if checkDigits != fmt.Sprintf("%02d", expectedCheckDigits) {
+		return nil, errors.New("IBAN check digits do not match")
+	}
+
+	accountNumber, err := strconv.ParseInt(basicBankAccountNumber[bankCodeLength+branchCodeLength:], 10, 64)
+	if err != nil || !IsValid(accountNumber) {
+		return nil, errors.New("IBAN does not contain a valid account number")
+	}
+
+	return &IBANComponents{
+		CountryCode:   countryCode,
+		BankCode:      basicBankAccountNumber[:bankCodeLength],
+		BranchCode:    basicBankAccountNumber[bankCodeLength : bankCodeLength+branchCodeLength],
+		AccountNumber: accountNumber,
+	}, nil
+}
+
+/*
+ibanCheckDigits returns the ISO 7064 mod-97 check digits of an IBAN
+
+The basic bank account number is followed by the country code and "00", every letter is replaced by 2 digits (A = 10, ..., Z = 35)
+and the check digits are 98 minus the remainder of the resulting number divided by 97.
+It returns an error when the country code is not 2 letters or the basic bank account number has a character other than a letter or a digit.
+*/
+func ibanCheckDigits(countryCode string, basicBankAccountNumber string) (int, error) {
+	if len(countryCode) != countryCodeLength {
+		return 0, fmt.Errorf("IBAN country code must be %d letters", countryCodeLength)
+	}
+	for _, character := range countryCode {
+		if character < 'A' || character > 'Z' {
+			return 0, fmt.Errorf("IBAN country code must be %d letters", countryCodeLength)
+		}
+	}
+
+	remainder := 0
+	for _, character := range basicBankAccountNumber + countryCode + "00" {
+		switch {
+		case character >= '0' && character <= '9':
+			remainder = (remainder*10 + int(character-'0')) % 97
+		case character >= 'A' && character <= 'Z':
+			remainder = (remainder*100 + int(character-'A') + 10) % 97
+		default:
+			return 0, fmt.Errorf("IBAN cannot contain the character %q", character)
+		}
+	}
+
+	return 98 - remainder, nil
+}
@@ -0,0 +1,76 @@
+package accountnumber
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	minVPAHandleLength   = 3
+	maxVPAHandleLength   = 50
+	minVPAProviderLength = 2
+	maxVPAProviderLength = 20
+
+	// MaxVPALength is the length of the longest virtual payment address
+	MaxVPALength = maxVPAHandleLength + 1 + maxVPAProviderLength
+)
+
+// VPAComponents are the parts a virtual payment address like "alice@gobank" is made up of
+type VPAComponents struct {
+	// Handle is the part chosen by the holder of the VPA, before the "@"
+	Handle string
+
+	// Provider is the handle of the bank the VPA is registered at, after the "@"
+	Provider string
+}
+
+// Address returns the VPA the components make up
+func (c VPAComponents) Address() string {
+	return c.Handle + "@" + c.Provider
+}
+
+/*
+ParseVPA verifies the layout of a virtual payment address and splits it into its components, surrounding spaces and letter case are ignored
+
+The handle is 3 to 50 lower case letters, digits or the characters . - _ and starts and ends with a letter or digit,
+the provider is 2 to 20 lower case letters or digits.
+*/
+func ParseVPA(address string) (*VPAComponents, error) {
+	address = strings.ToLower(strings.TrimSpace(address))
+
+	handle, provider, found := strings.Cut(address, "@")
+	if !found {
+		return nil, errors.New("a VPA must be of the form handle@provider")
+	}
+
+	if len(handle) < minVPAHandleLength || len(handle) > maxVPAHandleLength {
+		return nil, errors.New("the handle of a VPA must be 3 to 50 characters long")
+	}
+	for i, character := range handle {
+		isAlphanumeric := (character >= 'a' && character <= 'z') || (character >= '0' && character <= '9')
+		if isAlphanumeric {
+			continue
+		}
+		if i == 0 || i == len(handle)-1 || !strings.ContainsRune(".-_", character) {
+			return nil, errors.New("the handle of a VPA can only contain letters, digits and the characters . - _ between them")
+		}
+	}
+
+	if len(provider) < minVPAProviderLength || len(provider) > maxVPAProviderLength {
+		return nil, errors.New("the provider of a VPA must be 2 to 20 characters long")
+	}
+	for _, character := range provider {
+		isAlphanumeric := (character >= 'a' && character <= 'z') || (character >= '0' && character <= '9')
+		if !isAlphanumeric {
+			return nil, errors.New("the provider of a VPA can only contain letters and digits")
+		}
+	}
+
+	return &VPAComponents{Handle: handle, Provider: provider}, nil
+}
+
+// IsValidVPA reports whether the address has the layout of a virtual payment address, see ParseVPA
+func IsValidVPA(address string) bool {
+	_, err := ParseVPA(address)
+	return err == nil
+}
@@ -0,0 +1,163 @@
+package bulktransferfile
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/skamranahmed/go-bank/pkg/accountnumber"
+)
+
+/*
+A bulk transfer file lists the transfers a business customer pays out of one of its accounts in a single batch, eg: a payroll.
+
+It is a CSV file with a header row naming the columns in the order of csvColumns, every other line being a transfer
+made up of the account number it is paid to, its amount in the smallest currency unit (paise for INR) and an optional narration.
+*/
+var csvColumns = []string{
+	"to_account",
+	"amount",
+	"narration",
+}
+
+// maxNarrationLength is the same limit as the narration of a single transfer
+const maxNarrationLength = 140
+
+// Row is a single transfer of a bulk transfer file, Err is set when the line could not be parsed into a transfer
+type Row struct {
+	LineNumber int
+	Raw        string
+
+	ToAccountID int64
+	Amount      int64
+	Narration   string
+
+	Err error
+}
+
+// Parse splits the bulk transfer file into its rows, an error is only returned when the file as a whole cannot be read
+func Parse(content []byte) ([]Row, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1 // a row with the wrong number of fields is rejected on its own, not the whole file
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.New("bulk transfer file is empty")
+	}
+	if strings.Join(header, ",") != strings.Join(csvColumns, ",") {
+		return nil, fmt.Errorf("bulk transfer file header must be: %s", strings.Join(csvColumns, ","))
+	}
+
+	var rows []Row
+	for {
+		values, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		lineNumber, _ := reader.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			rows = append(rows, Row{LineNumber: parseErr.Line, Err: errors.New("malformed CSV line")})
+			continue
+		}
+
+		row := Row{LineNumber: lineNumber, Raw: strings.Join(values, ",")}
+		if len(values) != len(csvColumns) {
+			row.Err = fmt.Errorf("expected %d fields, got %d", len(csvColumns), len(values))
+			rows = append(rows, row)
+			continue
+		}
+
+		rows = append(rows, buildRow(row, values))
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("bulk transfer file has no transfers")
+	}
+	return rows, nil
+}
+
+// buildRow fills the row from the values of its fields, in the order of csvColumns
+func buildRow(row Row, values []string) Row {
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+
+	row.Narration = values[2]
+
+	toAccountID, toAccountErr := strconv.ParseInt(values[0], 10, 64)
+	amount, amountErr := strconv.ParseInt(values[1], 10, 64)
+	switch {
+	case values[0] == "":
+		row.Err = errors.New("to_account is missing")
+	case toAccountErr != nil || !accountnumber.IsValid(toAccountID):
+		row.Err = errors.New("to_account is not a valid account number")
+	case amountErr != nil || amount <= 0:
+		row.Err = errors.New("amount must be a positive number of paise")
+	case utf8.RuneCountInString(row.Narration) > maxNarrationLength:
+		row.Err = fmt.Errorf("narration must be at most %d characters", maxNarrationLength)
+	}
+	row.ToAccountID = toAccountID
+	row.Amount = amount
+
+	return row
+}
+
+// ResultEntry is the outcome of a single row of a bulk transfer file, as reported back to the customer
+type ResultEntry struct {
+	LineNumber    int
+	ToAccount     string
+	Amount        int64
+	Narration     string
+	Status        string
+	TransactionID string
+	Reason        string
+}
+
+var resultColumns = []string{
+	"line_number",
+	"to_account",
+	"amount",
+	"narration",
+	"status",
+	"transaction_id",
+	"reason",
+}
+
+// WriteResult returns the result file of a bulk transfer file, a CSV file with one line per row of the bulk transfer file
+func WriteResult(entries []ResultEntry) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	err := writer.Write(resultColumns)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		err = writer.Write([]string{
+			strconv.Itoa(entry.LineNumber),
+			entry.ToAccount,
+			strconv.FormatInt(entry.Amount, 10),
+			entry.Narration,
+			entry.Status,
+			entry.TransactionID,
+			entry.Reason,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
@@ -10,6 +10,8 @@ type CacheClient interface {
 	Set(ctx context.Context, key string, value any) error
 	SetWithTTL(ctx context.Context, key string, value any, expiration time.Duration) error
 	Delete(ctx context.Context, key string) error
+	GetAndDelete(ctx context.Context, key string) (any, error)
+	Increment(ctx context.Context, key string, expiration time.Duration) (int64, error)
 	Ping() error
 	Close() error
 }
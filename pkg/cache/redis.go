@@ -50,6 +50,26 @@ func (r *redisClient) Delete(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
 
+// GetAndDelete atomically gets the value at the key and deletes the key, so that only one of concurrent callers gets the value
+func (r *redisClient) GetAndDelete(ctx context.Context, key string) (any, error) {
+	return r.client.GetDel(ctx, key).Result()
+}
+
+// Increment atomically increments the counter at the key and returns its new value
+// The expiration is set only when the counter is created, so the counter resets once the expiration passes
+func (r *redisClient) Increment(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	var incrementCmd *redis.IntCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incrementCmd = pipe.Incr(ctx, key)
+		pipe.ExpireNX(ctx, key, expiration)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return incrementCmd.Val(), nil
+}
+
 func (r *redisClient) Close() error {
 	return r.client.Close()
 }
@@ -0,0 +1,283 @@
+package clearingfile
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/skamranahmed/go-bank/pkg/iso20022"
+)
+
+/*
+A clearing file lists the payments the clearing house has settled with the bank, one record per payment.
+
+It comes in one of 3 formats:
+  - CSV, with a header row naming the columns in the order of csvColumns
+  - FIXED_WIDTH, without a header, every line holding the fields of fixedWidthFields padded with spaces to their width
+  - PACS_008, an ISO 20022 pacs.008 credit transfer message, every CdtTrfTxInf element being a record
+
+Every record is made up of the UTR of the payment, its amount in the smallest currency unit (paise for INR),
+the account number and IFSC of the beneficiary and the name, account number and IFSC of the remitter.
+*/
+type Format string
+
+const (
+	CSV        Format = "CSV"
+	FixedWidth Format = "FIXED_WIDTH"
+	Pacs008    Format = "PACS_008"
+)
+
+// Record is a single payment of a clearing file, Err is set when the line could not be parsed into a payment
+type Record struct {
+	LineNumber int
+	Raw        string
+
+	UTR                      string
+	Amount                   int64
+	BeneficiaryAccountNumber string
+	BeneficiaryIFSC          string
+	RemitterName             string
+	RemitterAccountNumber    string
+	RemitterIFSC             string
+
+	// Currency is only set by formats that carry it, the other formats are in the currency of the bank
+	Currency string
+
+	Err error
+}
+
+var csvColumns = []string{
+	"utr",
+	"amount",
+	"beneficiary_account_number",
+	"beneficiary_ifsc",
+	"remitter_name",
+	"remitter_account_number",
+	"remitter_ifsc",
+}
+
+type field struct {
+	name  string
+	width int
+}
+
+var fixedWidthFields = []field{
+	{name: "utr", width: 22},
+	{name: "amount", width: 15},
+	{name: "beneficiary_account_number", width: 18},
+	{name: "beneficiary_ifsc", width: 11},
+	{name: "remitter_name", width: 35},
+	{name: "remitter_account_number", width: 18},
+	{name: "remitter_ifsc", width: 11},
+}
+
+// Parse splits the clearing file into its records, an error is only returned when the file as a whole cannot be read
+func Parse(format Format, content []byte) ([]Record, error) {
+	switch format {
+	case CSV:
+		return parseCSV(content)
+	case FixedWidth:
+		return parseFixedWidth(content)
+	case Pacs008:
+		return parsePacs008(content)
+	default:
+		return nil, fmt.Errorf("unknown clearing file format: %s", format)
+	}
+}
+
+func parseCSV(content []byte) ([]Record, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1 // a record with the wrong number of fields is rejected on its own, not the whole file
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.New("clearing file is empty")
+	}
+	if strings.Join(header, ",") != strings.Join(csvColumns, ",") {
+		return nil, fmt.Errorf("clearing file header must be: %s", strings.Join(csvColumns, ","))
+	}
+
+	var records []Record
+	for {
+		values, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		lineNumber, _ := reader.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			records = append(records, Record{LineNumber: parseErr.Line, Err: errors.New("malformed CSV line")})
+			continue
+		}
+
+		record := Record{LineNumber: lineNumber, Raw: strings.Join(values, ",")}
+		if len(values) != len(csvColumns) {
+			record.Err = fmt.Errorf("expected %d fields, got %d", len(csvColumns), len(values))
+			records = append(records, record)
+			continue
+		}
+
+		records = append(records, buildRecord(record, values))
+	}
+
+	return records, nil
+}
+
+func parseFixedWidth(content []byte) ([]Record, error) {
+	lineWidth := 0
+	for _, field := range fixedWidthFields {
+		lineWidth += field.width
+	}
+
+	var records []Record
+	for index, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		record := Record{LineNumber: index + 1, Raw: line}
+
+		// trailing spaces of the last field are often trimmed by the sender, so a shorter line is padded back
+		if len(line) > lineWidth {
+			record.Err = fmt.Errorf("line must be at most %d characters long, got %d", lineWidth, len(line))
+			records = append(records, record)
+			continue
+		}
+		line += strings.Repeat(" ", lineWidth-len(line))
+
+		values := make([]string, 0, len(fixedWidthFields))
+		offset := 0
+		for _, field := range fixedWidthFields {
+			values = append(values, line[offset:offset+field.width])
+			offset += field.width
+		}
+
+		records = append(records, buildRecord(record, values))
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("clearing file is empty")
+	}
+	return records, nil
+}
+
+// parsePacs008 turns every transaction of the message into a record, a message violating the schema is rejected as a whole
+func parsePacs008(content []byte) ([]Record, error) {
+	creditTransfer, err := iso20022.DecodeCreditTransfer(content)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]Record, 0, len(creditTransfer.Transactions))
+	for index, transaction := range creditTransfer.Transactions {
+		records = append(records, Record{
+			LineNumber:               index + 1,
+			Raw:                      transaction.Raw,
+			UTR:                      transaction.TransactionID,
+			Amount:                   transaction.Amount,
+			BeneficiaryAccountNumber: transaction.Creditor.Account(),
+			BeneficiaryIFSC:          transaction.Creditor.AgentIFSC,
+			RemitterName:             transaction.Debtor.Name,
+			RemitterAccountNumber:    transaction.Debtor.Account(),
+			RemitterIFSC:             transaction.Debtor.AgentIFSC,
+			Currency:                 transaction.Currency,
+		})
+	}
+
+	return records, nil
+}
+
+// buildRecord fills the record from the values of its fields, in the order of csvColumns
+func buildRecord(record Record, values []string) Record {
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+
+	record.UTR = values[0]
+	record.BeneficiaryAccountNumber = values[2]
+	record.BeneficiaryIFSC = strings.ToUpper(values[3])
+	record.RemitterName = values[4]
+	record.RemitterAccountNumber = values[5]
+	record.RemitterIFSC = strings.ToUpper(values[6])
+
+	amount, err := strconv.ParseInt(values[1], 10, 64)
+	switch {
+	case record.UTR == "":
+		record.Err = errors.New("utr is missing")
+	case err != nil || amount <= 0:
+		record.Err = errors.New("amount must be a positive number of paise")
+	case record.BeneficiaryAccountNumber == "":
+		record.Err = errors.New("beneficiary_account_number is missing")
+	case record.RemitterName == "":
+		record.Err = errors.New("remitter_name is missing")
+	}
+	record.Amount = amount
+
+	return record
+}
+
+// AcknowledgmentEntry is the outcome of a single record of a clearing file, as reported back to the clearing house
+type AcknowledgmentEntry struct {
+	UTR    string
+	Status string
+	Reason string
+}
+
+var acknowledgmentFields = []field{
+	{name: "utr", width: 22},
+	{name: "status", width: 10},
+	{name: "reason", width: 60},
+}
+
+// WriteAcknowledgment returns the acknowledgment file of a clearing file, in the same format as the clearing file
+func WriteAcknowledgment(format Format, entries []AcknowledgmentEntry) ([]byte, error) {
+	if format == Pacs008 {
+		return nil, errors.New("pacs.008 messages are acknowledged by the clearing house, not with an acknowledgment file")
+	}
+
+	var buffer bytes.Buffer
+
+	if format == FixedWidth {
+		for _, entry := range entries {
+			for i, value := range []string{entry.UTR, entry.Status, entry.Reason} {
+				width := acknowledgmentFields[i].width
+				if len(value) > width {
+					value = value[:width]
+				}
+				fmt.Fprintf(&buffer, "%-*s", width, value)
+			}
+			buffer.WriteString("\n")
+		}
+		return buffer.Bytes(), nil
+	}
+
+	writer := csv.NewWriter(&buffer)
+	header := make([]string, 0, len(acknowledgmentFields))
+	for _, field := range acknowledgmentFields {
+		header = append(header, field.name)
+	}
+
+	err := writer.Write(header)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		err = writer.Write([]string{entry.UTR, entry.Status, entry.Reason})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
@@ -0,0 +1,93 @@
+package fxratefile
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/skamranahmed/go-bank/pkg/money"
+)
+
+/*
+An FX rate file is the feed of exchange rates published by the treasury, usually once or a few times a day.
+
+It is a CSV file with a header row naming the columns in the order of csvColumns, every other line being the price
+of one unit of the base currency in the quote currency, eg: "USD,INR,83.25" for 1 USD = 83.25 INR.
+*/
+var csvColumns = []string{
+	"base_currency",
+	"quote_currency",
+	"rate",
+}
+
+/*
+Parse reads every rate of the FX rate file
+
+Unlike a clearing file, a rate file is taken as a whole or not at all, so that the rates of a feed are never published
+only in part. The error names the line of the first rate that could not be read.
+*/
+func Parse(content []byte) ([]money.Rate, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = len(csvColumns)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.New("FX rate file is empty")
+	}
+	if strings.Join(header, ",") != strings.Join(csvColumns, ",") {
+		return nil, fmt.Errorf("FX rate file header must be: %s", strings.Join(csvColumns, ","))
+	}
+
+	var rates []money.Rate
+	seenPairs := make(map[string]int)
+	for {
+		values, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return nil, fmt.Errorf("line %d: malformed CSV line", parseErr.Line)
+			}
+			return nil, err
+		}
+
+		lineNumber, _ := reader.FieldPos(0)
+		for i := range values {
+			values[i] = strings.TrimSpace(values[i])
+		}
+
+		base, err := money.ParseCurrency(values[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: base_currency %s", lineNumber, err.Error())
+		}
+		quote, err := money.ParseCurrency(values[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: quote_currency %s", lineNumber, err.Error())
+		}
+
+		rate, err := money.ParseRate(base, quote, values[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", lineNumber, err.Error())
+		}
+
+		// the same pair twice in a feed leaves it unclear which of the rates is meant
+		pair := string(base) + "/" + string(quote)
+		if firstLineNumber, ok := seenPairs[pair]; ok {
+			return nil, fmt.Errorf("line %d: %s is already rated on line %d", lineNumber, pair, firstLineNumber)
+		}
+		seenPairs[pair] = lineNumber
+
+		rates = append(rates, rate)
+	}
+
+	if len(rates) == 0 {
+		return nil, errors.New("FX rate file has no rates")
+	}
+	return rates, nil
+}
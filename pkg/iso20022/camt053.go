@@ -0,0 +1,263 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Statement is a camt.053 bank to customer statement, the entries booked to an account over a period and its balances around them
+type Statement struct {
+	MessageID    string
+	StatementID  string
+	CreationTime time.Time
+
+	From time.Time
+	To   time.Time
+
+	AccountIBAN  string
+	Currency     string
+	ServicerIFSC string
+
+	// the balances are in the smallest currency unit (paise for INR), a negative balance is an overdrawn account
+	OpeningBalance int64
+	ClosingBalance int64
+
+	Entries []StatementEntry
+
+	// AdditionalInformation is free text about the account printed with the statement, eg: its nominees
+	AdditionalInformation string
+}
+
+type StatementEntry struct {
+	Reference string
+
+	// Amount is in the smallest currency unit (paise for INR), IsDebit tells which way it moved the balance
+	Amount  int64
+	IsDebit bool
+
+	BookingTime time.Time
+
+	// TransactionCode is the proprietary bank transaction code of the entry, the type of the transaction
+	TransactionCode string
+	EndToEndID      string
+	Description     string
+}
+
+const (
+	creditIndicator = "CRDT"
+	debitIndicator  = "DBIT"
+
+	openingBalanceCode = "OPBD"
+	closingBalanceCode = "CLBD"
+
+	bookedEntryStatus = "BOOK"
+)
+
+type camt053Document struct {
+	XMLName       xml.Name
+	BkToCstmrStmt struct {
+		GroupHeader struct {
+			MessageID        string `xml:"MsgId"`
+			CreationDateTime string `xml:"CreDtTm"`
+		} `xml:"GrpHdr"`
+		Statement accountStatement `xml:"Stmt"`
+	} `xml:"BkToCstmrStmt"`
+}
+
+type accountStatement struct {
+	ID               string `xml:"Id"`
+	CreationDateTime string `xml:"CreDtTm"`
+	FromToDate       struct {
+		From string `xml:"FrDtTm"`
+		To   string `xml:"ToDtTm"`
+	} `xml:"FrToDt"`
+	Account struct {
+		ID struct {
+			IBAN string `xml:"IBAN"`
+		} `xml:"Id"`
+		Currency string `xml:"Ccy"`
+		Servicer *Agent `xml:"Svcr"`
+	} `xml:"Acct"`
+	Balances              []cashBalance       `xml:"Bal"`
+	TransactionsSummary   transactionsSummary `xml:"TxsSummry"`
+	Entries               []reportEntry       `xml:"Ntry"`
+	AdditionalInformation string              `xml:"AddtlStmtInf,omitempty"`
+}
+
+type cashBalance struct {
+	Type struct {
+		CodeOrProprietary struct {
+			Code string `xml:"Cd"`
+		} `xml:"CdOrPrtry"`
+	} `xml:"Tp"`
+	Amount               Amount `xml:"Amt"`
+	CreditDebitIndicator string `xml:"CdtDbtInd"`
+	Date                 struct {
+		Date string `xml:"Dt"`
+	} `xml:"Dt"`
+}
+
+type transactionsSummary struct {
+	TotalCreditEntries numberAndSum `xml:"TtlCdtNtries"`
+	TotalDebitEntries  numberAndSum `xml:"TtlDbtNtries"`
+}
+
+type numberAndSum struct {
+	NumberOfEntries string `xml:"NbOfNtries"`
+	Sum             string `xml:"Sum"`
+}
+
+type reportEntry struct {
+	Reference            string `xml:"NtryRef"`
+	Amount               Amount `xml:"Amt"`
+	CreditDebitIndicator string `xml:"CdtDbtInd"`
+	Status               struct {
+		Code string `xml:"Cd"`
+	} `xml:"Sts"`
+	BookingDate struct {
+		DateTime string `xml:"DtTm"`
+	} `xml:"BookgDt"`
+	ValueDate struct {
+		Date string `xml:"Dt"`
+	} `xml:"ValDt"`
+	BankTransactionCode struct {
+		Proprietary struct {
+			Code string `xml:"Cd"`
+		} `xml:"Prtry"`
+	} `xml:"BkTxCd"`
+	Details *entryDetails `xml:"NtryDtls,omitempty"`
+}
+
+type entryDetails struct {
+	TransactionDetails struct {
+		References            *transactionReferences `xml:"Refs,omitempty"`
+		AdditionalInformation string                 `xml:"AddtlTxInf,omitempty"`
+	} `xml:"TxDtls"`
+}
+
+type transactionReferences struct {
+	EndToEndID string `xml:"EndToEndId"`
+}
+
+func newCashBalance(code string, balance int64, currency string, date time.Time) cashBalance {
+	indicator := creditIndicator
+	if balance < 0 {
+		indicator = debitIndicator
+		balance = -balance
+	}
+
+	cashBalance := cashBalance{
+		Amount:               Amount{Currency: currency, Value: FormatAmount(balance)},
+		CreditDebitIndicator: indicator,
+	}
+	cashBalance.Type.CodeOrProprietary.Code = code
+	cashBalance.Date.Date = date.Format(dateLayout)
+	return cashBalance
+}
+
+/*
+EncodeStatement returns the camt.053 message of the statement
+
+The opening balance plus the credits less the debits of the entries must add up to the closing balance,
+a statement that does not reconcile is never produced.
+*/
+func EncodeStatement(statement Statement) ([]byte, error) {
+	v := &validator{}
+	v.text("GrpHdr/MsgId", statement.MessageID, maxTextLength)
+	v.text("Stmt/Id", statement.StatementID, maxTextLength)
+	v.text("Stmt/Acct/Id/IBAN", statement.AccountIBAN, maxTextLength)
+	if !currencyRegex.MatchString(statement.Currency) {
+		v.add("Stmt/Acct/Ccy", "must be a 3 letter ISO 4217 currency code")
+	}
+	if statement.To.Before(statement.From) {
+		v.add("Stmt/FrToDt", "the statement must not end before it starts")
+	}
+
+	document := camt053Document{XMLName: documentName(Camt053)}
+	document.BkToCstmrStmt.GroupHeader.MessageID = statement.MessageID
+	document.BkToCstmrStmt.GroupHeader.CreationDateTime = statement.CreationTime.UTC().Format(dateTimeLayout)
+
+	accountStatement := &document.BkToCstmrStmt.Statement
+	accountStatement.ID = statement.StatementID
+	accountStatement.CreationDateTime = statement.CreationTime.UTC().Format(dateTimeLayout)
+	accountStatement.FromToDate.From = statement.From.UTC().Format(dateTimeLayout)
+	accountStatement.FromToDate.To = statement.To.UTC().Format(dateTimeLayout)
+	accountStatement.Account.ID.IBAN = statement.AccountIBAN
+	accountStatement.Account.Currency = statement.Currency
+	if statement.ServicerIFSC != "" {
+		accountStatement.Account.Servicer = newAgent(statement.ServicerIFSC)
+	}
+
+	var creditCount, debitCount int
+	var creditSum, debitSum int64
+	for i, entry := range statement.Entries {
+		path := fmt.Sprintf("Stmt/Ntry[%d]", i+1)
+		if entry.Amount <= 0 {
+			v.add(path+"/Amt", "must be a positive amount")
+		}
+
+		reportEntry := reportEntry{
+			Reference:            entry.Reference,
+			Amount:               newAmount(entry.Amount, statement.Currency),
+			CreditDebitIndicator: creditIndicator,
+		}
+		if entry.IsDebit {
+			reportEntry.CreditDebitIndicator = debitIndicator
+			debitCount++
+			debitSum += entry.Amount
+		} else {
+			creditCount++
+			creditSum += entry.Amount
+		}
+		reportEntry.Status.Code = bookedEntryStatus
+		reportEntry.BookingDate.DateTime = entry.BookingTime.UTC().Format(dateTimeLayout)
+		reportEntry.ValueDate.Date = entry.BookingTime.UTC().Format(dateLayout)
+		reportEntry.BankTransactionCode.Proprietary.Code = entry.TransactionCode
+
+		if entry.EndToEndID != "" || entry.Description != "" {
+			reportEntry.Details = &entryDetails{}
+			if entry.EndToEndID != "" {
+				reportEntry.Details.TransactionDetails.References = &transactionReferences{EndToEndID: entry.EndToEndID}
+			}
+			reportEntry.Details.TransactionDetails.AdditionalInformation = truncate(entry.Description, maxAdditionalTransactionInformationLength)
+		}
+
+		accountStatement.Entries = append(accountStatement.Entries, reportEntry)
+	}
+
+	if statement.OpeningBalance+creditSum-debitSum != statement.ClosingBalance {
+		v.add("Stmt/Bal", "opening balance %s and the entries do not add up to closing balance %s", FormatAmount(statement.OpeningBalance), FormatAmount(statement.ClosingBalance))
+	}
+
+	accountStatement.Balances = []cashBalance{
+		newCashBalance(openingBalanceCode, statement.OpeningBalance, statement.Currency, statement.From.UTC()),
+		newCashBalance(closingBalanceCode, statement.ClosingBalance, statement.Currency, statement.To.UTC()),
+	}
+	accountStatement.TransactionsSummary = transactionsSummary{
+		TotalCreditEntries: numberAndSum{NumberOfEntries: strconv.Itoa(creditCount), Sum: FormatAmount(creditSum)},
+		TotalDebitEntries:  numberAndSum{NumberOfEntries: strconv.Itoa(debitCount), Sum: FormatAmount(debitSum)},
+	}
+
+	accountStatement.AdditionalInformation = truncate(statement.AdditionalInformation, maxAdditionalStatementInformationLength)
+
+	err := v.err()
+	if err != nil {
+		return nil, err
+	}
+	return marshalDocument(document)
+}
+
+// maxAdditionalTransactionInformationLength is the length of the Max500Text additional information of an entry
+const maxAdditionalTransactionInformationLength = 500
+
+// maxAdditionalStatementInformationLength is the length of the Max500Text additional information of a statement
+const maxAdditionalStatementInformationLength = 500
+
+func truncate(value string, maxLength int) string {
+	if len(value) <= maxLength {
+		return value
+	}
+	return value[:maxLength]
+}
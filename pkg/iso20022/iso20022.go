@@ -0,0 +1,343 @@
+package iso20022
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+ISO 20022 messages exchanged with the clearing house, every message is an XML Document in the namespace of its message type:
+  - pacs.008, a FI to FI customer credit transfer, sent for outbound external transfers and received for inbound payments
+  - pacs.004, a payment return, received when the beneficiary bank returns an outbound external transfer
+  - camt.053, a bank to customer statement, produced at the end of the day for an account
+
+Amounts are carried as decimals in the major currency unit (rupees for INR), they are converted to and from the smallest unit (paise).
+Agents (banks) are identified by the IFSC of their branch as a clearing system member of the Indian Financial System Code scheme.
+*/
+type MessageType string
+
+const (
+	Pacs008 MessageType = "pacs.008.001.08"
+	Pacs004 MessageType = "pacs.004.001.09"
+	Camt053 MessageType = "camt.053.001.08"
+)
+
+// Namespace returns the XML namespace of the Document of the message type
+func (m MessageType) Namespace() string {
+	return "urn:iso:std:iso:20022:tech:xsd:" + string(m)
+}
+
+const (
+	// ifscClearingSystem is the code of the Indian Financial System Code scheme in the external clearing system identification code set
+	ifscClearingSystem = "INFSC"
+
+	// maxTextLength is the length of the Max35Text identifiers and names of the messages
+	maxTextLength = 35
+
+	// maxLongTextLength is the length of the Max140Text names and unstructured remittance information
+	maxLongTextLength = 140
+
+	dateTimeLayout = "2006-01-02T15:04:05"
+	dateLayout     = "2006-01-02"
+)
+
+var (
+	currencyRegex = regexp.MustCompile(`^[A-Z]{3}$`)
+	uetrRegex     = regexp.MustCompile(`^[a-f0-9]{8}-[a-f0-9]{4}-4[a-f0-9]{3}-[89ab][a-f0-9]{3}-[a-f0-9]{12}$`)
+	amountRegex   = regexp.MustCompile(`^[0-9]{1,16}(\.[0-9]{1,2})?$`)
+)
+
+// DetectMessageType returns the type of the message from the namespace of its Document
+func DetectMessageType(content []byte) (MessageType, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", errors.New("message is not a well formed XML document")
+		}
+
+		element, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		if element.Name.Local != "Document" {
+			return "", fmt.Errorf("message root element must be Document, got: %s", element.Name.Local)
+		}
+		for _, messageType := range []MessageType{Pacs008, Pacs004, Camt053} {
+			if element.Name.Space == messageType.Namespace() {
+				return messageType, nil
+			}
+		}
+		return "", fmt.Errorf("unsupported message namespace: %s", element.Name.Space)
+	}
+}
+
+// ValidationError is a single violation of the schema of a message, Path locates the offending element, e.g. CdtTrfTxInf[2]/IntrBkSttlmAmt
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidationErrors are all the violations found in a message, a message with any of them is rejected as a whole
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, validationError := range e {
+		messages = append(messages, validationError.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// validator collects the violations found while validating a message
+type validator struct {
+	errors ValidationErrors
+}
+
+func (v *validator) add(path string, format string, args ...any) {
+	v.errors = append(v.errors, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// text checks that a mandatory text element is present and at most maxLength characters long
+func (v *validator) text(path string, value string, maxLength int) {
+	switch {
+	case strings.TrimSpace(value) == "":
+		v.add(path, "is missing")
+	case len(value) > maxLength:
+		v.add(path, "must be at most %d characters long", maxLength)
+	}
+}
+
+func (v *validator) dateTime(path string, value string) time.Time {
+	if value == "" {
+		v.add(path, "is missing")
+		return time.Time{}
+	}
+
+	parsed, err := parseDateTime(value)
+	if err != nil {
+		v.add(path, "must be an ISO date time, e.g. 2025-12-30T10:15:00")
+	}
+	return parsed
+}
+
+func (v *validator) date(path string, value string) time.Time {
+	if value == "" {
+		v.add(path, "is missing")
+		return time.Time{}
+	}
+
+	parsed, err := time.Parse(dateLayout, value)
+	if err != nil {
+		v.add(path, "must be an ISO date, e.g. 2025-12-30")
+	}
+	return parsed
+}
+
+func (v *validator) amount(path string, amount Amount) int64 {
+	if !currencyRegex.MatchString(amount.Currency) {
+		v.add(path+"/@Ccy", "must be a 3 letter ISO 4217 currency code")
+	}
+
+	value, err := ParseAmount(amount.Value)
+	if err != nil {
+		v.add(path, "%s", err.Error())
+	}
+	return value
+}
+
+func (v *validator) uetr(path string, value string) {
+	if value != "" && !uetrRegex.MatchString(value) {
+		v.add(path, "must be a lowercase UUID version 4")
+	}
+}
+
+// agent checks that the agent is identified by the IFSC of its branch and returns it
+func (v *validator) agent(path string, agent *Agent) string {
+	if agent == nil {
+		v.add(path, "is missing")
+		return ""
+	}
+
+	member := agent.FinancialInstitution.ClearingSystemMember
+	if member == nil || member.ClearingSystem.Code != ifscClearingSystem {
+		v.add(path+"/FinInstnId/ClrSysMmbId", "must identify the agent by its IFSC under clearing system %s", ifscClearingSystem)
+		return ""
+	}
+	v.text(path+"/FinInstnId/ClrSysMmbId/MmbId", member.MemberID, maxTextLength)
+	return strings.ToUpper(member.MemberID)
+}
+
+func (v *validator) err() error {
+	if len(v.errors) == 0 {
+		return nil
+	}
+	return v.errors
+}
+
+func parseDateTime(value string) (time.Time, error) {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return parsed.UTC(), nil
+	}
+	return time.Parse(dateTimeLayout, value)
+}
+
+// FormatAmount returns the amount in the smallest currency unit as a decimal in the major unit, e.g. 123450 -> 1234.50
+func FormatAmount(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/100, amount%100)
+}
+
+// ParseAmount returns the decimal amount in the major currency unit in the smallest unit, e.g. 1234.5 -> 123450
+func ParseAmount(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if !amountRegex.MatchString(value) {
+		return 0, errors.New("must be a decimal amount with at most 2 fraction digits")
+	}
+
+	units, fraction, _ := strings.Cut(value, ".")
+	fraction += strings.Repeat("0", 2-len(fraction))
+
+	major, err := strconv.ParseInt(units, 10, 64)
+	if err != nil {
+		return 0, errors.New("amount is too large")
+	}
+	minor, _ := strconv.ParseInt(fraction, 10, 64)
+
+	amount := major*100 + minor
+	if amount <= 0 {
+		return 0, errors.New("must be a positive amount")
+	}
+	return amount, nil
+}
+
+// Amount is an ActiveCurrencyAndAmount element, e.g. <IntrBkSttlmAmt Ccy="INR">1234.50</IntrBkSttlmAmt>
+type Amount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+func newAmount(amount int64, currency string) Amount {
+	return Amount{Currency: currency, Value: FormatAmount(amount)}
+}
+
+// documentName returns the name of the root element of a message of the type, the XMLName of a Document is left untagged so that its namespace is written
+func documentName(messageType MessageType) xml.Name {
+	return xml.Name{Space: messageType.Namespace(), Local: "Document"}
+}
+
+// Agent is a BranchAndFinancialInstitutionIdentification element
+type Agent struct {
+	FinancialInstitution struct {
+		ClearingSystemMember *ClearingSystemMember `xml:"ClrSysMmbId"`
+	} `xml:"FinInstnId"`
+}
+
+type ClearingSystemMember struct {
+	ClearingSystem struct {
+		Code string `xml:"Cd"`
+	} `xml:"ClrSysId"`
+	MemberID string `xml:"MmbId"`
+}
+
+func newAgent(ifsc string) *Agent {
+	member := &ClearingSystemMember{MemberID: ifsc}
+	member.ClearingSystem.Code = ifscClearingSystem
+
+	agent := &Agent{}
+	agent.FinancialInstitution.ClearingSystemMember = member
+	return agent
+}
+
+// CashAccount is a CashAccount element identifying the account either by its IBAN or by its number at the bank
+type CashAccount struct {
+	ID struct {
+		IBAN  string                        `xml:"IBAN,omitempty"`
+		Other *genericAccountIdentification `xml:"Othr,omitempty"`
+	} `xml:"Id"`
+}
+
+type genericAccountIdentification struct {
+	ID string `xml:"Id"`
+}
+
+func newCashAccount(iban string, accountNumber string) *CashAccount {
+	if iban == "" && accountNumber == "" {
+		return nil
+	}
+
+	account := &CashAccount{}
+	if iban != "" {
+		account.ID.IBAN = iban
+	} else {
+		account.ID.Other = &genericAccountIdentification{ID: accountNumber}
+	}
+	return account
+}
+
+// identification returns the IBAN of the account, or its number at the bank when it is not identified by an IBAN
+func (a *CashAccount) identification() string {
+	if a == nil {
+		return ""
+	}
+	if a.ID.IBAN != "" {
+		return a.ID.IBAN
+	}
+	if a.ID.Other != nil {
+		return a.ID.Other.ID
+	}
+	return ""
+}
+
+// split returns the IBAN and the number at the bank the account is identified by, only one of them is set
+func (a *CashAccount) split() (string, string) {
+	if a == nil {
+		return "", ""
+	}
+	if a.ID.Other != nil {
+		return a.ID.IBAN, a.ID.Other.ID
+	}
+	return a.ID.IBAN, ""
+}
+
+// marshalDocument returns the Document of the message, prefixed with the XML declaration
+func marshalDocument(document any) ([]byte, error) {
+	content, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), content...), nil
+}
+
+// unmarshalDocument decodes the Document of the message, failing when it is not a message of the expected type
+func unmarshalDocument(messageType MessageType, content []byte, document any) error {
+	detectedType, err := DetectMessageType(content)
+	if err != nil {
+		return err
+	}
+	if detectedType != messageType {
+		return fmt.Errorf("expected a %s message, got: %s", messageType, detectedType)
+	}
+
+	err = xml.Unmarshal(content, document)
+	if err != nil {
+		return fmt.Errorf("message could not be decoded: %s", err.Error())
+	}
+	return nil
+}
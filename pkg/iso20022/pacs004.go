@@ -0,0 +1,165 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// PaymentReturn is a pacs.004 payment return, the beneficiary banks returning payments they could not credit
+type PaymentReturn struct {
+	MessageID    string
+	CreationTime time.Time
+
+	Transactions []PaymentReturnTransaction
+}
+
+type PaymentReturnTransaction struct {
+	ReturnID string
+
+	// the original payment is identified by its UETR when set, otherwise by its end to end or transaction ID
+	OriginalEndToEndID    string
+	OriginalTransactionID string
+	OriginalUETR          string
+
+	// Amount is the returned amount in the smallest currency unit (paise for INR)
+	Amount         int64
+	Currency       string
+	SettlementDate time.Time
+
+	// ReasonCode is an ExternalReturnReason1Code, e.g. AC01 for an incorrect account number
+	ReasonCode            string
+	AdditionalInformation string
+}
+
+// returnReasons describes the return reason codes the domestic rails use
+var returnReasons = map[string]string{
+	"AC01": "Incorrect account number",
+	"AC03": "Invalid creditor account number",
+	"AC04": "Closed account number",
+	"AC06": "Blocked account",
+	"AG01": "Transaction forbidden on this type of account",
+	"AM05": "Duplicate payment",
+	"AM09": "Wrong amount",
+	"BE01": "Beneficiary name does not match the account",
+	"MS03": "Reason not specified",
+	"NARR": "Narrative",
+}
+
+// Reason returns a description of why the payment was returned
+func (t PaymentReturnTransaction) Reason() string {
+	description, ok := returnReasons[t.ReasonCode]
+	if !ok {
+		description = "Returned by the beneficiary bank"
+	}
+
+	reason := fmt.Sprintf("%s (%s)", description, t.ReasonCode)
+	if t.AdditionalInformation != "" {
+		reason += ": " + t.AdditionalInformation
+	}
+	return reason
+}
+
+type pacs004Document struct {
+	XMLName xml.Name
+	PmtRtr  struct {
+		GroupHeader  groupHeader           `xml:"GrpHdr"`
+		Transactions []paymentReturnTxInfo `xml:"TxInf"`
+	} `xml:"PmtRtr"`
+}
+
+type paymentReturnTxInfo struct {
+	ReturnID                 string                   `xml:"RtrId"`
+	OriginalEndToEndID       string                   `xml:"OrgnlEndToEndId"`
+	OriginalTransactionID    string                   `xml:"OrgnlTxId"`
+	OriginalUETR             string                   `xml:"OrgnlUETR"`
+	ReturnedSettlementAmount Amount                   `xml:"RtrdIntrBkSttlmAmt"`
+	InterbankSettlementDate  string                   `xml:"IntrBkSttlmDt"`
+	ReturnReasonInformation  *returnReasonInformation `xml:"RtrRsnInf"`
+}
+
+type returnReasonInformation struct {
+	Reason struct {
+		Code string `xml:"Cd"`
+	} `xml:"Rsn"`
+	AdditionalInformation string `xml:"AddtlInf"`
+}
+
+// maxAdditionalInformationLength is the length of the Max105Text additional information of a return reason
+const maxAdditionalInformationLength = 105
+
+/*
+DecodePaymentReturn decodes and validates a received pacs.004 message
+
+A message that is not a well formed pacs.004 Document returns a plain error, a Document violating the schema
+returns ValidationErrors listing every violation found.
+*/
+func DecodePaymentReturn(content []byte) (*PaymentReturn, error) {
+	var document pacs004Document
+	err := unmarshalDocument(Pacs004, content, &document)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &validator{}
+	header := document.PmtRtr.GroupHeader
+	paymentReturn := &PaymentReturn{MessageID: header.MessageID}
+
+	v.text("GrpHdr/MsgId", header.MessageID, maxTextLength)
+	paymentReturn.CreationTime = v.dateTime("GrpHdr/CreDtTm", header.CreationDateTime)
+
+	transactions := document.PmtRtr.Transactions
+	numberOfTransactions, err := strconv.Atoi(header.NumberOfTransactions)
+	switch {
+	case err != nil:
+		v.add("GrpHdr/NbOfTxs", "must be a number")
+	case numberOfTransactions != len(transactions):
+		v.add("GrpHdr/NbOfTxs", "is %d but the message holds %d transactions", numberOfTransactions, len(transactions))
+	}
+	if len(transactions) == 0 {
+		v.add("TxInf", "the message must hold at least one transaction")
+	}
+
+	for i, txInfo := range transactions {
+		path := fmt.Sprintf("TxInf[%d]", i+1)
+		transaction := PaymentReturnTransaction{
+			ReturnID:              txInfo.ReturnID,
+			OriginalEndToEndID:    txInfo.OriginalEndToEndID,
+			OriginalTransactionID: txInfo.OriginalTransactionID,
+			OriginalUETR:          txInfo.OriginalUETR,
+			Currency:              txInfo.ReturnedSettlementAmount.Currency,
+		}
+
+		if transaction.ReturnID != "" {
+			v.text(path+"/RtrId", transaction.ReturnID, maxTextLength)
+		}
+		if transaction.OriginalUETR == "" && transaction.OriginalEndToEndID == "" && transaction.OriginalTransactionID == "" {
+			v.add(path, "must identify the original payment by OrgnlUETR, OrgnlEndToEndId or OrgnlTxId")
+		}
+		v.uetr(path+"/OrgnlUETR", transaction.OriginalUETR)
+		transaction.Amount = v.amount(path+"/RtrdIntrBkSttlmAmt", txInfo.ReturnedSettlementAmount)
+		transaction.SettlementDate = v.date(path+"/IntrBkSttlmDt", txInfo.InterbankSettlementDate)
+
+		if txInfo.ReturnReasonInformation == nil || txInfo.ReturnReasonInformation.Reason.Code == "" {
+			v.add(path+"/RtrRsnInf/Rsn/Cd", "is missing")
+		} else {
+			transaction.ReasonCode = txInfo.ReturnReasonInformation.Reason.Code
+			transaction.AdditionalInformation = txInfo.ReturnReasonInformation.AdditionalInformation
+			if len(transaction.ReasonCode) > 4 {
+				v.add(path+"/RtrRsnInf/Rsn/Cd", "must be at most 4 characters long")
+			}
+			if len(transaction.AdditionalInformation) > maxAdditionalInformationLength {
+				v.add(path+"/RtrRsnInf/AddtlInf", "must be at most %d characters long", maxAdditionalInformationLength)
+			}
+		}
+
+		paymentReturn.Transactions = append(paymentReturn.Transactions, transaction)
+	}
+
+	err = v.err()
+	if err != nil {
+		return nil, err
+	}
+	return paymentReturn, nil
+}
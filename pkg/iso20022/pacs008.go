@@ -0,0 +1,288 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// CreditTransfer is a pacs.008 FI to FI customer credit transfer, a batch of payments settled through the same clearing system
+type CreditTransfer struct {
+	MessageID    string
+	CreationTime time.Time
+
+	// ClearingSystem is the rail the payments are settled over, e.g. NEFT
+	ClearingSystem string
+
+	Transactions []CreditTransferTransaction
+}
+
+type CreditTransferTransaction struct {
+	InstructionID string
+	EndToEndID    string
+
+	// TransactionID is the reference assigned by the clearing system, the UTR of the payment
+	TransactionID string
+
+	// UETR is the unique end to end transaction reference, a UUID that follows the payment across banks
+	UETR string
+
+	// Amount is in the smallest currency unit (paise for INR)
+	Amount         int64
+	Currency       string
+	SettlementDate time.Time
+
+	Debtor   Party
+	Creditor Party
+
+	RemittanceInformation string
+
+	// Raw is the CdtTrfTxInf element the transaction was decoded from, it is only set on decoded messages
+	Raw string
+}
+
+// Party is the debtor or creditor of a payment, its account is identified either by its IBAN or by its number at its bank
+type Party struct {
+	Name          string
+	AccountIBAN   string
+	AccountNumber string
+	AgentIFSC     string
+}
+
+// Account returns the IBAN of the account of the party, or its number when it is not identified by an IBAN
+func (p Party) Account() string {
+	if p.AccountIBAN != "" {
+		return p.AccountIBAN
+	}
+	return p.AccountNumber
+}
+
+type pacs008Document struct {
+	XMLName           xml.Name
+	FIToFICstmrCdtTrf struct {
+		GroupHeader  groupHeader            `xml:"GrpHdr"`
+		Transactions []creditTransferTxInfo `xml:"CdtTrfTxInf"`
+	} `xml:"FIToFICstmrCdtTrf"`
+}
+
+type groupHeader struct {
+	MessageID                      string                 `xml:"MsgId"`
+	CreationDateTime               string                 `xml:"CreDtTm"`
+	NumberOfTransactions           string                 `xml:"NbOfTxs"`
+	TotalInterbankSettlementAmount *Amount                `xml:"TtlIntrBkSttlmAmt,omitempty"`
+	SettlementInformation          *settlementInformation `xml:"SttlmInf,omitempty"`
+}
+
+type settlementInformation struct {
+	SettlementMethod string          `xml:"SttlmMtd"`
+	ClearingSystem   *clearingSystem `xml:"ClrSys,omitempty"`
+}
+
+type clearingSystem struct {
+	Proprietary string `xml:"Prtry"`
+}
+
+type paymentIdentification struct {
+	InstructionID string `xml:"InstrId,omitempty"`
+	EndToEndID    string `xml:"EndToEndId"`
+	TransactionID string `xml:"TxId"`
+	UETR          string `xml:"UETR,omitempty"`
+}
+
+type partyIdentification struct {
+	Name string `xml:"Nm"`
+}
+
+type creditTransferTxInfo struct {
+	XMLName                   xml.Name               `xml:"CdtTrfTxInf"`
+	PaymentID                 paymentIdentification  `xml:"PmtId"`
+	InterbankSettlementAmount Amount                 `xml:"IntrBkSttlmAmt"`
+	InterbankSettlementDate   string                 `xml:"IntrBkSttlmDt"`
+	ChargeBearer              string                 `xml:"ChrgBr"`
+	Debtor                    *partyIdentification   `xml:"Dbtr"`
+	DebtorAccount             *CashAccount           `xml:"DbtrAcct,omitempty"`
+	DebtorAgent               *Agent                 `xml:"DbtrAgt"`
+	CreditorAgent             *Agent                 `xml:"CdtrAgt"`
+	Creditor                  *partyIdentification   `xml:"Cdtr"`
+	CreditorAccount           *CashAccount           `xml:"CdtrAcct"`
+	RemittanceInformation     *remittanceInformation `xml:"RmtInf,omitempty"`
+}
+
+type remittanceInformation struct {
+	Unstructured string `xml:"Ustrd"`
+}
+
+// chargeBearerShared is the only charge bearer accepted by the domestic rails, the charges of each bank are borne by its own customer
+const chargeBearerShared = "SLEV"
+
+// settlementMethodClearing settles the payments through the clearing system named in the group header
+const settlementMethodClearing = "CLRG"
+
+/*
+EncodeCreditTransfer returns the pacs.008 message of the credit transfer
+
+The number of transactions and their total are computed from the transactions, the message is validated the same way
+DecodeCreditTransfer validates a received one before it is returned.
+*/
+func EncodeCreditTransfer(creditTransfer CreditTransfer) ([]byte, error) {
+	document := pacs008Document{XMLName: documentName(Pacs008)}
+
+	var total int64
+	currency := ""
+	for _, transaction := range creditTransfer.Transactions {
+		total += transaction.Amount
+		currency = transaction.Currency
+
+		txInfo := creditTransferTxInfo{
+			PaymentID: paymentIdentification{
+				InstructionID: transaction.InstructionID,
+				EndToEndID:    transaction.EndToEndID,
+				TransactionID: transaction.TransactionID,
+				UETR:          transaction.UETR,
+			},
+			InterbankSettlementAmount: newAmount(transaction.Amount, transaction.Currency),
+			InterbankSettlementDate:   transaction.SettlementDate.Format(dateLayout),
+			ChargeBearer:              chargeBearerShared,
+			Debtor:                    &partyIdentification{Name: transaction.Debtor.Name},
+			DebtorAccount:             newCashAccount(transaction.Debtor.AccountIBAN, transaction.Debtor.AccountNumber),
+			DebtorAgent:               newAgent(transaction.Debtor.AgentIFSC),
+			CreditorAgent:             newAgent(transaction.Creditor.AgentIFSC),
+			Creditor:                  &partyIdentification{Name: transaction.Creditor.Name},
+			CreditorAccount:           newCashAccount(transaction.Creditor.AccountIBAN, transaction.Creditor.AccountNumber),
+		}
+		if transaction.RemittanceInformation != "" {
+			txInfo.RemittanceInformation = &remittanceInformation{Unstructured: transaction.RemittanceInformation}
+		}
+		document.FIToFICstmrCdtTrf.Transactions = append(document.FIToFICstmrCdtTrf.Transactions, txInfo)
+	}
+
+	header := &document.FIToFICstmrCdtTrf.GroupHeader
+	header.MessageID = creditTransfer.MessageID
+	header.CreationDateTime = creditTransfer.CreationTime.UTC().Format(dateTimeLayout)
+	header.NumberOfTransactions = strconv.Itoa(len(creditTransfer.Transactions))
+	totalAmount := newAmount(total, currency)
+	header.TotalInterbankSettlementAmount = &totalAmount
+	header.SettlementInformation = &settlementInformation{SettlementMethod: settlementMethodClearing}
+	if creditTransfer.ClearingSystem != "" {
+		header.SettlementInformation.ClearingSystem = &clearingSystem{Proprietary: creditTransfer.ClearingSystem}
+	}
+
+	_, err := validateCreditTransfer(document)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalDocument(document)
+}
+
+/*
+DecodeCreditTransfer decodes and validates a received pacs.008 message
+
+A message that is not a well formed pacs.008 Document returns a plain error, a Document violating the schema
+returns ValidationErrors listing every violation found.
+*/
+func DecodeCreditTransfer(content []byte) (*CreditTransfer, error) {
+	var document pacs008Document
+	err := unmarshalDocument(Pacs008, content, &document)
+	if err != nil {
+		return nil, err
+	}
+
+	return validateCreditTransfer(document)
+}
+
+func validateCreditTransfer(document pacs008Document) (*CreditTransfer, error) {
+	v := &validator{}
+	header := document.FIToFICstmrCdtTrf.GroupHeader
+	creditTransfer := &CreditTransfer{MessageID: header.MessageID}
+
+	v.text("GrpHdr/MsgId", header.MessageID, maxTextLength)
+	creditTransfer.CreationTime = v.dateTime("GrpHdr/CreDtTm", header.CreationDateTime)
+
+	transactions := document.FIToFICstmrCdtTrf.Transactions
+	numberOfTransactions, err := strconv.Atoi(header.NumberOfTransactions)
+	switch {
+	case err != nil:
+		v.add("GrpHdr/NbOfTxs", "must be a number")
+	case numberOfTransactions != len(transactions):
+		v.add("GrpHdr/NbOfTxs", "is %d but the message holds %d transactions", numberOfTransactions, len(transactions))
+	}
+	if len(transactions) == 0 {
+		v.add("CdtTrfTxInf", "the message must hold at least one transaction")
+	}
+
+	if header.SettlementInformation == nil || header.SettlementInformation.SettlementMethod != settlementMethodClearing {
+		v.add("GrpHdr/SttlmInf/SttlmMtd", "must be %s", settlementMethodClearing)
+	} else if header.SettlementInformation.ClearingSystem != nil {
+		creditTransfer.ClearingSystem = header.SettlementInformation.ClearingSystem.Proprietary
+	}
+
+	var total int64
+	for i, txInfo := range transactions {
+		path := fmt.Sprintf("CdtTrfTxInf[%d]", i+1)
+		transaction := CreditTransferTransaction{
+			InstructionID: txInfo.PaymentID.InstructionID,
+			EndToEndID:    txInfo.PaymentID.EndToEndID,
+			TransactionID: txInfo.PaymentID.TransactionID,
+			UETR:          txInfo.PaymentID.UETR,
+			Currency:      txInfo.InterbankSettlementAmount.Currency,
+		}
+
+		v.text(path+"/PmtId/EndToEndId", transaction.EndToEndID, maxTextLength)
+		v.text(path+"/PmtId/TxId", transaction.TransactionID, maxTextLength)
+		v.uetr(path+"/PmtId/UETR", transaction.UETR)
+		transaction.Amount = v.amount(path+"/IntrBkSttlmAmt", txInfo.InterbankSettlementAmount)
+		transaction.SettlementDate = v.date(path+"/IntrBkSttlmDt", txInfo.InterbankSettlementDate)
+		if txInfo.ChargeBearer != chargeBearerShared {
+			v.add(path+"/ChrgBr", "must be %s", chargeBearerShared)
+		}
+
+		if txInfo.Debtor == nil {
+			v.add(path+"/Dbtr", "is missing")
+		} else {
+			v.text(path+"/Dbtr/Nm", txInfo.Debtor.Name, maxLongTextLength)
+			transaction.Debtor.Name = txInfo.Debtor.Name
+		}
+		transaction.Debtor.AccountIBAN, transaction.Debtor.AccountNumber = txInfo.DebtorAccount.split()
+		transaction.Debtor.AgentIFSC = v.agent(path+"/DbtrAgt", txInfo.DebtorAgent)
+
+		if txInfo.Creditor == nil {
+			v.add(path+"/Cdtr", "is missing")
+		} else {
+			v.text(path+"/Cdtr/Nm", txInfo.Creditor.Name, maxLongTextLength)
+			transaction.Creditor.Name = txInfo.Creditor.Name
+		}
+		v.text(path+"/CdtrAcct/Id", txInfo.CreditorAccount.identification(), maxTextLength)
+		transaction.Creditor.AccountIBAN, transaction.Creditor.AccountNumber = txInfo.CreditorAccount.split()
+		transaction.Creditor.AgentIFSC = v.agent(path+"/CdtrAgt", txInfo.CreditorAgent)
+
+		if txInfo.RemittanceInformation != nil {
+			if len(txInfo.RemittanceInformation.Unstructured) > maxLongTextLength {
+				v.add(path+"/RmtInf/Ustrd", "must be at most %d characters long", maxLongTextLength)
+			}
+			transaction.RemittanceInformation = txInfo.RemittanceInformation.Unstructured
+		}
+
+		raw, err := xml.Marshal(txInfo)
+		if err == nil {
+			transaction.Raw = string(raw)
+		}
+
+		total += transaction.Amount
+		creditTransfer.Transactions = append(creditTransfer.Transactions, transaction)
+	}
+
+	if header.TotalInterbankSettlementAmount != nil {
+		totalAmount := v.amount("GrpHdr/TtlIntrBkSttlmAmt", *header.TotalInterbankSettlementAmount)
+		if totalAmount != 0 && totalAmount != total {
+			v.add("GrpHdr/TtlIntrBkSttlmAmt", "is %s but the transactions add up to %s", FormatAmount(totalAmount), FormatAmount(total))
+		}
+	}
+
+	err = v.err()
+	if err != nil {
+		return nil, err
+	}
+	return creditTransfer, nil
+}
@@ -0,0 +1,84 @@
+package money
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Currency is an ISO 4217 alphabetic currency code, eg: INR
+type Currency string
+
+const (
+	AED Currency = "AED"
+	AUD Currency = "AUD"
+	BHD Currency = "BHD"
+	CAD Currency = "CAD"
+	CHF Currency = "CHF"
+	EUR Currency = "EUR"
+	GBP Currency = "GBP"
+	INR Currency = "INR"
+	JPY Currency = "JPY"
+	KWD Currency = "KWD"
+	SGD Currency = "SGD"
+	USD Currency = "USD"
+)
+
+/*
+minorUnits maps every supported currency to its ISO 4217 minor unit,
+the number of decimal places between the smallest unit amounts are stored in and a whole unit of the currency
+
+eg: amounts in INR are stored in paise (2 decimal places), in JPY in yen (no decimal places) and in KWD in fils (3 decimal places)
+*/
+var minorUnits = map[Currency]int{
+	AED: 2,
+	AUD: 2,
+	BHD: 3,
+	CAD: 2,
+	CHF: 2,
+	EUR: 2,
+	GBP: 2,
+	INR: 2,
+	JPY: 0,
+	KWD: 3,
+	SGD: 2,
+	USD: 2,
+}
+
+// ParseCurrency returns the currency of the ISO 4217 code, it must be one of the supported currencies
+func ParseCurrency(code string) (Currency, error) {
+	currency := Currency(code)
+	if !currency.IsSupported() {
+		return "", fmt.Errorf("%s is not a supported currency", code)
+	}
+	return currency, nil
+}
+
+// SupportedCurrencies returns the codes of every supported currency, in alphabetical order
+func SupportedCurrencies() []Currency {
+	currencies := make([]Currency, 0, len(minorUnits))
+	for currency := range minorUnits {
+		currencies = append(currencies, currency)
+	}
+	sort.Slice(currencies, func(i, j int) bool {
+		return currencies[i] < currencies[j]
+	})
+	return currencies
+}
+
+func (c Currency) IsSupported() bool {
+	_, ok := minorUnits[c]
+	return ok
+}
+
+// MinorUnits returns the number of decimal places of the currency, it panics for a currency that is not supported
+func (c Currency) MinorUnits() int {
+	units, ok := minorUnits[c]
+	if !ok {
+		panic(fmt.Sprintf("money: %s is not a supported currency", string(c)))
+	}
+	return units
+}
+
+func (c Currency) String() string {
+	return string(c)
+}
@@ -0,0 +1,117 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrCurrencyMismatch is returned when amounts of different currencies are added, subtracted or compared
+var ErrCurrencyMismatch = errors.New("money: amounts are in different currencies")
+
+/*
+Money is an amount stored in the smallest unit of its currency, eg: paise for INR
+
+Amounts of different currencies are never added, subtracted or compared with each other,
+converting an amount to another currency always goes through a Rate.
+*/
+type Money struct {
+	Amount   int64
+	Currency Currency
+}
+
+func New(amount int64, currency Currency) Money {
+	return Money{
+		Amount:   amount,
+		Currency: currency,
+	}
+}
+
+// FromWholeUnits returns the amount of whole units of the currency, eg: 10 INR is 1000 paise
+func FromWholeUnits(units int64, currency Currency) Money {
+	return New(units*pow10(currency.MinorUnits()), currency)
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return New(m.Amount+other.Amount, m.Currency), nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return New(m.Amount-other.Amount, m.Currency), nil
+}
+
+// Compare returns -1 when m is less than other, 0 when they are equal and +1 when m is greater than other
+func (m Money) Compare(other Money) (int, error) {
+	if m.Currency != other.Currency {
+		return 0, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+
+	switch {
+	case m.Amount < other.Amount:
+		return -1, nil
+	case m.Amount > other.Amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// String formats the amount in whole units of its currency, eg: "INR 1234.50" for 123450 paise
+func (m Money) String() string {
+	return fmt.Sprintf("%s %s", m.Currency, formatDecimal(m.Amount, m.Currency.MinorUnits()))
+}
+
+// formatDecimal formats a value scaled by 10^decimalPlaces with that many decimal places
+func formatDecimal(value int64, decimalPlaces int) string {
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	scale := pow10(decimalPlaces)
+	if decimalPlaces == 0 {
+		return fmt.Sprintf("%s%d", sign, value)
+	}
+	return fmt.Sprintf("%s%d.%0*d", sign, value/scale, decimalPlaces, value%scale)
+}
+
+// parseDecimal parses a non-negative decimal number of at most decimalPlaces decimal places into a value scaled by 10^decimalPlaces
+func parseDecimal(text string, decimalPlaces int) (int64, error) {
+	wholePart, fractionalPart, _ := strings.Cut(text, ".")
+	if wholePart == "" || len(fractionalPart) > decimalPlaces || strings.Contains(fractionalPart, ".") {
+		return 0, fmt.Errorf("must be a number with at most %d decimal places", decimalPlaces)
+	}
+
+	var value int64
+	for _, digit := range wholePart + fractionalPart + strings.Repeat("0", decimalPlaces-len(fractionalPart)) {
+		if digit < '0' || digit > '9' {
+			return 0, fmt.Errorf("must be a number with at most %d decimal places", decimalPlaces)
+		}
+		// overflow is checked before the digit is appended
+		if value > (1<<63-1-int64(digit-'0'))/10 {
+			return 0, errors.New("is too large")
+		}
+		value = value*10 + int64(digit-'0')
+	}
+
+	return value, nil
+}
+
+func pow10(exponent int) int64 {
+	result := int64(1)
+	for range exponent {
+		result *= 10
+	}
+	return result
+}
@@ -0,0 +1,105 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// RateDecimalPlaces is the precision exchange rates are stored with
+const RateDecimalPlaces = 8
+
+// RateScale is the value of an exchange rate of exactly 1, 10^RateDecimalPlaces
+const RateScale int64 = 100_000_000
+
+/*
+Rate is the price of one whole unit of the Base currency in the Quote currency, scaled by RateScale
+
+eg: 1 USD = 83.25 INR is the rate {Base: USD, Quote: INR, Value: 8325000000}
+*/
+type Rate struct {
+	Base  Currency
+	Quote Currency
+	Value int64
+}
+
+// ParseRate parses a rate written as a decimal number with at most RateDecimalPlaces decimal places, eg: "83.25"
+func ParseRate(base Currency, quote Currency, text string) (Rate, error) {
+	if !base.IsSupported() || !quote.IsSupported() {
+		return Rate{}, errors.New("rate must be between supported currencies")
+	}
+	if base == quote {
+		return Rate{}, errors.New("rate must be between two different currencies")
+	}
+
+	value, err := parseDecimal(text, RateDecimalPlaces)
+	if err != nil {
+		return Rate{}, fmt.Errorf("rate %s", err.Error())
+	}
+	if value == 0 {
+		return Rate{}, errors.New("rate must be greater than zero")
+	}
+
+	return Rate{
+		Base:  base,
+		Quote: quote,
+		Value: value,
+	}, nil
+}
+
+// String formats the value of the rate with RateDecimalPlaces decimal places, eg: "83.25000000"
+func (r Rate) String() string {
+	return formatDecimal(r.Value, RateDecimalPlaces)
+}
+
+// Invert returns the rate of the opposite direction, eg: the INR to USD rate of the USD to INR rate, rounded to RateDecimalPlaces
+func (r Rate) Invert() Rate {
+	scale := big.NewInt(RateScale)
+	numerator := new(big.Int).Mul(scale, scale)
+	return Rate{
+		Base:  r.Quote,
+		Quote: r.Base,
+		Value: roundedQuotient(numerator, big.NewInt(r.Value)).Int64(),
+	}
+}
+
+// WithMargin returns the rate reduced by the margin, so that converting an amount with it yields less of the quote currency
+func (r Rate) WithMargin(marginInBasisPoints int64) Rate {
+	value := new(big.Int).Mul(big.NewInt(r.Value), big.NewInt(10000-marginInBasisPoints))
+	return Rate{
+		Base:  r.Base,
+		Quote: r.Quote,
+		Value: value.Quo(value, big.NewInt(10000)).Int64(),
+	}
+}
+
+/*
+Convert converts an amount of the base currency to the quote currency, taking the minor units of both currencies into account
+
+The converted amount is rounded down to the smallest unit of the quote currency,
+so that the bank never pays out more than the rate is worth.
+*/
+func (r Rate) Convert(amount Money) (Money, error) {
+	if amount.Currency != r.Base {
+		return Money{}, fmt.Errorf("%w: %s rate cannot convert %s", ErrCurrencyMismatch, r.Base, amount.Currency)
+	}
+
+	// amount * value * 10^quoteMinorUnits / (RateScale * 10^baseMinorUnits)
+	numerator := new(big.Int).Mul(big.NewInt(amount.Amount), big.NewInt(r.Value))
+	numerator.Mul(numerator, big.NewInt(pow10(r.Quote.MinorUnits())))
+	denominator := new(big.Int).Mul(big.NewInt(RateScale), big.NewInt(pow10(r.Base.MinorUnits())))
+
+	converted := numerator.Quo(numerator, denominator)
+	if !converted.IsInt64() {
+		return Money{}, errors.New("money: converted amount is too large")
+	}
+
+	return New(converted.Int64(), r.Quote), nil
+}
+
+// roundedQuotient divides and rounds half away from zero, both the numerator and the denominator must be positive
+func roundedQuotient(numerator *big.Int, denominator *big.Int) *big.Int {
+	doubled := new(big.Int).Mul(numerator, big.NewInt(2))
+	doubled.Add(doubled, denominator)
+	return doubled.Quo(doubled, new(big.Int).Mul(denominator, big.NewInt(2)))
+}
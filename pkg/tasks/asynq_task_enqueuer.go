@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/hibiken/asynq"
 	"github.com/skamranahmed/go-bank/pkg/logger"
@@ -33,6 +34,15 @@ func NewAsynqTaskEnqueuer(redisConfig AsynqRedisConfig) TaskEnqueuer {
 }
 
 func (t *asynqTaskEnqueuer) Enqueue(ctx context.Context, task Task, maxRetryCount *int, queueName *string) error {
+	return t.enqueue(ctx, task, maxRetryCount, queueName)
+}
+
+func (t *asynqTaskEnqueuer) EnqueueAt(ctx context.Context, task Task, processAt time.Time, maxRetryCount *int, queueName *string) error {
+	return t.enqueue(ctx, task, maxRetryCount, queueName, asynq.ProcessAt(processAt))
+}
+
+// enqueue adds the task to the queue, extraOptions are applied on top of the default and the caller-provided options
+func (t *asynqTaskEnqueuer) enqueue(ctx context.Context, task Task, maxRetryCount *int, queueName *string, extraOptions ...asynq.Option) error {
 	queue := task.Queue()
 	if queue == "" {
 		queue = DefaultQueue
@@ -52,6 +62,7 @@ func (t *asynqTaskEnqueuer) Enqueue(ctx context.Context, task Task, maxRetryCoun
 	if queueName != nil {
 		taskOptions = append(taskOptions, asynq.Queue(*queueName))
 	}
+	taskOptions = append(taskOptions, extraOptions...)
 
 	taskToBeEnqueued, err := NewAsynqTask(ctx, task.Name(), task.Payload(), taskOptions...)
 	if err != nil {
@@ -2,6 +2,7 @@ package tasks
 
 import (
 	"context"
+	"time"
 )
 
 // Task represents a unit of work that can be enqueued and executed
@@ -32,6 +33,9 @@ type TaskEnqueuer interface {
 	// Enqueue adds a task to the queue
 	Enqueue(ctx context.Context, task Task, maxRetryCount *int, queueName *string) error
 
+	// EnqueueAt adds a task to the queue that is only processed once processAt is reached
+	EnqueueAt(ctx context.Context, task Task, processAt time.Time, maxRetryCount *int, queueName *string) error
+
 	// Close releases any resources held by the enqueuer.
 	// After Close is called, the enqueuer should not be used
 	Close() error
@@ -7,6 +7,8 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/go-testfixtures/testfixtures/v3"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -56,6 +58,52 @@ func MakeRequest(t *testing.T, app TestApp, endpoint string, httpMethod string,
 	return w
 }
 
+// LoadFixtures loads the yaml fixtures of the directory into the database of the app
+func LoadFixtures(t *testing.T, app TestApp, directory string) {
+	t.Helper()
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory(directory),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// MakeAuthenticatedRequest makes the request with a fresh access token of the user
+func MakeAuthenticatedRequest(t *testing.T, app TestApp, userID string, endpoint string, httpMethod string, requestPayload any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	accessToken, err := app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+	assert.NoError(t, err)
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+	}
+	return MakeRequest(t, app, endpoint, httpMethod, requestPayload, headers)
+}
+
+// GetAccountBalance reads the balance of the account straight from the database
+func GetAccountBalance(t *testing.T, app TestApp, accountID int64) int64 {
+	t.Helper()
+
+	var account accountModel.Account
+	err := app.Db.NewSelect().
+		Model(&account).
+		Column("balance").
+		Where("id = ?", accountID).
+		Scan(t.Context())
+	assert.NoError(t, err)
+	return account.Balance
+}
+
 func AssertFieldError(t *testing.T, resp ErrorResponse, field string, expectedErrMsg string) {
 	t.Helper()
 
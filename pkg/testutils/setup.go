@@ -13,7 +13,17 @@ import (
 	"github.com/skamranahmed/go-bank/config"
 	"github.com/skamranahmed/go-bank/internal"
 	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	balanceModel "github.com/skamranahmed/go-bank/internal/balance/model"
+	beneficiaryModel "github.com/skamranahmed/go-bank/internal/beneficiary/model"
+	depositModel "github.com/skamranahmed/go-bank/internal/deposit/model"
+	feeModel "github.com/skamranahmed/go-bank/internal/fee/model"
+	fxModel "github.com/skamranahmed/go-bank/internal/fx/model"
+	ledgerModel "github.com/skamranahmed/go-bank/internal/ledger/model"
+	loanModel "github.com/skamranahmed/go-bank/internal/loan/model"
+	paymentRequestModel "github.com/skamranahmed/go-bank/internal/paymentrequest/model"
+	transferModel "github.com/skamranahmed/go-bank/internal/transfer/model"
 	userModel "github.com/skamranahmed/go-bank/internal/user/model"
+	vpaModel "github.com/skamranahmed/go-bank/internal/vpa/model"
 	"github.com/skamranahmed/go-bank/pkg/cache"
 	"github.com/skamranahmed/go-bank/pkg/logger"
 	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
@@ -323,6 +333,40 @@ func allModels() []interface{} {
 		(*userModel.User)(nil),
 		(*accountModel.Account)(nil),
 		(*accountModel.Transaction)(nil),
+		(*ledgerModel.InternalAccount)(nil),
+		(*ledgerModel.InternalAccountEntry)(nil),
+		(*feeModel.FeeRule)(nil),
+		(*feeModel.FeeCharge)(nil),
+		(*balanceModel.DailyBalance)(nil),
+		(*balanceModel.AverageBalanceBreach)(nil),
+		(*depositModel.FixedDeposit)(nil),
+		(*depositModel.RecurringDeposit)(nil),
+		(*depositModel.RecurringDepositInstallment)(nil),
+		(*loanModel.Loan)(nil),
+		(*loanModel.Installment)(nil),
+		(*beneficiaryModel.Beneficiary)(nil),
+		(*transferModel.TransferLimitOverride)(nil),
+		(*transferModel.ScheduledTransfer)(nil),
+		(*transferModel.StandingInstruction)(nil),
+		(*transferModel.StandingInstructionExecution)(nil),
+		(*transferModel.TransferReversal)(nil),
+		(*transferModel.PaymentOrder)(nil),
+		(*transferModel.InboundPaymentFile)(nil),
+		(*transferModel.InboundPayment)(nil),
+		(*transferModel.BulkTransfer)(nil),
+		(*transferModel.BulkTransferItem)(nil),
+		(*vpaModel.VPA)(nil),
+		(*vpaModel.CollectRequest)(nil),
+		(*paymentRequestModel.PaymentRequest)(nil),
+		(*paymentRequestModel.PaymentRequestPayment)(nil),
+		(*fxModel.FXRate)(nil),
+		(*fxModel.FXQuote)(nil),
+		(*accountModel.Pocket)(nil),
+		(*accountModel.PocketMovement)(nil),
+		(*accountModel.AccountHolder)(nil),
+		(*transferModel.JointTransfer)(nil),
+		(*transferModel.JointTransferApproval)(nil),
+		(*accountModel.Nominee)(nil),
 		// add new models here
 	}
 }
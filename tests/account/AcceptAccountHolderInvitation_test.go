@@ -0,0 +1,115 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type AcceptAccountHolderInvitationTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestAcceptAccountHolderInvitationTestSuite(t *testing.T) {
+	suite.Run(t, new(AcceptAccountHolderInvitationTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *AcceptAccountHolderInvitationTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/AcceptAccountHolderInvitation_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *AcceptAccountHolderInvitationTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *AcceptAccountHolderInvitationTestSuite) acceptInvitation(t *testing.T, userID string, accountHolderID string) *httptest.ResponseRecorder {
+	return testutils.MakeAuthenticatedRequest(t, suite.app, userID, fmt.Sprintf("/v1/account-holder-invitations/%s/accept", accountHolderID), http.MethodPost, nil)
+}
+
+func (suite *AcceptAccountHolderInvitationTestSuite) TestRejections() {
+	suite.T().Run("invalid invitation ID returns 400", func(t *testing.T) {
+		responseRecorder := suite.acceptInvitation(t, "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f", "invalid")
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Invalid account holder ID")
+	})
+
+	suite.T().Run("invitation of another user returns 404", func(t *testing.T) {
+		responseRecorder := suite.acceptInvitation(t, "d4e5f6a7-b8c9-4d0e-9f1a-3b4c5d6e7f80", "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a05")
+		assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+
+		// the user is still only invited on their own invitation
+		var accountHolder model.AccountHolder
+		err := suite.app.Db.NewSelect().
+			Model(&accountHolder).
+			Where("id = ?", "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a06").
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, model.AccountHolderInvited, accountHolder.Status)
+	})
+}
+
+func (suite *AcceptAccountHolderInvitationTestSuite) TestAcceptAccountHolderInvitation() {
+	suite.T().Run("invited user accepts the invitation", func(t *testing.T) {
+		responseRecorder := suite.acceptInvitation(t, "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f", "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a05")
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.RespondToAccountHolderInvitationResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, string(model.AccountHolderActive), response.Data.Status)
+		assert.NotNil(t, response.Data.RespondedAt)
+
+		var accountHolder model.AccountHolder
+		err = suite.app.Db.NewSelect().
+			Model(&accountHolder).
+			Where("id = ?", "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a05").
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, model.AccountHolderActive, accountHolder.Status)
+		assert.NotNil(t, accountHolder.RespondedAt)
+	})
+
+	suite.T().Run("joint holder accesses the account", func(t *testing.T) {
+		account := getAccountDetails(t, suite.app, "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f", 66666666666660)
+		assert.Equal(t, model.MandateEitherOrSurvivor, account.Mandate)
+		assert.True(t, slices.ContainsFunc(account.Holders, func(accountHolder types.AccountHolderDto) bool {
+			return accountHolder.UserID == "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f"
+		}))
+	})
+
+	suite.T().Run("joint account is listed with the accounts of the joint holder", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f", "/v1/accounts", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetAccountsResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 3)
+		assert.True(t, slices.ContainsFunc(response.Data, func(account types.AccountDto) bool {
+			return account.ID == 66666666666660
+		}))
+	})
+
+	suite.T().Run("invitation cannot be accepted twice", func(t *testing.T) {
+		responseRecorder := suite.acceptInvitation(t, "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f", "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a05")
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "The invitation is no longer pending")
+	})
+}
@@ -0,0 +1,306 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/skamranahmed/go-bank/cmd/middleware"
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/authentication/dto"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// stepUp confirms the password of the user and returns the step-up token, it can be used for one sensitive action
+func stepUp(t *testing.T, app testutils.TestApp, userID string) string {
+	payload := dto.StepUpRequest{
+		Data: dto.StepUpData{
+			Password: "password",
+		},
+	}
+	responseRecorder := testutils.MakeAuthenticatedRequest(t, app, userID, "/v1/step-up", http.MethodPost, payload)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var response dto.StepUpResponse
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, response.StepUpToken)
+	return response.StepUpToken
+}
+
+// makeStepUpRequest sends the request with the step-up token in its header when one is given
+func makeStepUpRequest(t *testing.T, app testutils.TestApp, userID string, url string, method string, payload any, stepUpToken string) *httptest.ResponseRecorder {
+	accessToken, err := app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+	assert.NoError(t, err)
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+	}
+	if stepUpToken != "" {
+		headers[middleware.StepUpTokenHeader] = stepUpToken
+	}
+	return testutils.MakeRequest(t, app, url, method, payload, headers)
+}
+
+func getNominees(t *testing.T, app testutils.TestApp, userID string, accountID int64) []types.NomineeDto {
+	responseRecorder := testutils.MakeAuthenticatedRequest(t, app, userID, fmt.Sprintf("/v1/accounts/%d/nominees", accountID), http.MethodGet, nil)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var response types.GetNomineesResponse
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	return response.Data
+}
+
+func decodeNominee(t *testing.T, responseRecorder *httptest.ResponseRecorder) types.NomineeDto {
+	var response types.AddNomineeResponse
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	return response.Data
+}
+
+type AddNomineeTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestAddNomineeTestSuite(t *testing.T) {
+	suite.Run(t, new(AddNomineeTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *AddNomineeTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/AddNominee_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *AddNomineeTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *AddNomineeTestSuite) addNominee(t *testing.T, userID string, accountID int64, data types.NomineeRequestData, stepUpToken string) *httptest.ResponseRecorder {
+	payload := types.AddNomineeRequest{
+		Data: data,
+	}
+	return makeStepUpRequest(t, suite.app, userID, fmt.Sprintf("/v1/accounts/%d/nominees", accountID), http.MethodPost, payload, stepUpToken)
+}
+
+func (suite *AddNomineeTestSuite) TestRejections() {
+	guardianName := "Jane Doe"
+	guardianRelationship := "MOTHER"
+	minorDateOfBirth := time.Now().UTC().AddDate(-10, 0, 0).Format(time.DateOnly)
+
+	// the nominees of the account in the fixtures are given 50 and 30, to make room for a share of 20
+	rebalancedShares := []types.NomineeShareRequestData{
+		{NomineeID: "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c01", SharePercentage: 50},
+		{NomineeID: "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c02", SharePercentage: 30},
+	}
+
+	type scenario struct {
+		name       string
+		userID     string
+		accountID  int64
+		data       types.NomineeRequestData
+		withStepUp bool
+		statusCode int
+		errMessage string
+	}
+
+	tests := []scenario{
+		{
+			name:      "request without a step-up token returns 403",
+			userID:    "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			accountID: 12345678901237,
+			data: types.NomineeRequestData{
+				Name:            "John Doe",
+				Relationship:    string(model.NomineeSibling),
+				DateOfBirth:     "1985-01-20",
+				SharePercentage: 20,
+				OtherShares:     rebalancedShares,
+			},
+			withStepUp: false,
+			statusCode: http.StatusForbidden,
+			errMessage: "This action needs a valid step-up token, confirm your password to get one",
+		},
+		{
+			name:      "user who is not a holder returns 403",
+			userID:    "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e",
+			accountID: 12345678901237,
+			data: types.NomineeRequestData{
+				Name:            "John Doe",
+				Relationship:    string(model.NomineeSibling),
+				DateOfBirth:     "1985-01-20",
+				SharePercentage: 20,
+				OtherShares:     rebalancedShares,
+			},
+			withStepUp: true,
+			statusCode: http.StatusForbidden,
+			errMessage: "You do not have permission to access this account",
+		},
+		{
+			name:      "joint holder returns 403",
+			userID:    "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f",
+			accountID: 12345678901237,
+			data: types.NomineeRequestData{
+				Name:            "John Doe",
+				Relationship:    string(model.NomineeSibling),
+				DateOfBirth:     "1985-01-20",
+				SharePercentage: 20,
+				OtherShares:     rebalancedShares,
+			},
+			withStepUp: true,
+			statusCode: http.StatusForbidden,
+			errMessage: "Only the primary holder can change the nominees of this account",
+		},
+		{
+			name:      "minor nominee without a guardian returns 400",
+			userID:    "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			accountID: 12345678901237,
+			data: types.NomineeRequestData{
+				Name:            "Max Doe",
+				Relationship:    string(model.NomineeChild),
+				DateOfBirth:     minorDateOfBirth,
+				SharePercentage: 20,
+				OtherShares:     rebalancedShares,
+			},
+			withStepUp: true,
+			statusCode: http.StatusBadRequest,
+			errMessage: "A guardian is required for a minor nominee",
+		},
+		{
+			name:      "adult nominee with a guardian returns 400",
+			userID:    "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			accountID: 12345678901237,
+			data: types.NomineeRequestData{
+				Name:                 "John Doe",
+				Relationship:         string(model.NomineeSibling),
+				DateOfBirth:          "1985-01-20",
+				SharePercentage:      20,
+				GuardianName:         &guardianName,
+				GuardianRelationship: &guardianRelationship,
+				OtherShares:          rebalancedShares,
+			},
+			withStepUp: true,
+			statusCode: http.StatusBadRequest,
+			errMessage: "A guardian can only be appointed for a minor nominee",
+		},
+		{
+			name:      "nominee added without rebalancing the others returns 400",
+			userID:    "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			accountID: 12345678901237,
+			data: types.NomineeRequestData{
+				Name:            "John Doe",
+				Relationship:    string(model.NomineeSibling),
+				DateOfBirth:     "1985-01-20",
+				SharePercentage: 20,
+			},
+			withStepUp: true,
+			statusCode: http.StatusBadRequest,
+			errMessage: "The shares of the nominees must add up to 100, they would add up to 120",
+		},
+		{
+			name:      "first nominee without the whole share returns 400",
+			userID:    "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e",
+			accountID: 33333333333330,
+			data: types.NomineeRequestData{
+				Name:            "Alex Roe",
+				Relationship:    string(model.NomineeParent),
+				DateOfBirth:     "1960-08-30",
+				SharePercentage: 60,
+			},
+			withStepUp: true,
+			statusCode: http.StatusBadRequest,
+			errMessage: "The shares of the nominees must add up to 100, they would add up to 60",
+		},
+	}
+
+	nomineesBefore := getNominees(suite.T(), suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237)
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			var stepUpToken string
+			if tc.withStepUp {
+				stepUpToken = stepUp(t, suite.app, tc.userID)
+			}
+
+			responseRecorder := suite.addNominee(t, tc.userID, tc.accountID, tc.data, stepUpToken)
+			assert.Equal(t, tc.statusCode, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, "message", tc.errMessage)
+		})
+	}
+
+	suite.T().Run("nominees are unchanged", func(t *testing.T) {
+		assert.Equal(t, nomineesBefore, getNominees(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237))
+		assert.Empty(t, getNominees(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", 33333333333330))
+	})
+}
+
+func (suite *AddNomineeTestSuite) TestAddNominee() {
+	suite.T().Run("first nominee is given the whole share", func(t *testing.T) {
+		data := types.NomineeRequestData{
+			Name:            "Alex Roe",
+			Relationship:    string(model.NomineeParent),
+			DateOfBirth:     "1960-08-30",
+			SharePercentage: 100,
+		}
+		stepUpToken := stepUp(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e")
+		responseRecorder := suite.addNominee(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", 22222222222220, data, stepUpToken)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		nominee := decodeNominee(t, responseRecorder)
+		assert.Equal(t, "Alex Roe", nominee.Name)
+		assert.Equal(t, string(model.NomineeParent), nominee.Relationship)
+		assert.Equal(t, "1960-08-30", nominee.DateOfBirth)
+		assert.Equal(t, 100, nominee.SharePercentage)
+		assert.Nil(t, nominee.GuardianName)
+		assert.Equal(t, string(model.NomineeActive), nominee.Status)
+
+		// the step-up token can only be used once
+		responseRecorder = suite.addNominee(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", 22222222222220, data, stepUpToken)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		nominees := getNominees(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", 22222222222220)
+		if assert.Len(t, nominees, 1) {
+			assert.Equal(t, nominee.ID, nominees[0].ID)
+		}
+	})
+
+	suite.T().Run("other nominees make room for a new one", func(t *testing.T) {
+		guardianName := "Jane Doe"
+		guardianRelationship := "MOTHER"
+		data := types.NomineeRequestData{
+			Name:                 "Max Doe",
+			Relationship:         string(model.NomineeChild),
+			DateOfBirth:          time.Now().UTC().AddDate(-10, 0, 0).Format(time.DateOnly),
+			SharePercentage:      20,
+			GuardianName:         &guardianName,
+			GuardianRelationship: &guardianRelationship,
+			OtherShares: []types.NomineeShareRequestData{
+				{NomineeID: "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c01", SharePercentage: 50},
+				{NomineeID: "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c02", SharePercentage: 30},
+			},
+		}
+		responseRecorder := suite.addNominee(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237, data, stepUp(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"))
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		nominee := decodeNominee(t, responseRecorder)
+		assert.Equal(t, 20, nominee.SharePercentage)
+		assert.Equal(t, &guardianName, nominee.GuardianName)
+
+		nominees := getNominees(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237)
+		if assert.Len(t, nominees, 3) {
+			assert.Equal(t, 50, nominees[0].SharePercentage)
+			assert.Equal(t, 30, nominees[1].SharePercentage)
+			assert.Equal(t, nominee.ID, nominees[2].ID)
+		}
+	})
+}
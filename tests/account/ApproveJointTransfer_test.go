@@ -0,0 +1,172 @@
+package account
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	transferModel "github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ApproveJointTransferTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestApproveJointTransferTestSuite(t *testing.T) {
+	suite.Run(t, new(ApproveJointTransferTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *ApproveJointTransferTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/ApproveJointTransfer_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *ApproveJointTransferTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *ApproveJointTransferTestSuite) approveJointTransfer(t *testing.T, userID string, jointTransferID string) *httptest.ResponseRecorder {
+	return testutils.MakeAuthenticatedRequest(t, suite.app, userID, fmt.Sprintf("/v1/joint-transfers/%s/approve", jointTransferID), http.MethodPost, nil)
+}
+
+func (suite *ApproveJointTransferTestSuite) TestRejections() {
+	type scenario struct {
+		name            string
+		userID          string
+		jointTransferID string
+		statusCode      int
+		errMessage      string
+	}
+
+	tests := []scenario{
+		{
+			name:            "user who is not a holder returns 403",
+			userID:          "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f",
+			jointTransferID: "7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b01",
+			statusCode:      http.StatusForbidden,
+			errMessage:      "You do not have permission to access this joint transfer",
+		},
+		{
+			name:            "initiator approving again returns 409",
+			userID:          "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e",
+			jointTransferID: "7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b02",
+			statusCode:      http.StatusConflict,
+			errMessage:      "You have already approved this transfer",
+		},
+		{
+			name:            "rejected transfer returns 400",
+			userID:          "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			jointTransferID: "7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b03",
+			statusCode:      http.StatusBadRequest,
+			errMessage:      "Only a pending joint transfer can be approved or rejected",
+		},
+		{
+			name:            "invalid joint transfer ID returns 400",
+			userID:          "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			jointTransferID: "invalid",
+			statusCode:      http.StatusBadRequest,
+			errMessage:      "Invalid joint transfer ID",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := suite.approveJointTransfer(t, tc.userID, tc.jointTransferID)
+			assert.Equal(t, tc.statusCode, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, "message", tc.errMessage)
+		})
+	}
+
+	suite.T().Run("transfer still waits for the other holder", func(t *testing.T) {
+		var jointTransfer transferModel.JointTransfer
+		err := suite.app.Db.NewSelect().
+			Model(&jointTransfer).
+			Where("id = ?", "7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b02").
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, transferModel.JointTransferPending, jointTransfer.Status)
+		assert.Nil(t, jointTransfer.TransactionID)
+
+		approvalCount, err := suite.app.Db.NewSelect().
+			Model((*transferModel.JointTransferApproval)(nil)).
+			Where("joint_transfer_id = ?", "7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b02").
+			Count(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, approvalCount)
+	})
+}
+
+func (suite *ApproveJointTransferTestSuite) TestApproveJointTransfer() {
+	suite.T().Run("approval of the last holder executes the transfer", func(t *testing.T) {
+		responseRecorder := suite.approveJointTransfer(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b01")
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		jointTransfer := decodeJointTransfer(t, responseRecorder)
+		assert.Equal(t, transferModel.JointTransferCompleted, jointTransfer.Status)
+		assert.NotNil(t, jointTransfer.TransactionID)
+		assert.NotNil(t, jointTransfer.ExecutedAt)
+		assert.Len(t, jointTransfer.Approvals, 2)
+
+		assert.Equal(t, int64(20000), testutils.GetAccountBalance(t, suite.app, 33333333333330))
+		assert.Equal(t, int64(480000), testutils.GetAccountBalance(t, suite.app, 44444444444440))
+
+		var debit model.Transaction
+		err := suite.app.Db.NewSelect().
+			Model(&debit).
+			Where("account_id = ?", 44444444444440).
+			Where("type = ?", model.Debit).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(20000), debit.Amount)
+		if assert.NotNil(t, jointTransfer.TransactionID) {
+			assert.Equal(t, debit.ID.String(), *jointTransfer.TransactionID)
+		}
+
+		var approvals []transferModel.JointTransferApproval
+		err = suite.app.Db.NewSelect().
+			Model(&approvals).
+			Where("joint_transfer_id = ?", "7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b01").
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Len(t, approvals, 2)
+		for _, approval := range approvals {
+			assert.Equal(t, transferModel.JointTransferApproved, approval.Decision)
+		}
+	})
+
+	suite.T().Run("executed transfer cannot be approved again", func(t *testing.T) {
+		responseRecorder := suite.approveJointTransfer(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b01")
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Only a pending joint transfer can be approved or rejected")
+	})
+
+	suite.T().Run("approving again executes the transfer once the holders who had not approved it left", func(t *testing.T) {
+		responseRecorder := suite.approveJointTransfer(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b04")
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		jointTransfer := decodeJointTransfer(t, responseRecorder)
+		assert.Equal(t, transferModel.JointTransferCompleted, jointTransfer.Status)
+		assert.NotNil(t, jointTransfer.TransactionID)
+		assert.Equal(t, int64(490000), testutils.GetAccountBalance(t, suite.app, 55555555555550))
+
+		// approving again does not record a second decision of the holder
+		approvalCount, err := suite.app.Db.NewSelect().
+			Model((*transferModel.JointTransferApproval)(nil)).
+			Where("joint_transfer_id = ?", "7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b04").
+			Count(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 2, approvalCount)
+	})
+}
@@ -0,0 +1,137 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func getAccountDetails(t *testing.T, app testutils.TestApp, userID string, accountID int64) types.AccountDetailsDto {
+	responseRecorder := testutils.MakeAuthenticatedRequest(t, app, userID, fmt.Sprintf("/v1/accounts/%d", accountID), http.MethodGet, nil)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var response types.GetAccountByIDResponse
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	return response.Data
+}
+
+func movePocketFunds(t *testing.T, app testutils.TestApp, userID string, accountID int64, pocketID string, direction string, amount int64) *httptest.ResponseRecorder {
+	payload := types.MovePocketFundsRequest{
+		Data: types.MovePocketFundsRequestData{
+			Amount: amount,
+		},
+	}
+	return testutils.MakeAuthenticatedRequest(t, app, userID, fmt.Sprintf("/v1/accounts/%d/pockets/%s/%s", accountID, pocketID, direction), http.MethodPost, payload)
+}
+
+type ClosePocketTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestClosePocketTestSuite(t *testing.T) {
+	suite.Run(t, new(ClosePocketTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *ClosePocketTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/ClosePocket_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *ClosePocketTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *ClosePocketTestSuite) closePocket(t *testing.T, userID string, accountID int64, pocketID string) *httptest.ResponseRecorder {
+	return testutils.MakeAuthenticatedRequest(t, suite.app, userID, fmt.Sprintf("/v1/accounts/%d/pockets/%s", accountID, pocketID), http.MethodDelete, nil)
+}
+
+func (suite *ClosePocketTestSuite) TestRejections() {
+	suite.T().Run("pocket that is already closed returns 400", func(t *testing.T) {
+		responseRecorder := suite.closePocket(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237, "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f02")
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Pocket Wedding is closed")
+
+		var pocket model.Pocket
+		err := suite.app.Db.NewSelect().
+			Model(&pocket).
+			Where("id = ?", "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f02").
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, model.PocketClosed, pocket.Status)
+		assert.Equal(t, "2025-10-05", pocket.ClosedAt.UTC().Format(time.DateOnly))
+	})
+
+	suite.T().Run("pocket of another account returns 404", func(t *testing.T) {
+		responseRecorder := suite.closePocket(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", 22222222222220, "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f01")
+		assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	})
+}
+
+func (suite *ClosePocketTestSuite) TestClosePocket() {
+	suite.T().Run("closed pocket gives its balance back", func(t *testing.T) {
+		responseRecorder := suite.closePocket(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237, "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f01")
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.ClosePocketResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, string(model.PocketClosed), response.Data.Status)
+		assert.Equal(t, int64(0), response.Data.Balance)
+		assert.NotNil(t, response.Data.ClosedAt)
+
+		account := getAccountDetails(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237)
+		assert.Equal(t, int64(0), account.PocketedAmount)
+		assert.Equal(t, int64(150000), account.AvailableBalance)
+		assert.Len(t, account.Pockets, 0)
+
+		var pocketMovements []model.PocketMovement
+		err = suite.app.Db.NewSelect().
+			Model(&pocketMovements).
+			Where("pocket_id = ?", "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f01").
+			Order("created_at DESC").
+			Scan(t.Context())
+		assert.NoError(t, err)
+		if assert.Len(t, pocketMovements, 3) {
+			assert.Equal(t, model.PocketWithdrawal, pocketMovements[0].Type)
+			assert.Equal(t, int64(50000), pocketMovements[0].Amount)
+			assert.Equal(t, int64(0), pocketMovements[0].BalanceAfter)
+		}
+	})
+
+	suite.T().Run("closed pocket takes the round-ups of the account no more", func(t *testing.T) {
+		responseRecorder := suite.closePocket(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", 22222222222220, "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f03")
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.ClosePocketResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Nil(t, response.Data.RoundUpTo)
+
+		account := getAccountDetails(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", 22222222222220)
+		assert.Equal(t, int64(100000), account.AvailableBalance)
+		assert.Len(t, account.Pockets, 0)
+	})
+
+	suite.T().Run("money cannot be moved into a closed pocket", func(t *testing.T) {
+		responseRecorder := movePocketFunds(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237, "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f01", "deposit", 1000)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Pocket Vacation is closed")
+	})
+}
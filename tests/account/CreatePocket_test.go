@@ -0,0 +1,213 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type CreatePocketTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestCreatePocketTestSuite(t *testing.T) {
+	suite.Run(t, new(CreatePocketTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *CreatePocketTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/CreatePocket_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *CreatePocketTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *CreatePocketTestSuite) createPocket(t *testing.T, userID string, accountID int64, data types.CreatePocketRequestData) *httptest.ResponseRecorder {
+	payload := types.CreatePocketRequest{
+		Data: data,
+	}
+	return testutils.MakeAuthenticatedRequest(t, suite.app, userID, fmt.Sprintf("/v1/accounts/%d/pockets", accountID), http.MethodPost, payload)
+}
+
+func (suite *CreatePocketTestSuite) TestValidation() {
+	roundUpTo := int64(5)
+
+	type scenario struct {
+		name       string
+		data       types.CreatePocketRequestData
+		field      string
+		errMessage string
+	}
+
+	tests := []scenario{
+		{
+			name:       "missing name",
+			data:       types.CreatePocketRequestData{TargetAmount: 100000},
+			field:      "name",
+			errMessage: "name is a required field",
+		},
+		{
+			name:       "missing target amount",
+			data:       types.CreatePocketRequestData{Name: "Goa trip"},
+			field:      "target_amount",
+			errMessage: "target_amount is a required field",
+		},
+		{
+			name:       "invalid target date",
+			data:       types.CreatePocketRequestData{Name: "Goa trip", TargetAmount: 100000, TargetDate: "31-12-2030"},
+			field:      "target_date",
+			errMessage: "target_date is not a valid date",
+		},
+		{
+			name:       "round up to an unsupported amount",
+			data:       types.CreatePocketRequestData{Name: "Goa trip", TargetAmount: 100000, RoundUpTo: &roundUpTo},
+			field:      "round_up_to",
+			errMessage: "round_up_to must be one of: 1, 10, 100",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := suite.createPocket(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237, tc.data)
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, tc.field, tc.errMessage)
+		})
+	}
+}
+
+func (suite *CreatePocketTestSuite) TestRejections() {
+	roundUpTo := int64(1)
+
+	type scenario struct {
+		name       string
+		userID     string
+		accountID  int64
+		data       types.CreatePocketRequestData
+		statusCode int
+		errMessage string
+	}
+
+	tests := []scenario{
+		{
+			name:       "target date in the past returns 400",
+			userID:     "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			accountID:  12345678901237,
+			data:       types.CreatePocketRequestData{Name: "Goa trip", TargetAmount: 100000, TargetDate: time.Now().UTC().AddDate(0, 0, -1).Format(time.DateOnly)},
+			statusCode: http.StatusBadRequest,
+			errMessage: "Target date must be in the future",
+		},
+		{
+			name:       "pocket in a current account returns 400",
+			userID:     "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			accountID:  11111111111110,
+			data:       types.CreatePocketRequestData{Name: "Goa trip", TargetAmount: 100000},
+			statusCode: http.StatusBadRequest,
+			errMessage: "Pockets are only available for SAVINGS_ACCOUNT",
+		},
+		{
+			name:       "pocket with the name of an active pocket returns 409",
+			userID:     "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			accountID:  12345678901237,
+			data:       types.CreatePocketRequestData{Name: "vacation", TargetAmount: 100000},
+			statusCode: http.StatusConflict,
+			errMessage: "You already have a pocket named Vacation",
+		},
+		{
+			name:       "second pocket with round-ups returns 409",
+			userID:     "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e",
+			accountID:  22222222222220,
+			data:       types.CreatePocketRequestData{Name: "Coins", TargetAmount: 100000, RoundUpTo: &roundUpTo},
+			statusCode: http.StatusConflict,
+			errMessage: "Round-ups already go to the pocket Spare change, turn them off there first",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := suite.createPocket(t, tc.userID, tc.accountID, tc.data)
+			assert.Equal(t, tc.statusCode, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, "message", tc.errMessage)
+		})
+	}
+
+	suite.T().Run("pocket in an account of another user returns 403", func(t *testing.T) {
+		responseRecorder := suite.createPocket(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", 12345678901237, types.CreatePocketRequestData{
+			Name:         "Goa trip",
+			TargetAmount: 100000,
+		})
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	})
+
+	suite.T().Run("no pocket is created in the current account or next to the round-up pocket", func(t *testing.T) {
+		pocketCount, err := suite.app.Db.NewSelect().Model((*model.Pocket)(nil)).Where("account_id IN (?, ?)", 11111111111110, 22222222222220).Count(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, pocketCount)
+	})
+}
+
+func (suite *CreatePocketTestSuite) TestCreatePocket() {
+	suite.T().Run("pocket is created empty", func(t *testing.T) {
+		targetDate := time.Now().UTC().AddDate(1, 0, 0).Format(time.DateOnly)
+		responseRecorder := suite.createPocket(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237, types.CreatePocketRequestData{
+			Name:         "Goa trip",
+			TargetAmount: 300000,
+			TargetDate:   targetDate,
+		})
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.CreatePocketResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "Goa trip", response.Data.Name)
+		assert.Equal(t, int64(0), response.Data.Balance)
+		assert.Equal(t, int64(300000), response.Data.TargetAmount)
+		assert.Equal(t, targetDate, *response.Data.TargetDate)
+		assert.Equal(t, string(model.PocketActive), response.Data.Status)
+		assert.Nil(t, response.Data.RoundUpTo)
+
+		account := getAccountDetails(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237)
+		assert.Equal(t, int64(50000), account.PocketedAmount)
+		assert.Len(t, account.Pockets, 2)
+	})
+
+	suite.T().Run("name of a closed pocket can be used again", func(t *testing.T) {
+		roundUpTo := int64(10)
+		responseRecorder := suite.createPocket(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237, types.CreatePocketRequestData{
+			Name:         "Wedding",
+			TargetAmount: 1000000,
+			RoundUpTo:    &roundUpTo,
+		})
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.CreatePocketResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, roundUpTo, *response.Data.RoundUpTo)
+
+		var pocket model.Pocket
+		err = suite.app.Db.NewSelect().
+			Model(&pocket).
+			Where("id = ?", response.Data.ID).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, model.PocketActive, pocket.Status)
+		assert.Equal(t, int64(12345678901237), pocket.AccountID)
+	})
+}
@@ -0,0 +1,103 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type DeclineAccountHolderInvitationTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestDeclineAccountHolderInvitationTestSuite(t *testing.T) {
+	suite.Run(t, new(DeclineAccountHolderInvitationTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *DeclineAccountHolderInvitationTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/DeclineAccountHolderInvitation_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *DeclineAccountHolderInvitationTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *DeclineAccountHolderInvitationTestSuite) declineInvitation(t *testing.T, userID string, accountHolderID string) *httptest.ResponseRecorder {
+	return testutils.MakeAuthenticatedRequest(t, suite.app, userID, fmt.Sprintf("/v1/account-holder-invitations/%s/decline", accountHolderID), http.MethodPost, nil)
+}
+
+func (suite *DeclineAccountHolderInvitationTestSuite) TestDeclineAccountHolderInvitation() {
+	suite.T().Run("invitation of another user returns 404", func(t *testing.T) {
+		responseRecorder := suite.declineInvitation(t, "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f", "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a06")
+		assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+
+		var accountHolder model.AccountHolder
+		err := suite.app.Db.NewSelect().
+			Model(&accountHolder).
+			Where("id = ?", "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a06").
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, model.AccountHolderInvited, accountHolder.Status)
+		assert.Nil(t, accountHolder.RespondedAt)
+	})
+
+	suite.T().Run("invited user declines the invitation", func(t *testing.T) {
+		responseRecorder := suite.declineInvitation(t, "d4e5f6a7-b8c9-4d0e-9f1a-3b4c5d6e7f80", "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a06")
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.RespondToAccountHolderInvitationResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, string(model.AccountHolderDeclined), response.Data.Status)
+		assert.NotNil(t, response.Data.RespondedAt)
+	})
+
+	suite.T().Run("user who declined cannot access the account", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "d4e5f6a7-b8c9-4d0e-9f1a-3b4c5d6e7f80", "/v1/accounts/66666666666660", http.MethodGet, nil)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	})
+
+	suite.T().Run("invitation cannot be declined twice", func(t *testing.T) {
+		responseRecorder := suite.declineInvitation(t, "d4e5f6a7-b8c9-4d0e-9f1a-3b4c5d6e7f80", "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a06")
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "The invitation is no longer pending")
+	})
+
+	suite.T().Run("user who declined can be invited again", func(t *testing.T) {
+		payload := types.InviteAccountHolderRequest{
+			Data: types.InviteAccountHolderRequestData{
+				Username: "test_user_4",
+			},
+		}
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/accounts/66666666666660/holders", http.MethodPost, payload)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		// the declined invitation is kept, the new one is added next to it
+		var accountHolders []model.AccountHolder
+		err := suite.app.Db.NewSelect().
+			Model(&accountHolders).
+			Where("account_id = ?", 66666666666660).
+			Where("user_id = ?", "d4e5f6a7-b8c9-4d0e-9f1a-3b4c5d6e7f80").
+			Order("created_at ASC").
+			Scan(t.Context())
+		assert.NoError(t, err)
+		if assert.Len(t, accountHolders, 2) {
+			assert.Equal(t, model.AccountHolderDeclined, accountHolders[0].Status)
+			assert.Equal(t, model.AccountHolderInvited, accountHolders[1].Status)
+		}
+	})
+}
@@ -112,6 +112,22 @@ func (suite *GetAccountByIDTestSuite) TestInvalidAccountID() {
 		response := testutils.DecodeErrorResponse(t, responseRecorder)
 		testutils.AssertFieldError(t, response, "message", "Invalid account ID")
 	})
+
+	suite.T().Run("account ID with a wrong check digit returns 400", func(t *testing.T) {
+		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+		assert.NoError(t, err)
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/accounts/1000000000000000", http.MethodGet, nil, headers)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Invalid account ID")
+	})
 }
 
 func (suite *GetAccountByIDTestSuite) TestAccountNotFound() {
@@ -175,9 +191,31 @@ func (suite *GetAccountByIDTestSuite) TestSuccessfulGetAccountByID() {
 		assert.Equal(t, userID, response.Data.UserID)
 		assert.Equal(t, int64(150000), response.Data.Balance)
 		assert.Equal(t, "SAVINGS_ACCOUNT", string(response.Data.Type))
+		assert.Equal(t, "GOBK0000001", response.Data.IFSC)
+		assert.Equal(t, "IN76GOBK0000010012345678901234", response.Data.IBAN)
 		assert.NotZero(t, response.Data.CreatedAt)
 		assert.NotZero(t, response.Data.UpdatedAt)
 	})
+
+	suite.T().Run("account held at another branch has the IFSC and IBAN of that branch", func(t *testing.T) {
+		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+		assert.NoError(t, err)
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/accounts/98765432109876", http.MethodGet, nil, headers)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetAccountByIDResponse
+		err = json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "GOBK0000002", response.Data.IFSC)
+		assert.Equal(t, "IN47GOBK0000020098765432109876", response.Data.IBAN)
+	})
 }
 
 func (suite *GetAccountByIDTestSuite) TestResponseFormat() {
@@ -207,7 +245,7 @@ func (suite *GetAccountByIDTestSuite) TestResponseFormat() {
 		assert.True(t, ok, "data should be an object")
 
 		// verify all required fields exist
-		requiredFields := []string{"id", "created_at", "updated_at", "user_id", "balance", "type"}
+		requiredFields := []string{"id", "created_at", "updated_at", "user_id", "balance", "type", "ifsc", "iban"}
 		for _, field := range requiredFields {
 			_, exists := dataObject[field]
 			assert.True(t, exists, "account should contain field: %s", field)
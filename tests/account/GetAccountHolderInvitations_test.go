@@ -0,0 +1,63 @@
+package account
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type GetAccountHolderInvitationsTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestGetAccountHolderInvitationsTestSuite(t *testing.T) {
+	suite.Run(t, new(GetAccountHolderInvitationsTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *GetAccountHolderInvitationsTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/GetAccountHolderInvitations_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *GetAccountHolderInvitationsTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *GetAccountHolderInvitationsTestSuite) getInvitations(t *testing.T, userID string) []types.AccountHolderDto {
+	responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, userID, "/v1/account-holder-invitations", http.MethodGet, nil)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var response types.GetAccountHolderInvitationsResponse
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	return response.Data
+}
+
+func (suite *GetAccountHolderInvitationsTestSuite) TestGetAccountHolderInvitations() {
+	suite.T().Run("invited user sees the invitation", func(t *testing.T) {
+		invitations := suite.getInvitations(t, "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f")
+		assert.Len(t, invitations, 1)
+		assert.Equal(t, "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a05", invitations[0].ID)
+		assert.Equal(t, int64(66666666666660), invitations[0].AccountID)
+		assert.Equal(t, string(model.AccountHolderInvited), invitations[0].Status)
+	})
+
+	suite.T().Run("accepted invitations are not listed", func(t *testing.T) {
+		invitations := suite.getInvitations(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e")
+		assert.Len(t, invitations, 0)
+	})
+
+	suite.T().Run("primary holder does not see the invitations they sent", func(t *testing.T) {
+		invitations := suite.getInvitations(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d")
+		assert.Len(t, invitations, 0)
+	})
+}
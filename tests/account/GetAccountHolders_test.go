@@ -0,0 +1,69 @@
+package account
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type GetAccountHoldersTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestGetAccountHoldersTestSuite(t *testing.T) {
+	suite.Run(t, new(GetAccountHoldersTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *GetAccountHoldersTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/GetAccountHolders_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *GetAccountHoldersTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *GetAccountHoldersTestSuite) TestGetAccountHolders() {
+	suite.T().Run("primary holder sees the pending invitations", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/accounts/66666666666660/holders", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetAccountHoldersResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+		for _, accountHolder := range response.Data {
+			assert.Equal(t, string(model.AccountHolderInvited), accountHolder.Status)
+		}
+	})
+
+	suite.T().Run("joint holder sees every holder of the account", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f", "/v1/accounts/55555555555550/holders", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetAccountHoldersResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+		for _, accountHolder := range response.Data {
+			assert.Equal(t, string(model.AccountHolderActive), accountHolder.Status)
+		}
+	})
+
+	suite.T().Run("invited user cannot see the holders before accepting", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f", "/v1/accounts/66666666666660/holders", http.MethodGet, nil)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this account")
+	})
+}
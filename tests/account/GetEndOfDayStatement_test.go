@@ -0,0 +1,60 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type GetEndOfDayStatementTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestGetEndOfDayStatementTestSuite(t *testing.T) {
+	suite.Run(t, new(GetEndOfDayStatementTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *GetEndOfDayStatementTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/GetEndOfDayStatement_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *GetEndOfDayStatementTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *GetEndOfDayStatementTestSuite) getStatement(t *testing.T, userID string, accountID int64) string {
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format(time.DateOnly)
+	url := fmt.Sprintf("/v1/accounts/%d/statements/camt053?date=%s", accountID, yesterday)
+
+	responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, userID, url, http.MethodGet, nil)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var response types.GetEndOfDayStatementResponse
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	return response.Data.Content
+}
+
+func (suite *GetEndOfDayStatementTestSuite) TestNominees() {
+	suite.T().Run("statement lists the active nominees of the account", func(t *testing.T) {
+		content := suite.getStatement(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237)
+		assert.Contains(t, content, "<AddtlStmtInf>Nominees: Jane Doe (SPOUSE, 60%); Sam Doe (CHILD, 40%, guardian Jane Doe)</AddtlStmtInf>")
+		assert.NotContains(t, content, "John Doe")
+	})
+
+	suite.T().Run("statement of an account without nominees has no additional information", func(t *testing.T) {
+		content := suite.getStatement(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", 22222222222220)
+		assert.NotContains(t, content, "<AddtlStmtInf>")
+	})
+}
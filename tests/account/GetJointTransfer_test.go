@@ -0,0 +1,63 @@
+package account
+
+import (
+	"net/http"
+	"testing"
+
+	transferModel "github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type GetJointTransferTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestGetJointTransferTestSuite(t *testing.T) {
+	suite.Run(t, new(GetJointTransferTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *GetJointTransferTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/GetJointTransfer_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *GetJointTransferTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *GetJointTransferTestSuite) TestGetJointTransfer() {
+	// rejected by user 2
+	url := "/v1/joint-transfers/7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b03"
+
+	suite.T().Run("holder sees the transfer with the decisions of every holder", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", url, http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		jointTransfer := decodeJointTransfer(t, responseRecorder)
+		assert.Equal(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", jointTransfer.InitiatedByUserID)
+		assert.Equal(t, int64(10000), jointTransfer.Amount)
+		assert.Equal(t, transferModel.JointTransferRejected, jointTransfer.Status)
+
+		decisions := make(map[string]transferModel.JointTransferDecision)
+		for _, approval := range jointTransfer.Approvals {
+			decisions[approval.UserID] = approval.Decision
+		}
+		assert.Equal(t, map[string]transferModel.JointTransferDecision{
+			"a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d": transferModel.JointTransferApproved,
+			"b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e": transferModel.JointTransferDecisionRejected,
+		}, decisions)
+	})
+
+	suite.T().Run("user who is not a holder returns 403", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f", url, http.MethodGet, nil)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this joint transfer")
+	})
+}
@@ -0,0 +1,66 @@
+package account
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	transferModel "github.com/skamranahmed/go-bank/internal/transfer/model"
+	transferTypes "github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type GetJointTransfersTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestGetJointTransfersTestSuite(t *testing.T) {
+	suite.Run(t, new(GetJointTransfersTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *GetJointTransfersTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/GetJointTransfers_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *GetJointTransfersTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *GetJointTransfersTestSuite) TestGetJointTransfers() {
+	suite.T().Run("joint transfers of the account are listed to its holders", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/joint-transfers?account_id=44444444444440", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response transferTypes.GetJointTransfersResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 3)
+	})
+
+	suite.T().Run("joint transfers are filtered by status", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/joint-transfers?account_id=44444444444440&status=PENDING", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response transferTypes.GetJointTransfersResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+		for _, jointTransfer := range response.Data {
+			assert.Equal(t, transferModel.JointTransferPending, jointTransfer.Status)
+		}
+	})
+
+	suite.T().Run("user who is not a holder returns 403", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f", "/v1/joint-transfers?account_id=44444444444440", http.MethodGet, nil)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this joint transfer")
+	})
+}
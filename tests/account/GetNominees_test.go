@@ -0,0 +1,60 @@
+package account
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type GetNomineesTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestGetNomineesTestSuite(t *testing.T) {
+	suite.Run(t, new(GetNomineesTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *GetNomineesTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/GetNominees_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *GetNomineesTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *GetNomineesTestSuite) TestGetNominees() {
+	suite.T().Run("primary holder sees the active nominees in the order they were added", func(t *testing.T) {
+		nominees := getNominees(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237)
+		if assert.Len(t, nominees, 2) {
+			assert.Equal(t, "Jane Doe", nominees[0].Name)
+			assert.Equal(t, 60, nominees[0].SharePercentage)
+			assert.Nil(t, nominees[0].GuardianName)
+
+			assert.Equal(t, "Sam Doe", nominees[1].Name)
+			assert.Equal(t, 40, nominees[1].SharePercentage)
+			if assert.NotNil(t, nominees[1].GuardianName) {
+				assert.Equal(t, "Jane Doe", *nominees[1].GuardianName)
+			}
+		}
+	})
+
+	suite.T().Run("joint holder sees the nominees", func(t *testing.T) {
+		nominees := getNominees(t, suite.app, "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f", 12345678901237)
+		assert.Len(t, nominees, 2)
+	})
+
+	suite.T().Run("user who is not a holder returns 403", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/accounts/12345678901237/nominees", http.MethodGet, nil)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this account")
+	})
+}
@@ -0,0 +1,71 @@
+package account
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type GetPocketMovementsTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestGetPocketMovementsTestSuite(t *testing.T) {
+	suite.Run(t, new(GetPocketMovementsTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *GetPocketMovementsTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/GetPocketMovements_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *GetPocketMovementsTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *GetPocketMovementsTestSuite) TestGetPocketMovements() {
+	url := "/v1/accounts/12345678901237/pockets/5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f01/movements"
+
+	suite.T().Run("movements are listed newest first", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", url, http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetPocketMovementsResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+		assert.Equal(t, string(model.PocketWithdrawal), response.Data[0].Type)
+		assert.Equal(t, int64(10000), response.Data[0].Amount)
+		assert.Equal(t, int64(50000), response.Data[0].BalanceAfter)
+		assert.Equal(t, string(model.PocketDeposit), response.Data[1].Type)
+		assert.Nil(t, response.Data[1].TransactionID)
+	})
+
+	suite.T().Run("movements are paginated", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", url+"?limit=1&offset=1", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetPocketMovementsResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+		assert.Equal(t, string(model.PocketDeposit), response.Data[0].Type)
+	})
+
+	suite.T().Run("pocket of another account returns 404", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/accounts/22222222222220/pockets/5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f01/movements", http.MethodGet, nil)
+		assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Pocket not found")
+	})
+}
@@ -0,0 +1,67 @@
+package account
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type GetPocketsTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestGetPocketsTestSuite(t *testing.T) {
+	suite.Run(t, new(GetPocketsTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *GetPocketsTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/GetPockets_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *GetPocketsTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *GetPocketsTestSuite) TestGetPockets() {
+	suite.T().Run("every pocket of the account is listed, including the closed ones", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/accounts/12345678901237/pockets", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetPocketsResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+
+		statuses := make(map[string]string)
+		for _, pocket := range response.Data {
+			statuses[pocket.Name] = pocket.Status
+		}
+		assert.Equal(t, map[string]string{"Vacation": string(model.PocketActive), "Wedding": string(model.PocketClosed)}, statuses)
+	})
+
+	suite.T().Run("round-ups are shown in whole units of the currency", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/accounts/22222222222220/pockets", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetPocketsResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+		assert.Equal(t, int64(10), *response.Data[0].RoundUpTo)
+	})
+
+	suite.T().Run("pockets of an account of another user returns 403", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/accounts/12345678901237/pockets", http.MethodGet, nil)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	})
+}
@@ -0,0 +1,159 @@
+package account
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type GetTransactionsTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestGetTransactionsTestSuite(t *testing.T) {
+	suite.Run(t, new(GetTransactionsTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *GetTransactionsTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/GetTransactions_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *GetTransactionsTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *GetTransactionsTestSuite) TestMissingAuthorizationHeader() {
+	suite.T().Run("missing authorization header returns 401", func(t *testing.T) {
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/accounts/12345678901234/transactions", http.MethodGet, nil, nil)
+		assert.Equal(t, http.StatusUnauthorized, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Authorization header is missing")
+	})
+}
+
+func (suite *GetTransactionsTestSuite) TestAccountOfAnotherUser() {
+	suite.T().Run("account of another user returns 403", func(t *testing.T) {
+		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+		assert.NoError(t, err)
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/accounts/11111111111111/transactions", http.MethodGet, nil, headers)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this account")
+	})
+}
+
+func (suite *GetTransactionsTestSuite) TestValidationErrors() {
+	type scenario struct {
+		name       string
+		query      string
+		field      string
+		errMessage string
+	}
+
+	tests := []scenario{
+		{
+			name:       "limit above the maximum",
+			query:      "?limit=101",
+			field:      "limit",
+			errMessage: "limit must be less than or equal to 100",
+		},
+		{
+			name:       "negative offset",
+			query:      "?offset=-1",
+			field:      "offset",
+			errMessage: "offset must be greater than or equal to 0",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+			accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+			assert.NoError(t, err)
+
+			headers := map[string]string{
+				"Authorization": "Bearer " + accessToken,
+			}
+			responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/accounts/12345678901234/transactions"+tc.query, http.MethodGet, nil, headers)
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, tc.field, tc.errMessage)
+		})
+	}
+}
+
+func (suite *GetTransactionsTestSuite) TestSuccessfulGetTransactions() {
+	suite.T().Run("returns the transactions of the account newest first, with fees as their own type", func(t *testing.T) {
+		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+		assert.NoError(t, err)
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/accounts/12345678901234/transactions", http.MethodGet, nil, headers)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetTransactionsResponse
+		err = json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 3)
+		assert.Equal(t, "FEE", response.Data[0].Type)
+		assert.Equal(t, int64(590), response.Data[0].Amount)
+		assert.Equal(t, "DEBIT", response.Data[1].Type)
+		assert.Equal(t, "CREDIT", response.Data[2].Type)
+	})
+
+	suite.T().Run("paginates with limit and offset", func(t *testing.T) {
+		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+		assert.NoError(t, err)
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/accounts/12345678901234/transactions?limit=1&offset=1", http.MethodGet, nil, headers)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetTransactionsResponse
+		err = json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+		assert.Equal(t, "1b2c3d4e-5f6a-4b7c-9d8e-0f1a2b3c4d5e", response.Data[0].ID)
+	})
+}
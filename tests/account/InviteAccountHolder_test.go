@@ -0,0 +1,155 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type InviteAccountHolderTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestInviteAccountHolderTestSuite(t *testing.T) {
+	suite.Run(t, new(InviteAccountHolderTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *InviteAccountHolderTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/InviteAccountHolder_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *InviteAccountHolderTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *InviteAccountHolderTestSuite) inviteAccountHolder(t *testing.T, userID string, accountID int64, username string) *httptest.ResponseRecorder {
+	payload := types.InviteAccountHolderRequest{
+		Data: types.InviteAccountHolderRequestData{
+			Username: username,
+		},
+	}
+	return testutils.MakeAuthenticatedRequest(t, suite.app, userID, fmt.Sprintf("/v1/accounts/%d/holders", accountID), http.MethodPost, payload)
+}
+
+func (suite *InviteAccountHolderTestSuite) TestRejections() {
+	type scenario struct {
+		name       string
+		userID     string
+		accountID  int64
+		username   string
+		statusCode int
+		errMessage string
+	}
+
+	tests := []scenario{
+		{
+			name:       "user who is not a holder returns 403",
+			userID:     "d4e5f6a7-b8c9-4d0e-9f1a-3b4c5d6e7f80",
+			accountID:  12345678901237,
+			username:   "test_user_4",
+			statusCode: http.StatusForbidden,
+			errMessage: "You do not have permission to access this account",
+		},
+		{
+			name:       "joint holder returns 403",
+			userID:     "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e",
+			accountID:  12345678901237,
+			username:   "test_user_3",
+			statusCode: http.StatusForbidden,
+			errMessage: "Only the primary holder can invite holders to this account",
+		},
+		{
+			name:       "primary holder inviting themselves returns 400",
+			userID:     "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			accountID:  12345678901237,
+			username:   "test_user_1",
+			statusCode: http.StatusBadRequest,
+			errMessage: "You are already the primary holder of this account",
+		},
+		{
+			name:       "user who already holds the account returns 409",
+			userID:     "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			accountID:  12345678901237,
+			username:   "test_user_2",
+			statusCode: http.StatusConflict,
+			errMessage: "The user is already a holder of this account",
+		},
+		{
+			name:       "user who is already invited returns 409",
+			userID:     "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			accountID:  66666666666660,
+			username:   "test_user_3",
+			statusCode: http.StatusConflict,
+			errMessage: "The user is already invited to this account",
+		},
+	}
+
+	accountHolderCountBefore, err := suite.app.Db.NewSelect().Model((*model.AccountHolder)(nil)).Count(suite.T().Context())
+	assert.NoError(suite.T(), err)
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := suite.inviteAccountHolder(t, tc.userID, tc.accountID, tc.username)
+			assert.Equal(t, tc.statusCode, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, "message", tc.errMessage)
+		})
+	}
+
+	suite.T().Run("no holder is invited", func(t *testing.T) {
+		accountHolderCount, err := suite.app.Db.NewSelect().Model((*model.AccountHolder)(nil)).Count(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, accountHolderCountBefore, accountHolderCount)
+	})
+}
+
+func (suite *InviteAccountHolderTestSuite) TestInviteAccountHolder() {
+	suite.T().Run("primary holder invites a user", func(t *testing.T) {
+		responseRecorder := suite.inviteAccountHolder(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237, "test_user_3")
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.InviteAccountHolderResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12345678901237), response.Data.AccountID)
+		assert.Equal(t, "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f", response.Data.UserID)
+		assert.Equal(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", response.Data.InvitedByUserID)
+		assert.Equal(t, string(model.AccountHolderInvited), response.Data.Status)
+		assert.Nil(t, response.Data.RespondedAt)
+
+		var accountHolder model.AccountHolder
+		err = suite.app.Db.NewSelect().
+			Model(&accountHolder).
+			Where("id = ?", response.Data.ID).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, model.AccountHolderInvited, accountHolder.Status)
+		assert.Equal(t, int64(12345678901237), accountHolder.AccountID)
+	})
+
+	suite.T().Run("invited user cannot access the account before accepting", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f", "/v1/accounts/12345678901237", http.MethodGet, nil)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	})
+
+	suite.T().Run("inviting the same user twice returns 409", func(t *testing.T) {
+		responseRecorder := suite.inviteAccountHolder(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237, "test_user_3")
+		assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "The user is already invited to this account")
+	})
+}
@@ -0,0 +1,145 @@
+package account
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	transferModel "github.com/skamranahmed/go-bank/internal/transfer/model"
+	transferTypes "github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func makeInternalTransfer(t *testing.T, app testutils.TestApp, userID string, fromAccountID int64, toAccountID int64, amount int64) *httptest.ResponseRecorder {
+	payload := transferTypes.InternalTransferRequest{
+		Data: transferTypes.InternalTransferRequestData{
+			FromAccountID: fromAccountID,
+			ToAccountID:   toAccountID,
+			Amount:        &amount,
+		},
+	}
+	return testutils.MakeAuthenticatedRequest(t, app, userID, "/v1/transfers/internal", http.MethodPost, payload)
+}
+
+func decodeJointTransfer(t *testing.T, responseRecorder *httptest.ResponseRecorder) transferTypes.JointTransferDto {
+	var response transferTypes.JointTransferResponse
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	return response.Data
+}
+
+type JointAccountTransferTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestJointAccountTransferTestSuite(t *testing.T) {
+	suite.Run(t, new(JointAccountTransferTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *JointAccountTransferTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/JointAccountTransfer_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *JointAccountTransferTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *JointAccountTransferTestSuite) getJointTransfers(t *testing.T, fromAccountID int64) []transferModel.JointTransfer {
+	var jointTransfers []transferModel.JointTransfer
+	err := suite.app.Db.NewSelect().
+		Model(&jointTransfers).
+		Where("from_account_id = ?", fromAccountID).
+		Scan(t.Context())
+	assert.NoError(t, err)
+	return jointTransfers
+}
+
+func (suite *JointAccountTransferTestSuite) TestEitherOrSurvivor() {
+	suite.T().Run("user who is not a holder cannot transfer from the account", func(t *testing.T) {
+		responseRecorder := makeInternalTransfer(t, suite.app, "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f", 12345678901237, 33333333333330, 1000)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You are not authorized to perform transfer from this account")
+		assert.Equal(t, int64(500000), testutils.GetAccountBalance(t, suite.app, 12345678901237))
+	})
+
+	suite.T().Run("invited user cannot transfer from the account before accepting", func(t *testing.T) {
+		responseRecorder := makeInternalTransfer(t, suite.app, "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f", 66666666666660, 33333333333330, 1000)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+		assert.Equal(t, int64(100000), testutils.GetAccountBalance(t, suite.app, 66666666666660))
+	})
+
+	suite.T().Run("joint holder transfers alone", func(t *testing.T) {
+		responseRecorder := makeInternalTransfer(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", 12345678901237, 33333333333330, 100000)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response transferTypes.InternalTransferResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100000), response.Data.Transaction.Amount)
+
+		assert.Equal(t, int64(400000), testutils.GetAccountBalance(t, suite.app, 12345678901237))
+		assert.Equal(t, int64(100000), testutils.GetAccountBalance(t, suite.app, 33333333333330))
+
+		var debits []model.Transaction
+		err = suite.app.Db.NewSelect().
+			Model(&debits).
+			Where("account_id = ?", 12345678901237).
+			Where("type = ?", model.Debit).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		if assert.Len(t, debits, 1) {
+			assert.Equal(t, int64(100000), debits[0].Amount)
+			assert.Equal(t, int64(400000), debits[0].BalanceAfter)
+		}
+
+		// under either or survivor nothing waits for the approval of the primary holder
+		assert.Len(t, suite.getJointTransfers(t, 12345678901237), 0)
+	})
+}
+
+func (suite *JointAccountTransferTestSuite) TestJointly() {
+	suite.T().Run("transfer up to the threshold is executed right away", func(t *testing.T) {
+		responseRecorder := makeInternalTransfer(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", 44444444444440, 33333333333330, 5000)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		assert.Equal(t, int64(495000), testutils.GetAccountBalance(t, suite.app, 44444444444440))
+		assert.Len(t, suite.getJointTransfers(t, 44444444444440), 0)
+	})
+
+	suite.T().Run("transfer above the threshold waits for the approval of every holder", func(t *testing.T) {
+		balanceBefore := testutils.GetAccountBalance(t, suite.app, 44444444444440)
+		creditBalanceBefore := testutils.GetAccountBalance(t, suite.app, 33333333333330)
+
+		responseRecorder := makeInternalTransfer(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 44444444444440, 33333333333330, 20000)
+		assert.Equal(t, http.StatusAccepted, responseRecorder.Code)
+
+		jointTransfer := decodeJointTransfer(t, responseRecorder)
+		assert.Equal(t, transferModel.JointTransferPending, jointTransfer.Status)
+		assert.Equal(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", jointTransfer.InitiatedByUserID)
+		assert.Nil(t, jointTransfer.TransactionID)
+		assert.Equal(t, balanceBefore, testutils.GetAccountBalance(t, suite.app, 44444444444440))
+		assert.Equal(t, creditBalanceBefore, testutils.GetAccountBalance(t, suite.app, 33333333333330))
+
+		// initiating the transfer counts as the approval of the initiator
+		var approvals []transferModel.JointTransferApproval
+		err := suite.app.Db.NewSelect().
+			Model(&approvals).
+			Where("joint_transfer_id = ?", jointTransfer.ID).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		if assert.Len(t, approvals, 1) {
+			assert.Equal(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", approvals[0].UserID.String())
+			assert.Equal(t, transferModel.JointTransferApproved, approvals[0].Decision)
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package account
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	depositTypes "github.com/skamranahmed/go-bank/internal/deposit/types"
+	paymentRequestTypes "github.com/skamranahmed/go-bank/internal/paymentrequest/types"
+	transferModel "github.com/skamranahmed/go-bank/internal/transfer/model"
+	transferTypes "github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+const jointMandateErrMessage = "Transfers above the joint approval threshold of this account can only be made as internal transfers approved by every holder"
+
+/*
+JointMandateTestSuite covers the debits other than internal transfers out of a jointly operated account,
+none of them can wait for the approval of every holder, so they are rejected above the threshold
+*/
+type JointMandateTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestJointMandateTestSuite(t *testing.T) {
+	suite.Run(t, new(JointMandateTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *JointMandateTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/JointMandate_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *JointMandateTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *JointMandateTestSuite) TestExternalTransfer() {
+	suite.T().Run("external transfer above the threshold returns 400", func(t *testing.T) {
+		payload := transferTypes.ExternalTransferRequest{
+			Data: transferTypes.ExternalTransferRequestData{
+				FromAccountID:            44444444444440,
+				Rail:                     string(transferModel.IMPS),
+				BeneficiaryName:          "Jane Doe",
+				BeneficiaryAccountNumber: "50100123456789",
+				BeneficiaryIFSC:          "HDFC0001234",
+				Amount:                   int64Ptr(20000),
+			},
+		}
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/transfers/external", http.MethodPost, payload)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", jointMandateErrMessage)
+		assert.Equal(t, int64(500000), testutils.GetAccountBalance(t, suite.app, 44444444444440))
+	})
+}
+
+func (suite *JointMandateTestSuite) TestBulkTransfer() {
+	suite.T().Run("rows above the threshold are invalid", func(t *testing.T) {
+		payload := transferTypes.UploadBulkTransferRequest{
+			Data: transferTypes.UploadBulkTransferRequestData{
+				FromAccountID: 55555555555550,
+				FileName:      "payroll.csv",
+				Content:       "to_account,amount,narration\n33333333333330,5000,salary\n33333333333330,20000,bonus",
+			},
+		}
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f", "/v1/transfers/bulk", http.MethodPost, payload)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response transferTypes.BulkTransferResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, transferModel.BulkTransferInvalid, response.Data.Status)
+		if assert.Len(t, response.Data.Items, 2) {
+			assert.Equal(t, transferModel.BulkTransferItemPending, response.Data.Items[0].Status)
+			assert.Equal(t, transferModel.BulkTransferItemInvalid, response.Data.Items[1].Status)
+			if assert.NotNil(t, response.Data.Items[1].Reason) {
+				assert.Equal(t, "amount exceeds the joint approval threshold of 5000, it needs the approval of every holder of the account", *response.Data.Items[1].Reason)
+			}
+		}
+		assert.Equal(t, int64(500000), testutils.GetAccountBalance(t, suite.app, 55555555555550))
+	})
+}
+
+func (suite *JointMandateTestSuite) TestPaymentRequest() {
+	suite.T().Run("paying a payment request above the threshold returns 400", func(t *testing.T) {
+		payload := paymentRequestTypes.PayPaymentRequestRequest{
+			Data: paymentRequestTypes.PayPaymentRequestRequestData{
+				FromAccountID: 44444444444440,
+			},
+		}
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/payment-links/jointtoken000000000001/pay", http.MethodPost, payload)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", jointMandateErrMessage)
+		assert.Equal(t, int64(0), testutils.GetAccountBalance(t, suite.app, 33333333333330))
+		assert.Equal(t, int64(500000), testutils.GetAccountBalance(t, suite.app, 44444444444440))
+	})
+}
+
+func (suite *JointMandateTestSuite) TestFixedDeposit() {
+	suite.T().Run("fixed deposit above the threshold returns 400", func(t *testing.T) {
+		tenureInMonths := 12
+		payload := depositTypes.BookFixedDepositRequest{
+			Data: depositTypes.BookFixedDepositRequestData{
+				LinkedAccountID:     44444444444440,
+				Amount:              int64Ptr(500000),
+				TenureInMonths:      &tenureInMonths,
+				MaturityInstruction: "PAYOUT",
+			},
+		}
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/fixed-deposits", http.MethodPost, payload)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", jointMandateErrMessage)
+		assert.Equal(t, int64(500000), testutils.GetAccountBalance(t, suite.app, 44444444444440))
+	})
+}
+
+func (suite *JointMandateTestSuite) TestBalanceHistory() {
+	suite.T().Run("joint holder sees the balance history of the account", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/accounts/44444444444440/balance-history", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	})
+
+	suite.T().Run("invited user cannot see the balance history before accepting", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f", "/v1/accounts/66666666666660/balance-history", http.MethodGet, nil)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this account")
+	})
+}
@@ -0,0 +1,159 @@
+package account
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type NameEnquiryTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestNameEnquiryTestSuite(t *testing.T) {
+	suite.Run(t, new(NameEnquiryTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *NameEnquiryTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/NameEnquiry_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *NameEnquiryTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *NameEnquiryTestSuite) makeRequest(t *testing.T, userID string, url string) *httptest.ResponseRecorder {
+	accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+	assert.NoError(t, err)
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+	}
+	return testutils.MakeRequest(t, suite.app, url, http.MethodGet, nil, headers)
+}
+
+func (suite *NameEnquiryTestSuite) TestMissingAuthorizationHeader() {
+	suite.T().Run("missing authorization header returns 401", func(t *testing.T) {
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/name-enquiry?account_id=11111111111110", http.MethodGet, nil, nil)
+		assert.Equal(t, http.StatusUnauthorized, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Authorization header is missing")
+	})
+}
+
+func (suite *NameEnquiryTestSuite) TestValidationErrors() {
+	userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+	suite.T().Run("missing account id returns 400", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, userID, "/v1/name-enquiry")
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "account_id", "account_id is required when iban is not provided")
+	})
+
+	suite.T().Run("IBAN of another bank returns 400", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, userID, "/v1/name-enquiry?iban=IN72OTHR0000010011111111111110")
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "IBAN does not belong to an account at this bank")
+	})
+}
+
+func (suite *NameEnquiryTestSuite) TestAccountNotFound() {
+	suite.T().Run("non-existent account returns 404", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/name-enquiry?account_id=99999999999993")
+		assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Account not found")
+	})
+}
+
+func (suite *NameEnquiryTestSuite) TestSuccessfulNameEnquiry() {
+	userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+	scenarios := []struct {
+		name               string
+		url                string
+		expectedAccountID  int64
+		expectedHolderName string
+	}{
+		{
+			name:               "account number returns the masked holder name",
+			url:                "/v1/name-enquiry?account_id=11111111111110",
+			expectedAccountID:  11111111111110,
+			expectedHolderName: "S*** A****",
+		},
+		{
+			name:               "IBAN returns the masked holder name",
+			url:                "/v1/name-enquiry?iban=IN92GOBK0000010011111111111110",
+			expectedAccountID:  11111111111110,
+			expectedHolderName: "S*** A****",
+		},
+		{
+			name:               "holder without a name returns the masked username",
+			url:                "/v1/name-enquiry?account_id=22222222222220",
+			expectedAccountID:  22222222222220,
+			expectedHolderName: "t**********",
+		},
+	}
+
+	for _, tc := range scenarios {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := suite.makeRequest(t, userID, tc.url)
+			assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+			var response types.NameEnquiryResponse
+			err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedAccountID, response.Data.AccountID)
+			assert.Equal(t, tc.expectedHolderName, response.Data.HolderName)
+		})
+	}
+}
+
+func (suite *NameEnquiryTestSuite) TestRateLimit() {
+	suite.T().Run("name enquiries beyond the limit return 429", func(t *testing.T) {
+		userID := "d4e5f6a7-b8c9-7d8e-1f2a-3b4c5d6e7f80"
+
+		// the default config allows 10 name enquiries per window
+		for range 10 {
+			responseRecorder := suite.makeRequest(t, userID, "/v1/name-enquiry?account_id=11111111111110")
+			assert.Equal(t, http.StatusOK, responseRecorder.Code)
+		}
+
+		responseRecorder := suite.makeRequest(t, userID, "/v1/name-enquiry?account_id=11111111111110")
+		assert.Equal(t, http.StatusTooManyRequests, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Too many requests. Please try again later.")
+	})
+}
@@ -0,0 +1,130 @@
+package account
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type OpenAccountTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestOpenAccountTestSuite(t *testing.T) {
+	suite.Run(t, new(OpenAccountTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *OpenAccountTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/OpenAccount_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *OpenAccountTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *OpenAccountTestSuite) openAccount(t *testing.T, accountType string, currency string) *httptest.ResponseRecorder {
+	accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d")
+	assert.NoError(t, err)
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+	}
+	payload := types.OpenAccountRequest{
+		Data: types.OpenAccountRequestData{
+			Type:     accountType,
+			Currency: currency,
+		},
+	}
+	return testutils.MakeRequest(t, suite.app, "/v1/accounts", http.MethodPost, payload, headers)
+}
+
+func (suite *OpenAccountTestSuite) TestOpenAccountValidation() {
+	type scenario struct {
+		name        string
+		accountType string
+		currency    string
+		field       string
+		errMessage  string
+	}
+
+	tests := []scenario{
+		{
+			name:        "deposit account",
+			accountType: "FIXED_DEPOSIT",
+			currency:    "INR",
+			field:       "type",
+			errMessage:  "type must be one of: SAVINGS_ACCOUNT, CURRENT_ACCOUNT",
+		},
+		{
+			name:        "missing currency",
+			accountType: "SAVINGS_ACCOUNT",
+			currency:    "",
+			field:       "currency",
+			errMessage:  "currency is a required field",
+		},
+		{
+			name:        "unsupported currency",
+			accountType: "SAVINGS_ACCOUNT",
+			currency:    "XYZ",
+			field:       "currency",
+			errMessage:  "currency is not a supported currency",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := suite.openAccount(t, tc.accountType, tc.currency)
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, tc.field, tc.errMessage)
+		})
+	}
+}
+
+func (suite *OpenAccountTestSuite) TestOpenAccount() {
+	suite.T().Run("account of a type already held in the currency returns 409", func(t *testing.T) {
+		responseRecorder := suite.openAccount(t, "SAVINGS_ACCOUNT", "INR")
+		assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You already have a SAVINGS_ACCOUNT in INR")
+	})
+
+	suite.T().Run("account of the same type in another currency is opened", func(t *testing.T) {
+		responseRecorder := suite.openAccount(t, "SAVINGS_ACCOUNT", "USD")
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.OpenAccountResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "USD", response.Data.Currency)
+		assert.Equal(t, model.SavingsAccount, response.Data.Type)
+		assert.Equal(t, int64(0), response.Data.Balance)
+	})
+}
@@ -0,0 +1,105 @@
+package account
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	transferModel "github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type RejectJointTransferTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestRejectJointTransferTestSuite(t *testing.T) {
+	suite.Run(t, new(RejectJointTransferTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *RejectJointTransferTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/RejectJointTransfer_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *RejectJointTransferTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *RejectJointTransferTestSuite) rejectJointTransfer(t *testing.T, userID string, jointTransferID string) *httptest.ResponseRecorder {
+	return testutils.MakeAuthenticatedRequest(t, suite.app, userID, fmt.Sprintf("/v1/joint-transfers/%s/reject", jointTransferID), http.MethodPost, nil)
+}
+
+func (suite *RejectJointTransferTestSuite) TestRejectJointTransfer() {
+	suite.T().Run("user who is not a holder cannot reject the transfer", func(t *testing.T) {
+		responseRecorder := suite.rejectJointTransfer(t, "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f", "7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b02")
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this joint transfer")
+
+		approvalCount, err := suite.app.Db.NewSelect().
+			Model((*transferModel.JointTransferApproval)(nil)).
+			Where("joint_transfer_id = ?", "7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b02").
+			Count(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, approvalCount)
+	})
+
+	suite.T().Run("holder who approved the transfer cannot reject it", func(t *testing.T) {
+		responseRecorder := suite.rejectJointTransfer(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b02")
+		assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You have already approved this transfer")
+
+		var jointTransfer transferModel.JointTransfer
+		err := suite.app.Db.NewSelect().
+			Model(&jointTransfer).
+			Where("id = ?", "7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b02").
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, transferModel.JointTransferPending, jointTransfer.Status)
+	})
+
+	suite.T().Run("rejection of a holder rejects the transfer", func(t *testing.T) {
+		responseRecorder := suite.rejectJointTransfer(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b02")
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		jointTransfer := decodeJointTransfer(t, responseRecorder)
+		assert.Equal(t, transferModel.JointTransferRejected, jointTransfer.Status)
+		assert.Nil(t, jointTransfer.TransactionID)
+		assert.Len(t, jointTransfer.Approvals, 2)
+
+		assert.Equal(t, int64(500000), testutils.GetAccountBalance(t, suite.app, 44444444444440))
+		assert.Equal(t, int64(0), testutils.GetAccountBalance(t, suite.app, 33333333333330))
+
+		var approval transferModel.JointTransferApproval
+		err := suite.app.Db.NewSelect().
+			Model(&approval).
+			Where("joint_transfer_id = ?", "7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b02").
+			Where("user_id = ?", "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d").
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, transferModel.JointTransferDecisionRejected, approval.Decision)
+
+		transactionCount, err := suite.app.Db.NewSelect().Model((*model.Transaction)(nil)).Where("account_id = ?", 44444444444440).Count(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, transactionCount)
+	})
+
+	suite.T().Run("rejected transfer cannot be rejected again", func(t *testing.T) {
+		responseRecorder := suite.rejectJointTransfer(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b02")
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Only a pending joint transfer can be approved or rejected")
+	})
+}
@@ -0,0 +1,226 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	transferModel "github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type RemoveAccountHolderTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestRemoveAccountHolderTestSuite(t *testing.T) {
+	suite.Run(t, new(RemoveAccountHolderTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *RemoveAccountHolderTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/RemoveAccountHolder_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *RemoveAccountHolderTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *RemoveAccountHolderTestSuite) removeAccountHolder(t *testing.T, userID string, accountID int64, accountHolderID string) *httptest.ResponseRecorder {
+	return testutils.MakeAuthenticatedRequest(t, suite.app, userID, fmt.Sprintf("/v1/accounts/%d/holders/%s", accountID, accountHolderID), http.MethodDelete, nil)
+}
+
+func (suite *RemoveAccountHolderTestSuite) getAccountHolder(t *testing.T, accountHolderID string) model.AccountHolder {
+	var accountHolder model.AccountHolder
+	err := suite.app.Db.NewSelect().
+		Model(&accountHolder).
+		Where("id = ?", accountHolderID).
+		Scan(t.Context())
+	assert.NoError(t, err)
+	return accountHolder
+}
+
+func (suite *RemoveAccountHolderTestSuite) getScheduledTransfer(t *testing.T, scheduledTransferID string) transferModel.ScheduledTransfer {
+	var scheduledTransfer transferModel.ScheduledTransfer
+	err := suite.app.Db.NewSelect().
+		Model(&scheduledTransfer).
+		Where("id = ?", scheduledTransferID).
+		Scan(t.Context())
+	assert.NoError(t, err)
+	return scheduledTransfer
+}
+
+func (suite *RemoveAccountHolderTestSuite) getStandingInstruction(t *testing.T, standingInstructionID string) transferModel.StandingInstruction {
+	var standingInstruction transferModel.StandingInstruction
+	err := suite.app.Db.NewSelect().
+		Model(&standingInstruction).
+		Where("id = ?", standingInstructionID).
+		Scan(t.Context())
+	assert.NoError(t, err)
+	return standingInstruction
+}
+
+func (suite *RemoveAccountHolderTestSuite) getBulkTransfer(t *testing.T, bulkTransferID string) transferModel.BulkTransfer {
+	var bulkTransfer transferModel.BulkTransfer
+	err := suite.app.Db.NewSelect().
+		Model(&bulkTransfer).
+		Where("id = ?", bulkTransferID).
+		Scan(t.Context())
+	assert.NoError(t, err)
+	return bulkTransfer
+}
+
+func (suite *RemoveAccountHolderTestSuite) TestInstructionsOfFormerHolder() {
+	// user 4 left the either or survivor account after storing these instructions
+	executionDate := time.Date(2025, 9, 20, 0, 0, 0, 0, time.UTC)
+
+	suite.T().Run("scheduled transfer is cancelled instead of executed", func(t *testing.T) {
+		scheduledTransfer, err := suite.app.Services.TransferService.ExecuteScheduledTransfer(t.Context(), nil, uuid.MustParse("6b7c8d9e-0f1a-4b2c-9d3e-4f5a6b7c8d03"), time.Now().UTC())
+		assert.NoError(t, err)
+		assert.Nil(t, scheduledTransfer)
+
+		storedScheduledTransfer := suite.getScheduledTransfer(t, "6b7c8d9e-0f1a-4b2c-9d3e-4f5a6b7c8d03")
+		assert.Equal(t, transferModel.ScheduledTransferCancelled, storedScheduledTransfer.Status)
+		assert.Nil(t, storedScheduledTransfer.TransactionID)
+	})
+
+	suite.T().Run("standing instruction is cancelled instead of executed", func(t *testing.T) {
+		execution, err := suite.app.Services.TransferService.ExecuteStandingInstruction(t.Context(), nil, uuid.MustParse("7c8d9e0f-1a2b-4c3d-8e4f-5a6b7c8d9e02"), executionDate)
+		assert.NoError(t, err)
+		assert.Nil(t, execution)
+
+		standingInstruction := suite.getStandingInstruction(t, "7c8d9e0f-1a2b-4c3d-8e4f-5a6b7c8d9e02")
+		assert.Equal(t, transferModel.StandingInstructionCancelled, standingInstruction.Status)
+		assert.Equal(t, 0, standingInstruction.OccurrenceCount)
+	})
+
+	suite.T().Run("row of a confirmed bulk transfer is failed instead of executed", func(t *testing.T) {
+		item, err := suite.app.Services.TransferService.ExecuteBulkTransferItem(t.Context(), nil, uuid.MustParse("9e0f1a2b-3c4d-4e5f-8a6b-7c8d9e0f1a02"), time.Now().UTC())
+		assert.NoError(t, err)
+		if assert.NotNil(t, item) {
+			assert.Equal(t, transferModel.BulkTransferItemFailed, item.Status)
+			assert.Equal(t, "Not attempted, the user who uploaded the bulk transfer no longer holds the account", *item.Reason)
+			assert.Nil(t, item.TransactionID)
+		}
+	})
+
+	suite.T().Run("no money left the account", func(t *testing.T) {
+		assert.Equal(t, int64(0), testutils.GetAccountBalance(t, suite.app, 33333333333330))
+
+		transactionCount, err := suite.app.Db.NewSelect().Model((*model.Transaction)(nil)).Where("account_id = ?", 12345678901237).Count(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, transactionCount)
+	})
+}
+
+func (suite *RemoveAccountHolderTestSuite) TestRejections() {
+	suite.T().Run("user who is not a holder returns 403", func(t *testing.T) {
+		responseRecorder := suite.removeAccountHolder(t, "d4e5f6a7-b8c9-4d0e-9f1a-3b4c5d6e7f80", 12345678901237, "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a01")
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this account")
+	})
+
+	suite.T().Run("joint holder cannot remove another holder", func(t *testing.T) {
+		responseRecorder := suite.removeAccountHolder(t, "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f", 55555555555550, "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a03")
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Only the primary holder can remove another holder of this account")
+	})
+
+	suite.T().Run("holder of another account returns 404", func(t *testing.T) {
+		responseRecorder := suite.removeAccountHolder(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237, "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a02")
+		assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	})
+
+	suite.T().Run("holders are not removed", func(t *testing.T) {
+		for _, accountHolderID := range []string{"6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a01", "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a02", "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a03"} {
+			accountHolder := suite.getAccountHolder(t, accountHolderID)
+			assert.Equal(t, model.AccountHolderActive, accountHolder.Status)
+			assert.Nil(t, accountHolder.RemovedAt)
+		}
+	})
+}
+
+func (suite *RemoveAccountHolderTestSuite) TestRemoveAccountHolder() {
+	suite.T().Run("primary holder withdraws an invitation", func(t *testing.T) {
+		responseRecorder := suite.removeAccountHolder(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 66666666666660, "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a06")
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.RemoveAccountHolderResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, string(model.AccountHolderRemoved), response.Data.Status)
+
+		accountHolder := suite.getAccountHolder(t, "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a06")
+		assert.Equal(t, model.AccountHolderRemoved, accountHolder.Status)
+		assert.NotNil(t, accountHolder.RemovedAt)
+
+		responseRecorder = suite.removeAccountHolder(t, "d4e5f6a7-b8c9-4d0e-9f1a-3b4c5d6e7f80", 66666666666660, "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a06")
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	})
+
+	suite.T().Run("joint holder leaves the account", func(t *testing.T) {
+		responseRecorder := suite.removeAccountHolder(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", 12345678901237, "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a01")
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.RemoveAccountHolderResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, string(model.AccountHolderRemoved), response.Data.Status)
+		assert.Equal(t, model.AccountHolderRemoved, suite.getAccountHolder(t, "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a01").Status)
+
+		responseRecorder = testutils.MakeAuthenticatedRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/accounts/12345678901237", http.MethodGet, nil)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		// what user 2 left to be executed out of the account is cancelled, the primary holder's transfer is kept
+		assert.Equal(t, transferModel.ScheduledTransferCancelled, suite.getScheduledTransfer(t, "6b7c8d9e-0f1a-4b2c-9d3e-4f5a6b7c8d01").Status)
+		assert.Equal(t, transferModel.ScheduledTransferPending, suite.getScheduledTransfer(t, "6b7c8d9e-0f1a-4b2c-9d3e-4f5a6b7c8d02").Status)
+		assert.Equal(t, transferModel.StandingInstructionCancelled, suite.getStandingInstruction(t, "7c8d9e0f-1a2b-4c3d-8e4f-5a6b7c8d9e01").Status)
+		assert.Equal(t, transferModel.BulkTransferCancelled, suite.getBulkTransfer(t, "8d9e0f1a-2b3c-4d4e-9f5a-6b7c8d9e0f01").Status)
+	})
+
+	suite.T().Run("user who left cannot transfer from the account", func(t *testing.T) {
+		responseRecorder := makeInternalTransfer(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", 12345678901237, 33333333333330, 1000)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+		assert.Equal(t, int64(500000), testutils.GetAccountBalance(t, suite.app, 12345678901237))
+	})
+
+	suite.T().Run("primary holder transfers alone once the joint holders have left", func(t *testing.T) {
+		responseRecorder := suite.removeAccountHolder(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 44444444444440, "6e7f8a9b-0c1d-4e2f-8a3b-4c5d6e7f8a02")
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		// the joint transfer user 2 initiated would be executed on their behalf, so it is rejected
+		var jointTransfer transferModel.JointTransfer
+		err := suite.app.Db.NewSelect().
+			Model(&jointTransfer).
+			Where("id = ?", "7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b01").
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, transferModel.JointTransferRejected, jointTransfer.Status)
+		assert.Nil(t, jointTransfer.TransactionID)
+
+		// the account is still held under the JOINTLY mandate, but by its primary holder alone
+		responseRecorder = makeInternalTransfer(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 44444444444440, 33333333333330, 20000)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+		assert.Equal(t, int64(480000), testutils.GetAccountBalance(t, suite.app, 44444444444440))
+		assert.Equal(t, int64(20000), testutils.GetAccountBalance(t, suite.app, 33333333333330))
+
+		jointTransferCount, err := suite.app.Db.NewSelect().Model((*transferModel.JointTransfer)(nil)).Where("from_account_id = ?", 44444444444440).Where("initiated_by_user_id = ?", "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d").Count(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, jointTransferCount)
+	})
+}
@@ -0,0 +1,131 @@
+package account
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type RemoveNomineeTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestRemoveNomineeTestSuite(t *testing.T) {
+	suite.Run(t, new(RemoveNomineeTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *RemoveNomineeTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/RemoveNominee_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *RemoveNomineeTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *RemoveNomineeTestSuite) removeNominee(t *testing.T, userID string, nomineeID string, payload any) *httptest.ResponseRecorder {
+	return makeStepUpRequest(t, suite.app, userID, fmt.Sprintf("/v1/accounts/12345678901237/nominees/%s", nomineeID), http.MethodDelete, payload, stepUp(t, suite.app, userID))
+}
+
+func (suite *RemoveNomineeTestSuite) TestRejections() {
+	type scenario struct {
+		name       string
+		userID     string
+		nomineeID  string
+		statusCode int
+		errMessage string
+	}
+
+	tests := []scenario{
+		{
+			name:       "joint holder returns 403",
+			userID:     "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f",
+			nomineeID:  "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c01",
+			statusCode: http.StatusForbidden,
+			errMessage: "Only the primary holder can change the nominees of this account",
+		},
+		{
+			name:       "removed nominee returns 404",
+			userID:     "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			nomineeID:  "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c03",
+			statusCode: http.StatusNotFound,
+			errMessage: "Nominee not found",
+		},
+		{
+			name:       "nominee removed without handing over the share returns 400",
+			userID:     "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			nomineeID:  "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c01",
+			statusCode: http.StatusBadRequest,
+			errMessage: "The shares of the nominees must add up to 100, they would add up to 40",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := suite.removeNominee(t, tc.userID, tc.nomineeID, nil)
+			assert.Equal(t, tc.statusCode, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, "message", tc.errMessage)
+		})
+	}
+
+	suite.T().Run("nominees are unchanged", func(t *testing.T) {
+		nominees := getNominees(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237)
+		if assert.Len(t, nominees, 2) {
+			assert.Equal(t, 60, nominees[0].SharePercentage)
+			assert.Equal(t, 40, nominees[1].SharePercentage)
+		}
+	})
+}
+
+func (suite *RemoveNomineeTestSuite) TestRemoveNominee() {
+	suite.T().Run("share of the removed nominee is handed over to the remaining one", func(t *testing.T) {
+		payload := types.RemoveNomineeRequest{
+			Data: types.RemoveNomineeRequestData{
+				OtherShares: []types.NomineeShareRequestData{
+					{NomineeID: "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c02", SharePercentage: 100},
+				},
+			},
+		}
+		responseRecorder := suite.removeNominee(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c01", payload)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		nominee := decodeNominee(t, responseRecorder)
+		assert.Equal(t, string(model.NomineeRemoved), nominee.Status)
+		assert.NotNil(t, nominee.RemovedAt)
+
+		var removedNominee model.Nominee
+		err := suite.app.Db.NewSelect().
+			Model(&removedNominee).
+			Where("id = ?", "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c01").
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, model.NomineeRemoved, removedNominee.Status)
+		assert.NotNil(t, removedNominee.RemovedAt)
+
+		nominees := getNominees(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237)
+		if assert.Len(t, nominees, 1) {
+			assert.Equal(t, "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c02", nominees[0].ID)
+			assert.Equal(t, 100, nominees[0].SharePercentage)
+		}
+	})
+
+	suite.T().Run("last nominee is removed without a body", func(t *testing.T) {
+		responseRecorder := suite.removeNominee(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c02", nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		nominees := getNominees(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237)
+		assert.Empty(t, nominees)
+	})
+}
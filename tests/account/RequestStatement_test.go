@@ -0,0 +1,255 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	accountTasks "github.com/skamranahmed/go-bank/internal/account/tasks"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/mock"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type RequestStatementTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestRequestStatementTestSuite(t *testing.T) {
+	suite.Run(t, new(RequestStatementTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *RequestStatementTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/RequestStatement_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *RequestStatementTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *RequestStatementTestSuite) TestAccountOfAnotherUser() {
+	suite.T().Run("account of another user returns 403", func(t *testing.T) {
+		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+		assert.NoError(t, err)
+
+		payload := types.RequestStatementRequest{
+			Data: types.RequestStatementRequestData{
+				FromDate: "2025-10-01",
+				ToDate:   "2025-10-31",
+			},
+		}
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/accounts/11111111111111/statements", http.MethodPost, payload, headers)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this account")
+	})
+}
+
+func (suite *RequestStatementTestSuite) TestValidationErrors() {
+	type scenario struct {
+		name       string
+		payload    types.RequestStatementRequest
+		field      string
+		errMessage string
+	}
+
+	tests := []scenario{
+		{
+			name: "missing from_date",
+			payload: types.RequestStatementRequest{
+				Data: types.RequestStatementRequestData{
+					ToDate: "2025-10-31",
+				},
+			},
+			field:      "from_date",
+			errMessage: "from_date is a required field",
+		},
+		{
+			name: "invalid to_date",
+			payload: types.RequestStatementRequest{
+				Data: types.RequestStatementRequestData{
+					FromDate: "2025-10-01",
+					ToDate:   "31-10-2025",
+				},
+			},
+			field:      "to_date",
+			errMessage: "to_date is not a valid date",
+		},
+		{
+			name: "from_date after to_date",
+			payload: types.RequestStatementRequest{
+				Data: types.RequestStatementRequestData{
+					FromDate: "2025-10-31",
+					ToDate:   "2025-10-01",
+				},
+			},
+			field:      "message",
+			errMessage: "from_date cannot be after to_date",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+			accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+			assert.NoError(t, err)
+
+			headers := map[string]string{
+				"Authorization": "Bearer " + accessToken,
+			}
+			responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/accounts/12345678901234/statements", http.MethodPost, tc.payload, headers)
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, tc.field, tc.errMessage)
+		})
+	}
+}
+
+func (suite *RequestStatementTestSuite) TestStatementFee() {
+	payload := types.RequestStatementRequest{
+		Data: types.RequestStatementRequestData{
+			FromDate: "2025-10-01",
+			ToDate:   "2025-10-31",
+		},
+	}
+
+	// the subtests depend on each other because the free quota carries over from one request to the next
+	suite.T().Run("first statement of the month is free and the statement task is enqueued", func(t *testing.T) {
+		mockController := gomock.NewController(t)
+		defer mockController.Finish()
+
+		mockTaskEnqueuer := mock.NewMockTaskEnqueuer(mockController)
+		mockTaskEnqueuer.EXPECT().
+			Enqueue(gomock.Any(), gomock.Any(), nil, nil).
+			Return(nil).
+			Times(1)
+
+		appWithMock := testutils.NewTestApp(
+			suite.T().Context(),
+			&testutils.TestAppDeps{
+				Db:           suite.app.Db,     // reuse the db from the app
+				Cache:        suite.app.Cache,  // reuse the cache from the app
+				TaskEnqueuer: mockTaskEnqueuer, // inject mock TaskEnqueuer to verify task enqueuing
+			},
+			nil,
+			nil,
+		)
+
+		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+		accessToken, err := appWithMock.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+		assert.NoError(t, err)
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+		responseRecorder := testutils.MakeRequest(t, appWithMock, "/v1/accounts/12345678901234/statements", http.MethodPost, payload, headers)
+		assert.Equal(t, http.StatusAccepted, responseRecorder.Code)
+
+		var response types.RequestStatementResponse
+		err = json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, response.Data.ID)
+		assert.Equal(t, int64(0), response.Data.FeeCharged)
+	})
+
+	suite.T().Run("statement above the free quota is charged the fee and the tax", func(t *testing.T) {
+		mockController := gomock.NewController(t)
+		defer mockController.Finish()
+
+		mockTaskEnqueuer := mock.NewMockTaskEnqueuer(mockController)
+		mockTaskEnqueuer.EXPECT().
+			Enqueue(gomock.Any(), gomock.Any(), nil, nil).
+			DoAndReturn(func(ctx context.Context, task tasksHelper.Task, maxRetryCount *int, queueName *string) error {
+				assert.Equal(t, accountTasks.GenerateAccountStatementTaskName, task.Name())
+				return nil
+			}).
+			Times(1)
+
+		appWithMock := testutils.NewTestApp(
+			suite.T().Context(),
+			&testutils.TestAppDeps{
+				Db:           suite.app.Db,
+				Cache:        suite.app.Cache,
+				TaskEnqueuer: mockTaskEnqueuer,
+			},
+			nil,
+			nil,
+		)
+
+		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+		accessToken, err := appWithMock.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+		assert.NoError(t, err)
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+		responseRecorder := testutils.MakeRequest(t, appWithMock, "/v1/accounts/12345678901234/statements", http.MethodPost, payload, headers)
+		assert.Equal(t, http.StatusAccepted, responseRecorder.Code)
+
+		var response types.RequestStatementResponse
+		err = json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5900), response.Data.FeeCharged) // 5000 fee + 900 (18% tax)
+
+		var account accountModel.Account
+		err = suite.app.Db.NewSelect().
+			Model(&account).
+			Where("id = ?", 12345678901234).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(150000-5900), account.Balance)
+	})
+
+	suite.T().Run("statement fee that exceeds the balance returns 400", func(t *testing.T) {
+		userID := "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e"
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+		assert.NoError(t, err)
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+
+		// use up the free quota
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/accounts/11111111111111/statements", http.MethodPost, payload, headers)
+		assert.Equal(t, http.StatusAccepted, responseRecorder.Code)
+
+		responseRecorder = testutils.MakeRequest(t, suite.app, "/v1/accounts/11111111111111/statements", http.MethodPost, payload, headers)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have sufficient balance in your account to pay the fee of 5900")
+	})
+}
@@ -0,0 +1,129 @@
+package account
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	transferTypes "github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type RoundUpTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestRoundUpTestSuite(t *testing.T) {
+	suite.Run(t, new(RoundUpTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *RoundUpTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/RoundUp_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *RoundUpTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *RoundUpTestSuite) transfer(t *testing.T, userID string, fromAccountID int64, amount int64) {
+	payload := transferTypes.InternalTransferRequest{
+		Data: transferTypes.InternalTransferRequestData{
+			FromAccountID: fromAccountID,
+			ToAccountID:   11111111111110,
+			Amount:        &amount,
+		},
+	}
+	responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, userID, "/v1/transfers/internal", http.MethodPost, payload)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+}
+
+func (suite *RoundUpTestSuite) getPocketMovements(t *testing.T, userID string, url string) []types.PocketMovementDto {
+	responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, userID, url, http.MethodGet, nil)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var response types.GetPocketMovementsResponse
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	return response.Data
+}
+
+func (suite *RoundUpTestSuite) TestRoundUp() {
+	userID := "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e"
+	accountID := int64(22222222222220)
+
+	suite.T().Run("spare change of a transfer is swept into the pocket once the fee is charged", func(t *testing.T) {
+		// 123.45 INR is rounded up to 130 INR, sweeping 6.55 INR, on top of the fee of 5.90 INR
+		suite.transfer(t, userID, accountID, 12345)
+
+		account := getAccountDetails(t, suite.app, userID, accountID)
+		assert.Equal(t, int64(87065), account.Balance)
+		assert.Equal(t, int64(655), account.PocketedAmount)
+		assert.Equal(t, int64(86410), account.AvailableBalance)
+		assert.Len(t, account.Pockets, 1)
+		assert.Equal(t, int64(655), account.Pockets[0].Balance)
+
+		var debitTransaction model.Transaction
+		err := suite.app.Db.NewSelect().Model(&debitTransaction).Where("account_id = ?", accountID).Where("type = ?", model.Debit).Scan(t.Context())
+		assert.NoError(t, err)
+
+		pocketMovements := suite.getPocketMovements(t, userID, "/v1/accounts/22222222222220/pockets/5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f03/movements")
+		assert.Len(t, pocketMovements, 1)
+		assert.Equal(t, string(model.PocketRoundUp), pocketMovements[0].Type)
+		assert.Equal(t, int64(655), pocketMovements[0].Amount)
+		assert.Equal(t, debitTransaction.ID.String(), *pocketMovements[0].TransactionID)
+
+		// the receiver is credited the amount of the transfer, the spare change stays with the sender
+		assert.Equal(t, int64(62345), testutils.GetAccountBalance(t, suite.app, 11111111111110))
+	})
+
+	suite.T().Run("transfer of a round amount sweeps nothing", func(t *testing.T) {
+		suite.transfer(t, userID, accountID, 1000)
+
+		account := getAccountDetails(t, suite.app, userID, accountID)
+		assert.Equal(t, int64(85475), account.Balance)
+		assert.Equal(t, int64(655), account.PocketedAmount)
+	})
+
+	suite.T().Run("round-ups turned off sweep nothing", func(t *testing.T) {
+		roundUpTo := int64(0)
+		payload := types.UpdatePocketRequest{
+			Data: types.UpdatePocketRequestData{
+				RoundUpTo: &roundUpTo,
+			},
+		}
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, userID, "/v1/accounts/22222222222220/pockets/5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f03", http.MethodPatch, payload)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		suite.transfer(t, userID, accountID, 1234)
+
+		account := getAccountDetails(t, suite.app, userID, accountID)
+		assert.Equal(t, int64(83651), account.Balance)
+		assert.Equal(t, int64(655), account.PocketedAmount)
+	})
+}
+
+func (suite *RoundUpTestSuite) TestRoundUpNotCovered() {
+	userID := "c3d4e5f6-a7b8-6c7d-0e1f-2a3b4c5d6e7f"
+	accountID := int64(33333333333330)
+
+	suite.T().Run("transfer goes through without the round-up the fee leaves no money for", func(t *testing.T) {
+		// 93.45 INR would sweep 6.55 INR, but only 0.65 INR is left once the fee of 5.90 INR is charged
+		suite.transfer(t, userID, accountID, 9345)
+
+		account := getAccountDetails(t, suite.app, userID, accountID)
+		assert.Equal(t, int64(65), account.Balance)
+		assert.Equal(t, int64(0), account.PocketedAmount)
+		assert.Equal(t, int64(0), account.Pockets[0].Balance)
+
+		pocketMovements := suite.getPocketMovements(t, userID, "/v1/accounts/33333333333330/pockets/5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f04/movements")
+		assert.Len(t, pocketMovements, 0)
+	})
+}
@@ -0,0 +1,115 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	transferModel "github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type UpdateMandateTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestUpdateMandateTestSuite(t *testing.T) {
+	suite.Run(t, new(UpdateMandateTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *UpdateMandateTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/UpdateMandate_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *UpdateMandateTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *UpdateMandateTestSuite) updateMandate(t *testing.T, userID string, accountID int64, mandate model.AccountMandate, jointApprovalThreshold *int64) *httptest.ResponseRecorder {
+	payload := types.UpdateMandateRequest{
+		Data: types.UpdateMandateRequestData{
+			Mandate:                string(mandate),
+			JointApprovalThreshold: jointApprovalThreshold,
+		},
+	}
+	return testutils.MakeAuthenticatedRequest(t, suite.app, userID, fmt.Sprintf("/v1/accounts/%d/mandate", accountID), http.MethodPut, payload)
+}
+
+func (suite *UpdateMandateTestSuite) TestRejections() {
+	suite.T().Run("joint holder cannot change the mandate", func(t *testing.T) {
+		responseRecorder := suite.updateMandate(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", 12345678901237, model.MandateJointly, int64Ptr(5000))
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Only the primary holder can change the mandate of this account")
+	})
+
+	suite.T().Run("jointly mandate without a threshold returns 400", func(t *testing.T) {
+		responseRecorder := suite.updateMandate(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237, model.MandateJointly, nil)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "joint_approval_threshold is required for the JOINTLY mandate")
+	})
+
+	suite.T().Run("mandate is unchanged", func(t *testing.T) {
+		var account model.Account
+		err := suite.app.Db.NewSelect().
+			Model(&account).
+			Where("id = ?", 12345678901237).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, model.MandateEitherOrSurvivor, account.Mandate)
+		assert.Equal(t, int64(0), account.JointApprovalThreshold)
+	})
+}
+
+func (suite *UpdateMandateTestSuite) TestUpdateMandate() {
+	suite.T().Run("primary holder changes the mandate to jointly", func(t *testing.T) {
+		responseRecorder := suite.updateMandate(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237, model.MandateJointly, int64Ptr(5000))
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.UpdateMandateResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, model.MandateJointly, response.Data.Mandate)
+		assert.Equal(t, int64(5000), response.Data.JointApprovalThreshold)
+	})
+
+	suite.T().Run("transfer above the new threshold waits for the approval of every holder", func(t *testing.T) {
+		responseRecorder := makeInternalTransfer(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", 12345678901237, 33333333333330, 20000)
+		assert.Equal(t, http.StatusAccepted, responseRecorder.Code)
+
+		jointTransfer := decodeJointTransfer(t, responseRecorder)
+		assert.Equal(t, transferModel.JointTransferPending, jointTransfer.Status)
+		assert.Equal(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", jointTransfer.InitiatedByUserID)
+		assert.Equal(t, int64(500000), testutils.GetAccountBalance(t, suite.app, 12345678901237))
+		assert.Equal(t, int64(0), testutils.GetAccountBalance(t, suite.app, 33333333333330))
+	})
+
+	suite.T().Run("threshold is dropped under either or survivor", func(t *testing.T) {
+		responseRecorder := suite.updateMandate(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 44444444444440, model.MandateEitherOrSurvivor, int64Ptr(5000))
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.UpdateMandateResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, model.MandateEitherOrSurvivor, response.Data.Mandate)
+		assert.Equal(t, int64(0), response.Data.JointApprovalThreshold)
+
+		responseRecorder = makeInternalTransfer(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", 44444444444440, 33333333333330, 20000)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+		assert.Equal(t, int64(480000), testutils.GetAccountBalance(t, suite.app, 44444444444440))
+		assert.Equal(t, int64(20000), testutils.GetAccountBalance(t, suite.app, 33333333333330))
+	})
+}
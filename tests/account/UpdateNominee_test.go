@@ -0,0 +1,143 @@
+package account
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type UpdateNomineeTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestUpdateNomineeTestSuite(t *testing.T) {
+	suite.Run(t, new(UpdateNomineeTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *UpdateNomineeTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/UpdateNominee_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *UpdateNomineeTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *UpdateNomineeTestSuite) updateNominee(t *testing.T, userID string, nomineeID string, data types.NomineeRequestData) *httptest.ResponseRecorder {
+	payload := types.UpdateNomineeRequest{
+		Data: data,
+	}
+	return makeStepUpRequest(t, suite.app, userID, fmt.Sprintf("/v1/accounts/12345678901237/nominees/%s", nomineeID), http.MethodPut, payload, stepUp(t, suite.app, userID))
+}
+
+// childNominee returns the details of the minor nominee in the fixtures with the share
+func (suite *UpdateNomineeTestSuite) childNominee(sharePercentage int, otherShares ...types.NomineeShareRequestData) types.NomineeRequestData {
+	guardianName := "Jane Doe"
+	guardianRelationship := "MOTHER"
+	return types.NomineeRequestData{
+		Name:                 "Sam Doe",
+		Relationship:         string(model.NomineeChild),
+		DateOfBirth:          "2020-06-01",
+		SharePercentage:      sharePercentage,
+		GuardianName:         &guardianName,
+		GuardianRelationship: &guardianRelationship,
+		OtherShares:          otherShares,
+	}
+}
+
+func (suite *UpdateNomineeTestSuite) TestRejections() {
+	type scenario struct {
+		name       string
+		userID     string
+		nomineeID  string
+		data       types.NomineeRequestData
+		statusCode int
+		errMessage string
+	}
+
+	tests := []scenario{
+		{
+			name:       "joint holder returns 403",
+			userID:     "c3d4e5f6-a7b8-4c9d-8e1f-2a3b4c5d6e7f",
+			nomineeID:  "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c02",
+			data:       suite.childNominee(40),
+			statusCode: http.StatusForbidden,
+			errMessage: "Only the primary holder can change the nominees of this account",
+		},
+		{
+			name:       "invalid nominee ID returns 400",
+			userID:     "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			nomineeID:  "invalid",
+			data:       suite.childNominee(40),
+			statusCode: http.StatusBadRequest,
+			errMessage: "Invalid nominee ID",
+		},
+		{
+			name:       "removed nominee returns 404",
+			userID:     "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			nomineeID:  "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c03",
+			data:       suite.childNominee(40),
+			statusCode: http.StatusNotFound,
+			errMessage: "Nominee not found",
+		},
+		{
+			name:       "share changed without rebalancing the others returns 400",
+			userID:     "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			nomineeID:  "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c02",
+			data:       suite.childNominee(50),
+			statusCode: http.StatusBadRequest,
+			errMessage: "The shares of the nominees must add up to 100, they would add up to 110",
+		},
+		{
+			name:       "share of the nominee itself given as another share returns 400",
+			userID:     "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			nomineeID:  "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c02",
+			data:       suite.childNominee(50, types.NomineeShareRequestData{NomineeID: "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c02", SharePercentage: 50}),
+			statusCode: http.StatusBadRequest,
+			errMessage: "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c02 is not one of the other nominees of this account",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := suite.updateNominee(t, tc.userID, tc.nomineeID, tc.data)
+			assert.Equal(t, tc.statusCode, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, "message", tc.errMessage)
+		})
+	}
+
+	suite.T().Run("nominees are unchanged", func(t *testing.T) {
+		nominees := getNominees(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237)
+		if assert.Len(t, nominees, 2) {
+			assert.Equal(t, 60, nominees[0].SharePercentage)
+			assert.Equal(t, 40, nominees[1].SharePercentage)
+		}
+	})
+}
+
+func (suite *UpdateNomineeTestSuite) TestUpdateNominee() {
+	suite.T().Run("primary holder updates the share of a nominee", func(t *testing.T) {
+		data := suite.childNominee(50, types.NomineeShareRequestData{NomineeID: "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c01", SharePercentage: 50})
+		responseRecorder := suite.updateNominee(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c02", data)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+		assert.Equal(t, 50, decodeNominee(t, responseRecorder).SharePercentage)
+
+		nominees := getNominees(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237)
+		if assert.Len(t, nominees, 2) {
+			assert.Equal(t, 50, nominees[0].SharePercentage)
+			assert.Equal(t, 50, nominees[1].SharePercentage)
+		}
+	})
+}
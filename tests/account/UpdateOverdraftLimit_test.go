@@ -0,0 +1,225 @@
+package account
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// Helper function to create pointer to int64
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+type UpdateOverdraftLimitTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestUpdateOverdraftLimitTestSuite(t *testing.T) {
+	suite.Run(t, new(UpdateOverdraftLimitTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *UpdateOverdraftLimitTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/UpdateOverdraftLimit_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *UpdateOverdraftLimitTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *UpdateOverdraftLimitTestSuite) TestMissingAuthorizationHeader() {
+	suite.T().Run("missing authorization header returns 401", func(t *testing.T) {
+		payload := types.UpdateOverdraftLimitRequest{
+			Data: types.UpdateOverdraftLimitRequestData{
+				OverdraftLimit: int64Ptr(100000),
+			},
+		}
+
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/admin/accounts/98765432109876/overdraft-limit", http.MethodPut, payload, nil)
+		assert.Equal(t, http.StatusUnauthorized, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Authorization header is missing")
+	})
+}
+
+func (suite *UpdateOverdraftLimitTestSuite) TestNonAdminUser() {
+	suite.T().Run("non admin user returns 403", func(t *testing.T) {
+		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+		assert.NoError(t, err)
+
+		payload := types.UpdateOverdraftLimitRequest{
+			Data: types.UpdateOverdraftLimitRequestData{
+				OverdraftLimit: int64Ptr(100000),
+			},
+		}
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/admin/accounts/98765432109876/overdraft-limit", http.MethodPut, payload, headers)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to perform this action")
+	})
+}
+
+func (suite *UpdateOverdraftLimitTestSuite) TestValidationErrors() {
+	type scenario struct {
+		name       string
+		payload    types.UpdateOverdraftLimitRequest
+		field      string
+		errMessage string
+	}
+
+	tests := []scenario{
+		{
+			name: "missing overdraft_limit",
+			payload: types.UpdateOverdraftLimitRequest{
+				Data: types.UpdateOverdraftLimitRequestData{},
+			},
+			field:      "overdraft_limit",
+			errMessage: "overdraft_limit is a required field",
+		},
+		{
+			name: "negative overdraft_limit",
+			payload: types.UpdateOverdraftLimitRequest{
+				Data: types.UpdateOverdraftLimitRequestData{
+					OverdraftLimit: int64Ptr(-1),
+				},
+			},
+			field:      "overdraft_limit",
+			errMessage: "overdraft_limit must be greater than or equal to 0",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			adminUserID := "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9"
+
+			accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), adminUserID)
+			assert.NoError(t, err)
+
+			headers := map[string]string{
+				"Authorization": "Bearer " + accessToken,
+			}
+			responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/admin/accounts/98765432109876/overdraft-limit", http.MethodPut, tc.payload, headers)
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, tc.field, tc.errMessage)
+		})
+	}
+}
+
+func (suite *UpdateOverdraftLimitTestSuite) TestSavingsAccountNotEligible() {
+	suite.T().Run("overdraft limit on a savings account returns 400", func(t *testing.T) {
+		adminUserID := "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), adminUserID)
+		assert.NoError(t, err)
+
+		payload := types.UpdateOverdraftLimitRequest{
+			Data: types.UpdateOverdraftLimitRequestData{
+				OverdraftLimit: int64Ptr(100000),
+			},
+		}
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/admin/accounts/12345678901234/overdraft-limit", http.MethodPut, payload, headers)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Overdraft facility is only available for CURRENT_ACCOUNT")
+	})
+}
+
+func (suite *UpdateOverdraftLimitTestSuite) TestLimitBelowOverdrawnAmount() {
+	suite.T().Run("limit below the overdrawn amount returns 400", func(t *testing.T) {
+		adminUserID := "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), adminUserID)
+		assert.NoError(t, err)
+
+		payload := types.UpdateOverdraftLimitRequest{
+			Data: types.UpdateOverdraftLimitRequestData{
+				OverdraftLimit: int64Ptr(10000), // account is overdrawn by 20000
+			},
+		}
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/admin/accounts/77777777777777/overdraft-limit", http.MethodPut, payload, headers)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Overdraft limit cannot be less than the overdrawn amount of 20000")
+	})
+}
+
+func (suite *UpdateOverdraftLimitTestSuite) TestSuccessfulUpdate() {
+	suite.T().Run("admin sets overdraft limit on a current account", func(t *testing.T) {
+		adminUserID := "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), adminUserID)
+		assert.NoError(t, err)
+
+		payload := types.UpdateOverdraftLimitRequest{
+			Data: types.UpdateOverdraftLimitRequestData{
+				OverdraftLimit: int64Ptr(100000),
+			},
+		}
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/admin/accounts/98765432109876/overdraft-limit", http.MethodPut, payload, headers)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.UpdateOverdraftLimitResponse
+		err = json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(98765432109876), response.Data.ID)
+		assert.Equal(t, int64(100000), response.Data.OverdraftLimit)
+		assert.Equal(t, int64(98000+100000), response.Data.AvailableBalance)
+
+		// verify the limit is persisted
+		var account accountModel.Account
+		err = suite.app.Db.NewSelect().
+			Model(&account).
+			Where("id = ?", 98765432109876).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100000), account.OverdraftLimit)
+	})
+}
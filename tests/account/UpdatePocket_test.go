@@ -0,0 +1,148 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type UpdatePocketTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestUpdatePocketTestSuite(t *testing.T) {
+	suite.Run(t, new(UpdatePocketTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *UpdatePocketTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/UpdatePocket_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *UpdatePocketTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *UpdatePocketTestSuite) updatePocket(t *testing.T, userID string, accountID int64, pocketID string, data types.UpdatePocketRequestData) *httptest.ResponseRecorder {
+	payload := types.UpdatePocketRequest{
+		Data: data,
+	}
+	return testutils.MakeAuthenticatedRequest(t, suite.app, userID, fmt.Sprintf("/v1/accounts/%d/pockets/%s", accountID, pocketID), http.MethodPatch, payload)
+}
+
+func (suite *UpdatePocketTestSuite) TestRejections() {
+	roundUpTo := int64(5)
+	name := "Holiday"
+
+	suite.T().Run("round up to an unsupported amount returns 400", func(t *testing.T) {
+		responseRecorder := suite.updatePocket(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237, "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f01", types.UpdatePocketRequestData{
+			RoundUpTo: &roundUpTo,
+		})
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "round_up_to", "round_up_to must be one of: 0, 1, 10, 100")
+	})
+
+	suite.T().Run("target date in the past returns 400", func(t *testing.T) {
+		responseRecorder := suite.updatePocket(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237, "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f01", types.UpdatePocketRequestData{
+			TargetDate: time.Now().UTC().AddDate(0, 0, -1).Format(time.DateOnly),
+		})
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Target date must be in the future")
+	})
+
+	suite.T().Run("closed pocket returns 400", func(t *testing.T) {
+		responseRecorder := suite.updatePocket(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237, "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f02", types.UpdatePocketRequestData{
+			Name: &name,
+		})
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Pocket Wedding is closed")
+	})
+
+	suite.T().Run("pocket of another account returns 404", func(t *testing.T) {
+		responseRecorder := suite.updatePocket(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", 22222222222220, "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f01", types.UpdatePocketRequestData{
+			Name: &name,
+		})
+		assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	})
+
+	suite.T().Run("pocket is unchanged", func(t *testing.T) {
+		var pocket model.Pocket
+		err := suite.app.Db.NewSelect().
+			Model(&pocket).
+			Where("id = ?", "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f01").
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, "Vacation", pocket.Name)
+		assert.Nil(t, pocket.TargetDate)
+		assert.Nil(t, pocket.RoundUpTo)
+	})
+}
+
+func (suite *UpdatePocketTestSuite) TestUpdatePocket() {
+	suite.T().Run("pocket is renamed and given a new target", func(t *testing.T) {
+		name := "Holiday"
+		targetAmount := int64(800000)
+		responseRecorder := suite.updatePocket(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237, "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f01", types.UpdatePocketRequestData{
+			Name:         &name,
+			TargetAmount: &targetAmount,
+		})
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.UpdatePocketResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "Holiday", response.Data.Name)
+		assert.Equal(t, int64(800000), response.Data.TargetAmount)
+		assert.Equal(t, int64(50000), response.Data.Balance)
+
+		account := getAccountDetails(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237)
+		if assert.Len(t, account.Pockets, 1) {
+			assert.Equal(t, "Holiday", account.Pockets[0].Name)
+			assert.Equal(t, int64(800000), account.Pockets[0].TargetAmount)
+		}
+	})
+
+	suite.T().Run("round-ups are turned off", func(t *testing.T) {
+		roundUpTo := int64(0)
+		responseRecorder := suite.updatePocket(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", 22222222222220, "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f03", types.UpdatePocketRequestData{
+			RoundUpTo: &roundUpTo,
+		})
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.UpdatePocketResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Nil(t, response.Data.RoundUpTo)
+	})
+
+	suite.T().Run("round-ups are turned on", func(t *testing.T) {
+		roundUpTo := int64(100)
+		responseRecorder := suite.updatePocket(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", 22222222222220, "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f03", types.UpdatePocketRequestData{
+			RoundUpTo: &roundUpTo,
+		})
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.UpdatePocketResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100), *response.Data.RoundUpTo)
+	})
+}
@@ -0,0 +1,120 @@
+package account
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type WithdrawFromPocketTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestWithdrawFromPocketTestSuite(t *testing.T) {
+	suite.Run(t, new(WithdrawFromPocketTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *WithdrawFromPocketTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/WithdrawFromPocket_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *WithdrawFromPocketTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *WithdrawFromPocketTestSuite) TestRejections() {
+	type scenario struct {
+		name       string
+		userID     string
+		accountID  int64
+		pocketID   string
+		statusCode int
+		errMessage string
+	}
+
+	tests := []scenario{
+		{
+			name:       "withdrawing more than the pocket holds returns 400",
+			userID:     "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			accountID:  12345678901237,
+			pocketID:   "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f01",
+			statusCode: http.StatusBadRequest,
+			errMessage: "Insufficient balance in pocket Vacation",
+		},
+		{
+			name:       "closed pocket returns 400",
+			userID:     "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			accountID:  12345678901237,
+			pocketID:   "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f02",
+			statusCode: http.StatusBadRequest,
+			errMessage: "Pocket Wedding is closed",
+		},
+		{
+			name:       "pocket of another account returns 404",
+			userID:     "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e",
+			accountID:  22222222222220,
+			pocketID:   "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f01",
+			statusCode: http.StatusNotFound,
+			errMessage: "Pocket not found",
+		},
+	}
+
+	accountBefore := getAccountDetails(suite.T(), suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237)
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := movePocketFunds(t, suite.app, tc.userID, tc.accountID, tc.pocketID, "withdraw", 1000000)
+			assert.Equal(t, tc.statusCode, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, "message", tc.errMessage)
+		})
+	}
+
+	suite.T().Run("nothing is moved", func(t *testing.T) {
+		account := getAccountDetails(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237)
+		assert.Equal(t, accountBefore.PocketedAmount, account.PocketedAmount)
+		assert.Equal(t, accountBefore.AvailableBalance, account.AvailableBalance)
+	})
+}
+
+func (suite *WithdrawFromPocketTestSuite) TestWithdrawFromPocket() {
+	suite.T().Run("money withdrawn from the pocket is available again", func(t *testing.T) {
+		responseRecorder := movePocketFunds(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237, "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f01", "withdraw", 20000)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.MovePocketFundsResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(30000), response.Data.Balance)
+
+		account := getAccountDetails(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237)
+		assert.Equal(t, int64(150000), account.Balance)
+		assert.Equal(t, int64(30000), account.PocketedAmount)
+		assert.Equal(t, int64(120000), account.AvailableBalance)
+
+		var pocketMovement model.PocketMovement
+		err = suite.app.Db.NewSelect().
+			Model(&pocketMovement).
+			Where("pocket_id = ?", "5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f01").
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, model.PocketWithdrawal, pocketMovement.Type)
+		assert.Equal(t, int64(20000), pocketMovement.Amount)
+		assert.Equal(t, int64(30000), pocketMovement.BalanceAfter)
+
+		transactionCount, err := suite.app.Db.NewSelect().Model((*model.Transaction)(nil)).Where("account_id = ?", 12345678901237).Count(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, transactionCount)
+	})
+}
@@ -14,6 +14,7 @@ import (
 
 	userTasks "github.com/skamranahmed/go-bank/internal/user/tasks"
 	"github.com/skamranahmed/go-bank/mock"
+	"github.com/skamranahmed/go-bank/pkg/accountnumber"
 	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
 	"github.com/skamranahmed/go-bank/pkg/testutils"
 	"github.com/stretchr/testify/assert"
@@ -72,8 +73,10 @@ func (suite *SignUpTestSuite) TestValidationErrors() {
 			name: "missing email",
 			payload: dto.SignUpRequest{
 				Data: dto.SignUpData{
-					Username: "username",
-					Password: "password",
+					FirstName: "Test",
+					LastName:  "User",
+					Username:  "username",
+					Password:  "password",
 				},
 			},
 			field:              "email",
@@ -84,9 +87,11 @@ func (suite *SignUpTestSuite) TestValidationErrors() {
 			name: "empty email",
 			payload: dto.SignUpRequest{
 				Data: dto.SignUpData{
-					Email:    "",
-					Username: "username",
-					Password: "password",
+					FirstName: "Test",
+					LastName:  "User",
+					Email:     "",
+					Username:  "username",
+					Password:  "password",
 				},
 			},
 			field:              "email",
@@ -97,9 +102,11 @@ func (suite *SignUpTestSuite) TestValidationErrors() {
 			name: "invalid email",
 			payload: dto.SignUpRequest{
 				Data: dto.SignUpData{
-					Email:    "not_an_email",
-					Username: "username",
-					Password: "password",
+					FirstName: "Test",
+					LastName:  "User",
+					Email:     "not_an_email",
+					Username:  "username",
+					Password:  "password",
 				},
 			},
 			field:              "email",
@@ -110,8 +117,10 @@ func (suite *SignUpTestSuite) TestValidationErrors() {
 			name: "missing username",
 			payload: dto.SignUpRequest{
 				Data: dto.SignUpData{
-					Email:    "test_user_1@example.com",
-					Password: "password",
+					FirstName: "Test",
+					LastName:  "User",
+					Email:     "test_user_1@example.com",
+					Password:  "password",
 				},
 			},
 			field:              "username",
@@ -122,9 +131,11 @@ func (suite *SignUpTestSuite) TestValidationErrors() {
 			name: "empty username",
 			payload: dto.SignUpRequest{
 				Data: dto.SignUpData{
-					Email:    "test_user_1@example.com",
-					Username: "",
-					Password: "password",
+					FirstName: "Test",
+					LastName:  "User",
+					Email:     "test_user_1@example.com",
+					Username:  "",
+					Password:  "password",
 				},
 			},
 			field:              "username",
@@ -135,9 +146,11 @@ func (suite *SignUpTestSuite) TestValidationErrors() {
 			name: "short username (less than 8 characters)",
 			payload: dto.SignUpRequest{
 				Data: dto.SignUpData{
-					Email:    "test_user_1@example.com",
-					Username: "user",
-					Password: "password",
+					FirstName: "Test",
+					LastName:  "User",
+					Email:     "test_user_1@example.com",
+					Username:  "user",
+					Password:  "password",
 				},
 			},
 			field:              "username",
@@ -145,11 +158,27 @@ func (suite *SignUpTestSuite) TestValidationErrors() {
 			expectedStatusCode: http.StatusBadRequest,
 		},
 		{
-			name: "missing password",
+			name: "missing first name",
 			payload: dto.SignUpRequest{
 				Data: dto.SignUpData{
 					Email:    "test_user_1@example.com",
 					Username: "username",
+					Password: "password",
+					LastName: "User",
+				},
+			},
+			field:              "first_name",
+			errMessage:         "first_name is a required field",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "missing password",
+			payload: dto.SignUpRequest{
+				Data: dto.SignUpData{
+					FirstName: "Test",
+					LastName:  "User",
+					Email:     "test_user_1@example.com",
+					Username:  "username",
 				},
 			},
 			field:              "password",
@@ -160,9 +189,11 @@ func (suite *SignUpTestSuite) TestValidationErrors() {
 			name: "empty password",
 			payload: dto.SignUpRequest{
 				Data: dto.SignUpData{
-					Email:    "test_user_1@example.com",
-					Username: "username",
-					Password: "",
+					FirstName: "Test",
+					LastName:  "User",
+					Email:     "test_user_1@example.com",
+					Username:  "username",
+					Password:  "",
 				},
 			},
 			field:              "password",
@@ -173,9 +204,11 @@ func (suite *SignUpTestSuite) TestValidationErrors() {
 			name: "short password (less than 8 characters)",
 			payload: dto.SignUpRequest{
 				Data: dto.SignUpData{
-					Email:    "test_user_1@example.com",
-					Username: "username",
-					Password: "pass",
+					FirstName: "Test",
+					LastName:  "User",
+					Email:     "test_user_1@example.com",
+					Username:  "username",
+					Password:  "pass",
 				},
 			},
 			field:              "password",
@@ -210,9 +243,11 @@ func (suite *SignUpTestSuite) TestConflictErrors() {
 			name: "duplicate email",
 			payload: dto.SignUpRequest{
 				Data: dto.SignUpData{
-					Email:    "kamran@example.com",
-					Username: "username",
-					Password: "password",
+					FirstName: "Test",
+					LastName:  "User",
+					Email:     "kamran@example.com",
+					Username:  "username",
+					Password:  "password",
 				},
 			},
 			field:              "message",
@@ -223,9 +258,11 @@ func (suite *SignUpTestSuite) TestConflictErrors() {
 			name: "duplicate username",
 			payload: dto.SignUpRequest{
 				Data: dto.SignUpData{
-					Email:    "test_user_1@example.com",
-					Username: "kamran_ahmed",
-					Password: "password",
+					FirstName: "Test",
+					LastName:  "User",
+					Email:     "test_user_1@example.com",
+					Username:  "kamran_ahmed",
+					Password:  "password",
 				},
 			},
 			field:              "message",
@@ -279,9 +316,11 @@ func (suite *SignUpTestSuite) TestSuccessfulSignUp() {
 		// prepare request payload
 		payload := dto.SignUpRequest{
 			Data: dto.SignUpData{
-				Email:    "test_user_1@example.com",
-				Username: "username",
-				Password: "password",
+				FirstName: "Test",
+				LastName:  "User",
+				Email:     "test_user_1@example.com",
+				Username:  "username",
+				Password:  "password",
 			},
 		}
 
@@ -314,6 +353,8 @@ func (suite *SignUpTestSuite) TestSuccessfulSignUp() {
 		assert.NotZero(t, user.Password)
 		assert.Equal(t, "test_user_1@example.com", user.Email)
 		assert.Equal(t, "username", user.Username)
+		assert.Equal(t, "Test", user.FirstName)
+		assert.Equal(t, "User", user.LastName)
 		assert.NotEqual(t, "password", user.Password) // must not match the plain text password provided by the user
 
 		// check account record
@@ -329,6 +370,8 @@ func (suite *SignUpTestSuite) TestSuccessfulSignUp() {
 
 		// assert account record data
 		assert.NotZero(t, account.ID)
+		assert.True(t, accountnumber.IsValid(account.ID))
+		assert.Equal(t, int64(accountModel.SavingsAccount.ProductCode()), account.ID/100000000000000)
 		assert.NotZero(t, account.CreatedAt)
 		assert.NotZero(t, account.UpdatedAt)
 		assert.Equal(t, int64(0), account.Balance)
@@ -0,0 +1,81 @@
+package authentication
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/authentication/dto"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type StepUpTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestStepUpTestSuite(t *testing.T) {
+	suite.Run(t, new(StepUpTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *StepUpTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/StepUp_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *StepUpTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *StepUpTestSuite) stepUp(t *testing.T, userID string, password string) *httptest.ResponseRecorder {
+	payload := dto.StepUpRequest{
+		Data: dto.StepUpData{
+			Password: password,
+		},
+	}
+	return testutils.MakeAuthenticatedRequest(t, suite.app, userID, "/v1/step-up", http.MethodPost, payload)
+}
+
+func (suite *StepUpTestSuite) TestStepUp() {
+	suite.T().Run("incorrect password returns 401", func(t *testing.T) {
+		responseRecorder := suite.stepUp(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "incorrect-password")
+		assert.Equal(t, http.StatusUnauthorized, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Password is incorrect")
+	})
+
+	suite.T().Run("correct password returns a step-up token", func(t *testing.T) {
+		responseRecorder := suite.stepUp(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "password")
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response dto.StepUpResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, response.StepUpToken)
+		assert.Equal(t, 300, response.ExpiresInSeconds)
+	})
+}
+
+func (suite *StepUpTestSuite) TestRateLimit() {
+	suite.T().Run("step-ups beyond the limit return 429 even with the correct password", func(t *testing.T) {
+		userID := "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e"
+
+		// the default config allows 10 step-ups per window
+		for range 10 {
+			responseRecorder := suite.stepUp(t, userID, "incorrect-password")
+			assert.Equal(t, http.StatusUnauthorized, responseRecorder.Code)
+		}
+
+		responseRecorder := suite.stepUp(t, userID, "password")
+		assert.Equal(t, http.StatusTooManyRequests, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Too many requests. Please try again later.")
+	})
+}
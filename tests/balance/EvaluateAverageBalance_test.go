@@ -0,0 +1,105 @@
+package balance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/balance/model"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/uptrace/bun"
+)
+
+// EvaluateAverageBalance is run by the month-end worker task, so it is exercised through the service instead of an endpoint
+type EvaluateAverageBalanceTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestEvaluateAverageBalanceTestSuite(t *testing.T) {
+	suite.Run(t, new(EvaluateAverageBalanceTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *EvaluateAverageBalanceTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/EvaluateAverageBalance_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *EvaluateAverageBalanceTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *EvaluateAverageBalanceTestSuite) evaluate(t *testing.T, accountID int64) *model.AverageBalanceBreach {
+	september := time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC)
+	requiredAverageBalance := int64(500000)
+
+	var breach *model.AverageBalanceBreach
+	err := database.RunInTransaction(t.Context(), "evaluateAverageBalance", suite.app.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		breach, err = suite.app.Services.BalanceService.EvaluateAverageBalance(txCtx, tx, accountID, september, requiredAverageBalance)
+		return err
+	})
+	assert.NoError(t, err)
+	return breach
+}
+
+func (suite *EvaluateAverageBalanceTestSuite) TestAverageAboveRequirement() {
+	suite.T().Run("average balance above the requirement is not a breach", func(t *testing.T) {
+		breach := suite.evaluate(t, 11111111111111)
+		assert.Nil(t, breach)
+	})
+}
+
+func (suite *EvaluateAverageBalanceTestSuite) TestAverageBelowRequirement() {
+	suite.T().Run("average balance below the requirement is flagged and the penalty is charged", func(t *testing.T) {
+		breach := suite.evaluate(t, 12345678901234)
+		assert.NotNil(t, breach)
+
+		// average of the September snapshots only: (100000 + 300000) / 2
+		assert.Equal(t, int64(200000), breach.AverageBalance)
+		assert.Equal(t, int64(300000), breach.Shortfall())
+		assert.NotNil(t, breach.FeeChargeID)
+
+		// 10000 penalty + 1800 (18% tax)
+		var account accountModel.Account
+		err := suite.app.Db.NewSelect().
+			Model(&account).
+			Where("id = ?", 12345678901234).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(150000-11800), account.Balance)
+	})
+
+	suite.T().Run("re-evaluating the same month does not flag or charge again", func(t *testing.T) {
+		breach := suite.evaluate(t, 12345678901234)
+		assert.Nil(t, breach)
+
+		var account accountModel.Account
+		err := suite.app.Db.NewSelect().
+			Model(&account).
+			Where("id = ?", 12345678901234).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(150000-11800), account.Balance)
+	})
+}
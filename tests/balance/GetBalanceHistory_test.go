@@ -0,0 +1,145 @@
+package balance
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	"github.com/skamranahmed/go-bank/internal/balance/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type GetBalanceHistoryTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestGetBalanceHistoryTestSuite(t *testing.T) {
+	suite.Run(t, new(GetBalanceHistoryTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *GetBalanceHistoryTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/GetBalanceHistory_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *GetBalanceHistoryTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *GetBalanceHistoryTestSuite) TestMissingAuthorizationHeader() {
+	suite.T().Run("missing authorization header returns 401", func(t *testing.T) {
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/accounts/12345678901234/balance-history", http.MethodGet, nil, nil)
+		assert.Equal(t, http.StatusUnauthorized, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Authorization header is missing")
+	})
+}
+
+func (suite *GetBalanceHistoryTestSuite) TestAccountOfAnotherUser() {
+	suite.T().Run("account of another user returns 403", func(t *testing.T) {
+		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+		assert.NoError(t, err)
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/accounts/11111111111111/balance-history", http.MethodGet, nil, headers)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this account")
+	})
+}
+
+func (suite *GetBalanceHistoryTestSuite) TestValidationErrors() {
+	type scenario struct {
+		name       string
+		query      string
+		field      string
+		errMessage string
+	}
+
+	tests := []scenario{
+		{
+			name:       "invalid from_date",
+			query:      "?from_date=01-10-2025",
+			field:      "from_date",
+			errMessage: "from_date is not a valid date",
+		},
+		{
+			name:       "from_date after to_date",
+			query:      "?from_date=2025-10-03&to_date=2025-10-01",
+			field:      "message",
+			errMessage: "from_date cannot be after to_date",
+		},
+		{
+			name:       "range longer than the maximum",
+			query:      "?from_date=2024-01-01&to_date=2025-10-01",
+			field:      "message",
+			errMessage: "Balance history can be fetched for at most 366 days at a time",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+			accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+			assert.NoError(t, err)
+
+			headers := map[string]string{
+				"Authorization": "Bearer " + accessToken,
+			}
+			responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/accounts/12345678901234/balance-history"+tc.query, http.MethodGet, nil, headers)
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, tc.field, tc.errMessage)
+		})
+	}
+}
+
+func (suite *GetBalanceHistoryTestSuite) TestSuccessfulGetBalanceHistory() {
+	suite.T().Run("returns the daily closing balances in the range, oldest first", func(t *testing.T) {
+		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+		assert.NoError(t, err)
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/accounts/12345678901234/balance-history?from_date=2025-10-02&to_date=2025-10-31", http.MethodGet, nil, headers)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetBalanceHistoryResponse
+		err = json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, []types.DailyBalanceDto{
+			{Date: "2025-10-02", ClosingBalance: 120000},
+			{Date: "2025-10-03", ClosingBalance: 150000},
+		}, response.Data)
+	})
+}
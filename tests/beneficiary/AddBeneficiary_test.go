@@ -0,0 +1,352 @@
+package beneficiary
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/beneficiary/model"
+	beneficiaryTasks "github.com/skamranahmed/go-bank/internal/beneficiary/tasks"
+	"github.com/skamranahmed/go-bank/internal/beneficiary/types"
+	"github.com/skamranahmed/go-bank/mock"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type AddBeneficiaryTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestAddBeneficiaryTestSuite(t *testing.T) {
+	suite.Run(t, new(AddBeneficiaryTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *AddBeneficiaryTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/AddBeneficiary_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *AddBeneficiaryTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *AddBeneficiaryTestSuite) makeRequest(t *testing.T, app testutils.TestApp, userID string, url string, method string, payload any) *httptest.ResponseRecorder {
+	accessToken, err := app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+	assert.NoError(t, err)
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+	}
+	return testutils.MakeRequest(t, app, url, method, payload, headers)
+}
+
+func (suite *AddBeneficiaryTestSuite) TestMissingAuthorizationHeader() {
+	suite.T().Run("missing authorization header returns 401", func(t *testing.T) {
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/beneficiaries", http.MethodPost, nil, nil)
+		assert.Equal(t, http.StatusUnauthorized, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Authorization header is missing")
+	})
+}
+
+func (suite *AddBeneficiaryTestSuite) TestValidationErrors() {
+	type scenario struct {
+		name       string
+		payload    types.AddBeneficiaryRequest
+		field      string
+		errMessage string
+	}
+
+	tests := []scenario{
+		{
+			name: "missing nickname",
+			payload: types.AddBeneficiaryRequest{
+				Data: types.AddBeneficiaryRequestData{
+					AccountID: 11111111111110,
+				},
+			},
+			field:      "nickname",
+			errMessage: "nickname is a required field",
+		},
+		{
+			name: "nickname too long",
+			payload: types.AddBeneficiaryRequest{
+				Data: types.AddBeneficiaryRequestData{
+					Nickname:  "a nickname that is much longer than fifty characters",
+					AccountID: 11111111111110,
+				},
+			},
+			field:      "nickname",
+			errMessage: "nickname must be at most 50 characters",
+		},
+		{
+			name: "missing account_id and iban",
+			payload: types.AddBeneficiaryRequest{
+				Data: types.AddBeneficiaryRequestData{
+					Nickname: "Landlord",
+				},
+			},
+			field:      "account_id",
+			errMessage: "account_id is required when iban is not provided",
+		},
+		{
+			name: "account_id with a wrong check digit",
+			payload: types.AddBeneficiaryRequest{
+				Data: types.AddBeneficiaryRequestData{
+					Nickname:  "Landlord",
+					AccountID: 1000000000000000,
+				},
+			},
+			field:      "account_id",
+			errMessage: "account_id is not a valid account number",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := suite.makeRequest(t, suite.app, "c3d4e5f6-a7b8-6c7d-0e1f-2a3b4c5d6e7f", "/v1/beneficiaries", http.MethodPost, tc.payload)
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, tc.field, tc.errMessage)
+		})
+	}
+}
+
+func (suite *AddBeneficiaryTestSuite) TestInvalidBeneficiaryAccount() {
+	type scenario struct {
+		name               string
+		payload            types.AddBeneficiaryRequestData
+		expectedStatusCode int
+		errMessage         string
+	}
+
+	tests := []scenario{
+		{
+			name: "own account",
+			payload: types.AddBeneficiaryRequestData{
+				Nickname:  "Me",
+				AccountID: 12345678901237,
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			errMessage:         "You cannot add your own account as a beneficiary",
+		},
+		{
+			name: "fixed deposit account",
+			payload: types.AddBeneficiaryRequestData{
+				Nickname:  "Deposit",
+				AccountID: 45454545454544,
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			errMessage:         "Only a savings or current account can be added as a beneficiary",
+		},
+		{
+			name: "non-existent account",
+			payload: types.AddBeneficiaryRequestData{
+				Nickname:  "Nobody",
+				AccountID: 99999999999993,
+			},
+			expectedStatusCode: http.StatusNotFound,
+			errMessage:         "Account with ID 99999999999993 not found",
+		},
+		{
+			name: "iban of an account at another bank",
+			payload: types.AddBeneficiaryRequestData{
+				Nickname: "Landlord",
+				IBAN:     "IN72OTHR0000010011111111111110",
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			errMessage:         "IBAN does not belong to an account at this bank",
+		},
+		{
+			name: "iban and account id of different accounts",
+			payload: types.AddBeneficiaryRequestData{
+				Nickname:  "Landlord",
+				AccountID: 22222222222220,
+				IBAN:      "IN92GOBK0000010011111111111110",
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			errMessage:         "account_id and iban refer to different accounts",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			payload := types.AddBeneficiaryRequest{Data: tc.payload}
+			responseRecorder := suite.makeRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/beneficiaries", http.MethodPost, payload)
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, "message", tc.errMessage)
+		})
+	}
+}
+
+func (suite *AddBeneficiaryTestSuite) TestAddBeneficiary() {
+	suite.T().Run("adds the beneficiary in its cooling period and enqueues the notification", func(t *testing.T) {
+		mockController := gomock.NewController(t)
+		defer mockController.Finish()
+
+		// setup expectations for task enqueuing
+		var enqueuedTask tasksHelper.Task
+		mockTaskEnqueuer := mock.NewMockTaskEnqueuer(mockController)
+		mockTaskEnqueuer.EXPECT().
+			Enqueue(gomock.Any(), gomock.Any(), nil, nil).
+			Do(func(ctx context.Context, task tasksHelper.Task, maxRetryCount *int, queueName *string) {
+				enqueuedTask = task
+			}).
+			Return(nil).
+			Times(1)
+
+		appWithMock := testutils.NewTestApp(
+			suite.T().Context(),
+			&testutils.TestAppDeps{
+				Db:           suite.app.Db,     // reuse the db from the app
+				Cache:        suite.app.Cache,  // reuse the cache from the app
+				TaskEnqueuer: mockTaskEnqueuer, // inject mock TaskEnqueuer to verify task enqueuing
+			},
+			nil,
+			nil,
+		)
+
+		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+		payload := types.AddBeneficiaryRequest{
+			Data: types.AddBeneficiaryRequestData{
+				Nickname:  "Landlord",
+				AccountID: 11111111111110,
+			},
+		}
+
+		requestTime := time.Now().UTC()
+		responseRecorder := suite.makeRequest(t, appWithMock, userID, "/v1/beneficiaries", http.MethodPost, payload)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.AddBeneficiaryResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "Landlord", response.Data.Nickname)
+		assert.Equal(t, int64(11111111111110), response.Data.AccountID)
+		assert.Equal(t, "S*** A****", response.Data.HolderName)
+		assert.Equal(t, int64(0), response.Data.CoolingPeriodTransferredAmount)
+		assert.WithinDuration(t, requestTime.Add(24*time.Hour), response.Data.CoolingPeriodEndsAt, time.Minute)
+
+		// check beneficiary record
+		var beneficiary model.Beneficiary
+		err = suite.app.Db.NewSelect().
+			Model(&beneficiary).
+			Where("id = ?", response.Data.ID).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, uuid.MustParse(userID), beneficiary.UserID)
+
+		// check the notification was enqueued
+		assert.Equal(t, beneficiaryTasks.SendBeneficiaryAddedNotificationTaskName, enqueuedTask.Name())
+		enqueuedTaskPayload, ok := enqueuedTask.Payload().(beneficiaryTasks.SendBeneficiaryAddedNotificationTaskPayload)
+		assert.Equal(t, true, ok)
+		assert.Equal(t, response.Data.ID, enqueuedTaskPayload.BeneficiaryID)
+		assert.Equal(t, userID, enqueuedTaskPayload.UserID)
+	})
+
+	suite.T().Run("adds the beneficiary referenced by its iban", func(t *testing.T) {
+		payload := types.AddBeneficiaryRequest{
+			Data: types.AddBeneficiaryRequestData{
+				Nickname: "Sister",
+				IBAN:     "IN60GOBK0000010022222222222220", // IBAN of account 22222222222220
+			},
+		}
+
+		responseRecorder := suite.makeRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/beneficiaries", http.MethodPost, payload)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.AddBeneficiaryResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, int64(22222222222220), response.Data.AccountID)
+		assert.Equal(t, "R**** V****", response.Data.HolderName)
+	})
+
+	suite.T().Run("lists the beneficiaries of the user", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/beneficiaries", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetBeneficiariesResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Len(t, response.Data, 2)
+		assert.Equal(t, "Landlord", response.Data[0].Nickname)
+		assert.Equal(t, "Sister", response.Data[1].Nickname)
+	})
+}
+
+func (suite *AddBeneficiaryTestSuite) TestDuplicateBeneficiary() {
+	suite.T().Run("adding an account already saved as a beneficiary returns 409", func(t *testing.T) {
+		payload := types.AddBeneficiaryRequest{
+			Data: types.AddBeneficiaryRequestData{
+				Nickname:  "Landlord again",
+				AccountID: 12345678901237,
+			},
+		}
+
+		// user 2 saves user 1's account twice
+		responseRecorder := suite.makeRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/beneficiaries", http.MethodPost, payload)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		responseRecorder = suite.makeRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/beneficiaries", http.MethodPost, payload)
+		assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "This account is already saved as a beneficiary")
+	})
+}
+
+func (suite *AddBeneficiaryTestSuite) TestRateLimit() {
+	suite.T().Run("adding beneficiaries shares the rate limit of name enquiries", func(t *testing.T) {
+		userID := "d4e5f6a7-b8c9-7d8e-1f2a-3b4c5d6e7f80"
+
+		// the default config allows 10 name enquiries per window
+		for range 10 {
+			responseRecorder := suite.makeRequest(t, suite.app, userID, "/v1/name-enquiry?account_id=11111111111110", http.MethodGet, nil)
+			assert.Equal(t, http.StatusOK, responseRecorder.Code)
+		}
+
+		payload := types.AddBeneficiaryRequest{
+			Data: types.AddBeneficiaryRequestData{
+				Nickname:  "Landlord",
+				AccountID: 11111111111110,
+			},
+		}
+		responseRecorder := suite.makeRequest(t, suite.app, userID, "/v1/beneficiaries", http.MethodPost, payload)
+		assert.Equal(t, http.StatusTooManyRequests, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Too many requests. Please try again later.")
+	})
+}
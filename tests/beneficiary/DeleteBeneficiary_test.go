@@ -0,0 +1,115 @@
+package beneficiary
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	"github.com/skamranahmed/go-bank/internal/beneficiary/model"
+	"github.com/skamranahmed/go-bank/internal/beneficiary/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type DeleteBeneficiaryTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestDeleteBeneficiaryTestSuite(t *testing.T) {
+	suite.Run(t, new(DeleteBeneficiaryTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *DeleteBeneficiaryTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/DeleteBeneficiary_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *DeleteBeneficiaryTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *DeleteBeneficiaryTestSuite) makeRequest(t *testing.T, userID string, url string, method string) *httptest.ResponseRecorder {
+	accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+	assert.NoError(t, err)
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+	}
+	return testutils.MakeRequest(t, suite.app, url, method, nil, headers)
+}
+
+func (suite *DeleteBeneficiaryTestSuite) TestInvalidBeneficiaryID() {
+	suite.T().Run("invalid beneficiary ID returns 400", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/beneficiaries/invalid_id", http.MethodDelete)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Invalid beneficiary ID")
+	})
+}
+
+func (suite *DeleteBeneficiaryTestSuite) TestBeneficiaryNotFound() {
+	suite.T().Run("non-existent beneficiary returns 404", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/beneficiaries/00000000-0000-4000-8000-000000000000", http.MethodDelete)
+		assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Beneficiary not found")
+	})
+}
+
+func (suite *DeleteBeneficiaryTestSuite) TestUserCannotDeleteOthersBeneficiary() {
+	suite.T().Run("user cannot delete another user's beneficiary", func(t *testing.T) {
+		// user 1 tries to delete user 2's beneficiary
+		responseRecorder := suite.makeRequest(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/beneficiaries/7f1a2b3c-4d5e-4f60-8a71-b2c3d4e5f603", http.MethodDelete)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this beneficiary")
+
+		exists, err := suite.app.Db.NewSelect().
+			Model((*model.Beneficiary)(nil)).
+			Where("id = ?", "7f1a2b3c-4d5e-4f60-8a71-b2c3d4e5f603").
+			Exists(t.Context())
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+}
+
+func (suite *DeleteBeneficiaryTestSuite) TestSuccessfulDeleteBeneficiary() {
+	suite.T().Run("deleted beneficiary is no longer listed", func(t *testing.T) {
+		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+		responseRecorder := suite.makeRequest(t, userID, "/v1/beneficiaries/7f1a2b3c-4d5e-4f60-8a71-b2c3d4e5f601", http.MethodDelete)
+		assert.Equal(t, http.StatusNoContent, responseRecorder.Code)
+
+		responseRecorder = suite.makeRequest(t, userID, "/v1/beneficiaries", http.MethodGet)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetBeneficiariesResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Len(t, response.Data, 1)
+		assert.Equal(t, "7f1a2b3c-4d5e-4f60-8a71-b2c3d4e5f602", response.Data[0].ID)
+	})
+}
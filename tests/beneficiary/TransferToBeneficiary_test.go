@@ -0,0 +1,176 @@
+package beneficiary
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/beneficiary/model"
+	transferTypes "github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+type TransferToBeneficiaryTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestTransferToBeneficiaryTestSuite(t *testing.T) {
+	suite.Run(t, new(TransferToBeneficiaryTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *TransferToBeneficiaryTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/TransferToBeneficiary_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *TransferToBeneficiaryTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *TransferToBeneficiaryTestSuite) transfer(t *testing.T, beneficiaryID string, amount int64) *httptest.ResponseRecorder {
+	accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d")
+	assert.NoError(t, err)
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+	}
+	payload := transferTypes.InternalTransferRequest{
+		Data: transferTypes.InternalTransferRequestData{
+			FromAccountID: 12345678901237,
+			BeneficiaryID: beneficiaryID,
+			Amount:        int64Ptr(amount),
+		},
+	}
+	return testutils.MakeRequest(t, suite.app, "/v1/transfers/internal", http.MethodPost, payload, headers)
+}
+
+func (suite *TransferToBeneficiaryTestSuite) getBalance(t *testing.T, accountID int64) int64 {
+	var account accountModel.Account
+	err := suite.app.Db.NewSelect().
+		Model(&account).
+		Where("id = ?", accountID).
+		Scan(t.Context())
+	assert.NoError(t, err)
+	return account.Balance
+}
+
+func (suite *TransferToBeneficiaryTestSuite) TestInvalidBeneficiary() {
+	suite.T().Run("invalid beneficiary ID returns 400", func(t *testing.T) {
+		responseRecorder := suite.transfer(t, "invalid_id", 1000)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Invalid beneficiary ID")
+	})
+
+	suite.T().Run("another user's beneficiary returns 403", func(t *testing.T) {
+		responseRecorder := suite.transfer(t, "8e2b3c4d-5e6f-4071-9b82-c3d4e5f6a703", 1000)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this beneficiary")
+	})
+
+	suite.T().Run("beneficiary combined with an account id returns 400", func(t *testing.T) {
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d")
+		assert.NoError(t, err)
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+		payload := transferTypes.InternalTransferRequest{
+			Data: transferTypes.InternalTransferRequestData{
+				FromAccountID: 12345678901237,
+				ToAccountID:   22222222222220,
+				BeneficiaryID: "8e2b3c4d-5e6f-4071-9b82-c3d4e5f6a701",
+				Amount:        int64Ptr(1000),
+			},
+		}
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/transfers/internal", http.MethodPost, payload, headers)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "beneficiary_id cannot be combined with to_account_id or to_iban")
+	})
+}
+
+func (suite *TransferToBeneficiaryTestSuite) TestTransferDuringCoolingPeriod() {
+	beneficiaryID := "8e2b3c4d-5e6f-4071-9b82-c3d4e5f6a701"
+
+	suite.T().Run("transfers within the cooling period limit are credited to the beneficiary", func(t *testing.T) {
+		balanceBefore := suite.getBalance(t, 11111111111110)
+
+		responseRecorder := suite.transfer(t, beneficiaryID, 600000)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		assert.Equal(t, balanceBefore+600000, suite.getBalance(t, 11111111111110))
+
+		var beneficiary model.Beneficiary
+		err := suite.app.Db.NewSelect().
+			Model(&beneficiary).
+			Where("id = ?", beneficiaryID).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(600000), beneficiary.CoolingPeriodTransferredAmount)
+	})
+
+	suite.T().Run("transfers beyond the remaining cooling period limit are rejected", func(t *testing.T) {
+		balanceBefore := suite.getBalance(t, 11111111111110)
+
+		responseRecorder := suite.transfer(t, beneficiaryID, 500000)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "A newly added beneficiary can only receive up to 1000000 in total until 2099-01-01T00:00:00Z, the remaining limit is 400000")
+
+		assert.Equal(t, balanceBefore, suite.getBalance(t, 11111111111110))
+	})
+
+	suite.T().Run("the remaining cooling period limit can be transferred", func(t *testing.T) {
+		responseRecorder := suite.transfer(t, beneficiaryID, 400000)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var beneficiary model.Beneficiary
+		err := suite.app.Db.NewSelect().
+			Model(&beneficiary).
+			Where("id = ?", beneficiaryID).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1000000), beneficiary.CoolingPeriodTransferredAmount)
+	})
+}
+
+func (suite *TransferToBeneficiaryTestSuite) TestTransferAfterCoolingPeriod() {
+	suite.T().Run("transfers after the cooling period are not limited", func(t *testing.T) {
+		balanceBefore := suite.getBalance(t, 22222222222220)
+
+		responseRecorder := suite.transfer(t, "8e2b3c4d-5e6f-4071-9b82-c3d4e5f6a702", 2000000)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		assert.Equal(t, balanceBefore+2000000, suite.getBalance(t, 22222222222220))
+	})
+}
@@ -0,0 +1,292 @@
+package deposit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/deposit/model"
+	"github.com/skamranahmed/go-bank/internal/deposit/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// Helper function to create pointer to int64
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+// Helper function to create pointer to int
+func intPtr(i int) *int {
+	return &i
+}
+
+type BookFixedDepositTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestBookFixedDepositTestSuite(t *testing.T) {
+	suite.Run(t, new(BookFixedDepositTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *BookFixedDepositTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/BookFixedDeposit_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *BookFixedDepositTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *BookFixedDepositTestSuite) bookFixedDeposit(t *testing.T, userID string, payload types.BookFixedDepositRequest) *httptest.ResponseRecorder {
+	accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+	assert.NoError(t, err)
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+	}
+	return testutils.MakeRequest(t, suite.app, "/v1/fixed-deposits", http.MethodPost, payload, headers)
+}
+
+func (suite *BookFixedDepositTestSuite) TestMissingAuthorizationHeader() {
+	suite.T().Run("missing authorization header returns 401", func(t *testing.T) {
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/fixed-deposits", http.MethodPost, nil, nil)
+		assert.Equal(t, http.StatusUnauthorized, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Authorization header is missing")
+	})
+}
+
+func (suite *BookFixedDepositTestSuite) TestValidationErrors() {
+	type scenario struct {
+		name       string
+		payload    types.BookFixedDepositRequest
+		field      string
+		errMessage string
+	}
+
+	validData := func() types.BookFixedDepositRequestData {
+		return types.BookFixedDepositRequestData{
+			LinkedAccountID:     12345678901234,
+			Amount:              int64Ptr(500000),
+			TenureInMonths:      intPtr(12),
+			MaturityInstruction: "PAYOUT",
+		}
+	}
+
+	tests := []scenario{
+		{
+			name: "missing linked_account_id",
+			payload: func() types.BookFixedDepositRequest {
+				data := validData()
+				data.LinkedAccountID = 0
+				return types.BookFixedDepositRequest{Data: data}
+			}(),
+			field:      "linked_account_id",
+			errMessage: "linked_account_id is a required field",
+		},
+		{
+			name: "zero amount",
+			payload: func() types.BookFixedDepositRequest {
+				data := validData()
+				data.Amount = int64Ptr(0)
+				return types.BookFixedDepositRequest{Data: data}
+			}(),
+			field:      "amount",
+			errMessage: "amount must be greater than 0",
+		},
+		{
+			name: "tenure longer than the maximum",
+			payload: func() types.BookFixedDepositRequest {
+				data := validData()
+				data.TenureInMonths = intPtr(121)
+				return types.BookFixedDepositRequest{Data: data}
+			}(),
+			field:      "tenure_in_months",
+			errMessage: "tenure_in_months must be less than or equal to 120",
+		},
+		{
+			name: "unknown maturity_instruction",
+			payload: func() types.BookFixedDepositRequest {
+				data := validData()
+				data.MaturityInstruction = "REINVEST"
+				return types.BookFixedDepositRequest{Data: data}
+			}(),
+			field:      "maturity_instruction",
+			errMessage: "maturity_instruction must be one of: PAYOUT, AUTO_RENEW",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := suite.bookFixedDeposit(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", tc.payload)
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, tc.field, tc.errMessage)
+		})
+	}
+}
+
+func (suite *BookFixedDepositTestSuite) TestAccountOfAnotherUser() {
+	suite.T().Run("booking from the account of another user returns 403", func(t *testing.T) {
+		payload := types.BookFixedDepositRequest{
+			Data: types.BookFixedDepositRequestData{
+				LinkedAccountID:     11111111111111,
+				Amount:              int64Ptr(500000),
+				TenureInMonths:      intPtr(12),
+				MaturityInstruction: "PAYOUT",
+			},
+		}
+
+		responseRecorder := suite.bookFixedDeposit(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", payload)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this account")
+	})
+}
+
+func (suite *BookFixedDepositTestSuite) TestBusinessRuleErrors() {
+	type scenario struct {
+		name       string
+		userID     string
+		payload    types.BookFixedDepositRequest
+		errMessage string
+	}
+
+	tests := []scenario{
+		{
+			name:   "booking from a current account returns 400",
+			userID: "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			payload: types.BookFixedDepositRequest{
+				Data: types.BookFixedDepositRequestData{
+					LinkedAccountID:     98765432109876,
+					Amount:              int64Ptr(500000),
+					TenureInMonths:      intPtr(12),
+					MaturityInstruction: "PAYOUT",
+				},
+			},
+			errMessage: "Fixed deposits can only be booked from a SAVINGS_ACCOUNT",
+		},
+		{
+			name:   "amount below the minimum returns 400",
+			userID: "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			payload: types.BookFixedDepositRequest{
+				Data: types.BookFixedDepositRequestData{
+					LinkedAccountID:     12345678901234,
+					Amount:              int64Ptr(499999),
+					TenureInMonths:      intPtr(12),
+					MaturityInstruction: "PAYOUT",
+				},
+			},
+			errMessage: "Fixed deposit amount must be at least 500000",
+		},
+		{
+			name:   "amount above the balance returns 400",
+			userID: "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e",
+			payload: types.BookFixedDepositRequest{
+				Data: types.BookFixedDepositRequestData{
+					LinkedAccountID:     11111111111111,
+					Amount:              int64Ptr(500000), // balance is 100000
+					TenureInMonths:      intPtr(12),
+					MaturityInstruction: "PAYOUT",
+				},
+			},
+			errMessage: "You do not have sufficient balance in your account to perform the transfer",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := suite.bookFixedDeposit(t, tc.userID, tc.payload)
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, "message", tc.errMessage)
+		})
+	}
+
+	suite.T().Run("failed booking does not leave a deposit account behind", func(t *testing.T) {
+		count, err := suite.app.Db.NewSelect().
+			Model((*accountModel.Account)(nil)).
+			Where("type = ?", accountModel.FixedDeposit).
+			Count(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+}
+
+func (suite *BookFixedDepositTestSuite) TestSuccessfulBooking() {
+	suite.T().Run("booking moves the amount into a new fixed deposit account", func(t *testing.T) {
+		payload := types.BookFixedDepositRequest{
+			Data: types.BookFixedDepositRequestData{
+				LinkedAccountID:     12345678901234,
+				Amount:              int64Ptr(500000),
+				TenureInMonths:      intPtr(12),
+				MaturityInstruction: "AUTO_RENEW",
+			},
+		}
+
+		responseRecorder := suite.bookFixedDeposit(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", payload)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.BookFixedDepositResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		now := time.Now().UTC()
+		startDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		assert.Equal(t, int64(12345678901234), response.Data.LinkedAccountID)
+		assert.Equal(t, int64(500000), response.Data.PrincipalAmount)
+		assert.Equal(t, int64(700), response.Data.AnnualInterestRateInBasisPoints)
+		assert.Equal(t, 12, response.Data.TenureInMonths)
+		assert.Equal(t, startDate.Format(time.DateOnly), response.Data.StartDate)
+		assert.Equal(t, startDate.AddDate(1, 0, 0).Format(time.DateOnly), response.Data.MaturityDate)
+		assert.Equal(t, model.AutoRenew, response.Data.MaturityInstruction)
+		assert.Equal(t, model.Active, response.Data.Status)
+
+		// 7% compounded quarterly for 4 quarters: 8750 + 8903 + 9059 + 9217
+		assert.Equal(t, int64(500000+35929), *response.Data.MaturityAmount)
+
+		// verify the funds moved from the savings account to the deposit account
+		var savingsAccount accountModel.Account
+		err = suite.app.Db.NewSelect().
+			Model(&savingsAccount).
+			Where("id = ?", 12345678901234).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1000000-500000), savingsAccount.Balance)
+
+		var depositAccount accountModel.Account
+		err = suite.app.Db.NewSelect().
+			Model(&depositAccount).
+			Where("id = ?", response.Data.AccountID).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, accountModel.FixedDeposit, depositAccount.Type)
+		assert.Equal(t, int64(500000), depositAccount.Balance)
+	})
+}
@@ -0,0 +1,251 @@
+package deposit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/deposit/model"
+	"github.com/skamranahmed/go-bank/internal/deposit/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type BookRecurringDepositTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestBookRecurringDepositTestSuite(t *testing.T) {
+	suite.Run(t, new(BookRecurringDepositTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *BookRecurringDepositTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/BookRecurringDeposit_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *BookRecurringDepositTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *BookRecurringDepositTestSuite) makeRequest(t *testing.T, userID string, url string, method string, payload any) *httptest.ResponseRecorder {
+	accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+	assert.NoError(t, err)
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+	}
+	return testutils.MakeRequest(t, suite.app, url, method, payload, headers)
+}
+
+func (suite *BookRecurringDepositTestSuite) TestMissingAuthorizationHeader() {
+	suite.T().Run("missing authorization header returns 401", func(t *testing.T) {
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/recurring-deposits", http.MethodPost, nil, nil)
+		assert.Equal(t, http.StatusUnauthorized, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Authorization header is missing")
+	})
+}
+
+func (suite *BookRecurringDepositTestSuite) TestValidationErrors() {
+	type scenario struct {
+		name       string
+		payload    types.BookRecurringDepositRequest
+		field      string
+		errMessage string
+	}
+
+	validData := func() types.BookRecurringDepositRequestData {
+		return types.BookRecurringDepositRequestData{
+			LinkedAccountID:   12345678901234,
+			InstallmentAmount: int64Ptr(50000),
+			TenureInMonths:    intPtr(12),
+			DebitDayOfMonth:   intPtr(5),
+		}
+	}
+
+	tests := []scenario{
+		{
+			name: "zero installment_amount",
+			payload: func() types.BookRecurringDepositRequest {
+				data := validData()
+				data.InstallmentAmount = int64Ptr(0)
+				return types.BookRecurringDepositRequest{Data: data}
+			}(),
+			field:      "installment_amount",
+			errMessage: "installment_amount must be greater than 0",
+		},
+		{
+			name: "tenure shorter than the minimum",
+			payload: func() types.BookRecurringDepositRequest {
+				data := validData()
+				data.TenureInMonths = intPtr(5)
+				return types.BookRecurringDepositRequest{Data: data}
+			}(),
+			field:      "tenure_in_months",
+			errMessage: "tenure_in_months must be greater than or equal to 6",
+		},
+		{
+			name: "debit day that does not exist in every month",
+			payload: func() types.BookRecurringDepositRequest {
+				data := validData()
+				data.DebitDayOfMonth = intPtr(29)
+				return types.BookRecurringDepositRequest{Data: data}
+			}(),
+			field:      "debit_day_of_month",
+			errMessage: "debit_day_of_month must be less than or equal to 28",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := suite.makeRequest(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/recurring-deposits", http.MethodPost, tc.payload)
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, tc.field, tc.errMessage)
+		})
+	}
+}
+
+func (suite *BookRecurringDepositTestSuite) TestBusinessRuleErrors() {
+	type scenario struct {
+		name       string
+		payload    types.BookRecurringDepositRequest
+		errMessage string
+	}
+
+	tests := []scenario{
+		{
+			name: "booking from a current account returns 400",
+			payload: types.BookRecurringDepositRequest{
+				Data: types.BookRecurringDepositRequestData{
+					LinkedAccountID:   98765432109876,
+					InstallmentAmount: int64Ptr(50000),
+					TenureInMonths:    intPtr(12),
+					DebitDayOfMonth:   intPtr(5),
+				},
+			},
+			errMessage: "Recurring deposits can only be booked from a SAVINGS_ACCOUNT",
+		},
+		{
+			name: "installment below the minimum returns 400",
+			payload: types.BookRecurringDepositRequest{
+				Data: types.BookRecurringDepositRequestData{
+					LinkedAccountID:   12345678901234,
+					InstallmentAmount: int64Ptr(49999),
+					TenureInMonths:    intPtr(12),
+					DebitDayOfMonth:   intPtr(5),
+				},
+			},
+			errMessage: "Recurring deposit installment amount must be at least 50000",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := suite.makeRequest(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/recurring-deposits", http.MethodPost, tc.payload)
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, "message", tc.errMessage)
+		})
+	}
+}
+
+func (suite *BookRecurringDepositTestSuite) TestSuccessfulBooking() {
+	var recurringDepositID string
+
+	suite.T().Run("booking collects the first installment into a new recurring deposit account", func(t *testing.T) {
+		payload := types.BookRecurringDepositRequest{
+			Data: types.BookRecurringDepositRequestData{
+				LinkedAccountID:   12345678901234,
+				InstallmentAmount: int64Ptr(100000),
+				TenureInMonths:    intPtr(6),
+				DebitDayOfMonth:   intPtr(10),
+			},
+		}
+
+		responseRecorder := suite.makeRequest(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/recurring-deposits", http.MethodPost, payload)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.BookRecurringDepositResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		recurringDepositID = response.Data.ID
+
+		now := time.Now().UTC()
+		assert.Equal(t, int64(100000), response.Data.InstallmentAmount)
+		assert.Equal(t, int64(650), response.Data.AnnualInterestRateInBasisPoints)
+		assert.Equal(t, now.Format(time.DateOnly), response.Data.StartDate)
+		assert.Equal(t, time.Date(now.Year(), now.Month()+6, 10, 0, 0, 0, 0, time.UTC).Format(time.DateOnly), response.Data.MaturityDate)
+		assert.Equal(t, model.RecurringDepositActive, response.Data.Status)
+
+		var savingsAccount accountModel.Account
+		err = suite.app.Db.NewSelect().
+			Model(&savingsAccount).
+			Where("id = ?", 12345678901234).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1000000-100000), savingsAccount.Balance)
+
+		var depositAccount accountModel.Account
+		err = suite.app.Db.NewSelect().
+			Model(&depositAccount).
+			Where("id = ?", response.Data.AccountID).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, accountModel.RecurringDeposit, depositAccount.Type)
+		assert.Equal(t, int64(100000), depositAccount.Balance)
+	})
+
+	suite.T().Run("schedule lists every installment with the first one paid", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/recurring-deposits/"+recurringDepositID+"/schedule", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetRecurringDepositScheduleResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 6)
+
+		now := time.Now().UTC()
+		assert.Equal(t, model.InstallmentPaid, response.Data[0].Status)
+		assert.Equal(t, now.Format(time.DateOnly), response.Data[0].DueDate)
+		assert.NotNil(t, response.Data[0].TransactionID)
+		for i, installment := range response.Data[1:] {
+			assert.Equal(t, i+2, installment.InstallmentNumber)
+			assert.Equal(t, model.InstallmentPending, installment.Status)
+			assert.Equal(t, time.Date(now.Year(), now.Month()+time.Month(i+1), 10, 0, 0, 0, 0, time.UTC).Format(time.DateOnly), installment.DueDate)
+		}
+	})
+
+	suite.T().Run("schedule of another user's recurring deposit returns 403", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/recurring-deposits/"+recurringDepositID+"/schedule", http.MethodGet, nil)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this recurring deposit")
+	})
+}
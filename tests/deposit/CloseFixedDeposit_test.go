@@ -0,0 +1,199 @@
+package deposit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/deposit/model"
+	"github.com/skamranahmed/go-bank/internal/deposit/types"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/uptrace/bun"
+)
+
+type CloseFixedDepositTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestCloseFixedDepositTestSuite(t *testing.T) {
+	suite.Run(t, new(CloseFixedDepositTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *CloseFixedDepositTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/CloseFixedDeposit_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *CloseFixedDepositTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *CloseFixedDepositTestSuite) closeFixedDeposit(t *testing.T, userID string, fixedDepositID string) *httptest.ResponseRecorder {
+	accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+	assert.NoError(t, err)
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+	}
+	return testutils.MakeRequest(t, suite.app, "/v1/fixed-deposits/"+fixedDepositID+"/close", http.MethodPost, nil, headers)
+}
+
+func (suite *CloseFixedDepositTestSuite) TestErrors() {
+	type scenario struct {
+		name           string
+		fixedDepositID string
+		httpStatusCode int
+		errMessage     string
+	}
+
+	tests := []scenario{
+		{
+			name:           "invalid fixed deposit ID returns 400",
+			fixedDepositID: "not-a-uuid",
+			httpStatusCode: http.StatusBadRequest,
+			errMessage:     "Invalid fixed deposit ID",
+		},
+		{
+			name:           "non-existent fixed deposit returns 404",
+			fixedDepositID: "00000000-0000-4000-8000-000000000000",
+			httpStatusCode: http.StatusNotFound,
+			errMessage:     "Fixed deposit not found",
+		},
+		{
+			name:           "fixed deposit of another user returns 403",
+			fixedDepositID: "af2b99a2-a758-43a1-877e-b3acf4c23d1a",
+			httpStatusCode: http.StatusForbidden,
+			errMessage:     "You do not have permission to access this fixed deposit",
+		},
+		{
+			name:           "fixed deposit that is already paid out returns 400",
+			fixedDepositID: "8d0f7780-8536-41ef-a55c-f18ad2a01bf8",
+			httpStatusCode: http.StatusBadRequest,
+			errMessage:     "Fixed deposit is not active",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := suite.closeFixedDeposit(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", tc.fixedDepositID)
+			assert.Equal(t, tc.httpStatusCode, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, "message", tc.errMessage)
+		})
+	}
+}
+
+func (suite *CloseFixedDepositTestSuite) TestSuccessfulClosure() {
+	suite.T().Run("closing an active deposit pays the principal and interest out to the linked account", func(t *testing.T) {
+		var savingsAccountBefore accountModel.Account
+		err := suite.app.Db.NewSelect().
+			Model(&savingsAccountBefore).
+			Where("id = ?", 12345678901234).
+			Scan(t.Context())
+		assert.NoError(t, err)
+
+		responseRecorder := suite.closeFixedDeposit(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "7c9e6679-7425-40de-944b-e07fc1f90ae7")
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.CloseFixedDepositResponse
+		err = json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, model.Closed, response.Data.Status)
+		assert.NotNil(t, response.Data.ClosedAt)
+		assert.Nil(t, response.Data.MaturityAmount)
+		assert.Greater(t, response.Data.InterestPaid, int64(0))
+
+		var savingsAccount accountModel.Account
+		err = suite.app.Db.NewSelect().
+			Model(&savingsAccount).
+			Where("id = ?", 12345678901234).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, savingsAccountBefore.Balance+1000000+response.Data.InterestPaid, savingsAccount.Balance)
+
+		var depositAccount accountModel.Account
+		err = suite.app.Db.NewSelect().
+			Model(&depositAccount).
+			Where("id = ?", 21212121212121).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), depositAccount.Balance)
+	})
+
+	suite.T().Run("closing the same deposit again returns 400", func(t *testing.T) {
+		responseRecorder := suite.closeFixedDeposit(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "7c9e6679-7425-40de-944b-e07fc1f90ae7")
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Fixed deposit is not active")
+	})
+}
+
+func (suite *CloseFixedDepositTestSuite) TestPrematureClosurePenalty() {
+	suite.T().Run("interest on premature closure is paid at the booked rate less the penalty", func(t *testing.T) {
+		fixedDepositID := uuid.MustParse("9e1a8891-9647-42f0-b66d-a29be3b12c09")
+		closureDate := time.Date(2025, time.April, 11, 0, 0, 0, 0, time.UTC)
+
+		var fixedDeposit *model.FixedDeposit
+		err := database.RunInTransaction(t.Context(), "closeFixedDeposit", suite.app.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+			var err error
+			fixedDeposit, err = suite.app.Services.DepositService.CloseFixedDepositPrematurely(txCtx, tx, fixedDepositID, closureDate)
+			return err
+		})
+		assert.NoError(t, err)
+
+		// 6% (7% less 1% penalty) for 1 quarter: 15000, then simple interest on 1015000 for 10 days: 1668
+		assert.Equal(t, int64(15000+1668), fixedDeposit.InterestPaid)
+		assert.Equal(t, model.Closed, fixedDeposit.Status)
+
+		var interestCredits []accountModel.Transaction
+		err = suite.app.Db.NewSelect().
+			Model(&interestCredits).
+			Where("account_id = ?", 25252525252525).
+			Where("type = ?", accountModel.InterestCredit).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Len(t, interestCredits, 1)
+		assert.Equal(t, int64(16668), interestCredits[0].Amount)
+	})
+}
+
+func (suite *CloseFixedDepositTestSuite) TestMaturedDeposit() {
+	suite.T().Run("deposit that is due cannot be closed prematurely", func(t *testing.T) {
+		fixedDepositID := uuid.MustParse("af2b99a2-a758-43a1-877e-b3acf4c23d1a")
+		closureDate := time.Date(2035, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+		err := database.RunInTransaction(t.Context(), "closeFixedDeposit", suite.app.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+			_, err := suite.app.Services.DepositService.CloseFixedDepositPrematurely(txCtx, tx, fixedDepositID, closureDate)
+			return err
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Fixed deposit has already matured and will be processed shortly")
+	})
+}
@@ -0,0 +1,124 @@
+package deposit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/deposit/model"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/uptrace/bun"
+)
+
+// CollectRecurringDepositInstallment is run by the collection worker task, so it is exercised through the service instead of an endpoint
+type CollectRecurringDepositInstallmentTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestCollectRecurringDepositInstallmentTestSuite(t *testing.T) {
+	suite.Run(t, new(CollectRecurringDepositInstallmentTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *CollectRecurringDepositInstallmentTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/CollectRecurringDepositInstallment_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *CollectRecurringDepositInstallmentTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *CollectRecurringDepositInstallmentTestSuite) collect(t *testing.T, installmentID string, collectionDate time.Time) *model.RecurringDepositInstallment {
+	var installment *model.RecurringDepositInstallment
+	err := database.RunInTransaction(t.Context(), "collectRecurringDepositInstallment", suite.app.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		installment, err = suite.app.Services.DepositService.CollectRecurringDepositInstallment(txCtx, tx, uuid.MustParse(installmentID), collectionDate)
+		return err
+	})
+	assert.NoError(t, err)
+	return installment
+}
+
+func (suite *CollectRecurringDepositInstallmentTestSuite) getAccountBalance(t *testing.T, accountID int64) int64 {
+	var account accountModel.Account
+	err := suite.app.Db.NewSelect().
+		Model(&account).
+		Where("id = ?", accountID).
+		Scan(t.Context())
+	assert.NoError(t, err)
+	return account.Balance
+}
+
+func (suite *CollectRecurringDepositInstallmentTestSuite) TestSuccessfulCollection() {
+	suite.T().Run("installment before its due date is not collected", func(t *testing.T) {
+		installment := suite.collect(t, "6d3a4b5c-8e9f-4a0b-9c2d-3e4f5a6b7c8d", time.Date(2025, time.February, 4, 0, 0, 0, 0, time.UTC))
+		assert.Nil(t, installment)
+		assert.Equal(t, int64(1000000), suite.getAccountBalance(t, 12345678901234))
+	})
+
+	suite.T().Run("due installment is debited from the linked account", func(t *testing.T) {
+		installment := suite.collect(t, "6d3a4b5c-8e9f-4a0b-9c2d-3e4f5a6b7c8d", time.Date(2025, time.February, 5, 0, 0, 0, 0, time.UTC))
+		assert.NotNil(t, installment)
+		assert.Equal(t, model.InstallmentPaid, installment.Status)
+		assert.Equal(t, 1, installment.AttemptCount)
+		assert.NotNil(t, installment.PaidAt)
+		assert.NotNil(t, installment.TransactionID)
+
+		assert.Equal(t, int64(1000000-100000), suite.getAccountBalance(t, 12345678901234))
+		assert.Equal(t, int64(200000), suite.getAccountBalance(t, 31313131313131))
+	})
+
+	suite.T().Run("collecting the paid installment again does nothing", func(t *testing.T) {
+		installment := suite.collect(t, "6d3a4b5c-8e9f-4a0b-9c2d-3e4f5a6b7c8d", time.Date(2025, time.February, 6, 0, 0, 0, 0, time.UTC))
+		assert.Nil(t, installment)
+		assert.Equal(t, int64(1000000-100000), suite.getAccountBalance(t, 12345678901234))
+	})
+}
+
+func (suite *CollectRecurringDepositInstallmentTestSuite) TestInsufficientFunds() {
+	suite.T().Run("failed attempt within the grace period keeps the installment pending", func(t *testing.T) {
+		installment := suite.collect(t, "7e4b5c6d-9f0a-4b1c-8d3e-4f5a6b7c8d9e", time.Date(2025, time.February, 5, 0, 0, 0, 0, time.UTC))
+		assert.NotNil(t, installment)
+		assert.Equal(t, model.InstallmentPending, installment.Status)
+		assert.Equal(t, 1, installment.AttemptCount)
+		assert.NotNil(t, installment.LastAttemptedAt)
+		assert.Nil(t, installment.PaidAt)
+		assert.Equal(t, int64(50000), suite.getAccountBalance(t, 11111111111111))
+	})
+
+	suite.T().Run("failed attempt on the last day of the grace period marks the installment missed", func(t *testing.T) {
+		installment := suite.collect(t, "7e4b5c6d-9f0a-4b1c-8d3e-4f5a6b7c8d9e", time.Date(2025, time.February, 8, 0, 0, 0, 0, time.UTC))
+		assert.NotNil(t, installment)
+		assert.Equal(t, model.InstallmentMissed, installment.Status)
+		assert.Equal(t, 2, installment.AttemptCount)
+		assert.Equal(t, int64(50000), suite.getAccountBalance(t, 11111111111111))
+		assert.Equal(t, int64(100000), suite.getAccountBalance(t, 32323232323232))
+	})
+
+	suite.T().Run("missed installment is not collected again", func(t *testing.T) {
+		installment := suite.collect(t, "7e4b5c6d-9f0a-4b1c-8d3e-4f5a6b7c8d9e", time.Date(2025, time.February, 9, 0, 0, 0, 0, time.UTC))
+		assert.Nil(t, installment)
+	})
+}
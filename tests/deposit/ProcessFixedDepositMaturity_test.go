@@ -0,0 +1,125 @@
+package deposit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/deposit/model"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/uptrace/bun"
+)
+
+// ProcessFixedDepositMaturity is run by the maturity worker task, so it is exercised through the service instead of an endpoint
+type ProcessFixedDepositMaturityTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestProcessFixedDepositMaturityTestSuite(t *testing.T) {
+	suite.Run(t, new(ProcessFixedDepositMaturityTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *ProcessFixedDepositMaturityTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/ProcessFixedDepositMaturity_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *ProcessFixedDepositMaturityTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *ProcessFixedDepositMaturityTestSuite) process(t *testing.T, fixedDepositID string, processingDate time.Time) *model.FixedDeposit {
+	var fixedDeposit *model.FixedDeposit
+	err := database.RunInTransaction(t.Context(), "processFixedDepositMaturity", suite.app.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		fixedDeposit, err = suite.app.Services.DepositService.ProcessFixedDepositMaturity(txCtx, tx, uuid.MustParse(fixedDepositID), processingDate)
+		return err
+	})
+	assert.NoError(t, err)
+	return fixedDeposit
+}
+
+func (suite *ProcessFixedDepositMaturityTestSuite) getAccountBalance(t *testing.T, accountID int64) int64 {
+	var account accountModel.Account
+	err := suite.app.Db.NewSelect().
+		Model(&account).
+		Where("id = ?", accountID).
+		Scan(t.Context())
+	assert.NoError(t, err)
+	return account.Balance
+}
+
+func (suite *ProcessFixedDepositMaturityTestSuite) TestDepositNotDue() {
+	suite.T().Run("deposit before its maturity date is not processed", func(t *testing.T) {
+		fixedDeposit := suite.process(t, "7c9e6679-7425-40de-944b-e07fc1f90ae7", time.Date(2025, time.June, 30, 0, 0, 0, 0, time.UTC))
+		assert.Nil(t, fixedDeposit)
+		assert.Equal(t, int64(1000000), suite.getAccountBalance(t, 21212121212121))
+	})
+}
+
+func (suite *ProcessFixedDepositMaturityTestSuite) TestPayout() {
+	maturityDate := time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+	suite.T().Run("deposit with the payout instruction is paid out to the linked account", func(t *testing.T) {
+		savingsBalanceBefore := suite.getAccountBalance(t, 12345678901234)
+
+		fixedDeposit := suite.process(t, "7c9e6679-7425-40de-944b-e07fc1f90ae7", maturityDate)
+		assert.NotNil(t, fixedDeposit)
+		assert.Equal(t, model.Matured, fixedDeposit.Status)
+		assert.NotNil(t, fixedDeposit.ClosedAt)
+
+		// 7% compounded quarterly for 2 quarters: 17500 + 17806
+		assert.Equal(t, int64(35306), fixedDeposit.InterestPaid)
+		assert.Equal(t, int64(0), suite.getAccountBalance(t, 21212121212121))
+		assert.Equal(t, savingsBalanceBefore+1035306, suite.getAccountBalance(t, 12345678901234))
+	})
+
+	suite.T().Run("processing the paid out deposit again does nothing", func(t *testing.T) {
+		savingsBalanceBefore := suite.getAccountBalance(t, 12345678901234)
+
+		fixedDeposit := suite.process(t, "7c9e6679-7425-40de-944b-e07fc1f90ae7", maturityDate)
+		assert.Nil(t, fixedDeposit)
+		assert.Equal(t, savingsBalanceBefore, suite.getAccountBalance(t, 12345678901234))
+	})
+}
+
+func (suite *ProcessFixedDepositMaturityTestSuite) TestAutoRenew() {
+	suite.T().Run("deposit with the auto renew instruction is renewed with the interest added to the principal", func(t *testing.T) {
+		savingsBalanceBefore := suite.getAccountBalance(t, 12345678901234)
+
+		fixedDeposit := suite.process(t, "8d0f7780-8536-41ef-a55c-f18ad2a01bf8", time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC))
+		assert.NotNil(t, fixedDeposit)
+		assert.Equal(t, model.Active, fixedDeposit.Status)
+		assert.Equal(t, int64(1035306), fixedDeposit.PrincipalAmount)
+		assert.Equal(t, int64(35306), fixedDeposit.InterestPaid)
+		assert.Equal(t, 1, fixedDeposit.RenewalCount)
+		assert.Equal(t, "2025-07-01", fixedDeposit.StartDate.Format(time.DateOnly))
+		assert.Equal(t, "2026-01-01", fixedDeposit.MaturityDate.Format(time.DateOnly))
+
+		// the funds stay in the deposit account
+		assert.Equal(t, int64(1035306), suite.getAccountBalance(t, 23232323232323))
+		assert.Equal(t, savingsBalanceBefore, suite.getAccountBalance(t, 12345678901234))
+	})
+}
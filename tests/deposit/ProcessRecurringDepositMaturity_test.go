@@ -0,0 +1,121 @@
+package deposit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/deposit/model"
+	feeModel "github.com/skamranahmed/go-bank/internal/fee/model"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/uptrace/bun"
+)
+
+// ProcessRecurringDepositMaturity is run by the maturity worker task, so it is exercised through the service instead of an endpoint
+type ProcessRecurringDepositMaturityTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestProcessRecurringDepositMaturityTestSuite(t *testing.T) {
+	suite.Run(t, new(ProcessRecurringDepositMaturityTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *ProcessRecurringDepositMaturityTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/ProcessRecurringDepositMaturity_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *ProcessRecurringDepositMaturityTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *ProcessRecurringDepositMaturityTestSuite) process(t *testing.T, recurringDepositID string, processingDate time.Time) *model.RecurringDeposit {
+	var recurringDeposit *model.RecurringDeposit
+	err := database.RunInTransaction(t.Context(), "processRecurringDepositMaturity", suite.app.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		recurringDeposit, err = suite.app.Services.DepositService.ProcessRecurringDepositMaturity(txCtx, tx, uuid.MustParse(recurringDepositID), processingDate)
+		return err
+	})
+	assert.NoError(t, err)
+	return recurringDeposit
+}
+
+func (suite *ProcessRecurringDepositMaturityTestSuite) getAccountBalance(t *testing.T, accountID int64) int64 {
+	var account accountModel.Account
+	err := suite.app.Db.NewSelect().
+		Model(&account).
+		Where("id = ?", accountID).
+		Scan(t.Context())
+	assert.NoError(t, err)
+	return account.Balance
+}
+
+func (suite *ProcessRecurringDepositMaturityTestSuite) TestMaturity() {
+	suite.T().Run("deposit before its maturity date is not processed", func(t *testing.T) {
+		recurringDeposit := suite.process(t, "4b1e2f3a-6c7d-4e8f-9a0b-1c2d3e4f5a6b", time.Date(2025, time.July, 4, 0, 0, 0, 0, time.UTC))
+		assert.Nil(t, recurringDeposit)
+		assert.Equal(t, int64(400000), suite.getAccountBalance(t, 31313131313131))
+	})
+
+	suite.T().Run("deposit is paid out with interest on the paid installments less the missed installment penalties", func(t *testing.T) {
+		recurringDeposit := suite.process(t, "4b1e2f3a-6c7d-4e8f-9a0b-1c2d3e4f5a6b", time.Date(2025, time.July, 5, 0, 0, 0, 0, time.UTC))
+		assert.NotNil(t, recurringDeposit)
+		assert.Equal(t, model.RecurringDepositMatured, recurringDeposit.Status)
+		assert.NotNil(t, recurringDeposit.ClosedAt)
+
+		// 6.5% compounded quarterly from the day each installment was paid: 3276 + 2729 + 2168 + 1585
+		assert.Equal(t, int64(9758), recurringDeposit.InterestPaid)
+
+		// 2 missed installments charged INR 50 + 18% tax each
+		assert.Equal(t, int64(0), suite.getAccountBalance(t, 31313131313131))
+		assert.Equal(t, int64(100000+400000+9758-2*5900), suite.getAccountBalance(t, 12345678901234))
+
+		count, err := suite.app.Db.NewSelect().
+			Model((*feeModel.FeeCharge)(nil)).
+			Where("account_id = ?", 31313131313131).
+			Where("event = ?", feeModel.RecurringDepositMissedInstallment).
+			Where("status = ?", feeModel.Charged).
+			Count(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		var pendingInstallments int
+		pendingInstallments, err = suite.app.Db.NewSelect().
+			Model((*model.RecurringDepositInstallment)(nil)).
+			Where("recurring_deposit_id = ?", recurringDeposit.ID).
+			Where("status = ?", model.InstallmentPending).
+			Count(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, pendingInstallments)
+	})
+
+	suite.T().Run("processing the paid out deposit again does nothing", func(t *testing.T) {
+		savingsBalanceBefore := suite.getAccountBalance(t, 12345678901234)
+
+		recurringDeposit := suite.process(t, "4b1e2f3a-6c7d-4e8f-9a0b-1c2d3e4f5a6b", time.Date(2025, time.July, 5, 0, 0, 0, 0, time.UTC))
+		assert.Nil(t, recurringDeposit)
+		assert.Equal(t, savingsBalanceBefore, suite.getAccountBalance(t, 12345678901234))
+	})
+}
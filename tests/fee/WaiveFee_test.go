@@ -0,0 +1,260 @@
+package fee
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	feeModel "github.com/skamranahmed/go-bank/internal/fee/model"
+	"github.com/skamranahmed/go-bank/internal/fee/types"
+	ledgerModel "github.com/skamranahmed/go-bank/internal/ledger/model"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type WaiveFeeTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestWaiveFeeTestSuite(t *testing.T) {
+	suite.Run(t, new(WaiveFeeTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *WaiveFeeTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/WaiveFee_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *WaiveFeeTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *WaiveFeeTestSuite) TestNonAdminUser() {
+	suite.T().Run("non admin user returns 403", func(t *testing.T) {
+		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+		assert.NoError(t, err)
+
+		payload := types.WaiveFeeRequest{
+			Data: types.WaiveFeeRequestData{
+				Reason: "goodwill",
+			},
+		}
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/admin/fees/3d4e5f6a-7b8c-4d9e-9f0a-2b3c4d5e6f7a/waive", http.MethodPost, payload, headers)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to perform this action")
+	})
+}
+
+func (suite *WaiveFeeTestSuite) TestValidationErrors() {
+	suite.T().Run("missing reason returns 400", func(t *testing.T) {
+		adminUserID := "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), adminUserID)
+		assert.NoError(t, err)
+
+		payload := types.WaiveFeeRequest{
+			Data: types.WaiveFeeRequestData{},
+		}
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/admin/fees/3d4e5f6a-7b8c-4d9e-9f0a-2b3c4d5e6f7a/waive", http.MethodPost, payload, headers)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "reason", "reason is a required field")
+	})
+
+	suite.T().Run("invalid fee charge ID returns 400", func(t *testing.T) {
+		adminUserID := "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), adminUserID)
+		assert.NoError(t, err)
+
+		payload := types.WaiveFeeRequest{
+			Data: types.WaiveFeeRequestData{
+				Reason: "goodwill",
+			},
+		}
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/admin/fees/not-a-uuid/waive", http.MethodPost, payload, headers)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Invalid fee charge ID")
+	})
+}
+
+func (suite *WaiveFeeTestSuite) TestFeeChargeNotFound() {
+	suite.T().Run("unknown fee charge returns 404", func(t *testing.T) {
+		adminUserID := "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), adminUserID)
+		assert.NoError(t, err)
+
+		payload := types.WaiveFeeRequest{
+			Data: types.WaiveFeeRequestData{
+				Reason: "goodwill",
+			},
+		}
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/admin/fees/00000000-0000-4000-8000-000000000000/waive", http.MethodPost, payload, headers)
+		assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Fee charge not found")
+	})
+}
+
+func (suite *WaiveFeeTestSuite) TestFeeNotWaivable() {
+	type scenario struct {
+		name           string
+		feeChargeID    string
+		httpStatusCode int
+		errMessage     string
+	}
+
+	tests := []scenario{
+		{
+			name:           "fee covered by the free quota returns 400",
+			feeChargeID:    "5f6a7b8c-9d0e-4f1a-9b2c-4d5e6f7a8b9c",
+			httpStatusCode: http.StatusBadRequest,
+			errMessage:     "Fee was not charged, there is nothing to waive",
+		},
+		{
+			name:           "already waived fee returns 409",
+			feeChargeID:    "7b8c9d0e-1f2a-4b3c-9d4e-6f7a8b9c0d1e",
+			httpStatusCode: http.StatusConflict,
+			errMessage:     "Fee has already been waived",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			adminUserID := "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9"
+
+			accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), adminUserID)
+			assert.NoError(t, err)
+
+			payload := types.WaiveFeeRequest{
+				Data: types.WaiveFeeRequestData{
+					Reason: "goodwill",
+				},
+			}
+
+			headers := map[string]string{
+				"Authorization": "Bearer " + accessToken,
+			}
+			responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/admin/fees/"+tc.feeChargeID+"/waive", http.MethodPost, payload, headers)
+			assert.Equal(t, tc.httpStatusCode, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, "message", tc.errMessage)
+		})
+	}
+}
+
+func (suite *WaiveFeeTestSuite) TestSuccessfulWaiver() {
+	suite.T().Run("admin waives a charged fee and the account is refunded", func(t *testing.T) {
+		adminUserID := "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), adminUserID)
+		assert.NoError(t, err)
+
+		payload := types.WaiveFeeRequest{
+			Data: types.WaiveFeeRequestData{
+				Reason: "goodwill",
+			},
+		}
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/admin/fees/3d4e5f6a-7b8c-4d9e-9f0a-2b3c4d5e6f7a/waive", http.MethodPost, payload, headers)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.WaiveFeeResponse
+		err = json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, feeModel.Waived, response.Data.Status)
+		assert.Equal(t, int64(5900), response.Data.TotalAmount)
+		assert.NotNil(t, response.Data.WaivedAt)
+
+		// verify the fee and the tax are refunded to the account
+		var account accountModel.Account
+		err = suite.app.Db.NewSelect().
+			Model(&account).
+			Where("id = ?", 12345678901234).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(94100+5900), account.Balance)
+
+		// verify the refund shows up as a FEE_WAIVER transaction
+		var feeCharge feeModel.FeeCharge
+		err = suite.app.Db.NewSelect().
+			Model(&feeCharge).
+			Where("id = ?", "3d4e5f6a-7b8c-4d9e-9f0a-2b3c4d5e6f7a").
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.NotNil(t, feeCharge.WaiverTransactionID)
+
+		var waiverTransaction accountModel.Transaction
+		err = suite.app.Db.NewSelect().
+			Model(&waiverTransaction).
+			Where("id = ?", *feeCharge.WaiverTransactionID).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, accountModel.FeeWaiver, waiverTransaction.Type)
+		assert.Equal(t, int64(5900), waiverTransaction.Amount)
+
+		// verify the fee revenue and the tax payable are reversed
+		var entries []ledgerModel.InternalAccountEntry
+		err = suite.app.Db.NewSelect().
+			Model(&entries).
+			Where("reference = ?", "3d4e5f6a-7b8c-4d9e-9f0a-2b3c4d5e6f7a").
+			Order("internal_account_code ASC").
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Len(t, entries, 2)
+		assert.Equal(t, ledgerModel.FeeRevenue, entries[0].InternalAccountCode)
+		assert.Equal(t, ledgerModel.Debit, entries[0].Type)
+		assert.Equal(t, int64(5000), entries[0].Amount)
+		assert.Equal(t, ledgerModel.TaxPayable, entries[1].InternalAccountCode)
+		assert.Equal(t, ledgerModel.Debit, entries[1].Type)
+		assert.Equal(t, int64(900), entries[1].Amount)
+	})
+}
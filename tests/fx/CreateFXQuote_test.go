@@ -0,0 +1,179 @@
+package fx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	fxModel "github.com/skamranahmed/go-bank/internal/fx/model"
+	"github.com/skamranahmed/go-bank/internal/fx/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func createFXQuote(t *testing.T, app testutils.TestApp, userID string, fromAccountID, toAccountID, amount int64) *httptest.ResponseRecorder {
+	payload := types.CreateFXQuoteRequest{
+		Data: types.CreateFXQuoteRequestData{
+			FromAccountID: fromAccountID,
+			ToAccountID:   toAccountID,
+			Amount:        int64Ptr(amount),
+		},
+	}
+	return testutils.MakeAuthenticatedRequest(t, app, userID, "/v1/fx/quotes", http.MethodPost, payload)
+}
+
+type CreateFXQuoteTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestCreateFXQuoteTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateFXQuoteTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *CreateFXQuoteTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/CreateFXQuote_test")
+
+	// the rate in the fixtures is too old to be quoted
+	responseRecorder := publishFXRate(suite.T(), suite.app, "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9", "USD", "INR", "83.25")
+	assert.Equal(suite.T(), http.StatusCreated, responseRecorder.Code)
+}
+
+// TearDownSuite runs once after all tests
+func (suite *CreateFXQuoteTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *CreateFXQuoteTestSuite) TestRejections() {
+	type scenario struct {
+		name          string
+		userID        string
+		fromAccountID int64
+		toAccountID   int64
+		amount        int64
+		statusCode    int
+		errMessage    string
+	}
+
+	tests := []scenario{
+		{
+			name:          "another user's account returns 403",
+			userID:        "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			fromAccountID: 11111111111110,
+			toAccountID:   12345678901237,
+			amount:        100000,
+			statusCode:    http.StatusForbidden,
+			errMessage:    "You are not authorized to perform transfer from this account",
+		},
+		{
+			name:          "accounts in the same currency returns 400",
+			userID:        "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			fromAccountID: 12345678901237,
+			toAccountID:   22222222222220,
+			amount:        100000,
+			statusCode:    http.StatusBadRequest,
+			errMessage:    "Both accounts are held in INR, a transfer between them needs no FX quote",
+		},
+		{
+			name:          "currency without a rate returns 400",
+			userID:        "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e",
+			fromAccountID: 44444444444440,
+			toAccountID:   22222222222220,
+			amount:        100000,
+			statusCode:    http.StatusBadRequest,
+			errMessage:    "No FX rate is available to convert JPY to INR",
+		},
+		{
+			name:          "amount too small to be converted returns 400",
+			userID:        "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d",
+			fromAccountID: 12345678901237,
+			toAccountID:   11111111111110,
+			amount:        1,
+			statusCode:    http.StatusBadRequest,
+			errMessage:    "The amount is too small to be converted to USD",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := createFXQuote(t, suite.app, tc.userID, tc.fromAccountID, tc.toAccountID, tc.amount)
+			assert.Equal(t, tc.statusCode, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, "message", tc.errMessage)
+		})
+	}
+}
+
+func (suite *CreateFXQuoteTestSuite) TestCreateFXQuote() {
+	suite.T().Run("quote from the published rate less the margin", func(t *testing.T) {
+		// USD 10 at 83.25 less 0.5% is INR 828.3375, rounded down to the paisa
+		responseRecorder := createFXQuote(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 33333333333330, 22222222222220, 1000)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.FXQuoteResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "USD", response.Data.SourceCurrency)
+		assert.Equal(t, int64(1000), response.Data.SourceAmount)
+		assert.Equal(t, "INR", response.Data.TargetCurrency)
+		assert.Equal(t, int64(82833), response.Data.TargetAmount)
+		assert.Equal(t, "82.83375000", response.Data.Rate)
+		assert.Equal(t, fxModel.FXQuoteOpen, response.Data.Status)
+		assert.True(t, response.Data.ExpiresAt.After(response.Data.CreatedAt))
+
+		// the quote is priced from the rate published in SetupSuite, not the one in the fixtures
+		var fxQuote fxModel.FXQuote
+		err = suite.app.Db.NewSelect().
+			Model(&fxQuote).
+			Where("id = ?", response.Data.ID).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", fxQuote.UserID.String())
+		assert.NotEqual(t, "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d", fxQuote.FXRateID.String())
+		assert.Equal(t, int64(50), fxQuote.MarginInBasisPoints)
+
+		// a quote does not move any money
+		assert.Equal(t, int64(50000), testutils.GetAccountBalance(t, suite.app, 33333333333330))
+		assert.Equal(t, int64(0), testutils.GetAccountBalance(t, suite.app, 22222222222220))
+	})
+
+	suite.T().Run("quote from the inverted rate of the opposite pair", func(t *testing.T) {
+		// INR 1,000 at 1/83.25 less 0.5% is USD 11.95194, rounded down to the cent
+		responseRecorder := createFXQuote(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237, 11111111111110, 100000)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.FXQuoteResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1195), response.Data.TargetAmount)
+		assert.Equal(t, "0.01195194", response.Data.Rate)
+	})
+
+	suite.T().Run("a joint holder can quote from the joint account", func(t *testing.T) {
+		// the USD account of user 1 is also held by user 2
+		responseRecorder := createFXQuote(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", 33333333333330, 22222222222220, 1000)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.FXQuoteResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		// the quote belongs to the holder asking for it, not to the primary holder
+		var fxQuote fxModel.FXQuote
+		err = suite.app.Db.NewSelect().
+			Model(&fxQuote).
+			Where("id = ?", response.Data.ID).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", fxQuote.UserID.String())
+	})
+}
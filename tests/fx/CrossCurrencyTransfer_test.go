@@ -0,0 +1,217 @@
+package fx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	fxModel "github.com/skamranahmed/go-bank/internal/fx/model"
+	"github.com/skamranahmed/go-bank/internal/fx/types"
+	ledgerModel "github.com/skamranahmed/go-bank/internal/ledger/model"
+	transferTypes "github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/money"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type CrossCurrencyTransferTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestCrossCurrencyTransferTestSuite(t *testing.T) {
+	suite.Run(t, new(CrossCurrencyTransferTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *CrossCurrencyTransferTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/CrossCurrencyTransfer_test")
+
+	// the rate in the fixtures is too old to be quoted
+	responseRecorder := publishFXRate(suite.T(), suite.app, "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9", "USD", "INR", "83.25")
+	assert.Equal(suite.T(), http.StatusCreated, responseRecorder.Code)
+}
+
+// TearDownSuite runs once after all tests
+func (suite *CrossCurrencyTransferTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *CrossCurrencyTransferTestSuite) transfer(t *testing.T, fromAccountID, toAccountID, amount int64, fxQuoteID string) *httptest.ResponseRecorder {
+	payload := transferTypes.InternalTransferRequest{
+		Data: transferTypes.InternalTransferRequestData{
+			FromAccountID: fromAccountID,
+			ToAccountID:   toAccountID,
+			Amount:        int64Ptr(amount),
+			FXQuoteID:     fxQuoteID,
+		},
+	}
+	return testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/transfers/internal", http.MethodPost, payload)
+}
+
+func (suite *CrossCurrencyTransferTestSuite) getInternalAccount(t *testing.T, code ledgerModel.InternalAccountCode) ledgerModel.InternalAccount {
+	var internalAccount ledgerModel.InternalAccount
+	err := suite.app.Db.NewSelect().
+		Model(&internalAccount).
+		Where("code = ?", code).
+		Scan(t.Context())
+	assert.NoError(t, err)
+	return internalAccount
+}
+
+func (suite *CrossCurrencyTransferTestSuite) TestRejections() {
+	suite.T().Run("transfer between different currencies without a quote returns 400", func(t *testing.T) {
+		balanceBefore := testutils.GetAccountBalance(t, suite.app, 12345678901237)
+
+		responseRecorder := suite.transfer(t, 12345678901237, 11111111111110, 100000, "")
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "The accounts are held in INR and USD, a transfer between them needs an FX quote")
+		assert.Equal(t, balanceBefore, testutils.GetAccountBalance(t, suite.app, 12345678901237))
+	})
+
+	suite.T().Run("expired quote returns 400", func(t *testing.T) {
+		responseRecorder := suite.transfer(t, 12345678901237, 11111111111110, 8000, "1b2c3d4e-5f6a-4b7c-9d8e-0f1a2b3c4d5e")
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "FX quote has expired, ask for a new quote")
+	})
+
+	suite.T().Run("quote that was already used returns 409", func(t *testing.T) {
+		responseRecorder := suite.transfer(t, 12345678901237, 11111111111110, 16000, "2c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e6f")
+		assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "FX quote has already been used")
+	})
+}
+
+func (suite *CrossCurrencyTransferTestSuite) TestCrossCurrencyTransfer() {
+	var fxQuoteID string
+	suite.T().Run("quote is given", func(t *testing.T) {
+		responseRecorder := createFXQuote(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 12345678901237, 11111111111110, 100000)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.FXQuoteResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		fxQuoteID = response.Data.ID
+	})
+
+	suite.T().Run("quote for another amount returns 400", func(t *testing.T) {
+		responseRecorder := suite.transfer(t, 12345678901237, 11111111111110, 200000, fxQuoteID)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "The FX quote was given for another transfer")
+	})
+
+	suite.T().Run("transfer is made at the quoted amounts", func(t *testing.T) {
+		responseRecorder := suite.transfer(t, 12345678901237, 11111111111110, 100000, fxQuoteID)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response transferTypes.InternalTransferResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100000), response.Data.Transaction.Amount)
+
+		assert.Equal(t, int64(9900000), testutils.GetAccountBalance(t, suite.app, 12345678901237))
+		assert.Equal(t, int64(1195), testutils.GetAccountBalance(t, suite.app, 11111111111110))
+
+		var credits []accountModel.Transaction
+		err = suite.app.Db.NewSelect().
+			Model(&credits).
+			Where("account_id = ?", 11111111111110).
+			Where("type = ?", accountModel.Credit).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		if assert.Len(t, credits, 1) {
+			assert.Equal(t, int64(1195), credits[0].Amount)
+			if assert.NotNil(t, credits[0].CounterpartTransactionID) {
+				assert.Equal(t, response.Data.Transaction.ID, credits[0].CounterpartTransactionID.String())
+			}
+		}
+
+		// the bank took in INR and paid out USD
+		inrPosition := suite.getInternalAccount(t, ledgerModel.FXPosition(money.INR))
+		assert.Equal(t, int64(100000), inrPosition.Balance)
+		assert.Equal(t, money.INR, inrPosition.Currency)
+
+		usdPosition := suite.getInternalAccount(t, ledgerModel.FXPosition(money.USD))
+		assert.Equal(t, int64(-1195), usdPosition.Balance)
+		assert.Equal(t, money.USD, usdPosition.Currency)
+	})
+
+	suite.T().Run("quote is marked as used", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/fx/quotes/"+fxQuoteID, http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.FXQuoteResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, fxModel.FXQuoteUsed, response.Data.Status)
+		assert.NotNil(t, response.Data.TransactionID)
+		assert.NotNil(t, response.Data.UsedAt)
+	})
+
+	suite.T().Run("quote cannot be used twice", func(t *testing.T) {
+		responseRecorder := suite.transfer(t, 12345678901237, 11111111111110, 100000, fxQuoteID)
+		assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "FX quote has already been used")
+		assert.Equal(t, int64(9900000), testutils.GetAccountBalance(t, suite.app, 12345678901237))
+	})
+}
+
+func (suite *CrossCurrencyTransferTestSuite) TestTransferLimits() {
+	// quotes and transfers from the USD account of user 1, the limits of a savings account are amounts of INR
+	quoteAndTransfer := func(t *testing.T, amount int64) *httptest.ResponseRecorder {
+		responseRecorder := createFXQuote(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", 33333333333330, 22222222222220, amount)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.FXQuoteResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		return suite.transfer(t, 33333333333330, 22222222222220, amount, response.Data.ID)
+	}
+
+	suite.T().Run("amount is counted against the per-transaction limit in INR", func(t *testing.T) {
+		// USD 2,000 at the quoted 82.83375 is INR 1,65,667.50, above the limit of INR 1,00,000
+		responseRecorder := quoteAndTransfer(t, 200000)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "The amount exceeds the per-transaction limit of 10000000")
+		assert.Equal(t, int64(5000000), testutils.GetAccountBalance(t, suite.app, 33333333333330))
+		assert.Equal(t, int64(0), testutils.GetAccountBalance(t, suite.app, 22222222222220))
+	})
+
+	suite.T().Run("transfers within the limits in INR are made", func(t *testing.T) {
+		// USD 1,000 is INR 82,833.75 each time, INR 1,65,667.50 for the day
+		for range 2 {
+			responseRecorder := quoteAndTransfer(t, 100000)
+			assert.Equal(t, http.StatusOK, responseRecorder.Code)
+		}
+
+		assert.Equal(t, int64(4800000), testutils.GetAccountBalance(t, suite.app, 33333333333330))
+		assert.Equal(t, int64(16566750), testutils.GetAccountBalance(t, suite.app, 22222222222220))
+	})
+
+	suite.T().Run("debits of the day are counted against the daily limit in INR", func(t *testing.T) {
+		// another USD 1,000 makes INR 2,48,501.25 for the day, above the limit of INR 2,00,000
+		responseRecorder := quoteAndTransfer(t, 100000)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "The amount exceeds the daily transfer limit of 20000000, the remaining limit for today is 3433250")
+		assert.Equal(t, int64(4800000), testutils.GetAccountBalance(t, suite.app, 33333333333330))
+	})
+}
@@ -0,0 +1,59 @@
+package fx
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	fxModel "github.com/skamranahmed/go-bank/internal/fx/model"
+	"github.com/skamranahmed/go-bank/internal/fx/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type GetFXQuoteTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestGetFXQuoteTestSuite(t *testing.T) {
+	suite.Run(t, new(GetFXQuoteTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *GetFXQuoteTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/GetFXQuote_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *GetFXQuoteTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *GetFXQuoteTestSuite) TestGetFXQuote() {
+	// already used for a transfer
+	url := "/v1/fx/quotes/2c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e6f"
+
+	suite.T().Run("the user sees their quote", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", url, http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.FXQuoteResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(16000), response.Data.SourceAmount)
+		assert.Equal(t, int64(199), response.Data.TargetAmount)
+		assert.Equal(t, fxModel.FXQuoteUsed, response.Data.Status)
+		assert.NotNil(t, response.Data.UsedAt)
+	})
+
+	suite.T().Run("another user cannot see the quote", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", url, http.MethodGet, nil)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this FX quote")
+	})
+}
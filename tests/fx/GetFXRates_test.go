@@ -0,0 +1,54 @@
+package fx
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/fx/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type GetFXRatesTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestGetFXRatesTestSuite(t *testing.T) {
+	suite.Run(t, new(GetFXRatesTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *GetFXRatesTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/GetFXRates_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *GetFXRatesTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *GetFXRatesTestSuite) TestGetFXRates() {
+	suite.T().Run("a newer rate replaces the one in the fixtures", func(t *testing.T) {
+		responseRecorder := publishFXRate(t, suite.app, "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9", "USD", "INR", "83.25")
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	})
+
+	suite.T().Run("customers see the latest rate of every pair", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/fx-rates", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetFXRatesResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		rates := make(map[string]string)
+		for _, fxRate := range response.Data {
+			rates[fxRate.BaseCurrency+"/"+fxRate.QuoteCurrency] = fxRate.Rate
+		}
+		assert.Equal(t, map[string]string{"USD/INR": "83.25000000"}, rates)
+	})
+}
@@ -0,0 +1,122 @@
+package fx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	fxModel "github.com/skamranahmed/go-bank/internal/fx/model"
+	"github.com/skamranahmed/go-bank/internal/fx/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImportFXRatesTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestImportFXRatesTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportFXRatesTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *ImportFXRatesTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/ImportFXRates_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *ImportFXRatesTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *ImportFXRatesTestSuite) importFXRates(t *testing.T, userID string, content string) *httptest.ResponseRecorder {
+	payload := types.ImportFXRatesRequest{
+		Data: types.ImportFXRatesRequestData{
+			Content: content,
+		},
+	}
+	return testutils.MakeAuthenticatedRequest(t, suite.app, userID, "/v1/admin/fx-rates/import", http.MethodPost, payload)
+}
+
+func (suite *ImportFXRatesTestSuite) TestCustomerCannotImport() {
+	suite.T().Run("customer cannot import a rate file", func(t *testing.T) {
+		responseRecorder := suite.importFXRates(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "base_currency,quote_currency,rate\nEUR,INR,90.10\n")
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to perform this action")
+	})
+}
+
+func (suite *ImportFXRatesTestSuite) TestInvalidFiles() {
+	type scenario struct {
+		name       string
+		content    string
+		errMessage string
+	}
+
+	tests := []scenario{
+		{
+			name:       "file with another header",
+			content:    "from,to,rate\nEUR,INR,90.10\n",
+			errMessage: "The FX rate file could not be read: FX rate file header must be: base_currency,quote_currency,rate",
+		},
+		{
+			name:       "file with an unsupported currency",
+			content:    "base_currency,quote_currency,rate\nEUR,INR,90.10\nXYZ,INR,1.5\n",
+			errMessage: "The FX rate file could not be read: line 3: base_currency XYZ is not a supported currency",
+		},
+		{
+			name:       "file rating a pair twice",
+			content:    "base_currency,quote_currency,rate\nEUR,INR,90.10\nEUR,INR,90.20\n",
+			errMessage: "The FX rate file could not be read: line 3: EUR/INR is already rated on line 2",
+		},
+		{
+			name:       "file without rates",
+			content:    "base_currency,quote_currency,rate\n",
+			errMessage: "The FX rate file could not be read: FX rate file has no rates",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := suite.importFXRates(t, "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9", tc.content)
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, "message", tc.errMessage)
+		})
+	}
+}
+
+func (suite *ImportFXRatesTestSuite) TestImportFXRates() {
+	suite.T().Run("admin imports a rate file", func(t *testing.T) {
+		responseRecorder := suite.importFXRates(t, "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9", "base_currency,quote_currency,rate\nEUR,INR,90.10\nGBP,INR,105.5\n")
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.PublishFXRatesResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+
+		rates := make(map[string]string)
+		for _, fxRate := range response.Data {
+			assert.Equal(t, fxModel.FXRateSourceFile, fxRate.Source)
+			rates[fxRate.BaseCurrency+"/"+fxRate.QuoteCurrency] = fxRate.Rate
+		}
+		assert.Equal(t, "90.10000000", rates["EUR/INR"])
+		assert.Equal(t, "105.50000000", rates["GBP/INR"])
+
+		responseRecorder = testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/fx-rates", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var ratesResponse types.GetFXRatesResponse
+		err = json.Unmarshal(responseRecorder.Body.Bytes(), &ratesResponse)
+		assert.NoError(t, err)
+		assert.Len(t, ratesResponse.Data, 2)
+	})
+}
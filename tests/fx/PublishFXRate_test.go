@@ -0,0 +1,148 @@
+package fx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	fxModel "github.com/skamranahmed/go-bank/internal/fx/model"
+	"github.com/skamranahmed/go-bank/internal/fx/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func publishFXRate(t *testing.T, app testutils.TestApp, userID string, baseCurrency string, quoteCurrency string, rate string) *httptest.ResponseRecorder {
+	payload := types.PublishFXRateRequest{
+		Data: types.PublishFXRateRequestData{
+			BaseCurrency:  baseCurrency,
+			QuoteCurrency: quoteCurrency,
+			Rate:          rate,
+		},
+	}
+	return testutils.MakeAuthenticatedRequest(t, app, userID, "/v1/admin/fx-rates", http.MethodPost, payload)
+}
+
+type PublishFXRateTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestPublishFXRateTestSuite(t *testing.T) {
+	suite.Run(t, new(PublishFXRateTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *PublishFXRateTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/PublishFXRate_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *PublishFXRateTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *PublishFXRateTestSuite) TestCustomerCannotPublish() {
+	suite.T().Run("customer cannot publish a rate", func(t *testing.T) {
+		responseRecorder := publishFXRate(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "USD", "INR", "83.25")
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to perform this action")
+
+		count, err := suite.app.Db.NewSelect().Model((*fxModel.FXRate)(nil)).Count(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+}
+
+func (suite *PublishFXRateTestSuite) TestValidationErrors() {
+	type scenario struct {
+		name          string
+		baseCurrency  string
+		quoteCurrency string
+		rate          string
+		field         string
+		errMessage    string
+	}
+
+	tests := []scenario{
+		{
+			name:          "unsupported currency",
+			baseCurrency:  "XYZ",
+			quoteCurrency: "INR",
+			rate:          "83.25",
+			field:         "base_currency",
+			errMessage:    "base_currency is not a supported currency",
+		},
+		{
+			name:          "rate that is not a number",
+			baseCurrency:  "USD",
+			quoteCurrency: "INR",
+			rate:          "83,25",
+			field:         "message",
+			errMessage:    "rate must be a number with at most 8 decimal places",
+		},
+		{
+			name:          "rate with too many decimal places",
+			baseCurrency:  "USD",
+			quoteCurrency: "INR",
+			rate:          "83.123456789",
+			field:         "message",
+			errMessage:    "rate must be a number with at most 8 decimal places",
+		},
+		{
+			name:          "zero rate",
+			baseCurrency:  "USD",
+			quoteCurrency: "INR",
+			rate:          "0",
+			field:         "message",
+			errMessage:    "rate must be greater than zero",
+		},
+		{
+			name:          "rate of a currency to itself",
+			baseCurrency:  "USD",
+			quoteCurrency: "USD",
+			rate:          "1",
+			field:         "message",
+			errMessage:    "rate must be between two different currencies",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := publishFXRate(t, suite.app, "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9", tc.baseCurrency, tc.quoteCurrency, tc.rate)
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, tc.field, tc.errMessage)
+		})
+	}
+}
+
+func (suite *PublishFXRateTestSuite) TestPublishFXRate() {
+	suite.T().Run("admin publishes a rate", func(t *testing.T) {
+		responseRecorder := publishFXRate(t, suite.app, "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9", "USD", "INR", "83.25")
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.PublishFXRatesResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+		assert.Equal(t, "USD", response.Data[0].BaseCurrency)
+		assert.Equal(t, "INR", response.Data[0].QuoteCurrency)
+		assert.Equal(t, "83.25000000", response.Data[0].Rate)
+		assert.Equal(t, fxModel.FXRateSourceAdmin, response.Data[0].Source)
+
+		var fxRate fxModel.FXRate
+		err = suite.app.Db.NewSelect().
+			Model(&fxRate).
+			Where("id = ?", response.Data[0].ID).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9", fxRate.PublishedByUserID.String())
+		assert.Equal(t, int64(8325000000), fxRate.Rate)
+	})
+}
@@ -0,0 +1,261 @@
+package loan
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/loan/model"
+	"github.com/skamranahmed/go-bank/internal/loan/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+type ApplyForLoanTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestApplyForLoanTestSuite(t *testing.T) {
+	suite.Run(t, new(ApplyForLoanTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *ApplyForLoanTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/ApplyForLoan_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *ApplyForLoanTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *ApplyForLoanTestSuite) makeRequest(t *testing.T, userID string, url string, method string, payload any) *httptest.ResponseRecorder {
+	accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+	assert.NoError(t, err)
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+	}
+	return testutils.MakeRequest(t, suite.app, url, method, payload, headers)
+}
+
+func (suite *ApplyForLoanTestSuite) TestMissingAuthorizationHeader() {
+	suite.T().Run("missing authorization header returns 401", func(t *testing.T) {
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/loans", http.MethodPost, nil, nil)
+		assert.Equal(t, http.StatusUnauthorized, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Authorization header is missing")
+	})
+}
+
+func (suite *ApplyForLoanTestSuite) TestValidationErrors() {
+	type scenario struct {
+		name       string
+		payload    types.ApplyForLoanRequest
+		field      string
+		errMessage string
+	}
+
+	validData := func() types.ApplyForLoanRequestData {
+		return types.ApplyForLoanRequestData{
+			AccountID:      12345678901234,
+			Amount:         int64Ptr(3000000),
+			TenureInMonths: intPtr(12),
+		}
+	}
+
+	tests := []scenario{
+		{
+			name: "zero amount",
+			payload: func() types.ApplyForLoanRequest {
+				data := validData()
+				data.Amount = int64Ptr(0)
+				return types.ApplyForLoanRequest{Data: data}
+			}(),
+			field:      "amount",
+			errMessage: "amount must be greater than 0",
+		},
+		{
+			name: "tenure shorter than the minimum",
+			payload: func() types.ApplyForLoanRequest {
+				data := validData()
+				data.TenureInMonths = intPtr(2)
+				return types.ApplyForLoanRequest{Data: data}
+			}(),
+			field:      "tenure_in_months",
+			errMessage: "tenure_in_months must be greater than or equal to 3",
+		},
+		{
+			name: "tenure longer than the maximum",
+			payload: func() types.ApplyForLoanRequest {
+				data := validData()
+				data.TenureInMonths = intPtr(85)
+				return types.ApplyForLoanRequest{Data: data}
+			}(),
+			field:      "tenure_in_months",
+			errMessage: "tenure_in_months must be less than or equal to 84",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := suite.makeRequest(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/loans", http.MethodPost, tc.payload)
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, tc.field, tc.errMessage)
+		})
+	}
+}
+
+func (suite *ApplyForLoanTestSuite) TestBusinessRuleErrors() {
+	type scenario struct {
+		name       string
+		payload    types.ApplyForLoanRequest
+		statusCode int
+		errMessage string
+	}
+
+	tests := []scenario{
+		{
+			name: "amount below the minimum returns 400",
+			payload: types.ApplyForLoanRequest{
+				Data: types.ApplyForLoanRequestData{
+					AccountID:      12345678901234,
+					Amount:         int64Ptr(999999),
+					TenureInMonths: intPtr(12),
+				},
+			},
+			statusCode: http.StatusBadRequest,
+			errMessage: "Loan amount must be between 1000000 and 50000000",
+		},
+		{
+			name: "disbursement into a recurring deposit account returns 400",
+			payload: types.ApplyForLoanRequest{
+				Data: types.ApplyForLoanRequestData{
+					AccountID:      31313131313131,
+					Amount:         int64Ptr(3000000),
+					TenureInMonths: intPtr(12),
+				},
+			},
+			statusCode: http.StatusBadRequest,
+			errMessage: "Loans can only be disbursed to a savings or current account",
+		},
+		{
+			name: "disbursement into another user's account returns 403",
+			payload: types.ApplyForLoanRequest{
+				Data: types.ApplyForLoanRequestData{
+					AccountID:      11111111111111,
+					Amount:         int64Ptr(3000000),
+					TenureInMonths: intPtr(12),
+				},
+			},
+			statusCode: http.StatusForbidden,
+			errMessage: "You do not have permission to access this account",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := suite.makeRequest(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/loans", http.MethodPost, tc.payload)
+			assert.Equal(t, tc.statusCode, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, "message", tc.errMessage)
+		})
+	}
+}
+
+func (suite *ApplyForLoanTestSuite) TestSuccessfulApplication() {
+	var loanID string
+
+	suite.T().Run("application is recorded pending approval with its EMI", func(t *testing.T) {
+		payload := types.ApplyForLoanRequest{
+			Data: types.ApplyForLoanRequestData{
+				AccountID:      12345678901234,
+				Amount:         int64Ptr(3000000),
+				TenureInMonths: intPtr(3),
+			},
+		}
+
+		responseRecorder := suite.makeRequest(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/loans", http.MethodPost, payload)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.ApplyForLoanResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		loanID = response.Data.ID
+
+		assert.Equal(t, model.PendingApproval, response.Data.Status)
+		assert.Equal(t, int64(3000000), response.Data.PrincipalAmount)
+		assert.Equal(t, int64(1200), response.Data.AnnualInterestRateInBasisPoints)
+		assert.Equal(t, int64(1020067), response.Data.EmiAmount)
+		assert.Equal(t, int64(0), response.Data.OutstandingPrincipal)
+		assert.Nil(t, response.Data.DisbursedAt)
+	})
+
+	suite.T().Run("nothing is disbursed before the application is approved", func(t *testing.T) {
+		var account accountModel.Account
+		err := suite.app.Db.NewSelect().
+			Model(&account).
+			Where("id = ?", 12345678901234).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1000000), account.Balance)
+
+		responseRecorder := suite.makeRequest(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/loans/"+loanID+"/schedule", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetLoanScheduleResponse
+		err = json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Empty(t, response.Data)
+	})
+
+	suite.T().Run("application is listed for the user", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/loans", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetLoansResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+		assert.Equal(t, loanID, response.Data[0].ID)
+	})
+
+	suite.T().Run("another user cannot view the loan", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/loans/"+loanID, http.MethodGet, nil)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this loan")
+	})
+}
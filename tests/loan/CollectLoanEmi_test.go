@@ -0,0 +1,159 @@
+package loan
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	feeModel "github.com/skamranahmed/go-bank/internal/fee/model"
+	ledgerModel "github.com/skamranahmed/go-bank/internal/ledger/model"
+	"github.com/skamranahmed/go-bank/internal/loan/model"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/uptrace/bun"
+)
+
+// CollectEmi is run by the collection worker task, so it is exercised through the service instead of an endpoint
+type CollectLoanEmiTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestCollectLoanEmiTestSuite(t *testing.T) {
+	suite.Run(t, new(CollectLoanEmiTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *CollectLoanEmiTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/CollectLoanEmi_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *CollectLoanEmiTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *CollectLoanEmiTestSuite) collect(t *testing.T, installmentID string, collectionDate time.Time) *model.Installment {
+	var installment *model.Installment
+	err := database.RunInTransaction(t.Context(), "collectLoanEmi", suite.app.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		installment, err = suite.app.Services.LoanService.CollectEmi(txCtx, tx, uuid.MustParse(installmentID), collectionDate)
+		return err
+	})
+	assert.NoError(t, err)
+	return installment
+}
+
+func (suite *CollectLoanEmiTestSuite) getAccountBalance(t *testing.T, accountID int64) int64 {
+	var account accountModel.Account
+	err := suite.app.Db.NewSelect().
+		Model(&account).
+		Where("id = ?", accountID).
+		Scan(t.Context())
+	assert.NoError(t, err)
+	return account.Balance
+}
+
+func (suite *CollectLoanEmiTestSuite) getInternalAccountBalance(t *testing.T, code ledgerModel.InternalAccountCode) int64 {
+	var internalAccount ledgerModel.InternalAccount
+	err := suite.app.Db.NewSelect().
+		Model(&internalAccount).
+		Where("code = ?", code).
+		Scan(t.Context())
+	assert.NoError(t, err)
+	return internalAccount.Balance
+}
+
+func (suite *CollectLoanEmiTestSuite) TestSuccessfulCollection() {
+	suite.T().Run("EMI before its due date is not collected", func(t *testing.T) {
+		installment := suite.collect(t, "8b9c0d1e-2f3a-4b4c-9d5e-6f7a8b9c0d1e", time.Date(2025, time.April, 9, 0, 0, 0, 0, time.UTC))
+		assert.Nil(t, installment)
+		assert.Equal(t, int64(5000000), suite.getAccountBalance(t, 12345678901234))
+	})
+
+	suite.T().Run("last EMI is debited and closes the loan", func(t *testing.T) {
+		installment := suite.collect(t, "8b9c0d1e-2f3a-4b4c-9d5e-6f7a8b9c0d1e", time.Date(2025, time.April, 10, 0, 0, 0, 0, time.UTC))
+		assert.NotNil(t, installment)
+		assert.Equal(t, model.InstallmentPaid, installment.Status)
+		assert.Equal(t, 1, installment.AttemptCount)
+		assert.NotNil(t, installment.PaidAt)
+		assert.NotNil(t, installment.TransactionID)
+
+		assert.Equal(t, int64(5000000-1009965-10100), suite.getAccountBalance(t, 12345678901234))
+		assert.Equal(t, int64(1009965), suite.getInternalAccountBalance(t, ledgerModel.LoansOutstanding))
+		assert.Equal(t, int64(10100), suite.getInternalAccountBalance(t, ledgerModel.InterestIncome))
+
+		var loan model.Loan
+		err := suite.app.Db.NewSelect().
+			Model(&loan).
+			Where("id = ?", installment.LoanID).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, model.Closed, loan.Status)
+		assert.Equal(t, int64(0), loan.OutstandingPrincipal)
+		assert.NotNil(t, loan.ClosedAt)
+	})
+
+	suite.T().Run("collecting the paid EMI again does nothing", func(t *testing.T) {
+		installment := suite.collect(t, "8b9c0d1e-2f3a-4b4c-9d5e-6f7a8b9c0d1e", time.Date(2025, time.April, 11, 0, 0, 0, 0, time.UTC))
+		assert.Nil(t, installment)
+		assert.Equal(t, int64(5000000-1009965-10100), suite.getAccountBalance(t, 12345678901234))
+	})
+}
+
+func (suite *CollectLoanEmiTestSuite) TestInsufficientFunds() {
+	suite.T().Run("failed attempt within the grace period keeps the EMI pending without a late fee", func(t *testing.T) {
+		installment := suite.collect(t, "9c0d1e2f-3a4b-4c5d-8e6f-7a8b9c0d1e2f", time.Date(2025, time.February, 10, 0, 0, 0, 0, time.UTC))
+		assert.NotNil(t, installment)
+		assert.Equal(t, model.InstallmentPending, installment.Status)
+		assert.Equal(t, 1, installment.AttemptCount)
+		assert.NotNil(t, installment.LastAttemptedAt)
+		assert.Nil(t, installment.LateFeeChargedAt)
+		assert.Equal(t, int64(50000), suite.getAccountBalance(t, 11111111111111))
+	})
+
+	suite.T().Run("failed attempt at the end of the grace period charges the late fee", func(t *testing.T) {
+		installment := suite.collect(t, "9c0d1e2f-3a4b-4c5d-8e6f-7a8b9c0d1e2f", time.Date(2025, time.February, 13, 0, 0, 0, 0, time.UTC))
+		assert.NotNil(t, installment)
+		assert.Equal(t, model.InstallmentPending, installment.Status)
+		assert.Equal(t, 2, installment.AttemptCount)
+		assert.NotNil(t, installment.LateFeeChargedAt)
+
+		// INR 500 + 18% tax is more than the balance, so only the balance is charged
+		assert.Equal(t, int64(0), suite.getAccountBalance(t, 11111111111111))
+	})
+
+	suite.T().Run("later attempts do not charge the late fee again", func(t *testing.T) {
+		installment := suite.collect(t, "9c0d1e2f-3a4b-4c5d-8e6f-7a8b9c0d1e2f", time.Date(2025, time.February, 14, 0, 0, 0, 0, time.UTC))
+		assert.NotNil(t, installment)
+		assert.Equal(t, model.InstallmentPending, installment.Status)
+		assert.Equal(t, 3, installment.AttemptCount)
+
+		count, err := suite.app.Db.NewSelect().
+			Model((*feeModel.FeeCharge)(nil)).
+			Where("account_id = ?", 11111111111111).
+			Where("event = ?", feeModel.LoanLatePayment).
+			Count(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+}
@@ -0,0 +1,107 @@
+package loan
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/loan/model"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/uptrace/bun"
+)
+
+// foreclosure depends on the date relative to the schedule, so it is exercised through the service with fixed dates
+type ForecloseLoanTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestForecloseLoanTestSuite(t *testing.T) {
+	suite.Run(t, new(ForecloseLoanTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *ForecloseLoanTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/ForecloseLoan_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *ForecloseLoanTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *ForecloseLoanTestSuite) foreclose(t *testing.T, loanID string, foreclosureDate time.Time) (*model.Loan, error) {
+	var loan *model.Loan
+	err := database.RunInTransaction(t.Context(), "forecloseLoan", suite.app.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		loan, err = suite.app.Services.LoanService.ForecloseLoan(txCtx, tx, uuid.MustParse(loanID), foreclosureDate)
+		return err
+	})
+	return loan, err
+}
+
+func (suite *ForecloseLoanTestSuite) getAccountBalance(t *testing.T, accountID int64) int64 {
+	var account accountModel.Account
+	err := suite.app.Db.NewSelect().
+		Model(&account).
+		Where("id = ?", accountID).
+		Scan(t.Context())
+	assert.NoError(t, err)
+	return account.Balance
+}
+
+func (suite *ForecloseLoanTestSuite) TestInsufficientFunds() {
+	suite.T().Run("foreclosure is rejected when the account cannot cover it", func(t *testing.T) {
+		_, err := suite.foreclose(t, "5c6d7e8f-9a0b-4c1d-8e2f-3a4b5c6d7e8f", time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "You do not have sufficient balance in your account to repay 2023149")
+		assert.Equal(t, int64(1000000), suite.getAccountBalance(t, 11111111111111))
+	})
+}
+
+func (suite *ForecloseLoanTestSuite) TestSuccessfulForeclosure() {
+	suite.T().Run("foreclosure repays the outstanding principal with the interest accrued since the last EMI", func(t *testing.T) {
+		loan, err := suite.foreclose(t, "4b5c6d7e-8f9a-4b0c-9d1e-2f3a4b5c6d7e", time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		assert.Equal(t, model.Closed, loan.Status)
+		assert.Equal(t, int64(0), loan.OutstandingPrincipal)
+		assert.NotNil(t, loan.ClosedAt)
+
+		// 12% a year on 2009933 for the 20 days since 10 Feb is 13216
+		assert.Equal(t, int64(3000000-2009933-13216), suite.getAccountBalance(t, 12345678901234))
+
+		count, err := suite.app.Db.NewSelect().
+			Model((*model.Installment)(nil)).
+			Where("loan_id = ?", loan.ID).
+			Where("status = ?", model.InstallmentCancelled).
+			Count(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	suite.T().Run("closed loan cannot be foreclosed again", func(t *testing.T) {
+		_, err := suite.foreclose(t, "4b5c6d7e-8f9a-4b0c-9d1e-2f3a4b5c6d7e", time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Loan is not active")
+	})
+}
@@ -0,0 +1,113 @@
+package loan
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/loan/model"
+	"github.com/skamranahmed/go-bank/pkg/database"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/uptrace/bun"
+)
+
+// prepayment depends on the date relative to the schedule, so it is exercised through the service with fixed dates
+type PrepayLoanTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestPrepayLoanTestSuite(t *testing.T) {
+	suite.Run(t, new(PrepayLoanTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *PrepayLoanTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/PrepayLoan_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *PrepayLoanTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *PrepayLoanTestSuite) prepay(t *testing.T, amount int64, prepaymentDate time.Time) (*model.Loan, error) {
+	var loan *model.Loan
+	err := database.RunInTransaction(t.Context(), "prepayLoan", suite.app.Db, nil, func(txCtx context.Context, tx bun.Tx) error {
+		var err error
+		loan, err = suite.app.Services.LoanService.PrepayLoan(txCtx, tx, uuid.MustParse("0d1e2f3a-4b5c-4d6e-9f7a-8b9c0d1e2f3a"), amount, prepaymentDate)
+		return err
+	})
+	return loan, err
+}
+
+func (suite *PrepayLoanTestSuite) TestBusinessRuleErrors() {
+	suite.T().Run("prepaying the whole outstanding principal is rejected", func(t *testing.T) {
+		_, err := suite.prepay(t, 2009933, time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Prepayment amount must be less than the outstanding principal of 2009933, foreclose the loan to repay it in full")
+	})
+
+	suite.T().Run("prepaying with an overdue EMI is rejected", func(t *testing.T) {
+		_, err := suite.prepay(t, 1000000, time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Overdue EMIs must be paid before the loan can be prepaid")
+	})
+}
+
+func (suite *PrepayLoanTestSuite) TestSuccessfulPrepayment() {
+	suite.T().Run("prepayment reduces the EMI over the remaining tenure", func(t *testing.T) {
+		loan, err := suite.prepay(t, 1000000, time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		assert.Equal(t, model.Active, loan.Status)
+		assert.Equal(t, int64(2009933-1000000), loan.OutstandingPrincipal)
+		assert.Equal(t, int64(512554), loan.EmiAmount)
+
+		var account accountModel.Account
+		err = suite.app.Db.NewSelect().
+			Model(&account).
+			Where("id = ?", 12345678901234).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5000000-1000000), account.Balance)
+
+		var installments []model.Installment
+		err = suite.app.Db.NewSelect().
+			Model(&installments).
+			Where("loan_id = ?", loan.ID).
+			Order("installment_number ASC").
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Len(t, installments, 3)
+
+		// the paid EMI is left untouched
+		assert.Equal(t, model.InstallmentPaid, installments[0].Status)
+		assert.Equal(t, int64(990067), installments[0].PrincipalComponent)
+
+		assert.Equal(t, int64(1009933), installments[1].OpeningPrincipal)
+		assert.Equal(t, int64(502455), installments[1].PrincipalComponent)
+		assert.Equal(t, int64(10099), installments[1].InterestComponent)
+		assert.Equal(t, int64(507478), installments[2].OpeningPrincipal)
+		assert.Equal(t, int64(507478), installments[2].PrincipalComponent)
+		assert.Equal(t, int64(5075), installments[2].InterestComponent)
+	})
+}
@@ -0,0 +1,200 @@
+package loan
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	ledgerModel "github.com/skamranahmed/go-bank/internal/ledger/model"
+	"github.com/skamranahmed/go-bank/internal/loan/model"
+	"github.com/skamranahmed/go-bank/internal/loan/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReviewLoanTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestReviewLoanTestSuite(t *testing.T) {
+	suite.Run(t, new(ReviewLoanTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *ReviewLoanTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/ReviewLoan_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *ReviewLoanTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *ReviewLoanTestSuite) makeRequest(t *testing.T, userID string, url string, method string, payload any) *httptest.ResponseRecorder {
+	accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+	assert.NoError(t, err)
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+	}
+	return testutils.MakeRequest(t, suite.app, url, method, payload, headers)
+}
+
+func (suite *ReviewLoanTestSuite) TestAuthorization() {
+	suite.T().Run("customer cannot approve a loan", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/admin/loans/1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d/approve", http.MethodPost, nil)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to perform this action")
+	})
+
+	suite.T().Run("invalid loan ID returns 400", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9", "/v1/admin/loans/not-a-uuid/approve", http.MethodPost, nil)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Invalid loan ID")
+	})
+
+	suite.T().Run("invalid status filter returns 400", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9", "/v1/admin/loans?status=DEFAULTED", http.MethodGet, nil)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "status", "status must be one of: PENDING_APPROVAL, REJECTED, ACTIVE, CLOSED")
+	})
+}
+
+func (suite *ReviewLoanTestSuite) TestApproval() {
+	suite.T().Run("approval disburses the principal into the customer's account", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9", "/v1/admin/loans/1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d/approve", http.MethodPost, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.ReviewLoanResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, model.Active, response.Data.Status)
+		assert.Equal(t, int64(3000000), response.Data.OutstandingPrincipal)
+		assert.NotNil(t, response.Data.ReviewedAt)
+		assert.NotNil(t, response.Data.DisbursedAt)
+
+		var account accountModel.Account
+		err = suite.app.Db.NewSelect().
+			Model(&account).
+			Where("id = ?", 12345678901234).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100000+3000000), account.Balance)
+
+		var disbursements []accountModel.Transaction
+		err = suite.app.Db.NewSelect().
+			Model(&disbursements).
+			Where("account_id = ?", 12345678901234).
+			Where("type = ?", accountModel.LoanDisbursement).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Len(t, disbursements, 1)
+		assert.Equal(t, int64(3000000), disbursements[0].Amount)
+
+		var loansOutstanding ledgerModel.InternalAccount
+		err = suite.app.Db.NewSelect().
+			Model(&loansOutstanding).
+			Where("code = ?", ledgerModel.LoansOutstanding).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(-3000000), loansOutstanding.Balance)
+	})
+
+	suite.T().Run("customer can view the amortization schedule of the approved loan", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/loans/1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d/schedule", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetLoanScheduleResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 3)
+
+		// reducing balance at 1% a month: the interest falls and the principal rises with every EMI
+		assert.Equal(t, model.FirstEmiDueDate(time.Now().UTC()).Format(time.DateOnly), response.Data[0].DueDate)
+		assert.Equal(t, int64(990067), response.Data[0].PrincipalComponent)
+		assert.Equal(t, int64(30000), response.Data[0].InterestComponent)
+		assert.Equal(t, int64(999968), response.Data[1].PrincipalComponent)
+		assert.Equal(t, int64(20099), response.Data[1].InterestComponent)
+		assert.Equal(t, int64(1009965), response.Data[2].PrincipalComponent)
+		assert.Equal(t, int64(10100), response.Data[2].InterestComponent)
+		for _, installment := range response.Data {
+			assert.Equal(t, model.InstallmentPending, installment.Status)
+		}
+	})
+
+	suite.T().Run("approved loan cannot be reviewed again", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9", "/v1/admin/loans/1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d/approve", http.MethodPost, nil)
+		assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Loan application has already been reviewed")
+	})
+}
+
+func (suite *ReviewLoanTestSuite) TestRejection() {
+	suite.T().Run("missing reason returns 400", func(t *testing.T) {
+		payload := types.RejectLoanRequest{}
+
+		responseRecorder := suite.makeRequest(t, "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9", "/v1/admin/loans/2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e/reject", http.MethodPost, payload)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "reason", "reason is a required field")
+	})
+
+	suite.T().Run("rejection records the reason without disbursing", func(t *testing.T) {
+		payload := types.RejectLoanRequest{
+			Data: types.RejectLoanRequestData{
+				Reason: "Income documents could not be verified",
+			},
+		}
+
+		responseRecorder := suite.makeRequest(t, "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9", "/v1/admin/loans/2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e/reject", http.MethodPost, payload)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.ReviewLoanResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, model.Rejected, response.Data.Status)
+		assert.Equal(t, "Income documents could not be verified", *response.Data.RejectionReason)
+		assert.Nil(t, response.Data.DisbursedAt)
+		assert.Equal(t, int64(0), response.Data.OutstandingPrincipal)
+	})
+
+	suite.T().Run("rejected loans can be listed by status", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9", "/v1/admin/loans?status=REJECTED", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetLoansResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+		assert.Equal(t, "2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e", response.Data[0].ID)
+	})
+}
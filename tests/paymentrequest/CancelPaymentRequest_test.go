@@ -0,0 +1,77 @@
+package paymentrequest
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/paymentrequest/model"
+	"github.com/skamranahmed/go-bank/internal/paymentrequest/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type CancelPaymentRequestTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestCancelPaymentRequestTestSuite(t *testing.T) {
+	suite.Run(t, new(CancelPaymentRequestTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *CancelPaymentRequestTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/CancelPaymentRequest_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *CancelPaymentRequestTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *CancelPaymentRequestTestSuite) TestCancelPaymentRequest() {
+	// requested by user 1
+	url := "/v1/payment-requests/8c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e02/cancel"
+
+	suite.T().Run("another user cannot cancel the request", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", url, http.MethodPost, nil)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this payment request")
+	})
+
+	suite.T().Run("the requester cancels the request", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", url, http.MethodPost, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.PaymentRequestResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, model.PaymentRequestCancelled, response.Data.Status)
+		assert.NotNil(t, response.Data.ClosedAt)
+	})
+
+	suite.T().Run("a cancelled request cannot be paid", func(t *testing.T) {
+		payerBalanceBefore := testutils.GetAccountBalance(t, suite.app, 11111111111110)
+		requesterBalanceBefore := testutils.GetAccountBalance(t, suite.app, 12345678901237)
+
+		payload := types.PayPaymentRequestRequest{
+			Data: types.PayPaymentRequestRequestData{
+				FromAccountID: 11111111111110,
+			},
+		}
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/payment-links/pendingtoken0000000001/pay", http.MethodPost, payload)
+		assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Payment request has already been cancelled")
+
+		assert.Equal(t, payerBalanceBefore, testutils.GetAccountBalance(t, suite.app, 11111111111110))
+		assert.Equal(t, requesterBalanceBefore, testutils.GetAccountBalance(t, suite.app, 12345678901237))
+	})
+}
@@ -0,0 +1,104 @@
+package paymentrequest
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/skamranahmed/go-bank/internal/paymentrequest/model"
+	"github.com/skamranahmed/go-bank/internal/paymentrequest/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+type CreatePaymentRequestTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestCreatePaymentRequestTestSuite(t *testing.T) {
+	suite.Run(t, new(CreatePaymentRequestTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *CreatePaymentRequestTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/CreatePaymentRequest_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *CreatePaymentRequestTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *CreatePaymentRequestTestSuite) TestRejections() {
+	suite.T().Run("another user's account returns 403", func(t *testing.T) {
+		payload := types.CreatePaymentRequestRequest{
+			Data: types.CreatePaymentRequestRequestData{
+				AccountID: 11111111111110,
+				Amount:    int64Ptr(1000),
+			},
+		}
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/payment-requests", http.MethodPost, payload)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You can only request money into your own account")
+	})
+
+	suite.T().Run("an expiry beyond the maximum returns 400", func(t *testing.T) {
+		expiresInHours := 1000
+		payload := types.CreatePaymentRequestRequest{
+			Data: types.CreatePaymentRequestRequestData{
+				AccountID:      12345678901237,
+				Amount:         int64Ptr(1000),
+				ExpiresInHours: &expiresInHours,
+			},
+		}
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/payment-requests", http.MethodPost, payload)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "A payment request can expire at most 720 hours from now")
+	})
+}
+
+func (suite *CreatePaymentRequestTestSuite) TestCreatePaymentRequest() {
+	suite.T().Run("the request is created with a shareable link", func(t *testing.T) {
+		payload := types.CreatePaymentRequestRequest{
+			Data: types.CreatePaymentRequestRequestData{
+				AccountID: 12345678901237,
+				Amount:    int64Ptr(5000),
+				Note:      "Concert tickets",
+			},
+		}
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/payment-requests", http.MethodPost, payload)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.PaymentRequestResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		paymentRequest := response.Data
+		assert.NotEmpty(t, paymentRequest.Token)
+		assert.Equal(t, "http://localhost:8080/v1/payment-links/"+paymentRequest.Token, paymentRequest.Link)
+		assert.Equal(t, model.PaymentRequestPending, paymentRequest.Status)
+		assert.True(t, paymentRequest.ExpiresAt.After(time.Now().Add(167*time.Hour)))
+
+		var storedPaymentRequest model.PaymentRequest
+		err = suite.app.Db.NewSelect().
+			Model(&storedPaymentRequest).
+			Where("token = ?", paymentRequest.Token).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12345678901237), storedPaymentRequest.AccountID)
+		assert.Equal(t, int64(5000), storedPaymentRequest.Amount)
+		assert.Equal(t, int64(0), storedPaymentRequest.PaidAmount)
+	})
+}
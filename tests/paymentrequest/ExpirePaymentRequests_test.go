@@ -0,0 +1,55 @@
+package paymentrequest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skamranahmed/go-bank/internal/paymentrequest/model"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExpirePaymentRequestsTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestExpirePaymentRequestsTestSuite(t *testing.T) {
+	suite.Run(t, new(ExpirePaymentRequestsTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *ExpirePaymentRequestsTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/ExpirePaymentRequests_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *ExpirePaymentRequestsTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *ExpirePaymentRequestsTestSuite) TestExpirePaymentRequests() {
+	suite.T().Run("only requests not fully paid in time are expired", func(t *testing.T) {
+		paymentRequests, err := suite.app.Services.PaymentRequestService.ExpirePaymentRequests(t.Context(), nil, time.Now().UTC())
+		assert.NoError(t, err)
+
+		ids := make([]string, 0, len(paymentRequests))
+		for _, paymentRequest := range paymentRequests {
+			assert.Equal(t, model.PaymentRequestExpired, paymentRequest.Status)
+			assert.NotNil(t, paymentRequest.ClosedAt)
+			ids = append(ids, paymentRequest.ID.String())
+		}
+		assert.Equal(t, []string{"8c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e01"}, ids)
+
+		var openPaymentRequest model.PaymentRequest
+		err = suite.app.Db.NewSelect().
+			Model(&openPaymentRequest).
+			Where("id = ?", "8c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e02").
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, model.PaymentRequestPending, openPaymentRequest.Status)
+		assert.Nil(t, openPaymentRequest.ClosedAt)
+	})
+}
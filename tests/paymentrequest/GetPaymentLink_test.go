@@ -0,0 +1,47 @@
+package paymentrequest
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/paymentrequest/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type GetPaymentLinkTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestGetPaymentLinkTestSuite(t *testing.T) {
+	suite.Run(t, new(GetPaymentLinkTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *GetPaymentLinkTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/GetPaymentLink_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *GetPaymentLinkTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *GetPaymentLinkTestSuite) TestGetPaymentLink() {
+	suite.T().Run("another user views the link", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/payment-links/pendingtoken0000000001", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetPaymentLinkResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "K***** A****", response.Data.RequesterName)
+		assert.Equal(t, int64(5000), response.Data.RemainingAmount)
+		assert.Equal(t, "Concert tickets", *response.Data.Note)
+	})
+}
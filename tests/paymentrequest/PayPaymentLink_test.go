@@ -0,0 +1,132 @@
+package paymentrequest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/paymentrequest/model"
+	"github.com/skamranahmed/go-bank/internal/paymentrequest/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type PayPaymentLinkTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestPayPaymentLinkTestSuite(t *testing.T) {
+	suite.Run(t, new(PayPaymentLinkTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *PayPaymentLinkTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/PayPaymentLink_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *PayPaymentLinkTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *PayPaymentLinkTestSuite) pay(t *testing.T, userID string, token string, fromAccountID int64, amount *int64) *httptest.ResponseRecorder {
+	payload := types.PayPaymentRequestRequest{
+		Data: types.PayPaymentRequestRequestData{
+			FromAccountID: fromAccountID,
+			Amount:        amount,
+		},
+	}
+	return testutils.MakeAuthenticatedRequest(t, suite.app, userID, "/v1/payment-links/"+token+"/pay", http.MethodPost, payload)
+}
+
+func (suite *PayPaymentLinkTestSuite) TestPayPaymentLink() {
+	// requested by user 1 for 5000
+	token := "pendingtoken0000000001"
+
+	suite.T().Run("the requester cannot pay their own request", func(t *testing.T) {
+		responseRecorder := suite.pay(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", token, 12345678901237, nil)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You cannot pay your own payment request")
+	})
+
+	suite.T().Run("a part of the amount can be paid", func(t *testing.T) {
+		requesterBalanceBefore := testutils.GetAccountBalance(t, suite.app, 12345678901237)
+		payerBalanceBefore := testutils.GetAccountBalance(t, suite.app, 11111111111110)
+
+		responseRecorder := suite.pay(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", token, 11111111111110, int64Ptr(2000))
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.PayPaymentRequestResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, model.PaymentRequestPartiallyPaid, response.Data.PaymentRequest.Status)
+		assert.Equal(t, int64(3000), response.Data.PaymentRequest.RemainingAmount)
+		assert.Equal(t, int64(11111111111110), response.Data.Transaction.AccountID)
+		assert.Equal(t, requesterBalanceBefore+2000, testutils.GetAccountBalance(t, suite.app, 12345678901237))
+		assert.Equal(t, payerBalanceBefore-2000, testutils.GetAccountBalance(t, suite.app, 11111111111110))
+	})
+
+	suite.T().Run("paying more than the remaining amount returns 400", func(t *testing.T) {
+		responseRecorder := suite.pay(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", token, 11111111111110, int64Ptr(4000))
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Amount cannot exceed the remaining amount of 3000")
+	})
+
+	suite.T().Run("paying without an amount pays the rest", func(t *testing.T) {
+		payerBalanceBefore := testutils.GetAccountBalance(t, suite.app, 22222222222220)
+
+		responseRecorder := suite.pay(t, "c3d4e5f6-a7b8-6c7d-0e1f-2a3b4c5d6e7f", token, 22222222222220, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.PayPaymentRequestResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, model.PaymentRequestPaid, response.Data.PaymentRequest.Status)
+		assert.Equal(t, int64(0), response.Data.PaymentRequest.RemainingAmount)
+		assert.Equal(t, payerBalanceBefore-3000, testutils.GetAccountBalance(t, suite.app, 22222222222220))
+
+		var payments []model.PaymentRequestPayment
+		err = suite.app.Db.NewSelect().
+			Model(&payments).
+			Where("payment_request_id = ?", "8c3d4e5f-6a7b-4c8d-9e0f-1a2b3c4d5e02").
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Len(t, payments, 2)
+	})
+
+	suite.T().Run("a paid request cannot be paid again", func(t *testing.T) {
+		responseRecorder := suite.pay(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", token, 11111111111110, int64Ptr(100))
+		assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Payment request has already been paid")
+	})
+}
+
+func (suite *PayPaymentLinkTestSuite) TestExpiredPaymentLink() {
+	suite.T().Run("a request past its expiry cannot be paid", func(t *testing.T) {
+		responseRecorder := suite.pay(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "expiredtoken0000000001", 11111111111110, nil)
+		assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Payment request has expired")
+
+		var paymentRequest model.PaymentRequest
+		err := suite.app.Db.NewSelect().
+			Model(&paymentRequest).
+			Where("token = ?", "expiredtoken0000000001").
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1000), paymentRequest.PaidAmount)
+	})
+}
@@ -0,0 +1,386 @@
+package transfer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	transferTasks "github.com/skamranahmed/go-bank/internal/transfer/tasks"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/mock"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	bulkTransferUserID      = "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+	bulkTransferOtherUserID = "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e"
+	bulkTransferFileHeader  = "to_account,amount,narration"
+)
+
+type BulkTransferTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestBulkTransferTestSuite(t *testing.T) {
+	suite.Run(t, new(BulkTransferTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *BulkTransferTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/BulkTransfer_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *BulkTransferTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *BulkTransferTestSuite) makeRequest(t *testing.T, app testutils.TestApp, userID string, url string, method string, payload any) *httptest.ResponseRecorder {
+	accessToken, err := app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+	assert.NoError(t, err)
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+	}
+	return testutils.MakeRequest(t, app, url, method, payload, headers)
+}
+
+func (suite *BulkTransferTestSuite) uploadBulkTransfer(t *testing.T, userID string, fromAccountID int64, stopOnInsufficientFunds bool, rows ...string) *httptest.ResponseRecorder {
+	payload := types.UploadBulkTransferRequest{
+		Data: types.UploadBulkTransferRequestData{
+			FromAccountID:           fromAccountID,
+			FileName:                "payroll.csv",
+			Content:                 strings.Join(append([]string{bulkTransferFileHeader}, rows...), "\n"),
+			StopOnInsufficientFunds: stopOnInsufficientFunds,
+		},
+	}
+	return suite.makeRequest(t, suite.app, userID, "/v1/transfers/bulk", http.MethodPost, payload)
+}
+
+func (suite *BulkTransferTestSuite) uploadValidBulkTransfer(t *testing.T, fromAccountID int64, stopOnInsufficientFunds bool, rows ...string) types.BulkTransferDto {
+	responseRecorder := suite.uploadBulkTransfer(t, bulkTransferUserID, fromAccountID, stopOnInsufficientFunds, rows...)
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+	var response types.BulkTransferResponse
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, model.BulkTransferValidated, response.Data.Status)
+	return response.Data
+}
+
+// confirmBulkTransfer confirms the bulk transfer, verifying that its execution is enqueued
+func (suite *BulkTransferTestSuite) confirmBulkTransfer(t *testing.T, bulkTransferID string) types.BulkTransferDto {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockTaskEnqueuer := mock.NewMockTaskEnqueuer(mockController)
+	mockTaskEnqueuer.EXPECT().
+		Enqueue(gomock.Any(), gomock.Any(), nil, nil).
+		DoAndReturn(func(ctx context.Context, task tasksHelper.Task, maxRetryCount *int, queueName *string) error {
+			assert.Equal(t, transferTasks.ExecuteBulkTransferTaskName, task.Name())
+			return nil
+		}).
+		Times(1)
+
+	appWithMock := testutils.NewTestApp(
+		suite.T().Context(),
+		&testutils.TestAppDeps{
+			Db:           suite.app.Db,     // reuse the db from the app
+			Cache:        suite.app.Cache,  // reuse the cache from the app
+			TaskEnqueuer: mockTaskEnqueuer, // inject mock TaskEnqueuer to verify task enqueuing
+		},
+		nil,
+		nil,
+	)
+
+	responseRecorder := suite.makeRequest(t, appWithMock, bulkTransferUserID, "/v1/transfers/bulk/"+bulkTransferID+"/confirm", http.MethodPost, nil)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var response types.BulkTransferResponse
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	return response.Data
+}
+
+// executeBulkTransfer runs the steps of the execute bulk transfer task against the services
+func (suite *BulkTransferTestSuite) executeBulkTransfer(t *testing.T, bulkTransferID string) *model.BulkTransfer {
+	ctx := t.Context()
+	transferService := suite.app.Services.TransferService
+	id := uuid.MustParse(bulkTransferID)
+
+	bulkTransfer, err := transferService.StartBulkTransfer(ctx, nil, id)
+	assert.NoError(t, err)
+	assert.Equal(t, model.BulkTransferProcessing, bulkTransfer.Status)
+
+	pendingStatus := model.BulkTransferItemPending
+	items, err := transferService.ListBulkTransferItems(ctx, nil, types.BulkTransferItemListOptions{
+		BulkTransferID: &id,
+		Status:         &pendingStatus,
+	})
+	assert.NoError(t, err)
+
+	for _, item := range items {
+		_, err = transferService.ExecuteBulkTransferItem(ctx, nil, item.ID, time.Now().UTC())
+		assert.NoError(t, err)
+	}
+
+	bulkTransfer, err = transferService.CompleteBulkTransfer(ctx, nil, id, time.Now().UTC())
+	assert.NoError(t, err)
+	return bulkTransfer
+}
+
+func (suite *BulkTransferTestSuite) getBulkTransfer(t *testing.T, bulkTransferID string) types.BulkTransferDto {
+	responseRecorder := suite.makeRequest(t, suite.app, bulkTransferUserID, "/v1/transfers/bulk/"+bulkTransferID, http.MethodGet, nil)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var response types.BulkTransferResponse
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	return response.Data
+}
+
+func (suite *BulkTransferTestSuite) TestUploadBulkTransfer() {
+	suite.T().Run("bulk transfer cannot be paid from a savings account", func(t *testing.T) {
+		responseRecorder := suite.uploadBulkTransfer(t, bulkTransferUserID, 45454545454544, false, "11111111111110,1000,salary")
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Bulk transfers can only be made from a current account")
+	})
+
+	suite.T().Run("bulk transfer cannot be paid from an account of another user", func(t *testing.T) {
+		responseRecorder := suite.uploadBulkTransfer(t, bulkTransferOtherUserID, 12345678901237, false, "11111111111110,1000,salary")
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You are not authorized to perform transfer from this account")
+	})
+
+	suite.T().Run("file with an unexpected header is rejected", func(t *testing.T) {
+		payload := types.UploadBulkTransferRequest{
+			Data: types.UploadBulkTransferRequestData{
+				FromAccountID: 12345678901237,
+				FileName:      "payroll.csv",
+				Content:       "account,amount\n11111111111110,1000",
+			},
+		}
+		responseRecorder := suite.makeRequest(t, suite.app, bulkTransferUserID, "/v1/transfers/bulk", http.MethodPost, payload)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(
+			t, response, "message",
+			"The bulk transfer file could not be read: bulk transfer file header must be: to_account,amount,narration",
+		)
+	})
+
+	suite.T().Run("every invalid row is reported and the batch cannot be confirmed", func(t *testing.T) {
+		responseRecorder := suite.uploadBulkTransfer(
+			t, bulkTransferUserID, 12345678901237, false,
+			"11111111111110,1000,salary",
+			"1000000000000000,1000,bad check digit",
+			"44444444444440,1000,unknown account",
+			"22222222222220,0,nothing",
+			"12345678901237,1000,to itself",
+		)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.BulkTransferResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, model.BulkTransferInvalid, response.Data.Status)
+		assert.Equal(t, 5, response.Data.ItemCount)
+		assert.Len(t, response.Data.Items, 5)
+
+		expectedReasons := map[int]string{
+			3: "to_account is not a valid account number",
+			4: "to_account does not exist",
+			5: "amount must be a positive number of paise",
+			6: "to_account cannot be the account the bulk transfer is paid from",
+		}
+		for _, item := range response.Data.Items {
+			expectedReason, isInvalid := expectedReasons[item.LineNumber]
+			if !isInvalid {
+				assert.Equal(t, model.BulkTransferItemPending, item.Status)
+				assert.Nil(t, item.Reason)
+				continue
+			}
+
+			assert.Equal(t, model.BulkTransferItemInvalid, item.Status)
+			if assert.NotNil(t, item.Reason) {
+				assert.Equal(t, expectedReason, *item.Reason)
+			}
+		}
+
+		responseRecorder = suite.makeRequest(t, suite.app, bulkTransferUserID, "/v1/transfers/bulk/"+response.Data.ID+"/confirm", http.MethodPost, nil)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		errorResponse := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, errorResponse, "message", "This bulk transfer has invalid rows, correct them and upload the file again")
+	})
+
+	suite.T().Run("nothing is debited until the batch is confirmed", func(t *testing.T) {
+		bulkTransfer := suite.uploadValidBulkTransfer(t, 12345678901237, false, "11111111111110,1000,salary", "22222222222220,2500,")
+		assert.Equal(t, 2, bulkTransfer.ItemCount)
+		assert.Equal(t, int64(3500), bulkTransfer.TotalAmount)
+		assert.Equal(t, int64(0), bulkTransfer.EstimatedFee)
+		assert.Nil(t, bulkTransfer.ConfirmedAt)
+
+		assert.Equal(t, int64(100000), getAccountBalance(t, suite.app, 12345678901237))
+
+		// the batch is cancelled, so that it leaves no trace on the account
+		responseRecorder := suite.makeRequest(t, suite.app, bulkTransferUserID, "/v1/transfers/bulk/"+bulkTransfer.ID+"/cancel", http.MethodPost, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	})
+}
+
+func (suite *BulkTransferTestSuite) TestExecuteBulkTransfer() {
+	suite.T().Run("row the account cannot afford fails and the batch moves on", func(t *testing.T) {
+		var fromAccountID int64 = 12345678901237
+		balanceBefore := getAccountBalance(t, suite.app, fromAccountID)
+
+		uploaded := suite.uploadValidBulkTransfer(
+			t, fromAccountID, false,
+			"11111111111110,60000,salary",
+			"22222222222220,50000,bonus",
+			"22222222222220,30000,salary",
+		)
+
+		confirmed := suite.confirmBulkTransfer(t, uploaded.ID)
+		assert.Equal(t, model.BulkTransferQueued, confirmed.Status)
+		assert.NotNil(t, confirmed.ConfirmedAt)
+
+		bulkTransfer := suite.executeBulkTransfer(t, uploaded.ID)
+		assert.Equal(t, model.BulkTransferPartiallyCompleted, bulkTransfer.Status)
+		assert.Equal(t, 2, bulkTransfer.SucceededCount)
+		assert.Equal(t, 1, bulkTransfer.FailedCount)
+		assert.Equal(t, 0, bulkTransfer.SkippedCount)
+		assert.NotNil(t, bulkTransfer.CompletedAt)
+
+		assert.Equal(t, balanceBefore-90000, getAccountBalance(t, suite.app, fromAccountID))
+
+		details := suite.getBulkTransfer(t, uploaded.ID)
+		assert.Len(t, details.Items, 3)
+		assert.Equal(t, model.BulkTransferItemSucceeded, details.Items[0].Status)
+		assert.NotNil(t, details.Items[0].TransactionID)
+		assert.Equal(t, model.BulkTransferItemFailed, details.Items[1].Status)
+		if assert.NotNil(t, details.Items[1].Reason) {
+			assert.Equal(t, "Insufficient balance to transfer 50000 and pay its fee of 0", *details.Items[1].Reason)
+		}
+		assert.Equal(t, model.BulkTransferItemSucceeded, details.Items[2].Status)
+
+		// the result file holds the outcome of every row
+		responseRecorder := suite.makeRequest(t, suite.app, bulkTransferUserID, "/v1/transfers/bulk/"+uploaded.ID+"/result", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var resultResponse types.GetBulkTransferResultResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &resultResponse)
+		assert.NoError(t, err)
+		assert.Equal(t, "payroll.result.csv", resultResponse.Data.FileName)
+
+		lines := strings.Split(strings.TrimSpace(resultResponse.Data.Content), "\n")
+		assert.Len(t, lines, 4)
+		assert.Equal(t, "line_number,to_account,amount,narration,status,transaction_id,reason", lines[0])
+		assert.Equal(t, "2,11111111111110,60000,salary,SUCCEEDED,"+*details.Items[0].TransactionID+",", lines[1])
+		assert.Equal(t, "3,22222222222220,50000,bonus,FAILED,,Insufficient balance to transfer 50000 and pay its fee of 0", lines[2])
+	})
+
+	suite.T().Run("batch stops at the first row the account cannot afford", func(t *testing.T) {
+		var fromAccountID int64 = 33333333333330
+
+		uploaded := suite.uploadValidBulkTransfer(
+			t, fromAccountID, true,
+			"11111111111110,20000,salary",
+			"22222222222220,20000,salary",
+			"22222222222220,5000,salary",
+		)
+		suite.confirmBulkTransfer(t, uploaded.ID)
+
+		bulkTransfer := suite.executeBulkTransfer(t, uploaded.ID)
+		assert.Equal(t, model.BulkTransferStopped, bulkTransfer.Status)
+		assert.Equal(t, 1, bulkTransfer.SucceededCount)
+		assert.Equal(t, 1, bulkTransfer.FailedCount)
+		assert.Equal(t, 1, bulkTransfer.SkippedCount)
+		if assert.NotNil(t, bulkTransfer.StoppedAtLineNumber) {
+			assert.Equal(t, 3, *bulkTransfer.StoppedAtLineNumber)
+		}
+
+		// the last row is affordable, but it is never attempted
+		assert.Equal(t, int64(10000), getAccountBalance(t, suite.app, fromAccountID))
+
+		details := suite.getBulkTransfer(t, uploaded.ID)
+		assert.Equal(t, model.BulkTransferItemSkipped, details.Items[2].Status)
+		if assert.NotNil(t, details.Items[2].Reason) {
+			assert.Equal(t, "Not attempted, the bulk transfer stopped on insufficient balance at line 3", *details.Items[2].Reason)
+		}
+	})
+
+	suite.T().Run("batch already confirmed cannot be confirmed or cancelled again", func(t *testing.T) {
+		uploaded := suite.uploadValidBulkTransfer(t, 12345678901237, false, "11111111111110,100,salary")
+		suite.confirmBulkTransfer(t, uploaded.ID)
+
+		responseRecorder := suite.makeRequest(t, suite.app, bulkTransferUserID, "/v1/transfers/bulk/"+uploaded.ID+"/confirm", http.MethodPost, nil)
+		assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Bulk transfer is already queued")
+
+		responseRecorder = suite.makeRequest(t, suite.app, bulkTransferUserID, "/v1/transfers/bulk/"+uploaded.ID+"/cancel", http.MethodPost, nil)
+		assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+
+		response = testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Bulk transfer is already queued")
+	})
+
+	suite.T().Run("cancelled batch is never executed", func(t *testing.T) {
+		uploaded := suite.uploadValidBulkTransfer(t, 12345678901237, false, "11111111111110,100,salary")
+
+		responseRecorder := suite.makeRequest(t, suite.app, bulkTransferUserID, "/v1/transfers/bulk/"+uploaded.ID+"/cancel", http.MethodPost, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.BulkTransferResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, model.BulkTransferCancelled, response.Data.Status)
+
+		bulkTransfer, err := suite.app.Services.TransferService.StartBulkTransfer(t.Context(), nil, uuid.MustParse(uploaded.ID))
+		assert.NoError(t, err)
+		assert.Nil(t, bulkTransfer)
+	})
+
+	suite.T().Run("bulk transfer of another user cannot be accessed", func(t *testing.T) {
+		uploaded := suite.uploadValidBulkTransfer(t, 12345678901237, false, "11111111111110,100,salary")
+
+		responseRecorder := suite.makeRequest(t, suite.app, bulkTransferOtherUserID, "/v1/transfers/bulk/"+uploaded.ID, http.MethodGet, nil)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this bulk transfer")
+	})
+}
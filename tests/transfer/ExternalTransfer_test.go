@@ -0,0 +1,407 @@
+package transfer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	ledgerModel "github.com/skamranahmed/go-bank/internal/ledger/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+/*
+newExternalTransferTestApp bootstraps the app with the simulated rails returning the given share of the orders
+
+The rails are configured when the services are bootstrapped, so the outcome of every order sent in the app is known in advance.
+*/
+func newExternalTransferTestApp(t *testing.T, returnProbabilityInBasisPoints string) testutils.TestApp {
+	t.Setenv("PAYMENT_RAIL_RETURN_PROBABILITY_IN_BASIS_POINTS", returnProbabilityInBasisPoints)
+	app := testutils.NewTestApp(t.Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/ExternalTransfer_test"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return app
+}
+
+func createExternalTransfer(t *testing.T, app testutils.TestApp, userID string, data types.ExternalTransferRequestData) *httptest.ResponseRecorder {
+	accessToken, err := app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+	assert.NoError(t, err)
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+	}
+	return testutils.MakeRequest(t, app, "/v1/transfers/external", http.MethodPost, types.ExternalTransferRequest{Data: data}, headers)
+}
+
+func getAccountBalance(t *testing.T, app testutils.TestApp, accountID int64) int64 {
+	var account accountModel.Account
+	err := app.Db.NewSelect().
+		Model(&account).
+		Where("id = ?", accountID).
+		Scan(t.Context())
+	assert.NoError(t, err)
+	return account.Balance
+}
+
+func getOutboundClearingBalance(t *testing.T, app testutils.TestApp) int64 {
+	var internalAccount ledgerModel.InternalAccount
+	err := app.Db.NewSelect().
+		Model(&internalAccount).
+		Where("code = ?", ledgerModel.OutboundClearing).
+		Scan(t.Context())
+	assert.NoError(t, err)
+	return internalAccount.Balance
+}
+
+type ExternalTransferTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestExternalTransferTestSuite(t *testing.T) {
+	suite.Run(t, new(ExternalTransferTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *ExternalTransferTestSuite) SetupSuite() {
+	// none of the orders are returned
+	suite.app = newExternalTransferTestApp(suite.T(), "0")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *ExternalTransferTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *ExternalTransferTestSuite) TestCreateExternalTransfer() {
+	userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+	var fromAccountID int64 = 12345678901237
+
+	validRequestData := func() types.ExternalTransferRequestData {
+		return types.ExternalTransferRequestData{
+			FromAccountID:            fromAccountID,
+			Rail:                     string(model.IMPS),
+			BeneficiaryName:          "Jane Doe",
+			BeneficiaryAccountNumber: "50100123456789",
+			BeneficiaryIFSC:          "HDFC0001234",
+			Amount:                   int64Ptr(5000),
+		}
+	}
+
+	suite.T().Run("invalid IFSC is rejected", func(t *testing.T) {
+		data := validRequestData()
+		data.BeneficiaryIFSC = "HDFC1001234"
+
+		responseRecorder := createExternalTransfer(t, suite.app, userID, data)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "beneficiary_ifsc", "beneficiary_ifsc is not a valid IFSC")
+	})
+
+	suite.T().Run("beneficiary account number must only contain digits", func(t *testing.T) {
+		data := validRequestData()
+		data.BeneficiaryAccountNumber = "50100-1234567"
+
+		responseRecorder := createExternalTransfer(t, suite.app, userID, data)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "beneficiary_account_number", "beneficiary_account_number can only contain digits")
+	})
+
+	suite.T().Run("IFSC of this bank is rejected", func(t *testing.T) {
+		data := validRequestData()
+		data.BeneficiaryIFSC = "GOBK0000001"
+
+		responseRecorder := createExternalTransfer(t, suite.app, userID, data)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Transfers to accounts at this bank must be made as internal transfers")
+	})
+
+	suite.T().Run("RTGS is rejected below its minimum amount", func(t *testing.T) {
+		data := validRequestData()
+		data.Rail = string(model.RTGS)
+
+		responseRecorder := createExternalTransfer(t, suite.app, userID, data)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "RTGS is only available for amounts of at least 20000000, please use NEFT or IMPS")
+	})
+
+	suite.T().Run("IMPS is rejected above its maximum amount", func(t *testing.T) {
+		data := validRequestData()
+		data.Amount = int64Ptr(50000001)
+
+		responseRecorder := createExternalTransfer(t, suite.app, userID, data)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "IMPS is only available for amounts of up to 50000000, please use NEFT or RTGS")
+	})
+
+	suite.T().Run("user cannot transfer from an account they do not own", func(t *testing.T) {
+		responseRecorder := createExternalTransfer(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", validRequestData())
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	})
+
+	suite.T().Run("transfer exceeding the balance is rejected", func(t *testing.T) {
+		data := validRequestData()
+		data.Amount = int64Ptr(150001)
+
+		responseRecorder := createExternalTransfer(t, suite.app, userID, data)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+		assert.Equal(t, int64(150000), getAccountBalance(t, suite.app, fromAccountID))
+	})
+
+	suite.T().Run("transfer is debited, sent and settled", func(t *testing.T) {
+		data := validRequestData()
+		data.Narration = "Rent for December"
+
+		responseRecorder := createExternalTransfer(t, suite.app, userID, data)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.ExternalTransferResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, model.PaymentOrderInitiated, response.Data.Status)
+		assert.Equal(t, model.IMPS, response.Data.Rail)
+		assert.Equal(t, int64(5000), response.Data.Amount)
+		assert.Nil(t, response.Data.RailReference)
+
+		// the amount is held in the outbound clearing account until the order settles
+		assert.Equal(t, int64(145000), getAccountBalance(t, suite.app, fromAccountID))
+		assert.Equal(t, int64(5000), getOutboundClearingBalance(t, suite.app))
+
+		var debit accountModel.Transaction
+		err = suite.app.Db.NewSelect().
+			Model(&debit).
+			Where("id = ?", response.Data.DebitTransactionID).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, accountModel.ExternalTransfer, debit.Type)
+		assert.Equal(t, "IMPS transfer to ****6789", *debit.Description)
+		assert.Equal(t, "Rent for December", *debit.Narration)
+
+		paymentOrderID, err := uuid.Parse(response.Data.ID)
+		assert.NoError(t, err)
+
+		paymentOrder, err := suite.app.Services.TransferService.DispatchPaymentOrder(t.Context(), nil, paymentOrderID, time.Now().UTC())
+		assert.NoError(t, err)
+		assert.Equal(t, model.PaymentOrderSent, paymentOrder.Status)
+		assert.NotNil(t, paymentOrder.RailReference)
+		assert.NotNil(t, paymentOrder.OutcomeDueAt)
+
+		t.Run("order is only sent once", func(t *testing.T) {
+			paymentOrder, err := suite.app.Services.TransferService.DispatchPaymentOrder(t.Context(), nil, paymentOrderID, time.Now().UTC())
+			assert.NoError(t, err)
+			assert.Nil(t, paymentOrder)
+		})
+
+		paymentOrder, err = suite.app.Services.TransferService.CompletePaymentOrder(t.Context(), nil, paymentOrderID, time.Now().UTC())
+		assert.NoError(t, err)
+		assert.Equal(t, model.PaymentOrderSettled, paymentOrder.Status)
+		assert.NotNil(t, paymentOrder.SettledAt)
+
+		// the amount has left the bank
+		assert.Equal(t, int64(145000), getAccountBalance(t, suite.app, fromAccountID))
+		assert.Equal(t, int64(0), getOutboundClearingBalance(t, suite.app))
+	})
+
+	suite.T().Run("NEFT transfer waits for the next batch", func(t *testing.T) {
+		data := validRequestData()
+		data.Rail = string(model.NEFT)
+
+		responseRecorder := createExternalTransfer(t, suite.app, userID, data)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.ExternalTransferResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		// batches run every 30 minutes on the hour and half hour
+		assert.False(t, response.Data.DispatchAt.Before(response.Data.CreatedAt))
+		assert.Zero(t, response.Data.DispatchAt.Minute()%30)
+		assert.Zero(t, response.Data.DispatchAt.Second())
+	})
+}
+
+func (suite *ExternalTransferTestSuite) TestGetExternalTransfers() {
+	suite.T().Run("user can list their external transfers by status", func(t *testing.T) {
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d")
+		assert.NoError(t, err)
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/transfers/external?status=INITIATED", http.MethodGet, nil, headers)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetExternalTransfersResponse
+		err = json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		for _, paymentOrder := range response.Data {
+			assert.Equal(t, model.PaymentOrderInitiated, paymentOrder.Status)
+			assert.Equal(t, int64(12345678901237), paymentOrder.FromAccountID)
+		}
+	})
+}
+
+type ReturnedExternalTransferTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestReturnedExternalTransferTestSuite(t *testing.T) {
+	suite.Run(t, new(ReturnedExternalTransferTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *ReturnedExternalTransferTestSuite) SetupSuite() {
+	// every order is returned
+	suite.app = newExternalTransferTestApp(suite.T(), "10000")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *ReturnedExternalTransferTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *ReturnedExternalTransferTestSuite) TestReturnedExternalTransfer() {
+	suite.T().Run("returned transfer is credited back to the customer", func(t *testing.T) {
+		var fromAccountID int64 = 11111111111110
+
+		responseRecorder := createExternalTransfer(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", types.ExternalTransferRequestData{
+			FromAccountID:            fromAccountID,
+			Rail:                     string(model.IMPS),
+			BeneficiaryName:          "John Doe",
+			BeneficiaryAccountNumber: "000123456789",
+			BeneficiaryIFSC:          "ICIC0000042",
+			Amount:                   int64Ptr(20000),
+		})
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+		assert.Equal(t, int64(80000), getAccountBalance(t, suite.app, fromAccountID))
+
+		var response types.ExternalTransferResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		paymentOrderID, err := uuid.Parse(response.Data.ID)
+		assert.NoError(t, err)
+
+		_, err = suite.app.Services.TransferService.DispatchPaymentOrder(t.Context(), nil, paymentOrderID, time.Now().UTC())
+		assert.NoError(t, err)
+
+		paymentOrder, err := suite.app.Services.TransferService.CompletePaymentOrder(t.Context(), nil, paymentOrderID, time.Now().UTC())
+		assert.NoError(t, err)
+		assert.Equal(t, model.PaymentOrderReturned, paymentOrder.Status)
+		assert.NotNil(t, paymentOrder.ReturnReason)
+		assert.NotNil(t, paymentOrder.ReturnedAt)
+		assert.NotNil(t, paymentOrder.ReturnTransactionID)
+
+		// the amount is back with the customer and no longer in the outbound clearing account
+		assert.Equal(t, int64(100000), getAccountBalance(t, suite.app, fromAccountID))
+		assert.Equal(t, int64(0), getOutboundClearingBalance(t, suite.app))
+
+		var returnTransaction accountModel.Transaction
+		err = suite.app.Db.NewSelect().
+			Model(&returnTransaction).
+			Where("id = ?", *paymentOrder.ReturnTransactionID).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, accountModel.ExternalTransferReturn, returnTransaction.Type)
+		assert.Equal(t, int64(20000), returnTransaction.Amount)
+		assert.Equal(t, "Return of IMPS transfer to ****6789", *returnTransaction.Description)
+
+		var debit accountModel.Transaction
+		err = suite.app.Db.NewSelect().
+			Model(&debit).
+			Where("id = ?", paymentOrder.DebitTransactionID).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, accountModel.TransactionReversed, debit.Status)
+
+		t.Run("order is only completed once", func(t *testing.T) {
+			paymentOrder, err := suite.app.Services.TransferService.CompletePaymentOrder(t.Context(), nil, paymentOrderID, time.Now().UTC())
+			assert.NoError(t, err)
+			assert.Nil(t, paymentOrder)
+			assert.Equal(t, int64(100000), getAccountBalance(t, suite.app, fromAccountID))
+		})
+	})
+}
+
+func (suite *ReturnedExternalTransferTestSuite) TestReturnedRoundUp() {
+	suite.T().Run("spare change swept for a returned transfer is moved back to the main balance", func(t *testing.T) {
+		var fromAccountID int64 = 11111111111110
+		balanceBefore := getAccountBalance(t, suite.app, fromAccountID)
+
+		// 123.45 INR is rounded up to 130 INR, sweeping 6.55 INR into the pocket
+		responseRecorder := createExternalTransfer(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", types.ExternalTransferRequestData{
+			FromAccountID:            fromAccountID,
+			Rail:                     string(model.IMPS),
+			BeneficiaryName:          "John Doe",
+			BeneficiaryAccountNumber: "000123456789",
+			BeneficiaryIFSC:          "ICIC0000042",
+			Amount:                   int64Ptr(12345),
+		})
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var pocket accountModel.Pocket
+		err := suite.app.Db.NewSelect().Model(&pocket).Where("account_id = ?", fromAccountID).Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(655), pocket.Balance)
+
+		var response types.ExternalTransferResponse
+		err = json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		paymentOrderID, err := uuid.Parse(response.Data.ID)
+		assert.NoError(t, err)
+
+		_, err = suite.app.Services.TransferService.DispatchPaymentOrder(t.Context(), nil, paymentOrderID, time.Now().UTC())
+		assert.NoError(t, err)
+
+		paymentOrder, err := suite.app.Services.TransferService.CompletePaymentOrder(t.Context(), nil, paymentOrderID, time.Now().UTC())
+		assert.NoError(t, err)
+		assert.Equal(t, model.PaymentOrderReturned, paymentOrder.Status)
+
+		var account accountModel.Account
+		err = suite.app.Db.NewSelect().Model(&account).Where("id = ?", fromAccountID).Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, balanceBefore, account.Balance)
+		assert.Equal(t, int64(0), account.PocketedAmount)
+
+		var pocketMovements []accountModel.PocketMovement
+		err = suite.app.Db.NewSelect().Model(&pocketMovements).Where("pocket_id = ?", pocket.ID).Order("created_at ASC").Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Len(t, pocketMovements, 2)
+		assert.Equal(t, accountModel.PocketWithdrawal, pocketMovements[1].Type)
+		assert.Equal(t, int64(655), pocketMovements[1].Amount)
+		assert.Equal(t, int64(0), pocketMovements[1].BalanceAfter)
+		assert.Equal(t, paymentOrder.DebitTransactionID, *pocketMovements[1].TransactionID)
+	})
+}
@@ -0,0 +1,282 @@
+package transfer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/clearingfile"
+	"github.com/skamranahmed/go-bank/pkg/iso20022"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	iso20022AdminID    = "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9"
+	iso20022CustomerID = "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+	iso20022BankIFSC   = "GOBK0000001"
+)
+
+// paymentReturnTemplate is a pacs.004 returning a single payment, identified by its UETR
+const paymentReturnTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pacs.004.001.09">
+  <PmtRtr>
+    <GrpHdr>
+      <MsgId>%s</MsgId>
+      <CreDtTm>2025-12-31T10:00:00Z</CreDtTm>
+      <NbOfTxs>1</NbOfTxs>
+    </GrpHdr>
+    <TxInf>
+      <RtrId>RTR0001</RtrId>
+      <OrgnlUETR>%s</OrgnlUETR>
+      <RtrdIntrBkSttlmAmt Ccy="INR">%s</RtrdIntrBkSttlmAmt>
+      <IntrBkSttlmDt>2025-12-31</IntrBkSttlmDt>
+      <RtrRsnInf>
+        <Rsn><Cd>AC04</Cd></Rsn>
+        <AddtlInf>Account closed on 2025-12-01</AddtlInf>
+      </RtrRsnInf>
+    </TxInf>
+  </PmtRtr>
+</Document>`
+
+type ISO20022TestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestISO20022TestSuite(t *testing.T) {
+	suite.Run(t, new(ISO20022TestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *ISO20022TestSuite) SetupSuite() {
+	// none of the orders are returned by the simulated rails, they are only returned by pacs.004
+	suite.T().Setenv("PAYMENT_RAIL_RETURN_PROBABILITY_IN_BASIS_POINTS", "0")
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/ISO20022_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *ISO20022TestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *ISO20022TestSuite) makeRequest(t *testing.T, userID string, url string, method string, payload any) *httptest.ResponseRecorder {
+	accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+	assert.NoError(t, err)
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+	}
+	return testutils.MakeRequest(t, suite.app, url, method, payload, headers)
+}
+
+func (suite *ISO20022TestSuite) receiveMessages(t *testing.T, contents ...string) []types.ISO20022MessageResultDto {
+	messages := make([]types.ISO20022MessageRequestData, 0, len(contents))
+	for _, content := range contents {
+		messages = append(messages, types.ISO20022MessageRequestData{Content: content})
+	}
+
+	responseRecorder := suite.makeRequest(t, iso20022AdminID, "/v1/admin/iso20022-messages", http.MethodPost, types.ReceiveISO20022MessagesRequest{
+		Data: types.ReceiveISO20022MessagesRequestData{Messages: messages},
+	})
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var response types.ReceiveISO20022MessagesResponse
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response.Data, len(contents))
+	return response.Data
+}
+
+func newCreditTransfer(t *testing.T, messageID string, creditorAccounts ...iso20022.Party) string {
+	creditTransfer := iso20022.CreditTransfer{
+		MessageID:      messageID,
+		CreationTime:   time.Now().UTC(),
+		ClearingSystem: string(model.NEFT),
+	}
+	for i, creditor := range creditorAccounts {
+		reference := fmt.Sprintf("%s%02d", messageID, i+1)
+		creditor.Name = "Asha Verma"
+		creditor.AgentIFSC = iso20022BankIFSC
+		creditTransfer.Transactions = append(creditTransfer.Transactions, iso20022.CreditTransferTransaction{
+			EndToEndID:     reference,
+			TransactionID:  reference,
+			UETR:           uuid.NewString(),
+			Amount:         2500,
+			Currency:       "INR",
+			SettlementDate: time.Now().UTC(),
+			Debtor: iso20022.Party{
+				Name:          "Jane Doe",
+				AccountNumber: "50100123456789",
+				AgentIFSC:     "HDFC0001234",
+			},
+			Creditor:              creditor,
+			RemittanceInformation: "Invoice 42",
+		})
+	}
+
+	content, err := iso20022.EncodeCreditTransfer(creditTransfer)
+	assert.NoError(t, err)
+	return string(content)
+}
+
+func (suite *ISO20022TestSuite) TestReceiveCreditTransfer() {
+	var accountID int64 = 12345678901237
+
+	suite.T().Run("pacs.008 payments are credited by account number and IBAN", func(t *testing.T) {
+		content := newCreditTransfer(t, "NEFTIN0001",
+			iso20022.Party{AccountNumber: "12345678901237"},
+			iso20022.Party{AccountIBAN: "IN92GOBK0000010012345678901237"},
+		)
+
+		results := suite.receiveMessages(t, content)
+		result := results[0]
+		assert.Equal(t, types.ISO20022MessageProcessed, result.Status)
+		assert.Equal(t, string(iso20022.Pacs008), result.MessageType)
+		assert.Equal(t, "NEFTIN0001", result.MessageID)
+		assert.Empty(t, result.Errors)
+		assert.NotNil(t, result.InboundPaymentFile)
+		assert.Equal(t, clearingfile.Pacs008, result.InboundPaymentFile.Format)
+		assert.Equal(t, 2, result.InboundPaymentFile.RecordCount)
+		assert.Equal(t, 2, result.InboundPaymentFile.CreditedCount)
+
+		assert.Equal(t, int64(155000), getAccountBalance(t, suite.app, accountID))
+
+		t.Run("same message is only credited once", func(t *testing.T) {
+			results := suite.receiveMessages(t, content)
+			assert.Equal(t, types.ISO20022MessageRejected, results[0].Status)
+			assert.Equal(t, int64(155000), getAccountBalance(t, suite.app, accountID))
+		})
+	})
+
+	suite.T().Run("message violating the schema is reported with the paths of the violations", func(t *testing.T) {
+		content := newCreditTransfer(t, "NEFTIN0002", iso20022.Party{AccountNumber: "12345678901237"})
+		content = strings.ReplaceAll(content, ">25.00<", ">25.001<")
+		content = strings.Replace(content, "<NbOfTxs>1</NbOfTxs>", "<NbOfTxs>2</NbOfTxs>", 1)
+
+		results := suite.receiveMessages(t, content, "<Document>not iso 20022</Document>")
+		assert.Equal(t, types.ISO20022MessageInvalid, results[0].Status)
+		assert.Equal(t, "NEFTIN0002", results[0].MessageID)
+		assert.Nil(t, results[0].InboundPaymentFile)
+
+		paths := map[string]bool{}
+		for _, validationError := range results[0].Errors {
+			paths[validationError.Path] = true
+		}
+		assert.True(t, paths["GrpHdr/NbOfTxs"])
+		assert.True(t, paths["CdtTrfTxInf[1]/IntrBkSttlmAmt"])
+
+		// a message that is not ISO 20022 at all is rejected
+		assert.Equal(t, types.ISO20022MessageRejected, results[1].Status)
+
+		assert.Equal(t, int64(155000), getAccountBalance(t, suite.app, accountID))
+	})
+}
+
+func (suite *ISO20022TestSuite) TestPaymentOrderMessages() {
+	var accountID int64 = 12345678901237
+	balanceBefore := getAccountBalance(suite.T(), suite.app, accountID)
+
+	narration := "School fees"
+	responseRecorder := createExternalTransfer(suite.T(), suite.app, iso20022CustomerID, types.ExternalTransferRequestData{
+		FromAccountID:            accountID,
+		Rail:                     string(model.IMPS),
+		BeneficiaryName:          "Jane Doe",
+		BeneficiaryAccountNumber: "50100123456789",
+		BeneficiaryIFSC:          "HDFC0001234",
+		Amount:                   int64Ptr(5000),
+		Narration:                narration,
+	})
+	assert.Equal(suite.T(), http.StatusCreated, responseRecorder.Code)
+
+	var transferResponse types.ExternalTransferResponse
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &transferResponse)
+	assert.NoError(suite.T(), err)
+	paymentOrderID := transferResponse.Data.ID
+
+	suite.T().Run("order that has not been sent cannot be returned", func(t *testing.T) {
+		results := suite.receiveMessages(t, fmt.Sprintf(paymentReturnTemplate, "RTNMSG0001", paymentOrderID, "50.00"))
+		assert.Equal(t, types.ISO20022MessageRejected, results[0].Status)
+	})
+
+	_, err = suite.app.Services.TransferService.DispatchPaymentOrder(suite.T().Context(), nil, uuid.MustParse(paymentOrderID), time.Now().UTC())
+	assert.NoError(suite.T(), err)
+
+	suite.T().Run("pacs.008 of the order is exported", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, iso20022AdminID, "/v1/admin/payment-orders/"+paymentOrderID+"/pacs008", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetPaymentOrderCreditTransferResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, paymentOrderID+".xml", response.Data.FileName)
+
+		creditTransfer, err := iso20022.DecodeCreditTransfer([]byte(response.Data.Content))
+		assert.NoError(t, err)
+		assert.Len(t, creditTransfer.Transactions, 1)
+
+		transaction := creditTransfer.Transactions[0]
+		assert.Equal(t, paymentOrderID, transaction.UETR)
+		assert.Equal(t, int64(5000), transaction.Amount)
+		assert.Equal(t, "INR", transaction.Currency)
+		assert.Equal(t, "Asha Verma", transaction.Debtor.Name)
+		assert.Equal(t, "IN92GOBK0000010012345678901237", transaction.Debtor.AccountIBAN)
+		assert.Equal(t, "50100123456789", transaction.Creditor.AccountNumber)
+		assert.Equal(t, "HDFC0001234", transaction.Creditor.AgentIFSC)
+		assert.Equal(t, narration, transaction.RemittanceInformation)
+	})
+
+	suite.T().Run("customers cannot export the pacs.008 of an order", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, iso20022CustomerID, "/v1/admin/payment-orders/"+paymentOrderID+"/pacs008", http.MethodGet, nil)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	})
+
+	suite.T().Run("partial return is rejected", func(t *testing.T) {
+		results := suite.receiveMessages(t, fmt.Sprintf(paymentReturnTemplate, "RTNMSG0002", paymentOrderID, "10.00"))
+		assert.Equal(t, types.ISO20022MessageRejected, results[0].Status)
+		assert.Equal(t, balanceBefore-5000, getAccountBalance(t, suite.app, accountID))
+	})
+
+	suite.T().Run("pacs.004 returns the order to the customer", func(t *testing.T) {
+		results := suite.receiveMessages(t, fmt.Sprintf(paymentReturnTemplate, "RTNMSG0003", paymentOrderID, "50.00"))
+		result := results[0]
+		assert.Equal(t, types.ISO20022MessageProcessed, result.Status)
+		assert.Equal(t, string(iso20022.Pacs004), result.MessageType)
+		assert.Len(t, result.PaymentOrders, 1)
+		assert.Equal(t, model.PaymentOrderReturned, result.PaymentOrders[0].Status)
+		assert.Equal(t, "Closed account number (AC04): Account closed on 2025-12-01", *result.PaymentOrders[0].ReturnReason)
+		assert.NotNil(t, result.PaymentOrders[0].ReturnTransactionID)
+
+		assert.Equal(t, balanceBefore, getAccountBalance(t, suite.app, accountID))
+		assert.Equal(t, int64(0), getOutboundClearingBalance(t, suite.app))
+
+		t.Run("order is only returned once", func(t *testing.T) {
+			results := suite.receiveMessages(t, fmt.Sprintf(paymentReturnTemplate, "RTNMSG0004", paymentOrderID, "50.00"))
+			assert.Equal(t, types.ISO20022MessageRejected, results[0].Status)
+			assert.Equal(t, balanceBefore, getAccountBalance(t, suite.app, accountID))
+		})
+	})
+}
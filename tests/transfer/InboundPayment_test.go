@@ -0,0 +1,284 @@
+package transfer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	ledgerModel "github.com/skamranahmed/go-bank/internal/ledger/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/clearingfile"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	inboundPaymentAdminID            = "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9"
+	inboundPaymentCustomerID         = "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+	inboundPaymentBankIFSC           = "GOBK0000001"
+	inboundPaymentClearingFileHeader = "utr,amount,beneficiary_account_number,beneficiary_ifsc,remitter_name,remitter_account_number,remitter_ifsc"
+)
+
+type InboundPaymentTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestInboundPaymentTestSuite(t *testing.T) {
+	suite.Run(t, new(InboundPaymentTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *InboundPaymentTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/InboundPayment_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *InboundPaymentTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *InboundPaymentTestSuite) makeRequest(t *testing.T, userID string, url string, method string, payload any) *httptest.ResponseRecorder {
+	accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+	assert.NoError(t, err)
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+	}
+	return testutils.MakeRequest(t, suite.app, url, method, payload, headers)
+}
+
+func (suite *InboundPaymentTestSuite) uploadFile(t *testing.T, fileName string, format clearingfile.Format, lines ...string) *httptest.ResponseRecorder {
+	return suite.makeRequest(t, inboundPaymentAdminID, "/v1/admin/inbound-payment-files", http.MethodPost, types.UploadInboundPaymentFileRequest{
+		Data: types.UploadInboundPaymentFileRequestData{
+			FileName: fileName,
+			Format:   string(format),
+			Content:  strings.Join(lines, "\n") + "\n",
+		},
+	})
+}
+
+func (suite *InboundPaymentTestSuite) getInboundPayments(t *testing.T, query string) []types.InboundPaymentDto {
+	responseRecorder := suite.makeRequest(t, inboundPaymentAdminID, "/v1/admin/inbound-payments?"+query, http.MethodGet, nil)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var response types.GetInboundPaymentsResponse
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	return response.Data
+}
+
+func (suite *InboundPaymentTestSuite) getInternalAccountBalance(t *testing.T, code ledgerModel.InternalAccountCode) int64 {
+	var internalAccount ledgerModel.InternalAccount
+	err := suite.app.Db.NewSelect().
+		Model(&internalAccount).
+		Where("code = ?", code).
+		Scan(t.Context())
+	assert.NoError(t, err)
+	return internalAccount.Balance
+}
+
+func (suite *InboundPaymentTestSuite) TestInboundPayments() {
+	var accountID int64 = 12345678901237
+
+	var fileID string
+	suite.T().Run("CSV clearing file is ingested", func(t *testing.T) {
+		responseRecorder := suite.uploadFile(t, "neft_20251230.csv", clearingfile.CSV,
+			inboundPaymentClearingFileHeader,
+			"UTR0001,2500,12345678901237,"+inboundPaymentBankIFSC+",Jane Doe,50100123456789,HDFC0001234",
+			"UTR0002,4000,22222222222220,"+inboundPaymentBankIFSC+",John Doe,000123456789,ICIC0000042",   // valid account number, no such account
+			"UTR0003,1000,12345678901237,HDFC0001234,Jane Doe,50100123456789,HDFC0001234",                // not this bank
+			"UTR0001,2500,12345678901237,"+inboundPaymentBankIFSC+",Jane Doe,50100123456789,HDFC0001234", // repeated
+			"UTR0004,abc,12345678901237,"+inboundPaymentBankIFSC+",Jane Doe,50100123456789,HDFC0001234",  // malformed amount
+		)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.UploadInboundPaymentFileResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, model.InboundPaymentFileUpload, response.Data.Source)
+		assert.Equal(t, 5, response.Data.RecordCount)
+		assert.Equal(t, 1, response.Data.CreditedCount)
+		assert.Equal(t, 1, response.Data.SuspenseCount)
+		assert.Equal(t, 3, response.Data.RejectedCount)
+		fileID = response.Data.ID
+
+		assert.Equal(t, int64(152500), getAccountBalance(t, suite.app, accountID))
+
+		// the clearing house owes the bank both accepted payments, the unmatched one is parked in the suspense account
+		assert.Equal(t, int64(-6500), suite.getInternalAccountBalance(t, ledgerModel.InboundClearing))
+		assert.Equal(t, int64(4000), suite.getInternalAccountBalance(t, ledgerModel.InboundSuspense))
+
+		inboundPayments := suite.getInboundPayments(t, "file_id="+fileID)
+		assert.Len(t, inboundPayments, 5)
+
+		reasons := map[int]string{}
+		for _, inboundPayment := range inboundPayments {
+			if inboundPayment.Reason != nil {
+				reasons[inboundPayment.LineNumber] = *inboundPayment.Reason
+			}
+		}
+		assert.Equal(t, "beneficiary account does not exist", reasons[3])
+		assert.Equal(t, "beneficiary_ifsc does not belong to this bank", reasons[4])
+		assert.Equal(t, "utr is repeated in the clearing file", reasons[5])
+		assert.Equal(t, "amount must be a positive number of paise", reasons[6])
+
+		var credit accountModel.Transaction
+		err = suite.app.Db.NewSelect().
+			Model(&credit).
+			Where("account_id = ?", accountID).
+			Where("type = ?", accountModel.InboundTransfer).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2500), credit.Amount)
+		assert.Equal(t, "Transfer from Jane Doe, UTR UTR0001", *credit.Description)
+	})
+
+	suite.T().Run("same clearing file is only ingested once", func(t *testing.T) {
+		responseRecorder := suite.uploadFile(t, "neft_20251230_copy.csv", clearingfile.CSV,
+			inboundPaymentClearingFileHeader,
+			"UTR0001,2500,12345678901237,"+inboundPaymentBankIFSC+",Jane Doe,50100123456789,HDFC0001234",
+			"UTR0002,4000,22222222222220,"+inboundPaymentBankIFSC+",John Doe,000123456789,ICIC0000042",
+			"UTR0003,1000,12345678901237,HDFC0001234,Jane Doe,50100123456789,HDFC0001234",
+			"UTR0001,2500,12345678901237,"+inboundPaymentBankIFSC+",Jane Doe,50100123456789,HDFC0001234",
+			"UTR0004,abc,12345678901237,"+inboundPaymentBankIFSC+",Jane Doe,50100123456789,HDFC0001234",
+		)
+		assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "This clearing file has already been ingested")
+		assert.Equal(t, int64(152500), getAccountBalance(t, suite.app, accountID))
+	})
+
+	suite.T().Run("clearing file with the wrong header is rejected", func(t *testing.T) {
+		responseRecorder := suite.uploadFile(t, "neft_bad.csv", clearingfile.CSV,
+			"utr,amount",
+			"UTR0005,2500",
+		)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	})
+
+	suite.T().Run("fixed-width clearing file is ingested", func(t *testing.T) {
+		fixedWidthLine := func(utr, amount, accountNumber string) string {
+			return fmt.Sprintf("%-22s%-15s%-18s%-11s%-35s%-18s%-11s", utr, amount, accountNumber, inboundPaymentBankIFSC, "Jane Doe", "50100123456789", "HDFC0001234")
+		}
+
+		responseRecorder := suite.uploadFile(t, "rtgs_20251230.txt", clearingfile.FixedWidth,
+			fixedWidthLine("UTR0006", "3000", "12345678901237"),
+			fixedWidthLine("UTR0002", "4000", "22222222222220"),   // received in the previous file
+			fixedWidthLine("UTR0007", "1500", "1000000000000000"), // wrong check digit
+		)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.UploadInboundPaymentFileResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, response.Data.CreditedCount)
+		assert.Equal(t, 1, response.Data.SuspenseCount)
+		assert.Equal(t, 1, response.Data.RejectedCount)
+
+		assert.Equal(t, int64(155500), getAccountBalance(t, suite.app, accountID))
+
+		inboundPayments := suite.getInboundPayments(t, "file_id="+response.Data.ID+"&status=REJECTED")
+		assert.Len(t, inboundPayments, 1)
+		assert.Equal(t, "a payment with this utr has already been received", *inboundPayments[0].Reason)
+	})
+
+	suite.T().Run("customer cannot access the suspense queue", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, inboundPaymentCustomerID, "/v1/admin/inbound-payments?status=SUSPENSE", http.MethodGet, nil)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	})
+
+	suspendedPayments := suite.getInboundPayments(suite.T(), "status=SUSPENSE")
+	assert.Len(suite.T(), suspendedPayments, 2)
+
+	utrs := map[string]string{}
+	for _, inboundPayment := range suspendedPayments {
+		utrs[inboundPayment.UTR] = inboundPayment.ID
+	}
+
+	suite.T().Run("admin assigns a suspended payment to an account", func(t *testing.T) {
+		url := fmt.Sprintf("/v1/admin/inbound-payments/%s/assign", utrs["UTR0002"])
+		responseRecorder := suite.makeRequest(t, inboundPaymentAdminID, url, http.MethodPost, types.AssignInboundPaymentRequest{
+			Data: types.AssignInboundPaymentRequestData{AccountID: accountID},
+		})
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.InboundPaymentResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, model.InboundPaymentCredited, response.Data.Status)
+		assert.Equal(t, accountID, *response.Data.AccountID)
+		assert.Equal(t, inboundPaymentAdminID, *response.Data.ResolvedBy)
+		assert.NotNil(t, response.Data.TransactionID)
+
+		assert.Equal(t, int64(159500), getAccountBalance(t, suite.app, accountID))
+		assert.Equal(t, int64(1500), suite.getInternalAccountBalance(t, ledgerModel.InboundSuspense))
+
+		t.Run("payment is only assigned once", func(t *testing.T) {
+			responseRecorder := suite.makeRequest(t, inboundPaymentAdminID, url, http.MethodPost, types.AssignInboundPaymentRequest{
+				Data: types.AssignInboundPaymentRequestData{AccountID: accountID},
+			})
+			assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+			assert.Equal(t, int64(159500), getAccountBalance(t, suite.app, accountID))
+		})
+	})
+
+	suite.T().Run("admin returns a suspended payment to the remitter", func(t *testing.T) {
+		url := fmt.Sprintf("/v1/admin/inbound-payments/%s/return", utrs["UTR0007"])
+		responseRecorder := suite.makeRequest(t, inboundPaymentAdminID, url, http.MethodPost, types.ReturnInboundPaymentRequest{
+			Data: types.ReturnInboundPaymentRequestData{Reason: "Beneficiary account not found"},
+		})
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.InboundPaymentResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, model.InboundPaymentReturned, response.Data.Status)
+		assert.Equal(t, "Beneficiary account not found", *response.Data.Reason)
+
+		// the returned payment is owed back to the clearing house
+		assert.Equal(t, int64(0), suite.getInternalAccountBalance(t, ledgerModel.InboundSuspense))
+		assert.Equal(t, int64(-9500), suite.getInternalAccountBalance(t, ledgerModel.InboundClearing))
+		assert.Equal(t, int64(159500), getAccountBalance(t, suite.app, accountID))
+	})
+
+	suite.T().Run("acknowledgment reports the outcome of every record", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, inboundPaymentAdminID, fmt.Sprintf("/v1/admin/inbound-payment-files/%s/acknowledgment", fileID), http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetInboundPaymentAcknowledgmentResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "neft_20251230.ack.csv", response.Data.FileName)
+		assert.Equal(t, strings.Join([]string{
+			"utr,status,reason",
+			"UTR0001,ACCEPTED,",
+			"UTR0002,ACCEPTED,",
+			"UTR0003,REJECTED,beneficiary_ifsc does not belong to this bank",
+			"UTR0001,REJECTED,utr is repeated in the clearing file",
+			"UTR0004,REJECTED,amount must be a positive number of paise",
+		}, "\n")+"\n", response.Data.Content)
+	})
+}
@@ -0,0 +1,160 @@
+package transfer
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	feeModel "github.com/skamranahmed/go-bank/internal/fee/model"
+	ledgerModel "github.com/skamranahmed/go-bank/internal/ledger/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type PerformInternalTransferWithFeeTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestPerformInternalTransferWithFeeTestSuite(t *testing.T) {
+	suite.Run(t, new(PerformInternalTransferWithFeeTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *PerformInternalTransferWithFeeTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/PerformInternalTransferWithFee_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *PerformInternalTransferWithFeeTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+// the subtests depend on each other because the free quota and the balance carry over from one transfer to the next
+func (suite *PerformInternalTransferWithFeeTestSuite) TestTransferFee() {
+	userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+	accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(suite.T().Context(), userID)
+	assert.NoError(suite.T(), err)
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+	}
+
+	getSenderBalance := func(t *testing.T) int64 {
+		var account accountModel.Account
+		err := suite.app.Db.NewSelect().
+			Model(&account).
+			Where("id = ?", 12345678901234).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		return account.Balance
+	}
+
+	suite.T().Run("transfer within the free monthly quota is not charged", func(t *testing.T) {
+		payload := types.InternalTransferRequest{
+			Data: types.InternalTransferRequestData{
+				FromAccountID: 12345678901234,
+				ToAccountID:   11111111111111,
+				Amount:        int64Ptr(1000),
+			},
+		}
+
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/transfers/internal", http.MethodPost, payload, headers)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+		assert.Equal(t, int64(9000), getSenderBalance(t))
+
+		var feeCharge feeModel.FeeCharge
+		err := suite.app.Db.NewSelect().
+			Model(&feeCharge).
+			Where("account_id = ?", 12345678901234).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, feeModel.Free, feeCharge.Status)
+		assert.Nil(t, feeCharge.TransactionID)
+	})
+
+	suite.T().Run("transfer above the free monthly quota is charged the fee and the tax", func(t *testing.T) {
+		payload := types.InternalTransferRequest{
+			Data: types.InternalTransferRequestData{
+				FromAccountID: 12345678901234,
+				ToAccountID:   11111111111111,
+				Amount:        int64Ptr(1000),
+			},
+		}
+
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/transfers/internal", http.MethodPost, payload, headers)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.InternalTransferResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(8000), response.Data.Transaction.BalanceAfter)
+
+		// 9000 - 1000 (transfer) - 500 (fee) - 90 (18% tax on the fee)
+		assert.Equal(t, int64(7410), getSenderBalance(t))
+
+		var feeTransaction accountModel.Transaction
+		err = suite.app.Db.NewSelect().
+			Model(&feeTransaction).
+			Where("account_id = ?", 12345678901234).
+			Where("type = ?", accountModel.Fee).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(590), feeTransaction.Amount)
+		assert.Equal(t, int64(7410), feeTransaction.BalanceAfter)
+
+		var feeRevenue ledgerModel.InternalAccount
+		err = suite.app.Db.NewSelect().
+			Model(&feeRevenue).
+			Where("code = ?", ledgerModel.FeeRevenue).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(500), feeRevenue.Balance)
+
+		var taxPayable ledgerModel.InternalAccount
+		err = suite.app.Db.NewSelect().
+			Model(&taxPayable).
+			Where("code = ?", ledgerModel.TaxPayable).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(90), taxPayable.Balance)
+	})
+
+	suite.T().Run("transfer that leaves no balance for the fee is rolled back", func(t *testing.T) {
+		payload := types.InternalTransferRequest{
+			Data: types.InternalTransferRequestData{
+				FromAccountID: 12345678901234,
+				ToAccountID:   11111111111111,
+				Amount:        int64Ptr(7000),
+			},
+		}
+
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/transfers/internal", http.MethodPost, payload, headers)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have sufficient balance in your account to pay the fee of 590")
+
+		// neither the transfer nor the fee is applied
+		assert.Equal(t, int64(7410), getSenderBalance(t))
+	})
+}
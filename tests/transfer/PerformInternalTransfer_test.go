@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 	"testing"
 
@@ -145,7 +146,20 @@ func (suite *PerformInternalTransferTestSuite) TestValidationErrors() {
 				},
 			},
 			field:              "to_account_id",
-			errMessage:         "to_account_id is a required field",
+			errMessage:         "to_account_id is required when none of to_iban, beneficiary_id is provided",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "to_account_id with a wrong check digit",
+			payload: types.InternalTransferRequest{
+				Data: types.InternalTransferRequestData{
+					FromAccountID: 12345678901234,
+					ToAccountID:   1000000000000000,
+					Amount:        int64Ptr(10000),
+				},
+			},
+			field:              "to_account_id",
+			errMessage:         "to_account_id is not a valid account number",
 			expectedStatusCode: http.StatusBadRequest,
 		},
 		{
@@ -186,6 +200,48 @@ func (suite *PerformInternalTransferTestSuite) TestValidationErrors() {
 			errMessage:         "amount must be greater than 0",
 			expectedStatusCode: http.StatusBadRequest,
 		},
+		{
+			name: "narration longer than 140 characters",
+			payload: types.InternalTransferRequest{
+				Data: types.InternalTransferRequestData{
+					FromAccountID: 12345678901234,
+					ToAccountID:   11111111111111,
+					Amount:        int64Ptr(10000),
+					Narration:     strings.Repeat("a", 141),
+				},
+			},
+			field:              "narration",
+			errMessage:         "narration must be at most 140 characters",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "client_reference with a disallowed character",
+			payload: types.InternalTransferRequest{
+				Data: types.InternalTransferRequestData{
+					FromAccountID:   12345678901234,
+					ToAccountID:     11111111111111,
+					Amount:          int64Ptr(10000),
+					ClientReference: "INV 42",
+				},
+			},
+			field:              "client_reference",
+			errMessage:         "client_reference can only contain letters, digits and the characters - _ / .",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "unknown category",
+			payload: types.InternalTransferRequest{
+				Data: types.InternalTransferRequestData{
+					FromAccountID: 12345678901234,
+					ToAccountID:   11111111111111,
+					Amount:        int64Ptr(10000),
+					Category:      "GIFT",
+				},
+			},
+			field:              "category",
+			errMessage:         "category must be one of: BILL_PAYMENT, EDUCATION, FAMILY, MEDICAL, RENT, SALARY, SHOPPING, TRAVEL, OTHER",
+			expectedStatusCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, tc := range tests {
@@ -235,6 +291,151 @@ func (suite *PerformInternalTransferTestSuite) TestSameAccountTransfer() {
 	})
 }
 
+func (suite *PerformInternalTransferTestSuite) TestInvalidRecipientIBAN() {
+	testCases := []struct {
+		name       string
+		payload    types.InternalTransferRequestData
+		errMessage string
+	}{
+		{
+			name: "malformed iban",
+			payload: types.InternalTransferRequestData{
+				FromAccountID: 12345678901234,
+				ToIBAN:        "IN00GOBK0000010011111111111111",
+				Amount:        int64Ptr(10000),
+			},
+			errMessage: "Invalid IBAN",
+		},
+		{
+			name: "iban of an account at another bank",
+			payload: types.InternalTransferRequestData{
+				FromAccountID: 12345678901234,
+				ToIBAN:        "IN45OTHR0000010011111111111111",
+				Amount:        int64Ptr(10000),
+			},
+			errMessage: "IBAN does not belong to an account at this bank",
+		},
+		{
+			name: "iban and account id of different accounts",
+			payload: types.InternalTransferRequestData{
+				FromAccountID: 12345678901234,
+				ToAccountID:   22222222222222,
+				ToIBAN:        "IN65GOBK0000010011111111111111",
+				Amount:        int64Ptr(10000),
+			},
+			errMessage: "to_account_id and to_iban refer to different accounts",
+		},
+	}
+
+	userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+	for _, tc := range testCases {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+			assert.NoError(t, err)
+
+			headers := map[string]string{
+				"Authorization": "Bearer " + accessToken,
+			}
+
+			payload := types.InternalTransferRequest{Data: tc.payload}
+			responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/transfers/internal", http.MethodPost, payload, headers)
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, "message", tc.errMessage)
+		})
+	}
+}
+
+func (suite *PerformInternalTransferTestSuite) TestTransferUsingIBAN() {
+	suite.T().Run("transfer to a recipient referenced by its iban", func(t *testing.T) {
+		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+		assert.NoError(t, err)
+
+		var receiverAccountBefore accountModel.Account
+		err = suite.app.Db.NewSelect().
+			Model(&receiverAccountBefore).
+			Where("id = ?", 11111111111111).
+			Scan(t.Context())
+		assert.NoError(t, err)
+
+		transferAmount := int64(1000)
+		payload := types.InternalTransferRequest{
+			Data: types.InternalTransferRequestData{
+				FromAccountID: 12345678901234,
+				ToIBAN:        "IN65GOBK0000010011111111111111", // IBAN of account 11111111111111
+				Amount:        &transferAmount,
+			},
+		}
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/transfers/internal", http.MethodPost, payload, headers)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var receiverAccountAfter accountModel.Account
+		err = suite.app.Db.NewSelect().
+			Model(&receiverAccountAfter).
+			Where("id = ?", 11111111111111).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, receiverAccountBefore.Balance+transferAmount, receiverAccountAfter.Balance)
+	})
+}
+
+func (suite *PerformInternalTransferTestSuite) TestFixedDepositAccountTransfer() {
+	type scenario struct {
+		name          string
+		fromAccountID int64
+		toAccountID   int64
+	}
+
+	tests := []scenario{
+		{
+			name:          "transfer from a fixed deposit account returns 400",
+			fromAccountID: 45454545454545,
+			toAccountID:   12345678901234,
+		},
+		{
+			name:          "transfer to a fixed deposit account returns 400",
+			fromAccountID: 12345678901234,
+			toAccountID:   45454545454545,
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+			accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+			assert.NoError(t, err)
+
+			payload := types.InternalTransferRequest{
+				Data: types.InternalTransferRequestData{
+					FromAccountID: tc.fromAccountID,
+					ToAccountID:   tc.toAccountID,
+					Amount:        int64Ptr(10000),
+				},
+			}
+
+			headers := map[string]string{
+				"Authorization": "Bearer " + accessToken,
+			}
+
+			responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/transfers/internal", http.MethodPost, payload, headers)
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, "message", "Transfers are only allowed between savings and current accounts")
+		})
+	}
+}
+
 func (suite *PerformInternalTransferTestSuite) TestFromAccountNotFound() {
 	suite.T().Run("non-existent from_account_id returns 404", func(t *testing.T) {
 		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
@@ -440,6 +641,83 @@ func (suite *PerformInternalTransferTestSuite) TestSuccessfulTransfer() {
 	})
 }
 
+func (suite *PerformInternalTransferTestSuite) TestTransferWithRemittance() {
+	suite.T().Run("remittance information is stored on both transactions with a description for each side", func(t *testing.T) {
+		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+		assert.NoError(t, err)
+
+		payload := types.InternalTransferRequest{
+			Data: types.InternalTransferRequestData{
+				FromAccountID:   12345678901234,
+				ToAccountID:     11111111111111,
+				Amount:          int64Ptr(1000),
+				Narration:       "  Rent for\tMarch \u200b2025\n",
+				ClientReference: "INV-2025/03",
+				Category:        "RENT",
+			},
+		}
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/transfers/internal", http.MethodPost, payload, headers)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.InternalTransferResponse
+		err = json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		// the narration is sanitized before it is stored
+		assert.Equal(t, "Rent for March 2025", *response.Data.Transaction.Narration)
+		assert.Equal(t, "INV-2025/03", *response.Data.Transaction.ClientReference)
+		assert.Equal(t, "RENT", *response.Data.Transaction.Category)
+		assert.Equal(t, "Transfer to ****1111", *response.Data.Transaction.Description)
+
+		var receiverTransaction accountModel.Transaction
+		err = suite.app.Db.NewSelect().
+			Model(&receiverTransaction).
+			Where("counterpart_transaction_id = ?", response.Data.Transaction.ID).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, "Transfer from ****1234", *receiverTransaction.Description)
+		assert.Equal(t, "Rent for March 2025", *receiverTransaction.Narration)
+		assert.Equal(t, "INV-2025/03", *receiverTransaction.ClientReference)
+		assert.Equal(t, accountModel.Rent, *receiverTransaction.Category)
+	})
+
+	suite.T().Run("narration made of whitespace only is not stored", func(t *testing.T) {
+		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+		assert.NoError(t, err)
+
+		payload := types.InternalTransferRequest{
+			Data: types.InternalTransferRequestData{
+				FromAccountID: 12345678901234,
+				ToAccountID:   11111111111111,
+				Amount:        int64Ptr(1000),
+				Narration:     " \t ",
+			},
+		}
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/transfers/internal", http.MethodPost, payload, headers)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.InternalTransferResponse
+		err = json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Nil(t, response.Data.Transaction.Narration)
+		assert.Nil(t, response.Data.Transaction.Category)
+	})
+}
+
 func (suite *PerformInternalTransferTestSuite) TestTransferBetweenDifferentAccountTypes() {
 	suite.T().Run("transfer from SAVINGS_ACCOUNT to CURRENT_ACCOUNT", func(t *testing.T) {
 		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
@@ -545,6 +823,70 @@ func (suite *PerformInternalTransferTestSuite) TestTransferExactBalance() {
 	})
 }
 
+func (suite *PerformInternalTransferTestSuite) TestTransferUsingOverdraft() {
+	suite.T().Run("transfer beyond the overdraft limit returns 400", func(t *testing.T) {
+		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5e"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+		assert.NoError(t, err)
+
+		payload := types.InternalTransferRequest{
+			Data: types.InternalTransferRequestData{
+				FromAccountID: 88888888888888, // balance 10000 + overdraft limit 50000
+				ToAccountID:   11111111111111,
+				Amount:        int64Ptr(60001),
+			},
+		}
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/transfers/internal", http.MethodPost, payload, headers)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have sufficient balance in your account to perform the transfer")
+	})
+
+	suite.T().Run("transfer within the overdraft limit takes the balance below zero", func(t *testing.T) {
+		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5e"
+
+		accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+		assert.NoError(t, err)
+
+		transferAmount := int64(40000)
+		payload := types.InternalTransferRequest{
+			Data: types.InternalTransferRequestData{
+				FromAccountID: 88888888888888, // balance 10000 + overdraft limit 50000
+				ToAccountID:   11111111111111,
+				Amount:        &transferAmount,
+			},
+		}
+
+		headers := map[string]string{
+			"Authorization": "Bearer " + accessToken,
+		}
+
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/transfers/internal", http.MethodPost, payload, headers)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.InternalTransferResponse
+		err = json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(-30000), response.Data.Transaction.BalanceAfter)
+
+		// verify sender account is overdrawn
+		var senderAccountAfter accountModel.Account
+		err = suite.app.Db.NewSelect().
+			Model(&senderAccountAfter).
+			Where("id = ?", 88888888888888).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(-30000), senderAccountAfter.Balance)
+	})
+}
+
 func (suite *PerformInternalTransferTestSuite) TestResponseFormat() {
 	suite.T().Run("response has correct format", func(t *testing.T) {
 		userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
@@ -0,0 +1,291 @@
+package transfer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	transferTasks "github.com/skamranahmed/go-bank/internal/transfer/tasks"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/mock"
+	tasksHelper "github.com/skamranahmed/go-bank/pkg/tasks"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ScheduledTransferTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestScheduledTransferTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduledTransferTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *ScheduledTransferTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/ScheduledTransfer_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *ScheduledTransferTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *ScheduledTransferTestSuite) makeRequest(t *testing.T, app testutils.TestApp, userID string, url string, method string, payload any) *httptest.ResponseRecorder {
+	accessToken, err := app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+	assert.NoError(t, err)
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+	}
+	return testutils.MakeRequest(t, app, url, method, payload, headers)
+}
+
+func (suite *ScheduledTransferTestSuite) getAccountBalance(t *testing.T, accountID int64) int64 {
+	var account accountModel.Account
+	err := suite.app.Db.NewSelect().
+		Model(&account).
+		Where("id = ?", accountID).
+		Scan(t.Context())
+	assert.NoError(t, err)
+	return account.Balance
+}
+
+func (suite *ScheduledTransferTestSuite) TestCreateScheduledTransfer() {
+	userID := "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e"
+
+	suite.T().Run("scheduled transfer is stored and its execution is enqueued at the scheduled time", func(t *testing.T) {
+		scheduledAt := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Second)
+
+		mockController := gomock.NewController(t)
+		defer mockController.Finish()
+
+		mockTaskEnqueuer := mock.NewMockTaskEnqueuer(mockController)
+		mockTaskEnqueuer.EXPECT().
+			EnqueueAt(gomock.Any(), gomock.Any(), gomock.Any(), nil, nil).
+			DoAndReturn(func(ctx context.Context, task tasksHelper.Task, processAt time.Time, maxRetryCount *int, queueName *string) error {
+				assert.Equal(t, transferTasks.ExecuteScheduledTransferTaskName, task.Name())
+				assert.True(t, scheduledAt.Equal(processAt))
+				return nil
+			}).
+			Times(1)
+
+		appWithMock := testutils.NewTestApp(
+			suite.T().Context(),
+			&testutils.TestAppDeps{
+				Db:           suite.app.Db,     // reuse the db from the app
+				Cache:        suite.app.Cache,  // reuse the cache from the app
+				TaskEnqueuer: mockTaskEnqueuer, // inject mock TaskEnqueuer to verify task enqueuing
+			},
+			nil,
+			nil,
+		)
+
+		payload := types.CreateScheduledTransferRequest{
+			Data: types.CreateScheduledTransferRequestData{
+				FromAccountID: 11111111111110,
+				ToAccountID:   12345678901237,
+				Amount:        int64Ptr(1000),
+				ScheduledAt:   scheduledAt.Format(time.RFC3339),
+			},
+		}
+		responseRecorder := suite.makeRequest(t, appWithMock, userID, "/v1/transfers/scheduled", http.MethodPost, payload)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.CreateScheduledTransferResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, response.Data.ID)
+		assert.Equal(t, model.ScheduledTransferPending, response.Data.Status)
+		assert.Equal(t, int64(1000), response.Data.Amount)
+		assert.True(t, scheduledAt.Equal(response.Data.ScheduledAt))
+
+		// nothing is debited until the transfer is executed
+		assert.Equal(t, int64(100000), suite.getAccountBalance(t, 11111111111110))
+	})
+
+	suite.T().Run("scheduled time in the past returns 400", func(t *testing.T) {
+		payload := types.CreateScheduledTransferRequest{
+			Data: types.CreateScheduledTransferRequestData{
+				FromAccountID: 11111111111110,
+				ToAccountID:   12345678901237,
+				Amount:        int64Ptr(1000),
+				ScheduledAt:   time.Now().UTC().Add(-time.Hour).Format(time.RFC3339),
+			},
+		}
+		responseRecorder := suite.makeRequest(t, suite.app, userID, "/v1/transfers/scheduled", http.MethodPost, payload)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "A transfer can only be scheduled for a time in the future")
+	})
+
+	suite.T().Run("invalid scheduled time returns 400", func(t *testing.T) {
+		payload := types.CreateScheduledTransferRequest{
+			Data: types.CreateScheduledTransferRequestData{
+				FromAccountID: 11111111111110,
+				ToAccountID:   12345678901237,
+				Amount:        int64Ptr(1000),
+				ScheduledAt:   "2099-01-01",
+			},
+		}
+		responseRecorder := suite.makeRequest(t, suite.app, userID, "/v1/transfers/scheduled", http.MethodPost, payload)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	})
+
+	suite.T().Run("scheduling from an account of another user returns 403", func(t *testing.T) {
+		payload := types.CreateScheduledTransferRequest{
+			Data: types.CreateScheduledTransferRequestData{
+				FromAccountID: 22222222222220,
+				ToAccountID:   12345678901237,
+				Amount:        int64Ptr(1000),
+				ScheduledAt:   time.Now().UTC().Add(time.Hour).Format(time.RFC3339),
+			},
+		}
+		responseRecorder := suite.makeRequest(t, suite.app, userID, "/v1/transfers/scheduled", http.MethodPost, payload)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	})
+}
+
+func (suite *ScheduledTransferTestSuite) TestGetScheduledTransfers() {
+	userID := "c3d4e5f6-a7b8-6c7d-0e1f-2a3b4c5d6e7f"
+
+	suite.T().Run("lists the scheduled transfers of the user", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, suite.app, userID, "/v1/transfers/scheduled", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetScheduledTransfersResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+		assert.Equal(t, "6b7c8d9e-0f1a-4b2c-9d3e-4f5a6b7c8d04", response.Data[0].ID)
+	})
+
+	suite.T().Run("filters the scheduled transfers by status", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, suite.app, userID, "/v1/transfers/scheduled?status=COMPLETED", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetScheduledTransfersResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Empty(t, response.Data)
+	})
+
+	suite.T().Run("invalid status returns 400", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, suite.app, userID, "/v1/transfers/scheduled?status=UNKNOWN", http.MethodGet, nil)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "status", "status must be one of: PENDING, COMPLETED, FAILED, CANCELLED")
+	})
+}
+
+func (suite *ScheduledTransferTestSuite) TestCancelScheduledTransfer() {
+	scheduledTransferID := "6b7c8d9e-0f1a-4b2c-9d3e-4f5a6b7c8d01"
+	url := "/v1/transfers/scheduled/" + scheduledTransferID + "/cancel"
+
+	suite.T().Run("cancelling the scheduled transfer of another user returns 403", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, suite.app, "c3d4e5f6-a7b8-6c7d-0e1f-2a3b4c5d6e7f", url, http.MethodPost, nil)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this scheduled transfer")
+	})
+
+	suite.T().Run("pending scheduled transfer is cancelled", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", url, http.MethodPost, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.CancelScheduledTransferResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, model.ScheduledTransferCancelled, response.Data.Status)
+	})
+
+	suite.T().Run("cancelling a scheduled transfer that is no longer pending returns 400", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", url, http.MethodPost, nil)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Only a pending scheduled transfer can be cancelled")
+	})
+
+	suite.T().Run("cancelled scheduled transfer is not executed", func(t *testing.T) {
+		scheduledTransfer, err := suite.app.Services.TransferService.ExecuteScheduledTransfer(t.Context(), nil, uuid.MustParse(scheduledTransferID), time.Now().UTC())
+		assert.NoError(t, err)
+		assert.Nil(t, scheduledTransfer)
+	})
+
+	suite.T().Run("invalid scheduled transfer ID returns 400", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/transfers/scheduled/invalid/cancel", http.MethodPost, nil)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Invalid scheduled transfer ID")
+	})
+}
+
+func (suite *ScheduledTransferTestSuite) TestExecuteScheduledTransfer() {
+	suite.T().Run("due scheduled transfer is executed and completed", func(t *testing.T) {
+		executionTime := time.Now().UTC()
+
+		scheduledTransfer, err := suite.app.Services.TransferService.ExecuteScheduledTransfer(t.Context(), nil, uuid.MustParse("6b7c8d9e-0f1a-4b2c-9d3e-4f5a6b7c8d02"), executionTime)
+		assert.NoError(t, err)
+		assert.Equal(t, model.ScheduledTransferCompleted, scheduledTransfer.Status)
+		assert.NotNil(t, scheduledTransfer.TransactionID)
+		assert.NotNil(t, scheduledTransfer.ExecutedAt)
+
+		assert.Equal(t, int64(100000-20000), suite.getAccountBalance(t, 11111111111110))
+	})
+
+	suite.T().Run("executing a completed scheduled transfer again does nothing", func(t *testing.T) {
+		scheduledTransfer, err := suite.app.Services.TransferService.ExecuteScheduledTransfer(t.Context(), nil, uuid.MustParse("6b7c8d9e-0f1a-4b2c-9d3e-4f5a6b7c8d02"), time.Now().UTC())
+		assert.NoError(t, err)
+		assert.Nil(t, scheduledTransfer)
+
+		assert.Equal(t, int64(100000-20000), suite.getAccountBalance(t, 11111111111110))
+	})
+
+	suite.T().Run("scheduled transfer rejected by the bank is not executed and can be failed with the reason", func(t *testing.T) {
+		scheduledTransferID := uuid.MustParse("6b7c8d9e-0f1a-4b2c-9d3e-4f5a6b7c8d04")
+		executionTime := time.Now().UTC()
+
+		_, err := suite.app.Services.TransferService.ExecuteScheduledTransfer(t.Context(), nil, scheduledTransferID, executionTime)
+		var apiErr *server.ApiError
+		assert.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusBadRequest, apiErr.HttpStatusCode)
+		assert.Equal(t, "You do not have sufficient balance in your account to perform the transfer", apiErr.Message)
+
+		scheduledTransfer, err := suite.app.Services.TransferService.FailScheduledTransfer(t.Context(), nil, scheduledTransferID, apiErr.Message, executionTime)
+		assert.NoError(t, err)
+		assert.Equal(t, model.ScheduledTransferFailed, scheduledTransfer.Status)
+		assert.Equal(t, apiErr.Message, *scheduledTransfer.FailureReason)
+
+		assert.Equal(t, int64(1000), suite.getAccountBalance(t, 22222222222220))
+	})
+}
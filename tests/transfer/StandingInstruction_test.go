@@ -0,0 +1,368 @@
+package transfer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	"github.com/google/uuid"
+	"github.com/skamranahmed/go-bank/cmd/server"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type StandingInstructionTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestStandingInstructionTestSuite(t *testing.T) {
+	suite.Run(t, new(StandingInstructionTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *StandingInstructionTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/StandingInstruction_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *StandingInstructionTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *StandingInstructionTestSuite) makeRequest(t *testing.T, userID string, url string, method string, payload any) *httptest.ResponseRecorder {
+	accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+	assert.NoError(t, err)
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+	}
+	return testutils.MakeRequest(t, suite.app, url, method, payload, headers)
+}
+
+func (suite *StandingInstructionTestSuite) getAccountBalance(t *testing.T, accountID int64) int64 {
+	var account accountModel.Account
+	err := suite.app.Db.NewSelect().
+		Model(&account).
+		Where("id = ?", accountID).
+		Scan(t.Context())
+	assert.NoError(t, err)
+	return account.Balance
+}
+
+func (suite *StandingInstructionTestSuite) TestCreateStandingInstruction() {
+	userID := "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e"
+	now := time.Now().UTC()
+
+	suite.T().Run("weekly standing instruction is first due on its start date", func(t *testing.T) {
+		startDate := now.AddDate(0, 0, 1).Format(time.DateOnly)
+		payload := types.CreateStandingInstructionRequest{
+			Data: types.CreateStandingInstructionRequestData{
+				FromAccountID:  11111111111110,
+				ToAccountID:    12345678901237,
+				Amount:         int64Ptr(1000),
+				Frequency:      "WEEKLY",
+				StartDate:      startDate,
+				MaxOccurrences: intPtr(3),
+			},
+		}
+		responseRecorder := suite.makeRequest(t, userID, "/v1/standing-instructions", http.MethodPost, payload)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.CreateStandingInstructionResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, response.Data.ID)
+		assert.Equal(t, model.StandingInstructionActive, response.Data.Status)
+		assert.Equal(t, startDate, *response.Data.NextExecutionDate)
+		assert.Equal(t, 0, response.Data.OccurrenceCount)
+	})
+
+	suite.T().Run("monthly standing instruction is first due on its day of the month", func(t *testing.T) {
+		firstOfNextMonth := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		payload := types.CreateStandingInstructionRequest{
+			Data: types.CreateStandingInstructionRequestData{
+				FromAccountID: 11111111111110,
+				ToAccountID:   12345678901237,
+				Amount:        int64Ptr(1000),
+				Frequency:     "MONTHLY",
+				DayOfMonth:    intPtr(10),
+				StartDate:     firstOfNextMonth.Format(time.DateOnly),
+				EndDate:       firstOfNextMonth.AddDate(1, 0, 0).Format(time.DateOnly),
+			},
+		}
+		responseRecorder := suite.makeRequest(t, userID, "/v1/standing-instructions", http.MethodPost, payload)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.CreateStandingInstructionResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, firstOfNextMonth.AddDate(0, 0, 9).Format(time.DateOnly), *response.Data.NextExecutionDate)
+	})
+
+	suite.T().Run("monthly standing instruction without a day of the month returns 400", func(t *testing.T) {
+		payload := types.CreateStandingInstructionRequest{
+			Data: types.CreateStandingInstructionRequestData{
+				FromAccountID:  11111111111110,
+				ToAccountID:    12345678901237,
+				Amount:         int64Ptr(1000),
+				Frequency:      "MONTHLY",
+				StartDate:      now.AddDate(0, 0, 1).Format(time.DateOnly),
+				MaxOccurrences: intPtr(3),
+			},
+		}
+		responseRecorder := suite.makeRequest(t, userID, "/v1/standing-instructions", http.MethodPost, payload)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "The day of the month is required for a monthly standing instruction")
+	})
+
+	suite.T().Run("start date in the past returns 400", func(t *testing.T) {
+		payload := types.CreateStandingInstructionRequest{
+			Data: types.CreateStandingInstructionRequestData{
+				FromAccountID:  11111111111110,
+				ToAccountID:    12345678901237,
+				Amount:         int64Ptr(1000),
+				Frequency:      "WEEKLY",
+				StartDate:      now.AddDate(0, 0, -1).Format(time.DateOnly),
+				MaxOccurrences: intPtr(3),
+			},
+		}
+		responseRecorder := suite.makeRequest(t, userID, "/v1/standing-instructions", http.MethodPost, payload)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "The start date cannot be in the past")
+	})
+
+	suite.T().Run("standing instruction without an end date or a number of occurrences returns 400", func(t *testing.T) {
+		payload := types.CreateStandingInstructionRequest{
+			Data: types.CreateStandingInstructionRequestData{
+				FromAccountID: 11111111111110,
+				ToAccountID:   12345678901237,
+				Amount:        int64Ptr(1000),
+				Frequency:     "WEEKLY",
+				StartDate:     now.AddDate(0, 0, 1).Format(time.DateOnly),
+			},
+		}
+		responseRecorder := suite.makeRequest(t, userID, "/v1/standing-instructions", http.MethodPost, payload)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "end_date", "end_date is required when max_occurrences is not provided")
+	})
+
+	suite.T().Run("standing instruction from an account of another user returns 403", func(t *testing.T) {
+		payload := types.CreateStandingInstructionRequest{
+			Data: types.CreateStandingInstructionRequestData{
+				FromAccountID:  22222222222220,
+				ToAccountID:    12345678901237,
+				Amount:         int64Ptr(1000),
+				Frequency:      "WEEKLY",
+				StartDate:      now.AddDate(0, 0, 1).Format(time.DateOnly),
+				MaxOccurrences: intPtr(3),
+			},
+		}
+		responseRecorder := suite.makeRequest(t, userID, "/v1/standing-instructions", http.MethodPost, payload)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	})
+}
+
+func (suite *StandingInstructionTestSuite) TestGetStandingInstructions() {
+	userID := "d4e5f6a7-b8c9-7d8e-1f2a-3b4c5d6e7f80"
+
+	suite.T().Run("lists the standing instructions of the user", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, userID, "/v1/standing-instructions", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetStandingInstructionsResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+	})
+
+	suite.T().Run("filters the standing instructions by status", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, userID, "/v1/standing-instructions?status=COMPLETED", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetStandingInstructionsResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+		assert.Equal(t, "7c8d9e0f-1a2b-4c3d-8e4f-5a6b7c8d9e05", response.Data[0].ID)
+		assert.Nil(t, response.Data[0].NextExecutionDate)
+	})
+}
+
+func (suite *StandingInstructionTestSuite) TestCancelStandingInstruction() {
+	url := "/v1/standing-instructions/7c8d9e0f-1a2b-4c3d-8e4f-5a6b7c8d9e03/cancel"
+
+	suite.T().Run("cancelling the standing instruction of another user returns 403", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, "c3d4e5f6-a7b8-6c7d-0e1f-2a3b4c5d6e7f", url, http.MethodPost, nil)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this standing instruction")
+	})
+
+	suite.T().Run("resuming a standing instruction that is not suspended returns 400", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/standing-instructions/7c8d9e0f-1a2b-4c3d-8e4f-5a6b7c8d9e03/resume", http.MethodPost, nil)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Only a suspended standing instruction can be resumed")
+	})
+
+	suite.T().Run("active standing instruction is cancelled", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", url, http.MethodPost, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.CancelStandingInstructionResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, model.StandingInstructionCancelled, response.Data.Status)
+	})
+
+	suite.T().Run("cancelling a cancelled standing instruction returns 400", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", url, http.MethodPost, nil)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Only an active or suspended standing instruction can be cancelled")
+	})
+
+	suite.T().Run("invalid standing instruction ID returns 400", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/standing-instructions/invalid/cancel", http.MethodPost, nil)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Invalid standing instruction ID")
+	})
+}
+
+func (suite *StandingInstructionTestSuite) TestExecuteStandingInstruction() {
+	standingInstructionID := uuid.MustParse("7c8d9e0f-1a2b-4c3d-8e4f-5a6b7c8d9e01")
+	executionDate := time.Date(2025, 9, 20, 0, 0, 0, 0, time.UTC)
+
+	suite.T().Run("due occurrence is paid and the last occurrence completes the instruction", func(t *testing.T) {
+		execution, err := suite.app.Services.TransferService.ExecuteStandingInstruction(t.Context(), nil, standingInstructionID, executionDate)
+		assert.NoError(t, err)
+		assert.Equal(t, model.StandingInstructionExecutionSucceeded, execution.Status)
+		assert.Equal(t, 2, execution.OccurrenceNumber)
+		assert.NotNil(t, execution.TransactionID)
+
+		assert.Equal(t, int64(100000-10000), suite.getAccountBalance(t, 11111111111110))
+
+		standingInstruction, err := suite.app.Services.TransferService.GetStandingInstruction(t.Context(), nil, types.StandingInstructionQueryOptions{
+			ID: &standingInstructionID,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, model.StandingInstructionCompleted, standingInstruction.Status)
+		assert.Equal(t, 2, standingInstruction.OccurrenceCount)
+		assert.Nil(t, standingInstruction.NextExecutionDate)
+	})
+
+	suite.T().Run("executing the instruction again pays nothing", func(t *testing.T) {
+		execution, err := suite.app.Services.TransferService.ExecuteStandingInstruction(t.Context(), nil, standingInstructionID, executionDate)
+		assert.NoError(t, err)
+		assert.Nil(t, execution)
+
+		assert.Equal(t, int64(100000-10000), suite.getAccountBalance(t, 11111111111110))
+	})
+}
+
+func (suite *StandingInstructionTestSuite) TestStandingInstructionFailures() {
+	userID := "c3d4e5f6-a7b8-6c7d-0e1f-2a3b4c5d6e7f"
+	standingInstructionID := uuid.MustParse("7c8d9e0f-1a2b-4c3d-8e4f-5a6b7c8d9e02")
+	dueDate := time.Date(2025, 9, 20, 0, 0, 0, 0, time.UTC)
+
+	// the subtests depend on each other because every failed attempt counts towards the suspension
+	var failureReason string
+	suite.T().Run("occurrence that cannot be paid returns the reason", func(t *testing.T) {
+		_, err := suite.app.Services.TransferService.ExecuteStandingInstruction(t.Context(), nil, standingInstructionID, dueDate)
+		var apiErr *server.ApiError
+		assert.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusBadRequest, apiErr.HttpStatusCode)
+		assert.Equal(t, "You do not have sufficient balance in your account to perform the transfer", apiErr.Message)
+
+		failureReason = apiErr.Message
+	})
+
+	suite.T().Run("failed occurrence is retried within the retry period", func(t *testing.T) {
+		for _, executionDate := range []time.Time{dueDate, dueDate.AddDate(0, 0, 1)} {
+			standingInstruction, err := suite.app.Services.TransferService.RecordStandingInstructionFailure(t.Context(), nil, standingInstructionID, executionDate, failureReason)
+			assert.NoError(t, err)
+			assert.Equal(t, model.StandingInstructionActive, standingInstruction.Status)
+			assert.Equal(t, 0, standingInstruction.OccurrenceCount)
+			assert.True(t, dueDate.Equal(*standingInstruction.NextExecutionDate))
+		}
+	})
+
+	suite.T().Run("occurrence is skipped after the retry period and the instruction is suspended after repeated failures", func(t *testing.T) {
+		standingInstruction, err := suite.app.Services.TransferService.RecordStandingInstructionFailure(t.Context(), nil, standingInstructionID, dueDate.AddDate(0, 0, 3), failureReason)
+		assert.NoError(t, err)
+		assert.Equal(t, model.StandingInstructionSuspended, standingInstruction.Status)
+		assert.Equal(t, 3, standingInstruction.ConsecutiveFailureCount)
+		assert.Equal(t, 1, standingInstruction.OccurrenceCount)
+		assert.True(t, dueDate.AddDate(0, 0, 7).Equal(*standingInstruction.NextExecutionDate))
+	})
+
+	suite.T().Run("suspended instruction is not executed", func(t *testing.T) {
+		execution, err := suite.app.Services.TransferService.ExecuteStandingInstruction(t.Context(), nil, standingInstructionID, dueDate.AddDate(0, 0, 7))
+		assert.NoError(t, err)
+		assert.Nil(t, execution)
+	})
+
+	suite.T().Run("failed attempts are listed in the executions", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, userID, "/v1/standing-instructions/"+standingInstructionID.String()+"/executions", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetStandingInstructionExecutionsResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Data, 3)
+		assert.Equal(t, model.StandingInstructionExecutionFailed, response.Data[0].Status)
+		assert.Equal(t, model.StandingInstructionExecutionFailed, response.Data[1].Status)
+		assert.Equal(t, model.StandingInstructionExecutionSkipped, response.Data[2].Status)
+		assert.Equal(t, failureReason, *response.Data[2].FailureReason)
+	})
+
+	suite.T().Run("resumed instruction skips the occurrences missed while it was suspended", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, userID, "/v1/standing-instructions/"+standingInstructionID.String()+"/resume", http.MethodPost, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.ResumeStandingInstructionResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		// all 4 occurrences were due in the past, so none is left
+		assert.Equal(t, model.StandingInstructionCompleted, response.Data.Status)
+		assert.Equal(t, 4, response.Data.OccurrenceCount)
+		assert.Equal(t, 0, response.Data.ConsecutiveFailureCount)
+		assert.Nil(t, response.Data.NextExecutionDate)
+
+		assert.Equal(t, int64(1000), suite.getAccountBalance(t, 22222222222220))
+	})
+}
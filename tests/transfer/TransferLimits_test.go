@@ -0,0 +1,349 @@
+package transfer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	depositTypes "github.com/skamranahmed/go-bank/internal/deposit/types"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+type TransferLimitsTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestTransferLimitsTestSuite(t *testing.T) {
+	suite.Run(t, new(TransferLimitsTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *TransferLimitsTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/TransferLimits_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *TransferLimitsTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *TransferLimitsTestSuite) makeRequest(t *testing.T, userID string, url string, method string, payload any) *httptest.ResponseRecorder {
+	accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+	assert.NoError(t, err)
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+	}
+	return testutils.MakeRequest(t, suite.app, url, method, payload, headers)
+}
+
+func (suite *TransferLimitsTestSuite) transfer(t *testing.T, userID string, fromAccountID int64, amount int64) *httptest.ResponseRecorder {
+	payload := types.InternalTransferRequest{
+		Data: types.InternalTransferRequestData{
+			FromAccountID: fromAccountID,
+			ToAccountID:   12345678901237,
+			Amount:        int64Ptr(amount),
+		},
+	}
+	return suite.makeRequest(t, userID, "/v1/transfers/internal", http.MethodPost, payload)
+}
+
+func (suite *TransferLimitsTestSuite) TestDailyAmountLimit() {
+	userID := "c3d4e5f6-a7b8-6c7d-0e1f-2a3b4c5d6e7f"
+
+	suite.T().Run("transfers within the daily amount limit succeed", func(t *testing.T) {
+		responseRecorder := suite.transfer(t, userID, 22222222222220, 70000)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	})
+
+	suite.T().Run("transfer beyond the remaining daily amount returns 400", func(t *testing.T) {
+		responseRecorder := suite.transfer(t, userID, 22222222222220, 40000)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "The amount exceeds the daily transfer limit of 100000, the remaining limit for today is 30000")
+	})
+
+	suite.T().Run("the remaining daily amount can be transferred", func(t *testing.T) {
+		responseRecorder := suite.transfer(t, userID, 22222222222220, 30000)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	})
+}
+
+func (suite *TransferLimitsTestSuite) TestMonthlyAmountLimit() {
+	userID := "d4e5f6a7-b8c9-7d8e-1f2a-3b4c5d6e7f80"
+
+	suite.T().Run("transfers within the monthly amount limit succeed", func(t *testing.T) {
+		responseRecorder := suite.transfer(t, userID, 33333333333330, 30000)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	})
+
+	suite.T().Run("transfer beyond the remaining monthly amount returns 400", func(t *testing.T) {
+		responseRecorder := suite.transfer(t, userID, 33333333333330, 30000)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "The amount exceeds the monthly transfer limit of 50000, the remaining limit for this month is 20000")
+	})
+}
+
+func (suite *TransferLimitsTestSuite) TestPerTransactionAndDailyCountLimits() {
+	userID := "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e"
+
+	suite.T().Run("transfer above the per-transaction limit returns 400 and is rolled back", func(t *testing.T) {
+		responseRecorder := suite.transfer(t, userID, 11111111111110, 60000)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "The amount exceeds the per-transaction limit of 50000")
+	})
+
+	suite.T().Run("transfers within the daily count succeed", func(t *testing.T) {
+		for range 2 {
+			responseRecorder := suite.transfer(t, userID, 11111111111110, 1000)
+			assert.Equal(t, http.StatusOK, responseRecorder.Code)
+		}
+	})
+
+	suite.T().Run("transfer beyond the daily count returns 400", func(t *testing.T) {
+		responseRecorder := suite.transfer(t, userID, 11111111111110, 1000)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You have reached the daily limit of 2 transfers from this account")
+	})
+}
+
+func (suite *TransferLimitsTestSuite) TestDepositBookingsDoNotCountTowardsLimits() {
+	userID := "e5f6a7b8-c9d0-8e9f-2a3b-4c5d6e7f8091"
+
+	suite.T().Run("booking a fixed deposit is not counted as a transfer", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, userID, "/v1/fixed-deposits", http.MethodPost, depositTypes.BookFixedDepositRequest{
+			Data: depositTypes.BookFixedDepositRequestData{
+				LinkedAccountID:     44444444444440,
+				Amount:              int64Ptr(500000),
+				TenureInMonths:      intPtr(12),
+				MaturityInstruction: "PAYOUT",
+			},
+		})
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		// the daily count of 1 is still unused
+		responseRecorder = suite.transfer(t, userID, 44444444444440, 1000)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		responseRecorder = suite.transfer(t, userID, 44444444444440, 1000)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You have reached the daily limit of 1 transfers from this account")
+	})
+}
+
+func (suite *TransferLimitsTestSuite) TestGetTransferLimits() {
+	suite.T().Run("returns the overrides of the user on top of the defaults", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/transfer-limits", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetTransferLimitsResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []types.TransferLimitsDto{
+			{
+				AccountType:          "SAVINGS_ACCOUNT",
+				PerTransactionAmount: 50000,
+				DailyCount:           2,
+				DailyAmount:          20000000,
+				MonthlyAmount:        100000000,
+			},
+			{
+				AccountType:          "CURRENT_ACCOUNT",
+				PerTransactionAmount: 50000000,
+				DailyCount:           50,
+				DailyAmount:          100000000,
+				MonthlyAmount:        500000000,
+			},
+		}, response.Data)
+	})
+}
+
+func (suite *TransferLimitsTestSuite) TestUpdateTransferLimits() {
+	userID := "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d"
+
+	type scenario struct {
+		name       string
+		payload    types.UpdateTransferLimitsRequest
+		field      string
+		errMessage string
+	}
+
+	tests := []scenario{
+		{
+			name: "missing account type",
+			payload: types.UpdateTransferLimitsRequest{
+				Data: types.UpdateTransferLimitsRequestData{
+					DailyCount: intPtr(5),
+				},
+			},
+			field:      "account_type",
+			errMessage: "account_type is a required field",
+		},
+		{
+			name: "deposit account type",
+			payload: types.UpdateTransferLimitsRequest{
+				Data: types.UpdateTransferLimitsRequestData{
+					AccountType: "FIXED_DEPOSIT",
+					DailyCount:  intPtr(5),
+				},
+			},
+			field:      "account_type",
+			errMessage: "account_type must be one of: SAVINGS_ACCOUNT, CURRENT_ACCOUNT",
+		},
+		{
+			name: "no limits",
+			payload: types.UpdateTransferLimitsRequest{
+				Data: types.UpdateTransferLimitsRequestData{
+					AccountType: "SAVINGS_ACCOUNT",
+				},
+			},
+			field:      "per_transaction_amount",
+			errMessage: "per_transaction_amount is required when none of daily_count, daily_amount, monthly_amount is provided",
+		},
+		{
+			name: "zero limit",
+			payload: types.UpdateTransferLimitsRequest{
+				Data: types.UpdateTransferLimitsRequestData{
+					AccountType: "SAVINGS_ACCOUNT",
+					DailyAmount: int64Ptr(0),
+				},
+			},
+			field:      "daily_amount",
+			errMessage: "daily_amount must be greater than 0",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := suite.makeRequest(t, userID, "/v1/transfer-limits", http.MethodPut, tc.payload)
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, tc.field, tc.errMessage)
+		})
+	}
+
+	suite.T().Run("user can lower their limits", func(t *testing.T) {
+		payload := types.UpdateTransferLimitsRequest{
+			Data: types.UpdateTransferLimitsRequestData{
+				AccountType:          "SAVINGS_ACCOUNT",
+				PerTransactionAmount: int64Ptr(5000000),
+				DailyCount:           intPtr(10),
+			},
+		}
+		responseRecorder := suite.makeRequest(t, userID, "/v1/transfer-limits", http.MethodPut, payload)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.UpdateTransferLimitsResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, types.TransferLimitsDto{
+			AccountType:          "SAVINGS_ACCOUNT",
+			PerTransactionAmount: 5000000,
+			DailyCount:           10,
+			DailyAmount:          20000000,
+			MonthlyAmount:        100000000,
+		}, response.Data)
+	})
+
+	suite.T().Run("user cannot raise their limits", func(t *testing.T) {
+		payload := types.UpdateTransferLimitsRequest{
+			Data: types.UpdateTransferLimitsRequestData{
+				AccountType:          "SAVINGS_ACCOUNT",
+				PerTransactionAmount: int64Ptr(6000000),
+			},
+		}
+		responseRecorder := suite.makeRequest(t, userID, "/v1/transfer-limits", http.MethodPut, payload)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You can only lower your per-transaction limit, please contact the bank to raise it")
+	})
+
+	suite.T().Run("non admin user cannot update the limits of another user", func(t *testing.T) {
+		payload := types.UpdateTransferLimitsRequest{
+			Data: types.UpdateTransferLimitsRequestData{
+				AccountType:          "SAVINGS_ACCOUNT",
+				PerTransactionAmount: int64Ptr(20000000),
+			},
+		}
+		responseRecorder := suite.makeRequest(t, userID, "/v1/admin/users/"+userID+"/transfer-limits", http.MethodPut, payload)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to perform this action")
+	})
+
+	suite.T().Run("admin can raise the limits of a user", func(t *testing.T) {
+		payload := types.UpdateTransferLimitsRequest{
+			Data: types.UpdateTransferLimitsRequestData{
+				AccountType:          "SAVINGS_ACCOUNT",
+				PerTransactionAmount: int64Ptr(20000000),
+			},
+		}
+		responseRecorder := suite.makeRequest(t, "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9", "/v1/admin/users/"+userID+"/transfer-limits", http.MethodPut, payload)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.UpdateTransferLimitsResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		// the daily count lowered by the user is kept
+		assert.Equal(t, types.TransferLimitsDto{
+			AccountType:          "SAVINGS_ACCOUNT",
+			PerTransactionAmount: 20000000,
+			DailyCount:           10,
+			DailyAmount:          20000000,
+			MonthlyAmount:        100000000,
+		}, response.Data)
+	})
+
+	suite.T().Run("admin gets 404 for a non-existent user", func(t *testing.T) {
+		payload := types.UpdateTransferLimitsRequest{
+			Data: types.UpdateTransferLimitsRequestData{
+				AccountType: "SAVINGS_ACCOUNT",
+				DailyCount:  intPtr(5),
+			},
+		}
+		responseRecorder := suite.makeRequest(t, "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9", "/v1/admin/users/00000000-0000-4000-8000-000000000000/transfer-limits", http.MethodPut, payload)
+		assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "User not found")
+	})
+}
@@ -0,0 +1,265 @@
+package transfer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-testfixtures/testfixtures/v3"
+	"github.com/google/uuid"
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	accountTypes "github.com/skamranahmed/go-bank/internal/account/types"
+	"github.com/skamranahmed/go-bank/internal/transfer/model"
+	"github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+const transferReversalAdminID string = "f1e2d3c4-b5a6-4978-8695-a4b3c2d1e0f9"
+
+type TransferReversalTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestTransferReversalTestSuite(t *testing.T) {
+	suite.Run(t, new(TransferReversalTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *TransferReversalTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+
+	fixtures, err := testfixtures.New(
+		testfixtures.Database(suite.app.Db.DB),
+		testfixtures.Dialect("postgres"),
+		testfixtures.Directory("./fixtures/TransferReversal_test"),
+	)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	err = fixtures.Load()
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// TearDownSuite runs once after all tests
+func (suite *TransferReversalTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *TransferReversalTestSuite) makeRequest(t *testing.T, userID string, url string, method string, payload any) *httptest.ResponseRecorder {
+	accessToken, err := suite.app.Services.AuthenticationService.CreateAccessToken(t.Context(), userID)
+	assert.NoError(t, err)
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + accessToken,
+	}
+	return testutils.MakeRequest(t, suite.app, url, method, payload, headers)
+}
+
+func (suite *TransferReversalTestSuite) getAccount(t *testing.T, accountID int64) accountModel.Account {
+	var account accountModel.Account
+	err := suite.app.Db.NewSelect().
+		Model(&account).
+		Where("id = ?", accountID).
+		Scan(t.Context())
+	assert.NoError(t, err)
+	return account
+}
+
+func (suite *TransferReversalTestSuite) getTransactionStatus(t *testing.T, transactionID uuid.UUID) accountModel.TransactionStatus {
+	var transaction accountModel.Transaction
+	err := suite.app.Db.NewSelect().
+		Model(&transaction).
+		Where("id = ?", transactionID).
+		Scan(t.Context())
+	assert.NoError(t, err)
+	return transaction.Status
+}
+
+func (suite *TransferReversalTestSuite) reverse(t *testing.T, userID string, transactionID string) *httptest.ResponseRecorder {
+	payload := types.ReverseTransferRequest{
+		Data: types.ReverseTransferRequestData{
+			Reason: "Sent to the wrong account",
+		},
+	}
+	return suite.makeRequest(t, userID, "/v1/admin/transactions/"+transactionID+"/reversal", http.MethodPost, payload)
+}
+
+func (suite *TransferReversalTestSuite) TestReverseTransfer() {
+	suite.T().Run("non admin user cannot reverse a transfer", func(t *testing.T) {
+		responseRecorder := suite.reverse(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", uuid.NewString())
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	})
+
+	suite.T().Run("reversing an unknown transaction returns 404", func(t *testing.T) {
+		responseRecorder := suite.reverse(t, transferReversalAdminID, uuid.NewString())
+		assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	})
+
+	suite.T().Run("transfer is reversed in full when the receiver can cover it", func(t *testing.T) {
+		debit, err := suite.app.Services.TransferService.MoveFunds(t.Context(), nil, 11111111111110, 12345678901237, 5000, accountTypes.Remittance{})
+		assert.NoError(t, err)
+
+		responseRecorder := suite.reverse(t, transferReversalAdminID, debit.ID.String())
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.ReverseTransferResponse
+		err = json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, model.TransferReversalCompleted, response.Data.Status)
+		assert.Equal(t, int64(5000), response.Data.ReversedAmount)
+		assert.Equal(t, int64(0), response.Data.HeldAmount)
+		assert.Equal(t, int64(12345678901237), response.Data.FromAccountID)
+		assert.Equal(t, int64(11111111111110), response.Data.ToAccountID)
+		assert.Equal(t, transferReversalAdminID, response.Data.InitiatedBy)
+		assert.Equal(t, "Sent to the wrong account", response.Data.Reason)
+		assert.NotNil(t, response.Data.CompletedAt)
+
+		// both balances are back to where they were before the transfer
+		assert.Equal(t, int64(100000), suite.getAccount(t, 11111111111110).Balance)
+		assert.Equal(t, int64(150000), suite.getAccount(t, 12345678901237).Balance)
+		assert.Equal(t, accountModel.TransactionReversed, suite.getTransactionStatus(t, debit.ID))
+
+		// the compensating transactions reference the original ones
+		var compensatingTransactions []accountModel.Transaction
+		err = suite.app.Db.NewSelect().
+			Model(&compensatingTransactions).
+			Where("type IN (?)", []accountModel.TransactionType{accountModel.ReversalDebit, accountModel.ReversalCredit}).
+			Order("type ASC").
+			Scan(t.Context())
+		assert.NoError(t, err)
+		assert.Len(t, compensatingTransactions, 2)
+		assert.Equal(t, accountModel.ReversalDebit, compensatingTransactions[0].Type)
+		assert.Equal(t, int64(12345678901237), compensatingTransactions[0].AccountID)
+		assert.Equal(t, accountModel.ReversalCredit, compensatingTransactions[1].Type)
+		assert.Equal(t, int64(11111111111110), compensatingTransactions[1].AccountID)
+		assert.Equal(t, debit.ID, *compensatingTransactions[1].ReversedTransactionID)
+
+		t.Run("transfer cannot be reversed twice", func(t *testing.T) {
+			responseRecorder := suite.reverse(t, transferReversalAdminID, debit.ID.String())
+			assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, "message", "Transfer has already been reversed")
+
+			assert.Equal(t, int64(100000), suite.getAccount(t, 11111111111110).Balance)
+		})
+	})
+
+	suite.T().Run("only the debit of a transfer can be reversed", func(t *testing.T) {
+		debit, err := suite.app.Services.TransferService.MoveFunds(t.Context(), nil, 11111111111110, 33333333333330, 1000, accountTypes.Remittance{})
+		assert.NoError(t, err)
+
+		var credit accountModel.Transaction
+		err = suite.app.Db.NewSelect().
+			Model(&credit).
+			Where("counterpart_transaction_id = ?", debit.ID).
+			Scan(t.Context())
+		assert.NoError(t, err)
+
+		responseRecorder := suite.reverse(t, transferReversalAdminID, credit.ID.String())
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	})
+
+	suite.T().Run("amount the receiver cannot cover is held and collected once it can", func(t *testing.T) {
+		// the receiver spends most of the mistaken transfer before it is reversed
+		debit, err := suite.app.Services.TransferService.MoveFunds(t.Context(), nil, 33333333333330, 22222222222220, 50000, accountTypes.Remittance{})
+		assert.NoError(t, err)
+		_, err = suite.app.Services.TransferService.MoveFunds(t.Context(), nil, 22222222222220, 12345678901237, 45000, accountTypes.Remittance{})
+		assert.NoError(t, err)
+
+		responseRecorder := suite.reverse(t, transferReversalAdminID, debit.ID.String())
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.ReverseTransferResponse
+		err = json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, model.TransferReversalOnHold, response.Data.Status)
+		assert.Equal(t, int64(6000), response.Data.ReversedAmount)
+		assert.Equal(t, int64(44000), response.Data.HeldAmount)
+		assert.Nil(t, response.Data.CompletedAt)
+
+		receiverAccount := suite.getAccount(t, 22222222222220)
+		assert.Equal(t, int64(0), receiverAccount.Balance)
+		assert.Equal(t, int64(44000), receiverAccount.HeldAmount)
+		assert.Equal(t, accountModel.TransactionPartiallyReversed, suite.getTransactionStatus(t, debit.ID))
+
+		// the held amount cannot be spent
+		_, err = suite.app.Services.TransferService.MoveFunds(t.Context(), nil, 22222222222220, 12345678901237, 1000, accountTypes.Remittance{})
+		assert.Error(t, err)
+
+		transferReversalID, err := uuid.Parse(response.Data.ID)
+		assert.NoError(t, err)
+
+		t.Run("nothing is collected while the receiver has no balance", func(t *testing.T) {
+			transferReversal, err := suite.app.Services.TransferService.CollectTransferReversalHold(t.Context(), nil, transferReversalID)
+			assert.NoError(t, err)
+			assert.Nil(t, transferReversal)
+		})
+
+		t.Run("held amount is collected once the receiver is credited", func(t *testing.T) {
+			_, err := suite.app.Services.TransferService.MoveFunds(t.Context(), nil, 12345678901237, 22222222222220, 50000, accountTypes.Remittance{})
+			assert.NoError(t, err)
+
+			transferReversal, err := suite.app.Services.TransferService.CollectTransferReversalHold(t.Context(), nil, transferReversalID)
+			assert.NoError(t, err)
+			assert.NotNil(t, transferReversal)
+			assert.Equal(t, model.TransferReversalCompleted, transferReversal.Status)
+			assert.Equal(t, int64(50000), transferReversal.ReversedAmount)
+			assert.Equal(t, int64(0), transferReversal.HeldAmount)
+
+			receiverAccount := suite.getAccount(t, 22222222222220)
+			assert.Equal(t, int64(6000), receiverAccount.Balance)
+			assert.Equal(t, int64(0), receiverAccount.HeldAmount)
+			assert.Equal(t, int64(101000), suite.getAccount(t, 33333333333330).Balance)
+			assert.Equal(t, accountModel.TransactionReversed, suite.getTransactionStatus(t, debit.ID))
+		})
+	})
+
+	suite.T().Run("the overdraft limit of the receiver is not drawn on to reverse a transfer", func(t *testing.T) {
+		// the receiver spends the mistaken transfer and more, going into its overdraft
+		debit, err := suite.app.Services.TransferService.MoveFunds(t.Context(), nil, 11111111111110, 44444444444440, 10000, accountTypes.Remittance{})
+		assert.NoError(t, err)
+		_, err = suite.app.Services.TransferService.MoveFunds(t.Context(), nil, 44444444444440, 12345678901237, 15000, accountTypes.Remittance{})
+		assert.NoError(t, err)
+
+		responseRecorder := suite.reverse(t, transferReversalAdminID, debit.ID.String())
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.ReverseTransferResponse
+		err = json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, model.TransferReversalOnHold, response.Data.Status)
+		assert.Equal(t, int64(0), response.Data.ReversedAmount)
+		assert.Equal(t, int64(10000), response.Data.HeldAmount)
+
+		receiverAccount := suite.getAccount(t, 44444444444440)
+		assert.Equal(t, int64(-5000), receiverAccount.Balance)
+		assert.Equal(t, int64(10000), receiverAccount.HeldAmount)
+	})
+}
+
+func (suite *TransferReversalTestSuite) TestGetTransferReversals() {
+	suite.T().Run("admin can list the reversals by status", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, transferReversalAdminID, "/v1/admin/transfer-reversals?status=ON_HOLD", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetTransferReversalsResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		for _, transferReversal := range response.Data {
+			assert.Equal(t, model.TransferReversalOnHold, transferReversal.Status)
+		}
+	})
+
+	suite.T().Run("invalid status is rejected", func(t *testing.T) {
+		responseRecorder := suite.makeRequest(t, transferReversalAdminID, "/v1/admin/transfer-reversals?status=REVERSED", http.MethodGet, nil)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	})
+}
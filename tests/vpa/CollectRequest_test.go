@@ -0,0 +1,239 @@
+package vpa
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	"github.com/skamranahmed/go-bank/internal/vpa/model"
+	"github.com/skamranahmed/go-bank/internal/vpa/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+type CollectRequestTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestCollectRequestTestSuite(t *testing.T) {
+	suite.Run(t, new(CollectRequestTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *CollectRequestTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/CollectRequest_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *CollectRequestTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *CollectRequestTestSuite) createCollectRequest(t *testing.T, payeeUserID string, payeeAddress string, payerAddress string, amount int64) *httptest.ResponseRecorder {
+	payload := types.CreateCollectRequestRequest{
+		Data: types.CreateCollectRequestRequestData{
+			PayeeAddress: payeeAddress,
+			PayerAddress: payerAddress,
+			Amount:       int64Ptr(amount),
+			Note:         "Dinner",
+		},
+	}
+	return testutils.MakeAuthenticatedRequest(t, suite.app, payeeUserID, "/v1/collect-requests", http.MethodPost, payload)
+}
+
+func (suite *CollectRequestTestSuite) TestCreateCollectRequestRejections() {
+	type scenario struct {
+		name         string
+		payeeAddress string
+		payerAddress string
+		statusCode   int
+		errMessage   string
+	}
+
+	tests := []scenario{
+		{
+			name:         "VPA of another user as the payee returns 403",
+			payeeAddress: "kamran@gobank",
+			payerAddress: "rahul@gobank",
+			statusCode:   http.StatusForbidden,
+			errMessage:   "You can only request money to your own VPA",
+		},
+		{
+			name:         "own VPA as the payer returns 400",
+			payeeAddress: "sana@gobank",
+			payerAddress: "sana@gobank",
+			statusCode:   http.StatusBadRequest,
+			errMessage:   "You cannot request money from your own VPA",
+		},
+		{
+			name:         "unregistered payer VPA returns 404",
+			payeeAddress: "sana@gobank",
+			payerAddress: "nobody@gobank",
+			statusCode:   http.StatusNotFound,
+			errMessage:   "VPA not found",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := suite.createCollectRequest(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", tc.payeeAddress, tc.payerAddress, 1000)
+			assert.Equal(t, tc.statusCode, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, "message", tc.errMessage)
+		})
+	}
+}
+
+func (suite *CollectRequestTestSuite) TestApproveCollectRequest() {
+	var collectRequestID string
+
+	suite.T().Run("the payee requests money from the payer", func(t *testing.T) {
+		responseRecorder := suite.createCollectRequest(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "sana@gobank", "Kamran@gobank", 3000)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.CollectRequestResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "sana@gobank", response.Data.PayeeAddress)
+		assert.Equal(t, "kamran@gobank", response.Data.PayerAddress)
+		assert.Equal(t, int64(3000), response.Data.Amount)
+		assert.Equal(t, model.CollectRequestPending, response.Data.Status)
+		assert.True(t, response.Data.ExpiresAt.After(time.Now()))
+		collectRequestID = response.Data.ID
+	})
+
+	suite.T().Run("the request is listed for the payer", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/collect-requests?role=PAYER&status=PENDING", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetCollectRequestsResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		ids := make([]string, 0, len(response.Data))
+		for _, collectRequest := range response.Data {
+			ids = append(ids, collectRequest.ID)
+		}
+		assert.Contains(t, ids, collectRequestID)
+	})
+
+	suite.T().Run("the payee cannot approve their own request", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/collect-requests/"+collectRequestID+"/approve", http.MethodPost, nil)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Only the payer can approve or decline this collect request")
+	})
+
+	suite.T().Run("approving pays the payee from the account linked to the payer's VPA", func(t *testing.T) {
+		payerBalanceBefore := testutils.GetAccountBalance(t, suite.app, 12345678901237)
+		payeeBalanceBefore := testutils.GetAccountBalance(t, suite.app, 11111111111110)
+
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/collect-requests/"+collectRequestID+"/approve", http.MethodPost, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.CollectRequestResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, model.CollectRequestApproved, response.Data.Status)
+		assert.NotNil(t, response.Data.RespondedAt)
+
+		// the collect request points at the debit of the payer's account
+		if assert.NotNil(t, response.Data.TransactionID) {
+			var transaction accountModel.Transaction
+			err = suite.app.Db.NewSelect().
+				Model(&transaction).
+				Where("id = ?", *response.Data.TransactionID).
+				Scan(t.Context())
+			assert.NoError(t, err)
+			assert.Equal(t, int64(12345678901237), transaction.AccountID)
+			assert.Equal(t, accountModel.Debit, transaction.Type)
+			assert.Equal(t, int64(3000), transaction.Amount)
+		}
+
+		assert.Equal(t, payerBalanceBefore-3000, testutils.GetAccountBalance(t, suite.app, 12345678901237))
+		assert.Equal(t, payeeBalanceBefore+3000, testutils.GetAccountBalance(t, suite.app, 11111111111110))
+	})
+
+	suite.T().Run("an approved request cannot be approved again", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/collect-requests/"+collectRequestID+"/approve", http.MethodPost, nil)
+		assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Collect request has already been approved")
+	})
+}
+
+func (suite *CollectRequestTestSuite) TestDeclineCollectRequest() {
+	var collectRequestID string
+
+	suite.T().Run("the payee requests money from the payer", func(t *testing.T) {
+		responseRecorder := suite.createCollectRequest(t, "c3d4e5f6-a7b8-6c7d-0e1f-2a3b4c5d6e7f", "rahul@gobank", "sana@gobank", 1000)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.CollectRequestResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		collectRequestID = response.Data.ID
+	})
+
+	suite.T().Run("declining moves no money", func(t *testing.T) {
+		payerBalanceBefore := testutils.GetAccountBalance(t, suite.app, 11111111111110)
+
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/collect-requests/"+collectRequestID+"/decline", http.MethodPost, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.CollectRequestResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, model.CollectRequestDeclined, response.Data.Status)
+		assert.Nil(t, response.Data.TransactionID)
+		assert.Equal(t, payerBalanceBefore, testutils.GetAccountBalance(t, suite.app, 11111111111110))
+	})
+
+	suite.T().Run("a declined request cannot be approved", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/collect-requests/"+collectRequestID+"/approve", http.MethodPost, nil)
+		assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Collect request has already been declined")
+	})
+}
+
+func (suite *CollectRequestTestSuite) TestExpireCollectRequests() {
+	collectRequestID := "7b2c3d4e-5f6a-4b7c-9d8e-0f1a2b3c4d01"
+
+	suite.T().Run("unanswered requests past their expiry are expired", func(t *testing.T) {
+		collectRequests, err := suite.app.Services.VPAService.ExpireCollectRequests(t.Context(), nil, time.Now().UTC())
+		assert.NoError(t, err)
+
+		ids := make([]string, 0, len(collectRequests))
+		for _, collectRequest := range collectRequests {
+			assert.Equal(t, model.CollectRequestExpired, collectRequest.Status)
+			ids = append(ids, collectRequest.ID.String())
+		}
+		assert.Contains(t, ids, collectRequestID)
+	})
+
+	suite.T().Run("an expired request cannot be approved", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/collect-requests/"+collectRequestID+"/approve", http.MethodPost, nil)
+		assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Collect request has expired")
+	})
+}
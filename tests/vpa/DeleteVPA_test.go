@@ -0,0 +1,63 @@
+package vpa
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/vpa/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type DeleteVPATestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestDeleteVPATestSuite(t *testing.T) {
+	suite.Run(t, new(DeleteVPATestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *DeleteVPATestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/DeleteVPA_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *DeleteVPATestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *DeleteVPATestSuite) TestDeleteVPA() {
+	// kamran@gobank of user 1
+	url := "/v1/vpas/6a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c01"
+
+	suite.T().Run("another user cannot delete the VPA", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", url, http.MethodDelete, nil)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this VPA")
+	})
+
+	suite.T().Run("deletes the VPA", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", url, http.MethodDelete, nil)
+		assert.Equal(t, http.StatusNoContent, responseRecorder.Code)
+
+		responseRecorder = testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/vpas", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetVPAsResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Empty(t, response.Data)
+	})
+
+	suite.T().Run("a deleted VPA returns 404", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", url, http.MethodDelete, nil)
+		assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	})
+}
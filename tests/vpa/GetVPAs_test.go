@@ -0,0 +1,59 @@
+package vpa
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/vpa/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type GetVPAsTestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestGetVPAsTestSuite(t *testing.T) {
+	suite.Run(t, new(GetVPAsTestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *GetVPAsTestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/GetVPAs_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *GetVPAsTestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *GetVPAsTestSuite) TestMissingAuthorizationHeader() {
+	suite.T().Run("missing authorization header returns 401", func(t *testing.T) {
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/vpas", http.MethodGet, nil, nil)
+		assert.Equal(t, http.StatusUnauthorized, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Authorization header is missing")
+	})
+}
+
+func (suite *GetVPAsTestSuite) TestGetVPAs() {
+	suite.T().Run("lists only the VPAs of the user", func(t *testing.T) {
+		responseRecorder := testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/vpas", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetVPAsResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		addresses := make([]string, 0, len(response.Data))
+		for _, vpa := range response.Data {
+			addresses = append(addresses, vpa.Address)
+		}
+		assert.ElementsMatch(t, []string{"kamran@gobank"}, addresses)
+	})
+}
@@ -0,0 +1,195 @@
+package vpa
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/vpa/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type RegisterVPATestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestRegisterVPATestSuite(t *testing.T) {
+	suite.Run(t, new(RegisterVPATestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *RegisterVPATestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/RegisterVPA_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *RegisterVPATestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *RegisterVPATestSuite) registerVPA(t *testing.T, userID string, address string, accountID int64) *httptest.ResponseRecorder {
+	payload := types.RegisterVPARequest{
+		Data: types.RegisterVPARequestData{
+			Address:   address,
+			AccountID: accountID,
+		},
+	}
+	return testutils.MakeAuthenticatedRequest(t, suite.app, userID, "/v1/vpas", http.MethodPost, payload)
+}
+
+func (suite *RegisterVPATestSuite) TestMissingAuthorizationHeader() {
+	suite.T().Run("missing authorization header returns 401", func(t *testing.T) {
+		responseRecorder := testutils.MakeRequest(t, suite.app, "/v1/vpas", http.MethodPost, nil, nil)
+		assert.Equal(t, http.StatusUnauthorized, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Authorization header is missing")
+	})
+}
+
+func (suite *RegisterVPATestSuite) TestValidationErrors() {
+	type scenario struct {
+		name       string
+		address    string
+		field      string
+		errMessage string
+	}
+
+	tests := []scenario{
+		{
+			name:       "missing address",
+			address:    "",
+			field:      "address",
+			errMessage: "address is a required field",
+		},
+		{
+			name:       "address without a provider",
+			address:    "kamran",
+			field:      "address",
+			errMessage: "address is not a valid VPA",
+		},
+		{
+			name:       "handle too short",
+			address:    "ka@gobank",
+			field:      "address",
+			errMessage: "address is not a valid VPA",
+		},
+		{
+			name:       "handle with a disallowed character",
+			address:    "kamran!@gobank",
+			field:      "address",
+			errMessage: "address is not a valid VPA",
+		},
+		{
+			name:       "handle ending with a separator",
+			address:    "kamran.@gobank",
+			field:      "address",
+			errMessage: "address is not a valid VPA",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := suite.registerVPA(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", tc.address, 12345678901237)
+			assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, tc.field, tc.errMessage)
+		})
+	}
+}
+
+func (suite *RegisterVPATestSuite) TestRejections() {
+	type scenario struct {
+		name       string
+		address    string
+		accountID  int64
+		statusCode int
+		errMessage string
+	}
+
+	tests := []scenario{
+		{
+			name:       "VPA of another bank returns 400",
+			address:    "kamran.ahmed@otherbank",
+			accountID:  12345678901237,
+			statusCode: http.StatusBadRequest,
+			errMessage: "VPA does not belong to this bank, it must end with @gobank",
+		},
+		{
+			name:       "another user's account returns 403",
+			address:    "kamran.ahmed@gobank",
+			accountID:  11111111111110,
+			statusCode: http.StatusForbidden,
+			errMessage: "You can only link your own account to a VPA",
+		},
+		{
+			name:       "fixed deposit account returns 400",
+			address:    "kamran.ahmed@gobank",
+			accountID:  45454545454544,
+			statusCode: http.StatusBadRequest,
+			errMessage: "Only a savings or current account can be linked to a VPA",
+		},
+		{
+			name:       "VPA already taken by another user returns 409",
+			address:    "Sana@gobank",
+			accountID:  12345678901237,
+			statusCode: http.StatusConflict,
+			errMessage: "This VPA is already taken",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.T().Run(tc.name, func(t *testing.T) {
+			responseRecorder := suite.registerVPA(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", tc.address, tc.accountID)
+			assert.Equal(t, tc.statusCode, responseRecorder.Code)
+
+			response := testutils.DecodeErrorResponse(t, responseRecorder)
+			testutils.AssertFieldError(t, response, "message", tc.errMessage)
+		})
+	}
+}
+
+func (suite *RegisterVPATestSuite) TestRegisterVPA() {
+	suite.T().Run("registers the VPA in lower case", func(t *testing.T) {
+		responseRecorder := suite.registerVPA(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "Sana.Ahmed@GoBank", 11111111111110)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		var response types.RegisterVPAResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "sana.ahmed@gobank", response.Data.Address)
+		assert.Equal(t, int64(11111111111110), response.Data.AccountID)
+	})
+
+	suite.T().Run("limits the number of VPAs per user", func(t *testing.T) {
+		// the user already has sana@gobank and sana.ahmed@gobank
+		responseRecorder := suite.registerVPA(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "sana-shop@gobank", 11111111111110)
+		assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+		responseRecorder = suite.registerVPA(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "sana_2@gobank", 11111111111110)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		errResponse := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, errResponse, "message", "You can register at most 3 VPAs")
+
+		responseRecorder = testutils.MakeAuthenticatedRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/vpas", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.GetVPAsResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+
+		addresses := make([]string, 0, len(response.Data))
+		for _, vpa := range response.Data {
+			addresses = append(addresses, vpa.Address)
+		}
+		assert.ElementsMatch(t, []string{"sana@gobank", "sana.ahmed@gobank", "sana-shop@gobank"}, addresses)
+	})
+}
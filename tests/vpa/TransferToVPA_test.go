@@ -0,0 +1,94 @@
+package vpa
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	accountModel "github.com/skamranahmed/go-bank/internal/account/model"
+	transferTypes "github.com/skamranahmed/go-bank/internal/transfer/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type TransferToVPATestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestTransferToVPATestSuite(t *testing.T) {
+	suite.Run(t, new(TransferToVPATestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *TransferToVPATestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/TransferToVPA_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *TransferToVPATestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *TransferToVPATestSuite) transfer(t *testing.T, data transferTypes.InternalTransferRequestData) *httptest.ResponseRecorder {
+	payload := transferTypes.InternalTransferRequest{
+		Data: data,
+	}
+	return testutils.MakeAuthenticatedRequest(t, suite.app, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", "/v1/transfers/internal", http.MethodPost, payload)
+}
+
+func (suite *TransferToVPATestSuite) TestTransferToVPA() {
+	suite.T().Run("credits the account linked to the VPA", func(t *testing.T) {
+		senderBalanceBefore := testutils.GetAccountBalance(t, suite.app, 11111111111110)
+		balanceBefore := testutils.GetAccountBalance(t, suite.app, 22222222222220)
+
+		responseRecorder := suite.transfer(t, transferTypes.InternalTransferRequestData{
+			FromAccountID: 11111111111110,
+			ToVPA:         "Rahul@gobank",
+			Amount:        int64Ptr(1500),
+		})
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		assert.Equal(t, senderBalanceBefore-1500, testutils.GetAccountBalance(t, suite.app, 11111111111110))
+		assert.Equal(t, balanceBefore+1500, testutils.GetAccountBalance(t, suite.app, 22222222222220))
+
+		var credits []accountModel.Transaction
+		err := suite.app.Db.NewSelect().
+			Model(&credits).
+			Where("account_id = ?", 22222222222220).
+			Where("type = ?", accountModel.Credit).
+			Scan(t.Context())
+		assert.NoError(t, err)
+		if assert.Len(t, credits, 1) {
+			assert.Equal(t, int64(1500), credits[0].Amount)
+			assert.NotNil(t, credits[0].CounterpartTransactionID)
+		}
+	})
+
+	suite.T().Run("an unregistered VPA returns 404", func(t *testing.T) {
+		responseRecorder := suite.transfer(t, transferTypes.InternalTransferRequestData{
+			FromAccountID: 11111111111110,
+			ToVPA:         "nobody@gobank",
+			Amount:        int64Ptr(1500),
+		})
+		assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "VPA not found")
+	})
+
+	suite.T().Run("a VPA combined with an account id returns 400", func(t *testing.T) {
+		responseRecorder := suite.transfer(t, transferTypes.InternalTransferRequestData{
+			FromAccountID: 11111111111110,
+			ToAccountID:   22222222222220,
+			ToVPA:         "rahul@gobank",
+			Amount:        int64Ptr(1500),
+		})
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "to_vpa cannot be combined with to_account_id, to_iban or beneficiary_id")
+	})
+}
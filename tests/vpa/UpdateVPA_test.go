@@ -0,0 +1,94 @@
+package vpa
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/skamranahmed/go-bank/internal/vpa/types"
+	"github.com/skamranahmed/go-bank/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type UpdateVPATestSuite struct {
+	suite.Suite
+	app testutils.TestApp
+}
+
+func TestUpdateVPATestSuite(t *testing.T) {
+	suite.Run(t, new(UpdateVPATestSuite))
+}
+
+// SetupSuite runs once before all tests
+func (suite *UpdateVPATestSuite) SetupSuite() {
+	suite.app = testutils.NewTestApp(suite.T().Context(), nil, postgresTestContainer, redisTestContainer)
+	testutils.LoadFixtures(suite.T(), suite.app, "./fixtures/UpdateVPA_test")
+}
+
+// TearDownSuite runs once after all tests
+func (suite *UpdateVPATestSuite) TearDownSuite() {
+	suite.app.TeardownFunc()
+}
+
+func (suite *UpdateVPATestSuite) updateVPA(t *testing.T, userID string, vpaID string, accountID int64) *httptest.ResponseRecorder {
+	payload := types.UpdateVPARequest{
+		Data: types.UpdateVPARequestData{
+			AccountID: accountID,
+		},
+	}
+	return testutils.MakeAuthenticatedRequest(t, suite.app, userID, "/v1/vpas/"+vpaID, http.MethodPatch, payload)
+}
+
+func (suite *UpdateVPATestSuite) TestRejections() {
+	// kamran@gobank of user 1
+	vpaID := "6a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c01"
+
+	suite.T().Run("another user cannot relink the VPA", func(t *testing.T) {
+		responseRecorder := suite.updateVPA(t, "b2c3d4e5-f6a7-5b6c-9d0e-1f2a3b4c5d6e", vpaID, 11111111111110)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You do not have permission to access this VPA")
+	})
+
+	suite.T().Run("linking another user's account returns 403", func(t *testing.T) {
+		responseRecorder := suite.updateVPA(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", vpaID, 11111111111110)
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "You can only link your own account to a VPA")
+	})
+
+	suite.T().Run("linking a fixed deposit account returns 400", func(t *testing.T) {
+		responseRecorder := suite.updateVPA(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", vpaID, 45454545454544)
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+		response := testutils.DecodeErrorResponse(t, responseRecorder)
+		testutils.AssertFieldError(t, response, "message", "Only a savings or current account can be linked to a VPA")
+	})
+}
+
+func (suite *UpdateVPATestSuite) TestUpdateVPA() {
+	suite.T().Run("links the VPA to another account of the user", func(t *testing.T) {
+		responseRecorder := suite.updateVPA(t, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "6a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c01", 33333333333330)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response types.UpdateVPAResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "kamran@gobank", response.Data.Address)
+		assert.Equal(t, int64(33333333333330), response.Data.AccountID)
+
+		responseRecorder = testutils.MakeAuthenticatedRequest(t, suite.app, "a1b2c3d4-e5f6-4a5b-8c9d-0e1f2a3b4c5d", "/v1/vpas", http.MethodGet, nil)
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var vpasResponse types.GetVPAsResponse
+		err = json.Unmarshal(responseRecorder.Body.Bytes(), &vpasResponse)
+		assert.NoError(t, err)
+		if assert.Len(t, vpasResponse.Data, 1) {
+			assert.Equal(t, int64(33333333333330), vpasResponse.Data[0].AccountID)
+		}
+	})
+}